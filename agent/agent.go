@@ -161,6 +161,10 @@ const (
 	MongoOplogSize         = "MONGO_OPLOG_SIZE"
 	NumaCtlPreference      = "NUMA_CTL_PREFERENCE"
 	AllowsSecureConnection = "SECURE_CONTROLLER_CONNECTION"
+
+	// SecretsKey holds the base64-encoded key with which controllers
+	// encrypt the values of secrets. It is not stored in the database.
+	SecretsKey = "SECRETS_KEY"
 )
 
 // The Config interface is the sole way that the agent gets access to the
@@ -6,6 +6,7 @@ package agent
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"github.com/juju/names"
 
 	"github.com/juju/juju/api/base"
@@ -55,6 +56,17 @@ func (st *State) StateServingInfo() (params.StateServingInfo, error) {
 	return results, err
 }
 
+// SecretsKey returns the key with which the controller encrypts the
+// values of secrets. It requires version 3 of the facade.
+func (st *State) SecretsKey() ([]byte, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("SecretsKey() (need V3+)")
+	}
+	var result params.BytesResult
+	err := st.facade.FacadeCall("SecretsKey", nil, &result)
+	return result.Result, err
+}
+
 // IsMaster reports whether the connected machine
 // agent lives at the same network address as the primary
 // mongo server for the replica set.
@@ -38,6 +38,17 @@ func (APICallerFunc) Close() error {
 	return nil
 }
 
+// BestVersionCaller is an APICallerFunc that reports the given version
+// as the best version of every facade.
+type BestVersionCaller struct {
+	APICallerFunc
+	BestVersion int
+}
+
+func (c BestVersionCaller) BestFacadeVersion(facade string) int {
+	return c.BestVersion
+}
+
 func (APICallerFunc) HTTPClient() (*httprequest.Client, error) {
 	return nil, errors.New("no HTTP client available in this test")
 }
@@ -45,6 +45,22 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// FilteredStatus returns the status of the juju model, restricted
+// on the controller to the entities matching both the patterns
+// and the filter. Older controllers, which would ignore the filter
+// and return the unfiltered status, are refused.
+func (c *Client) FilteredStatus(patterns []string, filter params.StatusFilter) (*params.FullStatus, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("filtering status on this controller")
+	}
+	var result params.FullStatus
+	p := params.StatusParams{Patterns: patterns, Filter: filter}
+	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // UnitStatusHistory retrieves the last <size> results of <kind:combined|agent|workload> status
 // for <unitName> unit
 func (c *Client) UnitStatusHistory(kind params.HistoryKind, unitName string, size int) (*params.UnitStatusHistory, error) {
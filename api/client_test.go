@@ -232,6 +232,23 @@ func (s *clientSuite) TestClientEnvironmentUsers(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestFilteredStatusOldServer(c *gc.C) {
+	client := s.APIState.Client()
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, paramsIn interface{}, response interface{}) error {
+			c.Errorf("unexpected call to %q", request)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := client.FilteredStatus(nil, params.StatusFilter{
+		Series: []string{"trusty"},
+	})
+	c.Assert(err, gc.ErrorMatches, "filtering status on this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *clientSuite) TestShareEnvironmentExistingUser(c *gc.C) {
 	client := s.APIState.Client()
 	user := s.Factory.MakeModelUser(c, nil)
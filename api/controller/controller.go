@@ -133,3 +133,22 @@ func (c *Client) ModelStatus(tags ...names.ModelTag) ([]base.ModelStatus, error)
 	}
 	return results, nil
 }
+
+// ModelLeadership returns the controller's view of the service leadership
+// leases in each of the supplied models.
+func (c *Client) ModelLeadership(tags ...names.ModelTag) ([]params.ModelLeadershipResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i] = params.Entity{Tag: tag.String()}
+	}
+	var results params.ModelLeadershipResults
+	if err := c.facade.FacadeCall("ModelLeadership", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d results, got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
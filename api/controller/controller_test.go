@@ -160,3 +160,18 @@ func (s *controllerSuite) TestModelStatus(c *gc.C) {
 		Life:               params.Alive,
 	}})
 }
+
+func (s *controllerSuite) TestModelLeadership(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sysManager := s.OpenAPI(c)
+	results, err := sysManager.ModelLeadership(s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Error, gc.IsNil)
+	c.Assert(results[0].Leases, gc.HasLen, 1)
+	c.Check(results[0].Leases[0].Service, gc.Equals, "mysql")
+	c.Check(results[0].Leases[0].Holder, gc.Equals, "mysql/0")
+	c.Check(results[0].Leases[0].Claimed, gc.NotNil)
+}
@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodel provides the client side of the CrossModel API
+// facade, with which services are offered by one model and consumed by
+// another on the same controller.
+package crossmodel
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const crossModelFacade = "CrossModel"
+
+// Client provides access to the CrossModel API facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new CrossModel client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, crossModelFacade)
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Offer makes the named service available for consumption by other
+// models, under the supplied offer name, through the supplied endpoints
+// and by the supplied users in addition to the model owner.
+func (c *Client) Offer(offerName, serviceName string, endpoints []string, users []names.UserTag) error {
+	offer := params.AddOffer{
+		Name:        offerName,
+		ServiceName: serviceName,
+		Endpoints:   endpoints,
+	}
+	for _, user := range users {
+		offer.UserTags = append(offer.UserTags, user.String())
+	}
+	args := params.AddOffers{Offers: []params.AddOffer{offer}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Offer", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListOffers returns the offers made by the model.
+func (c *Client) ListOffers() ([]params.OfferDetails, error) {
+	var results params.ListOffersResults
+	if err := c.facade.FacadeCall("ListOffers", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Offers, nil
+}
+
+// Consume adds a remote service to the model for the offer with the
+// supplied URL, of the form [<owner>/]<model>.<offer>, and returns the
+// name of the remote service. If serviceName is empty, the offer name is
+// used.
+func (c *Client) Consume(offerURL, serviceName string) (string, error) {
+	args := params.ConsumeOfferArgs{Args: []params.ConsumeOfferArg{{
+		OfferURL:    offerURL,
+		ServiceName: serviceName,
+	}}}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("Consume", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/crossmodel"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&CrossModelSuite{})
+
+type CrossModelSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *CrossModelSuite) TestOffer(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrossModel")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Offer")
+		c.Check(arg, jc.DeepEquals, params.AddOffers{Offers: []params.AddOffer{{
+			Name:        "db",
+			ServiceName: "mysql",
+			Endpoints:   []string{"server"},
+			UserTags:    []string{"user-bob"},
+		}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "bam"}}},
+		}
+		callCount++
+		return nil
+	})
+	client := crossmodel.NewClient(apiCaller)
+	err := client.Offer("db", "mysql", []string{"server"}, []names.UserTag{names.NewUserTag("bob")})
+	c.Check(err, gc.ErrorMatches, "bam")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *CrossModelSuite) TestListOffers(c *gc.C) {
+	offers := []params.OfferDetails{{
+		URL:         "admin/dbs.db",
+		Name:        "db",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrossModel")
+		c.Check(request, gc.Equals, "ListOffers")
+		c.Assert(result, gc.FitsTypeOf, &params.ListOffersResults{})
+		*(result.(*params.ListOffersResults)) = params.ListOffersResults{Offers: offers}
+		return nil
+	})
+	client := crossmodel.NewClient(apiCaller)
+	result, err := client.ListOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, offers)
+}
+
+func (s *CrossModelSuite) TestConsume(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrossModel")
+		c.Check(request, gc.Equals, "Consume")
+		c.Check(arg, jc.DeepEquals, params.ConsumeOfferArgs{Args: []params.ConsumeOfferArg{{
+			OfferURL:    "admin/dbs.db",
+			ServiceName: "shared-db",
+		}}})
+		c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: "shared-db"}},
+		}
+		return nil
+	})
+	client := crossmodel.NewClient(apiCaller)
+	name, err := client.Consume("admin/dbs.db", "shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "shared-db")
+}
+
+func (s *CrossModelSuite) TestConsumeError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}}},
+		}
+		return nil
+	})
+	client := crossmodel.NewClient(apiCaller)
+	_, err := client.Consume("admin/dbs.db", "")
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	c.Check(params.IsCodeUnauthorized(err), jc.IsTrue)
+}
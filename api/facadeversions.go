@@ -17,7 +17,7 @@ import (
 var facadeVersions = map[string]int{
 	"Action":                       1,
 	"Addresser":                    2,
-	"Agent":                        2,
+	"Agent":                        3,
 	"AgentTools":                   1,
 	"AllWatcher":                   1,
 	"AllModelWatcher":              2,
@@ -26,15 +26,17 @@ var facadeVersions = map[string]int{
 	"Block":                        2,
 	"Charms":                       2,
 	"CharmRevisionUpdater":         1,
-	"Client":                       1,
+	"Client":                       2,
 	"Cleaner":                      2,
 	"Controller":                   2,
+	"CrossModel":                   1,
 	"Deployer":                     1,
 	"DiscoverSpaces":               2,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   2,
+	"Firewaller":                   3,
+	"FirewallReport":               1,
 	"HighAvailability":             2,
 	"ImageManager":                 2,
 	"ImageMetadata":                2,
@@ -56,18 +58,19 @@ var facadeVersions = map[string]int{
 	"ProxyUpdater":                 1,
 	"Reboot":                       2,
 	"RelationUnitsWatcher":         1,
+	"RemoteRelations":              1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
-	"Service":                      3,
-	"Storage":                      2,
+	"Service":                      5,
+	"Storage":                      3,
 	"Spaces":                       2,
 	"Subnets":                      2,
-	"StatusHistory":                2,
-	"StorageProvisioner":           2,
+	"StatusHistory":                4,
+	"StorageProvisioner":           3,
 	"StringsWatcher":               1,
 	"Upgrader":                     1,
 	"UnitAssigner":                 1,
-	"Uniter":                       3,
+	"Uniter":                       6,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
 	"Undertaker":                   1,
@@ -90,6 +90,25 @@ func (st *State) WatchModelMachines() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to the
+// subnets of the current model, including changes to the spaces they
+// are in. It requires version 3 of the facade.
+func (st *State) WatchSubnets() (watcher.StringsWatcher, error) {
+	if st.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("WatchSubnets() (need V3+)")
+	}
+	var result params.StringsWatchResult
+	err := st.facade.FacadeCall("WatchSubnets", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchOpenedPorts returns a StringsWatcher that notifies of
 // changes to the opened ports for the current model.
 func (st *State) WatchOpenedPorts() (watcher.StringsWatcher, error) {
@@ -75,3 +75,29 @@ func (s *Service) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposedCIDRs returns the source CIDRs from which the open ports of
+// this service may be accessed, when it is exposed. An empty result
+// means the ports may be accessed from anywhere, as it always does for
+// controllers older than version 3 of the facade.
+func (s *Service) ExposedCIDRs() ([]string, error) {
+	if s.st.BestAPIVersion() < 3 {
+		return nil, nil
+	}
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposedCIDRs", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package firewallreport contains the implementation of a client to
+// report firewall drift between state and the provider.
+package firewallreport
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the firewall report api.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the firewall report api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "FirewallReport")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Report returns the ingress rules wanted for the model's machines, or
+// for the whole model in global firewall mode, along with the rules the
+// provider actually applies and the differences between them.
+func (c *Client) Report() (params.FirewallReportResult, error) {
+	var result params.FirewallReportResult
+	if err := c.facade.FacadeCall("Report", nil, &result); err != nil {
+		return params.FirewallReportResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
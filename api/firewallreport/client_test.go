@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallreport_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/firewallreport"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestReport(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "FirewallReport")
+			c.Check(request, gc.Equals, "Report")
+			c.Check(a, gc.IsNil)
+			result := response.(*params.FirewallReportResult)
+			*result = params.FirewallReportResult{
+				FirewallMode: "global",
+				Global: &params.FirewallReport{
+					Missing: []params.IngressRule{{
+						PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+					}},
+				},
+			}
+			return nil
+		})
+	client := firewallreport.NewClient(apiCaller)
+	result, err := client.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result.FirewallMode, gc.Equals, "global")
+	c.Assert(result.Global.Missing, gc.HasLen, 1)
+}
+
+func (s *clientSuite) TestReportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := firewallreport.NewClient(apiCaller)
+	_, err := client.Report()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/api/machiner"
 	"github.com/juju/juju/api/provisioner"
 	"github.com/juju/juju/api/reboot"
+	"github.com/juju/juju/api/remoterelations"
 	"github.com/juju/juju/api/unitassigner"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/api/upgrader"
@@ -174,6 +175,7 @@ type Connection interface {
 	InstancePoller() *instancepoller.API
 	CharmRevisionUpdater() *charmrevisionupdater.State
 	Cleaner() *cleaner.API
+	RemoteRelations() *remoterelations.API
 	MetadataUpdater() *imagemetadata.Client
 	UnitAssigner() unitassigner.API
 }
@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+const remoteRelationsFacade = "RemoteRelations"
+
+// API provides access to the RemoteRelations API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side RemoteRelations facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, remoteRelationsFacade)
+	return &API{facade: facadeCaller}
+}
+
+// SyncRemoteRelations calls the server-side SyncRemoteRelations method.
+func (api *API) SyncRemoteRelations() error {
+	return api.facade.FacadeCall("SyncRemoteRelations", nil, nil)
+}
+
+// WatchRemoteRelations calls the server-side WatchRemoteRelations method.
+func (api *API) WatchRemoteRelations() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := api.facade.FacadeCall("WatchRemoteRelations", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result)
+	return w, nil
+}
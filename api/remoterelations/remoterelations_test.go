@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/remoterelations"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RemoteRelationsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&RemoteRelationsSuite{})
+
+func (s *RemoteRelationsSuite) TestSyncRemoteRelations(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "RemoteRelations")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SyncRemoteRelations")
+		called = true
+		return nil
+	})
+	err := remoterelations.NewAPI(apiCaller).SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *RemoteRelationsSuite) TestWatchRemoteRelationsFailFacadeCall(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchRemoteRelations")
+		return errors.New("client error!")
+	})
+	w, err := remoterelations.NewAPI(apiCaller).WatchRemoteRelations()
+	c.Assert(err, gc.ErrorMatches, "client error!")
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *RemoteRelationsSuite) TestWatchRemoteRelationsFailFacadeResult(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchRemoteRelations")
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "server error"},
+		}
+		return nil
+	})
+	w, err := remoterelations.NewAPI(apiCaller).WatchRemoteRelations()
+	c.Assert(err, gc.ErrorMatches, "server error")
+	c.Assert(w, gc.IsNil)
+}
@@ -220,6 +220,23 @@ func (c *Client) Expose(service string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeTo changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open, but only to the given
+// source CIDRs and to the subnets of the given spaces. It requires
+// version 4 of the facade; older controllers would ignore the
+// restriction and expose the ports to anywhere.
+func (c *Client) ExposeTo(service string, cidrs, spaces []string) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotImplementedf("ExposeTo() (need V4+)")
+	}
+	params := params.ServiceExpose{
+		ServiceName:    service,
+		ExposeToCIDRs:  cidrs,
+		ExposeToSpaces: spaces,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(service string) error {
@@ -227,6 +244,37 @@ func (c *Client) Unexpose(service string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// TransferLeadership hands leadership of the named service over to the
+// named unit, once the current leader's lease runs out. It requires
+// version 5 of the facade.
+func (c *Client) TransferLeadership(service, unit string) error {
+	if c.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("TransferLeadership() (need V5+)")
+	}
+	params := params.ServiceTransferLeadership{ServiceName: service, UnitName: unit}
+	return c.facade.FacadeCall("TransferLeadership", params, nil)
+}
+
+// PinLeadership prevents the named service's leadership from changing
+// when its leader's lease expires. It requires version 5 of the facade.
+func (c *Client) PinLeadership(service string) error {
+	if c.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("PinLeadership() (need V5+)")
+	}
+	params := params.ServicePinLeadership{ServiceName: service}
+	return c.facade.FacadeCall("PinLeadership", params, nil)
+}
+
+// UnpinLeadership allows the named service's leadership to change again
+// when its leader's lease expires. It requires version 5 of the facade.
+func (c *Client) UnpinLeadership(service string) error {
+	if c.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("UnpinLeadership() (need V5+)")
+	}
+	params := params.ServiceUnpinLeadership{ServiceName: service}
+	return c.facade.FacadeCall("UnpinLeadership", params, nil)
+}
+
 // Get returns the configuration for the named service.
 func (c *Client) Get(service string) (*params.ServiceGetResults, error) {
 	var results params.ServiceGetResults
@@ -274,3 +322,34 @@ func (c *Client) DestroyRelation(endpoints ...string) error {
 	params := params.DestroyRelation{Endpoints: endpoints}
 	return c.facade.FacadeCall("DestroyRelation", params, nil)
 }
+
+// SuspendRelation suspends the relation between the specified endpoints.
+func (c *Client) SuspendRelation(endpoints ...string) error {
+	if c.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("SuspendRelation() (need V5+)")
+	}
+	args := params.SetRelationSuspended{Endpoints: endpoints, Suspended: true}
+	return c.facade.FacadeCall("SetRelationSuspended", args, nil)
+}
+
+// ResumeRelation resumes the suspended relation between the specified
+// endpoints.
+func (c *Client) ResumeRelation(endpoints ...string) error {
+	if c.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("ResumeRelation() (need V5+)")
+	}
+	args := params.SetRelationSuspended{Endpoints: endpoints}
+	return c.facade.FacadeCall("SetRelationSuspended", args, nil)
+}
+
+// ShowRelation returns the details of the relation between the specified
+// endpoints, including the units in its scope.
+func (c *Client) ShowRelation(endpoints ...string) (params.ShowRelationResult, error) {
+	var result params.ShowRelationResult
+	if c.facade.BestAPIVersion() < 5 {
+		return result, errors.NotImplementedf("ShowRelation() (need V5+)")
+	}
+	args := params.ShowRelation{Endpoints: endpoints}
+	err := c.facade.FacadeCall("ShowRelation", args, &result)
+	return result, err
+}
@@ -4,6 +4,7 @@
 package service_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -159,3 +160,160 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestExposeTo(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		args, ok := a.(params.ServiceExpose)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.ServiceExpose{
+			ServiceName:    "mysql",
+			ExposeToCIDRs:  []string{"10.0.0.0/8"},
+			ExposeToSpaces: []string{"internal"},
+		})
+		return nil
+	})
+	err := s.client.ExposeTo("mysql", []string{"10.0.0.0/8"}, []string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExposeToOldServer(c *gc.C) {
+	service.PatchBestAPIVersion(s, s.client, 3)
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	err := s.client.ExposeTo("mysql", []string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	c.Assert(err, gc.ErrorMatches, `ExposeTo\(\) \(need V4\+\) not implemented`)
+}
+
+func (s *serviceSuite) TestTransferLeadership(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "TransferLeadership")
+		args, ok := a.(params.ServiceTransferLeadership)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, gc.Equals, params.ServiceTransferLeadership{"mysql", "mysql/1"})
+		return nil
+	})
+	err := s.client.TransferLeadership("mysql", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestPinLeadership(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "PinLeadership")
+		c.Assert(a, gc.Equals, params.ServicePinLeadership{"mysql"})
+		return nil
+	})
+	err := s.client.PinLeadership("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestUnpinLeadership(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "UnpinLeadership")
+		c.Assert(a, gc.Equals, params.ServiceUnpinLeadership{"mysql"})
+		return errors.New("boom")
+	})
+	err := s.client.UnpinLeadership("mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestLeadershipOldServer(c *gc.C) {
+	service.PatchBestAPIVersion(s, s.client, 4)
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	err := s.client.TransferLeadership("mysql", "mysql/1")
+	c.Check(err, gc.ErrorMatches, `TransferLeadership\(\) \(need V5\+\) not implemented`)
+	err = s.client.PinLeadership("mysql")
+	c.Check(err, gc.ErrorMatches, `PinLeadership\(\) \(need V5\+\) not implemented`)
+	err = s.client.UnpinLeadership("mysql")
+	c.Check(err, gc.ErrorMatches, `UnpinLeadership\(\) \(need V5\+\) not implemented`)
+}
+
+func (s *serviceSuite) TestShowRelationOldServer(c *gc.C) {
+	service.PatchBestAPIVersion(s, s.client, 4)
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	_, err := s.client.ShowRelation("wordpress", "mysql")
+	c.Check(err, gc.ErrorMatches, `ShowRelation\(\) \(need V5\+\) not implemented`)
+}
+
+func (s *serviceSuite) TestSuspendRelationOldServer(c *gc.C) {
+	service.PatchBestAPIVersion(s, s.client, 4)
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	err := s.client.SuspendRelation("wordpress", "mysql")
+	c.Check(err, gc.ErrorMatches, `SuspendRelation\(\) \(need V5\+\) not implemented`)
+	err = s.client.ResumeRelation("wordpress", "mysql")
+	c.Check(err, gc.ErrorMatches, `ResumeRelation\(\) \(need V5\+\) not implemented`)
+}
+
+func (s *serviceSuite) TestShowRelation(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ShowRelation")
+		c.Assert(a, jc.DeepEquals, params.ShowRelation{Endpoints: []string{"wordpress", "mysql"}})
+		result, ok := response.(*params.ShowRelationResult)
+		c.Assert(ok, jc.IsTrue)
+		result.Id = 3
+		result.Key = "wordpress:db mysql:server"
+		return nil
+	})
+	result, err := s.client.ShowRelation("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result.Id, gc.Equals, 3)
+	c.Assert(result.Key, gc.Equals, "wordpress:db mysql:server")
+}
+
+func (s *serviceSuite) TestSuspendRelation(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetRelationSuspended")
+		c.Assert(a, jc.DeepEquals, params.SetRelationSuspended{
+			Endpoints: []string{"wordpress", "mysql"},
+			Suspended: true,
+		})
+		return nil
+	})
+	err := s.client.SuspendRelation("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestResumeRelation(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetRelationSuspended")
+		c.Assert(a, jc.DeepEquals, params.SetRelationSuspended{
+			Endpoints: []string{"wordpress", "mysql"},
+		})
+		return nil
+	})
+	err := s.client.ResumeRelation("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -4,6 +4,7 @@
 package service
 
 import (
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/base/testing"
 )
 
@@ -13,3 +14,18 @@ import (
 func PatchFacadeCall(p testing.Patcher, client *Client, f func(request string, params, response interface{}) error) {
 	testing.PatchFacadeCall(p, &client.facade, f)
 }
+
+// PatchBestAPIVersion patches the client's facade such that
+// BestAPIVersion reports the provided version.
+func PatchBestAPIVersion(p testing.Patcher, client *Client, version int) {
+	p.PatchValue(&client.facade, &versionedFacade{client.facade, version})
+}
+
+type versionedFacade struct {
+	base.FacadeCaller
+	version int
+}
+
+func (f *versionedFacade) BestAPIVersion() int {
+	return f.version
+}
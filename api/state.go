@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/api/machiner"
 	"github.com/juju/juju/api/provisioner"
 	"github.com/juju/juju/api/reboot"
+	"github.com/juju/juju/api/remoterelations"
 	"github.com/juju/juju/api/unitassigner"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/api/upgrader"
@@ -270,6 +271,11 @@ func (st *state) Cleaner() *cleaner.API {
 	return cleaner.NewAPI(st)
 }
 
+// RemoteRelations returns access to the RemoteRelations API
+func (st *state) RemoteRelations() *remoterelations.API {
+	return remoterelations.NewAPI(st)
+}
+
 // ServerVersion holds the version of the API server that we are connected to.
 // It is possible that this version is Zero if the server does not report this
 // during login. The second result argument indicates if the version number is
@@ -4,7 +4,13 @@
 package statushistory
 
 import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -12,19 +18,61 @@ const apiName = "StatusHistory"
 
 // Facade allows calls to "StatusHistory" endpoints
 type Facade struct {
+	*common.ModelWatcher
 	facade base.FacadeCaller
 }
 
 // NewFacade returns a status "StatusHistory" Facade.
 func NewFacade(caller base.APICaller) *Facade {
 	facadeCaller := base.NewFacadeCaller(caller, apiName)
-	return &Facade{facadeCaller}
+	return &Facade{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
 }
 
-// Prune calls "StatusHistory.Prune"
-func (s *Facade) Prune(maxLogsPerEntity int) error {
+// Prune calls "StatusHistory.Prune". Age and size limits which are
+// not positive are replaced by the model's configured limits or the
+// defaults; the history of each entity is only limited separately if
+// maxLogsPerEntity is positive.
+func (s *Facade) Prune(maxHistoryTime time.Duration, maxHistoryMB, maxLogsPerEntity int) error {
 	p := params.StatusHistoryPruneArgs{
+		MaxHistoryTime:   maxHistoryTime,
+		MaxHistoryMB:     maxHistoryMB,
 		MaxLogsPerEntity: maxLogsPerEntity,
 	}
 	return s.facade.FacadeCall("Prune", p, nil)
 }
+
+// ModelStatusHistory calls "StatusHistory.ModelStatusHistory",
+// returning the status history of the entities in the model
+// matching the arguments, ordered from oldest to newest. It
+// requires version 3 of the facade.
+func (s *Facade) ModelStatusHistory(args params.ModelStatusHistoryArgs) ([]params.HistoricalStatus, error) {
+	if s.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("ModelStatusHistory() (need V3+)")
+	}
+	var result params.ModelStatusHistoryResult
+	if err := s.facade.FacadeCall("ModelStatusHistory", args, &result); err != nil {
+		return nil, err
+	}
+	return result.Statuses, nil
+}
+
+// HookHistory calls "StatusHistory.HookHistory", returning up to size
+// of the most recent operations executed by the unit's agent, ordered
+// from newest to oldest. It requires version 4 of the facade.
+func (s *Facade) HookHistory(unit names.UnitTag, size int) ([]params.HookRecord, error) {
+	if s.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("HookHistory() (need V4+)")
+	}
+	args := params.HookHistoryArgs{
+		Unit: unit.String(),
+		Size: size,
+	}
+	var result params.HookHistoryResult
+	if err := s.facade.FacadeCall("HookHistory", args, &result); err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
@@ -150,3 +150,173 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// CreateVolumeSnapshots creates snapshots of the volumes identified by
+// the specified volume or storage tags, returning the IDs of the new
+// snapshots.
+func (c *Client) CreateVolumeSnapshots(tags []names.Tag) ([]params.StringResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("CreateVolumeSnapshots() (need V3+)")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("CreateVolumeSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(tags), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListVolumeSnapshots lists snapshots of the specified volumes.
+// If no volumes are provided, a list of all volume snapshots
+// is returned.
+func (c *Client) ListVolumeSnapshots(volumes []names.VolumeTag) ([]params.VolumeSnapshotDetails, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("ListVolumeSnapshots() (need V3+)")
+	}
+	var filter params.VolumeSnapshotFilter
+	for _, volume := range volumes {
+		filter.VolumeTags = append(filter.VolumeTags, volume.String())
+	}
+	args := params.VolumeSnapshotFilters{[]params.VolumeSnapshotFilter{filter}}
+	var results params.VolumeSnapshotDetailsListResults
+	if err := c.facade.FacadeCall("ListVolumeSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// DestroyVolumeSnapshots destroys the volume snapshots with the
+// specified IDs.
+func (c *Client) DestroyVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("DestroyVolumeSnapshots() (need V3+)")
+	}
+	args := params.VolumeSnapshotIds{ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DestroyVolumeSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(ids), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// RestoreVolumeSnapshots creates new volumes from the specified volume
+// snapshots, returning the tags of the new volumes.
+func (c *Client) RestoreVolumeSnapshots(snapshots []params.RestoreVolumeSnapshotParams) ([]params.StringResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("RestoreVolumeSnapshots() (need V3+)")
+	}
+	args := params.RestoreVolumeSnapshotsParams{snapshots}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("RestoreVolumeSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(snapshots) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(snapshots), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ResizeStorage requests that the volumes or filesystems assigned to
+// the specified storage instances be grown to the given sizes.
+func (c *Client) ResizeStorage(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("ResizeStorage() (need V3+)")
+	}
+	args := params.StoragesResizeParams{storages}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storages) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storages), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// Detach detaches the specified storage instances from the units that
+// own them, without destroying the storage. If force is true, the
+// storage attachments are removed without waiting for the units' agents
+// to run storage-detaching hooks.
+func (c *Client) Detach(tags []names.StorageTag, force bool) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("Detach() (need V3+)")
+	}
+	args := params.StorageDetachmentParams{
+		Storages: params.Entities{Entities: make([]params.Entity, len(tags))},
+		Force:    force,
+	}
+	for i, tag := range tags {
+		args.Storages.Entities[i].Tag = tag.String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Detach", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(tags), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// Attach attaches the specified detached storage instances to units.
+func (c *Client) Attach(ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("Attach() (need V3+)")
+	}
+	args := params.StorageAttachmentIds{ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Attach", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(ids), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// StorageUsage returns a report of the storage capacity allocated in
+// the model, and how much of it is in use, per storage pool, per machine
+// and per unit.
+func (c *Client) StorageUsage() (params.StorageUsage, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.StorageUsage{}, errors.NotImplementedf("StorageUsage() (need V3+)")
+	}
+	var result params.StorageUsage
+	if err := c.facade.FacadeCall("StorageUsage", nil, &result); err != nil {
+		return params.StorageUsage{}, errors.Trace(err)
+	}
+	return result, nil
+}
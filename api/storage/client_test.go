@@ -581,3 +581,384 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateVolumeSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{"volume-0"}, {"storage-data-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+			*(result.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{
+					{Result: "0@0"},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	results, err := storageClient.CreateVolumeSnapshots([]names.Tag{
+		names.NewVolumeTag("0"),
+		names.NewStorageTag("data/0"),
+	})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StringResult{
+		{Result: "0@0"},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *storageMockSuite) TestListVolumeSnapshots(c *gc.C) {
+	var called bool
+	details := params.VolumeSnapshotDetails{
+		Id:        "0@0",
+		VolumeTag: "volume-0",
+		Pool:      "loop",
+		Life:      params.Alive,
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListVolumeSnapshots")
+			c.Check(a, jc.DeepEquals, params.VolumeSnapshotFilters{
+				Filters: []params.VolumeSnapshotFilter{{
+					VolumeTags: []string{"volume-0"},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsListResults{})
+			*(result.(*params.VolumeSnapshotDetailsListResults)) = params.VolumeSnapshotDetailsListResults{
+				Results: []params.VolumeSnapshotDetailsListResult{{
+					Result: []params.VolumeSnapshotDetails{details},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	found, err := storageClient.ListVolumeSnapshots([]names.VolumeTag{names.NewVolumeTag("0")})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []params.VolumeSnapshotDetails{details})
+}
+
+func (s *storageMockSuite) TestListVolumeSnapshotsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.VolumeSnapshotDetailsListResults)) = params.VolumeSnapshotDetailsListResults{
+				Results: []params.VolumeSnapshotDetailsListResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	_, err := storageClient.ListVolumeSnapshots(nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DestroyVolumeSnapshots")
+			c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{
+				Ids: []string{"0@0", "0@1"},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	results, err := storageClient.DestroyVolumeSnapshots([]string{"0@0", "0@1"})
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *storageMockSuite) TestRestoreVolumeSnapshots(c *gc.C) {
+	var called bool
+	snapshots := []params.RestoreVolumeSnapshotParams{
+		{Id: "0@0"},
+		{Id: "1@0", MachineTag: "machine-1"},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RestoreVolumeSnapshots")
+			c.Check(a, jc.DeepEquals, params.RestoreVolumeSnapshotsParams{
+				Snapshots: snapshots,
+			})
+			*(result.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{
+					{Result: "volume-2"},
+					{Result: "volume-3"},
+				},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	results, err := storageClient.RestoreVolumeSnapshots(snapshots)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StringResult{
+		{Result: "volume-2"},
+		{Result: "volume-3"},
+	})
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	var called bool
+	storages := []params.StorageResizeParams{
+		{StorageTag: "storage-data-0", Size: 2048},
+		{StorageTag: "storage-data-1", Size: 512},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ResizeStorage")
+			c.Check(a, jc.DeepEquals, params.StoragesResizeParams{storages})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	results, err := storageClient.ResizeStorage(storages)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *storageMockSuite) TestDetach(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Detach")
+			c.Check(a, jc.DeepEquals, params.StorageDetachmentParams{
+				Storages: params.Entities{
+					Entities: []params.Entity{
+						{Tag: "storage-data-0"},
+						{Tag: "storage-data-1"},
+					},
+				},
+				Force: true,
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	results, err := storageClient.Detach([]names.StorageTag{
+		names.NewStorageTag("data/0"),
+		names.NewStorageTag("data/1"),
+	}, true)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *storageMockSuite) TestDetachOldServer(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2})
+	_, err := storageClient.Detach([]names.StorageTag{names.NewStorageTag("data/0")}, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = storageClient.Attach([]params.StorageAttachmentId{{
+		StorageTag: "storage-data-0", UnitTag: "unit-mysql-1",
+	}})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *storageMockSuite) TestAttach(c *gc.C) {
+	var called bool
+	ids := []params.StorageAttachmentId{
+		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Attach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{ids})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	results, err := storageClient.Attach(ids)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *storageMockSuite) TestAttachResultCountMismatch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	_, err := storageClient.Attach([]params.StorageAttachmentId{
+		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `expected 1 result\(s\), got 2`)
+}
+
+func (s *storageMockSuite) TestStorageUsage(c *gc.C) {
+	available := uint64(4096)
+	expected := params.StorageUsage{
+		Pools: []params.StoragePoolUsage{{
+			Name:      "ebs",
+			Provider:  "ebs",
+			Volumes:   1,
+			Allocated: 1024,
+			Available: &available,
+		}},
+		Machines: []params.MachineStorageUsage{{
+			MachineTag: "machine-0",
+			Volumes:    1,
+			Allocated:  1024,
+			Available:  2048,
+		}},
+		Units: []params.UnitStorageUsage{{
+			UnitTag:    "unit-mysql-0",
+			StorageTag: "storage-data-0",
+			Pool:       "ebs",
+			Size:       1024,
+		}},
+	}
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "StorageUsage")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.StorageUsage)) = expected
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	usage, err := storageClient.StorageUsage()
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, expected)
+}
+
+func (s *storageMockSuite) TestStorageUsageFacadeCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3})
+	_, err := storageClient.StorageUsage()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestStorageUsageOldServer(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2})
+	_, err := storageClient.StorageUsage()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
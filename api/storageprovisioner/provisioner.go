@@ -94,6 +94,15 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeSnapshots watches for lifecycle changes to snapshots of
+// volumes scoped to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("WatchVolumeSnapshots() (need V3+)")
+	}
+	return st.watchStorageEntities("WatchVolumeSnapshots")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -466,3 +475,155 @@ func (st *State) SetStatus(args []params.EntityStatusArgs) error {
 	}
 	return result.Combine()
 }
+
+// VolumeSnapshotParams returns the parameters for creating or destroying
+// the volume snapshots with the specified IDs.
+func (st *State) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("VolumeSnapshotParams() (need V3+)")
+	}
+	var results params.VolumeSnapshotParamsResults
+	args := params.VolumeSnapshotIds{ids}
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly created volume
+// snapshots.
+func (st *State) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("SetVolumeSnapshotInfo() (need V3+)")
+	}
+	var results params.ErrorResults
+	args := params.VolumeSnapshots{snapshots}
+	err := st.facade.FacadeCall("SetVolumeSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshotErrors records the errors that occurred when creating
+// volume snapshots.
+func (st *State) SetVolumeSnapshotErrors(snapshotErrors []params.VolumeSnapshotError) ([]params.ErrorResult, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("SetVolumeSnapshotErrors() (need V3+)")
+	}
+	var results params.ErrorResults
+	args := params.VolumeSnapshotErrors{snapshotErrors}
+	err := st.facade.FacadeCall("SetVolumeSnapshotErrors", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshotErrors) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(snapshotErrors), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// RemoveVolumeSnapshots removes the volume snapshots with the specified
+// IDs from state.
+func (st *State) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("RemoveVolumeSnapshots() (need V3+)")
+	}
+	var results params.ErrorResults
+	args := params.VolumeSnapshotIds{ids}
+	if err := st.facade.FacadeCall("RemoveVolumeSnapshots", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("VolumeResizeParams() (need V3+)")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// FilesystemResizeParams returns the parameters for growing the
+// filesystems with the specified tags.
+func (st *State) FilesystemResizeParams(tags []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("FilesystemResizeParams() (need V3+)")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.FilesystemResizeParamsResults
+	err := st.facade.FacadeCall("FilesystemResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSizes records the sizes of volumes that have been grown.
+func (st *State) SetVolumeSizes(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("SetVolumeSizes() (need V3+)")
+	}
+	var results params.ErrorResults
+	args := params.StorageSizes{sizes}
+	err := st.facade.FacadeCall("SetVolumeSizes", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(sizes) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(sizes), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetFilesystemSizes records the sizes of filesystems that have been
+// grown.
+func (st *State) SetFilesystemSizes(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("SetFilesystemSizes() (need V3+)")
+	}
+	var results params.ErrorResults
+	args := params.StorageSizes{sizes}
+	err := st.facade.FacadeCall("SetFilesystemSizes", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(sizes) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(sizes), len(results.Results)))
+	}
+	return results.Results, nil
+}
@@ -726,7 +726,10 @@ func (s *provisionerSuite) testClientError(c *gc.C, apiCall func(*storageprovisi
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		return errors.New("blargh")
 	})
-	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	st, err := storageprovisioner.NewState(
+		testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3},
+		names.NewMachineTag("123"),
+	)
 	c.Assert(err, jc.ErrorIsNil)
 	err = apiCall(st)
 	c.Check(err, gc.ErrorMatches, "blargh")
@@ -952,3 +955,298 @@ func (s *provisionerSuite) TestModelConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(outputCfg.AllAttrs(), jc.DeepEquals, inputCfg.AllAttrs())
 }
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeSnapshotParams")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"100@0"}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotParamsResults{})
+		*(result.(*params.VolumeSnapshotParamsResults)) = params.VolumeSnapshotParamsResults{
+			Results: []params.VolumeSnapshotParamsResult{{
+				Result: params.VolumeSnapshotParams{
+					Id:        "100@0",
+					VolumeTag: "volume-100",
+					VolumeId:  "vol-ume",
+					Size:      1024,
+					Provider:  "loop",
+					Life:      params.Alive,
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotParams, err := st.VolumeSnapshotParams([]string{"100@0"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(snapshotParams, jc.DeepEquals, []params.VolumeSnapshotParamsResult{{
+		Result: params.VolumeSnapshotParams{
+			Id: "100@0", VolumeTag: "volume-100", VolumeId: "vol-ume",
+			Size: 1024, Provider: "loop", Life: params.Alive,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	var callCount int
+	snapshots := []params.VolumeSnapshot{{
+		Id:        "100@0",
+		VolumeTag: "volume-100",
+		Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024},
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetVolumeSnapshotInfo")
+		c.Check(arg, jc.DeepEquals, params.VolumeSnapshots{snapshots})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSnapshotInfo(snapshots)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotErrors(c *gc.C) {
+	var callCount int
+	snapshotErrors := []params.VolumeSnapshotError{{Id: "100@0", Message: "boom"}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetVolumeSnapshotErrors")
+		c.Check(arg, jc.DeepEquals, params.VolumeSnapshotErrors{snapshotErrors})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSnapshotErrors(snapshotErrors)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RemoveVolumeSnapshots")
+		c.Check(arg, jc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"100@0"}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.RemoveVolumeSnapshots([]string{"100@0"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParamsClientError(c *gc.C) {
+	s.testClientError(c, func(st *storageprovisioner.State) error {
+		_, err := st.VolumeSnapshotParams(nil)
+		return err
+	})
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshotsClientError(c *gc.C) {
+	s.testClientError(c, func(st *storageprovisioner.State) error {
+		_, err := st.RemoveVolumeSnapshots(nil)
+		return err
+	})
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotsOldServer(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	st, err := storageprovisioner.NewState(
+		testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2},
+		names.NewMachineTag("123"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeSnapshots()
+	c.Check(err, gc.ErrorMatches, `WatchVolumeSnapshots\(\) \(need V3\+\) not implemented`)
+	_, err = st.VolumeSnapshotParams(nil)
+	c.Check(err, gc.ErrorMatches, `VolumeSnapshotParams\(\) \(need V3\+\) not implemented`)
+	_, err = st.SetVolumeSnapshotInfo(nil)
+	c.Check(err, gc.ErrorMatches, `SetVolumeSnapshotInfo\(\) \(need V3\+\) not implemented`)
+	_, err = st.SetVolumeSnapshotErrors(nil)
+	c.Check(err, gc.ErrorMatches, `SetVolumeSnapshotErrors\(\) \(need V3\+\) not implemented`)
+	_, err = st.RemoveVolumeSnapshots(nil)
+	c.Check(err, gc.ErrorMatches, `RemoveVolumeSnapshots\(\) \(need V3\+\) not implemented`)
+}
+
+func (s *provisionerSuite) TestResizeOldServer(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	st, err := storageprovisioner.NewState(
+		testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2},
+		names.NewMachineTag("123"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.VolumeResizeParams(nil)
+	c.Check(err, gc.ErrorMatches, `VolumeResizeParams\(\) \(need V3\+\) not implemented`)
+	_, err = st.FilesystemResizeParams(nil)
+	c.Check(err, gc.ErrorMatches, `FilesystemResizeParams\(\) \(need V3\+\) not implemented`)
+	_, err = st.SetVolumeSizes(nil)
+	c.Check(err, gc.ErrorMatches, `SetVolumeSizes\(\) \(need V3\+\) not implemented`)
+	_, err = st.SetFilesystemSizes(nil)
+	c.Check(err, gc.ErrorMatches, `SetFilesystemSizes\(\) \(need V3\+\) not implemented`)
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+		*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+			Results: []params.VolumeResizeParamsResult{{
+				Result: params.VolumeResizeParams{
+					VolumeTag: "volume-100",
+					VolumeId:  "vol-ume",
+					Size:      2048,
+					Provider:  "loop",
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(resizeParams, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: params.VolumeResizeParams{
+			VolumeTag: "volume-100", VolumeId: "vol-ume", Size: 2048, Provider: "loop",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestFilesystemResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "FilesystemResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"filesystem-100"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.FilesystemResizeParamsResults{})
+		*(result.(*params.FilesystemResizeParamsResults)) = params.FilesystemResizeParamsResults{
+			Results: []params.FilesystemResizeParamsResult{{
+				Result: params.FilesystemResizeParams{
+					FilesystemTag: "filesystem-100",
+					FilesystemId:  "fs-id",
+					Size:          2048,
+					Provider:      "rootfs",
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.FilesystemResizeParams([]names.FilesystemTag{names.NewFilesystemTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(resizeParams, jc.DeepEquals, []params.FilesystemResizeParamsResult{{
+		Result: params.FilesystemResizeParams{
+			FilesystemTag: "filesystem-100", FilesystemId: "fs-id", Size: 2048, Provider: "rootfs",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetVolumeSizes(c *gc.C) {
+	var callCount int
+	sizes := []params.StorageSize{{Tag: "volume-100", Size: 2048}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetVolumeSizes")
+		c.Check(arg, jc.DeepEquals, params.StorageSizes{sizes})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSizes(sizes)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestSetFilesystemSizes(c *gc.C) {
+	var callCount int
+	sizes := []params.StorageSize{{Tag: "filesystem-100", Size: 2048}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetFilesystemSizes")
+		c.Check(arg, jc.DeepEquals, params.StorageSizes{sizes})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(testing.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 3}, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetFilesystemSizes(sizes)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.ErrorMatches, "FAIL")
+}
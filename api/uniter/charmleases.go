@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lease"
+)
+
+// ClaimCharmLease claims, or extends, the named charm lease on behalf of
+// the unit, so that it is held until at least duration after the call.
+// The scope must be the unit's service or the model. If another unit holds
+// the lease, it returns lease.ErrClaimDenied.
+func (u *Unit) ClaimCharmLease(scope names.Tag, name string, duration time.Duration) error {
+	if u.st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("ClaimCharmLease() (need V6+)")
+	}
+	args := u.charmLeases(scope, name)
+	args.Leases[0].DurationSeconds = duration.Seconds()
+	var result params.ErrorResults
+	err := u.st.facade.FacadeCall("ClaimCharmLeases", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = result.OneError()
+	if params.IsCodeLeaseClaimDenied(err) {
+		return lease.ErrClaimDenied
+	}
+	return err
+}
+
+// ReleaseCharmLease gives up the named charm lease on behalf of the unit.
+// If the unit does not hold the lease, it returns lease.ErrNotHeld.
+func (u *Unit) ReleaseCharmLease(scope names.Tag, name string) error {
+	if u.st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("ReleaseCharmLease() (need V6+)")
+	}
+	var result params.ErrorResults
+	err := u.st.facade.FacadeCall("ReleaseCharmLeases", u.charmLeases(scope, name), &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = result.OneError()
+	if params.IsCodeLeaseNotHeld(err) {
+		return lease.ErrNotHeld
+	}
+	return err
+}
+
+// CheckCharmLease reports whether the unit holds the named charm lease.
+func (u *Unit) CheckCharmLease(scope names.Tag, name string) (bool, error) {
+	if u.st.facade.BestAPIVersion() < 6 {
+		return false, errors.NotImplementedf("CheckCharmLease() (need V6+)")
+	}
+	var results params.BoolResults
+	err := u.st.facade.FacadeCall("CheckCharmLeases", u.charmLeases(scope, name), &results)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+func (u *Unit) charmLeases(scope names.Tag, name string) params.CharmLeases {
+	return params.CharmLeases{Leases: []params.CharmLease{{
+		UnitTag:  u.tag.String(),
+		ScopeTag: scope.String(),
+		Name:     name,
+	}}}
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+)
+
+func (s *unitSuite) TestClaimCheckReleaseCharmLease(c *gc.C) {
+	scope := s.wordpressService.Tag()
+	err := s.apiUnit.ClaimCharmLease(scope, "backup", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	held, err := s.apiUnit.CheckCharmLease(scope, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsTrue)
+
+	err = s.apiUnit.ReleaseCharmLease(scope, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	held, err = s.apiUnit.CheckCharmLease(scope, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsFalse)
+
+	err = s.apiUnit.ReleaseCharmLease(scope, "backup")
+	c.Check(err, gc.Equals, lease.ErrNotHeld)
+}
+
+func (s *unitSuite) TestClaimCharmLeaseDenied(c *gc.C) {
+	leaseName, err := state.CharmLeaseName(s.State.ModelTag(), "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CharmLeaseClaimer().Claim(leaseName, "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.apiUnit.ClaimCharmLease(s.State.ModelTag(), "backup", time.Minute)
+	c.Check(err, gc.Equals, lease.ErrClaimDenied)
+}
+
+func (s *unitSuite) TestClaimCharmLeaseOtherService(c *gc.C) {
+	err := s.apiUnit.ClaimCharmLease(names.NewServiceTag("mysql"), "backup", time.Minute)
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	c.Check(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
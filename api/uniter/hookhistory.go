@@ -0,0 +1,27 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddHookRecords records operations executed by the unit's agent.
+func (u *Unit) AddHookRecords(records ...params.HookRecord) error {
+	if u.st.facade.BestAPIVersion() < 4 {
+		return errors.NotImplementedf("AddHookRecords() (need V4+)")
+	}
+	args := params.HookRecordsArgs{Units: []params.UnitHookRecords{{
+		Tag:     u.tag.String(),
+		Records: records,
+	}}}
+	var result params.ErrorResults
+	err := u.st.facade.FacadeCall("AddHookRecords", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
@@ -0,0 +1,37 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *unitSuite) TestAddHookRecords(c *gc.C) {
+	started := time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)
+	err := s.apiUnit.AddHookRecords(params.HookRecord{
+		Operation:  "run config-changed hook",
+		Hook:       "config-changed",
+		RelationId: -1,
+		Started:    started,
+		Duration:   time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := s.wordpressUnit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Operation, gc.Equals, "run config-changed hook")
+	c.Check(records[0].Started, gc.DeepEquals, started)
+	c.Check(records[0].Duration, gc.Equals, time.Second)
+}
+
+func (s *unitSuite) TestAddHookRecordsInvalid(c *gc.C) {
+	err := s.apiUnit.AddHookRecords(params.HookRecord{})
+	c.Assert(err, gc.ErrorMatches, "hook record with no operation not valid")
+}
@@ -17,10 +17,11 @@ import (
 // Relation represents a relation between one or two service
 // endpoints.
 type Relation struct {
-	st   *State
-	tag  names.RelationTag
-	id   int
-	life params.Life
+	st        *State
+	tag       names.RelationTag
+	id        int
+	life      params.Life
+	suspended bool
 }
 
 // Tag returns the relation tag.
@@ -46,6 +47,11 @@ func (r *Relation) Life() params.Life {
 	return r.life
 }
 
+// Suspended returns whether the relation is suspended.
+func (r *Relation) Suspended() bool {
+	return r.suspended
+}
+
 // Refresh refreshes the contents of the relation from the underlying
 // state. It returns an error that satisfies errors.IsNotFound if the
 // relation has been removed.
@@ -54,10 +60,11 @@ func (r *Relation) Refresh() error {
 	if err != nil {
 		return err
 	}
-	// NOTE: The life cycle information is the only
-	// thing that can change - id, tag and endpoint
-	// information are static.
+	// NOTE: The life cycle information and suspension
+	// are the only things that can change - id, tag and
+	// endpoint information are static.
 	r.life = result.Life
+	r.suspended = result.Suspended
 
 	return nil
 }
@@ -73,6 +73,19 @@ func (s *relationSuite) TestRefresh(c *gc.C) {
 	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
 }
 
+func (s *relationSuite) TestRefreshSuspended(c *gc.C) {
+	c.Assert(s.apiRelation.Suspended(), jc.IsFalse)
+
+	err := s.stateRelation.SetSuspended(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiRelation.Suspended(), jc.IsFalse)
+
+	err = s.apiRelation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiRelation.Suspended(), jc.IsTrue)
+	c.Assert(s.apiRelation.Life(), gc.Equals, params.Alive)
+}
+
 func (s *relationSuite) TestEndpoint(c *gc.C) {
 	apiEndpoint, err := s.apiRelation.Endpoint()
 	c.Assert(err, jc.ErrorIsNil)
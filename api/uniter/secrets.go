@@ -0,0 +1,83 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddSecretRevision stores the supplied values as a new revision of the
+// named secret owned by the unit's service, creating the secret if it
+// does not exist, and returns the new revision.
+func (u *Unit) AddSecretRevision(name string, values map[string]string) (int, error) {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return 0, errors.NotImplementedf("AddSecretRevision() (need V5+)")
+	}
+	args := u.secretArgs(u.ServiceTag(), name)
+	args.Args[0].Values = values
+	var results params.IntResults
+	err := u.st.facade.FacadeCall("AddSecretRevisions", args, &results)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.Result, nil
+}
+
+// SecretValues returns the values of the supplied revision of the named
+// secret owned by the supplied service, and the revision; revision 0
+// denotes the latest.
+func (u *Unit) SecretValues(owner names.ServiceTag, name string, revision int) (int, map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return 0, nil, errors.NotImplementedf("SecretValues() (need V5+)")
+	}
+	args := u.secretArgs(owner, name)
+	args.Args[0].Revision = revision
+	var results params.SecretValuesResults
+	err := u.st.facade.FacadeCall("GetSecretValues", args, &results)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, nil, result.Error
+	}
+	return result.Revision, result.Values, nil
+}
+
+// GrantSecret allows the services at the other end of the supplied
+// relation to read the named secret owned by the unit's service.
+func (u *Unit) GrantSecret(name string, relation names.RelationTag) error {
+	if u.st.facade.BestAPIVersion() < 5 {
+		return errors.NotImplementedf("GrantSecret() (need V5+)")
+	}
+	args := u.secretArgs(u.ServiceTag(), name)
+	args.Args[0].RelationTag = relation.String()
+	var result params.ErrorResults
+	err := u.st.facade.FacadeCall("GrantSecrets", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+func (u *Unit) secretArgs(owner names.ServiceTag, name string) params.SecretArgs {
+	return params.SecretArgs{Args: []params.SecretArg{{
+		UnitTag:  u.tag.String(),
+		OwnerTag: owner.String(),
+		Name:     name,
+	}}}
+}
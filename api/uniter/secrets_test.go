@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *unitSuite) TestAddSecretRevisionAndValues(c *gc.C) {
+	owner := s.wordpressService.ServiceTag()
+	revision, err := s.apiUnit.AddSecretRevision("admin", map[string]string{"password": "a"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revision, gc.Equals, 1)
+	revision, err = s.apiUnit.AddSecretRevision("admin", map[string]string{"password": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revision, gc.Equals, 2)
+
+	revision, values, err := s.apiUnit.SecretValues(owner, "admin", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revision, gc.Equals, 2)
+	c.Check(values, jc.DeepEquals, map[string]string{"password": "b"})
+	revision, values, err = s.apiUnit.SecretValues(owner, "admin", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revision, gc.Equals, 1)
+	c.Check(values, jc.DeepEquals, map[string]string{"password": "a"})
+}
+
+func (s *unitSuite) TestSecretValuesOtherService(c *gc.C) {
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := s.State.AddSecretRevision(mysql.ServiceTag(), "root", map[string]string{"password": "a"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.apiUnit.SecretValues(names.NewServiceTag("mysql"), "root", 0)
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	c.Check(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *unitSuite) TestGrantSecret(c *gc.C) {
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	rel := s.addRelation(c, "wordpress", "mysql")
+	_, err := s.apiUnit.AddSecretRevision("admin", map[string]string{"password": "a"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.apiUnit.GrantSecret("admin", rel.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(s.wordpressService.ServiceTag(), "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead(mysql.ServiceTag()), jc.IsTrue)
+}
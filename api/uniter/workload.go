@@ -0,0 +1,45 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SetWorkloadVersion records the version of the software the unit runs.
+func (u *Unit) SetWorkloadVersion(version string) error {
+	if u.st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("SetWorkloadVersion() (need V6+)")
+	}
+	args := params.EntityWorkloadVersions{Entities: []params.EntityWorkloadVersion{{
+		Tag:             u.tag.String(),
+		WorkloadVersion: version,
+	}}}
+	var result params.ErrorResults
+	err := u.st.facade.FacadeCall("SetWorkloadVersion", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// SetWorkloadInfo updates the information about the software the unit
+// runs with the supplied changes. Keys with empty values are removed.
+func (u *Unit) SetWorkloadInfo(changes map[string]string) error {
+	if u.st.facade.BestAPIVersion() < 6 {
+		return errors.NotImplementedf("SetWorkloadInfo() (need V6+)")
+	}
+	args := params.EntitiesWorkloadInfo{Entities: []params.EntityWorkloadInfo{{
+		Tag:  u.tag.String(),
+		Info: changes,
+	}}}
+	var result params.ErrorResults
+	err := u.st.facade.FacadeCall("SetWorkloadInfo", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
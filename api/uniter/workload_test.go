@@ -0,0 +1,32 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+func (s *unitSuite) TestSetWorkloadVersion(c *gc.C) {
+	err := s.apiUnit.SetWorkloadVersion("4.5.2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.WorkloadVersion(), gc.Equals, "4.5.2")
+}
+
+func (s *unitSuite) TestSetWorkloadInfo(c *gc.C) {
+	err := s.apiUnit.SetWorkloadInfo(map[string]string{"edition": "enterprise"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.WorkloadInfo(), jc.DeepEquals, map[string]string{"edition": "enterprise"})
+}
+
+func (s *unitSuite) TestSetWorkloadInfoInvalid(c *gc.C) {
+	err := s.apiUnit.SetWorkloadInfo(map[string]string{"bad.key": "x"})
+	c.Assert(err, gc.ErrorMatches, `cannot set workload info for unit "wordpress/0": key "bad.key" not valid`)
+}
@@ -19,6 +19,9 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Agent", 2, NewAgentAPIV2)
+
+	// Version 3 adds SecretsKey, otherwise compatible.
+	common.RegisterStandardFacade("Agent", 3, NewAgentAPIV2)
 }
 
 // AgentAPIV2 implements the version 2 of the API provided to an agent.
@@ -100,6 +103,19 @@ func (api *AgentAPIV2) StateServingInfo() (result state.StateServingInfo, err er
 	return api.st.StateServingInfo()
 }
 
+// SecretsKey returns the key with which the controller encrypts the
+// values of secrets, so that other controllers can do the same.
+func (api *AgentAPIV2) SecretsKey() (params.BytesResult, error) {
+	if !api.auth.AuthModelManager() {
+		return params.BytesResult{}, common.ErrPerm
+	}
+	key, err := api.st.SecretsKey()
+	if err != nil {
+		return params.BytesResult{}, errors.Trace(err)
+	}
+	return params.BytesResult{Result: key}, nil
+}
+
 // MongoIsMaster is called by the IsMaster API call
 // instead of mongo.IsMaster. It exists so it can
 // be overridden by tests.
@@ -28,6 +28,12 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Client", 1, NewClient)
+
+	// Version 2 has the same set of methods as 1, with the same
+	// signatures, but its FullStatus honours the filter in the
+	// status parameters. Clients must require version 2 to filter
+	// status; otherwise they are compatible.
+	common.RegisterStandardFacade("Client", 2, NewClient)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.client")
@@ -37,6 +37,8 @@ type stateInterface interface {
 	AllMachines() ([]*state.Machine, error)
 	AllServices() ([]*state.Service, error)
 	AllRelations() ([]*state.Relation, error)
+	AllRemoteServices() ([]*state.RemoteService, error)
+	GetModel(names.ModelTag) (*state.Model, error)
 	AllNetworks() ([]*state.Network, error)
 	AddOneMachine(state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideMachine(state.MachineTemplate, string, instance.ContainerType) (*state.Machine, error)
@@ -101,6 +101,8 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		return noStatus, errors.Annotate(err, "could not fetch relations")
 	} else if context.networks, err = fetchNetworks(c.api.stateAccessor); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch networks")
+	} else if context.remoteServices, err = fetchRemoteServices(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch remote services")
 	}
 
 	logger.Debugf("Services: %v", context.services)
@@ -191,6 +193,13 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		}
 	}
 
+	if err := context.applyStatusFilter(args.Filter); err != nil {
+		return noStatus, errors.Annotate(err, "could not filter status")
+	}
+	if len(args.Patterns) > 0 || !args.Filter.IsEmpty() {
+		context.filterRemoteServices()
+	}
+
 	newToolsVersion, err := c.newToolsVersionAvailable()
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine if there is a new tools version available")
@@ -205,6 +214,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Services:         context.processServices(),
 		Networks:         context.processNetworks(),
 		Relations:        context.processRelations(),
+		RemoteServices:   context.processRemoteServices(c.api.stateAccessor),
 	}, nil
 }
 
@@ -259,6 +269,8 @@ type statusContext struct {
 	units        map[string]map[string]*state.Unit
 	networks     map[string]*state.Network
 	latestCharms map[charm.URL]string
+	// remoteServices: remote service name -> remote service
+	remoteServices map[string]*state.RemoteService
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -382,6 +394,20 @@ func fetchRelations(st stateInterface) (map[string][]*state.Relation, error) {
 	return out, nil
 }
 
+// fetchRemoteServices returns a map of all remote services keyed by
+// name.
+func fetchRemoteServices(st stateInterface) (map[string]*state.RemoteService, error) {
+	services, err := st.AllRemoteServices()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*state.RemoteService)
+	for _, service := range services {
+		out[service.Name()] = service
+	}
+	return out, nil
+}
+
 // fetchNetworks returns a map from network name to network.
 func fetchNetworks(st stateInterface) (map[string]*state.Network, error) {
 	networks, err := st.AllNetworks()
@@ -524,6 +550,85 @@ func (context *statusContext) getAllRelations() []*state.Relation {
 	return out
 }
 
+// filterRemoteServices removes the remote services that are not related
+// to any of the services remaining in the status context.
+func (context *statusContext) filterRemoteServices() {
+	for name := range context.remoteServices {
+		related := false
+		for _, relation := range context.relations[name] {
+			eps, err := relation.RelatedEndpoints(name)
+			if err != nil {
+				continue
+			}
+			for _, ep := range eps {
+				if _, ok := context.services[ep.ServiceName]; ok {
+					related = true
+				}
+			}
+		}
+		if !related {
+			delete(context.remoteServices, name)
+		}
+	}
+}
+
+func (context *statusContext) processRemoteServices(st stateInterface) map[string]params.RemoteServiceStatus {
+	if len(context.remoteServices) == 0 {
+		return nil
+	}
+	servicesMap := make(map[string]params.RemoteServiceStatus)
+	for name, service := range context.remoteServices {
+		servicesMap[name] = context.processRemoteService(st, service)
+	}
+	return servicesMap
+}
+
+func (context *statusContext) processRemoteService(st stateInterface, service *state.RemoteService) (status params.RemoteServiceStatus) {
+	status.SourceService = service.SourceService()
+	status.OfferName = service.OfferName()
+	status.Life = processLife(service)
+	eps, err := service.Endpoints()
+	if err != nil {
+		status.Err = err
+		return
+	}
+	for _, ep := range eps {
+		status.Endpoints = append(status.Endpoints, params.RemoteEndpoint{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+		})
+	}
+	if model, err := st.GetModel(service.SourceModel()); err == nil {
+		status.SourceModel = model.Owner().Canonical() + "/" + model.Name()
+	} else if errors.IsNotFound(err) {
+		status.SourceModel = service.SourceModel().Id()
+	} else {
+		status.Err = err
+		return
+	}
+	status.Relations = make(map[string][]string)
+	for _, relation := range context.relations[service.Name()] {
+		ep, err := relation.Endpoint(service.Name())
+		if err != nil {
+			status.Err = err
+			return
+		}
+		eps, err := relation.RelatedEndpoints(service.Name())
+		if err != nil {
+			status.Err = err
+			return
+		}
+		for _, related := range eps {
+			status.Relations[ep.Name] = append(status.Relations[ep.Name], related.ServiceName)
+		}
+	}
+	for relationName, serviceNames := range status.Relations {
+		status.Relations[relationName] = set.NewStrings(serviceNames...).SortedValues()
+	}
+	return status
+}
+
 func (context *statusContext) processNetworks() map[string]params.NetworkStatus {
 	networksMap := make(map[string]params.NetworkStatus)
 	for name, network := range context.networks {
@@ -678,6 +783,10 @@ func (context *statusContext) processUnit(unit *state.Unit, serviceCharm string)
 		result.Charm = curl.String()
 	}
 	processUnitAndAgentStatus(unit, &result)
+	result.WorkloadVersion = unit.WorkloadVersion()
+	if info := unit.WorkloadInfo(); len(info) > 0 {
+		result.WorkloadInfo = info
+	}
 
 	if subUnits := unit.SubordinateNames(); len(subUnits) > 0 {
 		result.Subordinates = make(map[string]params.UnitStatus)
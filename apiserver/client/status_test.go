@@ -6,6 +6,7 @@ package client_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/apiserver/client"
 	"github.com/juju/juju/apiserver/params"
@@ -164,3 +165,148 @@ func (s *statusUnitTestSuite) TestMeterStatus(c *gc.C) {
 		}
 	}
 }
+
+func (s *statusUnitTestSuite) TestWorkloadVersion(c *gc.C) {
+	service := s.MakeService(c, &factory.ServiceParams{Name: "postgresql"})
+	unit := s.MakeUnit(c, &factory.UnitParams{Service: service})
+	err := unit.SetWorkloadVersion("9.5.2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetWorkloadInfo(map[string]string{"edition": "community"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unitStatus := status.Services["postgresql"].Units[unit.Name()]
+	c.Check(unitStatus.WorkloadVersion, gc.Equals, "9.5.2")
+	c.Check(unitStatus.WorkloadInfo, jc.DeepEquals, map[string]string{"edition": "community"})
+}
+
+func (s *statusUnitTestSuite) TestRemoteServices(c *gc.C) {
+	offerSt := s.MakeModel(c, &factory.ModelParams{Name: "shared"})
+	defer offerSt.Close()
+	f := factory.NewFactory(offerSt)
+	f.MakeService(c, &factory.ServiceParams{Name: "mysql"})
+	offer, err := offerSt.AddOffer(state.AddOfferArgs{Name: "db", ServiceName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ConsumeOffer(state.ConsumeOfferArgs{Offer: offer, User: env.Owner()})
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.MakeService(c, &factory.ServiceParams{
+		Name:  "wordpress",
+		Charm: s.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	eps, err := s.State.InferEndpoints(wordpress.Name(), "db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.RemoteServices, jc.DeepEquals, map[string]params.RemoteServiceStatus{
+		"db": {
+			SourceModel:   env.Owner().Canonical() + "/shared",
+			SourceService: "mysql",
+			OfferName:     "db",
+			Endpoints: []params.RemoteEndpoint{{
+				Name:      "server",
+				Role:      charm.RoleProvider,
+				Interface: "mysql",
+			}},
+			Relations: map[string][]string{"server": {"wordpress"}},
+		},
+	})
+}
+
+func (s *statusUnitTestSuite) TestFilterByWorkloadStatus(c *gc.C) {
+	service := s.MakeService(c, &factory.ServiceParams{Name: "wordpress"})
+	blocked := s.MakeUnit(c, &factory.UnitParams{
+		Service: service,
+		Status:  &state.StatusInfo{Status: state.StatusBlocked, Message: "waiting for db"},
+	})
+	active := s.MakeUnit(c, &factory.UnitParams{
+		Service: service,
+		Status:  &state.StatusInfo{Status: state.StatusActive},
+	})
+	activeMachine, err := active.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.FilteredStatus(nil, params.StatusFilter{
+		WorkloadStatuses: []params.Status{params.StatusBlocked},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Services, gc.HasLen, 1)
+	units := status.Services["wordpress"].Units
+	c.Check(units, gc.HasLen, 1)
+	c.Check(units[blocked.Name()].Workload.Status, gc.Equals, params.StatusBlocked)
+	_, ok := status.Machines[activeMachine]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *statusUnitTestSuite) TestFilterBySeries(c *gc.C) {
+	trusty := s.MakeMachine(c, &factory.MachineParams{Series: "trusty"})
+	s.MakeMachine(c, &factory.MachineParams{Series: "precise"})
+
+	client := s.APIState.Client()
+	status, err := client.FilteredStatus(nil, params.StatusFilter{
+		Series: []string{"trusty"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 1)
+	c.Check(status.Machines[trusty.Id()].Series, gc.Equals, "trusty")
+}
+
+func (s *statusUnitTestSuite) TestFilterByCharm(c *gc.C) {
+	wordpress := s.MakeCharm(c, &factory.CharmParams{Name: "wordpress"})
+	mysql := s.MakeCharm(c, &factory.CharmParams{Name: "mysql"})
+	s.MakeService(c, &factory.ServiceParams{Name: "wordpress", Charm: wordpress})
+	s.MakeService(c, &factory.ServiceParams{Name: "mysql", Charm: mysql})
+
+	client := s.APIState.Client()
+	status, err := client.FilteredStatus(nil, params.StatusFilter{
+		Charms: []string{"mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Services, gc.HasLen, 1)
+	_, ok := status.Services["mysql"]
+	c.Check(ok, jc.IsTrue)
+	c.Check(status.Machines, gc.HasLen, 0)
+}
+
+func (s *statusUnitTestSuite) TestFilterRelatedTo(c *gc.C) {
+	wordpress := s.MakeService(c, &factory.ServiceParams{
+		Name:  "wordpress",
+		Charm: s.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	mysql := s.MakeService(c, &factory.ServiceParams{
+		Name:  "mysql",
+		Charm: s.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	s.MakeService(c, &factory.ServiceParams{
+		Name:  "other",
+		Charm: s.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	eps, err := s.State.InferEndpoints(wordpress.Name(), mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	s.MakeRelation(c, &factory.RelationParams{Endpoints: eps})
+
+	client := s.APIState.Client()
+	status, err := client.FilteredStatus(nil, params.StatusFilter{
+		RelatedTo: []string{"wordpress"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Services, gc.HasLen, 1)
+	_, ok := status.Services["mysql"]
+	c.Check(ok, jc.IsTrue)
+}
+
+func (s *statusUnitTestSuite) TestFilterInvalidStatus(c *gc.C) {
+	client := s.APIState.Client()
+	_, err := client.FilteredStatus(nil, params.StatusFilter{
+		WorkloadStatuses: []params.Status{"bogus"},
+	})
+	c.Assert(err, gc.ErrorMatches, `could not filter status: workload status "bogus" not valid`)
+}
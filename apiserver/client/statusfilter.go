@@ -0,0 +1,282 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// validateStatusFilter returns an error if the filter refers to
+// statuses that are not known to juju.
+func validateStatusFilter(filter params.StatusFilter) error {
+	for _, s := range filter.WorkloadStatuses {
+		if !state.Status(s).KnownWorkloadStatus() {
+			return errors.NotValidf("workload status %q", s)
+		}
+	}
+	for _, s := range filter.AgentStatuses {
+		if !state.Status(s).KnownAgentStatus() {
+			return errors.NotValidf("agent status %q", s)
+		}
+	}
+	return nil
+}
+
+// statusFilter evaluates a params.StatusFilter against the
+// entities held in a statusContext.
+type statusFilter struct {
+	params.StatusFilter
+	context *statusContext
+
+	// machines holds every machine in the context, keyed by id.
+	machines map[string]*state.Machine
+
+	// related holds the names of the services related to one
+	// of the services in RelatedTo.
+	related set.Strings
+}
+
+func newStatusFilter(context *statusContext, filter params.StatusFilter) (*statusFilter, error) {
+	if err := validateStatusFilter(filter); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f := &statusFilter{
+		StatusFilter: filter,
+		context:      context,
+		machines:     make(map[string]*state.Machine),
+		related:      make(set.Strings),
+	}
+	for _, machineList := range context.machines {
+		for _, m := range machineList {
+			f.machines[m.Id()] = m
+		}
+	}
+	for _, name := range filter.RelatedTo {
+		for _, relation := range context.relations[name] {
+			eps, err := relation.RelatedEndpoints(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, ep := range eps {
+				if ep.ServiceName != name {
+					f.related.Add(ep.ServiceName)
+				}
+			}
+		}
+	}
+	return f, nil
+}
+
+// hasUnitCriteria reports whether the filter has criteria
+// which can only be satisfied by units.
+func (f *statusFilter) hasUnitCriteria() bool {
+	return len(f.WorkloadStatuses) > 0 || len(f.AgentStatuses) > 0 || len(f.Series) > 0
+}
+
+// hasServiceCriteria reports whether the filter has criteria
+// which can only be satisfied by services and their units.
+func (f *statusFilter) hasServiceCriteria() bool {
+	return len(f.Charms) > 0 || len(f.RelatedTo) > 0
+}
+
+func (f *statusFilter) matchService(s *state.Service) bool {
+	if len(f.RelatedTo) > 0 && !f.related.Contains(s.Name()) {
+		return false
+	}
+	if len(f.Charms) > 0 {
+		curl, _ := s.CharmURL()
+		if curl == nil || !matchCharm(f.Charms, curl) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchCharm(charms []string, curl *charm.URL) bool {
+	nameRevision := fmt.Sprintf("%s-%d", curl.Name, curl.Revision)
+	for _, c := range charms {
+		if c == curl.Name || c == nameRevision || c == curl.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *statusFilter) matchSeries(machineId string) bool {
+	if len(f.Series) == 0 {
+		return true
+	}
+	m, ok := f.machines[machineId]
+	if !ok {
+		return false
+	}
+	return set.NewStrings(f.Series...).Contains(m.Series())
+}
+
+func statusStrings(statuses []params.Status) []string {
+	out := make([]string, len(statuses))
+	for i, s := range statuses {
+		out[i] = string(s)
+	}
+	return out
+}
+
+// matchUnit reports whether the unit's own status, and that of its
+// service and machine, satisfy the filter. Subordinate units are
+// evaluated against their principal's machine.
+func (f *statusFilter) matchUnit(u *state.Unit, machineId string) (bool, error) {
+	service, ok := f.context.services[u.ServiceName()]
+	if !ok || !f.matchService(service) {
+		return false, nil
+	}
+	if !f.matchSeries(machineId) {
+		return false, nil
+	}
+	if len(f.AgentStatuses) > 0 {
+		agentStatus, err := u.AgentStatus()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if matches, _, _ := matchAgentStatus(statusStrings(f.AgentStatuses), agentStatus.Status); !matches {
+			return false, nil
+		}
+	}
+	if len(f.WorkloadStatuses) > 0 {
+		matches, _, err := unitMatchWorkloadStatus(u, statusStrings(f.WorkloadStatuses))
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchMachine reports whether a machine satisfies the filter in
+// its own right, independent of the units it hosts.
+func (f *statusFilter) matchMachine(m *state.Machine) (bool, error) {
+	if f.hasServiceCriteria() || len(f.WorkloadStatuses) > 0 {
+		return false, nil
+	}
+	if !f.matchSeries(m.Id()) {
+		return false, nil
+	}
+	if len(f.AgentStatuses) > 0 {
+		statusInfo, err := m.Status()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		matches, _, _ := matchAgentStatus(statusStrings(f.AgentStatuses), statusInfo.Status)
+		return matches, nil
+	}
+	return true, nil
+}
+
+// applyStatusFilter removes from the context everything that
+// does not satisfy the filter. A principal unit is kept if it, or
+// one of its subordinates, matches; the subordinates of a kept
+// principal are kept with it. Machines are kept if they match in
+// their own right or host a kept unit.
+func (context *statusContext) applyStatusFilter(filter params.StatusFilter) error {
+	if filter.IsEmpty() {
+		return nil
+	}
+	f, err := newStatusFilter(context, filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	keptUnits := make(set.Strings)
+	keptMachines := make(set.Strings)
+	for _, unitMap := range context.units {
+		for _, unit := range unitMap {
+			if !unit.IsPrincipal() {
+				continue
+			}
+			machineId, err := unit.AssignedMachineId()
+			if err != nil {
+				machineId = ""
+			}
+			matches, err := f.matchUnit(unit, machineId)
+			if err != nil {
+				return errors.Annotate(err, "could not filter units")
+			}
+			subordinates := unit.SubordinateNames()
+			for _, subName := range subordinates {
+				if matches {
+					break
+				}
+				subUnit := context.unitByName(subName)
+				if subUnit == nil {
+					continue
+				}
+				if matches, err = f.matchUnit(subUnit, machineId); err != nil {
+					return errors.Annotate(err, "could not filter units")
+				}
+			}
+			if !matches {
+				continue
+			}
+			keptUnits.Add(unit.Name())
+			keptUnits = keptUnits.Union(set.NewStrings(subordinates...))
+			for machineId != "" {
+				keptMachines.Add(machineId)
+				machineId = state.ParentId(machineId)
+			}
+		}
+	}
+
+	for svcName, unitMap := range context.units {
+		for name := range unitMap {
+			if !keptUnits.Contains(name) {
+				delete(unitMap, name)
+			}
+		}
+		if len(unitMap) > 0 {
+			continue
+		}
+		svc, ok := context.services[svcName]
+		if ok && !f.hasUnitCriteria() && f.matchService(svc) {
+			// Services without units are still of interest
+			// when the filter only concerns services.
+			continue
+		}
+		delete(context.services, svcName)
+		delete(context.units, svcName)
+	}
+
+	for id, machineList := range context.machines {
+		matched := make([]*state.Machine, 0, len(machineList))
+		for _, m := range machineList {
+			matches, err := f.matchMachine(m)
+			if err != nil {
+				return errors.Annotate(err, "could not filter machines")
+			}
+			if matches {
+				for mid := m.Id(); mid != ""; mid = state.ParentId(mid) {
+					keptMachines.Add(mid)
+				}
+			}
+		}
+		for _, m := range machineList {
+			if keptMachines.Contains(m.Id()) {
+				matched = append(matched, m)
+			}
+		}
+		if len(matched) == 0 {
+			delete(context.machines, id)
+			continue
+		}
+		context.machines[id] = matched
+	}
+	return nil
+}
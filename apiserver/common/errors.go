@@ -111,6 +111,7 @@ var singletonErrorCodes = map[error]string{
 	txn.ErrExcessiveContention:   params.CodeExcessiveContention,
 	leadership.ErrClaimDenied:    params.CodeLeadershipClaimDenied,
 	lease.ErrClaimDenied:         params.CodeLeaseClaimDenied,
+	lease.ErrNotHeld:             params.CodeLeaseNotHeld,
 	ErrBadId:                     params.CodeNotFound,
 	ErrBadCreds:                  params.CodeUnauthorized,
 	ErrPerm:                      params.CodeUnauthorized,
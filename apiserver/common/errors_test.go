@@ -138,6 +138,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeLeaseClaimDenied,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeLeaseClaimDenied,
+}, {
+	err:        lease.ErrNotHeld,
+	code:       params.CodeLeaseNotHeld,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeLeaseNotHeld,
 }, {
 	err:        common.OperationBlockedError("test"),
 	code:       params.CodeOperationBlocked,
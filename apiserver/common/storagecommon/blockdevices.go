@@ -22,6 +22,9 @@ func BlockDeviceFromState(in state.BlockDeviceInfo) storage.BlockDevice {
 		in.FilesystemType,
 		in.InUse,
 		in.MountPoint,
+		in.UsedSize,
+		in.VolumeGroup,
+		in.FreeSize,
 	}
 }
 
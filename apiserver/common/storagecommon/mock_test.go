@@ -18,13 +18,17 @@ import (
 type fakeStorage struct {
 	testing.Stub
 	storagecommon.StorageInterface
-	storageInstance        func(names.StorageTag) (state.StorageInstance, error)
-	storageInstanceVolume  func(names.StorageTag) (state.Volume, error)
-	volumeAttachment       func(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
-	blockDevices           func(names.MachineTag) ([]state.BlockDeviceInfo, error)
-	watchVolumeAttachment  func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
-	watchBlockDevices      func(names.MachineTag) state.NotifyWatcher
-	watchStorageAttachment func(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	storageInstance           func(names.StorageTag) (state.StorageInstance, error)
+	storageInstanceVolume     func(names.StorageTag) (state.Volume, error)
+	storageInstanceFilesystem func(names.StorageTag) (state.Filesystem, error)
+	volumeAttachment          func(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
+	filesystemAttachment      func(names.MachineTag, names.FilesystemTag) (state.FilesystemAttachment, error)
+	blockDevices              func(names.MachineTag) ([]state.BlockDeviceInfo, error)
+	watchVolumeAttachment     func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	watchFilesystemAttachment func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
+	watchFilesystem           func(names.FilesystemTag) state.NotifyWatcher
+	watchBlockDevices         func(names.MachineTag) state.NotifyWatcher
+	watchStorageAttachment    func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 }
 
 func (s *fakeStorage) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
@@ -37,11 +41,21 @@ func (s *fakeStorage) StorageInstanceVolume(tag names.StorageTag) (state.Volume,
 	return s.storageInstanceVolume(tag)
 }
 
+func (s *fakeStorage) StorageInstanceFilesystem(tag names.StorageTag) (state.Filesystem, error) {
+	s.MethodCall(s, "StorageInstanceFilesystem", tag)
+	return s.storageInstanceFilesystem(tag)
+}
+
 func (s *fakeStorage) VolumeAttachment(m names.MachineTag, v names.VolumeTag) (state.VolumeAttachment, error) {
 	s.MethodCall(s, "VolumeAttachment", m, v)
 	return s.volumeAttachment(m, v)
 }
 
+func (s *fakeStorage) FilesystemAttachment(m names.MachineTag, f names.FilesystemTag) (state.FilesystemAttachment, error) {
+	s.MethodCall(s, "FilesystemAttachment", m, f)
+	return s.filesystemAttachment(m, f)
+}
+
 func (s *fakeStorage) BlockDevices(m names.MachineTag) ([]state.BlockDeviceInfo, error) {
 	s.MethodCall(s, "BlockDevices", m)
 	return s.blockDevices(m)
@@ -52,6 +66,16 @@ func (s *fakeStorage) WatchVolumeAttachment(m names.MachineTag, v names.VolumeTa
 	return s.watchVolumeAttachment(m, v)
 }
 
+func (s *fakeStorage) WatchFilesystemAttachment(m names.MachineTag, f names.FilesystemTag) state.NotifyWatcher {
+	s.MethodCall(s, "WatchFilesystemAttachment", m, f)
+	return s.watchFilesystemAttachment(m, f)
+}
+
+func (s *fakeStorage) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	s.MethodCall(s, "WatchFilesystem", f)
+	return s.watchFilesystem(f)
+}
+
 func (s *fakeStorage) WatchBlockDevices(m names.MachineTag) state.NotifyWatcher {
 	s.MethodCall(s, "WatchBlockDevices", m)
 	return s.watchBlockDevices(m)
@@ -135,6 +159,39 @@ func (v *fakeVolumeAttachment) Info() (state.VolumeAttachmentInfo, error) {
 	return *v.info, nil
 }
 
+type fakeFilesystem struct {
+	state.Filesystem
+	tag  names.FilesystemTag
+	info *state.FilesystemInfo
+}
+
+func (f *fakeFilesystem) FilesystemTag() names.FilesystemTag {
+	return f.tag
+}
+
+func (f *fakeFilesystem) Tag() names.Tag {
+	return f.tag
+}
+
+func (f *fakeFilesystem) Info() (state.FilesystemInfo, error) {
+	if f.info == nil {
+		return state.FilesystemInfo{}, errors.NotProvisionedf("filesystem %v", f.tag.Id())
+	}
+	return *f.info, nil
+}
+
+type fakeFilesystemAttachment struct {
+	state.FilesystemAttachment
+	info *state.FilesystemAttachmentInfo
+}
+
+func (f *fakeFilesystemAttachment) Info() (state.FilesystemAttachmentInfo, error) {
+	if f.info == nil {
+		return state.FilesystemAttachmentInfo{}, errors.NotProvisionedf("filesystem attachment")
+	}
+	return *f.info, nil
+}
+
 type fakePoolManager struct {
 	poolmanager.PoolManager
 }
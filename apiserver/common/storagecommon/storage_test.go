@@ -148,16 +148,55 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoNoBlockDevice(c *g
 	s.st.CheckCallNames(c, "StorageInstance", "StorageInstanceVolume", "VolumeAttachment", "BlockDevices")
 }
 
+func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoBlockDeviceSize(c *gc.C) {
+	s.volumeAttachment.info.DeviceName = "sda"
+	s.blockDevices[0].Size = 2048
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindBlock,
+		Location: filepath.FromSlash("/dev/sda"),
+		Size:     2048,
+	})
+}
+
+func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoFilesystem(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindFilesystem
+	filesystemTag := names.NewFilesystemTag("0")
+	s.st.storageInstanceFilesystem = func(tag names.StorageTag) (state.Filesystem, error) {
+		return &fakeFilesystem{
+			tag:  filesystemTag,
+			info: &state.FilesystemInfo{FilesystemId: "fs-id", Size: 2048},
+		}, nil
+	}
+	s.st.filesystemAttachment = func(m names.MachineTag, f names.FilesystemTag) (state.FilesystemAttachment, error) {
+		c.Assert(f, gc.Equals, filesystemTag)
+		return &fakeFilesystemAttachment{
+			info: &state.FilesystemAttachmentInfo{MountPoint: "/srv"},
+		}, nil
+	}
+	info, err := storagecommon.StorageAttachmentInfo(s.st, s.storageAttachment, s.machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.CheckCallNames(c, "StorageInstance", "StorageInstanceFilesystem", "FilesystemAttachment")
+	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
+		Kind:     storage.StorageKindFilesystem,
+		Location: "/srv",
+		Size:     2048,
+	})
+}
+
 type watchStorageAttachmentSuite struct {
-	storageTag               names.StorageTag
-	machineTag               names.MachineTag
-	unitTag                  names.UnitTag
-	st                       *fakeStorage
-	storageInstance          *fakeStorageInstance
-	volume                   *fakeVolume
-	volumeAttachmentWatcher  *fakeNotifyWatcher
-	blockDevicesWatcher      *fakeNotifyWatcher
-	storageAttachmentWatcher *fakeNotifyWatcher
+	storageTag                  names.StorageTag
+	machineTag                  names.MachineTag
+	unitTag                     names.UnitTag
+	st                          *fakeStorage
+	storageInstance             *fakeStorageInstance
+	volume                      *fakeVolume
+	volumeAttachmentWatcher     *fakeNotifyWatcher
+	filesystemAttachmentWatcher *fakeNotifyWatcher
+	filesystemWatcher           *fakeNotifyWatcher
+	blockDevicesWatcher         *fakeNotifyWatcher
+	storageAttachmentWatcher    *fakeNotifyWatcher
 }
 
 var _ = gc.Suite(&watchStorageAttachmentSuite{})
@@ -173,9 +212,13 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 	}
 	s.volume = &fakeVolume{tag: names.NewVolumeTag("0")}
 	s.volumeAttachmentWatcher = &fakeNotifyWatcher{ch: make(chan struct{}, 1)}
+	s.filesystemAttachmentWatcher = &fakeNotifyWatcher{ch: make(chan struct{}, 1)}
+	s.filesystemWatcher = &fakeNotifyWatcher{ch: make(chan struct{}, 1)}
 	s.blockDevicesWatcher = &fakeNotifyWatcher{ch: make(chan struct{}, 1)}
 	s.storageAttachmentWatcher = &fakeNotifyWatcher{ch: make(chan struct{}, 1)}
 	s.volumeAttachmentWatcher.ch <- struct{}{}
+	s.filesystemAttachmentWatcher.ch <- struct{}{}
+	s.filesystemWatcher.ch <- struct{}{}
 	s.blockDevicesWatcher.ch <- struct{}{}
 	s.storageAttachmentWatcher.ch <- struct{}{}
 	s.st = &fakeStorage{
@@ -185,9 +228,18 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		storageInstanceVolume: func(tag names.StorageTag) (state.Volume, error) {
 			return s.volume, nil
 		},
+		storageInstanceFilesystem: func(tag names.StorageTag) (state.Filesystem, error) {
+			return &fakeFilesystem{tag: names.NewFilesystemTag("0")}, nil
+		},
 		watchVolumeAttachment: func(names.MachineTag, names.VolumeTag) state.NotifyWatcher {
 			return s.volumeAttachmentWatcher
 		},
+		watchFilesystemAttachment: func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher {
+			return s.filesystemAttachmentWatcher
+		},
+		watchFilesystem: func(names.FilesystemTag) state.NotifyWatcher {
+			return s.filesystemWatcher
+		},
 		watchBlockDevices: func(names.MachineTag) state.NotifyWatcher {
 			return s.blockDevicesWatcher
 		},
@@ -226,6 +278,30 @@ func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, c
 	)
 }
 
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentFilesystemAttachmentChanges(c *gc.C) {
+	s.testWatchFilesystemStorageAttachment(c, func() {
+		s.filesystemAttachmentWatcher.ch <- struct{}{}
+	})
+}
+
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentFilesystemChanges(c *gc.C) {
+	s.testWatchFilesystemStorageAttachment(c, func() {
+		s.filesystemWatcher.ch <- struct{}{}
+	})
+}
+
+func (s *watchStorageAttachmentSuite) testWatchFilesystemStorageAttachment(c *gc.C, change func()) {
+	s.storageInstance.kind = state.StorageKindFilesystem
+	s.testWatchStorageAttachment(c, change)
+	s.st.CheckCallNames(c,
+		"StorageInstance",
+		"StorageInstanceFilesystem",
+		"WatchFilesystemAttachment",
+		"WatchFilesystem",
+		"WatchStorageAttachment",
+	)
+}
+
 func (s *watchStorageAttachmentSuite) testWatchStorageAttachment(c *gc.C, change func()) {
 	w, err := storagecommon.WatchStorageAttachment(
 		s.st,
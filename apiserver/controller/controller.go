@@ -33,6 +33,7 @@ type Controller interface {
 	RemoveBlocks(args params.RemoveBlocksArgs) error
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
+	ModelLeadership(args params.Entities) (params.ModelLeadershipResults, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
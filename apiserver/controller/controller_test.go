@@ -267,3 +267,33 @@ func (s *controllerSuite) TestModelStatus(c *gc.C) {
 		Life:               params.Alive,
 	}})
 }
+
+func (s *controllerSuite) TestModelLeadership(c *gc.C) {
+	before := time.Now()
+	err := s.State.LeadershipClaimer().ClaimLeadership("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("blog", "blog/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelTag := s.State.ModelTag().String()
+	results, err := s.controller.ModelLeadership(params.Entities{
+		Entities: []params.Entity{{Tag: modelTag}, {Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+
+	result := results.Results[0]
+	c.Check(result.ModelTag, gc.Equals, modelTag)
+	c.Check(result.Error, gc.IsNil)
+	c.Assert(result.Leases, gc.HasLen, 2)
+	c.Check(result.Leases[0].Service, gc.Equals, "blog")
+	c.Check(result.Leases[0].Holder, gc.Equals, "blog/1")
+	c.Check(result.Leases[1].Service, gc.Equals, "mysql")
+	c.Check(result.Leases[1].Holder, gc.Equals, "mysql/0")
+	c.Assert(result.Leases[1].Claimed, gc.NotNil)
+	c.Check(result.Leases[1].Claimed.Before(before), jc.IsFalse)
+	c.Check(result.Leases[1].Expiry.Sub(*result.Leases[1].Claimed), gc.Equals, time.Minute)
+	c.Check(result.Writers, gc.Not(gc.HasLen), 0)
+
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/lease"
+)
+
+// ModelLeadership returns this controller's view of the service leadership
+// leases in each of the supplied models, along with what it knows about
+// the clocks of the controllers that wrote them. It is intended to help
+// diagnose disagreements about which unit is leader.
+func (c *ControllerAPI) ModelLeadership(args params.Entities) (params.ModelLeadershipResults, error) {
+	results := params.ModelLeadershipResults{
+		Results: make([]params.ModelLeadershipResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		result, err := c.modelLeadership(entity.Tag)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		result.ModelTag = entity.Tag
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) modelLeadership(tag string) (params.ModelLeadershipResult, error) {
+	var result params.ModelLeadershipResult
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	st, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer st.Close()
+
+	snapshot, err := st.LeadershipLeases()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for service, detail := range snapshot.Leases {
+		result.Leases = append(result.Leases, leadershipLease(service, detail))
+	}
+	sort.Sort(orderedLeadershipLeases(result.Leases))
+	for writer, skew := range snapshot.Skews {
+		result.Writers = append(result.Writers, leaseWriter(writer, skew))
+	}
+	sort.Sort(orderedLeaseWriters(result.Writers))
+	return result, nil
+}
+
+func leadershipLease(service string, detail lease.Detail) params.LeadershipLease {
+	result := params.LeadershipLease{
+		Service:        service,
+		Holder:         detail.Holder,
+		Writer:         detail.Writer,
+		Expiry:         detail.Expiry,
+		EarliestExpiry: detail.EarliestExpiry,
+		LatestExpiry:   detail.LatestExpiry,
+	}
+	if !detail.Claimed.IsZero() {
+		claimed := detail.Claimed
+		result.Claimed = &claimed
+	}
+	return result
+}
+
+func leaseWriter(writer string, skew lease.Skew) params.LeaseWriter {
+	result := params.LeaseWriter{Writer: writer}
+	if skew != (lease.Skew{}) {
+		result.LastWrite = &skew.LastWrite
+		result.ReadBeginning = &skew.Beginning
+		result.ReadEnd = &skew.End
+	}
+	return result
+}
+
+type orderedLeadershipLeases []params.LeadershipLease
+
+func (o orderedLeadershipLeases) Len() int           { return len(o) }
+func (o orderedLeadershipLeases) Less(i, j int) bool { return o[i].Service < o[j].Service }
+func (o orderedLeadershipLeases) Swap(i, j int)      { o[i], o[j] = o[j], o[i] }
+
+type orderedLeaseWriters []params.LeaseWriter
+
+func (o orderedLeaseWriters) Len() int           { return len(o) }
+func (o orderedLeaseWriters) Less(i, j int) bool { return o[i].Writer < o[j].Writer }
+func (o orderedLeaseWriters) Swap(i, j int)      { o[i], o[j] = o[j], o[i] }
@@ -0,0 +1,212 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodel provides the API facade with which services are
+// offered by one model and consumed by another on the same controller.
+package crossmodel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("CrossModel", 1, NewCrossModelAPI)
+}
+
+// CrossModelAPI provides access to the CrossModel API facade.
+type CrossModelAPI struct {
+	st    *state.State
+	user  names.UserTag
+	check *common.BlockChecker
+}
+
+// NewCrossModelAPI creates a new server-side CrossModel API facade.
+func NewCrossModelAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*CrossModelAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	user, ok := authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return nil, common.ErrPerm
+	}
+	return &CrossModelAPI{
+		st:    st,
+		user:  user,
+		check: common.NewBlockChecker(st),
+	}, nil
+}
+
+// Offer makes the supplied services available for consumption by other
+// models.
+func (api *CrossModelAPI) Offer(args params.AddOffers) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Offers)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Offers {
+		err := api.offer(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *CrossModelAPI) offer(arg params.AddOffer) error {
+	users := make([]names.UserTag, len(arg.UserTags))
+	for i, userTag := range arg.UserTags {
+		user, err := names.ParseUserTag(userTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		users[i] = user
+	}
+	_, err := api.st.AddOffer(state.AddOfferArgs{
+		Name:        arg.Name,
+		ServiceName: arg.ServiceName,
+		Endpoints:   arg.Endpoints,
+		Users:       users,
+	})
+	return errors.Trace(err)
+}
+
+// ListOffers returns the offers made by the model.
+func (api *CrossModelAPI) ListOffers() (params.ListOffersResults, error) {
+	var result params.ListOffersResults
+	model, err := api.st.Model()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	offers, err := api.st.AllOffers()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, offer := range offers {
+		eps, err := offer.Endpoints()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		details := params.OfferDetails{
+			URL:         offerURL(model, offer.Name()),
+			Name:        offer.Name(),
+			ServiceName: offer.ServiceName(),
+		}
+		for _, ep := range eps {
+			details.Endpoints = append(details.Endpoints, ep.Name)
+		}
+		for _, user := range offer.Users() {
+			details.UserTags = append(details.UserTags, user.String())
+		}
+		result.Offers = append(result.Offers, details)
+	}
+	return result, nil
+}
+
+// Consume adds remote services to the model for the supplied offers, made
+// by other models on the controller. The name of each remote service is
+// returned.
+func (api *CrossModelAPI) Consume(args params.ConsumeOfferArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		name, err := api.consume(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = name
+	}
+	return result, nil
+}
+
+func (api *CrossModelAPI) consume(arg params.ConsumeOfferArg) (string, error) {
+	owner, modelName, offerName, err := parseOfferURL(arg.OfferURL)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if owner == "" {
+		owner = api.user.Canonical()
+	}
+	model, err := api.findModel(names.NewUserTag(owner), modelName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	offerSt, err := api.st.ForModel(model.ModelTag())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer offerSt.Close()
+
+	// Users that may not consume an offer do not learn whether it exists.
+	offer, err := offerSt.Offer(offerName)
+	if errors.IsNotFound(err) {
+		return "", common.ErrPerm
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	rsvc, err := api.st.ConsumeOffer(state.ConsumeOfferArgs{
+		Offer: offer,
+		Name:  arg.ServiceName,
+		User:  api.user,
+	})
+	if errors.IsUnauthorized(err) {
+		return "", common.ErrPerm
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return rsvc.Name(), nil
+}
+
+// findModel returns the model with the supplied owner and name; if there
+// is no such model, common.ErrPerm is returned.
+func (api *CrossModelAPI) findModel(owner names.UserTag, name string) (*state.Model, error) {
+	models, err := api.st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, model := range models {
+		if model.Name() == name && model.Owner().Canonical() == owner.Canonical() {
+			return model, nil
+		}
+	}
+	return nil, common.ErrPerm
+}
+
+// offerURL returns the URL with which consumers identify the named offer
+// made by the supplied model.
+func offerURL(model *state.Model, offerName string) string {
+	return fmt.Sprintf("%s/%s.%s", model.Owner().Canonical(), model.Name(), offerName)
+}
+
+// parseOfferURL splits an offer URL of the form [<owner>/]<model>.<offer>
+// into its parts; owner is empty if not specified.
+func parseOfferURL(url string) (owner, modelName, offerName string, err error) {
+	rest := url
+	if i := strings.Index(rest, "/"); i >= 0 {
+		owner, rest = rest[:i], rest[i+1:]
+		if !names.IsValidUser(owner) {
+			return "", "", "", errors.NotValidf("offer URL %q", url)
+		}
+	}
+	i := strings.LastIndex(rest, ".")
+	if i <= 0 || i == len(rest)-1 {
+		return "", "", "", errors.NotValidf("offer URL %q", url)
+	}
+	return owner, rest[:i], rest[i+1:], nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	commontesting "github.com/juju/juju/apiserver/common/testing"
+	"github.com/juju/juju/apiserver/crossmodel"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type crossModelSuite struct {
+	jujutesting.JujuConnSuite
+	commontesting.BlockHelper
+
+	authorizer apiservertesting.FakeAuthorizer
+	api        *crossmodel.CrossModelAPI
+
+	offerState *state.State
+}
+
+var _ = gc.Suite(&crossModelSuite{})
+
+func (s *crossModelSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.BlockHelper = commontesting.NewBlockHelper(s.APIState)
+	s.AddCleanup(func(*gc.C) { s.BlockHelper.Close() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.api, err = crossmodel.NewCrossModelAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.offerState = s.Factory.MakeModel(c, &factory.ModelParams{Name: "dbs"})
+	s.AddCleanup(func(*gc.C) { s.offerState.Close() })
+	f := factory.NewFactory(s.offerState)
+	f.MakeService(c, &factory.ServiceParams{Name: "mysql"})
+}
+
+func (s *crossModelSuite) TestNewAPIRequiresClient(c *gc.C) {
+	anAuthorizer := s.authorizer
+	anAuthorizer.Tag = names.NewMachineTag("1")
+	_, err := crossmodel.NewCrossModelAPI(s.State, nil, anAuthorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *crossModelSuite) offerAPI(c *gc.C, tag names.UserTag) *crossmodel.CrossModelAPI {
+	authorizer := s.authorizer
+	authorizer.Tag = tag
+	api, err := crossmodel.NewCrossModelAPI(s.offerState, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *crossModelSuite) TestOfferAndList(c *gc.C) {
+	api := s.offerAPI(c, s.AdminUserTag(c))
+	results, err := api.Offer(params.AddOffers{Offers: []params.AddOffer{{
+		ServiceName: "mysql",
+		UserTags:    []string{"user-bob"},
+	}, {
+		Name:        "db",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+	}, {
+		ServiceName: "nope",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `cannot add offer "nope": service "nope" not found`)
+
+	list, err := api.ListOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	owner := s.AdminUserTag(c).Canonical()
+	c.Assert(list.Offers, jc.DeepEquals, []params.OfferDetails{{
+		URL:         owner + "/dbs.db",
+		Name:        "db",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+	}, {
+		URL:         owner + "/dbs.mysql",
+		Name:        "mysql",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+		UserTags:    []string{"user-bob@local"},
+	}})
+}
+
+func (s *crossModelSuite) TestConsume(c *gc.C) {
+	_, err := s.offerState.AddOffer(state.AddOfferArgs{Name: "db", ServiceName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.Consume(params.ConsumeOfferArgs{Args: []params.ConsumeOfferArg{{
+		OfferURL: "dbs.db",
+	}, {
+		OfferURL:    s.AdminUserTag(c).Canonical() + "/dbs.db",
+		ServiceName: "shared-db",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StringResult{
+		{Result: "db"},
+		{Result: "shared-db"},
+	})
+
+	rsvc, err := s.State.RemoteService("shared-db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rsvc.SourceModel(), gc.Equals, s.offerState.ModelTag())
+	c.Check(rsvc.SourceService(), gc.Equals, "mysql")
+	c.Check(rsvc.OfferName(), gc.Equals, "db")
+}
+
+func (s *crossModelSuite) TestConsumeNotFound(c *gc.C) {
+	results, err := s.api.Consume(params.ConsumeOfferArgs{Args: []params.ConsumeOfferArg{{
+		OfferURL: "dbs.db",
+	}, {
+		OfferURL: "nope.db",
+	}, {
+		OfferURL: "db",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `offer URL "db" not valid`)
+}
+
+func (s *crossModelSuite) TestConsumeUnauthorized(c *gc.C) {
+	_, err := s.offerState.AddOffer(state.AddOfferArgs{
+		Name:        "db",
+		ServiceName: "mysql",
+		Users:       []names.UserTag{names.NewUserTag("bob")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	owner := s.AdminUserTag(c).Canonical()
+	for _, user := range []string{"mary", "bob"} {
+		s.Factory.MakeUser(c, &factory.UserParams{Name: user})
+		s.authorizer.Tag = names.NewUserTag(user)
+		api, err := crossmodel.NewCrossModelAPI(s.State, nil, s.authorizer)
+		c.Assert(err, jc.ErrorIsNil)
+		results, err := api.Consume(params.ConsumeOfferArgs{Args: []params.ConsumeOfferArg{{
+			OfferURL:    owner + "/dbs.db",
+			ServiceName: user + "-db",
+		}}})
+		c.Assert(err, jc.ErrorIsNil)
+		if user == "mary" {
+			c.Check(results.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
+		} else {
+			c.Check(results.Results[0].Error, gc.IsNil)
+			c.Check(results.Results[0].Result, gc.Equals, "bob-db")
+		}
+	}
+}
+
+func (s *crossModelSuite) TestConsumeBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "TestConsumeBlocked")
+	_, err := s.api.Consume(params.ConsumeOfferArgs{Args: []params.ConsumeOfferArg{{
+		OfferURL: "dbs.db",
+	}}})
+	s.AssertBlocked(c, err, "TestConsumeBlocked")
+}
+
+func (s *crossModelSuite) TestParseOfferURL(c *gc.C) {
+	for i, t := range []struct {
+		url       string
+		owner     string
+		modelName string
+		offerName string
+		err       string
+	}{{
+		url:       "dbs.db",
+		modelName: "dbs",
+		offerName: "db",
+	}, {
+		url:       "bob@local/my.dbs.db",
+		owner:     "bob@local",
+		modelName: "my.dbs",
+		offerName: "db",
+	}, {
+		url: "dbs",
+		err: `offer URL "dbs" not valid`,
+	}, {
+		url: "dbs.",
+		err: `offer URL "dbs." not valid`,
+	}, {
+		url: "/dbs.db",
+		err: `offer URL "/dbs.db" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, t.url)
+		owner, modelName, offerName, err := crossmodel.ParseOfferURL(t.url)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(owner, gc.Equals, t.owner)
+		c.Check(modelName, gc.Equals, t.modelName)
+		c.Check(offerName, gc.Equals, t.offerName)
+	}
+}
@@ -106,6 +106,9 @@ func stateBlockDeviceInfo(devices []storage.BlockDevice) []state.BlockDeviceInfo
 			dev.FilesystemType,
 			dev.InUse,
 			dev.MountPoint,
+			dev.UsedSize,
+			dev.VolumeGroup,
+			dev.FreeSize,
 		}
 	}
 	return result
@@ -17,6 +17,10 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 2, NewFirewallerAPI)
+
+	// Version 3 adds GetExposedCIDRs and WatchSubnets, otherwise
+	// compatible.
+	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -152,6 +156,21 @@ func (f *FirewallerAPI) watchOneEnvironOpenedPorts(tag names.Tag) (string, []str
 	return "", nil, watcher.EnsureErr(watch)
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to the
+// subnets of the model, including changes to the spaces they are in, so
+// that the source CIDRs of services exposed to spaces can be re-resolved.
+func (f *FirewallerAPI) WatchSubnets() (params.StringsWatchResult, error) {
+	watch := f.st.WatchSubnets()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: f.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
 // GetMachinePorts returns the port ranges opened on a machine for the
 // specified network as a map mapping port ranges to the tags of the
 // units that opened them.
@@ -239,7 +258,9 @@ func (f *FirewallerAPI) GetMachineActiveNetworks(args params.Entities) (params.S
 	return result, nil
 }
 
-// GetExposed returns the exposed flag value for each given service.
+// GetExposed returns the exposed flag value for each given service. A
+// service exposed only to spaces that hold no subnets is reported as not
+// exposed, since its ports may not be accessed from anywhere.
 func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, error) {
 	result := params.BoolResults{
 		Results: make([]params.BoolResult, len(args.Entities)),
@@ -256,13 +277,52 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 		}
 		service, err := f.getService(canAccess, tag)
 		if err == nil {
-			result.Results[i].Result = service.IsExposed()
+			result.Results[i].Result, err = isExposed(service)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GetExposedCIDRs returns the source CIDRs to which each given service
+// is exposed, including those of the subnets currently in the spaces it
+// is exposed to. An empty result means the service is exposed to anywhere.
+func (f *FirewallerAPI) GetExposedCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseServiceTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result, err = service.ExposedSourceCIDRs()
 		}
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result, nil
 }
 
+// isExposed returns whether the service's open ports may be accessed
+// from anywhere outside the model.
+func isExposed(service *state.Service) (bool, error) {
+	if !service.IsExposed() {
+		return false, nil
+	}
+	cidrs, err := service.ExposedSourceCIDRs()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cidrs == nil || len(cidrs) > 0, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -0,0 +1,205 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package firewallreport implements the API endpoint reporting the
+// differences between the ingress rules the firewaller wants applied,
+// as computed from state, and the rules the provider actually applies.
+package firewallreport
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("FirewallReport", 1, NewAPI)
+}
+
+// firewallReportState defines the state methods used by the API.
+type firewallReportState interface {
+	ModelConfig() (*config.Config, error)
+	AllMachines() ([]*state.Machine, error)
+	Service(name string) (*state.Service, error)
+}
+
+// API implements the FirewallReport facade.
+type API struct {
+	state firewallReportState
+}
+
+// NewAPI returns a new FirewallReport API facade.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{state: st}, nil
+}
+
+// machineRules holds the ingress rules wanted for a provisioned machine.
+type machineRules struct {
+	machine    *state.Machine
+	instanceId instance.Id
+	wanted     []network.IngressRule
+}
+
+// Report computes the ingress rules wanted by the firewaller for each
+// provisioned machine, or for the whole model in global firewall mode,
+// and compares them with the rules the provider reports as applied.
+// Nothing is changed.
+func (api *API) Report() (params.FirewallReportResult, error) {
+	cfg, err := api.state.ModelConfig()
+	if err != nil {
+		return params.FirewallReportResult{}, errors.Trace(err)
+	}
+	mode := cfg.FirewallMode()
+	result := params.FirewallReportResult{FirewallMode: mode}
+	if mode != config.FwInstance && mode != config.FwGlobal {
+		return result, nil
+	}
+	env, err := environs.New(cfg)
+	if err != nil {
+		return params.FirewallReportResult{}, errors.Annotate(err, "getting environ")
+	}
+	machines, err := api.wantedRules()
+	if err != nil {
+		return params.FirewallReportResult{}, errors.Trace(err)
+	}
+
+	if mode == config.FwGlobal {
+		var wanted []network.IngressRule
+		for _, m := range machines {
+			wanted = append(wanted, m.wanted...)
+		}
+		report := params.FirewallReport{}
+		actual, err := env.IngressRules()
+		if err != nil {
+			report.Error = common.ServerError(err)
+		}
+		fillReport(&report, wanted, actual)
+		result.Global = &report
+		return result, nil
+	}
+
+	result.Machines = make([]params.FirewallReport, len(machines))
+	for i, m := range machines {
+		report := params.FirewallReport{
+			MachineTag: m.machine.Tag().String(),
+			InstanceId: string(m.instanceId),
+		}
+		actual, err := instanceIngressRules(env, m.machine.Id(), m.instanceId)
+		if err != nil {
+			report.Error = common.ServerError(err)
+		}
+		fillReport(&report, m.wanted, actual)
+		result.Machines[i] = report
+	}
+	return result, nil
+}
+
+// wantedRules returns the ingress rules wanted for each provisioned
+// machine: the port ranges opened by units of exposed services, open
+// to the source CIDRs the services are exposed to. The rules are
+// computed just as the firewaller computes them.
+func (api *API) wantedRules() ([]machineRules, error) {
+	machines, err := api.state.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	services := make(map[string]*state.Service)
+	exposure := func(unitName string) (bool, []string, error) {
+		serviceName, err := names.UnitService(unitName)
+		if err != nil {
+			return false, nil, errors.Trace(err)
+		}
+		svc, ok := services[serviceName]
+		if !ok {
+			svc, err = api.state.Service(serviceName)
+			if err != nil {
+				return false, nil, errors.Trace(err)
+			}
+			services[serviceName] = svc
+		}
+		if !svc.IsExposed() {
+			return false, nil, nil
+		}
+		cidrs, err := svc.ExposedSourceCIDRs()
+		if err != nil {
+			return false, nil, errors.Trace(err)
+		}
+		return true, cidrs, nil
+	}
+	var results []machineRules
+	for _, m := range machines {
+		instanceId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		allPorts, err := m.AllPorts()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var wanted []network.IngressRule
+		for _, ports := range allPorts {
+			rules, err := network.ExposedIngressRules(ports.AllPortRanges(), exposure)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			wanted = append(wanted, rules...)
+		}
+		results = append(results, machineRules{
+			machine:    m,
+			instanceId: instanceId,
+			wanted:     wanted,
+		})
+	}
+	return results, nil
+}
+
+// instanceIngressRules returns the ingress rules the provider applies
+// to the instance of the machine with the given id.
+func instanceIngressRules(env environs.Environ, machineId string, instanceId instance.Id) ([]network.IngressRule, error) {
+	instances, err := env.Instances([]instance.Id{instanceId})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return instances[0].IngressRules(machineId)
+}
+
+// fillReport sets the wanted and actual rules of the report, along with
+// the rules that are wanted but not applied (missing) and applied but
+// not wanted (extraneous). The rules are normalised, so that rules a
+// provider groups differently from the firewaller still compare equal.
+func fillReport(report *params.FirewallReport, wanted, actual []network.IngressRule) {
+	wanted = network.NormaliseIngressRules(wanted)
+	actual = network.NormaliseIngressRules(actual)
+	report.Wanted = toParams(wanted)
+	report.Actual = toParams(actual)
+	if report.Error != nil {
+		// We can't tell what has drifted without the actual rules.
+		return
+	}
+	report.Missing = toParams(network.DiffIngressRules(wanted, actual))
+	report.Extraneous = toParams(network.DiffIngressRules(actual, wanted))
+}
+
+func toParams(rules []network.IngressRule) []params.IngressRule {
+	result := make([]params.IngressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = params.FromNetworkIngressRule(rule)
+	}
+	return result
+}
@@ -0,0 +1,235 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallreport_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/firewallreport"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/juju"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
+)
+
+type firewallReportBaseSuite struct {
+	jujutesting.JujuConnSuite
+
+	api *firewallreport.API
+	svc *state.Service
+}
+
+func (s *firewallReportBaseSuite) setUpTest(c *gc.C, firewallMode string) {
+	add := map[string]interface{}{"firewall-mode": firewallMode}
+	s.DummyConfig = dummy.SampleConfig().Merge(add).Delete("admin-secret", "ca-private-key")
+	s.JujuConnSuite.SetUpTest(c)
+
+	authorizer := apiservertesting.FakeAuthorizer{Tag: s.AdminUserTag(c)}
+	api, err := firewallreport.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+	s.svc = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "dummy"))
+}
+
+// addUnit adds a unit of the service, and starts an instance for the
+// machine it is assigned to.
+func (s *firewallReportBaseSuite) addUnit(c *gc.C) (*state.Unit, *state.Machine, instance.Instance) {
+	units, err := juju.AddUnits(s.State, s.svc, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := units[0].AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	inst, hc := jujutesting.AssertStartInstance(c, s.Environ, m.Id())
+	err = m.SetProvisioned(inst.Id(), "fake_nonce", hc)
+	c.Assert(err, jc.ErrorIsNil)
+	return units[0], m, inst
+}
+
+func ruleParams(rules ...network.IngressRule) []params.IngressRule {
+	result := make([]params.IngressRule, len(rules))
+	for i, rule := range rules {
+		result[i] = params.FromNetworkIngressRule(rule)
+	}
+	return result
+}
+
+type InstanceModeSuite struct {
+	firewallReportBaseSuite
+}
+
+var _ = gc.Suite(&InstanceModeSuite{})
+
+func (s *InstanceModeSuite) SetUpTest(c *gc.C) {
+	s.firewallReportBaseSuite.setUpTest(c, config.FwInstance)
+}
+
+func (s *InstanceModeSuite) TestNewAPIRequiresClient(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")}
+	_, err := firewallreport.NewAPI(s.State, nil, authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *InstanceModeSuite) TestReportNoMachines(c *gc.C) {
+	result, err := s.api.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FirewallReportResult{
+		FirewallMode: config.FwInstance,
+	})
+}
+
+func (s *InstanceModeSuite) TestReportDrift(c *gc.C) {
+	u, m, inst := s.addUnit(c)
+	err := s.svc.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The provider has port 80 open correctly, but has port 8080 open
+	// to anywhere when nothing wants it, and lacks port 443.
+	err = inst.OpenPorts(m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 8080, 8080),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.FirewallMode, gc.Equals, config.FwInstance)
+	c.Assert(result.Global, gc.IsNil)
+	c.Assert(result.Machines, jc.DeepEquals, []params.FirewallReport{{
+		MachineTag: m.Tag().String(),
+		InstanceId: string(inst.Id()),
+		Wanted: ruleParams(
+			network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+			network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8"),
+		),
+		Actual: ruleParams(
+			network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+			network.MustNewIngressRule("tcp", 8080, 8080),
+		),
+		Missing: ruleParams(
+			network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8"),
+		),
+		Extraneous: ruleParams(
+			network.MustNewIngressRule("tcp", 8080, 8080),
+		),
+	}})
+}
+
+func (s *InstanceModeSuite) TestReportMergedSourceCIDRs(c *gc.C) {
+	u, m, inst := s.addUnit(c)
+	err := s.svc.SetExposedTo([]string{"10.0.0.0/8", "192.168.0.0/16"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The provider merges the source CIDRs of the port range into a
+	// single rule, which allows just what the wanted rules do.
+	err = inst.OpenPorts(m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	split := ruleParams(
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+	)
+	c.Assert(result.Machines, jc.DeepEquals, []params.FirewallReport{{
+		MachineTag: m.Tag().String(),
+		InstanceId: string(inst.Id()),
+		Wanted:     split,
+		Actual:     split,
+		Missing:    []params.IngressRule{},
+		Extraneous: []params.IngressRule{},
+	}})
+}
+
+func (s *InstanceModeSuite) TestReportUnexposedService(c *gc.C) {
+	u, m, inst := s.addUnit(c)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines, jc.DeepEquals, []params.FirewallReport{{
+		MachineTag: m.Tag().String(),
+		InstanceId: string(inst.Id()),
+		Wanted:     []params.IngressRule{},
+		Actual:     []params.IngressRule{},
+		Missing:    []params.IngressRule{},
+		Extraneous: []params.IngressRule{},
+	}})
+}
+
+func (s *InstanceModeSuite) TestReportSkipsUnprovisionedMachines(c *gc.C) {
+	_, err := juju.AddUnits(s.State, s.svc, 1, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines, gc.HasLen, 0)
+}
+
+type GlobalModeSuite struct {
+	firewallReportBaseSuite
+}
+
+var _ = gc.Suite(&GlobalModeSuite{})
+
+func (s *GlobalModeSuite) SetUpTest(c *gc.C) {
+	s.firewallReportBaseSuite.setUpTest(c, config.FwGlobal)
+}
+
+func (s *GlobalModeSuite) TestReportDrift(c *gc.C) {
+	u1, _, _ := s.addUnit(c)
+	u2, _, _ := s.addUnit(c)
+	err := s.svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.OpenPort("udp", 53)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Environ.OpenPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("tcp", 22, 22),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.FirewallMode, gc.Equals, config.FwGlobal)
+	c.Assert(result.Machines, gc.HasLen, 0)
+	c.Assert(result.Global, jc.DeepEquals, &params.FirewallReport{
+		Wanted: ruleParams(
+			network.MustNewIngressRule("tcp", 80, 80),
+			network.MustNewIngressRule("udp", 53, 53),
+		),
+		Actual: ruleParams(
+			network.MustNewIngressRule("tcp", 22, 22),
+			network.MustNewIngressRule("tcp", 80, 80),
+		),
+		Missing: ruleParams(
+			network.MustNewIngressRule("udp", 53, 53),
+		),
+		Extraneous: ruleParams(
+			network.MustNewIngressRule("tcp", 22, 22),
+		),
+	})
+}
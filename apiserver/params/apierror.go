@@ -75,6 +75,7 @@ const (
 	CodeOperationBlocked          = "operation is blocked"
 	CodeLeadershipClaimDenied     = "leadership claim denied"
 	CodeLeaseClaimDenied          = "lease claim denied"
+	CodeLeaseNotHeld              = "lease not held"
 	CodeNotSupported              = "not supported"
 	CodeBadRequest                = "bad request"
 	CodeMethodNotAllowed          = "method not allowed"
@@ -190,6 +191,10 @@ func IsCodeLeaseClaimDenied(err error) bool {
 	return ErrCode(err) == CodeLeaseClaimDenied
 }
 
+func IsCodeLeaseNotHeld(err error) bool {
+	return ErrCode(err) == CodeLeaseNotHeld
+}
+
 func IsCodeNotSupported(err error) bool {
 	return ErrCode(err) == CodeNotSupported
 }
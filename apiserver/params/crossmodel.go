@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// AddOffers holds the services to offer for consumption by other models.
+type AddOffers struct {
+	Offers []AddOffer
+}
+
+// AddOffer describes a service to offer for consumption by other models.
+type AddOffer struct {
+	// Name is the name of the offer; it defaults to the service name.
+	Name string
+
+	// ServiceName is the name of the service to offer.
+	ServiceName string
+
+	// Endpoints holds the names of the endpoints that consumers may
+	// relate to. If empty, all of the service's endpoints are offered.
+	Endpoints []string
+
+	// UserTags holds the users, in addition to the model owner, that
+	// may consume the offer.
+	UserTags []string
+}
+
+// OfferDetails describes an offer made by a model.
+type OfferDetails struct {
+	// URL identifies the offer to consumers, as <owner>/<model>.<offer>.
+	URL         string
+	Name        string
+	ServiceName string
+	Endpoints   []string
+	UserTags    []string
+}
+
+// ListOffersResults holds the offers made by a model.
+type ListOffersResults struct {
+	Offers []OfferDetails
+}
+
+// ConsumeOfferArgs holds the offers to consume.
+type ConsumeOfferArgs struct {
+	Args []ConsumeOfferArg
+}
+
+// ConsumeOfferArg identifies an offer to consume, and the name by which
+// the offered service will be known in the consuming model.
+type ConsumeOfferArg struct {
+	// OfferURL identifies the offer, as [<owner>/]<model>.<offer>.
+	OfferURL string
+
+	// ServiceName is the name of the remote service to create; it
+	// defaults to the offer name.
+	ServiceName string
+}
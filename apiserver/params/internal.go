@@ -266,11 +266,12 @@ type RelationUnitsSettings struct {
 // RelationResult returns information about a single relation,
 // or an error.
 type RelationResult struct {
-	Error    *Error
-	Life     Life
-	Id       int
-	Key      string
-	Endpoint multiwatcher.Endpoint
+	Error     *Error
+	Life      Life
+	Suspended bool
+	Id        int
+	Key       string
+	Endpoint  multiwatcher.Endpoint
 }
 
 // RelationResults holds the result of an API call that returns
@@ -291,6 +292,32 @@ type EntitiesCharmURL struct {
 	Entities []EntityCharmURL
 }
 
+// EntityWorkloadVersion holds an entity's tag and the version of the
+// software it runs.
+type EntityWorkloadVersion struct {
+	Tag             string
+	WorkloadVersion string
+}
+
+// EntityWorkloadVersions holds the parameters for making a
+// SetWorkloadVersion API call.
+type EntityWorkloadVersions struct {
+	Entities []EntityWorkloadVersion
+}
+
+// EntityWorkloadInfo holds an entity's tag and changes to the
+// information about the software it runs. Empty values remove keys.
+type EntityWorkloadInfo struct {
+	Tag  string
+	Info map[string]string
+}
+
+// EntitiesWorkloadInfo holds the parameters for making a
+// SetWorkloadInfo API call.
+type EntitiesWorkloadInfo struct {
+	Entities []EntityWorkloadInfo
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
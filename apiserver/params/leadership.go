@@ -84,3 +84,27 @@ type MergeLeadershipSettingsParam struct {
 	// Settings are the Leadership settings you wish to merge in.
 	Settings Settings
 }
+
+// CharmLeases is a collection of charm leases, for making bulk claim,
+// release and check requests.
+type CharmLeases struct {
+	Leases []CharmLease
+}
+
+// CharmLease identifies a charm-defined named lease, and the unit on whose
+// behalf it is being claimed, released or checked.
+type CharmLease struct {
+
+	// UnitTag is the unit which is acting on the lease.
+	UnitTag string
+
+	// ScopeTag is the service or model within which the lease is named.
+	ScopeTag string
+
+	// Name is the charm-defined name of the lease.
+	Name string
+
+	// DurationSeconds is the number of seconds for which the lease is
+	// required. It is only used when claiming.
+	DurationSeconds float64
+}
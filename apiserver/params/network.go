@@ -223,6 +223,55 @@ func (pr PortRange) NetworkPortRange() network.PortRange {
 	}
 }
 
+// IngressRule represents a port range opened to a set of source CIDRs.
+// An empty set of source CIDRs means the port range is open to anywhere.
+type IngressRule struct {
+	PortRange   PortRange `json:"PortRange"`
+	SourceCIDRs []string  `json:"SourceCIDRs,omitempty"`
+}
+
+// FromNetworkIngressRule is a convenience helper to create a parameter
+// out of the network type, here for IngressRule.
+func FromNetworkIngressRule(rule network.IngressRule) IngressRule {
+	return IngressRule{
+		PortRange:   FromNetworkPortRange(rule.PortRange),
+		SourceCIDRs: rule.SourceCIDRs,
+	}
+}
+
+// NetworkIngressRule is a convenience helper to return the parameter
+// as network type, here for IngressRule.
+func (rule IngressRule) NetworkIngressRule() network.IngressRule {
+	return network.IngressRule{
+		PortRange:   rule.PortRange.NetworkPortRange(),
+		SourceCIDRs: rule.SourceCIDRs,
+	}
+}
+
+// FirewallReport holds the ingress rules the firewaller wants applied
+// to a machine, or to the whole model in global firewall mode, along
+// with the rules actually applied by the provider and the differences
+// between them. MachineTag and InstanceId are empty for the global
+// report.
+type FirewallReport struct {
+	MachineTag string        `json:"MachineTag,omitempty"`
+	InstanceId string        `json:"InstanceId,omitempty"`
+	Wanted     []IngressRule `json:"Wanted"`
+	Actual     []IngressRule `json:"Actual"`
+	Missing    []IngressRule `json:"Missing"`
+	Extraneous []IngressRule `json:"Extraneous"`
+	Error      *Error        `json:"Error,omitempty"`
+}
+
+// FirewallReportResult holds the result of a FirewallReport.Report call.
+// Global is set only when the model uses the global firewall mode, and
+// Machines only when it uses the instance firewall mode.
+type FirewallReportResult struct {
+	FirewallMode string           `json:"FirewallMode"`
+	Global       *FirewallReport  `json:"Global,omitempty"`
+	Machines     []FirewallReport `json:"Machines,omitempty"`
+}
+
 // EntityPort holds an entity's tag, a protocol and a port.
 type EntityPort struct {
 	Tag      string `json:"Tag"`
@@ -119,6 +119,47 @@ type DestroyRelation struct {
 	Endpoints []string
 }
 
+// SetRelationSuspended holds the parameters for making the
+// SetRelationSuspended call. The endpoints specified are unordered.
+type SetRelationSuspended struct {
+	Endpoints []string
+	Suspended bool
+}
+
+// ShowRelation holds the parameters for making the ShowRelation call.
+// The endpoints specified are unordered.
+type ShowRelation struct {
+	Endpoints []string
+}
+
+// ShowRelationResult holds the details of a relation returned by the
+// ShowRelation call.
+type ShowRelationResult struct {
+	Id        int
+	Key       string
+	Scope     charm.RelationScope
+	Status    string
+	Endpoints []RelationEndpointDetails
+	Units     []RelationUnitDetails
+}
+
+// RelationEndpointDetails describes one endpoint of a relation.
+type RelationEndpointDetails struct {
+	ServiceName string
+	Name        string
+	Role        charm.RelationRole
+	Interface   string
+}
+
+// RelationUnitDetails describes a unit in a relation's scope. Settings
+// holds the unit's relation settings, and is only set for administrators.
+type RelationUnitDetails struct {
+	UnitName  string
+	Endpoint  string
+	Departing bool
+	Settings  map[string]interface{}
+}
+
 // AddCharmWithAuthorization holds the arguments for making an AddCharmWithAuthorization API call.
 type AddCharmWithAuthorization struct {
 	URL                string
@@ -241,6 +282,31 @@ type ServiceSetCharm struct {
 // ServiceExpose holds the parameters for making the service Expose call.
 type ServiceExpose struct {
 	ServiceName string
+
+	// ExposeToCIDRs and ExposeToSpaces, if either is non-empty,
+	// restrict the exposure of the service to the given source CIDRs
+	// and to the CIDRs of the subnets in the given spaces.
+	ExposeToCIDRs  []string `json:",omitempty"`
+	ExposeToSpaces []string `json:",omitempty"`
+}
+
+// ServiceTransferLeadership holds the parameters for making the service
+// TransferLeadership call.
+type ServiceTransferLeadership struct {
+	ServiceName string
+	UnitName    string
+}
+
+// ServicePinLeadership holds the parameters for making the service
+// PinLeadership call.
+type ServicePinLeadership struct {
+	ServiceName string
+}
+
+// ServiceUnpinLeadership holds the parameters for making the service
+// UnpinLeadership call.
+type ServiceUnpinLeadership struct {
+	ServiceName string
 }
 
 // ServiceSet holds the parameters for a service Set
@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// SecretArgs holds the arguments for making bulk requests to add
+// revisions to, get the values of, or grant access to secrets.
+type SecretArgs struct {
+	Args []SecretArg
+}
+
+// SecretArg identifies a secret, and the unit on whose behalf it is being
+// acted on.
+type SecretArg struct {
+
+	// UnitTag is the unit which is acting on the secret.
+	UnitTag string
+
+	// OwnerTag is the service which owns the secret. Only units of the
+	// owning service may add revisions to the secret, or grant access
+	// to it.
+	OwnerTag string
+
+	// Name is the name of the secret within the owning service.
+	Name string
+
+	// Revision is the revision of the secret's values to get; zero
+	// denotes the latest. It is only used when getting values.
+	Revision int
+
+	// Values holds the values of a new revision. It is only used when
+	// adding revisions.
+	Values map[string]string
+
+	// RelationTag identifies the relation whose remote services are
+	// to be granted access to the secret. It is only used when granting.
+	RelationTag string
+}
+
+// SecretValuesResults holds the results of a bulk request for the values
+// of secrets.
+type SecretValuesResults struct {
+	Results []SecretValuesResult
+}
+
+// SecretValuesResult holds the values of one revision of a secret, or an
+// error.
+type SecretValuesResult struct {
+	Error    *Error
+	Revision int
+	Values   map[string]string
+}
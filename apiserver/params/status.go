@@ -18,6 +18,42 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string
+	Filter   StatusFilter
+}
+
+// StatusFilter holds criteria, evaluated by the controller, which
+// restrict the machines, services and units returned by FullStatus.
+// Each non-empty field must be satisfied for an entity to match;
+// values within a field are alternatives.
+type StatusFilter struct {
+	// WorkloadStatuses holds the unit workload statuses to match.
+	WorkloadStatuses []Status
+
+	// AgentStatuses holds the unit and machine agent statuses
+	// to match.
+	AgentStatuses []Status
+
+	// Series holds the machine series to match. Units match
+	// on the series of the machine they are assigned to.
+	Series []string
+
+	// Charms holds the charms to match, given as a charm name
+	// ("mysql"), a name and revision ("mysql-42") or a full
+	// charm URL.
+	Charms []string
+
+	// RelatedTo holds the names of services; only services
+	// related to one of them, and their units, match.
+	RelatedTo []string
+}
+
+// IsEmpty reports whether the filter has no criteria.
+func (f StatusFilter) IsEmpty() bool {
+	return len(f.WorkloadStatuses) == 0 &&
+		len(f.AgentStatuses) == 0 &&
+		len(f.Series) == 0 &&
+		len(f.Charms) == 0 &&
+		len(f.RelatedTo) == 0
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -30,6 +66,7 @@ type FullStatus struct {
 	Services         map[string]ServiceStatus
 	Networks         map[string]NetworkStatus
 	Relations        []RelationStatus
+	RemoteServices   map[string]RemoteServiceStatus
 }
 
 // MachineStatus holds status info about a machine.
@@ -63,6 +100,33 @@ type ServiceStatus struct {
 	Status        AgentStatus
 }
 
+// RemoteServiceStatus holds status info about a service in another
+// model on the same controller, that was either consumed from an offer
+// made by that model or consumes one of this model's offers.
+type RemoteServiceStatus struct {
+	Err error
+
+	// SourceModel names the model holding the service, as
+	// <owner>/<model>.
+	SourceModel   string
+	SourceService string
+
+	// OfferName is the name of the offer from which the service was
+	// consumed; it is empty if the service consumes an offer made by
+	// this model.
+	OfferName string
+	Endpoints []RemoteEndpoint
+	Life      string
+	Relations map[string][]string
+}
+
+// RemoteEndpoint describes a relation endpoint of a remote service.
+type RemoteEndpoint struct {
+	Name      string
+	Role      charm.RelationRole
+	Interface string
+}
+
 // MeterStatus represents the meter status of a unit.
 type MeterStatus struct {
 	Color   string
@@ -90,6 +154,11 @@ type UnitStatus struct {
 	PublicAddress string
 	Charm         string
 	Subordinates  map[string]UnitStatus
+
+	// WorkloadVersion and WorkloadInfo describe the software the
+	// unit runs, as reported by its charm.
+	WorkloadVersion string
+	WorkloadInfo    map[string]string
 }
 
 // TODO(ericsnow) Rename to ServiceNetworksSepcification.
@@ -172,19 +241,58 @@ type UnitStatusHistory struct {
 }
 
 const (
-	// DefaultMaxLogsPerEntity is the default value for logs for each entity
-	// that should be kept at any given time.
-	DefaultMaxLogsPerEntity = 100
-
 	// DefaultPruneInterval is the default interval that should be waited
 	// between prune calls.
 	DefaultPruneInterval = 5 * time.Minute
 )
 
+// ModelStatusHistoryArgs holds the parameters of a query for the
+// status history of the entities in a model.
+type ModelStatusHistoryArgs struct {
+	// From and To, if set, bound the times of the returned entries.
+	From *time.Time
+	To   *time.Time
+
+	// Entities holds the tags of the machines, services and units
+	// of interest. If empty, all entities are included.
+	Entities []string
+
+	// Size, if positive, limits the result to the most recent
+	// Size entries.
+	Size int
+}
+
+// HistoricalStatus holds a status recorded for an entity.
+type HistoricalStatus struct {
+	Entity string
+	Kind   HistoryKind
+	Status Status
+	Info   string
+	Data   map[string]interface{}
+	Since  *time.Time
+}
+
+// ModelStatusHistoryResult holds the status history of a model,
+// ordered from oldest to newest.
+type ModelStatusHistoryResult struct {
+	Statuses []HistoricalStatus
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
+	// MaxLogsPerEntity, if positive, is the number of entries
+	// kept for each entity.
 	MaxLogsPerEntity int
+
+	// MaxHistoryTime is the maximum age of the entries kept. If
+	// not positive, the model's configured limit is used.
+	MaxHistoryTime time.Duration
+
+	// MaxHistoryMB is the maximum total size of the entries kept
+	// for the model. If not positive, the model's configured limit
+	// is used.
+	MaxHistoryMB int
 }
 
 // StatusResult holds an entity status, extra information, or an
@@ -227,6 +335,10 @@ const (
 	KindAgent HistoryKind = "agent"
 	// KindWorkload represents a charm workload status history entry.
 	KindWorkload HistoryKind = "workload"
+	// KindMachine represents a machine status history entry.
+	KindMachine HistoryKind = "machine"
+	// KindService represents a service status history entry.
+	KindService HistoryKind = "service"
 )
 
 // Life describes the lifecycle state of an entity ("alive", "dying" or "dead").
@@ -370,3 +482,44 @@ const (
 	// StatusDestroying indicates that the storage is being destroyed.
 	StatusDestroying Status = "destroying"
 )
+
+// HookRecord describes a single operation executed by a unit agent,
+// such as running a hook or an action.
+type HookRecord struct {
+	Operation  string
+	Hook       string
+	RelationId int
+	RemoteUnit string
+	Started    time.Time
+	Duration   time.Duration
+	ExitCode   int
+	Stderr     string
+	Error      string
+}
+
+// UnitHookRecords holds hook records to add for a unit.
+type UnitHookRecords struct {
+	Tag     string
+	Records []HookRecord
+}
+
+// HookRecordsArgs holds hook records to add for a number of units.
+type HookRecordsArgs struct {
+	Units []UnitHookRecords
+}
+
+// HookHistoryArgs holds the parameters of a query for the hook
+// history of a unit.
+type HookHistoryArgs struct {
+	// Unit is the tag of the unit.
+	Unit string
+
+	// Size is the number of most recent records to return.
+	Size int
+}
+
+// HookHistoryResult holds the hook history of a unit, ordered from
+// newest to oldest.
+type HookHistoryResult struct {
+	Records []HookRecord
+}
@@ -94,6 +94,10 @@ type StorageAttachment struct {
 	Kind     StorageKind
 	Location string
 	Life     Life
+
+	// Size is the size of the attached storage, in MiB,
+	// or zero if it is not yet known.
+	Size uint64
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -108,6 +112,16 @@ type StorageAttachmentIds struct {
 	Ids []StorageAttachmentId `json:"ids"`
 }
 
+// StorageDetachmentParams holds the parameters for detaching storage
+// instances from the units that own them.
+type StorageDetachmentParams struct {
+	Storages Entities `json:"storages"`
+
+	// Force causes the storage attachments to be removed without
+	// waiting for the units' agents to run storage-detaching hooks.
+	Force bool `json:"force,omitempty"`
+}
+
 // StorageAttachmentIdsResult holds the result of an API call to retrieve the
 // IDs of a unit's attached storage instances.
 type StorageAttachmentIdsResult struct {
@@ -210,6 +224,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshotid,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
@@ -668,3 +683,295 @@ type StorageAddParams struct {
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// VolumeSnapshot identifies and describes a volume snapshot.
+type VolumeSnapshot struct {
+	Id        string             `json:"id"`
+	VolumeTag string             `json:"volumetag"`
+	Info      VolumeSnapshotInfo `json:"info"`
+}
+
+// VolumeSnapshotInfo describes a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `json:"snapshotid"`
+	Size       uint64 `json:"size"`
+}
+
+// VolumeSnapshots describes a set of volume snapshots.
+type VolumeSnapshots struct {
+	VolumeSnapshots []VolumeSnapshot `json:"volumesnapshots"`
+}
+
+// VolumeSnapshotIds holds a set of volume snapshot IDs.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotError records an error that occurred when creating
+// a volume snapshot.
+type VolumeSnapshotError struct {
+	Id      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// VolumeSnapshotErrors holds a set of volume snapshot errors.
+type VolumeSnapshotErrors struct {
+	Errors []VolumeSnapshotError `json:"errors"`
+}
+
+// VolumeSnapshotParams holds the parameters for creating or
+// destroying a volume snapshot.
+type VolumeSnapshotParams struct {
+	Id         string                 `json:"id"`
+	VolumeTag  string                 `json:"volumetag"`
+	VolumeId   string                 `json:"volumeid"`
+	Size       uint64                 `json:"size"`
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Life       Life                   `json:"life"`
+
+	// Info is set if the snapshot has already been created,
+	// and is required for destroying the snapshot.
+	Info *VolumeSnapshotInfo `json:"info,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds provisioning parameters for a
+// volume snapshot.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds provisioning parameters for
+// multiple volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotDetails describes a volume snapshot for the purpose
+// of volume snapshot CLI commands.
+type VolumeSnapshotDetails struct {
+	// Id is the ID of the snapshot.
+	Id string `json:"id"`
+
+	// VolumeTag is the tag of the volume that the snapshot
+	// was taken from.
+	VolumeTag string `json:"volumetag"`
+
+	// StorageTag is the tag of the storage instance that the
+	// volume was assigned to, if any.
+	StorageTag string `json:"storagetag,omitempty"`
+
+	// Pool is the name of the storage pool of the snapshot's volume.
+	Pool string `json:"pool"`
+
+	// Life is the lifecycle state of the snapshot.
+	Life Life `json:"life"`
+
+	// Info contains information about the snapshot, if it has
+	// been created by the storage provider.
+	Info *VolumeSnapshotInfo `json:"info,omitempty"`
+
+	// Error contains the error, if any, that occurred when the
+	// storage provider last attempted to create the snapshot.
+	Error string `json:"error,omitempty"`
+}
+
+// VolumeSnapshotFilter holds a filter for listing volume snapshots.
+// An empty filter matches all volume snapshots.
+type VolumeSnapshotFilter struct {
+	// VolumeTags holds the tags of the volumes whose
+	// snapshots should be listed.
+	VolumeTags []string `json:"volumetags,omitempty"`
+}
+
+// VolumeSnapshotFilters holds a collection of volume snapshot filters.
+type VolumeSnapshotFilters struct {
+	Filters []VolumeSnapshotFilter `json:"filters,omitempty"`
+}
+
+// VolumeSnapshotDetailsListResult holds a collection of volume
+// snapshot details.
+type VolumeSnapshotDetailsListResult struct {
+	Result []VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDetailsListResults holds a collection of collections
+// of volume snapshot details.
+type VolumeSnapshotDetailsListResults struct {
+	Results []VolumeSnapshotDetailsListResult `json:"results,omitempty"`
+}
+
+// RestoreVolumeSnapshotParams holds the parameters for restoring
+// a volume snapshot to a new volume.
+type RestoreVolumeSnapshotParams struct {
+	// Id is the ID of the snapshot to restore.
+	Id string `json:"id"`
+
+	// MachineTag is the tag of the machine to attach the new volume
+	// to. If empty, the volume is attached to the machine that the
+	// snapshot's volume is scoped to, or else attached to.
+	MachineTag string `json:"machinetag,omitempty"`
+}
+
+// RestoreVolumeSnapshotsParams holds the parameters for restoring
+// a set of volume snapshots.
+type RestoreVolumeSnapshotsParams struct {
+	Snapshots []RestoreVolumeSnapshotParams `json:"snapshots"`
+}
+
+// VolumeResizeParams holds the parameters for growing a volume.
+type VolumeResizeParams struct {
+	VolumeTag  string                 `json:"volumetag"`
+	VolumeId   string                 `json:"volumeid"`
+	Size       uint64                 `json:"size"`
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// VolumeResizeParamsResult holds resize parameters for a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resize parameters for multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// FilesystemResizeParams holds the parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	FilesystemTag string                 `json:"filesystemtag"`
+	VolumeTag     string                 `json:"volumetag,omitempty"`
+	FilesystemId  string                 `json:"filesystemid"`
+	Size          uint64                 `json:"size"`
+	Provider      string                 `json:"provider"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// FilesystemResizeParamsResult holds resize parameters for a filesystem.
+type FilesystemResizeParamsResult struct {
+	Result FilesystemResizeParams `json:"result"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// FilesystemResizeParamsResults holds resize parameters for multiple
+// filesystems.
+type FilesystemResizeParamsResults struct {
+	Results []FilesystemResizeParamsResult `json:"results,omitempty"`
+}
+
+// StorageSize records the size, in MiB, of a volume or filesystem.
+type StorageSize struct {
+	Tag  string `json:"tag"`
+	Size uint64 `json:"size"`
+}
+
+// StorageSizes holds a set of volume or filesystem sizes.
+type StorageSizes struct {
+	Sizes []StorageSize `json:"sizes"`
+}
+
+// StorageResizeParams holds the parameters for growing a storage
+// instance.
+type StorageResizeParams struct {
+	// StorageTag is the tag of the storage instance to grow.
+	StorageTag string `json:"storagetag"`
+
+	// Size is the new size of the storage instance, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StoragesResizeParams holds the parameters for growing a set of
+// storage instances.
+type StoragesResizeParams struct {
+	Storages []StorageResizeParams `json:"storages"`
+}
+
+// StoragePoolUsage describes the capacity allocated from a storage
+// pool, and how much of it is in use.
+type StoragePoolUsage struct {
+	// Name is the pool's name.
+	Name string `json:"name"`
+
+	// Provider is the type of storage provider the pool represents.
+	Provider string `json:"provider,omitempty"`
+
+	// Volumes is the number of volumes in the pool.
+	Volumes int `json:"volumes"`
+
+	// Filesystems is the number of filesystems in the pool.
+	Filesystems int `json:"filesystems"`
+
+	// Allocated is the total size, in MiB, of the provisioned volumes
+	// and filesystems in the pool.
+	Allocated uint64 `json:"allocated"`
+
+	// Used is the total space, in MiB, used by filesystems in the
+	// pool, as reported by the machines they are mounted on.
+	Used uint64 `json:"used"`
+
+	// Available is the capacity, in MiB, from which new volumes and
+	// filesystems may be allocated in the pool, if known. For lvm
+	// pools, this is the free space in the pool's volume group on
+	// all machines.
+	Available *uint64 `json:"available,omitempty"`
+}
+
+// MachineStorageUsage describes the storage capacity allocated to
+// a machine, and the capacity of the machine's unused disks.
+type MachineStorageUsage struct {
+	// MachineTag is the tag of the machine.
+	MachineTag string `json:"machinetag"`
+
+	// Volumes is the number of volumes attached to the machine.
+	Volumes int `json:"volumes"`
+
+	// Filesystems is the number of filesystems attached to the machine.
+	Filesystems int `json:"filesystems"`
+
+	// Allocated is the total size, in MiB, of the volumes and
+	// filesystems attached to the machine.
+	Allocated uint64 `json:"allocated"`
+
+	// Used is the total space, in MiB, used by filesystems mounted
+	// on the machine.
+	Used uint64 `json:"used"`
+
+	// Available is the total size, in MiB, of the block devices on
+	// the machine that are unused, unformatted and not backing any
+	// volume.
+	Available uint64 `json:"available"`
+}
+
+// UnitStorageUsage describes the size and usage of a storage instance
+// attached to a unit.
+type UnitStorageUsage struct {
+	// UnitTag is the tag of the unit that the storage is attached to.
+	UnitTag string `json:"unittag"`
+
+	// StorageTag is the tag of the storage instance.
+	StorageTag string `json:"storagetag"`
+
+	// Pool is the name of the storage pool of the storage instance's
+	// volume or filesystem.
+	Pool string `json:"pool,omitempty"`
+
+	// Size is the size, in MiB, of the storage instance's volume or
+	// filesystem, if it has been provisioned.
+	Size uint64 `json:"size"`
+
+	// Used is the space, in MiB, used by the storage instance's
+	// filesystem, if known.
+	Used *uint64 `json:"used,omitempty"`
+}
+
+// StorageUsage describes the storage capacity and usage in a model,
+// per storage pool, per machine, and per unit.
+type StorageUsage struct {
+	Pools    []StoragePoolUsage    `json:"pools"`
+	Machines []MachineStorageUsage `json:"machines"`
+	Units    []UnitStorageUsage    `json:"units"`
+}
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -46,3 +48,43 @@ type ModelStatus struct {
 type ModelStatusResults struct {
 	Results []ModelStatus `json:"models"`
 }
+
+// ModelLeadershipResults holds the service leadership leases for a group
+// of models.
+type ModelLeadershipResults struct {
+	Results []ModelLeadershipResult `json:"results"`
+}
+
+// ModelLeadershipResult holds a controller's view of the service
+// leadership leases in a model.
+type ModelLeadershipResult struct {
+	ModelTag string            `json:"model-tag"`
+	Leases   []LeadershipLease `json:"leases,omitempty"`
+	Writers  []LeaseWriter     `json:"writers,omitempty"`
+	Error    *Error            `json:"error,omitempty"`
+}
+
+// LeadershipLease holds the details of a service's leadership lease.
+// Expiry and Claimed are expressed according to the writer's clock;
+// EarliestExpiry and LatestExpiry according to the controller's.
+type LeadershipLease struct {
+	Service        string     `json:"service"`
+	Holder         string     `json:"holder"`
+	Writer         string     `json:"writer"`
+	Expiry         time.Time  `json:"expiry"`
+	EarliestExpiry time.Time  `json:"earliest-expiry"`
+	LatestExpiry   time.Time  `json:"latest-expiry"`
+	Claimed        *time.Time `json:"claimed,omitempty"`
+}
+
+// LeaseWriter holds what a controller knows about the clock of a client
+// that has written leases. LastWrite is the latest time the writer is
+// known to have written, according to its own clock; ReadBeginning and
+// ReadEnd bound the controller's time when it read that value. All are
+// omitted for the controller itself.
+type LeaseWriter struct {
+	Writer        string     `json:"writer"`
+	LastWrite     *time.Time `json:"last-write,omitempty"`
+	ReadBeginning *time.Time `json:"read-beginning,omitempty"`
+	ReadEnd       *time.Time `json:"read-end,omitempty"`
+}
@@ -44,11 +44,16 @@ var readOnlyCalls = set.NewStrings(
 	"Client.UnitStatusHistory",
 	"Client.WatchAll",
 	// TODO: add controller work.
+	"CrossModel.ListOffers",
+	"FirewallReport.Report",
 	"KeyManager.ListKeys",
 	"Service.GetConstraints",
 	"Service.CharmRelations",
 	"Service.Get",
+	"Service.ShowRelation",
 	"Spaces.ListSpaces",
+	"StatusHistory.HookHistory",
+	"StatusHistory.ModelStatusHistory",
 	"Storage.ListStorageDetails",
 	"Storage.ListFilesystems",
 	"Storage.ListPools",
@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations implements the API interface used by the
+// cross-model relations worker.
+package remoterelations
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("RemoteRelations", 1, NewRemoteRelationsAPI)
+}
+
+// RemoteRelationsAPI implements the API used by the cross-model
+// relations worker.
+type RemoteRelationsAPI struct {
+	st        StateInterface
+	resources *common.Resources
+}
+
+// NewRemoteRelationsAPI creates a new instance of the RemoteRelations API.
+func NewRemoteRelationsAPI(
+	st *state.State,
+	res *common.Resources,
+	authorizer common.Authorizer,
+) (*RemoteRelationsAPI, error) {
+	if !authorizer.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &RemoteRelationsAPI{
+		st:        getState(st),
+		resources: res,
+	}, nil
+}
+
+// SyncRemoteRelations syncs the model's cross-model relations with their
+// mirrors in the offering models.
+func (api *RemoteRelationsAPI) SyncRemoteRelations() error {
+	return api.st.SyncRemoteRelations()
+}
+
+// WatchRemoteRelations watches for changes that require the model's
+// cross-model relations to be synced.
+func (api *RemoteRelationsAPI) WatchRemoteRelations() (params.NotifyWatchResult, error) {
+	watch := api.st.WatchRemoteRelations()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
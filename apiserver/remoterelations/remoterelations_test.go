@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/remoterelations"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RemoteRelationsSuite struct {
+	coretesting.BaseSuite
+
+	st         *mockState
+	api        *remoterelations.RemoteRelationsAPI
+	authoriser apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&RemoteRelationsSuite{})
+
+func (s *RemoteRelationsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.authoriser = apiservertesting.FakeAuthorizer{
+		EnvironManager: true,
+	}
+	s.st = &mockState{&testing.Stub{}, false}
+	remoterelations.PatchState(s, s.st)
+	var err error
+	res := common.NewResources()
+	s.api, err = remoterelations.NewRemoteRelationsAPI(nil, res, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api, gc.NotNil)
+}
+
+func (s *RemoteRelationsSuite) TestNewRemoteRelationsAPIRequiresEnvironManager(c *gc.C) {
+	anAuthoriser := s.authoriser
+	anAuthoriser.EnvironManager = false
+	api, err := remoterelations.NewRemoteRelationsAPI(nil, nil, anAuthoriser)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *RemoteRelationsSuite) TestWatchRemoteRelationsSuccess(c *gc.C) {
+	result, err := s.api.WatchRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Not(gc.Equals), "")
+	s.st.CheckCallNames(c, "WatchRemoteRelations")
+}
+
+func (s *RemoteRelationsSuite) TestWatchRemoteRelationsFailure(c *gc.C) {
+	s.st.SetErrors(errors.New("boom!"))
+	s.st.watchFails = true
+
+	result, err := s.api.WatchRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error.Error(), gc.Equals, "boom!")
+	s.st.CheckCallNames(c, "WatchRemoteRelations")
+}
+
+func (s *RemoteRelationsSuite) TestSyncRemoteRelationsSuccess(c *gc.C) {
+	err := s.api.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.CheckCallNames(c, "SyncRemoteRelations")
+}
+
+func (s *RemoteRelationsSuite) TestSyncRemoteRelationsFailure(c *gc.C) {
+	s.st.SetErrors(errors.New("boom!"))
+	err := s.api.SyncRemoteRelations()
+	c.Assert(err, gc.ErrorMatches, "boom!")
+	s.st.CheckCallNames(c, "SyncRemoteRelations")
+}
+
+type mockState struct {
+	*testing.Stub
+	watchFails bool
+}
+
+type notifyWatcher struct {
+	out chan struct{}
+	st  *mockState
+}
+
+func (w *notifyWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *notifyWatcher) Stop() error {
+	return nil
+}
+
+func (w *notifyWatcher) Kill() {
+}
+
+func (w *notifyWatcher) Wait() error {
+	return nil
+}
+
+func (w *notifyWatcher) Err() error {
+	return w.st.NextErr()
+}
+
+func (st *mockState) WatchRemoteRelations() state.NotifyWatcher {
+	w := &notifyWatcher{
+		out: make(chan struct{}, 1),
+		st:  st,
+	}
+	if st.watchFails {
+		close(w.out)
+	} else {
+		w.out <- struct{}{}
+	}
+	st.MethodCall(st, "WatchRemoteRelations")
+	return w
+}
+
+func (st *mockState) SyncRemoteRelations() error {
+	st.MethodCall(st, "SyncRemoteRelations")
+	return st.NextErr()
+}
@@ -0,0 +1,19 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import "github.com/juju/juju/state"
+
+type StateInterface interface {
+	SyncRemoteRelations() error
+	WatchRemoteRelations() state.NotifyWatcher
+}
+
+type stateShim struct {
+	*state.State
+}
+
+var getState = func(st *state.State) StateInterface {
+	return stateShim{st}
+}
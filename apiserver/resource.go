@@ -7,14 +7,17 @@ package apiserver
 
 import (
 	"net/http"
+	"path/filepath"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"github.com/juju/utils/clock"
 
 	internalserver "github.com/juju/juju/resource/api/private/server"
 	"github.com/juju/juju/resource/api/server"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/state"
+	statestorage "github.com/juju/juju/state/storage"
 )
 
 type resourcesHandlerDeps struct {
@@ -45,6 +48,15 @@ func (deps resourcesHandlerDeps) ConnectForUnitAgent(req *http.Request) (*state.
 
 func newResourceHandler(httpCtxt httpContext) http.Handler {
 	deps := resourcesHandlerDeps{httpCtxt}
+	// Interrupted uploads are copied to the controller model's blob
+	// storage, so they can be resumed on any controller.
+	st := httpCtxt.srv.state
+	staging := server.NewDirUploadStaging(
+		filepath.Join(httpCtxt.srv.dataDir, "resource-uploads"),
+		statestorage.NewStorage(st.ModelUUID(), st.MongoSession()),
+		server.DefaultUploadExpiry,
+		clock.WallClock,
+	)
 	return server.NewLegacyHTTPHandler(
 		func(req *http.Request) (server.DataStore, names.Tag, error) {
 			st, entity, err := deps.ConnectForUser(req)
@@ -61,6 +73,7 @@ func newResourceHandler(httpCtxt httpContext) http.Handler {
 			}
 			return ds, entity.Tag(), nil
 		},
+		staging,
 	)
 }
 
@@ -47,6 +47,7 @@ func (r *restrictedRootSuite) TestFindAllowedMethod(c *gc.C) {
 	r.assertMethodAllowed(c, "Controller", 2, "DestroyController")
 	r.assertMethodAllowed(c, "Controller", 2, "ModelConfig")
 	r.assertMethodAllowed(c, "Controller", 2, "ListBlockedModels")
+	r.assertMethodAllowed(c, "Controller", 2, "ModelLeadership")
 }
 
 func (r *restrictedRootSuite) TestFindDisallowedMethod(c *gc.C) {
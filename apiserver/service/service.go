@@ -29,6 +29,15 @@ var (
 
 func init() {
 	common.RegisterStandardFacade("Service", 3, NewAPI)
+
+	// Version 4 adds ExposeToCIDRs and ExposeToSpaces to Expose,
+	// otherwise compatible.
+	common.RegisterStandardFacade("Service", 4, NewAPI)
+
+	// Version 5 adds TransferLeadership, PinLeadership,
+	// UnpinLeadership, ShowRelation and SetRelationSuspended,
+	// otherwise compatible.
+	common.RegisterStandardFacade("Service", 5, NewAPI)
 }
 
 // Service defines the methods on the service API end point.
@@ -439,7 +448,9 @@ func (api *API) CharmRelations(p params.ServiceCharmRelations) (params.ServiceCh
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If ExposeToCIDRs or
+// ExposeToSpaces are given, the ports are only exposed to those source
+// CIDRs and to the subnets of those spaces.
 func (api *API) Expose(args params.ServiceExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
@@ -448,7 +459,7 @@ func (api *API) Expose(args params.ServiceExpose) error {
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	return svc.SetExposedTo(args.ExposeToCIDRs, args.ExposeToSpaces)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -464,6 +475,33 @@ func (api *API) Unexpose(args params.ServiceUnexpose) error {
 	return svc.ClearExposed()
 }
 
+// TransferLeadership hands leadership of a service over to the
+// specified unit, once the current leader's lease runs out.
+func (api *API) TransferLeadership(args params.ServiceTransferLeadership) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return api.state.TransferLeadership(args.ServiceName, args.UnitName)
+}
+
+// PinLeadership prevents a service's leadership from changing when its
+// leader's lease expires.
+func (api *API) PinLeadership(args params.ServicePinLeadership) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return api.state.PinLeadership(args.ServiceName)
+}
+
+// UnpinLeadership allows a service's leadership to change again when its
+// leader's lease expires.
+func (api *API) UnpinLeadership(args params.ServiceUnpinLeadership) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return api.state.UnpinLeadership(args.ServiceName)
+}
+
 // addServiceUnits adds a given number of units to a service.
 func addServiceUnits(st *state.State, args params.AddServiceUnits) ([]*state.Unit, error) {
 	service, err := st.Service(args.ServiceName)
@@ -591,3 +629,109 @@ func (api *API) DestroyRelation(args params.DestroyRelation) error {
 	}
 	return rel.Destroy()
 }
+
+// SetRelationSuspended suspends or resumes the relation between the
+// specified endpoints.
+func (api *API) SetRelationSuspended(args params.SetRelationSuspended) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	eps, err := api.state.InferEndpoints(args.Endpoints...)
+	if err != nil {
+		return err
+	}
+	rel, err := api.state.EndpointsRelation(eps...)
+	if err != nil {
+		return err
+	}
+	return rel.SetSuspended(args.Suspended)
+}
+
+// ShowRelation returns the details of the relation between the specified
+// endpoints, including the units in its scope. The units' relation
+// settings are only included for controller administrators and the model
+// owner.
+func (api *API) ShowRelation(args params.ShowRelation) (params.ShowRelationResult, error) {
+	var result params.ShowRelationResult
+	eps, err := api.state.InferEndpoints(args.Endpoints...)
+	if err != nil {
+		return result, err
+	}
+	rel, err := api.state.EndpointsRelation(eps...)
+	if err != nil {
+		return result, err
+	}
+	showSettings, err := api.isAdmin()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Id = rel.Id()
+	result.Key = rel.String()
+	result.Scope = charm.ScopeGlobal
+	result.Status = relationStatus(rel)
+	for _, ep := range rel.Endpoints() {
+		if ep.Scope == charm.ScopeContainer {
+			result.Scope = charm.ScopeContainer
+		}
+		result.Endpoints = append(result.Endpoints, params.RelationEndpointDetails{
+			ServiceName: ep.ServiceName,
+			Name:        ep.Name,
+			Role:        ep.Role,
+			Interface:   ep.Interface,
+		})
+	}
+	units, err := rel.UnitsInScope()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, ru := range units {
+		joined, err := ru.Joined()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		details := params.RelationUnitDetails{
+			UnitName:  ru.UnitName(),
+			Endpoint:  ru.Endpoint().Name,
+			Departing: !joined,
+		}
+		if showSettings {
+			if details.Settings, err = ru.ReadSettings(ru.UnitName()); err != nil {
+				return result, errors.Trace(err)
+			}
+		}
+		result.Units = append(result.Units, details)
+	}
+	return result, nil
+}
+
+// relationStatus returns a short description of the state of the
+// relation.
+func relationStatus(rel *state.Relation) string {
+	if rel.Life() == state.Alive {
+		if rel.Suspended() {
+			return "suspended"
+		}
+		return "active"
+	}
+	return rel.Life().String()
+}
+
+// isAdmin reports whether the authenticated user is a controller
+// administrator or the owner of the model.
+func (api *API) isAdmin() (bool, error) {
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return false, nil
+	}
+	if isAdmin, err := api.state.IsControllerAdministrator(user); err != nil {
+		return false, errors.Trace(err)
+	} else if isAdmin {
+		return true, nil
+	}
+	env, err := api.state.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return env.Owner().Canonical() == user.Canonical(), nil
+}
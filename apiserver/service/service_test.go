@@ -1778,7 +1778,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err = s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1790,6 +1790,49 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeToCIDRsAndSpaces(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "dummy-service", charm)
+	_, err := s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, cidr := range []string{"10.0.0.0/24", "10.0.1.0/24"} {
+		_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: cidr, SpaceName: "internal"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err = s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName:    "dummy-service",
+		ExposeToCIDRs:  []string{"192.168.0.0/16"},
+		ExposeToSpaces: []string{"internal"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	service, err := s.State.Service("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.IsExposed(), jc.IsTrue)
+	c.Assert(service.ExposedCIDRs(), jc.DeepEquals, []string{"192.168.0.0/16"})
+	c.Assert(service.ExposedSpaces(), jc.DeepEquals, []string{"internal"})
+	cidrs, err := service.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{
+		"10.0.0.0/24", "10.0.1.0/24", "192.168.0.0/16",
+	})
+}
+func (s *serviceSuite) TestServiceExposeToUnknownSpace(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "dummy-service", charm)
+
+	err := s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName:    "dummy-service",
+		ExposeToSpaces: []string{"missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "dummy-service": space "missing" not found`)
+
+	service, err := s.State.Service("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.IsExposed(), jc.IsFalse)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1830,7 +1873,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err := s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1845,7 +1888,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err := s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2555,6 +2598,123 @@ func (s *serviceSuite) TestBlockDestroyDestroyRelation(c *gc.C) {
 	s.assertDestroyRelation(c, endpoints)
 }
 
+func (s *serviceSuite) setupShowRelationScenario(c *gc.C) *state.Relation {
+	endpoints := []string{"wordpress", "mysql"}
+	relation := s.setupDestroyRelationScenario(c, endpoints)
+	for _, name := range endpoints {
+		svc, err := s.State.Service(name)
+		c.Assert(err, jc.ErrorIsNil)
+		unit, err := svc.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		ru, err := relation.Unit(unit)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(map[string]interface{}{"name": unit.Name()})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return relation
+}
+
+func (s *serviceSuite) TestShowRelation(c *gc.C) {
+	relation := s.setupShowRelationScenario(c)
+	result, err := s.serviceApi.ShowRelation(params.ShowRelation{Endpoints: []string{"mysql", "wordpress"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ShowRelationResult{
+		Id:     relation.Id(),
+		Key:    "wordpress:db mysql:server",
+		Scope:  charm.ScopeGlobal,
+		Status: "active",
+		Endpoints: []params.RelationEndpointDetails{{
+			ServiceName: "wordpress",
+			Name:        "db",
+			Role:        charm.RoleRequirer,
+			Interface:   "mysql",
+		}, {
+			ServiceName: "mysql",
+			Name:        "server",
+			Role:        charm.RoleProvider,
+			Interface:   "mysql",
+		}},
+		Units: []params.RelationUnitDetails{{
+			UnitName: "mysql/0",
+			Endpoint: "server",
+			Settings: map[string]interface{}{"name": "mysql/0"},
+		}, {
+			UnitName: "wordpress/0",
+			Endpoint: "db",
+			Settings: map[string]interface{}{"name": "wordpress/0"},
+		}},
+	})
+}
+
+func (s *serviceSuite) TestShowRelationHidesSettingsFromNonAdmins(c *gc.C) {
+	s.setupShowRelationScenario(c)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	s.authorizer.Tag = user.UserTag()
+	api, err := service.NewAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.ShowRelation(params.ShowRelation{Endpoints: []string{"mysql", "wordpress"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Units, jc.DeepEquals, []params.RelationUnitDetails{
+		{UnitName: "mysql/0", Endpoint: "server"},
+		{UnitName: "wordpress/0", Endpoint: "db"},
+	})
+}
+
+func (s *serviceSuite) TestShowRelationNotFound(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := s.serviceApi.ShowRelation(params.ShowRelation{Endpoints: []string{"wordpress", "mysql"}})
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+}
+
+func (s *serviceSuite) TestSetRelationSuspended(c *gc.C) {
+	endpoints := []string{"wordpress", "mysql"}
+	relation := s.setupDestroyRelationScenario(c, endpoints)
+	err := s.serviceApi.SetRelationSuspended(params.SetRelationSuspended{
+		Endpoints: endpoints,
+		Suspended: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = relation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.Suspended(), jc.IsTrue)
+
+	result, err := s.serviceApi.ShowRelation(params.ShowRelation{Endpoints: endpoints})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status, gc.Equals, "suspended")
+
+	err = s.serviceApi.SetRelationSuspended(params.SetRelationSuspended{
+		Endpoints: endpoints,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = relation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.Suspended(), jc.IsFalse)
+}
+
+func (s *serviceSuite) TestSetRelationSuspendedNotFound(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.serviceApi.SetRelationSuspended(params.SetRelationSuspended{
+		Endpoints: []string{"wordpress", "mysql"},
+		Suspended: true,
+	})
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+}
+
+func (s *serviceSuite) TestBlockChangeSetRelationSuspended(c *gc.C) {
+	endpoints := []string{"wordpress", "mysql"}
+	relation := s.setupDestroyRelationScenario(c, endpoints)
+	s.BlockAllChanges(c, "TestBlockChangeSetRelationSuspended")
+	err := s.serviceApi.SetRelationSuspended(params.SetRelationSuspended{
+		Endpoints: endpoints,
+		Suspended: true,
+	})
+	s.AssertBlocked(c, err, "TestBlockChangeSetRelationSuspended")
+	err = relation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.Suspended(), jc.IsFalse)
+}
+
 type mockStorageProvider struct {
 	storage.Provider
 	kind storage.StorageKind
@@ -2626,3 +2786,49 @@ func (s *recordingStorage) Remove(path string) error {
 	s.blobs.Remove(path)
 	return nil
 }
+
+func (s *serviceSuite) TestServiceTransferLeadership(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+
+	err := s.serviceApi.TransferLeadership(params.ServiceTransferLeadership{
+		ServiceName: service.Name(),
+		UnitName:    unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.PinLeadership(service.Name())
+	c.Assert(err, gc.ErrorMatches, `cannot pin leadership .*: leadership is being transferred to ".*"`)
+}
+
+func (s *serviceSuite) TestServicePinLeadership(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+
+	err := s.serviceApi.PinLeadership(params.ServicePinLeadership{service.Name()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.TransferLeadership(service.Name(), unit.Name())
+	c.Assert(err, gc.ErrorMatches, `cannot transfer leadership .*: leadership is pinned`)
+
+	err = s.serviceApi.UnpinLeadership(params.ServiceUnpinLeadership{service.Name()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.TransferLeadership(service.Name(), unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serviceSuite) TestServicePinLeadershipNotFound(c *gc.C) {
+	err := s.serviceApi.PinLeadership(params.ServicePinLeadership{"unknown-service"})
+	c.Assert(err, gc.ErrorMatches, `cannot pin leadership of service "unknown-service": service "unknown-service" not found`)
+}
+
+func (s *serviceSuite) TestBlockChangesServicePinLeadership(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	s.BlockAllChanges(c, "TestBlockChangesServicePinLeadership")
+
+	err := s.serviceApi.PinLeadership(params.ServicePinLeadership{service.Name()})
+	s.AssertBlocked(c, err, "TestBlockChangesServicePinLeadership")
+	err = s.serviceApi.UnpinLeadership(params.ServiceUnpinLeadership{service.Name()})
+	s.AssertBlocked(c, err, "TestBlockChangesServicePinLeadership")
+	err = s.serviceApi.TransferLeadership(params.ServiceTransferLeadership{service.Name(), service.Name() + "/0"})
+	s.AssertBlocked(c, err, "TestBlockChangesServicePinLeadership")
+}
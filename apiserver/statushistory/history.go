@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statushistory
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ModelStatusHistory returns the status history of the entities in
+// the model matching the arguments, ordered from oldest to newest.
+func (api *API) ModelStatusHistory(args params.ModelStatusHistoryArgs) (params.ModelStatusHistoryResult, error) {
+	if !api.authorizer.AuthClient() {
+		return params.ModelStatusHistoryResult{}, common.ErrPerm
+	}
+	filter := state.StatusHistoryFilter{
+		Size: args.Size,
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	for _, entity := range args.Entities {
+		tag, err := names.ParseTag(entity)
+		if err != nil {
+			return params.ModelStatusHistoryResult{}, errors.Trace(err)
+		}
+		filter.Entities = append(filter.Entities, tag)
+	}
+	entries, err := api.st.ModelStatusHistory(filter)
+	if err != nil {
+		return params.ModelStatusHistoryResult{}, errors.Trace(err)
+	}
+	result := params.ModelStatusHistoryResult{
+		Statuses: make([]params.HistoricalStatus, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Statuses[i] = params.HistoricalStatus{
+			Entity: entry.Entity.String(),
+			Kind:   historyKind(entry.Kind),
+			Status: params.Status(entry.Status),
+			Info:   entry.Message,
+			Data:   entry.Data,
+			Since:  entry.Since,
+		}
+	}
+	return result, nil
+}
+
+func historyKind(kind state.StatusHistoryKind) params.HistoryKind {
+	switch kind {
+	case state.StatusHistoryMachine:
+		return params.KindMachine
+	case state.StatusHistoryService:
+		return params.KindService
+	case state.StatusHistoryAgent:
+		return params.KindAgent
+	}
+	return params.KindWorkload
+}
+
+// HookHistory returns the most recent operations executed by the agent
+// of the specified unit, ordered from newest to oldest.
+func (api *API) HookHistory(args params.HookHistoryArgs) (params.HookHistoryResult, error) {
+	if !api.authorizer.AuthClient() {
+		return params.HookHistoryResult{}, common.ErrPerm
+	}
+	tag, err := names.ParseUnitTag(args.Unit)
+	if err != nil {
+		return params.HookHistoryResult{}, errors.Trace(err)
+	}
+	unit, err := api.st.Unit(tag.Id())
+	if err != nil {
+		return params.HookHistoryResult{}, errors.Trace(err)
+	}
+	records, err := unit.HookHistory(args.Size)
+	if err != nil {
+		return params.HookHistoryResult{}, errors.Trace(err)
+	}
+	result := params.HookHistoryResult{
+		Records: make([]params.HookRecord, len(records)),
+	}
+	for i, record := range records {
+		result.Records[i] = params.HookRecord{
+			Operation:  record.Operation,
+			Hook:       record.Hook,
+			RelationId: record.RelationId,
+			RemoteUnit: record.RemoteUnit,
+			Started:    record.Started,
+			Duration:   record.Duration,
+			ExitCode:   record.ExitCode,
+			Stderr:     record.Stderr,
+			Error:      record.Error,
+		}
+	}
+	return result, nil
+}
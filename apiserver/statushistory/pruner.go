@@ -4,38 +4,83 @@
 package statushistory
 
 import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
-
-	"github.com/juju/loggo"
 )
 
 func init() {
 	common.RegisterStandardFacade("StatusHistory", 2, NewAPI)
+
+	// Version 3 adds ModelStatusHistory, which returns a bounded
+	// number of entries when neither a size nor a time range is
+	// given. Version 2 is otherwise compatible.
+	common.RegisterStandardFacade("StatusHistory", 3, NewAPI)
+
+	// Version 4 adds HookHistory, otherwise compatible.
+	common.RegisterStandardFacade("StatusHistory", 4, NewAPI)
 }
 
 var logger = loggo.GetLogger("juju.apiserver.statushistory")
 
 // API is the concrete implementation of the Pruner endpoint..
 type API struct {
+	*common.ModelWatcher
 	st         *state.State
 	authorizer common.Authorizer
 }
 
 // NewAPI returns an API Instance.
-func NewAPI(st *state.State, _ *common.Resources, auth common.Authorizer) (*API, error) {
+func NewAPI(st *state.State, resources *common.Resources, auth common.Authorizer) (*API, error) {
 	return &API{
-		st:         st,
-		authorizer: auth,
+		ModelWatcher: common.NewModelWatcher(st, resources, auth),
+		st:           st,
+		authorizer:   auth,
 	}, nil
 }
 
-// Prune endpoint removes status history entries until
-// only the N newest records per unit remain.
+// Prune endpoint removes status history entries older than
+// MaxHistoryTime, then the oldest entries until the model's history
+// fits in MaxHistoryMB, and, if MaxLogsPerEntity is positive, entries
+// until only the MaxLogsPerEntity newest records per entity remain.
+// It also discards all but the state.MaxHookHistory newest hook
+// records of each unit. Age and size limits which are not positive
+// are replaced by the model's configured limits, or the defaults.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthModelManager() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxLogsPerEntity)
+	if p.MaxHistoryTime <= 0 || p.MaxHistoryMB <= 0 {
+		cfg, err := api.st.ModelConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if p.MaxHistoryTime <= 0 {
+			p.MaxHistoryTime = cfg.MaxStatusHistoryAge()
+		}
+		if p.MaxHistoryMB <= 0 {
+			p.MaxHistoryMB = int(cfg.MaxStatusHistorySizeMB())
+		}
+	}
+	minHistoryTime := time.Now().Add(-p.MaxHistoryTime)
+	if err := state.PruneStatusHistoryByAge(api.st, minHistoryTime); err != nil {
+		return errors.Trace(err)
+	}
+	if err := state.PruneStatusHistoryBySize(api.st, p.MaxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+	if p.MaxLogsPerEntity > 0 {
+		if err := state.PruneStatusHistory(api.st, p.MaxLogsPerEntity); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := state.PruneHookHistory(api.st, state.MaxHookHistory); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
 }
@@ -8,5 +8,6 @@ var (
 	ValidateNameCriteria     = (*API).validateNameCriteria
 	ValidateProviderCriteria = (*API).validateProviderCriteria
 
-	CreateAPI = createAPI
+	CreateAPI     = createAPI
+	IsPartitionOf = isPartitionOf
 )
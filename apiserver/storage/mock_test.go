@@ -46,6 +46,7 @@ type mockState struct {
 	storageInstanceFilesystemAttachment func(m names.MachineTag, f names.FilesystemTag) (state.FilesystemAttachment, error)
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
+	watchFilesystem                     func(names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices                   func(names.MachineTag) state.NotifyWatcher
 	modelName                           string
@@ -60,6 +61,17 @@ type mockState struct {
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
+	allMachineTags                      func() ([]names.MachineTag, error)
+	addVolumeSnapshot                   func(names.VolumeTag) (string, error)
+	volumeSnapshot                      func(string) (state.VolumeSnapshot, error)
+	volumeSnapshots                     func(names.VolumeTag) ([]state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(string) error
+	restoreVolumeSnapshot               func(string, names.MachineTag) (names.VolumeTag, error)
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	forceDetachStorage                  func(names.StorageTag, names.UnitTag) error
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -102,6 +114,10 @@ func (st *mockState) WatchFilesystemAttachment(mtag names.MachineTag, f names.Fi
 	return st.watchFilesystemAttachment(mtag, f)
 }
 
+func (st *mockState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return st.watchFilesystem(f)
+}
+
 func (st *mockState) WatchVolumeAttachment(mtag names.MachineTag, v names.VolumeTag) state.NotifyWatcher {
 	return st.watchVolumeAttachment(mtag, v)
 }
@@ -161,6 +177,53 @@ func (st *mockState) BlockDevices(m names.MachineTag) ([]state.BlockDeviceInfo,
 	return []state.BlockDeviceInfo{}, nil
 }
 
+func (st *mockState) AllMachineTags() ([]names.MachineTag, error) {
+	if st.allMachineTags != nil {
+		return st.allMachineTags()
+	}
+	return []names.MachineTag{}, nil
+}
+
+func (st *mockState) AddVolumeSnapshot(volume names.VolumeTag) (string, error) {
+	return st.addVolumeSnapshot(volume)
+}
+
+func (st *mockState) VolumeSnapshot(id string) (state.VolumeSnapshot, error) {
+	return st.volumeSnapshot(id)
+}
+
+func (st *mockState) VolumeSnapshots(volume names.VolumeTag) ([]state.VolumeSnapshot, error) {
+	return st.volumeSnapshots(volume)
+}
+
+func (st *mockState) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockState) DestroyVolumeSnapshot(id string) error {
+	return st.destroyVolumeSnapshot(id)
+}
+
+func (st *mockState) RestoreVolumeSnapshot(id string, machine names.MachineTag) (names.VolumeTag, error) {
+	return st.restoreVolumeSnapshot(id, machine)
+}
+
+func (st *mockState) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockState) DetachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.detachStorage(storage, unit)
+}
+
+func (st *mockState) ForceDetachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.forceDetachStorage(storage, unit)
+}
+
+func (st *mockState) AttachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.attachStorage(storage, unit)
+}
+
 type mockNotifyWatcher struct {
 	state.NotifyWatcher
 	changes chan struct{}
@@ -239,6 +302,13 @@ func (m *mockFilesystem) Info() (state.FilesystemInfo, error) {
 	return state.FilesystemInfo{}, errors.NotProvisionedf("filesystem")
 }
 
+func (m *mockFilesystem) Params() (state.FilesystemParams, bool) {
+	return state.FilesystemParams{
+		Pool: "rootfs",
+		Size: 1024,
+	}, true
+}
+
 func (m *mockFilesystem) Status() (state.StatusInfo, error) {
 	return state.StatusInfo{Status: state.StatusAttached}, nil
 }
@@ -295,6 +365,7 @@ func (m *mockStorageInstance) CharmURL() *charm.URL {
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	storage *mockStorageInstance
+	unit    names.UnitTag
 }
 
 func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
@@ -302,6 +373,9 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
+	if m.unit.Id() != "" {
+		return m.unit
+	}
 	return m.storage.Owner().(names.UnitTag)
 }
 
@@ -347,3 +421,38 @@ func (b mockBlock) Type() state.BlockType {
 func (b mockBlock) Message() string {
 	return b.msg
 }
+
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id     string
+	volume names.VolumeTag
+	info   *state.VolumeSnapshotInfo
+	err    string
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return "loop"
+}
+
+func (m *mockVolumeSnapshot) Life() state.Life {
+	return state.Alive
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if m.info != nil {
+		return *m.info, nil
+	}
+	return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.id)
+}
+
+func (m *mockVolumeSnapshot) Error() string {
+	return m.err
+}
@@ -55,7 +55,7 @@ func (s *poolSuite) TestListManyResults(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	assertPoolNames(c, results.Results[0].Result,
 		"testpool0", "testpool1",
-		"dummy", "loop",
+		"dummy", "loop", "lvm",
 		"tmpfs", "rootfs")
 }
 
@@ -165,7 +165,7 @@ func (s *poolSuite) TestListNoPools(c *gc.C) {
 	results, err := s.api.ListPools(params.StoragePoolFilters{[]params.StoragePoolFilter{{}}})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
-	assertPoolNames(c, results.Results[0].Result, "dummy", "rootfs", "loop", "tmpfs")
+	assertPoolNames(c, results.Results[0].Result, "dummy", "rootfs", "loop", "lvm", "tmpfs")
 }
 
 func (s *poolSuite) TestListFilterEmpty(c *gc.C) {
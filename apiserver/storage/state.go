@@ -41,6 +41,9 @@ type storageAccess interface {
 	// WatchFilesystemAttachment is required for storage functionality.
 	WatchFilesystemAttachment(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 
+	// WatchFilesystem is required for storage functionality.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// WatchVolumeAttachment is required for storage functionality.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
@@ -50,6 +53,9 @@ type storageAccess interface {
 	// BlockDevices is required for storage functionality.
 	BlockDevices(names.MachineTag) ([]state.BlockDeviceInfo, error)
 
+	// AllMachineTags is required for storage usage functionality.
+	AllMachineTags() ([]names.MachineTag, error)
+
 	// ModelName is required for pool functionality.
 	ModelName() (string, error)
 
@@ -80,6 +86,36 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// AddVolumeSnapshot is required for volume snapshot functionality.
+	AddVolumeSnapshot(volume names.VolumeTag) (string, error)
+
+	// VolumeSnapshot is required for volume snapshot functionality.
+	VolumeSnapshot(id string) (state.VolumeSnapshot, error)
+
+	// VolumeSnapshots is required for volume snapshot functionality.
+	VolumeSnapshots(volume names.VolumeTag) ([]state.VolumeSnapshot, error)
+
+	// AllVolumeSnapshots is required for volume snapshot functionality.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// DestroyVolumeSnapshot is required for volume snapshot functionality.
+	DestroyVolumeSnapshot(id string) error
+
+	// RestoreVolumeSnapshot is required for volume snapshot functionality.
+	RestoreVolumeSnapshot(id string, machine names.MachineTag) (names.VolumeTag, error)
+
+	// ResizeStorageInstance is required for storage resize functionality.
+	ResizeStorageInstance(tag names.StorageTag, size uint64) error
+
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(storage names.StorageTag, unit names.UnitTag) error
+
+	// ForceDetachStorage is required for storage detach functionality.
+	ForceDetachStorage(storage names.StorageTag, unit names.UnitTag) error
+
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(storage names.StorageTag, unit names.UnitTag) error
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
@@ -116,3 +152,16 @@ func (s stateShim) ModelName() (string, error) {
 	}
 	return cfg.Name(), nil
 }
+
+// AllMachineTags returns the tags of all machines in the model.
+func (s stateShim) AllMachineTags() ([]names.MachineTag, error) {
+	machines, err := s.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags := make([]names.MachineTag, len(machines))
+	for i, m := range machines {
+		tags[i] = m.MachineTag()
+	}
+	return tags, nil
+}
@@ -6,6 +6,9 @@
 package storage
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	"github.com/juju/utils/set"
@@ -16,11 +19,16 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/storage/provider/registry"
 )
 
 func init() {
 	common.RegisterStandardFacade("Storage", 2, NewAPI)
+
+	// Version 3 adds volume snapshots, ResizeStorage, Detach, Attach
+	// and StorageUsage, otherwise compatible.
+	common.RegisterStandardFacade("Storage", 3, NewAPI)
 }
 
 // API implements the storage interface and is the concrete
@@ -704,3 +712,751 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// CreateVolumeSnapshots creates snapshots of the volumes identified by
+// the specified volume or storage tags. The result for each entity is
+// the ID of the new snapshot, which will be created asynchronously by
+// the storage provisioner.
+// A "CHANGE" block can block this operation.
+func (a *API) CreateVolumeSnapshots(args params.Entities) (params.StringResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (string, error) {
+		volumeTag, err := a.entityVolumeTag(arg.Tag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return a.storage.AddVolumeSnapshot(volumeTag)
+	}
+	for i, arg := range args.Entities {
+		id, err := one(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = id
+	}
+	return results, nil
+}
+
+// entityVolumeTag returns the tag of the volume identified by the
+// specified volume or storage tag.
+func (a *API) entityVolumeTag(tagString string) (names.VolumeTag, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return names.VolumeTag{}, errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.VolumeTag:
+		return tag, nil
+	case names.StorageTag:
+		volume, err := a.storage.StorageInstanceVolume(tag)
+		if err != nil {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		return volume.VolumeTag(), nil
+	}
+	return names.VolumeTag{}, errors.NotValidf("%s", names.ReadableString(tag))
+}
+
+// ListVolumeSnapshots lists volume snapshots with the given filters.
+// Each filter produces an independent list of snapshots, or an error
+// if the filter is invalid or the snapshots could not be listed.
+func (a *API) ListVolumeSnapshots(filters params.VolumeSnapshotFilters) (params.VolumeSnapshotDetailsListResults, error) {
+	results := params.VolumeSnapshotDetailsListResults{
+		Results: make([]params.VolumeSnapshotDetailsListResult, len(filters.Filters)),
+	}
+	for i, filter := range filters.Filters {
+		snapshots, err := filterVolumeSnapshots(a.storage, filter)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		details, err := createVolumeSnapshotDetailsList(a.storage, snapshots)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = details
+	}
+	return results, nil
+}
+
+func filterVolumeSnapshots(st storageAccess, f params.VolumeSnapshotFilter) ([]state.VolumeSnapshot, error) {
+	if len(f.VolumeTags) == 0 {
+		snapshots, err := st.AllVolumeSnapshots()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return snapshots, nil
+	}
+	var snapshots []state.VolumeSnapshot
+	for _, volume := range f.VolumeTags {
+		volumeTag, err := names.ParseVolumeTag(volume)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeSnapshots, err := st.VolumeSnapshots(volumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots = append(snapshots, volumeSnapshots...)
+	}
+	return snapshots, nil
+}
+
+func createVolumeSnapshotDetailsList(
+	st storageAccess, snapshots []state.VolumeSnapshot,
+) ([]params.VolumeSnapshotDetails, error) {
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	results := make([]params.VolumeSnapshotDetails, len(snapshots))
+	for i, s := range snapshots {
+		details := params.VolumeSnapshotDetails{
+			Id:        s.Id(),
+			VolumeTag: s.Volume().String(),
+			Pool:      s.Pool(),
+			Life:      params.Life(s.Life().String()),
+			Error:     s.Error(),
+		}
+		if info, err := s.Info(); err == nil {
+			details.Info = &params.VolumeSnapshotInfo{
+				SnapshotId: info.SnapshotId,
+				Size:       info.Size,
+			}
+		} else if !errors.IsNotProvisioned(err) {
+			return nil, errors.Trace(err)
+		}
+		// The snapshot may outlive its volume, in which
+		// case the storage instance is no longer known.
+		volume, err := st.Volume(s.Volume())
+		if err == nil {
+			if storageTag, err := volume.StorageInstance(); err == nil {
+				details.StorageTag = storageTag.String()
+			}
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "getting details for snapshot %q", s.Id())
+		}
+		results[i] = details
+	}
+	return results, nil
+}
+
+// ResizeStorage requests that the volumes or filesystems assigned to
+// storage instances be grown to the specified sizes. The volumes and
+// filesystems will be grown asynchronously by the storage provisioner.
+// A "CHANGE" block can block this operation.
+func (a *API) ResizeStorage(args params.StoragesResizeParams) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Storages)),
+	}
+	one := func(arg params.StorageResizeParams) error {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return common.ErrPerm
+		}
+		err = a.storage.ResizeStorageInstance(storageTag, arg.Size)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Storages {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// Detach detaches the specified storage instances from the units that
+// own them, without destroying the storage. Detached storage is owned
+// by the unit's service, and may be attached to another unit of the
+// service with Attach. If Force is set, the storage attachments are
+// removed without waiting for the units' agents, which may be down, to
+// run storage-detaching hooks; this also completes an earlier detach
+// that is waiting for a unit agent.
+// A "CHANGE" block can block this operation.
+func (a *API) Detach(args params.StorageDetachmentParams) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Storages.Entities)),
+	}
+	one := func(arg params.Entity) error {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return common.ErrPerm
+		}
+		si, err := a.storage.StorageInstance(storageTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if args.Force {
+			unitTag, err := a.attachedUnit(si)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return errors.Trace(a.storage.ForceDetachStorage(storageTag, unitTag))
+		}
+		unitTag, ok := si.Owner().(names.UnitTag)
+		if !ok {
+			return errors.Errorf(
+				"%s is not attached to a unit",
+				names.ReadableString(storageTag),
+			)
+		}
+		return errors.Trace(a.storage.DetachStorage(storageTag, unitTag))
+	}
+	for i, arg := range args.Storages.Entities {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// attachedUnit returns the tag of the unit that the storage instance is
+// attached to: the unit that owns it, or if it has been detached but the
+// attachment not yet removed, the unit of its remaining attachment.
+func (a *API) attachedUnit(si state.StorageInstance) (names.UnitTag, error) {
+	if unitTag, ok := si.Owner().(names.UnitTag); ok {
+		return unitTag, nil
+	}
+	attachments, err := a.storage.StorageAttachments(si.StorageTag())
+	if err != nil {
+		return names.UnitTag{}, errors.Trace(err)
+	}
+	if len(attachments) != 1 {
+		return names.UnitTag{}, errors.Errorf(
+			"%s is not attached to a unit",
+			names.ReadableString(si.StorageTag()),
+		)
+	}
+	return attachments[0].Unit(), nil
+}
+
+// Attach attaches the specified detached storage instances to units.
+// A "CHANGE" block can block this operation.
+func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(arg params.StorageAttachmentId) error {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return common.ErrPerm
+		}
+		unitTag, err := names.ParseUnitTag(arg.UnitTag)
+		if err != nil {
+			return common.ErrPerm
+		}
+		err = a.storage.AttachStorage(storageTag, unitTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Ids {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// DestroyVolumeSnapshots destroys the volume snapshots with the
+// specified IDs. The snapshots will be removed asynchronously by
+// the storage provisioner.
+// A "REMOVE" block can block this operation.
+func (a *API) DestroyVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := a.storage.DestroyVolumeSnapshot(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RestoreVolumeSnapshots creates new volumes from the specified volume
+// snapshots, and attaches them to machines. The result for each
+// snapshot is the tag of the new volume.
+//
+// If no machine is specified, the new volume is attached to the machine
+// that the snapshot's volume is scoped to, or else the machine that the
+// snapshot's volume is attached to.
+// A "CHANGE" block can block this operation.
+func (a *API) RestoreVolumeSnapshots(args params.RestoreVolumeSnapshotsParams) (params.StringResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Snapshots)),
+	}
+	one := func(arg params.RestoreVolumeSnapshotParams) (string, error) {
+		var machineTag names.MachineTag
+		if arg.MachineTag != "" {
+			tag, err := names.ParseMachineTag(arg.MachineTag)
+			if err != nil {
+				return "", errors.Trace(err)
+			}
+			machineTag = tag
+		} else {
+			tag, err := a.volumeSnapshotMachine(arg.Id)
+			if err != nil {
+				return "", errors.Trace(err)
+			}
+			machineTag = tag
+		}
+		volumeTag, err := a.storage.RestoreVolumeSnapshot(arg.Id, machineTag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return volumeTag.String(), nil
+	}
+	for i, arg := range args.Snapshots {
+		volumeTag, err := one(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = volumeTag
+	}
+	return results, nil
+}
+
+// volumeSnapshotMachine returns the tag of the machine that the volume
+// of the specified snapshot is scoped to, or else attached to.
+func (a *API) volumeSnapshotMachine(id string) (names.MachineTag, error) {
+	snapshot, err := a.storage.VolumeSnapshot(id)
+	if err != nil {
+		return names.MachineTag{}, errors.Trace(err)
+	}
+	volumeTag := snapshot.Volume()
+	if machineTag, ok := names.VolumeMachine(volumeTag); ok {
+		return machineTag, nil
+	}
+	attachments, err := a.storage.VolumeAttachments(volumeTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return names.MachineTag{}, errors.Trace(err)
+	}
+	if len(attachments) != 1 {
+		return names.MachineTag{}, errors.Errorf(
+			"no machine specified, and %s is not attached to exactly one machine",
+			names.ReadableString(volumeTag),
+		)
+	}
+	return attachments[0].Machine(), nil
+}
+
+// StorageUsage returns a report of the storage capacity allocated in
+// the model, and how much of it is in use, per storage pool, per machine
+// and per unit. The space used by filesystems is as last reported by
+// the machines they are mounted on.
+func (a *API) StorageUsage() (params.StorageUsage, error) {
+	u := &storageUsage{
+		st:            a.storage,
+		pools:         make(map[string]*params.StoragePoolUsage),
+		machines:      make(map[names.MachineTag]*params.MachineStorageUsage),
+		storage:       make(map[names.StorageTag]params.UnitStorageUsage),
+		blockDevices:  make(map[names.MachineTag][]state.BlockDeviceInfo),
+		volumeDevices: make(map[names.MachineTag]set.Strings),
+		volumeGroups:  make(map[string]string),
+	}
+	pools, err := a.listPools(params.StoragePoolFilter{})
+	if err != nil {
+		return params.StorageUsage{}, errors.Trace(err)
+	}
+	for _, pool := range pools {
+		u.pools[pool.Name] = &params.StoragePoolUsage{
+			Name:     pool.Name,
+			Provider: pool.Provider,
+		}
+		if pool.Provider == string(provider.LVMProviderType) {
+			// The available capacity of a pool with invalid
+			// configuration is unknown, and left unset.
+			if volumeGroup, err := provider.LVMPoolVolumeGroup(pool.Attrs); err == nil {
+				u.volumeGroups[pool.Name] = volumeGroup
+			}
+		}
+	}
+	machineTags, err := a.storage.AllMachineTags()
+	if err != nil {
+		return params.StorageUsage{}, errors.Trace(err)
+	}
+	for _, tag := range machineTags {
+		devices, err := a.storage.BlockDevices(tag)
+		if err != nil {
+			return params.StorageUsage{}, errors.Trace(err)
+		}
+		u.blockDevices[tag] = devices
+		u.machine(tag)
+	}
+	if err := u.addVolumes(); err != nil {
+		return params.StorageUsage{}, errors.Annotate(err, "getting volume usage")
+	}
+	if err := u.addFilesystems(); err != nil {
+		return params.StorageUsage{}, errors.Annotate(err, "getting filesystem usage")
+	}
+	units, err := u.unitUsage()
+	if err != nil {
+		return params.StorageUsage{}, errors.Annotate(err, "getting unit storage usage")
+	}
+	u.addAvailable()
+	return params.StorageUsage{
+		Pools:    u.poolUsage(),
+		Machines: u.machineUsage(),
+		Units:    units,
+	}, nil
+}
+
+// storageUsage accumulates the storage usage report for a model.
+type storageUsage struct {
+	st       storageAccess
+	pools    map[string]*params.StoragePoolUsage
+	machines map[names.MachineTag]*params.MachineStorageUsage
+
+	// storage records the pool, size and usage of the volume or
+	// filesystem assigned to each storage instance.
+	storage map[names.StorageTag]params.UnitStorageUsage
+
+	// blockDevices records the block devices of each machine.
+	blockDevices map[names.MachineTag][]state.BlockDeviceInfo
+
+	// volumeDevices records the names of the block devices on
+	// each machine that back volumes.
+	volumeDevices map[names.MachineTag]set.Strings
+
+	// volumeGroups records the LVM volume group of each lvm pool.
+	volumeGroups map[string]string
+}
+
+func (u *storageUsage) pool(name string) *params.StoragePoolUsage {
+	pool, ok := u.pools[name]
+	if !ok {
+		pool = &params.StoragePoolUsage{Name: name}
+		u.pools[name] = pool
+	}
+	return pool
+}
+
+func (u *storageUsage) machine(tag names.MachineTag) *params.MachineStorageUsage {
+	machine, ok := u.machines[tag]
+	if !ok {
+		machine = &params.MachineStorageUsage{MachineTag: tag.String()}
+		u.machines[tag] = machine
+	}
+	return machine
+}
+
+// addVolumes adds the volumes in the model, and their attachments,
+// to the pool and machine usage.
+func (u *storageUsage) addVolumes() error {
+	volumes, err := u.st.AllVolumes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, v := range volumes {
+		info, err := v.Info()
+		provisioned := err == nil
+		if err != nil && !errors.IsNotProvisioned(err) {
+			return errors.Trace(err)
+		}
+		if !provisioned {
+			volumeParams, _ := v.Params()
+			info = state.VolumeInfo{Pool: volumeParams.Pool}
+		}
+		pool := u.pool(info.Pool)
+		pool.Volumes++
+		pool.Allocated += info.Size
+		if storageTag, err := v.StorageInstance(); err == nil {
+			u.storage[storageTag] = params.UnitStorageUsage{
+				StorageTag: storageTag.String(),
+				Pool:       info.Pool,
+				Size:       info.Size,
+			}
+		}
+		if !provisioned {
+			continue
+		}
+		attachments, err := u.st.VolumeAttachments(v.VolumeTag())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, attachment := range attachments {
+			attachmentInfo, err := attachment.Info()
+			if errors.IsNotProvisioned(err) {
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			machineTag := attachment.Machine()
+			machine := u.machine(machineTag)
+			machine.Volumes++
+			machine.Allocated += info.Size
+			dev, ok := storagecommon.MatchingBlockDevice(
+				u.blockDevices[machineTag], info, attachmentInfo,
+			)
+			if !ok {
+				continue
+			}
+			if u.volumeDevices[machineTag] == nil {
+				u.volumeDevices[machineTag] = set.NewStrings()
+			}
+			u.volumeDevices[machineTag].Add(dev.DeviceName)
+		}
+	}
+	return nil
+}
+
+// addFilesystems adds the filesystems in the model, and their
+// attachments, to the pool and machine usage. The size of a
+// volume-backed filesystem is not added to the allocated capacity,
+// as it is already accounted for by its volume.
+func (u *storageUsage) addFilesystems() error {
+	filesystems, err := u.st.AllFilesystems()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, f := range filesystems {
+		info, err := f.Info()
+		provisioned := err == nil
+		if err != nil && !errors.IsNotProvisioned(err) {
+			return errors.Trace(err)
+		}
+		if !provisioned {
+			filesystemParams, _ := f.Params()
+			info = state.FilesystemInfo{Pool: filesystemParams.Pool}
+		}
+		_, err = f.Volume()
+		volumeBacked := err == nil
+		if err != nil && err != state.ErrNoBackingVolume {
+			return errors.Trace(err)
+		}
+		pool := u.pool(info.Pool)
+		pool.Filesystems++
+		if !volumeBacked {
+			pool.Allocated += info.Size
+		}
+
+		var used *uint64
+		if provisioned {
+			attachments, err := u.st.FilesystemAttachments(f.FilesystemTag())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, attachment := range attachments {
+				attachmentInfo, err := attachment.Info()
+				if errors.IsNotProvisioned(err) {
+					continue
+				} else if err != nil {
+					return errors.Trace(err)
+				}
+				machineTag := attachment.Machine()
+				machine := u.machine(machineTag)
+				machine.Filesystems++
+				if !volumeBacked {
+					machine.Allocated += info.Size
+				}
+				dev, ok := mountedBlockDevice(
+					u.blockDevices[machineTag], attachmentInfo.MountPoint,
+				)
+				if !ok {
+					continue
+				}
+				machine.Used += dev.UsedSize
+				if used == nil {
+					// A filesystem shared by several machines
+					// counts towards the pool's usage only once.
+					usedSize := dev.UsedSize
+					used = &usedSize
+					pool.Used += usedSize
+				}
+			}
+		}
+		if storageTag, err := f.Storage(); err == nil {
+			u.storage[storageTag] = params.UnitStorageUsage{
+				StorageTag: storageTag.String(),
+				Pool:       info.Pool,
+				Size:       info.Size,
+				Used:       used,
+			}
+		}
+	}
+	return nil
+}
+
+// addAvailable adds the sizes of the unused block devices on each
+// machine to the machine's available capacity. Devices that back
+// volumes, and the partitions of those devices, are not available;
+// nor are disks that have been partitioned, as their partitions are
+// counted instead. The free space in the LVM physical volumes of
+// each lvm pool's volume group is added to the pool's available
+// capacity.
+func (u *storageUsage) addAvailable() {
+	volumeGroupFree := make(map[string]uint64)
+	for tag, devices := range u.blockDevices {
+		machine := u.machine(tag)
+		volumeDevices := u.volumeDevices[tag]
+		for _, dev := range devices {
+			if dev.VolumeGroup != "" {
+				volumeGroupFree[dev.VolumeGroup] += dev.FreeSize
+			}
+			if dev.InUse || dev.FilesystemType != "" || dev.MountPoint != "" {
+				continue
+			}
+			if isVolumeDevice(dev.DeviceName, volumeDevices.Values()) {
+				continue
+			}
+			if isPartitioned(dev, devices) {
+				continue
+			}
+			machine.Available += dev.Size
+		}
+	}
+	for poolName, volumeGroup := range u.volumeGroups {
+		available := volumeGroupFree[volumeGroup]
+		u.pool(poolName).Available = &available
+	}
+}
+
+// unitUsage returns the usage of the storage instances attached
+// to units.
+func (u *storageUsage) unitUsage() ([]params.UnitStorageUsage, error) {
+	storageInstances, err := u.st.AllStorageInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.UnitStorageUsage
+	for _, si := range storageInstances {
+		storageTag := si.StorageTag()
+		attachments, err := u.st.StorageAttachments(storageTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		usage, ok := u.storage[storageTag]
+		if !ok {
+			usage.StorageTag = storageTag.String()
+		}
+		for _, attachment := range attachments {
+			usage.UnitTag = attachment.Unit().String()
+			result = append(result, usage)
+		}
+	}
+	return result, nil
+}
+
+func (u *storageUsage) poolUsage() []params.StoragePoolUsage {
+	poolNames := make([]string, 0, len(u.pools))
+	for name := range u.pools {
+		poolNames = append(poolNames, name)
+	}
+	sort.Strings(poolNames)
+	result := make([]params.StoragePoolUsage, len(poolNames))
+	for i, name := range poolNames {
+		result[i] = *u.pools[name]
+	}
+	return result
+}
+
+func (u *storageUsage) machineUsage() []params.MachineStorageUsage {
+	ids := make([]string, 0, len(u.machines))
+	for tag := range u.machines {
+		ids = append(ids, tag.Id())
+	}
+	sort.Strings(ids)
+	result := make([]params.MachineStorageUsage, len(ids))
+	for i, id := range ids {
+		result[i] = *u.machines[names.NewMachineTag(id)]
+	}
+	return result
+}
+
+// mountedBlockDevice returns the block device mounted at the
+// specified mount point, if any.
+func mountedBlockDevice(devices []state.BlockDeviceInfo, mountPoint string) (state.BlockDeviceInfo, bool) {
+	if mountPoint == "" {
+		return state.BlockDeviceInfo{}, false
+	}
+	for _, dev := range devices {
+		if dev.MountPoint == mountPoint {
+			return dev, true
+		}
+	}
+	return state.BlockDeviceInfo{}, false
+}
+
+// isPartitioned reports whether any of the other devices is
+// a partition of the specified device.
+func isPartitioned(dev state.BlockDeviceInfo, devices []state.BlockDeviceInfo) bool {
+	for _, other := range devices {
+		if isPartitionOf(other.DeviceName, dev.DeviceName) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVolumeDevice reports whether the device name is one of the
+// specified volume device names, or a partition of one of them.
+func isVolumeDevice(deviceName string, volumeDevices []string) bool {
+	for _, volumeDevice := range volumeDevices {
+		if deviceName == volumeDevice || isPartitionOf(deviceName, volumeDevice) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPartitionOf reports whether the device name is the name of a
+// partition of the specified disk. Partitions of a disk whose name
+// ends in a digit are named with a "p<N>" suffix (e.g. loop0p1,
+// nvme0n1p1); those of other disks with a "<N>" suffix (e.g. sda1).
+// Thus loop10 is not a partition of loop1, nor sdaa of sda.
+func isPartitionOf(deviceName, diskName string) bool {
+	if diskName == "" || !strings.HasPrefix(deviceName, diskName) {
+		return false
+	}
+	suffix := deviceName[len(diskName):]
+	if isDigit(diskName[len(diskName)-1]) {
+		if !strings.HasPrefix(suffix, "p") {
+			return false
+		}
+		suffix = suffix[1:]
+	}
+	if suffix == "" {
+		return false
+	}
+	for i := 0; i < len(suffix); i++ {
+		if !isDigit(suffix[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,172 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type storageAttachSuite struct {
+	baseStorageSuite
+
+	detached      map[names.StorageTag]names.UnitTag
+	forceDetached map[names.StorageTag]names.UnitTag
+	attached      map[names.StorageTag]names.UnitTag
+}
+
+var _ = gc.Suite(&storageAttachSuite{})
+
+func (s *storageAttachSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.detached = make(map[names.StorageTag]names.UnitTag)
+	s.forceDetached = make(map[names.StorageTag]names.UnitTag)
+	s.attached = make(map[names.StorageTag]names.UnitTag)
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.detached[storage] = unit
+		return nil
+	}
+	s.state.forceDetachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.forceDetached[storage] = unit
+		return nil
+	}
+	s.state.attachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		if storage != s.storageTag {
+			return errors.NotFoundf("storage instance %q", storage.Id())
+		}
+		if unit.Id() == "mysql/2" {
+			return errors.New(`storage is not detached from service "mysql"`)
+		}
+		s.attached[storage] = unit
+		return nil
+	}
+}
+
+func (s *storageAttachSuite) TestDetach(c *gc.C) {
+	results, err := s.api.Detach(params.StorageDetachmentParams{
+		Storages: params.Entities{
+			Entities: []params.Entity{
+				{Tag: s.storageTag.String()},
+				{Tag: "storage-data-42"},
+				{Tag: s.unitTag.String()},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	c.Assert(s.detached, jc.DeepEquals, map[names.StorageTag]names.UnitTag{
+		s.storageTag: s.unitTag,
+	})
+	c.Assert(s.forceDetached, gc.HasLen, 0)
+}
+
+func (s *storageAttachSuite) TestDetachForce(c *gc.C) {
+	results, err := s.api.Detach(params.StorageDetachmentParams{
+		Storages: params.Entities{
+			Entities: []params.Entity{{Tag: s.storageTag.String()}},
+		},
+		Force: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	c.Assert(s.forceDetached, jc.DeepEquals, map[names.StorageTag]names.UnitTag{
+		s.storageTag: s.unitTag,
+	})
+	c.Assert(s.detached, gc.HasLen, 0)
+}
+
+func (s *storageAttachSuite) TestDetachForceAlreadyDetached(c *gc.C) {
+	// The storage has been detached, but the unit agent
+	// has not removed the storage attachment.
+	s.storageInstance.owner = names.NewServiceTag("mysql")
+	s.state.storageInstanceAttachments = func(tag names.StorageTag) ([]state.StorageAttachment, error) {
+		return []state.StorageAttachment{&mockStorageAttachment{
+			storage: s.storageInstance,
+			unit:    s.unitTag,
+		}}, nil
+	}
+	results, err := s.api.Detach(params.StorageDetachmentParams{
+		Storages: params.Entities{
+			Entities: []params.Entity{{Tag: s.storageTag.String()}},
+		},
+		Force: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(s.forceDetached, jc.DeepEquals, map[names.StorageTag]names.UnitTag{
+		s.storageTag: s.unitTag,
+	})
+}
+
+func (s *storageAttachSuite) TestDetachNotOwnedByUnit(c *gc.C) {
+	s.storageInstance.owner = names.NewServiceTag("mysql")
+	results, err := s.api.Detach(params.StorageDetachmentParams{
+		Storages: params.Entities{
+			Entities: []params.Entity{{Tag: s.storageTag.String()}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "storage data/0 is not attached to a unit")
+	c.Assert(s.detached, gc.HasLen, 0)
+}
+
+func (s *storageAttachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(params.StorageDetachmentParams{
+		Storages: params.Entities{
+			Entities: []params.Entity{{Tag: s.storageTag.String()}},
+		},
+	})
+	s.assertBlocked(c, err, "TestDetachBlocked")
+	c.Assert(s.detached, gc.HasLen, 0)
+}
+
+func (s *storageAttachSuite) TestAttach(c *gc.C) {
+	results, err := s.api.Attach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{
+			{StorageTag: s.storageTag.String(), UnitTag: "unit-mysql-1"},
+			{StorageTag: s.storageTag.String(), UnitTag: "unit-mysql-2"},
+			{StorageTag: "storage-data-42", UnitTag: "unit-mysql-1"},
+			{StorageTag: s.storageTag.String(), UnitTag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `storage is not detached from service "mysql"`}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	c.Assert(s.attached, jc.DeepEquals, map[names.StorageTag]names.UnitTag{
+		s.storageTag: names.NewUnitTag("mysql/1"),
+	})
+}
+
+func (s *storageAttachSuite) TestAttachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAttachBlocked")
+	_, err := s.api.Attach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{{
+			StorageTag: s.storageTag.String(), UnitTag: "unit-mysql-1",
+		}},
+	})
+	s.assertBlocked(c, err, "TestAttachBlocked")
+	c.Assert(s.attached, gc.HasLen, 0)
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+
+	resized map[names.StorageTag]uint64
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.resized = make(map[names.StorageTag]uint64)
+	s.state.resizeStorageInstance = func(tag names.StorageTag, size uint64) error {
+		if tag != s.storageTag {
+			return errors.NotFoundf("storage instance %q", tag.Id())
+		}
+		if size <= 1024 {
+			return errors.Errorf("new size %dMiB is not larger than volume %q size 1024MiB", size, s.volumeTag.Id())
+		}
+		s.resized[tag] = size
+		return nil
+	}
+}
+
+func (s *storageResizeSuite) TestResizeStorage(c *gc.C) {
+	results, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{
+			{StorageTag: s.storageTag.String(), Size: 2048},
+			{StorageTag: s.storageTag.String(), Size: 512},
+			{StorageTag: "storage-data-42", Size: 2048},
+			{StorageTag: "volume-0", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `new size 512MiB is not larger than volume "22" size 1024MiB`}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	c.Assert(s.resized, jc.DeepEquals, map[names.StorageTag]uint64{s.storageTag: 2048})
+}
+
+func (s *storageResizeSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+	c.Assert(s.resized, gc.HasLen, 0)
+}
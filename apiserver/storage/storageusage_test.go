@@ -0,0 +1,239 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/storage"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+)
+
+type storageUsageSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageUsageSuite{})
+
+func (s *storageUsageSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+
+	// Use a model with no registered storage providers, so
+	// that only the pools below are reported.
+	s.state.modelName = "storageusagetest"
+	var err error
+	s.pools["ebs-ssd"], err = jujustorage.NewConfig("ebs-ssd", "ebs", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["fast"], err = jujustorage.NewConfig("fast", "ebs", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.volume.info = &state.VolumeInfo{
+		HardwareId: "abc",
+		Pool:       "ebs-ssd",
+		Size:       2048,
+	}
+	s.volumeAttachment.info = &state.VolumeAttachmentInfo{}
+	s.filesystem.volume = &s.volumeTag
+	s.filesystem.info = &state.FilesystemInfo{
+		Pool: "ebs-ssd",
+		Size: 2000,
+	}
+	s.filesystemAttachment.info = &state.FilesystemAttachmentInfo{
+		MountPoint: "/srv",
+	}
+
+	otherMachineTag := names.NewMachineTag("1")
+	s.state.allMachineTags = func() ([]names.MachineTag, error) {
+		return []names.MachineTag{s.machineTag, otherMachineTag}, nil
+	}
+	s.state.blockDevices = func(m names.MachineTag) ([]state.BlockDeviceInfo, error) {
+		switch m {
+		case s.machineTag:
+			return []state.BlockDeviceInfo{{
+				DeviceName: "xvda",
+				Size:       8192,
+				InUse:      true,
+			}, {
+				DeviceName:     "xvda1",
+				Size:           8191,
+				FilesystemType: "ext4",
+				MountPoint:     "/",
+				InUse:          true,
+			}, {
+				DeviceName:     "xvdf",
+				HardwareId:     "abc",
+				Size:           2048,
+				FilesystemType: "ext4",
+				MountPoint:     "/srv",
+				UsedSize:       512,
+				InUse:          true,
+			}, {
+				DeviceName: "xvdg",
+				Size:       4096,
+			}}, nil
+		case otherMachineTag:
+			return []state.BlockDeviceInfo{{
+				DeviceName: "xvdb",
+				Size:       1024,
+			}, {
+				DeviceName: "xvdc",
+				Size:       1024,
+			}, {
+				DeviceName: "xvdc1",
+				Size:       1024,
+			}, {
+				DeviceName: "xvdcc",
+				Size:       512,
+			}}, nil
+		}
+		return nil, nil
+	}
+}
+
+func (s *storageUsageSuite) TestStorageUsage(c *gc.C) {
+	used := uint64(512)
+	result, err := s.api.StorageUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StorageUsage{
+		Pools: []params.StoragePoolUsage{{
+			Name:        "ebs-ssd",
+			Provider:    "ebs",
+			Volumes:     1,
+			Filesystems: 1,
+			Allocated:   2048,
+			Used:        512,
+		}, {
+			Name:     "fast",
+			Provider: "ebs",
+		}},
+		Machines: []params.MachineStorageUsage{{
+			MachineTag: "machine-1",
+			Available:  2560,
+		}, {
+			MachineTag:  "machine-66",
+			Volumes:     1,
+			Filesystems: 1,
+			Allocated:   2048,
+			Used:        512,
+			Available:   4096,
+		}},
+		Units: []params.UnitStorageUsage{{
+			UnitTag:    "unit-mysql-0",
+			StorageTag: "storage-data-0",
+			Pool:       "ebs-ssd",
+			Size:       2000,
+			Used:       &used,
+		}},
+	})
+}
+
+func (s *storageUsageSuite) TestStorageUsageNotProvisioned(c *gc.C) {
+	s.volume.info = nil
+	s.filesystem.info = nil
+	s.filesystem.volume = nil
+	s.state.allMachineTags = nil
+	result, err := s.api.StorageUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StorageUsage{
+		Pools: []params.StoragePoolUsage{{
+			Name:     "ebs-ssd",
+			Provider: "ebs",
+		}, {
+			Name:     "fast",
+			Provider: "ebs",
+		}, {
+			Name:    "loop",
+			Volumes: 1,
+		}, {
+			Name:        "rootfs",
+			Filesystems: 1,
+		}},
+		Machines: []params.MachineStorageUsage{},
+		Units: []params.UnitStorageUsage{{
+			UnitTag:    "unit-mysql-0",
+			StorageTag: "storage-data-0",
+			Pool:       "rootfs",
+		}},
+	})
+}
+
+func (s *storageUsageSuite) TestStorageUsageLVMAvailable(c *gc.C) {
+	var err error
+	s.pools["lvm-data"], err = jujustorage.NewConfig("lvm-data", "lvm", map[string]interface{}{
+		"volume-group": "data",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["lvm-empty"], err = jujustorage.NewConfig("lvm-empty", "lvm", map[string]interface{}{
+		"volume-group": "empty",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.state.blockDevices = func(m names.MachineTag) ([]state.BlockDeviceInfo, error) {
+		return []state.BlockDeviceInfo{{
+			DeviceName:     "xvdb",
+			Size:           4096,
+			FilesystemType: "LVM2_member",
+			InUse:          true,
+			VolumeGroup:    "data",
+			FreeSize:       1024,
+		}, {
+			DeviceName:     "xvdc",
+			Size:           4096,
+			FilesystemType: "LVM2_member",
+			InUse:          true,
+			VolumeGroup:    "other",
+			FreeSize:       4096,
+		}}, nil
+	}
+
+	// The free space of the volume group's physical volumes on
+	// all machines is available to the pool; pools of other
+	// providers have no known available capacity.
+	dataAvailable, emptyAvailable := uint64(2048), uint64(0)
+	result, err := s.api.StorageUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Pools, jc.DeepEquals, []params.StoragePoolUsage{{
+		Name:        "ebs-ssd",
+		Provider:    "ebs",
+		Volumes:     1,
+		Filesystems: 1,
+		Allocated:   2048,
+	}, {
+		Name:     "fast",
+		Provider: "ebs",
+	}, {
+		Name:      "lvm-data",
+		Provider:  "lvm",
+		Available: &dataAvailable,
+	}, {
+		Name:      "lvm-empty",
+		Provider:  "lvm",
+		Available: &emptyAvailable,
+	}})
+}
+
+func (s *storageUsageSuite) TestIsPartitionOf(c *gc.C) {
+	for i, test := range []struct {
+		device, disk string
+		expect       bool
+	}{
+		{"sda1", "sda", true},
+		{"sda12", "sda", true},
+		{"sda", "sda", false},
+		{"sdaa", "sda", false},
+		{"sdaa1", "sda", false},
+		{"loop0p1", "loop0", true},
+		{"loop10", "loop1", false},
+		{"loop1p", "loop1", false},
+		{"nvme0n1p2", "nvme0n1", true},
+		{"nvme0n10", "nvme0n1", false},
+		{"xvdf", "", false},
+	} {
+		c.Logf("test %d: %q of %q", i, test.device, test.disk)
+		c.Check(storage.IsPartitionOf(test.device, test.disk), gc.Equals, test.expect)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type volumeSnapshotSuite struct {
+	baseStorageSuite
+
+	snapshot *mockVolumeSnapshot
+}
+
+var _ = gc.Suite(&volumeSnapshotSuite{})
+
+func (s *volumeSnapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.snapshot = &mockVolumeSnapshot{
+		id:     s.volumeTag.Id() + "@0",
+		volume: s.volumeTag,
+		info:   &state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024},
+	}
+	s.state.addVolumeSnapshot = func(volume names.VolumeTag) (string, error) {
+		s.calls = append(s.calls, "AddVolumeSnapshot")
+		if volume != s.volumeTag {
+			return "", errors.NotFoundf("%s", names.ReadableString(volume))
+		}
+		return s.snapshot.id, nil
+	}
+	s.state.volumeSnapshot = func(id string) (state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, "VolumeSnapshot")
+		if id != s.snapshot.id {
+			return nil, errors.NotFoundf("volume snapshot %q", id)
+		}
+		return s.snapshot, nil
+	}
+	s.state.volumeSnapshots = func(volume names.VolumeTag) ([]state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, "VolumeSnapshots")
+		if volume != s.volumeTag {
+			return nil, nil
+		}
+		return []state.VolumeSnapshot{s.snapshot}, nil
+	}
+	s.state.allVolumeSnapshots = func() ([]state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, "AllVolumeSnapshots")
+		return []state.VolumeSnapshot{s.snapshot}, nil
+	}
+	s.state.destroyVolumeSnapshot = func(id string) error {
+		s.calls = append(s.calls, "DestroyVolumeSnapshot")
+		if id != s.snapshot.id {
+			return errors.NotFoundf("volume snapshot %q", id)
+		}
+		return nil
+	}
+	s.state.restoreVolumeSnapshot = func(id string, machine names.MachineTag) (names.VolumeTag, error) {
+		s.calls = append(s.calls, "RestoreVolumeSnapshot")
+		if machine != s.machineTag {
+			return names.VolumeTag{}, errors.Errorf("unexpected machine %s", machine.Id())
+		}
+		return names.NewVolumeTag("23"), nil
+	}
+}
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	results, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.volumeTag.String()},
+			{Tag: s.storageTag.String()},
+			{Tag: "volume-42"},
+			{Tag: s.unitTag.String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "22@0"},
+			{Result: "22@0"},
+			{Error: &params.Error{Message: `volume 42 not found`, Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `unit mysql/0 not valid`}},
+		},
+	})
+}
+
+func (s *volumeSnapshotSuite) TestCreateVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateVolumeSnapshotsBlocked")
+	_, err := s.api.CreateVolumeSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: s.volumeTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestCreateVolumeSnapshotsBlocked")
+}
+
+func (s *volumeSnapshotSuite) TestListVolumeSnapshots(c *gc.C) {
+	results, err := s.api.ListVolumeSnapshots(params.VolumeSnapshotFilters{
+		Filters: []params.VolumeSnapshotFilter{
+			{},
+			{VolumeTags: []string{"volume-42"}},
+			{VolumeTags: []string{"machine-0"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotDetailsListResults{
+		Results: []params.VolumeSnapshotDetailsListResult{
+			{Result: []params.VolumeSnapshotDetails{{
+				Id:         "22@0",
+				VolumeTag:  "volume-22",
+				StorageTag: "storage-data-0",
+				Pool:       "loop",
+				Life:       params.Alive,
+				Info: &params.VolumeSnapshotInfo{
+					SnapshotId: "snap-0",
+					Size:       1024,
+				},
+			}}},
+			{},
+			{Error: &params.Error{Message: `"machine-0" is not a valid volume tag`}},
+		},
+	})
+}
+
+func (s *volumeSnapshotSuite) TestListVolumeSnapshotsVolumeRemoved(c *gc.C) {
+	s.snapshot.volume = names.NewVolumeTag("42")
+	s.snapshot.info = nil
+	s.snapshot.err = "boom"
+	results, err := s.api.ListVolumeSnapshots(params.VolumeSnapshotFilters{
+		Filters: []params.VolumeSnapshotFilter{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotDetailsListResults{
+		Results: []params.VolumeSnapshotDetailsListResult{
+			{Result: []params.VolumeSnapshotDetails{{
+				Id:        "22@0",
+				VolumeTag: "volume-42",
+				Pool:      "loop",
+				Life:      params.Alive,
+				Error:     "boom",
+			}}},
+		},
+	})
+}
+
+func (s *volumeSnapshotSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	results, err := s.api.DestroyVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"22@0", "22@1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `volume snapshot "22@1" not found`, Code: params.CodeNotFound}},
+		},
+	})
+}
+
+func (s *volumeSnapshotSuite) TestDestroyVolumeSnapshotsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestDestroyVolumeSnapshotsBlocked")
+	_, err := s.api.DestroyVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"22@0"},
+	})
+	s.assertBlocked(c, err, "TestDestroyVolumeSnapshotsBlocked")
+}
+
+func (s *volumeSnapshotSuite) TestRestoreVolumeSnapshots(c *gc.C) {
+	results, err := s.api.RestoreVolumeSnapshots(params.RestoreVolumeSnapshotsParams{
+		Snapshots: []params.RestoreVolumeSnapshotParams{
+			{Id: "22@0", MachineTag: s.machineTag.String()},
+			{Id: "22@0"},
+			{Id: "22@0", MachineTag: "machine-1"},
+			{Id: "22@1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "volume-23"},
+			{Result: "volume-23"},
+			{Error: &params.Error{Message: "unexpected machine 1"}},
+			{Error: &params.Error{Message: `volume snapshot "22@1" not found`, Code: params.CodeNotFound}},
+		},
+	})
+}
+
+func (s *volumeSnapshotSuite) TestRestoreVolumeSnapshotsMachineScoped(c *gc.C) {
+	s.snapshot.volume = names.NewVolumeTag("66/0")
+	results, err := s.api.RestoreVolumeSnapshots(params.RestoreVolumeSnapshotsParams{
+		Snapshots: []params.RestoreVolumeSnapshotParams{{Id: "22@0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{{Result: "volume-23"}},
+	})
+}
+
+func (s *volumeSnapshotSuite) TestRestoreVolumeSnapshotsNotAttached(c *gc.C) {
+	s.snapshot.volume = names.NewVolumeTag("42")
+	results, err := s.api.RestoreVolumeSnapshots(params.RestoreVolumeSnapshotsParams{
+		Snapshots: []params.RestoreVolumeSnapshotParams{{Id: "22@0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{{
+			Error: &params.Error{
+				Message: "no machine specified, and volume 42 is not attached to exactly one machine",
+			},
+		}},
+	})
+}
@@ -21,6 +21,13 @@ var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
 func init() {
 	common.RegisterStandardFacade("StorageProvisioner", 2, NewStorageProvisionerAPI)
+
+	// Version 3 adds WatchVolumeSnapshots, VolumeSnapshotParams,
+	// SetVolumeSnapshotInfo, SetVolumeSnapshotErrors,
+	// RemoveVolumeSnapshots, VolumeResizeParams,
+	// FilesystemResizeParams, SetVolumeSizes and SetFilesystemSizes,
+	// otherwise compatible.
+	common.RegisterStandardFacade("StorageProvisioner", 3, NewStorageProvisionerAPI)
 }
 
 // StorageProvisionerAPI provides access to the Provisioner API facade.
@@ -267,6 +274,12 @@ func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.S
 	return s.watchStorageEntities(args, s.st.WatchModelFilesystems, s.st.WatchMachineFilesystems)
 }
 
+// WatchVolumeSnapshots watches for changes to snapshots of volumes
+// scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeSnapshots, s.st.WatchMachineVolumeSnapshots)
+}
+
 func (s *StorageProvisionerAPI) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -1170,3 +1183,328 @@ func (s *StorageProvisionerAPI) RemoveAttachment(args params.MachineStorageIds)
 	}
 	return results, nil
 }
+
+// VolumeSnapshotParams returns the parameters for creating or destroying
+// the volume snapshots with the specified IDs.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		volumeTag, err := state.VolumeSnapshotVolume(id)
+		if err != nil || !canAccess(volumeTag) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		}
+		// Snapshots are removed by the storage provisioner itself,
+		// which will then see the removal; report the snapshot as
+		// not found rather than hiding it behind ErrPerm.
+		snapshot, err := s.st.VolumeSnapshot(id)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		providerType, cfg, err := storagecommon.StoragePoolConfig(snapshot.Pool(), poolManager)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		result := params.VolumeSnapshotParams{
+			Id:         id,
+			VolumeTag:  volumeTag.String(),
+			Provider:   string(providerType),
+			Attributes: cfg.Attrs(),
+			Life:       params.Life(snapshot.Life().String()),
+		}
+		if info, err := snapshot.Info(); err == nil {
+			result.Info = &params.VolumeSnapshotInfo{info.SnapshotId, info.Size}
+		} else if !errors.IsNotProvisioned(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		// The snapshot's volume may have been removed since the
+		// snapshot was created; it is only required for creating
+		// the snapshot.
+		volume, err := s.st.Volume(volumeTag)
+		if errors.IsNotFound(err) {
+			return result, nil
+		} else if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		if volumeInfo, err := volume.Info(); err == nil {
+			result.VolumeId = volumeInfo.VolumeId
+			result.Size = volumeInfo.Size
+		} else if !errors.IsNotProvisioned(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		return result, nil
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly created
+// volume snapshots.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotInfo(args params.VolumeSnapshots) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.VolumeSnapshots)),
+	}
+	one := func(arg params.VolumeSnapshot) error {
+		volumeTag, err := state.VolumeSnapshotVolume(arg.Id)
+		if err != nil || !canAccess(volumeTag) {
+			return common.ErrPerm
+		}
+		err = s.st.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+			arg.Info.SnapshotId,
+			arg.Info.Size,
+		})
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.VolumeSnapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotErrors records the errors that occurred when
+// creating volume snapshots.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotErrors(args params.VolumeSnapshotErrors) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Errors)),
+	}
+	one := func(arg params.VolumeSnapshotError) error {
+		volumeTag, err := state.VolumeSnapshotVolume(arg.Id)
+		if err != nil || !canAccess(volumeTag) {
+			return common.ErrPerm
+		}
+		err = s.st.SetVolumeSnapshotError(arg.Id, arg.Message)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Errors {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the volume snapshots with the
+// specified IDs from state.
+func (s *StorageProvisionerAPI) RemoveVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	one := func(id string) error {
+		volumeTag, err := state.VolumeSnapshotVolume(id)
+		if err != nil || !canAccess(volumeTag) {
+			return common.ErrPerm
+		}
+		return s.st.RemoveVolumeSnapshot(id)
+	}
+	for i, id := range args.Ids {
+		err := one(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// VolumeResizeParams returns the parameters for growing the volumes
+// with the specified tags. If a volume has no pending resize, a
+// NotFound error is returned for that volume.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		size, ok := volume.PendingResize()
+		if !ok {
+			return params.VolumeResizeParams{}, errors.NotFoundf("pending resize for volume %q", tag.Id())
+		}
+		info, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		providerType, cfg, err := storagecommon.StoragePoolConfig(info.Pool, poolManager)
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		return params.VolumeResizeParams{
+			VolumeTag:  tag.String(),
+			VolumeId:   info.VolumeId,
+			Size:       size,
+			Provider:   string(providerType),
+			Attributes: cfg.Attrs(),
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// FilesystemResizeParams returns the parameters for growing the
+// filesystems with the specified tags. If a filesystem has no pending
+// resize, a NotFound error is returned for that filesystem.
+func (s *StorageProvisionerAPI) FilesystemResizeParams(args params.Entities) (params.FilesystemResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.FilesystemResizeParamsResults{}, err
+	}
+	results := params.FilesystemResizeParamsResults{
+		Results: make([]params.FilesystemResizeParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (params.FilesystemResizeParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.FilesystemResizeParams{}, common.ErrPerm
+		}
+		filesystem, err := s.st.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return params.FilesystemResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		size, ok := filesystem.PendingResize()
+		if !ok {
+			return params.FilesystemResizeParams{}, errors.NotFoundf("pending resize for filesystem %q", tag.Id())
+		}
+		info, err := filesystem.Info()
+		if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		providerType, cfg, err := storagecommon.StoragePoolConfig(info.Pool, poolManager)
+		if err != nil {
+			return params.FilesystemResizeParams{}, err
+		}
+		result := params.FilesystemResizeParams{
+			FilesystemTag: tag.String(),
+			FilesystemId:  info.FilesystemId,
+			Size:          size,
+			Provider:      string(providerType),
+			Attributes:    cfg.Attrs(),
+		}
+		if volumeTag, err := filesystem.Volume(); err == nil {
+			result.VolumeTag = volumeTag.String()
+		} else if err != state.ErrNoBackingVolume {
+			return params.FilesystemResizeParams{}, err
+		}
+		return result, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.FilesystemResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSizes records the sizes of volumes that have been grown.
+func (s *StorageProvisionerAPI) SetVolumeSizes(args params.StorageSizes) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Sizes)),
+	}
+	one := func(arg params.StorageSize) error {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		err = s.st.SetVolumeSize(tag, arg.Size)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Sizes {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetFilesystemSizes records the sizes of filesystems that have been
+// grown.
+func (s *StorageProvisionerAPI) SetFilesystemSizes(args params.StorageSizes) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Sizes)),
+	}
+	one := func(arg params.StorageSize) error {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		err = s.st.SetFilesystemSize(tag, arg.Size)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Sizes {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -1253,3 +1253,260 @@ func (b byMachineAndEntity) Less(i, j int) bool {
 func (b byMachineAndEntity) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
+
+func (s *provisionerSuite) setupVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo("2@0", state.VolumeSnapshotInfo{
+		SnapshotId: "snap-def",
+		Size:       4096,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{"0/0@0", "2@0", "2@42", "1/0@0", "invalid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				Id:        "0/0@0",
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Size:      1024,
+				Provider:  "machinescoped",
+				Life:      params.Alive,
+			}},
+			{Result: params.VolumeSnapshotParams{
+				Id:        "2@0",
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Size:      4096,
+				Provider:  "environscoped",
+				Life:      params.Alive,
+				Info: &params.VolumeSnapshotInfo{
+					SnapshotId: "snap-def",
+					Size:       4096,
+				},
+			}},
+			{Error: &params.Error{Message: `volume snapshot "2@42" not found`, Code: "not found"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	results, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshots{
+		VolumeSnapshots: []params.VolumeSnapshot{{
+			Id:        "0/0@0",
+			VolumeTag: "volume-0-0",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-abc", Size: 1024},
+		}, {
+			Id:        "0/0@1",
+			VolumeTag: "volume-0-0",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-abc", Size: 1024},
+		}, {
+			Id:        "1/0@0",
+			VolumeTag: "volume-1-0",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-abc", Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	snapshot, err := s.State.VolumeSnapshot("0/0@0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-abc", Size: 1024})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotErrors(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	results, err := s.api.SetVolumeSnapshotErrors(params.VolumeSnapshotErrors{
+		Errors: []params.VolumeSnapshotError{
+			{Id: "0/0@0", Message: "boom"},
+			{Id: "1/0@0", Message: "boom"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	snapshot, err := s.State.VolumeSnapshot("0/0@0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Error(), gc.Equals, "boom")
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	err := s.State.DestroyVolumeSnapshot("2@0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"2@0", "0/0@0", "2@42", "1/0@0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `removing volume snapshot "0/0@0": volume snapshot is not dying`}},
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+	_, err = s.State.VolumeSnapshot("2@0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-1"},
+	}}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0@0"}},
+			{StringsWatcherId: "2", Changes: []string{"2@0"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	w0 := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w0)
+	w1 := s.resources.Get("2")
+	defer statetesting.AssertStop(c, w1)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, w0.(state.StringsWatcher))
+	wc.AssertNoChange()
+	wc = statetesting.NewStringsWatcherC(c, s.State, w1.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{{"volume-0-0"}, {"volume-2"}, {"volume-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Size:      2048,
+				Provider:  "machinescoped",
+			}},
+			{Error: &params.Error{Message: `pending resize for volume "2" not found`, Code: "not found"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestFilesystemResizeParams(c *gc.C) {
+	s.setupFilesystems(c)
+	err := s.State.ResizeFilesystem(names.NewFilesystemTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.FilesystemResizeParams(params.Entities{
+		Entities: []params.Entity{{"filesystem-0-0"}, {"filesystem-2"}, {"filesystem-42"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemResizeParamsResults{
+		Results: []params.FilesystemResizeParamsResult{
+			{Error: &params.Error{Message: `pending resize for filesystem "0/0" not found`, Code: "not found"}},
+			{Result: params.FilesystemResizeParams{
+				FilesystemTag: "filesystem-2",
+				FilesystemId:  "def",
+				Size:          8192,
+				Provider:      "environscoped",
+			}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSizes(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetVolumeSizes(params.StorageSizes{
+		Sizes: []params.StorageSize{
+			{Tag: "volume-0-0", Size: 2048},
+			{Tag: "volume-1-0", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := volume.PendingResize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2048))
+}
+
+func (s *provisionerSuite) TestSetFilesystemSizes(c *gc.C) {
+	s.setupFilesystems(c)
+	err := s.State.ResizeFilesystem(names.NewFilesystemTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetFilesystemSizes(params.StorageSizes{
+		Sizes: []params.StorageSize{
+			{Tag: "filesystem-0-0", Size: 2048},
+			{Tag: "filesystem-1-0", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	filesystem, err := s.State.Filesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := filesystem.PendingResize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2048))
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+)
+
+// ClaimCharmLeases claims, or extends, each of the supplied charm leases
+// on behalf of the specified unit. A claim that cannot be granted because
+// another unit holds the lease fails with a lease-claim-denied error.
+func (u *UniterAPIV3) ClaimCharmLeases(args params.CharmLeases) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Leases)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	claimer := u.st.CharmLeaseClaimer()
+	for i, arg := range args.Leases {
+		leaseName, unitTag, err := u.charmLease(canAccess, arg)
+		if err == nil {
+			duration := time.Duration(arg.DurationSeconds * float64(time.Second))
+			err = claimer.Claim(leaseName, unitTag.Id(), duration)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ReleaseCharmLeases gives up each of the supplied charm leases on behalf
+// of the specified unit. Releasing a lease the unit does not hold fails
+// with a lease-not-held error.
+func (u *UniterAPIV3) ReleaseCharmLeases(args params.CharmLeases) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Leases)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	releaser := u.st.CharmLeaseReleaser()
+	for i, arg := range args.Leases {
+		leaseName, unitTag, err := u.charmLease(canAccess, arg)
+		if err == nil {
+			err = releaser.Release(leaseName, unitTag.Id())
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// CheckCharmLeases reports whether the specified unit holds each of the
+// supplied charm leases.
+func (u *UniterAPIV3) CheckCharmLeases(args params.CharmLeases) (params.BoolResults, error) {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Leases)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.BoolResults{}, err
+	}
+	checker := u.st.CharmLeaseChecker()
+	for i, arg := range args.Leases {
+		leaseName, unitTag, err := u.charmLease(canAccess, arg)
+		if err == nil {
+			err = checker.Token(leaseName, unitTag.Id()).Check(nil)
+			if errors.Cause(err) == lease.ErrNotHeld {
+				result.Results[i].Result = false
+				continue
+			}
+			result.Results[i].Result = err == nil
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// charmLease returns the name of the lease backing the supplied charm
+// lease, and the unit acting on it, so long as the authenticated unit may
+// act within the lease's scope: its own service, or the model.
+func (u *UniterAPIV3) charmLease(canAccess common.AuthFunc, arg params.CharmLease) (string, names.UnitTag, error) {
+	unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil || !canAccess(unitTag) {
+		return "", names.UnitTag{}, common.ErrPerm
+	}
+	scope, err := names.ParseTag(arg.ScopeTag)
+	if err != nil {
+		return "", names.UnitTag{}, common.ErrPerm
+	}
+	switch scope := scope.(type) {
+	case names.ServiceTag:
+		serviceName, err := names.UnitService(unitTag.Id())
+		if err != nil || scope.Id() != serviceName {
+			return "", names.UnitTag{}, common.ErrPerm
+		}
+	case names.ModelTag:
+		if scope != u.st.ModelTag() {
+			return "", names.UnitTag{}, common.ErrPerm
+		}
+	default:
+		return "", names.UnitTag{}, common.ErrPerm
+	}
+	leaseName, err := state.CharmLeaseName(scope, arg.Name)
+	if err != nil {
+		return "", names.UnitTag{}, errors.Trace(err)
+	}
+	return leaseName, unitTag, nil
+}
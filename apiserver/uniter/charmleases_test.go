@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+)
+
+func (s *uniterSuite) TestClaimCharmLeases(c *gc.C) {
+	args := params.CharmLeases{Leases: []params.CharmLease{
+		{UnitTag: "unit-wordpress-0", ScopeTag: "service-wordpress", Name: "backup", DurationSeconds: 60},
+		{UnitTag: "unit-wordpress-0", ScopeTag: s.State.ModelTag().String(), Name: "backup", DurationSeconds: 60},
+		{UnitTag: "unit-wordpress-0", ScopeTag: "service-mysql", Name: "backup", DurationSeconds: 60},
+		{UnitTag: "unit-mysql-0", ScopeTag: "service-mysql", Name: "backup", DurationSeconds: 60},
+		{UnitTag: "unit-wordpress-0", ScopeTag: "unit-wordpress-0", Name: "backup", DurationSeconds: 60},
+		{UnitTag: "unit-wordpress-0", ScopeTag: "service-wordpress", Name: "Not Valid", DurationSeconds: 60},
+		{UnitTag: "unit-wordpress-0", ScopeTag: "service-wordpress", Name: "other", DurationSeconds: 7200},
+	}}
+	result, err := s.uniter.ClaimCharmLeases(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{&params.Error{Message: `charm lease name "Not Valid" not valid`}},
+			{&params.Error{Message: `cannot claim lease for 2h0m0s: longer than 1h0m0s`}},
+		},
+	})
+
+	leaseName, err := state.CharmLeaseName(s.wordpress.Tag(), "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CharmLeaseChecker().Token(leaseName, "wordpress/0").Check(nil)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) TestClaimCharmLeasesDenied(c *gc.C) {
+	leaseName, err := state.CharmLeaseName(s.wordpress.Tag(), "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CharmLeaseClaimer().Claim(leaseName, "wordpress/1", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.ClaimCharmLeases(params.CharmLeases{Leases: []params.CharmLease{
+		{UnitTag: "unit-wordpress-0", ScopeTag: "service-wordpress", Name: "backup", DurationSeconds: 60},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, jc.Satisfies, params.IsCodeLeaseClaimDenied)
+}
+
+func (s *uniterSuite) TestReleaseCharmLeases(c *gc.C) {
+	leaseName, err := state.CharmLeaseName(s.wordpress.Tag(), "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CharmLeaseClaimer().Claim(leaseName, "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.ReleaseCharmLeases(params.CharmLeases{Leases: []params.CharmLease{
+		{UnitTag: "unit-wordpress-0", ScopeTag: "service-wordpress", Name: "backup"},
+		{UnitTag: "unit-wordpress-0", ScopeTag: "service-wordpress", Name: "backup"},
+		{UnitTag: "unit-mysql-0", ScopeTag: "service-mysql", Name: "backup"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeLeaseNotHeld)
+	c.Check(result.Results[2].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	err = s.State.CharmLeaseChecker().Token(leaseName, "wordpress/0").Check(nil)
+	c.Check(err, gc.Equals, lease.ErrNotHeld)
+}
+
+func (s *uniterSuite) TestCheckCharmLeases(c *gc.C) {
+	leaseName, err := state.CharmLeaseName(s.State.ModelTag(), "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CharmLeaseClaimer().Claim(leaseName, "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelTag := s.State.ModelTag().String()
+	result, err := s.uniter.CheckCharmLeases(params.CharmLeases{Leases: []params.CharmLease{
+		{UnitTag: "unit-wordpress-0", ScopeTag: modelTag, Name: "backup"},
+		{UnitTag: "unit-wordpress-0", ScopeTag: "service-wordpress", Name: "backup"},
+		{UnitTag: "unit-mysql-0", ScopeTag: modelTag, Name: "backup"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: true},
+			{Result: false},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
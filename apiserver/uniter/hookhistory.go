@@ -0,0 +1,51 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddHookRecords records operations executed by the agents of the
+// specified units.
+func (u *UniterAPIV3) AddHookRecords(args params.HookRecordsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Units)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Units {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err == nil {
+			records := make([]state.HookRecord, len(arg.Records))
+			for j, record := range arg.Records {
+				records[j] = state.HookRecord{
+					Operation:  record.Operation,
+					Hook:       record.Hook,
+					RelationId: record.RelationId,
+					RemoteUnit: record.RemoteUnit,
+					Started:    record.Started,
+					Duration:   record.Duration,
+					ExitCode:   record.ExitCode,
+					Stderr:     record.Stderr,
+					Error:      record.Error,
+				}
+			}
+			err = unit.AddHookRecords(records...)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+func (s *uniterSuite) TestAddHookRecords(c *gc.C) {
+	started := time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)
+	record := params.HookRecord{
+		Operation:  "run install hook",
+		Hook:       "install",
+		RelationId: -1,
+		Started:    started,
+		Duration:   3 * time.Second,
+		ExitCode:   1,
+		Stderr:     "oops",
+		Error:      "exit status 1",
+	}
+	args := params.HookRecordsArgs{Units: []params.UnitHookRecords{
+		{Tag: "unit-wordpress-0", Records: []params.HookRecord{record}},
+		{Tag: "unit-wordpress-0", Records: []params.HookRecord{{}}},
+		{Tag: "unit-mysql-0", Records: []params.HookRecord{record}},
+		{Tag: "service-wordpress", Records: []params.HookRecord{record}},
+		{Tag: "unit-foo-42", Records: []params.HookRecord{record}},
+	}}
+	result, err := s.uniter.AddHookRecords(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{&params.Error{Message: "hook record with no operation not valid"}},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	records, err := s.wordpressUnit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []state.HookRecord{{
+		Operation:  "run install hook",
+		Hook:       "install",
+		RelationId: -1,
+		Started:    started,
+		Duration:   3 * time.Second,
+		ExitCode:   1,
+		Stderr:     "oops",
+		Error:      "exit status 1",
+	}})
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddSecretRevisions stores the supplied values as new revisions of each
+// of the secrets, creating them if necessary, and returns the revisions.
+// Secrets may only be added to by units of the owning service.
+func (u *UniterAPIV3) AddSecretRevisions(args params.SecretArgs) (params.IntResults, error) {
+	result := params.IntResults{
+		Results: make([]params.IntResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.IntResults{}, err
+	}
+	for i, arg := range args.Args {
+		_, owner, err := u.ownSecret(canAccess, arg)
+		if err == nil {
+			result.Results[i].Result, err = u.st.AddSecretRevision(owner, arg.Name, arg.Values)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GetSecretValues returns the values of the requested revision of each of
+// the secrets. Secrets may only be read by units of the owning service,
+// and of the services it has granted access to.
+func (u *UniterAPIV3) GetSecretValues(args params.SecretArgs) (params.SecretValuesResults, error) {
+	result := params.SecretValuesResults{
+		Results: make([]params.SecretValuesResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretValuesResults{}, err
+	}
+	for i, arg := range args.Args {
+		secret, err := u.readableSecret(canAccess, arg)
+		if err == nil {
+			revision := arg.Revision
+			if revision == 0 {
+				revision = secret.Revision()
+			}
+			result.Results[i].Revision = revision
+			result.Results[i].Values, err = secret.Values(revision)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GrantSecrets allows the services at the other ends of the supplied
+// relations to read each of the secrets. Access may only be granted by
+// units of the owning service, in relations they take part in.
+func (u *UniterAPIV3) GrantSecrets(args params.SecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		result.Results[i].Error = common.ServerError(u.grantSecret(canAccess, arg))
+	}
+	return result, nil
+}
+
+func (u *UniterAPIV3) grantSecret(canAccess common.AuthFunc, arg params.SecretArg) error {
+	unitTag, owner, err := u.ownSecret(canAccess, arg)
+	if err != nil {
+		return err
+	}
+	rel, _, err := u.getRelationAndUnit(canAccess, arg.RelationTag, unitTag)
+	if err != nil {
+		return err
+	}
+	endpoints, err := rel.RelatedEndpoints(owner.Id())
+	if err != nil {
+		// The unit's service is not part of the relation.
+		return common.ErrPerm
+	}
+	secret, err := u.st.Secret(owner, arg.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, ep := range endpoints {
+		if err := secret.Grant(names.NewServiceTag(ep.ServiceName)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ownSecret returns the tags of the unit acting on the supplied secret,
+// and of its service, so long as that service owns the secret.
+func (u *UniterAPIV3) ownSecret(canAccess common.AuthFunc, arg params.SecretArg) (names.UnitTag, names.ServiceTag, error) {
+	unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil || !canAccess(unitTag) {
+		return names.UnitTag{}, names.ServiceTag{}, common.ErrPerm
+	}
+	serviceName, err := names.UnitService(unitTag.Id())
+	if err != nil || arg.OwnerTag != names.NewServiceTag(serviceName).String() {
+		return names.UnitTag{}, names.ServiceTag{}, common.ErrPerm
+	}
+	return unitTag, names.NewServiceTag(serviceName), nil
+}
+
+// readableSecret returns the supplied secret, so long as the unit acting
+// on it may read it. Secrets of other services that the unit may not read
+// are indistinguishable from ones that do not exist.
+func (u *UniterAPIV3) readableSecret(canAccess common.AuthFunc, arg params.SecretArg) (*state.Secret, error) {
+	unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil || !canAccess(unitTag) {
+		return nil, common.ErrPerm
+	}
+	serviceName, err := names.UnitService(unitTag.Id())
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	owner, err := names.ParseServiceTag(arg.OwnerTag)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	secret, err := u.st.Secret(owner, arg.Name)
+	if errors.IsNotFound(err) && owner.Id() != serviceName {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !secret.CanRead(names.NewServiceTag(serviceName)) {
+		return nil, common.ErrPerm
+	}
+	return secret, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *uniterSuite) TestAddSecretRevisions(c *gc.C) {
+	args := params.SecretArgs{Args: []params.SecretArg{
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "admin", Values: map[string]string{"password": "a"}},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "admin", Values: map[string]string{"password": "b"}},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-mysql", Name: "admin", Values: map[string]string{"password": "a"}},
+		{UnitTag: "unit-mysql-0", OwnerTag: "service-mysql", Name: "admin", Values: map[string]string{"password": "a"}},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "Not Valid", Values: map[string]string{"password": "a"}},
+	}}
+	result, err := s.uniter.AddSecretRevisions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IntResults{
+		Results: []params.IntResult{
+			{Result: 1},
+			{Result: 2},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `secret name "Not Valid" not valid`}},
+		},
+	})
+
+	secret, err := s.State.Secret(s.wordpress.ServiceTag(), "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	values, err := secret.Values(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values, jc.DeepEquals, map[string]string{"password": "b"})
+}
+
+func (s *uniterSuite) TestGetSecretValues(c *gc.C) {
+	_, err := s.State.AddSecretRevision(s.wordpress.ServiceTag(), "admin", map[string]string{"password": "a"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecretRevision(s.wordpress.ServiceTag(), "admin", map[string]string{"password": "b"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecretRevision(s.mysql.ServiceTag(), "root", map[string]string{"password": "c"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecretRevision(s.mysql.ServiceTag(), "shared", map[string]string{"password": "d"})
+	c.Assert(err, jc.ErrorIsNil)
+	shared, err := s.State.Secret(s.mysql.ServiceTag(), "shared")
+	c.Assert(err, jc.ErrorIsNil)
+	err = shared.Grant(s.wordpress.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.SecretArgs{Args: []params.SecretArg{
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "admin"},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "admin", Revision: 1},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-mysql", Name: "shared"},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-mysql", Name: "root"},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-mysql", Name: "missing"},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "missing"},
+		{UnitTag: "unit-mysql-0", OwnerTag: "service-mysql", Name: "root"},
+	}}
+	result, err := s.uniter.GetSecretValues(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretValuesResults{
+		Results: []params.SecretValuesResult{
+			{Revision: 2, Values: map[string]string{"password": "b"}},
+			{Revision: 1, Values: map[string]string{"password": "a"}},
+			{Revision: 1, Values: map[string]string{"password": "d"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`secret "wordpress/missing"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestGrantSecrets(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	_, err := s.State.AddSecretRevision(s.wordpress.ServiceTag(), "admin", map[string]string{"password": "a"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.SecretArgs{Args: []params.SecretArg{
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "admin", RelationTag: rel.Tag().String()},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-mysql", Name: "admin", RelationTag: rel.Tag().String()},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "admin", RelationTag: "relation-foo.bar#baz.qux"},
+		{UnitTag: "unit-wordpress-0", OwnerTag: "service-wordpress", Name: "missing", RelationTag: rel.Tag().String()},
+	}}
+	result, err := s.uniter.GrantSecrets(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.NotFoundError(`secret "wordpress/missing"`)},
+		},
+	})
+
+	secret, err := s.State.Secret(s.wordpress.ServiceTag(), "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead(s.mysql.ServiceTag()), jc.IsTrue)
+}
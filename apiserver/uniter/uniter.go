@@ -30,6 +30,18 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 3, NewUniterAPIV3)
+
+	// Version 4 adds AddHookRecords, otherwise compatible.
+	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV3)
+
+	// Version 5 adds AddSecretRevisions, GetSecretValues and
+	// GrantSecrets, otherwise compatible.
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV3)
+
+	// Version 6 adds ClaimCharmLeases, ReleaseCharmLeases,
+	// CheckCharmLeases, SetWorkloadVersion and SetWorkloadInfo,
+	// otherwise compatible.
+	common.RegisterStandardFacade("Uniter", 6, NewUniterAPIV3)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -1144,19 +1156,30 @@ func (u *UniterAPIV3) EnterScope(args params.RelationUnits) (params.ErrorResults
 		}
 		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, tag)
 		if err == nil {
-			// Construct the settings, passing the unit's
-			// private address (we already know it).
-			privateAddress, _ := relUnit.PrivateAddress()
-			settings := map[string]interface{}{
-				"private-address": privateAddress.Value,
-			}
-			err = relUnit.EnterScope(settings)
+			err = u.enterScope(relUnit)
 		}
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result, nil
 }
 
+// enterScope enters the relation unit into its scope, passing the unit's
+// private address (we already know it) in its settings. A unit that is
+// re-entering the scope of a resumed relation keeps the rest of the
+// settings it had before the relation was suspended.
+func (u *UniterAPIV3) enterScope(relUnit *state.RelationUnit) error {
+	settings := make(map[string]interface{})
+	existing, err := relUnit.Settings()
+	if err == nil {
+		settings = existing.Map()
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	privateAddress, _ := relUnit.PrivateAddress()
+	settings["private-address"] = privateAddress.Value
+	return relUnit.EnterScope(settings)
+}
+
 // LeaveScope signals each unit has left its scope in the relation,
 // for all of the given relation/unit pairs. See also
 // state.RelationUnit.LeaveScope().
@@ -1410,9 +1433,10 @@ func (u *UniterAPIV3) prepareRelationResult(rel *state.Relation, unit *state.Uni
 		return nothing, err
 	}
 	return params.RelationResult{
-		Id:   rel.Id(),
-		Key:  rel.String(),
-		Life: params.Life(rel.Life().String()),
+		Id:        rel.Id(),
+		Key:       rel.String(),
+		Life:      params.Life(rel.Life().String()),
+		Suspended: rel.Suspended(),
 		Endpoint: multiwatcher.Endpoint{
 			ServiceName: ep.ServiceName,
 			Relation:    ep.Relation,
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
+	args := params.EntityWorkloadVersions{Entities: []params.EntityWorkloadVersion{
+		{Tag: "unit-wordpress-0", WorkloadVersion: "4.5.2"},
+		{Tag: "unit-mysql-0", WorkloadVersion: "5.7"},
+		{Tag: "service-wordpress", WorkloadVersion: "4.5.2"},
+		{Tag: "unit-foo-42", WorkloadVersion: "1.0"},
+	}}
+	result, err := s.uniter.SetWorkloadVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.WorkloadVersion(), gc.Equals, "4.5.2")
+}
+
+func (s *uniterSuite) TestSetWorkloadInfo(c *gc.C) {
+	args := params.EntitiesWorkloadInfo{Entities: []params.EntityWorkloadInfo{
+		{Tag: "unit-wordpress-0", Info: map[string]string{"edition": "enterprise"}},
+		{Tag: "unit-wordpress-0", Info: map[string]string{"bad.key": "x"}},
+		{Tag: "unit-mysql-0", Info: map[string]string{"edition": "enterprise"}},
+		{Tag: "service-wordpress", Info: map[string]string{"edition": "enterprise"}},
+		{Tag: "unit-foo-42", Info: map[string]string{"edition": "enterprise"}},
+	}}
+	result, err := s.uniter.SetWorkloadInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{&params.Error{Message: `cannot set workload info for unit "wordpress/0": key "bad.key" not valid`}},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.WorkloadInfo(), jc.DeepEquals, map[string]string{"edition": "enterprise"})
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cmd/juju/charmcmd"
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/cmd/juju/crossmodel"
 	"github.com/juju/juju/cmd/juju/helptopics"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
@@ -139,6 +140,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewModelStatusHistoryCommand())
+	r.Register(status.NewShowHookHistoryCommand())
+	r.Register(service.NewShowRelationCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
@@ -147,6 +151,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
 	r.Register(newDebugHooksCommand())
+	r.Register(newReplayHookCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -196,6 +201,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewSetCommand())
 	r.Register(model.NewUnsetCommand())
 	r.Register(model.NewRetryProvisioningCommand())
+	r.Register(model.NewFirewallReportCommand())
 	r.Register(model.NewDestroyCommand())
 
 	r.Register(model.NewShareCommand())
@@ -214,14 +220,25 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage and control services
 	r.Register(service.NewAddUnitCommand())
+	r.Register(service.NewSuspendRelationCommand())
+	r.Register(service.NewResumeRelationCommand())
 	r.Register(service.NewGetCommand())
 	r.Register(service.NewSetCommand())
 	r.Register(service.NewDeployCommand())
 	r.Register(service.NewExposeCommand())
 	r.Register(service.NewUnexposeCommand())
+	r.Register(service.NewLeaderTransferCommand())
+	r.Register(service.NewLeaderPinCommand())
+	r.Register(service.NewLeaderUnpinCommand())
+	r.Register(service.NewShowLeadershipCommand())
 	r.Register(service.NewServiceGetConstraintsCommand())
 	r.Register(service.NewServiceSetConstraintsCommand())
 
+	// Cross-model relation commands
+	r.Register(crossmodel.NewOfferCommand())
+	r.Register(crossmodel.NewListOffersCommand())
+	r.Register(crossmodel.NewConsumeCommand())
+
 	// Operation protection commands
 	r.Register(block.NewSuperBlockCommand())
 	r.Register(block.NewUnblockCommand())
@@ -231,6 +248,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.RegisterSuperAlias("list-storage", "storage", "list", nil)
 	r.RegisterSuperAlias("show-storage", "storage", "show", nil)
 	r.RegisterSuperAlias("add-storage", "storage", "add", nil)
+	r.Register(storage.NewCreateSnapshotCommand())
+	r.Register(storage.NewListSnapshotsCommand())
+	r.Register(storage.NewRemoveSnapshotCommand())
+	r.Register(storage.NewRestoreSnapshotCommand())
+	r.Register(storage.NewResizeCommand())
+	r.Register(storage.NewDetachCommand())
+	r.Register(storage.NewAttachCommand())
+	r.Register(storage.NewUsageCommand())
 
 	// Manage spaces
 	r.Register(space.NewSuperCommand())
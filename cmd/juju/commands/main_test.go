@@ -208,6 +208,7 @@ var commandNames = []string{
 	"add-storage",
 	"add-subnet",
 	"add-user",
+	"attach-storage",
 	"autoload-credentials",
 	"backups",
 	"block",
@@ -216,9 +217,11 @@ var commandNames = []string{
 	"change-user-password",
 	"charm",
 	"collect-metrics",
+	"consume",
 	"create-backup",
 	"create-budget",
 	"create-model",
+	"create-storage-snapshot",
 	"debug-hooks",
 	"debug-log",
 	"debug-metrics",
@@ -228,10 +231,12 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-service",
 	"destroy-unit",
+	"detach-storage",
 	"disable-user",
 	"enable-ha",
 	"enable-user",
 	"expose",
+	"firewall-report",
 	"get-config",
 	"get-configs",
 	"get-constraints",
@@ -242,6 +247,9 @@ var commandNames = []string{
 	"import-ssh-key",
 	"import-ssh-keys",
 	"kill-controller",
+	"leader-pin",
+	"leader-transfer",
+	"leader-unpin",
 	"list-actions",
 	"list-all-blocks",
 	"list-budgets",
@@ -251,15 +259,20 @@ var commandNames = []string{
 	"list-machine",
 	"list-machines",
 	"list-models",
+	"list-offers",
 	"list-plans",
 	"list-shares",
 	"list-ssh-key",
 	"list-ssh-keys",
 	"list-spaces",
 	"list-storage",
+	"list-storage-snapshots",
 	"list-users",
 	"machine",
 	"machines",
+	"model-status-history",
+	"offer",
+	"offers",
 	"publish",
 	"register",
 	"remove-all-blocks",
@@ -269,9 +282,14 @@ var commandNames = []string{
 	"remove-service",  // alias for destroy-service
 	"remove-ssh-key",
 	"remove-ssh-keys",
+	"remove-storage-snapshot",
 	"remove-unit", // alias for destroy-unit
+	"replay-hook",
+	"resize-storage",
 	"resolved",
 	"restore-backup",
+	"restore-storage-snapshot",
+	"resume-relation",
 	"retry-provisioning",
 	"run",
 	"run-action",
@@ -295,10 +313,14 @@ var commandNames = []string{
 	"show-cloud",
 	"show-controller",
 	"show-controllers",
+	"show-hook-history",
+	"show-leadership",
 	"show-machine",
 	"show-machines",
+	"show-relation",
 	"show-status",
 	"show-storage",
+	"show-storage-usage",
 	"show-user",
 	"space",
 	"ssh",
@@ -306,6 +328,7 @@ var commandNames = []string{
 	"status-history",
 	"storage",
 	"subnet",
+	"suspend-relation",
 	"switch",
 	"switch-user",
 	"sync-tools",
@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/names"
+	"github.com/juju/utils"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+// unitDataDir is the data directory of unit agents. Like debug-hooks,
+// replay-hook is only supported on units of Ubuntu and other Linux
+// series, which all share it.
+var unitDataDir = paths.MustSucceed(paths.DataDir(config.LatestLtsSeries()))
+
+func newReplayHookCommand() cmd.Command {
+	return modelcmd.Wrap(&replayHookCommand{})
+}
+
+// replayHookCommand replays a failed hook on a unit, over ssh.
+type replayHookCommand struct {
+	sshCommand
+	hookName   string
+	relationId int
+}
+
+const replayHookDoc = `
+Run a failed hook of a unit again, with the hook tools answering from the
+context captured when the hook failed, rather than from the controller.
+If no hook is specified, the unit's most recently failed hook is replayed.
+A relation hook is replayed for the relation in which it most recently
+failed, unless --relation is given.
+The output of the replayed hook is shown, and its exit code returned.
+
+Hook contexts are only captured when the model's capture-failed-hooks
+setting is true. Changes the replayed hook makes via the hook tools are
+not applied, but changes it makes to the machine are not undone.
+
+Examples:
+    juju set-model-config capture-failed-hooks=true
+    juju replay-hook mysql/0
+    juju replay-hook mysql/0 db-relation-changed
+    juju replay-hook --relation 3 mysql/0 db-relation-changed
+
+See Also:
+    juju help debug-hooks
+    juju help show-hook-history
+`
+
+func (c *replayHookCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "replay-hook",
+		Args:    "<unit name> [<hook name>]",
+		Purpose: "replay a failed hook against its captured context",
+		Doc:     replayHookDoc,
+	}
+}
+
+func (c *replayHookCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.IntVar(&c.relationId, "relation", -1, "id of the relation for which to replay a relation hook")
+}
+
+func (c *replayHookCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("no unit name specified")
+	}
+	c.Target, args = args[0], args[1:]
+	if !names.IsValidUnit(c.Target) {
+		return fmt.Errorf("%q is not a valid unit name", c.Target)
+	}
+	if len(args) > 0 {
+		c.hookName, args = args[0], args[1:]
+		if !replay.IsValidHookName(c.hookName) {
+			return fmt.Errorf("%q is not a valid hook name", c.hookName)
+		}
+	}
+	if c.relationId >= 0 && c.hookName == "" {
+		return fmt.Errorf("--relation requires a hook name")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run connects to the unit's machine via SSH and runs the unit agent's
+// replay-hook command there.
+func (c *replayHookCommand) Run(ctx *cmd.Context) error {
+	var err error
+	c.apiClient, err = c.initAPIClient()
+	if err != nil {
+		return err
+	}
+	defer c.apiClient.Close()
+	unitTag := names.NewUnitTag(c.Target)
+	jujud := path.Join(tools.ToolsDir(unitDataDir, unitTag.String()), "jujud")
+	command := fmt.Sprintf("sudo %s replay-hook", utils.ShQuote(jujud))
+	if c.relationId >= 0 {
+		command += " --relation " + strconv.Itoa(c.relationId)
+	}
+	command += " " + utils.ShQuote(c.Target)
+	if c.hookName != "" {
+		command += " " + utils.ShQuote(c.hookName)
+	}
+	c.Args = []string{command}
+	return c.sshCommand.Run(ctx)
+}
@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&ReplayHookSuite{})
+
+type ReplayHookSuite struct {
+	SSHCommonSuite
+}
+
+var replayHookTests = []struct {
+	about  string
+	args   []string
+	result string
+	error  string
+}{{
+	about:  "replay the latest failed hook",
+	args:   []string{"replay-hook", "--proxy=false", "mysql/0"},
+	result: sshArgsNoProxy + "ubuntu@dummymodel-0.dns sudo '/var/lib/juju/tools/unit-mysql-0/jujud' replay-hook 'mysql/0'",
+}, {
+	about:  "replay a named hook",
+	args:   []string{"replay-hook", "--proxy=false", "mysql/0", "config-changed"},
+	result: sshArgsNoProxy + "ubuntu@dummymodel-0.dns sudo '/var/lib/juju/tools/unit-mysql-0/jujud' replay-hook 'mysql/0' 'config-changed'",
+}, {
+	about:  "replay a relation hook for a given relation",
+	args:   []string{"replay-hook", "--proxy=false", "--relation", "3", "mysql/0", "db-relation-changed"},
+	result: sshArgsNoProxy + "ubuntu@dummymodel-0.dns sudo '/var/lib/juju/tools/unit-mysql-0/jujud' replay-hook --relation 3 'mysql/0' 'db-relation-changed'",
+}, {
+	about: "invalid hook name",
+	args:  []string{"replay-hook", "mysql/0", "../../etc/passwd"},
+	error: `error: "../../etc/passwd" is not a valid hook name`,
+}, {
+	about: "relation without hook",
+	args:  []string{"replay-hook", "--relation", "3", "mysql/0"},
+	error: "error: --relation requires a hook name",
+}, {
+	about: "no unit",
+	args:  []string{"replay-hook"},
+	error: "error: no unit name specified",
+}, {
+	about: "invalid unit name",
+	args:  []string{"replay-hook", "mysql"},
+	error: `error: "mysql" is not a valid unit name`,
+}, {
+	about: "more than one hook",
+	args:  []string{"replay-hook", "mysql/0", "start", "stop"},
+	error: `error: unrecognized args: ["stop"]`,
+}, {
+	about: "unknown unit",
+	args:  []string{"replay-hook", "--proxy=false", "nonexistent/123"},
+	error: `error: unit "nonexistent/123" not found (not found)`,
+}}
+
+func (s *ReplayHookSuite) TestReplayHookCommand(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("replay-hook is not supported on windows")
+	}
+	m := s.makeMachines(1, c, true)
+	dummy := s.AddTestingCharm(c, "dummy")
+	srv := s.AddTestingService(c, "mysql", dummy)
+	s.addUnit(srv, m[0], c)
+
+	for i, t := range replayHookTests {
+		c.Logf("test %d: %s -> %s", i, t.about, t.args)
+		ctx := coretesting.Context(c)
+		jujucmd := cmd.NewSuperCommand(cmd.SuperCommandParams{})
+		jujucmd.Register(newReplayHookCommand())
+
+		code := cmd.Main(jujucmd, ctx, t.args)
+		stdout := strings.TrimRight(ctx.Stdout.(*bytes.Buffer).String(), "\r\n")
+		stderr := strings.TrimRight(ctx.Stderr.(*bytes.Buffer).String(), "\r\n")
+		if t.error != "" {
+			c.Check(code, gc.Not(gc.Equals), 0)
+			c.Check(stderr, jc.Contains, t.error)
+			continue
+		}
+		c.Check(code, gc.Equals, 0)
+		c.Check(stderr, gc.Equals, "")
+		c.Check(stdout, gc.Equals, t.result)
+	}
+}
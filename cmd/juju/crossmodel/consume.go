@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/api/crossmodel"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var consumeDoc = `
+Adds a remote service to the current model, representing a service
+offered by another model on the same controller. The remote service may
+then be related to the model's services with add-relation, like any other
+service; relation settings are passed between the models, and the remote
+service's units appear to the related units as usual.
+
+The offer is identified by a URL of the form [<owner>/]<model>.<offer>,
+as shown by list-offers in the offering model; the owner defaults to the
+current user. The remote service is named after the offer unless another
+name is given.
+
+Examples:
+    juju consume admin@local/shared.db
+    juju consume shared.db wiki-db
+
+See Also:
+   juju help offer
+   juju help add-relation
+`
+
+// NewConsumeCommand returns a command which consumes an offer made by
+// another model.
+func NewConsumeCommand() cmd.Command {
+	return modelcmd.Wrap(&consumeCommand{})
+}
+
+type consumeAPI interface {
+	Close() error
+	Consume(offerURL, serviceName string) (string, error)
+}
+
+type consumeCommand struct {
+	modelcmd.ModelCommandBase
+	OfferURL    string
+	ServiceName string
+
+	api consumeAPI
+}
+
+func (c *consumeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "consume",
+		Args:    "<offer URL> [<service name>]",
+		Purpose: "add a remote service offered by another model",
+		Doc:     consumeDoc,
+	}
+}
+
+func (c *consumeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer URL specified")
+	}
+	c.OfferURL, args = args[0], args[1:]
+	if len(args) > 0 {
+		if !names.IsValidService(args[0]) {
+			return fmt.Errorf("invalid service name %q", args[0])
+		}
+		c.ServiceName, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *consumeCommand) getAPI() (consumeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return crossmodel.NewClient(root), nil
+}
+
+func (c *consumeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	name, err := client.Consume(c.OfferURL, c.ServiceName)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Added remote service %q", name)
+	return nil
+}
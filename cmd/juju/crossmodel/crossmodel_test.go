@@ -0,0 +1,199 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/crossmodel"
+	"github.com/juju/juju/testing"
+)
+
+type CrossModelCommandsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	stub *jujutesting.Stub
+	fake *fakeCrossModelAPI
+}
+
+var _ = gc.Suite(&CrossModelCommandsSuite{})
+
+func (s *CrossModelCommandsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.fake = &fakeCrossModelAPI{stub: s.stub}
+}
+
+func (s *CrossModelCommandsSuite) TestOfferInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no service specified`,
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid service name "mysql/0"`,
+	}, {
+		args: []string{"mysql:"},
+		err:  `invalid endpoints in "mysql:"`,
+	}, {
+		args: []string{"mysql", "db/0"},
+		err:  `invalid offer name "db/0"`,
+	}, {
+		args: []string{"mysql", "db", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql", "--users", "bob,-"},
+		err:  `invalid value "bob,-" for flag --users: invalid user name "-"`,
+	}, {
+		args: []string{"mysql:server,admin", "db", "--users", "bob"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(crossmodel.NewOfferCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *CrossModelCommandsSuite) TestOffer(c *gc.C) {
+	_, err := testing.RunCommand(c, crossmodel.NewOfferCommandForTest(s.fake), "mysql:server", "db", "--users", "bob,mary")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Offer", "Close")
+	s.stub.CheckCall(c, 0, "Offer", "db", "mysql", []string{"server"}, []names.UserTag{
+		names.NewUserTag("bob"), names.NewUserTag("mary"),
+	})
+}
+
+func (s *CrossModelCommandsSuite) TestOfferError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	_, err := testing.RunCommand(c, crossmodel.NewOfferCommandForTest(s.fake), "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.stub.CheckCall(c, 0, "Offer", "", "mysql", []string(nil), []names.UserTag(nil))
+}
+
+func (s *CrossModelCommandsSuite) TestListOffersTabular(c *gc.C) {
+	s.fake.offers = []params.OfferDetails{{
+		URL:         "admin@local/shared.mysql",
+		Name:        "mysql",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+	}, {
+		URL:         "admin@local/shared.db",
+		Name:        "db",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server", "admin"},
+		UserTags:    []string{"user-bob@local"},
+	}}
+	ctx, err := testing.RunCommand(c, crossmodel.NewListOffersCommandForTest(s.fake))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"OFFER  SERVICE  ENDPOINTS     URL                       USERS\n"+
+		"db     mysql    server,admin  admin@local/shared.db     bob@local\n"+
+		"mysql  mysql    server        admin@local/shared.mysql  -\n",
+	)
+	s.stub.CheckCallNames(c, "ListOffers", "Close")
+}
+
+func (s *CrossModelCommandsSuite) TestListOffersYAML(c *gc.C) {
+	s.fake.offers = []params.OfferDetails{{
+		URL:         "admin@local/shared.db",
+		Name:        "db",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+		UserTags:    []string{"user-bob@local"},
+	}}
+	ctx, err := testing.RunCommand(c, crossmodel.NewListOffersCommandForTest(s.fake), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"db:\n"+
+		"  url: admin@local/shared.db\n"+
+		"  service: mysql\n"+
+		"  endpoints:\n"+
+		"  - server\n"+
+		"  users:\n"+
+		"  - bob@local\n",
+	)
+}
+
+func (s *CrossModelCommandsSuite) TestConsumeInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no offer URL specified`,
+	}, {
+		args: []string{"shared.db", "db/0"},
+		err:  `invalid service name "db/0"`,
+	}, {
+		args: []string{"shared.db", "db", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"admin@local/shared.db", "wiki-db"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(crossmodel.NewConsumeCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *CrossModelCommandsSuite) TestConsume(c *gc.C) {
+	ctx, err := testing.RunCommand(c, crossmodel.NewConsumeCommandForTest(s.fake), "shared.db", "wiki-db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "Added remote service \"wiki-db\"\n")
+	s.stub.CheckCallNames(c, "Consume", "Close")
+	s.stub.CheckCall(c, 0, "Consume", "shared.db", "wiki-db")
+}
+
+func (s *CrossModelCommandsSuite) TestConsumeError(c *gc.C) {
+	s.stub.SetErrors(&params.Error{Message: "permission denied", Code: params.CodeUnauthorized})
+	_, err := testing.RunCommand(c, crossmodel.NewConsumeCommandForTest(s.fake), "shared.db")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeCrossModelAPI struct {
+	stub   *jujutesting.Stub
+	offers []params.OfferDetails
+}
+
+func (f *fakeCrossModelAPI) Close() error {
+	f.stub.AddCall("Close")
+	return nil
+}
+
+func (f *fakeCrossModelAPI) Offer(offerName, serviceName string, endpoints []string, users []names.UserTag) error {
+	f.stub.AddCall("Offer", offerName, serviceName, endpoints, users)
+	return f.stub.NextErr()
+}
+
+func (f *fakeCrossModelAPI) ListOffers() ([]params.OfferDetails, error) {
+	f.stub.AddCall("ListOffers")
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.offers, nil
+}
+
+func (f *fakeCrossModelAPI) Consume(offerURL, serviceName string) (string, error) {
+	f.stub.AddCall("Consume", offerURL, serviceName)
+	if err := f.stub.NextErr(); err != nil {
+		return "", err
+	}
+	if serviceName == "" {
+		serviceName = "db"
+	}
+	return serviceName, nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewOfferCommandForTest returns an offer command with the api provided
+// as specified.
+func NewOfferCommandForTest(api offerAPI) cmd.Command {
+	return modelcmd.Wrap(&offerCommand{api: api})
+}
+
+// NewListOffersCommandForTest returns a list-offers command with the api
+// provided as specified.
+func NewListOffersCommandForTest(api listOffersAPI) cmd.Command {
+	return modelcmd.Wrap(&listOffersCommand{api: api})
+}
+
+// NewConsumeCommandForTest returns a consume command with the api
+// provided as specified.
+func NewConsumeCommandForTest(api consumeAPI) cmd.Command {
+	return modelcmd.Wrap(&consumeCommand{api: api})
+}
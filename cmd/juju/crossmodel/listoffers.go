@@ -0,0 +1,168 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/crossmodel"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var listOffersDoc = `
+Lists the offers made by the current model, with the URLs by which other
+models consume them.
+
+Examples:
+    juju list-offers
+    juju offers --format yaml
+
+See Also:
+   juju help offer
+   juju help consume
+`
+
+// NewListOffersCommand returns a command which lists the offers made by
+// the model.
+func NewListOffersCommand() cmd.Command {
+	return modelcmd.Wrap(&listOffersCommand{})
+}
+
+type listOffersAPI interface {
+	Close() error
+	ListOffers() ([]params.OfferDetails, error)
+}
+
+type listOffersCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	api listOffersAPI
+}
+
+func (c *listOffersCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-offers",
+		Purpose: "list the offers made by a model",
+		Doc:     listOffersDoc,
+		Aliases: []string{"offers"},
+	}
+}
+
+func (c *listOffersCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOffersTabular,
+	})
+}
+
+func (c *listOffersCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listOffersCommand) getAPI() (listOffersAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return crossmodel.NewClient(root), nil
+}
+
+func (c *listOffersCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	offers, err := client.ListOffers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	formatted, err := formatOffers(offers)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// FormattedOffer describes an offer made by a model.
+type FormattedOffer struct {
+	URL       string   `yaml:"url" json:"url"`
+	Service   string   `yaml:"service" json:"service"`
+	Endpoints []string `yaml:"endpoints" json:"endpoints"`
+	Users     []string `yaml:"users,omitempty" json:"users,omitempty"`
+}
+
+func formatOffers(offers []params.OfferDetails) (map[string]FormattedOffer, error) {
+	formatted := make(map[string]FormattedOffer)
+	for _, offer := range offers {
+		var users []string
+		for _, userTag := range offer.UserTags {
+			user, err := names.ParseUserTag(userTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			users = append(users, user.Canonical())
+		}
+		formatted[offer.Name] = FormattedOffer{
+			URL:       offer.URL,
+			Service:   offer.ServiceName,
+			Endpoints: offer.Endpoints,
+			Users:     users,
+		}
+	}
+	return formatted, nil
+}
+
+func formatOffersTabular(value interface{}) ([]byte, error) {
+	offers, ok := value.(map[string]FormattedOffer)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", offers, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+
+	var offerNames []string
+	for name := range offers {
+		offerNames = append(offerNames, name)
+	}
+	sort.Strings(offerNames)
+	fmt.Fprintln(tw, "OFFER\tSERVICE\tENDPOINTS\tURL\tUSERS")
+	for _, name := range offerNames {
+		offer := offers[name]
+		users := strings.Join(offer.Users, ",")
+		if users == "" {
+			users = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			name, offer.Service, strings.Join(offer.Endpoints, ","), offer.URL, users,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodel provides the commands with which services are
+// offered by one model and consumed by another on the same controller.
+package crossmodel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/crossmodel"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var offerDoc = `
+Offers a service in the current model for consumption by other models on
+the same controller. Only the listed endpoints may be related to by
+consumers; if none are listed, all of the service's provider and requirer
+endpoints are offered.
+
+The offer is known by the service name unless another name is given. Other
+models consume it with the URL <owner>/<model>.<offer>, as shown by
+list-offers.
+
+The model owner may always consume the model's offers. Other users may
+consume an offer only if listed with --users.
+
+Examples:
+    juju offer mysql
+    juju offer mysql:server db --users bob,mary
+
+See Also:
+   juju help list-offers
+   juju help consume
+`
+
+// NewOfferCommand returns a command which offers a service for
+// consumption by other models.
+func NewOfferCommand() cmd.Command {
+	return modelcmd.Wrap(&offerCommand{})
+}
+
+type offerAPI interface {
+	Close() error
+	Offer(offerName, serviceName string, endpoints []string, users []names.UserTag) error
+}
+
+type offerCommand struct {
+	modelcmd.ModelCommandBase
+	ServiceName string
+	Endpoints   []string
+	OfferName   string
+	Users       []names.UserTag
+
+	api offerAPI
+}
+
+func (c *offerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "offer",
+		Args:    "<service>[:<endpoint>[,<endpoint>...]] [<offer name>]",
+		Purpose: "offer a service for consumption by other models",
+		Doc:     offerDoc,
+	}
+}
+
+func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(userTagsValue{&c.Users}, "users", "comma-separated users, other than the model owner, that may consume the offer")
+}
+
+func (c *offerCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no service specified")
+	}
+	service := args[0]
+	if i := strings.Index(service, ":"); i >= 0 {
+		for _, ep := range strings.Split(service[i+1:], ",") {
+			if ep == "" {
+				return fmt.Errorf("invalid endpoints in %q", service)
+			}
+			c.Endpoints = append(c.Endpoints, ep)
+		}
+		service = service[:i]
+	}
+	if !names.IsValidService(service) {
+		return fmt.Errorf("invalid service name %q", service)
+	}
+	c.ServiceName = service
+	args = args[1:]
+	if len(args) > 0 {
+		if !names.IsValidService(args[0]) {
+			return fmt.Errorf("invalid offer name %q", args[0])
+		}
+		c.OfferName, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *offerCommand) getAPI() (offerAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return crossmodel.NewClient(root), nil
+}
+
+func (c *offerCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.Offer(c.OfferName, c.ServiceName, c.Endpoints, c.Users)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// userTagsValue implements gnuflag.Value for a comma-separated list of
+// user names.
+type userTagsValue struct {
+	tags *[]names.UserTag
+}
+
+func (v userTagsValue) Set(s string) error {
+	var tags []names.UserTag
+	for _, name := range strings.Split(s, ",") {
+		if !names.IsValidUser(name) {
+			return fmt.Errorf("invalid user name %q", name)
+		}
+		tags = append(tags, names.NewUserTag(name))
+	}
+	*v.tags = tags
+	return nil
+}
+
+func (v userTagsValue) String() string {
+	if v.tags == nil {
+		return ""
+	}
+	users := make([]string, len(*v.tags))
+	for i, tag := range *v.tags {
+		users[i] = tag.Id()
+	}
+	return strings.Join(users, ",")
+}
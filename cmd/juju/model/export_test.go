@@ -85,3 +85,11 @@ func NewDestroyCommandForTest(api DestroyEnvironmentAPI, store jujuclient.Client
 		modelcmd.ModelSkipFlags,
 	)
 }
+
+// NewFirewallReportCommandForTest returns a FirewallReportCommand with the api provided as specified.
+func NewFirewallReportCommandForTest(api FirewallReportAPI) cmd.Command {
+	cmd := &firewallReportCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd)
+}
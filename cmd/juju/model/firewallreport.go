@@ -0,0 +1,222 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/firewallreport"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var firewallReportDoc = `
+Compares the ingress rules the firewaller wants applied, as computed from
+the ports opened by units of exposed services, with the rules the provider
+actually applies. Nothing is changed.
+
+When the model uses the "instance" firewall mode the report is made for
+each provisioned machine; when it uses the "global" firewall mode a single
+report is made for the whole model. Rules that are wanted but not applied
+are reported as missing; rules that are applied but not wanted are
+reported as extraneous.
+
+Examples:
+    juju firewall-report
+    juju firewall-report --format yaml
+`
+
+// NewFirewallReportCommand returns a command which reports firewall
+// drift between state and the provider.
+func NewFirewallReportCommand() cmd.Command {
+	return modelcmd.Wrap(&firewallReportCommand{})
+}
+
+// FirewallReportAPI defines the API methods that the firewall-report
+// command uses.
+type FirewallReportAPI interface {
+	Close() error
+	Report() (params.FirewallReportResult, error)
+}
+
+type firewallReportCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api FirewallReportAPI
+}
+
+func (c *firewallReportCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "firewall-report",
+		Purpose: "report differences between wanted and applied firewall rules",
+		Doc:     firewallReportDoc,
+	}
+}
+
+func (c *firewallReportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatFirewallReportTabular,
+	})
+}
+
+func (c *firewallReportCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *firewallReportCommand) getAPI() (FirewallReportAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return firewallreport.NewClient(root), nil
+}
+
+func (c *firewallReportCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.Report()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatFirewallReport(result))
+}
+
+// FormattedFirewallReport holds the firewall report of a model.
+type FormattedFirewallReport struct {
+	FirewallMode string                            `yaml:"firewall-mode" json:"firewall-mode"`
+	Global       *FormattedFirewallRules           `yaml:"global,omitempty" json:"global,omitempty"`
+	Machines     map[string]FormattedFirewallRules `yaml:"machines,omitempty" json:"machines,omitempty"`
+}
+
+// FormattedFirewallRules holds the wanted and applied ingress rules of a
+// machine, or of the whole model, and the differences between them.
+type FormattedFirewallRules struct {
+	InstanceId string   `yaml:"instance-id,omitempty" json:"instance-id,omitempty"`
+	Wanted     []string `yaml:"wanted" json:"wanted"`
+	Actual     []string `yaml:"actual" json:"actual"`
+	Missing    []string `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Extraneous []string `yaml:"extraneous,omitempty" json:"extraneous,omitempty"`
+	Error      string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func formatFirewallReport(result params.FirewallReportResult) FormattedFirewallReport {
+	formatted := FormattedFirewallReport{FirewallMode: result.FirewallMode}
+	if result.Global != nil {
+		rules := formatFirewallRules(*result.Global)
+		formatted.Global = &rules
+	}
+	if len(result.Machines) > 0 {
+		formatted.Machines = make(map[string]FormattedFirewallRules)
+		for _, report := range result.Machines {
+			tag, err := names.ParseMachineTag(report.MachineTag)
+			if err != nil {
+				continue
+			}
+			formatted.Machines[tag.Id()] = formatFirewallRules(report)
+		}
+	}
+	return formatted
+}
+
+func formatFirewallRules(report params.FirewallReport) FormattedFirewallRules {
+	formatted := FormattedFirewallRules{
+		InstanceId: report.InstanceId,
+		Wanted:     formatIngressRules(report.Wanted),
+		Actual:     formatIngressRules(report.Actual),
+		Missing:    formatIngressRules(report.Missing),
+		Extraneous: formatIngressRules(report.Extraneous),
+	}
+	if report.Error != nil {
+		formatted.Error = report.Error.Error()
+	}
+	return formatted
+}
+
+func formatIngressRules(rules []params.IngressRule) []string {
+	result := make([]string, len(rules))
+	for i, rule := range rules {
+		result[i] = rule.NetworkIngressRule().String()
+	}
+	return result
+}
+
+func formatFirewallReportTabular(value interface{}) ([]byte, error) {
+	report, ok := value.(FormattedFirewallReport)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", report, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+
+	fmt.Fprintf(tw, "FIREWALL MODE: %s\n", report.FirewallMode)
+	if report.Global == nil && len(report.Machines) == 0 {
+		tw.Flush()
+		return out.Bytes(), nil
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "MACHINE\tINSTANCE\tSTATUS\tMISSING\tEXTRANEOUS")
+	writeRow := func(machine string, rules FormattedFirewallRules) {
+		instanceId := rules.InstanceId
+		if instanceId == "" {
+			instanceId = "-"
+		}
+		status := "ok"
+		if rules.Error != "" {
+			status = "error: " + rules.Error
+		} else if len(rules.Missing) > 0 || len(rules.Extraneous) > 0 {
+			status = "drifted"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			machine, instanceId, status,
+			formatRuleList(rules.Missing), formatRuleList(rules.Extraneous),
+		)
+	}
+	if report.Global != nil {
+		writeRow("(global)", *report.Global)
+	}
+	var machines []string
+	for machine := range report.Machines {
+		machines = append(machines, machine)
+	}
+	sort.Strings(machines)
+	for _, machine := range machines {
+		writeRow(machine, report.Machines[machine])
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+func formatRuleList(rules []string) string {
+	if len(rules) == 0 {
+		return "-"
+	}
+	return strings.Join(rules, ", ")
+}
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type firewallReportSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeFirewallReportAPI
+}
+
+var _ = gc.Suite(&firewallReportSuite{})
+
+type fakeFirewallReportAPI struct {
+	result params.FirewallReportResult
+	err    error
+}
+
+func (f *fakeFirewallReportAPI) Close() error {
+	return nil
+}
+
+func (f *fakeFirewallReportAPI) Report() (params.FirewallReportResult, error) {
+	return f.result, f.err
+}
+
+func ingressRule(protocol string, port int, sourceCIDRs ...string) params.IngressRule {
+	return params.IngressRule{
+		PortRange:   params.PortRange{FromPort: port, ToPort: port, Protocol: protocol},
+		SourceCIDRs: sourceCIDRs,
+	}
+}
+
+func (s *firewallReportSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeFirewallReportAPI{
+		result: params.FirewallReportResult{
+			FirewallMode: "instance",
+			Machines: []params.FirewallReport{{
+				MachineTag: "machine-0",
+				InstanceId: "i-0",
+				Wanted:     []params.IngressRule{ingressRule("tcp", 80, "10.0.0.0/8"), ingressRule("tcp", 443, "10.0.0.0/8")},
+				Actual:     []params.IngressRule{ingressRule("tcp", 80, "10.0.0.0/8"), ingressRule("tcp", 8080)},
+				Missing:    []params.IngressRule{ingressRule("tcp", 443, "10.0.0.0/8")},
+				Extraneous: []params.IngressRule{ingressRule("tcp", 8080)},
+			}, {
+				MachineTag: "machine-1",
+				InstanceId: "i-1",
+				Wanted:     []params.IngressRule{ingressRule("tcp", 22)},
+				Actual:     []params.IngressRule{ingressRule("tcp", 22)},
+			}, {
+				MachineTag: "machine-2",
+				InstanceId: "i-2",
+				Error:      &params.Error{Message: "boom"},
+			}},
+		},
+	}
+}
+
+func (s *firewallReportSuite) runFirewallReport(c *gc.C, args ...string) (string, error) {
+	context, err := testing.RunCommand(c, model.NewFirewallReportCommandForTest(s.api), args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(context), nil
+}
+
+func (s *firewallReportSuite) TestInit(c *gc.C) {
+	_, err := s.runFirewallReport(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *firewallReportSuite) TestTabular(c *gc.C) {
+	out, err := s.runFirewallReport(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"FIREWALL MODE: instance\n"+
+		"\n"+
+		"MACHINE  INSTANCE  STATUS       MISSING                  EXTRANEOUS\n"+
+		"0        i-0       drifted      443/tcp from 10.0.0.0/8  8080/tcp\n"+
+		"1        i-1       ok           -                        -\n"+
+		"2        i-2       error: boom  -                        -\n")
+}
+
+func (s *firewallReportSuite) TestTabularGlobal(c *gc.C) {
+	s.api.result = params.FirewallReportResult{
+		FirewallMode: "global",
+		Global: &params.FirewallReport{
+			Wanted:     []params.IngressRule{ingressRule("tcp", 80), ingressRule("udp", 53)},
+			Actual:     []params.IngressRule{ingressRule("tcp", 22), ingressRule("tcp", 80)},
+			Missing:    []params.IngressRule{ingressRule("udp", 53)},
+			Extraneous: []params.IngressRule{ingressRule("tcp", 22)},
+		},
+	}
+	out, err := s.runFirewallReport(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"FIREWALL MODE: global\n"+
+		"\n"+
+		"MACHINE   INSTANCE  STATUS   MISSING  EXTRANEOUS\n"+
+		"(global)  -         drifted  53/udp   22/tcp\n")
+}
+
+func (s *firewallReportSuite) TestTabularNoFirewall(c *gc.C) {
+	s.api.result = params.FirewallReportResult{FirewallMode: "none"}
+	out, err := s.runFirewallReport(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "FIREWALL MODE: none\n")
+}
+
+func (s *firewallReportSuite) TestYAML(c *gc.C) {
+	out, err := s.runFirewallReport(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+firewall-mode: instance
+machines:
+  "0":
+    instance-id: i-0
+    wanted:
+    - 80/tcp from 10.0.0.0/8
+    - 443/tcp from 10.0.0.0/8
+    actual:
+    - 80/tcp from 10.0.0.0/8
+    - 8080/tcp
+    missing:
+    - 443/tcp from 10.0.0.0/8
+    extraneous:
+    - 8080/tcp
+  "1":
+    instance-id: i-1
+    wanted:
+    - 22/tcp
+    actual:
+    - 22/tcp
+  "2":
+    instance-id: i-2
+    wanted: []
+    actual: []
+    error: boom
+`[1:])
+}
+
+func (s *firewallReportSuite) TestError(c *gc.C) {
+	s.api.err = errors.New("kaboom")
+	_, err := s.runFirewallReport(c)
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
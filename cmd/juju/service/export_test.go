@@ -5,6 +5,7 @@ package service
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -29,3 +30,54 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 		api: api,
 	})
 }
+
+// NewLeaderTransferCommandForTest returns a leader-transfer command with
+// the api provided as specified.
+func NewLeaderTransferCommandForTest(api serviceLeadershipAPI) cmd.Command {
+	return modelcmd.Wrap(&leaderTransferCommand{
+		leadershipCommand: leadershipCommand{api: api},
+	})
+}
+
+// NewLeaderPinCommandForTest returns a leader-pin command with the api
+// provided as specified.
+func NewLeaderPinCommandForTest(api serviceLeadershipAPI) cmd.Command {
+	return modelcmd.Wrap(&leaderPinCommand{
+		leadershipCommand: leadershipCommand{api: api},
+	})
+}
+
+// NewLeaderUnpinCommandForTest returns a leader-unpin command with the api
+// provided as specified.
+func NewLeaderUnpinCommandForTest(api serviceLeadershipAPI) cmd.Command {
+	return modelcmd.Wrap(&leaderUnpinCommand{
+		leadershipCommand: leadershipCommand{api: api},
+	})
+}
+
+// NewShowLeadershipCommandForTest returns a show-leadership command with
+// the api and model tag provided as specified.
+func NewShowLeadershipCommandForTest(api showLeadershipAPI, modelTag names.ModelTag) cmd.Command {
+	return modelcmd.Wrap(&showLeadershipCommand{
+		api:      api,
+		modelTag: modelTag,
+	})
+}
+
+// NewShowRelationCommandForTest returns a show-relation command with the
+// api provided as specified.
+func NewShowRelationCommandForTest(api showRelationAPI) cmd.Command {
+	return modelcmd.Wrap(&showRelationCommand{api: api})
+}
+
+// NewSuspendRelationCommandForTest returns a suspend-relation command
+// with the api provided as specified.
+func NewSuspendRelationCommandForTest(api suspendRelationAPI) cmd.Command {
+	return modelcmd.Wrap(&suspendRelationCommand{api: api})
+}
+
+// NewResumeRelationCommandForTest returns a resume-relation command with
+// the api provided as specified.
+func NewResumeRelationCommandForTest(api resumeRelationAPI) cmd.Command {
+	return modelcmd.Wrap(&resumeRelationCommand{api: api})
+}
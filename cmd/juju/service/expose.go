@@ -6,6 +6,7 @@ package service
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/service"
 	"github.com/juju/juju/cmd/juju/block"
@@ -20,13 +21,22 @@ func NewExposeCommand() cmd.Command {
 // exposeCommand is responsible exposing services.
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
-	ServiceName string
+	ServiceName    string
+	ExposeToCIDRs  []string
+	ExposeToSpaces []string
 }
 
 var jujuExposeHelp = `
 Adjusts firewall rules and similar security mechanisms of the provider, to
 allow the service to be accessed on its public address.
 
+By default the service is exposed to any source address. Use --to-cidrs
+and/or --to-spaces to restrict access to the given source CIDRs and to
+the subnets of the given spaces, e.g.
+
+    juju expose --to-cidrs 10.0.0.0/8 mysql
+    juju expose --to-spaces internal,dmz mysql
+
 `
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -38,6 +48,11 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(cmd.NewStringsValue(nil, &c.ExposeToCIDRs), "to-cidrs", "only expose to these comma-separated source CIDRs")
+	f.Var(cmd.NewStringsValue(nil, &c.ExposeToSpaces), "to-spaces", "only expose to the subnets of these comma-separated spaces")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no service name specified")
@@ -49,6 +64,7 @@ func (c *exposeCommand) Init(args []string) error {
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeTo(serviceName string, cidrs, spaces []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -68,5 +84,12 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ServiceName), block.BlockChange)
+	if len(c.ExposeToCIDRs) == 0 && len(c.ExposeToSpaces) == 0 {
+		return block.ProcessBlockedError(client.Expose(c.ServiceName), block.BlockChange)
+	}
+	err = client.ExposeTo(c.ServiceName, c.ExposeToCIDRs, c.ExposeToSpaces)
+	if errors.IsNotImplemented(err) {
+		return errors.New("this controller does not support exposing services to specific CIDRs or spaces")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
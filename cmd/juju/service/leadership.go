@@ -0,0 +1,217 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var leaderTransferDoc = `
+Hands leadership of a service over to one of its units. The current
+leader is not allowed to extend its leadership, and is told it has been
+deposed once its lease runs out; the chosen unit is then elected leader.
+No other unit may become leader while the transfer is in progress.
+
+If the chosen unit does not take up leadership within a few minutes,
+for example because its agent is down, normal leader election resumes.
+
+Leadership cannot be transferred while it is pinned.
+
+Examples:
+    juju leader-transfer mysql mysql/2
+
+See Also:
+   juju help leader-pin
+`
+
+var leaderPinDoc = `
+Pins leadership of a service to its current leader. While pinned, the
+leader keeps its leadership even if its agent stops renewing it, so
+maintenance such as a database failover does not trigger an election.
+Use leader-unpin to allow leadership to change again.
+
+Examples:
+    juju leader-pin mysql
+
+See Also:
+   juju help leader-unpin
+   juju help leader-transfer
+`
+
+var leaderUnpinDoc = `
+Reverses the effect of leader-pin: if the service's leader stops renewing
+its leadership, a new leader will be elected.
+
+Examples:
+    juju leader-unpin mysql
+
+See Also:
+   juju help leader-pin
+`
+
+// NewLeaderTransferCommand returns a command which hands leadership of a
+// service over to one of its units.
+func NewLeaderTransferCommand() cmd.Command {
+	return modelcmd.Wrap(&leaderTransferCommand{})
+}
+
+// NewLeaderPinCommand returns a command which pins a service's leadership.
+func NewLeaderPinCommand() cmd.Command {
+	return modelcmd.Wrap(&leaderPinCommand{})
+}
+
+// NewLeaderUnpinCommand returns a command which unpins a service's
+// leadership.
+func NewLeaderUnpinCommand() cmd.Command {
+	return modelcmd.Wrap(&leaderUnpinCommand{})
+}
+
+type serviceLeadershipAPI interface {
+	Close() error
+	TransferLeadership(serviceName, unitName string) error
+	PinLeadership(serviceName string) error
+	UnpinLeadership(serviceName string) error
+}
+
+type leadershipCommand struct {
+	modelcmd.ModelCommandBase
+	ServiceName string
+	api         serviceLeadershipAPI
+}
+
+func (c *leadershipCommand) getAPI() (serviceLeadershipAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+// initService consumes the service name from the front of args, and
+// returns the remaining args.
+func (c *leadershipCommand) initService(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no service name specified")
+	}
+	if !names.IsValidService(args[0]) {
+		return nil, fmt.Errorf("invalid service name %q", args[0])
+	}
+	c.ServiceName = args[0]
+	return args[1:], nil
+}
+
+// run calls the supplied func with an API client, and reports any block
+// preventing the change.
+func (c *leadershipCommand) run(call func(serviceLeadershipAPI) error) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = call(client)
+	if errors.IsNotImplemented(err) {
+		return errors.New("this controller does not support changing service leadership")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+type leaderTransferCommand struct {
+	leadershipCommand
+	UnitName string
+}
+
+func (c *leaderTransferCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "leader-transfer",
+		Args:    "<service> <unit>",
+		Purpose: "hand service leadership over to a unit",
+		Doc:     leaderTransferDoc,
+	}
+}
+
+func (c *leaderTransferCommand) Init(args []string) error {
+	args, err := c.initService(args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("no unit specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return fmt.Errorf("invalid unit name %q", args[0])
+	}
+	c.UnitName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *leaderTransferCommand) Run(_ *cmd.Context) error {
+	return c.run(func(client serviceLeadershipAPI) error {
+		return client.TransferLeadership(c.ServiceName, c.UnitName)
+	})
+}
+
+type leaderPinCommand struct {
+	leadershipCommand
+}
+
+func (c *leaderPinCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "leader-pin",
+		Args:    "<service>",
+		Purpose: "prevent service leadership from changing",
+		Doc:     leaderPinDoc,
+	}
+}
+
+func (c *leaderPinCommand) Init(args []string) error {
+	args, err := c.initService(args)
+	if err != nil {
+		return err
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *leaderPinCommand) Run(_ *cmd.Context) error {
+	return c.run(func(client serviceLeadershipAPI) error {
+		return client.PinLeadership(c.ServiceName)
+	})
+}
+
+type leaderUnpinCommand struct {
+	leadershipCommand
+}
+
+func (c *leaderUnpinCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "leader-unpin",
+		Args:    "<service>",
+		Purpose: "allow service leadership to change again",
+		Doc:     leaderUnpinDoc,
+	}
+}
+
+func (c *leaderUnpinCommand) Init(args []string) error {
+	args, err := c.initService(args)
+	if err != nil {
+		return err
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *leaderUnpinCommand) Run(_ *cmd.Context) error {
+	return c.run(func(client serviceLeadershipAPI) error {
+		return client.UnpinLeadership(c.ServiceName)
+	})
+}
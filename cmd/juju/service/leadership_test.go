@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type LeadershipCommandsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	stub *jujutesting.Stub
+	fake *fakeServiceLeadershipAPI
+}
+
+var _ = gc.Suite(&LeadershipCommandsSuite{})
+
+func (s *LeadershipCommandsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.fake = &fakeServiceLeadershipAPI{stub: s.stub}
+}
+
+func (s *LeadershipCommandsSuite) TestTransferInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no service name specified`,
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid service name "mysql/0"`,
+	}, {
+		args: []string{"mysql"},
+		err:  `no unit specified`,
+	}, {
+		args: []string{"mysql", "mysql"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"mysql", "mysql/1", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql", "mysql/1"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(service.NewLeaderTransferCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *LeadershipCommandsSuite) TestPinInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no service name specified`,
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid service name "mysql/0"`,
+	}, {
+		args: []string{"mysql", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"mysql"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(service.NewLeaderPinCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+		err = testing.InitCommand(service.NewLeaderUnpinCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *LeadershipCommandsSuite) TestTransfer(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewLeaderTransferCommandForTest(s.fake), "mysql", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "TransferLeadership", "Close")
+	s.stub.CheckCall(c, 0, "TransferLeadership", "mysql", "mysql/1")
+}
+
+func (s *LeadershipCommandsSuite) TestPin(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewLeaderPinCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "PinLeadership", "Close")
+	s.stub.CheckCall(c, 0, "PinLeadership", "mysql")
+}
+
+func (s *LeadershipCommandsSuite) TestUnpin(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewLeaderUnpinCommandForTest(s.fake), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "UnpinLeadership", "Close")
+	s.stub.CheckCall(c, 0, "UnpinLeadership", "mysql")
+}
+
+func (s *LeadershipCommandsSuite) TestPinFailure(c *gc.C) {
+	s.stub.SetErrors(errors.New(`leadership is being transferred to "mysql/1"`))
+
+	_, err := testing.RunCommand(c, service.NewLeaderPinCommandForTest(s.fake), "mysql")
+
+	c.Check(err, gc.ErrorMatches, `leadership is being transferred to "mysql/1"`)
+	s.stub.CheckCallNames(c, "PinLeadership", "Close")
+}
+
+func (s *LeadershipCommandsSuite) TestPinNotSupported(c *gc.C) {
+	s.stub.SetErrors(errors.NotImplementedf("PinLeadership() (need V5+)"))
+
+	_, err := testing.RunCommand(c, service.NewLeaderPinCommandForTest(s.fake), "mysql")
+
+	c.Check(err, gc.ErrorMatches, "this controller does not support changing service leadership")
+	s.stub.CheckCallNames(c, "PinLeadership", "Close")
+}
+
+type fakeServiceLeadershipAPI struct {
+	stub *jujutesting.Stub
+}
+
+func (f *fakeServiceLeadershipAPI) Close() error {
+	f.stub.AddCall("Close")
+	return f.stub.NextErr()
+}
+
+func (f *fakeServiceLeadershipAPI) TransferLeadership(serviceName, unitName string) error {
+	f.stub.AddCall("TransferLeadership", serviceName, unitName)
+	return f.stub.NextErr()
+}
+
+func (f *fakeServiceLeadershipAPI) PinLeadership(serviceName string) error {
+	f.stub.AddCall("PinLeadership", serviceName)
+	return f.stub.NextErr()
+}
+
+func (f *fakeServiceLeadershipAPI) UnpinLeadership(serviceName string) error {
+	f.stub.AddCall("UnpinLeadership", serviceName)
+	return f.stub.NextErr()
+}
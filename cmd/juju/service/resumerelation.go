@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	apiservice "github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var resumeRelationDoc = `
+Resumes a relation suspended with suspend-relation. The units of both
+services join the relation again, with the settings they had when it was
+suspended, and run the relation-joined and relation-changed hooks.
+
+Examples:
+    juju resume-relation wordpress mysql
+
+See Also:
+   juju help suspend-relation
+`
+
+// NewResumeRelationCommand returns a command which resumes a suspended
+// relation.
+func NewResumeRelationCommand() cmd.Command {
+	return modelcmd.Wrap(&resumeRelationCommand{})
+}
+
+type resumeRelationAPI interface {
+	Close() error
+	ResumeRelation(endpoints ...string) error
+}
+
+// resumeRelationCommand resumes a suspended relation between two services.
+type resumeRelationCommand struct {
+	modelcmd.ModelCommandBase
+	Endpoints []string
+
+	api resumeRelationAPI
+}
+
+func (c *resumeRelationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resume-relation",
+		Args:    "<service1>[:<relation name1>] <service2>[:<relation name2>]",
+		Purpose: "resume a suspended relation between two services",
+		Doc:     resumeRelationDoc,
+	}
+}
+
+func (c *resumeRelationCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("a relation must involve two services")
+	}
+	c.Endpoints = args
+	return nil
+}
+
+func (c *resumeRelationCommand) getAPI() (resumeRelationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiservice.NewClient(root), nil
+}
+
+func (c *resumeRelationCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.ResumeRelation(c.Endpoints...)
+	if errors.IsNotImplemented(err) {
+		return errors.New("this controller does not support suspending relations")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
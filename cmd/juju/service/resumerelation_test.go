@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type ResumeRelationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	stub *jujutesting.Stub
+	fake *fakeSuspendRelationAPI
+}
+
+var _ = gc.Suite(&ResumeRelationSuite{})
+
+func (s *ResumeRelationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.fake = &fakeSuspendRelationAPI{stub: s.stub}
+}
+
+func (s *ResumeRelationSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(service.NewResumeRelationCommand(), []string{"wordpress"})
+	c.Check(err, gc.ErrorMatches, `a relation must involve two services`)
+	err = testing.InitCommand(service.NewResumeRelationCommand(), []string{"wordpress", "mysql"})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ResumeRelationSuite) TestResumeRelation(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewResumeRelationCommandForTest(s.fake), "wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "ResumeRelation", "Close")
+	s.stub.CheckCall(c, 0, "ResumeRelation", []string{"wordpress", "mysql"})
+}
+
+func (s *ResumeRelationSuite) TestResumeRelationError(c *gc.C) {
+	s.stub.SetErrors(errors.New(`relation "wordpress:db mysql:server" not found`))
+	_, err := testing.RunCommand(c, service.NewResumeRelationCommandForTest(s.fake), "wordpress", "mysql")
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+	s.stub.CheckCallNames(c, "ResumeRelation", "Close")
+}
+
+func (s *ResumeRelationSuite) TestResumeRelationNotSupported(c *gc.C) {
+	s.stub.SetErrors(errors.NotImplementedf("ResumeRelation() (need V5+)"))
+	_, err := testing.RunCommand(c, service.NewResumeRelationCommandForTest(s.fake), "wordpress", "mysql")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support suspending relations")
+	s.stub.CheckCallNames(c, "ResumeRelation", "Close")
+}
@@ -0,0 +1,242 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var showLeadershipDoc = `
+Shows the controller's view of the leadership lease of every service in
+the model, or of the named service. For each lease it shows the leader,
+the controller that last wrote the lease, when the lease was last claimed
+or extended, and when it expires. Claim and expiry times are according
+to the writing controller's clock.
+
+It also shows what is known about the clock of each controller that has
+written leases. SKEW is the writer's most recently recorded time less
+the time at which it was read, and is a lower bound on how far the
+writer's clock is ahead of the controller answering the request: a
+positive value means the writer's clock is certainly ahead, while a
+negative value may simply mean the writer has not written recently.
+
+This command is intended for diagnosing disagreements about leadership,
+and requires controller administrator access.
+
+Examples:
+    juju show-leadership
+    juju show-leadership mysql --format yaml
+
+See Also:
+   juju help leader-transfer
+   juju help leader-pin
+`
+
+// NewShowLeadershipCommand returns a command which shows the leadership
+// leases of services in the model.
+func NewShowLeadershipCommand() cmd.Command {
+	return modelcmd.Wrap(&showLeadershipCommand{})
+}
+
+type showLeadershipAPI interface {
+	Close() error
+	ModelLeadership(tags ...names.ModelTag) ([]params.ModelLeadershipResult, error)
+}
+
+type showLeadershipCommand struct {
+	modelcmd.ModelCommandBase
+	out         cmd.Output
+	ServiceName string
+
+	api      showLeadershipAPI
+	modelTag names.ModelTag
+}
+
+func (c *showLeadershipCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-leadership",
+		Args:    "[<service>]",
+		Purpose: "show service leadership leases",
+		Doc:     showLeadershipDoc,
+	}
+}
+
+func (c *showLeadershipCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatLeadershipTabular,
+	})
+}
+
+func (c *showLeadershipCommand) Init(args []string) error {
+	if len(args) > 0 {
+		if !names.IsValidService(args[0]) {
+			return fmt.Errorf("invalid service name %q", args[0])
+		}
+		c.ServiceName, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showLeadershipCommand) getAPI() (showLeadershipAPI, names.ModelTag, error) {
+	if c.api != nil {
+		return c.api, c.modelTag, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, names.ModelTag{}, errors.Trace(err)
+	}
+	modelTag, err := root.ModelTag()
+	if err != nil {
+		root.Close()
+		return nil, names.ModelTag{}, errors.Trace(err)
+	}
+	return controller.NewClient(root), modelTag, nil
+}
+
+func (c *showLeadershipCommand) Run(ctx *cmd.Context) error {
+	client, modelTag, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.ModelLeadership(modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return errors.Trace(results[0].Error)
+	}
+	formatted := formatLeadership(results[0], c.ServiceName)
+	if c.ServiceName != "" && len(formatted.Services) == 0 {
+		ctx.Infof("service %q has no leader", c.ServiceName)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// FormattedLeadership holds the leadership leases of a model's services,
+// and what is known about the clocks of the controllers that wrote them.
+type FormattedLeadership struct {
+	Services map[string]FormattedLease       `yaml:"services" json:"services"`
+	Writers  map[string]FormattedLeaseWriter `yaml:"writers" json:"writers"`
+}
+
+// FormattedLease describes a single service's leadership lease.
+type FormattedLease struct {
+	Leader      string `yaml:"leader" json:"leader"`
+	Writer      string `yaml:"writer" json:"writer"`
+	Claimed     string `yaml:"claimed,omitempty" json:"claimed,omitempty"`
+	Expiry      string `yaml:"expiry" json:"expiry"`
+	LocalExpiry string `yaml:"local-expiry" json:"local-expiry"`
+}
+
+// FormattedLeaseWriter describes what is known about the clock of a
+// controller that has written leases.
+type FormattedLeaseWriter struct {
+	Local     bool   `yaml:"local,omitempty" json:"local,omitempty"`
+	LastWrite string `yaml:"last-write,omitempty" json:"last-write,omitempty"`
+	Skew      string `yaml:"skew,omitempty" json:"skew,omitempty"`
+}
+
+func formatLeadership(result params.ModelLeadershipResult, serviceName string) FormattedLeadership {
+	formatted := FormattedLeadership{
+		Services: make(map[string]FormattedLease),
+		Writers:  make(map[string]FormattedLeaseWriter),
+	}
+	for _, lease := range result.Leases {
+		if serviceName != "" && lease.Service != serviceName {
+			continue
+		}
+		formattedLease := FormattedLease{
+			Leader:      lease.Holder,
+			Writer:      lease.Writer,
+			Expiry:      common.FormatTime(&lease.Expiry, true),
+			LocalExpiry: common.FormatTime(&lease.LatestExpiry, true),
+		}
+		if lease.Claimed != nil {
+			formattedLease.Claimed = common.FormatTime(lease.Claimed, true)
+		}
+		formatted.Services[lease.Service] = formattedLease
+	}
+	for _, writer := range result.Writers {
+		if writer.LastWrite == nil {
+			formatted.Writers[writer.Writer] = FormattedLeaseWriter{Local: true}
+			continue
+		}
+		skew := writer.LastWrite.Sub(*writer.ReadEnd)
+		formatted.Writers[writer.Writer] = FormattedLeaseWriter{
+			LastWrite: common.FormatTime(writer.LastWrite, true),
+			Skew:      skew.String(),
+		}
+	}
+	return formatted
+}
+
+func formatLeadershipTabular(value interface{}) ([]byte, error) {
+	leadership, ok := value.(FormattedLeadership)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", leadership, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+
+	var services []string
+	for service := range leadership.Services {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	fmt.Fprintln(tw, "SERVICE\tLEADER\tWRITER\tCLAIMED\tEXPIRES")
+	for _, service := range services {
+		lease := leadership.Services[service]
+		claimed := lease.Claimed
+		if claimed == "" {
+			claimed = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			service, lease.Leader, lease.Writer, claimed, lease.Expiry,
+		)
+	}
+	fmt.Fprintln(tw)
+
+	var writers []string
+	for writer := range leadership.Writers {
+		writers = append(writers, writer)
+	}
+	sort.Strings(writers)
+	fmt.Fprintln(tw, "WRITER\tLAST WRITE\tSKEW")
+	for _, name := range writers {
+		writer := leadership.Writers[name]
+		if writer.Local {
+			fmt.Fprintf(tw, "%s\t-\tlocal\n", name)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", name, writer.LastWrite, writer.Skew)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
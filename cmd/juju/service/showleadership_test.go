@@ -0,0 +1,177 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type ShowLeadershipSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	stub     *jujutesting.Stub
+	fake     *fakeShowLeadershipAPI
+	modelTag names.ModelTag
+}
+
+var _ = gc.Suite(&ShowLeadershipSuite{})
+
+func (s *ShowLeadershipSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.modelTag = testing.ModelTag
+
+	now := time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)
+	claimed := now.Add(-10 * time.Second)
+	lastWrite := now.Add(2 * time.Second)
+	readBeginning := now.Add(-time.Second)
+	readEnd := now
+	s.fake = &fakeShowLeadershipAPI{
+		stub: s.stub,
+		result: params.ModelLeadershipResult{
+			ModelTag: s.modelTag.String(),
+			Leases: []params.LeadershipLease{{
+				Service:        "mysql",
+				Holder:         "mysql/1",
+				Writer:         "machine-0",
+				Expiry:         now.Add(time.Minute),
+				EarliestExpiry: now.Add(time.Minute),
+				LatestExpiry:   now.Add(time.Minute),
+				Claimed:        &claimed,
+			}, {
+				Service:        "wordpress",
+				Holder:         "wordpress/0",
+				Writer:         "machine-1",
+				Expiry:         now.Add(time.Minute),
+				EarliestExpiry: now.Add(57 * time.Second),
+				LatestExpiry:   now.Add(59 * time.Second),
+			}},
+			Writers: []params.LeaseWriter{{
+				Writer: "machine-0",
+			}, {
+				Writer:        "machine-1",
+				LastWrite:     &lastWrite,
+				ReadBeginning: &readBeginning,
+				ReadEnd:       &readEnd,
+			}},
+		},
+	}
+}
+
+func (s *ShowLeadershipSuite) runShowLeadership(c *gc.C, args ...string) (string, error) {
+	command := service.NewShowLeadershipCommandForTest(s.fake, s.modelTag)
+	ctx, err := testing.RunCommand(c, command, args...)
+	if err != nil {
+		return "", err
+	}
+	return testing.Stdout(ctx), nil
+}
+
+func (s *ShowLeadershipSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+	}, {
+		args: []string{"mysql"},
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid service name "mysql/0"`,
+	}, {
+		args: []string{"mysql", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(service.NewShowLeadershipCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *ShowLeadershipSuite) TestTabular(c *gc.C) {
+	out, err := s.runShowLeadership(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, ""+
+		"SERVICE    LEADER       WRITER     CLAIMED               EXPIRES\n"+
+		"mysql      mysql/1      machine-0  2016-05-04 03:01:51Z  2016-05-04 03:03:01Z\n"+
+		"wordpress  wordpress/0  machine-1  -                     2016-05-04 03:03:01Z\n"+
+		"\n"+
+		"WRITER     LAST WRITE            SKEW\n"+
+		"machine-0  -                     local\n"+
+		"machine-1  2016-05-04 03:02:03Z  2s\n",
+	)
+	s.stub.CheckCallNames(c, "ModelLeadership", "Close")
+	s.stub.CheckCall(c, 0, "ModelLeadership", []names.ModelTag{s.modelTag})
+}
+
+func (s *ShowLeadershipSuite) TestYAMLFiltersService(c *gc.C) {
+	out, err := s.runShowLeadership(c, "wordpress", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, ""+
+		"services:\n"+
+		"  wordpress:\n"+
+		"    leader: wordpress/0\n"+
+		"    writer: machine-1\n"+
+		"    expiry: 2016-05-04 03:03:01Z\n"+
+		"    local-expiry: 2016-05-04 03:03:00Z\n"+
+		"writers:\n"+
+		"  machine-0:\n"+
+		"    local: true\n"+
+		"  machine-1:\n"+
+		"    last-write: 2016-05-04 03:02:03Z\n"+
+		"    skew: 2s\n",
+	)
+}
+
+func (s *ShowLeadershipSuite) TestNoLeader(c *gc.C) {
+	command := service.NewShowLeadershipCommandForTest(s.fake, s.modelTag)
+	ctx, err := testing.RunCommand(c, command, "haproxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "service \"haproxy\" has no leader\n")
+}
+
+func (s *ShowLeadershipSuite) TestModelError(c *gc.C) {
+	s.fake.result.Error = &params.Error{Message: "permission denied"}
+	_, err := s.runShowLeadership(c)
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	s.stub.CheckCallNames(c, "ModelLeadership", "Close")
+}
+
+func (s *ShowLeadershipSuite) TestAPIError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	_, err := s.runShowLeadership(c)
+	c.Check(err, gc.ErrorMatches, "boom")
+	s.stub.CheckCallNames(c, "ModelLeadership", "Close")
+}
+
+type fakeShowLeadershipAPI struct {
+	stub   *jujutesting.Stub
+	result params.ModelLeadershipResult
+}
+
+func (f *fakeShowLeadershipAPI) Close() error {
+	f.stub.AddCall("Close")
+	return nil
+}
+
+func (f *fakeShowLeadershipAPI) ModelLeadership(tags ...names.ModelTag) ([]params.ModelLeadershipResult, error) {
+	f.stub.AddCall("ModelLeadership", tags)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return []params.ModelLeadershipResult{f.result}, nil
+}
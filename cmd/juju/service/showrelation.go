@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	apiservice "github.com/juju/juju/api/service"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var showRelationDoc = `
+Shows the details of the relation between two service endpoints: its
+scope and status, the endpoints involved, and the units that have entered
+the relation's scope. Units that are leaving the relation are marked as
+departing.
+
+Controller administrators and the model owner also see each unit's
+relation settings, as a hook would see them with relation-get; this can
+help to debug misbehaving interfaces without using debug-hooks.
+
+Examples:
+    juju show-relation wordpress mysql
+    juju show-relation wordpress:db mysql:server --format json
+
+See Also:
+   juju help add-relation
+`
+
+// NewShowRelationCommand returns a command which shows the details of a
+// relation.
+func NewShowRelationCommand() cmd.Command {
+	return modelcmd.Wrap(&showRelationCommand{})
+}
+
+type showRelationAPI interface {
+	Close() error
+	ShowRelation(endpoints ...string) (params.ShowRelationResult, error)
+}
+
+type showRelationCommand struct {
+	modelcmd.ModelCommandBase
+	out       cmd.Output
+	Endpoints []string
+
+	api showRelationAPI
+}
+
+func (c *showRelationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-relation",
+		Args:    "<service1>[:<relation name1>] <service2>[:<relation name2>]",
+		Purpose: "show the details of a relation",
+		Doc:     showRelationDoc,
+	}
+}
+
+func (c *showRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *showRelationCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("a relation must involve two services")
+	}
+	c.Endpoints = args
+	return nil
+}
+
+func (c *showRelationCommand) getAPI() (showRelationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiservice.NewClient(root), nil
+}
+
+func (c *showRelationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ShowRelation(c.Endpoints...)
+	if errors.IsNotImplemented(err) {
+		return errors.New("this controller does not support showing relations")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatRelation(result))
+}
+
+// FormattedRelation holds the details of a relation.
+type FormattedRelation struct {
+	Relation  string                               `yaml:"relation" json:"relation"`
+	Id        int                                  `yaml:"id" json:"id"`
+	Scope     string                               `yaml:"scope" json:"scope"`
+	Status    string                               `yaml:"status" json:"status"`
+	Endpoints map[string]FormattedRelationEndpoint `yaml:"endpoints" json:"endpoints"`
+	Units     map[string]FormattedRelationUnit     `yaml:"units,omitempty" json:"units,omitempty"`
+}
+
+// FormattedRelationEndpoint describes one endpoint of a relation.
+type FormattedRelationEndpoint struct {
+	Endpoint  string `yaml:"endpoint" json:"endpoint"`
+	Role      string `yaml:"role" json:"role"`
+	Interface string `yaml:"interface" json:"interface"`
+}
+
+// FormattedRelationUnit describes a unit in a relation's scope.
+type FormattedRelationUnit struct {
+	Endpoint  string                 `yaml:"endpoint" json:"endpoint"`
+	Departing bool                   `yaml:"departing,omitempty" json:"departing,omitempty"`
+	Settings  map[string]interface{} `yaml:"settings,omitempty" json:"settings,omitempty"`
+}
+
+func formatRelation(result params.ShowRelationResult) FormattedRelation {
+	formatted := FormattedRelation{
+		Relation:  result.Key,
+		Id:        result.Id,
+		Scope:     string(result.Scope),
+		Status:    result.Status,
+		Endpoints: make(map[string]FormattedRelationEndpoint),
+	}
+	for _, ep := range result.Endpoints {
+		formatted.Endpoints[ep.ServiceName] = FormattedRelationEndpoint{
+			Endpoint:  ep.Name,
+			Role:      string(ep.Role),
+			Interface: ep.Interface,
+		}
+	}
+	if len(result.Units) > 0 {
+		formatted.Units = make(map[string]FormattedRelationUnit)
+	}
+	for _, unit := range result.Units {
+		formatted.Units[unit.UnitName] = FormattedRelationUnit{
+			Endpoint:  unit.Endpoint,
+			Departing: unit.Departing,
+			Settings:  unit.Settings,
+		}
+	}
+	return formatted
+}
@@ -0,0 +1,146 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type ShowRelationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	stub *jujutesting.Stub
+	fake *fakeShowRelationAPI
+}
+
+var _ = gc.Suite(&ShowRelationSuite{})
+
+func (s *ShowRelationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.fake = &fakeShowRelationAPI{
+		stub: s.stub,
+		result: params.ShowRelationResult{
+			Id:     1,
+			Key:    "wordpress:db mysql:server",
+			Scope:  charm.ScopeGlobal,
+			Status: "active",
+			Endpoints: []params.RelationEndpointDetails{{
+				ServiceName: "wordpress",
+				Name:        "db",
+				Role:        charm.RoleRequirer,
+				Interface:   "mysql",
+			}, {
+				ServiceName: "mysql",
+				Name:        "server",
+				Role:        charm.RoleProvider,
+				Interface:   "mysql",
+			}},
+			Units: []params.RelationUnitDetails{{
+				UnitName: "mysql/0",
+				Endpoint: "server",
+				Settings: map[string]interface{}{"host": "10.0.0.1"},
+			}, {
+				UnitName:  "wordpress/0",
+				Endpoint:  "db",
+				Departing: true,
+			}},
+		},
+	}
+}
+
+func (s *ShowRelationSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `a relation must involve two services`,
+	}, {
+		args: []string{"wordpress"},
+		err:  `a relation must involve two services`,
+	}, {
+		args: []string{"wordpress", "mysql", "extra"},
+		err:  `a relation must involve two services`,
+	}, {
+		args: []string{"wordpress:db", "mysql"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(service.NewShowRelationCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *ShowRelationSuite) TestYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, service.NewShowRelationCommandForTest(s.fake), "wordpress", "mysql:server")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"relation: wordpress:db mysql:server\n"+
+		"id: 1\n"+
+		"scope: global\n"+
+		"status: active\n"+
+		"endpoints:\n"+
+		"  mysql:\n"+
+		"    endpoint: server\n"+
+		"    role: provider\n"+
+		"    interface: mysql\n"+
+		"  wordpress:\n"+
+		"    endpoint: db\n"+
+		"    role: requirer\n"+
+		"    interface: mysql\n"+
+		"units:\n"+
+		"  mysql/0:\n"+
+		"    endpoint: server\n"+
+		"    settings:\n"+
+		"      host: 10.0.0.1\n"+
+		"  wordpress/0:\n"+
+		"    endpoint: db\n"+
+		"    departing: true\n",
+	)
+	s.stub.CheckCallNames(c, "ShowRelation", "Close")
+	s.stub.CheckCall(c, 0, "ShowRelation", []string{"wordpress", "mysql:server"})
+}
+
+func (s *ShowRelationSuite) TestAPIError(c *gc.C) {
+	s.stub.SetErrors(errors.New(`relation "wordpress:db mysql:server" not found`))
+	_, err := testing.RunCommand(c, service.NewShowRelationCommandForTest(s.fake), "wordpress", "mysql")
+	c.Check(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" not found`)
+	s.stub.CheckCallNames(c, "ShowRelation", "Close")
+}
+
+func (s *ShowRelationSuite) TestNotSupported(c *gc.C) {
+	s.stub.SetErrors(errors.NotImplementedf("ShowRelation() (need V5+)"))
+	_, err := testing.RunCommand(c, service.NewShowRelationCommandForTest(s.fake), "wordpress", "mysql")
+	c.Check(err, gc.ErrorMatches, "this controller does not support showing relations")
+	s.stub.CheckCallNames(c, "ShowRelation", "Close")
+}
+
+type fakeShowRelationAPI struct {
+	stub   *jujutesting.Stub
+	result params.ShowRelationResult
+}
+
+func (f *fakeShowRelationAPI) Close() error {
+	f.stub.AddCall("Close")
+	return nil
+}
+
+func (f *fakeShowRelationAPI) ShowRelation(endpoints ...string) (params.ShowRelationResult, error) {
+	f.stub.AddCall("ShowRelation", endpoints)
+	if err := f.stub.NextErr(); err != nil {
+		return params.ShowRelationResult{}, err
+	}
+	return f.result, nil
+}
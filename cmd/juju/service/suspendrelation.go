@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	apiservice "github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var suspendRelationDoc = `
+Suspends the relation between two service endpoints, without removing it.
+The units of both services run the relation-departed and relation-broken
+hooks, as they would if the relation were removed, and leave the relation;
+but the relation and its settings are kept, and no units can join it until
+it is resumed with resume-relation.
+
+Peer relations, and relations with a subordinate service, cannot be
+suspended.
+
+Examples:
+    juju suspend-relation wordpress mysql
+    juju suspend-relation wordpress:db mysql:server
+
+See Also:
+   juju help resume-relation
+   juju help show-relation
+`
+
+// NewSuspendRelationCommand returns a command which suspends a relation.
+func NewSuspendRelationCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendRelationCommand{})
+}
+
+type suspendRelationAPI interface {
+	Close() error
+	SuspendRelation(endpoints ...string) error
+}
+
+// suspendRelationCommand suspends a relation between two services.
+type suspendRelationCommand struct {
+	modelcmd.ModelCommandBase
+	Endpoints []string
+
+	api suspendRelationAPI
+}
+
+func (c *suspendRelationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "suspend-relation",
+		Args:    "<service1>[:<relation name1>] <service2>[:<relation name2>]",
+		Purpose: "suspend a relation between two services",
+		Doc:     suspendRelationDoc,
+	}
+}
+
+func (c *suspendRelationCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("a relation must involve two services")
+	}
+	c.Endpoints = args
+	return nil
+}
+
+func (c *suspendRelationCommand) getAPI() (suspendRelationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiservice.NewClient(root), nil
+}
+
+func (c *suspendRelationCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SuspendRelation(c.Endpoints...)
+	if errors.IsNotImplemented(err) {
+		return errors.New("this controller does not support suspending relations")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
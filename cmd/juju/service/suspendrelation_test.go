@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type SuspendRelationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	stub *jujutesting.Stub
+	fake *fakeSuspendRelationAPI
+}
+
+var _ = gc.Suite(&SuspendRelationSuite{})
+
+func (s *SuspendRelationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.stub = &jujutesting.Stub{}
+	s.fake = &fakeSuspendRelationAPI{stub: s.stub}
+}
+
+func (s *SuspendRelationSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `a relation must involve two services`,
+	}, {
+		args: []string{"wordpress"},
+		err:  `a relation must involve two services`,
+	}, {
+		args: []string{"wordpress", "mysql", "extra"},
+		err:  `a relation must involve two services`,
+	}, {
+		args: []string{"wordpress:db", "mysql"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(service.NewSuspendRelationCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *SuspendRelationSuite) TestSuspendRelation(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewSuspendRelationCommandForTest(s.fake), "wordpress", "mysql:server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "SuspendRelation", "Close")
+	s.stub.CheckCall(c, 0, "SuspendRelation", []string{"wordpress", "mysql:server"})
+}
+
+func (s *SuspendRelationSuite) TestSuspendRelationError(c *gc.C) {
+	s.stub.SetErrors(errors.New(`cannot suspend relation "riak:ring": is a peer relation`))
+	_, err := testing.RunCommand(c, service.NewSuspendRelationCommandForTest(s.fake), "riak", "riak")
+	c.Assert(err, gc.ErrorMatches, `cannot suspend relation "riak:ring": is a peer relation`)
+	s.stub.CheckCallNames(c, "SuspendRelation", "Close")
+}
+
+func (s *SuspendRelationSuite) TestSuspendRelationNotSupported(c *gc.C) {
+	s.stub.SetErrors(errors.NotImplementedf("SuspendRelation() (need V5+)"))
+	_, err := testing.RunCommand(c, service.NewSuspendRelationCommandForTest(s.fake), "wordpress", "mysql")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support suspending relations")
+	s.stub.CheckCallNames(c, "SuspendRelation", "Close")
+}
+
+type fakeSuspendRelationAPI struct {
+	stub *jujutesting.Stub
+}
+
+func (f *fakeSuspendRelationAPI) Close() error {
+	f.stub.AddCall("Close")
+	return nil
+}
+
+func (f *fakeSuspendRelationAPI) SuspendRelation(endpoints ...string) error {
+	f.stub.AddCall("SuspendRelation", endpoints)
+	return f.stub.NextErr()
+}
+
+func (f *fakeSuspendRelationAPI) ResumeRelation(endpoints ...string) error {
+	f.stub.AddCall("ResumeRelation", endpoints)
+	return f.stub.NextErr()
+}
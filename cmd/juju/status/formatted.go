@@ -17,6 +17,8 @@ type formattedStatus struct {
 	Machines    map[string]machineStatus `json:"machines"`
 	Services    map[string]serviceStatus `json:"services"`
 	Networks    map[string]networkStatus `json:"networks,omitempty" yaml:",omitempty"`
+
+	RemoteServices map[string]remoteServiceStatus `json:"remote-services,omitempty" yaml:"remote-services,omitempty"`
 }
 
 type formattedMachineStatus struct {
@@ -95,6 +97,37 @@ func (s serviceStatus) MarshalYAML() (interface{}, error) {
 	return serviceStatusNoMarshal(s), nil
 }
 
+type remoteServiceStatus struct {
+	Err           error                     `json:"-" yaml:",omitempty"`
+	SourceModel   string                    `json:"source-model" yaml:"source-model"`
+	SourceService string                    `json:"source-service" yaml:"source-service"`
+	Offer         string                    `json:"offer,omitempty" yaml:"offer,omitempty"`
+	Life          string                    `json:"life,omitempty" yaml:"life,omitempty"`
+	Endpoints     map[string]remoteEndpoint `json:"endpoints" yaml:"endpoints"`
+	Relations     map[string][]string       `json:"relations,omitempty" yaml:"relations,omitempty"`
+}
+
+type remoteEndpoint struct {
+	Interface string `json:"interface" yaml:"interface"`
+	Role      string `json:"role" yaml:"role"`
+}
+
+type remoteServiceStatusNoMarshal remoteServiceStatus
+
+func (s remoteServiceStatus) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatus{s.Err.Error()})
+	}
+	return json.Marshal(remoteServiceStatusNoMarshal(s))
+}
+
+func (s remoteServiceStatus) MarshalYAML() (interface{}, error) {
+	if s.Err != nil {
+		return errorStatus{s.Err.Error()}, nil
+	}
+	return remoteServiceStatusNoMarshal(s), nil
+}
+
 type meterStatus struct {
 	Color   string `json:"color,omitempty" yaml:"color,omitempty"`
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
@@ -106,6 +139,9 @@ type unitStatus struct {
 	AgentStatusInfo    statusInfoContents `json:"agent-status,omitempty" yaml:"agent-status"`
 	MeterStatus        *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 
+	WorkloadVersion string            `json:"workload-version,omitempty" yaml:"workload-version,omitempty"`
+	WorkloadInfo    map[string]string `json:"workload-info,omitempty" yaml:"workload-info,omitempty"`
+
 	Charm         string                `json:"upgrading-from,omitempty" yaml:"upgrading-from,omitempty"`
 	Machine       string                `json:"machine,omitempty" yaml:"machine,omitempty"`
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
@@ -56,6 +56,12 @@ func (sf *statusFormatter) format() formattedStatus {
 		}
 		out.Networks[k] = sf.formatNetwork(n)
 	}
+	for sn, s := range sf.status.RemoteServices {
+		if out.RemoteServices == nil {
+			out.RemoteServices = make(map[string]remoteServiceStatus)
+		}
+		out.RemoteServices[sn] = sf.formatRemoteService(s)
+	}
 	return out
 }
 
@@ -177,6 +183,8 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		PublicAddress:      info.unit.PublicAddress,
 		Charm:              info.unit.Charm,
 		Subordinates:       make(map[string]unitStatus),
+		WorkloadVersion:    info.unit.WorkloadVersion,
+		WorkloadInfo:       info.unit.WorkloadInfo,
 	}
 
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
@@ -244,6 +252,25 @@ func (sf *statusFormatter) formatNetwork(network params.NetworkStatus) networkSt
 	}
 }
 
+func (sf *statusFormatter) formatRemoteService(service params.RemoteServiceStatus) remoteServiceStatus {
+	out := remoteServiceStatus{
+		Err:           service.Err,
+		SourceModel:   service.SourceModel,
+		SourceService: service.SourceService,
+		Offer:         service.OfferName,
+		Life:          service.Life,
+		Endpoints:     make(map[string]remoteEndpoint),
+		Relations:     service.Relations,
+	}
+	for _, ep := range service.Endpoints {
+		out.Endpoints[ep.Name] = remoteEndpoint{
+			Interface: ep.Interface,
+			Role:      string(ep.Role),
+		}
+	}
+	return out
+}
+
 func makeHAStatus(hasVote, wantsVote bool) string {
 	var s string
 	switch {
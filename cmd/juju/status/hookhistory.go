@@ -0,0 +1,174 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/statushistory"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// NewShowHookHistoryCommand returns a command that reports the hooks and
+// other operations most recently run by a unit's agent.
+func NewShowHookHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&showHookHistoryCommand{})
+}
+
+type showHookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out      cmd.Output
+	size     int
+	isoTime  bool
+	unitName string
+}
+
+var showHookHistoryDoc = `
+This command reports the hooks, actions and other operations most recently
+run by a unit's agent, most recent first. For each it shows when it started,
+how long it took, the exit code of the charm code it ran and, if it failed,
+why. The yaml and json formats also include the final standard error output
+of the charm code.
+
+Only the most recent 100 operations are kept for each unit.
+
+Examples:
+    juju show-hook-history mysql/0
+    juju show-hook-history mysql/0 -n 5 --format yaml
+
+See Also:
+   juju help status-history
+`
+
+func (c *showHookHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "[-n N] <unit>",
+		Purpose: "output the hooks recently run by a unit",
+		Doc:     showHookHistoryDoc,
+	}
+}
+
+func (c *showHookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.IntVar(&c.size, "n", 20, "show the N most recent operations")
+	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatHookHistoryTabular,
+	})
+}
+
+func (c *showHookHistoryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit specified")
+	}
+	c.unitName, args = args[0], args[1:]
+	if !names.IsValidUnit(c.unitName) {
+		return errors.Errorf("invalid unit name %q", c.unitName)
+	}
+	if c.size < 1 {
+		return errors.Errorf("invalid history size: %d", c.size)
+	}
+	if !c.isoTime {
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			var err error
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type showHookHistoryAPI interface {
+	HookHistory(unit names.UnitTag, size int) ([]params.HookRecord, error)
+	Close() error
+}
+
+var newShowHookHistoryAPI = func(c *showHookHistoryCommand) (showHookHistoryAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &modelStatusHistoryClient{statushistory.NewFacade(root), root}, nil
+}
+
+// hookHistoryEntry is the formatted representation of a hook record.
+type hookHistoryEntry struct {
+	Time       string `yaml:"time" json:"time"`
+	Operation  string `yaml:"operation" json:"operation"`
+	Hook       string `yaml:"hook,omitempty" json:"hook,omitempty"`
+	RelationId *int   `yaml:"relation-id,omitempty" json:"relation-id,omitempty"`
+	RemoteUnit string `yaml:"remote-unit,omitempty" json:"remote-unit,omitempty"`
+	Duration   string `yaml:"duration" json:"duration"`
+	ExitCode   int    `yaml:"exit-code" json:"exit-code"`
+	Error      string `yaml:"error,omitempty" json:"error,omitempty"`
+	Stderr     string `yaml:"stderr,omitempty" json:"stderr,omitempty"`
+}
+
+func (c *showHookHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := newShowHookHistoryAPI(c)
+	if err != nil {
+		return errors.Errorf(connectionError, c.ConnectionName(), err)
+	}
+	defer client.Close()
+
+	records, err := client.HookHistory(names.NewUnitTag(c.unitName), c.size)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(records) == 0 {
+		ctx.Infof("no hook history available")
+		return nil
+	}
+	entries := make([]hookHistoryEntry, len(records))
+	for i, r := range records {
+		started := r.Started
+		entries[i] = hookHistoryEntry{
+			Time:       common.FormatTime(&started, c.isoTime),
+			Operation:  r.Operation,
+			Hook:       r.Hook,
+			RemoteUnit: r.RemoteUnit,
+			Duration:   (r.Duration / time.Millisecond * time.Millisecond).String(),
+			ExitCode:   r.ExitCode,
+			Error:      r.Error,
+			Stderr:     r.Stderr,
+		}
+		if r.RelationId >= 0 {
+			relationId := r.RelationId
+			entries[i].RelationId = &relationId
+		}
+	}
+	return c.out.Write(ctx, entries)
+}
+
+func formatHookHistoryTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]hookHistoryEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tOPERATION\tDURATION\tEXIT\tERROR")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", e.Time, e.Operation, e.Duration, e.ExitCode, e.Error)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
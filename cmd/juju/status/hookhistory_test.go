@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type fakeShowHookHistoryAPI struct {
+	unit    names.UnitTag
+	size    int
+	records []params.HookRecord
+	closed  bool
+}
+
+func (f *fakeShowHookHistoryAPI) HookHistory(unit names.UnitTag, size int) ([]params.HookRecord, error) {
+	f.unit = unit
+	f.size = size
+	return f.records, nil
+}
+
+func (f *fakeShowHookHistoryAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func runShowHookHistory(c *gc.C, args ...string) (code int, stdout, stderr string) {
+	ctx := coretesting.Context(c)
+	code = cmd.Main(NewShowHookHistoryCommand(), ctx, args)
+	stdout = ctx.Stdout.(*bytes.Buffer).String()
+	stderr = ctx.Stderr.(*bytes.Buffer).String()
+	return
+}
+
+func (s *StatusSuite) patchShowHookHistoryAPI(fake *fakeShowHookHistoryAPI) {
+	s.PatchValue(&newShowHookHistoryAPI, func(_ *showHookHistoryCommand) (showHookHistoryAPI, error) {
+		return fake, nil
+	})
+}
+
+func (s *StatusSuite) TestShowHookHistory(c *gc.C) {
+	started := time.Date(2016, 3, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeShowHookHistoryAPI{
+		records: []params.HookRecord{{
+			Operation:  "run db-relation-joined (1; wordpress/0) hook",
+			Hook:       "relation-joined",
+			RelationId: 1,
+			RemoteUnit: "wordpress/0",
+			Started:    started.Add(time.Minute),
+			Duration:   1234567 * time.Microsecond,
+			ExitCode:   1,
+			Stderr:     "oops\n",
+			Error:      "hook failed",
+		}, {
+			Operation:  "run install hook",
+			Hook:       "install",
+			RelationId: -1,
+			Started:    started,
+			Duration:   42 * time.Second,
+		}},
+	}
+	s.patchShowHookHistoryAPI(fake)
+
+	code, stdout, stderr := runShowHookHistory(c, "--utc", "-n", "5", "mysql/0")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("%s", stderr))
+	c.Check(fake.closed, jc.IsTrue)
+	c.Check(fake.unit, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Check(fake.size, gc.Equals, 5)
+	c.Check(stdout, gc.Equals, `
+TIME                 OPERATION                                    DURATION EXIT ERROR
+2016-03-01 10:01:00Z run db-relation-joined (1; wordpress/0) hook 1.234s   1    hook failed
+2016-03-01 10:00:00Z run install hook                             42s      0    
+`[1:])
+}
+
+func (s *StatusSuite) TestShowHookHistoryYAML(c *gc.C) {
+	fake := &fakeShowHookHistoryAPI{
+		records: []params.HookRecord{{
+			Operation:  "run db-relation-joined (1; wordpress/0) hook",
+			Hook:       "relation-joined",
+			RelationId: 1,
+			RemoteUnit: "wordpress/0",
+			Started:    time.Date(2016, 3, 1, 10, 0, 0, 0, time.UTC),
+			Duration:   time.Second,
+			ExitCode:   1,
+			Stderr:     "oops\n",
+			Error:      "hook failed",
+		}},
+	}
+	s.patchShowHookHistoryAPI(fake)
+
+	code, stdout, stderr := runShowHookHistory(c, "--utc", "--format", "yaml", "mysql/0")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("%s", stderr))
+	c.Check(fake.size, gc.Equals, 20)
+	c.Check(stdout, gc.Equals, `
+- time: 2016-03-01 10:00:00Z
+  operation: run db-relation-joined (1; wordpress/0) hook
+  hook: relation-joined
+  relation-id: 1
+  remote-unit: wordpress/0
+  duration: 1s
+  exit-code: 1
+  error: hook failed
+  stderr: |
+    oops
+`[1:])
+}
+
+func (s *StatusSuite) TestShowHookHistoryEmpty(c *gc.C) {
+	s.patchShowHookHistoryAPI(&fakeShowHookHistoryAPI{})
+	code, stdout, stderr := runShowHookHistory(c, "mysql/0")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "no hook history available\n")
+}
+
+func (s *StatusSuite) TestShowHookHistoryInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no unit specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"mysql/0", "-n", "0"},
+		err:  "invalid history size: 0",
+	}, {
+		args: []string{"mysql/0", "mysql/1"},
+		err:  `unrecognized args: \["mysql/1"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := coretesting.InitCommand(&showHookHistoryCommand{}, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/statushistory"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// NewModelStatusHistoryCommand returns a command that reports the
+// status changes of all, or a selection of, the entities in a model
+// over a period of time.
+func NewModelStatusHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&modelStatusHistoryCommand{})
+}
+
+type modelStatusHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out      cmd.Output
+	from     string
+	to       string
+	size     int
+	isoTime  bool
+	entities []string
+
+	args params.ModelStatusHistoryArgs
+}
+
+var modelStatusHistoryDoc = `
+This command reports the status changes of the machines, services and
+units in a model, ordered by the time they occurred. The entities may
+be restricted by naming them; by default all entities are included.
+
+The period reported may be bounded with --from and --to, each of which
+accepts either a time in RFC3339 format or a duration, which is taken
+to be relative to now. If neither bound nor -n is given, only the 100
+most recent statuses are shown:
+
+    juju model-status-history --from 2h
+    juju model-status-history --from 2016-03-01T10:00:00Z --to 2016-03-01T11:00:00Z mysql/0 0
+`
+
+func (c *modelStatusHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "model-status-history",
+		Args:    "[--from T] [--to T] [-n N] [<machine>|<service>|<unit> ...]",
+		Purpose: "output past statuses of entities in a model",
+		Doc:     modelStatusHistoryDoc,
+	}
+}
+
+func (c *modelStatusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.from, "from", "", "only show statuses set at or after this time or duration ago")
+	f.StringVar(&c.to, "to", "", "only show statuses set at or before this time or duration ago")
+	f.IntVar(&c.size, "n", 0, "only show the N most recent statuses")
+	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+func (c *modelStatusHistoryCommand) Init(args []string) error {
+	now := time.Now()
+	from, err := parseHistoryTime(c.from, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --from")
+	}
+	to, err := parseHistoryTime(c.to, now)
+	if err != nil {
+		return errors.Annotate(err, "invalid --to")
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return errors.New("--to must not be earlier than --from")
+	}
+	if c.size < 0 {
+		return errors.Errorf("invalid history size: %d", c.size)
+	}
+	c.args = params.ModelStatusHistoryArgs{
+		From: from,
+		To:   to,
+		Size: c.size,
+	}
+	for _, arg := range args {
+		tag, err := entityTag(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.args.Entities = append(c.args.Entities, tag.String())
+	}
+	if !c.isoTime {
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+// parseHistoryTime interprets value as either an RFC3339 time or
+// a duration before now. An empty value yields a nil time.
+func parseHistoryTime(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, errors.Errorf("%q is neither an RFC3339 time nor a duration", value)
+	}
+	if d < 0 {
+		return nil, errors.Errorf("negative duration %q", value)
+	}
+	t := now.Add(-d)
+	return &t, nil
+}
+
+// entityTag returns the tag of the machine, service or unit
+// with the given name.
+func entityTag(name string) (names.Tag, error) {
+	switch {
+	case names.IsValidMachine(name):
+		return names.NewMachineTag(name), nil
+	case names.IsValidUnit(name):
+		return names.NewUnitTag(name), nil
+	case names.IsValidService(name):
+		return names.NewServiceTag(name), nil
+	}
+	return nil, errors.NotValidf("machine, service or unit name %q", name)
+}
+
+type modelStatusHistoryAPI interface {
+	ModelStatusHistory(params.ModelStatusHistoryArgs) ([]params.HistoricalStatus, error)
+	Close() error
+}
+
+type modelStatusHistoryClient struct {
+	*statushistory.Facade
+	closer interface {
+		Close() error
+	}
+}
+
+func (c *modelStatusHistoryClient) Close() error {
+	return c.closer.Close()
+}
+
+var newModelStatusHistoryAPI = func(c *modelStatusHistoryCommand) (modelStatusHistoryAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &modelStatusHistoryClient{statushistory.NewFacade(root), root}, nil
+}
+
+// historyEntry is the formatted representation of a status
+// history entry.
+type historyEntry struct {
+	Time    string `yaml:"time" json:"time"`
+	Entity  string `yaml:"entity" json:"entity"`
+	Kind    string `yaml:"type" json:"type"`
+	Status  string `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func (c *modelStatusHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := newModelStatusHistoryAPI(c)
+	if err != nil {
+		return errors.Errorf(connectionError, c.ConnectionName(), err)
+	}
+	defer client.Close()
+
+	statuses, err := client.ModelStatusHistory(c.args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(statuses) == 0 {
+		ctx.Infof("no status history available")
+		return nil
+	}
+	entries := make([]historyEntry, len(statuses))
+	for i, s := range statuses {
+		entity := s.Entity
+		if tag, err := names.ParseTag(s.Entity); err == nil {
+			entity = tag.Id()
+		}
+		entries[i] = historyEntry{
+			Time:    common.FormatTime(s.Since, c.isoTime),
+			Entity:  entity,
+			Kind:    string(s.Kind),
+			Status:  string(s.Status),
+			Message: s.Info,
+		}
+	}
+	return c.out.Write(ctx, entries)
+}
+
+func (c *modelStatusHistoryCommand) formatTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]historyEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tENTITY\tTYPE\tSTATUS\tMESSAGE")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", e.Time, e.Entity, e.Kind, e.Status, e.Message)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
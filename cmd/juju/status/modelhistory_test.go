@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type fakeModelStatusHistoryAPI struct {
+	args     params.ModelStatusHistoryArgs
+	statuses []params.HistoricalStatus
+	closed   bool
+}
+
+func (f *fakeModelStatusHistoryAPI) ModelStatusHistory(args params.ModelStatusHistoryArgs) ([]params.HistoricalStatus, error) {
+	f.args = args
+	return f.statuses, nil
+}
+
+func (f *fakeModelStatusHistoryAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func runModelStatusHistory(c *gc.C, args ...string) (code int, stdout, stderr string) {
+	ctx := coretesting.Context(c)
+	code = cmd.Main(NewModelStatusHistoryCommand(), ctx, args)
+	stdout = ctx.Stdout.(*bytes.Buffer).String()
+	stderr = ctx.Stderr.(*bytes.Buffer).String()
+	return
+}
+
+func (s *WatchSuite) TestParseHistoryTime(c *gc.C) {
+	now := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t, err := parseHistoryTime("", now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(t, gc.IsNil)
+
+	t, err = parseHistoryTime("2h", now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*t, gc.Equals, now.Add(-2*time.Hour))
+
+	t, err = parseHistoryTime("2016-03-01T10:00:00Z", now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(t.Equal(now.Add(-2*time.Hour)), jc.IsTrue)
+
+	_, err = parseHistoryTime("yesterday", now)
+	c.Check(err, gc.ErrorMatches, `"yesterday" is neither an RFC3339 time nor a duration`)
+}
+
+func (s *StatusSuite) TestModelStatusHistory(c *gc.C) {
+	since := time.Date(2016, 3, 1, 10, 0, 0, 0, time.UTC)
+	fake := &fakeModelStatusHistoryAPI{
+		statuses: []params.HistoricalStatus{{
+			Entity: "machine-0",
+			Kind:   params.KindMachine,
+			Status: params.StatusStarted,
+			Since:  &since,
+		}, {
+			Entity: "unit-mysql-0",
+			Kind:   params.KindWorkload,
+			Status: params.StatusBlocked,
+			Info:   "waiting for storage",
+			Since:  &since,
+		}},
+	}
+	s.PatchValue(&newModelStatusHistoryAPI, func(_ *modelStatusHistoryCommand) (modelStatusHistoryAPI, error) {
+		return fake, nil
+	})
+
+	code, stdout, stderr := runModelStatusHistory(c,
+		"--utc", "--from", "2016-03-01T09:00:00Z", "-n", "5", "mysql/0", "0", "wordpress",
+	)
+	c.Assert(code, gc.Equals, 0, gc.Commentf("%s", stderr))
+	c.Check(fake.closed, jc.IsTrue)
+	c.Check(fake.args.To, gc.IsNil)
+	c.Check(fake.args.From.Equal(since.Add(-time.Hour)), jc.IsTrue)
+	c.Check(fake.args.Size, gc.Equals, 5)
+	c.Check(fake.args.Entities, jc.DeepEquals, []string{"unit-mysql-0", "machine-0", "service-wordpress"})
+	c.Check(stdout, gc.Equals, `
+TIME                 ENTITY  TYPE     STATUS  MESSAGE
+2016-03-01 10:00:00Z 0       machine  started 
+2016-03-01 10:00:00Z mysql/0 workload blocked waiting for storage
+`[1:])
+}
+
+func (s *StatusSuite) TestModelStatusHistoryInvalidRange(c *gc.C) {
+	code, _, stderr := runModelStatusHistory(c, "--from", "1h", "--to", "2h")
+	c.Check(code, gc.Equals, 2)
+	c.Check(stderr, gc.Equals, "error: --to must not be earlier than --from\n")
+}
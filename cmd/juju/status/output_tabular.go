@@ -122,6 +122,15 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		}
 
 	}
+	if len(fs.RemoteServices) > 0 {
+		p()
+		p("[Remote services]")
+		p("NAME\tSOURCE-MODEL\tSOURCE-SERVICE\tOFFER")
+		for _, svcName := range common.SortStringsNaturally(stringKeysFromMap(fs.RemoteServices)) {
+			svc := fs.RemoteServices[svcName]
+			p(svcName, svc.SourceModel, svc.SourceService, svc.Offer)
+		}
+	}
 	if relations.len() > 0 {
 		p()
 		p("[Relations]")
@@ -144,6 +153,7 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		p(
 			indent("", level*2, name),
 			u.WorkloadStatusInfo.Current,
+			u.WorkloadVersion,
 			u.AgentStatusInfo.Current,
 			u.AgentStatusInfo.Version,
 			u.Machine,
@@ -153,7 +163,7 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		)
 	}
 
-	header := []string{"ID", "WORKLOAD-STATE", "AGENT-STATE", "VERSION", "MACHINE", "PORTS", "PUBLIC-ADDRESS", "MESSAGE"}
+	header := []string{"ID", "WORKLOAD-STATE", "APP-VERSION", "AGENT-STATE", "VERSION", "MACHINE", "PORTS", "PUBLIC-ADDRESS", "MESSAGE"}
 
 	p("\n[Units]")
 	p(strings.Join(header, "\t"))
@@ -22,6 +22,7 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	FilteredStatus(patterns []string, filter params.StatusFilter) (*params.FullStatus, error)
 	Close() error
 }
 
@@ -36,7 +37,16 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	watch    bool
 	api      statusAPI
+
+	// The following hold the filter criteria which are
+	// evaluated by the controller.
+	workloadStatuses []string
+	agentStatuses    []string
+	series           []string
+	charms           []string
+	relatedTo        []string
 }
 
 var statusDoc = `
@@ -70,6 +80,21 @@ will be displayed.
 Wildcards ('*') may be specified in service/unit names to match any sequence
 of characters. For example, 'nova-*' will match any service whose name begins
 with 'nova-': 'nova-compute', 'nova-volume', etc.
+
+The status may also be filtered on the controller by workload status,
+agent status, machine series, charm and relation. Each flag accepts a
+comma-separated list of alternatives, and all given flags must match:
+
+    juju status --workload-status blocked,error
+    juju status --series trusty --charm mysql-42
+    juju status --related-to mysql
+
+With --watch, the status is displayed and then redisplayed whenever
+a machine, service, unit or relation in the model changes. Lines that
+changed since the previous display are highlighted. Changes to existing
+machines, services and units are applied to the displayed status as
+they occur; the full status is only fetched again when entities are
+added or removed.
 `
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -84,6 +109,12 @@ func (c *statusCommand) Info() *cmd.Info {
 
 func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	f.BoolVar(&c.watch, "watch", false, "redisplay status whenever the model changes")
+	f.Var(cmd.NewStringsValue(nil, &c.workloadStatuses), "workload-status", "only show units with these workload statuses")
+	f.Var(cmd.NewStringsValue(nil, &c.agentStatuses), "agent-status", "only show units and machines with these agent statuses")
+	f.Var(cmd.NewStringsValue(nil, &c.series), "series", "only show machines, and units on machines, with these series")
+	f.Var(cmd.NewStringsValue(nil, &c.charms), "charm", "only show services using these charms (name, name-revision or URL)")
+	f.Var(cmd.NewStringsValue(nil, &c.relatedTo), "related-to", "only show services related to these services")
 
 	defaultFormat := "tabular"
 
@@ -132,7 +163,11 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
-	status, err := apiclient.Status(c.patterns)
+	if c.watch {
+		return c.runWatch(ctx, apiclient)
+	}
+
+	status, err := c.fetchStatus(apiclient)
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
@@ -148,3 +183,27 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	formatted := formatter.format()
 	return c.out.Write(ctx, formatted)
 }
+
+func (c *statusCommand) filter() params.StatusFilter {
+	filter := params.StatusFilter{
+		Series:    c.series,
+		Charms:    c.charms,
+		RelatedTo: c.relatedTo,
+	}
+	for _, s := range c.workloadStatuses {
+		filter.WorkloadStatuses = append(filter.WorkloadStatuses, params.Status(s))
+	}
+	for _, s := range c.agentStatuses {
+		filter.AgentStatuses = append(filter.AgentStatuses, params.Status(s))
+	}
+	return filter
+}
+
+// fetchStatus requests the status, only passing a filter to the
+// controller when one was specified.
+func (c *statusCommand) fetchStatus(apiclient statusAPI) (*params.FullStatus, error) {
+	if filter := c.filter(); !filter.IsEmpty() {
+		return apiclient.FilteredStatus(c.patterns, filter)
+	}
+	return apiclient.Status(c.patterns)
+}
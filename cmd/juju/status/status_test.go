@@ -2405,6 +2405,18 @@ func (st setUnitTools) step(c *gc.C, ctx *context) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type setUnitWorkloadVersion struct {
+	unitName string
+	version  string
+}
+
+func (st setUnitWorkloadVersion) step(c *gc.C, ctx *context) {
+	u, err := ctx.st.Unit(st.unitName)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.SetWorkloadVersion(st.version)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type addCharm struct {
 	name string
 }
@@ -2796,6 +2808,7 @@ func (s *StatusSuite) TestStatusAllFormats(c *gc.C) {
 type fakeApiClient struct {
 	statusReturn *params.FullStatus
 	patternsUsed []string
+	filterUsed   *params.StatusFilter
 	closeCalled  bool
 }
 
@@ -2810,11 +2823,44 @@ func (a *fakeApiClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeApiClient) FilteredStatus(patterns []string, filter params.StatusFilter) (*params.FullStatus, error) {
+	a.patternsUsed = patterns
+	a.filterUsed = &filter
+	return a.statusReturn, nil
+}
+
 func (a *fakeApiClient) Close() error {
 	a.closeCalled = true
 	return nil
 }
 
+func (s *StatusSuite) TestStatusWorkloadVersion(c *gc.C) {
+	client := newFakeApiClient(&params.FullStatus{
+		Services: map[string]params.ServiceStatus{
+			"postgresql": {
+				Charm: "cs:trusty/postgresql-1",
+				Units: map[string]params.UnitStatus{
+					"postgresql/0": {
+						WorkloadVersion: "9.5.2",
+						WorkloadInfo:    map[string]string{"edition": "community"},
+					},
+				},
+			},
+		},
+	})
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--format", "yaml")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("%s", stderr))
+	c.Check(string(stdout), jc.Contains, `
+        workload-version: 9.5.2
+        workload-info:
+          edition: community
+`[1:])
+}
+
 func (s *StatusSuite) TestStatusWithFormatSummary(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -2991,6 +3037,7 @@ func (s *StatusSuite) prepareTabularData(c *gc.C) *context {
 			state.StatusMaintenance,
 			"installing all the things", nil},
 		setUnitTools{"mysql/0", version.MustParseBinary("1.2.3-trusty-ppc")},
+		setUnitWorkloadVersion{"mysql/0", "5.7.13"},
 		addService{name: "logging", charm: "logging"},
 		setServiceExposed{"logging", true},
 		relateServices{"wordpress", "mysql"},
@@ -3040,11 +3087,11 @@ mysql       wordpress db                regular
 wordpress   logging   logging-directory subordinate 
 
 [Units]     
-ID          WORKLOAD-STATE AGENT-STATE VERSION MACHINE PORTS PUBLIC-ADDRESS   MESSAGE                        
-mysql/0     maintenance    idle        1.2.3   2             dummymodel-2.dns installing all the things      
-  logging/1 error          idle                              dummymodel-2.dns somehow lost in all those logs 
-wordpress/0 active         idle        1.2.3   1             dummymodel-1.dns                                
-  logging/0 active         idle                              dummymodel-1.dns                                
+ID          WORKLOAD-STATE APP-VERSION AGENT-STATE VERSION MACHINE PORTS PUBLIC-ADDRESS   MESSAGE                        
+mysql/0     maintenance    5.7.13      idle        1.2.3   2             dummymodel-2.dns installing all the things      
+  logging/1 error                      idle                              dummymodel-2.dns somehow lost in all those logs 
+wordpress/0 active                     idle        1.2.3   1             dummymodel-1.dns                                
+  logging/0 active                     idle                              dummymodel-1.dns                                
 
 [Machines] 
 ID         STATE   DNS              INS-ID       SERIES  AZ         
@@ -3099,15 +3146,50 @@ NAME       STATUS EXPOSED CHARM
 foo               false         
 
 [Units] 
-ID      WORKLOAD-STATE AGENT-STATE VERSION MACHINE PORTS PUBLIC-ADDRESS MESSAGE                           
-foo/0   maintenance    executing                                        (config-changed) doing some work  
-foo/1   maintenance    executing                                        (backup database) doing some work 
+ID      WORKLOAD-STATE APP-VERSION AGENT-STATE VERSION MACHINE PORTS PUBLIC-ADDRESS MESSAGE                           
+foo/0   maintenance                executing                                        (config-changed) doing some work  
+foo/1   maintenance                executing                                        (backup database) doing some work 
 
 [Machines] 
 ID         STATE DNS INS-ID SERIES AZ 
 `[1:])
 }
 
+func (s *StatusSuite) TestStatusWithFilter(c *gc.C) {
+	client := newFakeApiClient(&params.FullStatus{})
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, _, stderr := runStatus(c,
+		"--format", "yaml",
+		"--workload-status", "blocked,error",
+		"--series", "trusty",
+		"--charm", "mysql-42",
+		"--related-to", "wordpress",
+		"mysql/*",
+	)
+	c.Assert(code, gc.Equals, 0, gc.Commentf("%s", stderr))
+	c.Check(client.patternsUsed, jc.DeepEquals, []string{"mysql/*"})
+	c.Check(client.filterUsed, jc.DeepEquals, &params.StatusFilter{
+		WorkloadStatuses: []params.Status{params.StatusBlocked, params.StatusError},
+		Series:           []string{"trusty"},
+		Charms:           []string{"mysql-42"},
+		RelatedTo:        []string{"wordpress"},
+	})
+}
+
+func (s *StatusSuite) TestStatusWithoutFilter(c *gc.C) {
+	client := newFakeApiClient(&params.FullStatus{})
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return &client, nil
+	})
+
+	code, _, stderr := runStatus(c, "--format", "yaml")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("%s", stderr))
+	c.Check(client.filterUsed, gc.IsNil)
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusApi(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
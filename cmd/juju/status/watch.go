@@ -0,0 +1,372 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const (
+	// clearScreen moves the cursor to the top left corner
+	// and clears the terminal before each redraw.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// highlightStart and highlightEnd surround the lines
+	// which changed since the previous redraw.
+	highlightStart = "\x1b[1m"
+	highlightEnd   = "\x1b[0m"
+)
+
+// allWatcher is the subset of the api.AllWatcher methods used
+// when watching status.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var newAllWatcherForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status with %T", apiclient)
+	}
+	return client.WatchAll()
+}
+
+// watchedKinds holds the kinds of entity whose changes
+// affect the rendered status.
+var watchedKinds = map[string]bool{
+	"machine":  true,
+	"service":  true,
+	"unit":     true,
+	"relation": true,
+	"model":    true,
+}
+
+// statusAffected reports whether any of the given deltas
+// concern entities which are rendered in the status output.
+func statusAffected(deltas []multiwatcher.Delta) bool {
+	for _, delta := range deltas {
+		if watchedKinds[delta.Entity.EntityId().Kind] {
+			return true
+		}
+	}
+	return false
+}
+
+// runWatch renders the status once and then re-renders it each
+// time the model's AllWatcher reports a relevant change, until
+// the watcher fails. Changes to existing entities are applied to
+// the cached status; FullStatus is only requested again when the
+// changes cannot be applied, such as when entities are added or
+// removed.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI) error {
+	watcher, err := newAllWatcherForStatus(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	defer watcher.Stop()
+
+	var status *params.FullStatus
+	var previous []byte
+	for {
+		deltas, err := watcher.Next()
+		if err != nil {
+			return errors.Annotate(err, "cannot watch model")
+		}
+		// The first batch contains the entire model, so we
+		// always fetch and render the status for it.
+		if status != nil {
+			if !statusAffected(deltas) {
+				continue
+			}
+			if !applyDeltas(status, deltas, c.filter()) {
+				status = nil
+			}
+		}
+		if status == nil {
+			if status, err = c.fetchWatchedStatus(ctx, apiclient); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		current, err := c.renderStatus(ctx, status)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(current) == 0 {
+			// The status was written to an output file.
+			continue
+		}
+		fmt.Fprint(ctx.Stdout, clearScreen)
+		ctx.Stdout.Write(highlightChanges(previous, current))
+		previous = current
+	}
+}
+
+// fetchWatchedStatus fetches the current status, reporting any
+// partial failure on stderr.
+func (c *statusCommand) fetchWatchedStatus(ctx *cmd.Context, apiclient statusAPI) (*params.FullStatus, error) {
+	status, err := c.fetchStatus(apiclient)
+	if err != nil {
+		if status == nil {
+			return nil, errors.Trace(err)
+		}
+		fmt.Fprintf(ctx.Stderr, "%v\n", err)
+	} else if status == nil {
+		return nil, errors.Errorf("unable to obtain the current status")
+	}
+	return status, nil
+}
+
+// renderStatus formats the status through the command's output,
+// returning what would be written to stdout so that changes can
+// be highlighted.
+func (c *statusCommand) renderStatus(ctx *cmd.Context, status *params.FullStatus) ([]byte, error) {
+	var buf bytes.Buffer
+	bufCtx := *ctx
+	bufCtx.Stdout = &buf
+	formatted := NewStatusFormatter(status, c.isoTime).format()
+	if err := c.out.Write(&bufCtx, formatted); err != nil {
+		return nil, errors.Trace(err)
+	}
+	output := buf.Bytes()
+	if len(output) > 0 && output[len(output)-1] != '\n' {
+		output = append(output, '\n')
+	}
+	return output, nil
+}
+
+// applyDeltas applies the deltas to the status, and reports whether
+// it was able to. Deltas which add or remove entities cannot be
+// applied, as FullStatus derives relations, subordinates and
+// containers from the structure of the model; nor can changes to
+// fields evaluated by the status filter, as they may change which
+// entities match it. The status may have been partially updated
+// when false is returned, and must then be fetched again.
+func applyDeltas(status *params.FullStatus, deltas []multiwatcher.Delta, filter params.StatusFilter) bool {
+	for _, delta := range deltas {
+		if !watchedKinds[delta.Entity.EntityId().Kind] {
+			continue
+		}
+		if delta.Removed {
+			return false
+		}
+		var ok bool
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.MachineInfo:
+			ok = applyMachineInfo(status.Machines, info, filter)
+		case *multiwatcher.ServiceInfo:
+			ok = applyServiceInfo(status, info, filter)
+		case *multiwatcher.UnitInfo:
+			ok = applyUnitInfo(status, info, filter)
+		case *multiwatcher.RelationInfo:
+			ok = hasRelation(status, info.Id)
+		case *multiwatcher.ModelInfo:
+			ok = info.Name == status.ModelName
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// applyMachineInfo updates the machine, or container, in machines
+// with the given info.
+func applyMachineInfo(machines map[string]params.MachineStatus, info *multiwatcher.MachineInfo, filter params.StatusFilter) bool {
+	machine, ok := machines[info.Id]
+	if !ok {
+		for id, parent := range machines {
+			if applyMachineInfo(parent.Containers, info, filter) {
+				machines[id] = parent
+				return true
+			}
+		}
+		return false
+	}
+	if len(filter.AgentStatuses) > 0 && machine.Agent.Status != params.Status(info.Status) {
+		return false
+	}
+	if len(filter.Series) > 0 && machine.Series != info.Series {
+		return false
+	}
+	if machine.Agent.Status != params.Status(info.Status) || machine.Agent.Info != info.StatusInfo {
+		// The time of the change is not reported.
+		machine.Agent.Since = nil
+	}
+	machine.Agent.Status = params.Status(info.Status)
+	machine.Agent.Info = info.StatusInfo
+	machine.Agent.Data = filterStatusData(info.StatusData)
+	machine.Agent.Life = formatLife(info.Life)
+	machine.Series = info.Series
+	machine.Jobs = info.Jobs
+	machine.HasVote = info.HasVote
+	machine.WantsVote = info.WantsVote
+	if info.InstanceId != "" {
+		machine.InstanceId = instance.Id(info.InstanceId)
+		addr, _ := network.SelectPublicAddress(info.Addresses)
+		machine.DNSName = addr.Value
+	}
+	if info.HardwareCharacteristics != nil {
+		machine.Hardware = info.HardwareCharacteristics.String()
+	}
+	machines[info.Id] = machine
+	return true
+}
+
+// applyServiceInfo updates the service in the status with the
+// given info.
+func applyServiceInfo(status *params.FullStatus, info *multiwatcher.ServiceInfo, filter params.StatusFilter) bool {
+	service, ok := status.Services[info.Name]
+	if !ok {
+		return false
+	}
+	if len(filter.Charms) > 0 && service.Charm != info.CharmURL {
+		return false
+	}
+	service.Charm = info.CharmURL
+	service.Exposed = info.Exposed
+	service.Life = formatLife(info.Life)
+	service.Status = applyStatusInfo(service.Status, info.Status)
+	status.Services[info.Name] = service
+	return true
+}
+
+// applyUnitInfo updates the unit, or subordinate unit, in the status
+// with the given info.
+func applyUnitInfo(status *params.FullStatus, info *multiwatcher.UnitInfo, filter params.StatusFilter) bool {
+	units, serviceCharm := findUnits(status, info)
+	unit, ok := units[info.Name]
+	if !ok {
+		return false
+	}
+	workload := applyStatusInfo(unit.Workload, info.WorkloadStatus)
+	agent := applyStatusInfo(unit.UnitAgent, info.AgentStatus)
+	if len(filter.WorkloadStatuses) > 0 && workload.Status != unit.Workload.Status {
+		return false
+	}
+	if len(filter.AgentStatuses) > 0 && agent.Status != unit.UnitAgent.Status {
+		return false
+	}
+	unit.Workload, unit.UnitAgent = workload, agent
+	unit.PublicAddress = info.PublicAddress
+	unit.OpenedPorts = nil
+	for _, portRange := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, portRange.String())
+	}
+	if !info.Subordinate {
+		unit.Machine = info.MachineId
+	}
+	unit.Charm = ""
+	if info.CharmURL != serviceCharm {
+		unit.Charm = info.CharmURL
+	}
+	unit.WorkloadVersion = info.WorkloadVersion
+	unit.WorkloadInfo = nil
+	if len(info.WorkloadInfo) > 0 {
+		unit.WorkloadInfo = info.WorkloadInfo
+	}
+	units[info.Name] = unit
+	return true
+}
+
+// findUnits returns the map of units in the status which holds the
+// unit with the given info, and the charm of the service whose units
+// they are, or of the principal unit's service for subordinates.
+func findUnits(status *params.FullStatus, info *multiwatcher.UnitInfo) (map[string]params.UnitStatus, string) {
+	if !info.Subordinate {
+		service := status.Services[info.Service]
+		return service.Units, service.Charm
+	}
+	for _, service := range status.Services {
+		for _, unit := range service.Units {
+			if _, ok := unit.Subordinates[info.Name]; ok {
+				return unit.Subordinates, service.Charm
+			}
+		}
+	}
+	return nil, ""
+}
+
+// applyStatusInfo returns the agent status updated with the given
+// status info.
+func applyStatusInfo(status params.AgentStatus, info multiwatcher.StatusInfo) params.AgentStatus {
+	status.Status = params.Status(info.Current)
+	status.Info = info.Message
+	status.Data = filterStatusData(info.Data)
+	status.Since = info.Since
+	if info.Version != "" {
+		status.Version = info.Version
+	}
+	return status
+}
+
+func hasRelation(status *params.FullStatus, id int) bool {
+	for _, relation := range status.Relations {
+		if relation.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// formatLife returns the life as reported in status, which omits
+// the usual "alive".
+func formatLife(life multiwatcher.Life) string {
+	if life == "alive" {
+		return ""
+	}
+	return string(life)
+}
+
+// filterStatusData returns the status data that the controller
+// passes on in status, which is only the relation ID.
+func filterStatusData(data map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	if relationId, ok := data["relation-id"]; ok {
+		out["relation-id"] = relationId
+	}
+	return out
+}
+
+// highlightChanges returns current with every line that did not
+// appear in previous highlighted. Nothing is highlighted when
+// there is no previous output.
+func highlightChanges(previous, current []byte) []byte {
+	if previous == nil {
+		return current
+	}
+	seen := make(map[string]int)
+	for _, line := range bytes.Split(previous, []byte("\n")) {
+		seen[string(line)]++
+	}
+	var out bytes.Buffer
+	lines := bytes.Split(current, []byte("\n"))
+	for i, line := range lines {
+		switch {
+		case seen[string(line)] > 0:
+			seen[string(line)]--
+			out.Write(line)
+		case len(bytes.TrimSpace(line)) == 0:
+			out.Write(line)
+		default:
+			out.WriteString(highlightStart)
+			out.Write(line)
+			out.WriteString(highlightEnd)
+		}
+		if i < len(lines)-1 {
+			out.WriteByte('\n')
+		}
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,294 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WatchSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func (s *WatchSuite) TestStatusAffected(c *gc.C) {
+	c.Check(statusAffected(nil), jc.IsFalse)
+	c.Check(statusAffected([]multiwatcher.Delta{{
+		Entity: &multiwatcher.AnnotationInfo{Tag: "unit-foo-0"},
+	}}), jc.IsFalse)
+	c.Check(statusAffected([]multiwatcher.Delta{{
+		Entity: &multiwatcher.AnnotationInfo{Tag: "unit-foo-0"},
+	}, {
+		Entity: &multiwatcher.UnitInfo{Name: "foo/0"},
+	}}), jc.IsTrue)
+}
+
+func (s *WatchSuite) TestHighlightChangesFirstRender(c *gc.C) {
+	current := []byte("a\nb\n")
+	c.Assert(string(highlightChanges(nil, current)), gc.Equals, "a\nb\n")
+}
+
+func (s *WatchSuite) TestHighlightChanges(c *gc.C) {
+	previous := []byte("[Units]\nfoo/0 active\nfoo/1 active\n\n[Machines]\n")
+	current := []byte("[Units]\nfoo/0 active\nfoo/1 blocked\n\n[Machines]\n")
+	c.Assert(string(highlightChanges(previous, current)), gc.Equals,
+		"[Units]\nfoo/0 active\n"+highlightStart+"foo/1 blocked"+highlightEnd+"\n\n[Machines]\n")
+}
+
+func (s *WatchSuite) TestApplyDeltasUnit(c *gc.C) {
+	since := time.Date(2016, 4, 1, 0, 0, 0, 0, time.UTC)
+	status := &params.FullStatus{
+		Services: map[string]params.ServiceStatus{
+			"foo": {
+				Charm: "cs:quantal/foo-1",
+				Units: map[string]params.UnitStatus{
+					"foo/0": {
+						Machine: "0",
+						Subordinates: map[string]params.UnitStatus{
+							"logging/0": {},
+						},
+					},
+				},
+			},
+		},
+	}
+	ok := applyDeltas(status, []multiwatcher.Delta{{
+		Entity: &multiwatcher.UnitInfo{
+			Name:          "foo/0",
+			Service:       "foo",
+			CharmURL:      "cs:quantal/foo-2",
+			MachineId:     "0",
+			PublicAddress: "10.0.0.1",
+			PortRanges:    []network.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+			WorkloadStatus: multiwatcher.StatusInfo{
+				Current: multiwatcher.Status("blocked"),
+				Message: "waiting for db",
+				Since:   &since,
+				Data:    map[string]interface{}{"relation-id": 1.0, "secret": "x"},
+			},
+			AgentStatus: multiwatcher.StatusInfo{Current: multiwatcher.Status("idle")},
+		},
+	}, {
+		Entity: &multiwatcher.UnitInfo{
+			Name:           "logging/0",
+			Service:        "logging",
+			CharmURL:       "cs:quantal/logging-1",
+			Subordinate:    true,
+			WorkloadStatus: multiwatcher.StatusInfo{Current: multiwatcher.Status("active")},
+		},
+	}}, params.StatusFilter{})
+	c.Assert(ok, jc.IsTrue)
+	unit := status.Services["foo"].Units["foo/0"]
+	c.Check(unit.Charm, gc.Equals, "cs:quantal/foo-2")
+	c.Check(unit.PublicAddress, gc.Equals, "10.0.0.1")
+	c.Check(unit.OpenedPorts, jc.DeepEquals, []string{"80/tcp"})
+	c.Check(unit.Workload, jc.DeepEquals, params.AgentStatus{
+		Status: params.StatusBlocked,
+		Info:   "waiting for db",
+		Since:  &since,
+		Data:   map[string]interface{}{"relation-id": 1.0},
+	})
+	c.Check(unit.UnitAgent.Status, gc.Equals, params.StatusIdle)
+	c.Check(unit.Subordinates["logging/0"].Workload.Status, gc.Equals, params.StatusActive)
+	c.Check(unit.Subordinates["logging/0"].Charm, gc.Equals, "cs:quantal/logging-1")
+}
+
+func (s *WatchSuite) TestApplyDeltasMachineContainer(c *gc.C) {
+	status := &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id: "0",
+				Containers: map[string]params.MachineStatus{
+					"0/lxc/0": {Id: "0/lxc/0", InstanceId: "pending"},
+				},
+			},
+		},
+	}
+	ok := applyDeltas(status, []multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{
+			Id:         "0/lxc/0",
+			InstanceId: "juju-lxc-0",
+			Status:     multiwatcher.Status("started"),
+			Series:     "trusty",
+			Life:       multiwatcher.Life("alive"),
+			Addresses:  []network.Address{network.NewScopedAddress("1.2.3.4", network.ScopePublic)},
+		},
+	}}, params.StatusFilter{})
+	c.Assert(ok, jc.IsTrue)
+	container := status.Machines["0"].Containers["0/lxc/0"]
+	c.Check(container.InstanceId, gc.Equals, instance.Id("juju-lxc-0"))
+	c.Check(container.DNSName, gc.Equals, "1.2.3.4")
+	c.Check(container.Agent.Status, gc.Equals, params.StatusStarted)
+	c.Check(container.Agent.Life, gc.Equals, "")
+	c.Check(container.Series, gc.Equals, "trusty")
+}
+
+func (s *WatchSuite) TestApplyDeltasStructuralChanges(c *gc.C) {
+	newStatus := func() *params.FullStatus {
+		return &params.FullStatus{
+			Machines: map[string]params.MachineStatus{"0": {Id: "0"}},
+			Services: map[string]params.ServiceStatus{
+				"foo": {Units: map[string]params.UnitStatus{"foo/0": {}}},
+			},
+			Relations: []params.RelationStatus{{Id: 1}},
+		}
+	}
+	for i, test := range []multiwatcher.Delta{
+		{Entity: &multiwatcher.UnitInfo{Name: "foo/1", Service: "foo"}},
+		{Entity: &multiwatcher.UnitInfo{Name: "bar/0", Service: "bar"}},
+		{Entity: &multiwatcher.MachineInfo{Id: "1"}},
+		{Entity: &multiwatcher.ServiceInfo{Name: "bar"}},
+		{Entity: &multiwatcher.RelationInfo{Id: 2}},
+		{Entity: &multiwatcher.UnitInfo{Name: "foo/0", Service: "foo"}, Removed: true},
+	} {
+		c.Logf("test %d", i)
+		c.Check(applyDeltas(newStatus(), []multiwatcher.Delta{test}, params.StatusFilter{}), jc.IsFalse)
+	}
+	c.Check(applyDeltas(newStatus(), []multiwatcher.Delta{
+		{Entity: &multiwatcher.RelationInfo{Id: 1}},
+		{Entity: &multiwatcher.AnnotationInfo{Tag: "unit-foo-1"}},
+	}, params.StatusFilter{}), jc.IsTrue)
+}
+
+func (s *WatchSuite) TestApplyDeltasFilteredStatusChange(c *gc.C) {
+	status := &params.FullStatus{
+		Services: map[string]params.ServiceStatus{
+			"foo": {Units: map[string]params.UnitStatus{
+				"foo/0": {Workload: params.AgentStatus{Status: params.StatusBlocked}},
+			}},
+		},
+	}
+	filter := params.StatusFilter{WorkloadStatuses: []params.Status{params.StatusBlocked}}
+	delta := multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           "foo/0",
+		Service:        "foo",
+		WorkloadStatus: multiwatcher.StatusInfo{Current: multiwatcher.Status("blocked"), Message: "still"},
+	}}
+	// A change which leaves the filtered status alone is applied.
+	c.Check(applyDeltas(status, []multiwatcher.Delta{delta}, filter), jc.IsTrue)
+	// A change to the filtered status may change which units match.
+	delta.Entity.(*multiwatcher.UnitInfo).WorkloadStatus.Current = multiwatcher.Status("active")
+	c.Check(applyDeltas(status, []multiwatcher.Delta{delta}, filter), jc.IsFalse)
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, errors.New("watcher stopped")
+	}
+	deltas := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+type countingApiClient struct {
+	fakeApiClient
+	calls int
+}
+
+func (a *countingApiClient) Status(patterns []string) (*params.FullStatus, error) {
+	a.calls++
+	return a.fakeApiClient.Status(patterns)
+}
+
+func (s *StatusSuite) TestStatusWatch(c *gc.C) {
+	client := &countingApiClient{
+		fakeApiClient: newFakeApiClient(&params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"0": {Id: "0", Agent: params.AgentStatus{Status: params.StatusStarted}},
+			},
+			Services: map[string]params.ServiceStatus{
+				"foo": {
+					Charm: "cs:quantal/foo-1",
+					Units: map[string]params.UnitStatus{
+						"foo/0": {
+							Machine:  "0",
+							Workload: params.AgentStatus{Status: params.StatusMaintenance},
+						},
+					},
+				},
+			},
+		}),
+	}
+	watcher := &fakeAllWatcher{
+		deltas: [][]multiwatcher.Delta{
+			{{Entity: &multiwatcher.UnitInfo{Name: "foo/0", Service: "foo"}}},
+			{{Entity: &multiwatcher.UnitInfo{
+				Name:           "foo/0",
+				Service:        "foo",
+				CharmURL:       "cs:quantal/foo-1",
+				MachineId:      "0",
+				WorkloadStatus: multiwatcher.StatusInfo{Current: multiwatcher.Status("active"), Message: "ready"},
+			}}},
+			{{Entity: &multiwatcher.AnnotationInfo{Tag: "unit-foo-0"}}},
+			{{Entity: &multiwatcher.MachineInfo{Id: "1"}}},
+		},
+	}
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&newAllWatcherForStatus, func(_ statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--watch", "--format", "yaml", "foo")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "error: cannot watch model: watcher stopped\n")
+	// The status is fetched for the initial batch, and again only
+	// for the new machine; the unit's change is applied to the
+	// cached status, and the annotation change is ignored.
+	c.Check(client.calls, gc.Equals, 2)
+	c.Check(client.patternsUsed, jc.DeepEquals, []string{"foo"})
+	c.Check(watcher.stopped, jc.IsTrue)
+	c.Check(client.closeCalled, jc.IsTrue)
+	c.Check(string(stdout), jc.HasPrefix, clearScreen)
+	c.Check(string(stdout), jc.Contains, highlightStart+"          message: ready"+highlightEnd)
+}
+
+func (s *StatusSuite) TestStatusWatchOutputFile(c *gc.C) {
+	client := &countingApiClient{
+		fakeApiClient: newFakeApiClient(&params.FullStatus{}),
+	}
+	watcher := &fakeAllWatcher{
+		deltas: [][]multiwatcher.Delta{
+			{{Entity: &multiwatcher.UnitInfo{Name: "foo/0", Service: "foo"}}},
+		},
+	}
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&newAllWatcherForStatus, func(_ statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	path := filepath.Join(c.MkDir(), "status.yaml")
+	code, stdout, _ := runStatus(c, "--watch", "--format", "yaml", "-o", path)
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stdout), gc.Equals, "")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "model: \"\"\nmachines: {}\nservices: {}\n")
+}
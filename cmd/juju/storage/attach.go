@@ -0,0 +1,232 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// AttachAPI defines the API methods that the detach-storage and
+// attach-storage commands use.
+type AttachAPI interface {
+	Close() error
+	Detach([]names.StorageTag, bool) ([]params.ErrorResult, error)
+	Attach([]params.StorageAttachmentId) ([]params.ErrorResult, error)
+}
+
+const detachCommandDoc = `
+Detaches storage instances from the units that they are attached to,
+without destroying the storage. Detached storage is owned by the unit's
+service, and outlives the unit; it may later be attached to another unit
+of the same service with "juju attach-storage".
+
+The unit is notified with a "storage-detaching" hook before the storage
+is detached. Once detached, the storage's volume or filesystem is detached
+from the unit's machine.
+
+If the unit's agent is down, the storage-detaching hook cannot run and
+the storage is never detached. The --force option detaches the storage
+without running the hook; it also completes an earlier detach-storage
+that is waiting for the unit's agent.
+
+Storage whose volume or filesystem is scoped to a machine, such as "loop"
+or "rootfs" storage, cannot be detached.
+
+Examples:
+    juju detach-storage pgdata/0
+    juju detach-storage --force pgdata/0
+`
+
+// NewDetachCommand returns a command that detaches storage instances
+// from units.
+func NewDetachCommand() cmd.Command {
+	return modelcmd.Wrap(&detachCommand{})
+}
+
+// detachCommand detaches storage instances from units.
+type detachCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (AttachAPI, error)
+
+	storageTags []names.StorageTag
+	force       bool
+}
+
+// Info implements Command.Info.
+func (c *detachCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detach-storage",
+		Args:    "<storage ID> ...",
+		Purpose: "detach storage from units without destroying it",
+		Doc:     detachCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *detachCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "detach without waiting for the storage-detaching hook to run")
+}
+
+// Init implements Command.Init.
+func (c *detachCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no storage IDs specified")
+	}
+	tags, err := parseStorageIds(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.storageTags = tags
+	return nil
+}
+
+func (c *detachCommand) getAPI() (AttachAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	return c.NewStorageAPI()
+}
+
+// Run implements Command.Run.
+func (c *detachCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Detach(c.storageTags, c.force)
+	if errors.IsNotImplemented(err) {
+		return errors.New("this controller does not support detaching storage")
+	} else if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot detach storage %s: %v\n", c.storageTags[i].Id(), result.Error)
+		}
+	}
+	return nil
+}
+
+const attachCommandDoc = `
+Attaches storage instances, previously detached with "juju detach-storage",
+to a unit. The unit must belong to the service that the storage was
+detached from, and the unit's charm must declare storage of the same name
+and kind.
+
+If the unit is assigned to a machine, the storage's volume or filesystem
+is attached to the machine, and the unit is notified with a
+"storage-attached" hook once it is available.
+
+Examples:
+    juju attach-storage postgresql/1 pgdata/0
+`
+
+// NewAttachCommand returns a command that attaches detached storage
+// instances to a unit.
+func NewAttachCommand() cmd.Command {
+	return modelcmd.Wrap(&attachCommand{})
+}
+
+// attachCommand attaches detached storage instances to a unit.
+type attachCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (AttachAPI, error)
+
+	unitTag     names.UnitTag
+	storageTags []names.StorageTag
+}
+
+// Info implements Command.Info.
+func (c *attachCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "attach-storage",
+		Args:    "<unit name> <storage ID> ...",
+		Purpose: "attach detached storage to a unit",
+		Doc:     attachCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *attachCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit specified")
+	case 1:
+		return errors.New("no storage IDs specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.NotValidf("unit name %q", args[0])
+	}
+	tags, err := parseStorageIds(args[1:])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.unitTag = names.NewUnitTag(args[0])
+	c.storageTags = tags
+	return nil
+}
+
+func (c *attachCommand) getAPI() (AttachAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	return c.NewStorageAPI()
+}
+
+// Run implements Command.Run.
+func (c *attachCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	ids := make([]params.StorageAttachmentId, len(c.storageTags))
+	for i, tag := range c.storageTags {
+		ids[i] = params.StorageAttachmentId{
+			StorageTag: tag.String(),
+			UnitTag:    c.unitTag.String(),
+		}
+	}
+	results, err := api.Attach(ids)
+	if errors.IsNotImplemented(err) {
+		return errors.New("this controller does not support attaching storage")
+	} else if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(
+				ctx.Stderr, "cannot attach storage %s to %s: %v\n",
+				c.storageTags[i].Id(), c.unitTag.Id(), result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// parseStorageIds parses the specified storage IDs, returning the
+// corresponding storage tags.
+func parseStorageIds(ids []string) ([]names.StorageTag, error) {
+	tags := make([]names.StorageTag, len(ids))
+	for i, id := range ids {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		tags[i] = names.NewStorageTag(id)
+	}
+	return tags, nil
+}
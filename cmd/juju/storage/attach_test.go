@@ -0,0 +1,140 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type attachSuite struct {
+	SubStorageSuite
+	mockAPI *mockAttachAPI
+}
+
+var _ = gc.Suite(&attachSuite{})
+
+func (s *attachSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockAttachAPI{}
+}
+
+func (s *attachSuite) runDetach(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewDetachCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *attachSuite) runAttach(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewAttachCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *attachSuite) TestDetachNoArgs(c *gc.C) {
+	_, err := s.runDetach(c)
+	c.Assert(err, gc.ErrorMatches, "no storage IDs specified")
+}
+
+func (s *attachSuite) TestDetachInvalidId(c *gc.C) {
+	_, err := s.runDetach(c, "data/0", "0/1")
+	c.Assert(err, gc.ErrorMatches, `storage ID "0/1" not valid`)
+}
+
+func (s *attachSuite) TestDetach(c *gc.C) {
+	var called bool
+	s.mockAPI.detach = func(tags []names.StorageTag, force bool) ([]params.ErrorResult, error) {
+		called = true
+		c.Assert(force, jc.IsFalse)
+		c.Assert(tags, jc.DeepEquals, []names.StorageTag{
+			names.NewStorageTag("data/0"),
+			names.NewStorageTag("data/1"),
+		})
+		return []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+		}, nil
+	}
+	ctx, err := s.runDetach(c, "data/0", "data/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "cannot detach storage data/1: boom\n")
+}
+
+func (s *attachSuite) TestDetachForce(c *gc.C) {
+	var called bool
+	s.mockAPI.detach = func(tags []names.StorageTag, force bool) ([]params.ErrorResult, error) {
+		called = true
+		c.Assert(force, jc.IsTrue)
+		c.Assert(tags, jc.DeepEquals, []names.StorageTag{names.NewStorageTag("data/0")})
+		return []params.ErrorResult{{}}, nil
+	}
+	_, err := s.runDetach(c, "--force", "data/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *attachSuite) TestDetachNotSupported(c *gc.C) {
+	s.mockAPI.detach = func([]names.StorageTag, bool) ([]params.ErrorResult, error) {
+		return nil, errors.NotImplementedf("Detach() (need V3+)")
+	}
+	_, err := s.runDetach(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support detaching storage")
+}
+
+func (s *attachSuite) TestAttachNoArgs(c *gc.C) {
+	_, err := s.runAttach(c)
+	c.Assert(err, gc.ErrorMatches, "no unit specified")
+}
+
+func (s *attachSuite) TestAttachNoStorage(c *gc.C) {
+	_, err := s.runAttach(c, "mysql/1")
+	c.Assert(err, gc.ErrorMatches, "no storage IDs specified")
+}
+
+func (s *attachSuite) TestAttachInvalidUnit(c *gc.C) {
+	_, err := s.runAttach(c, "mysql", "data/0")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+}
+
+func (s *attachSuite) TestAttach(c *gc.C) {
+	var called bool
+	s.mockAPI.attach = func(ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+		called = true
+		c.Assert(ids, jc.DeepEquals, []params.StorageAttachmentId{
+			{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+			{StorageTag: "storage-data-1", UnitTag: "unit-mysql-1"},
+		})
+		return []params.ErrorResult{
+			{Error: &params.Error{Message: "boom"}},
+			{},
+		}, nil
+	}
+	ctx, err := s.runAttach(c, "mysql/1", "data/0", "data/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "cannot attach storage data/0 to mysql/1: boom\n")
+}
+
+type mockAttachAPI struct {
+	detach func([]names.StorageTag, bool) ([]params.ErrorResult, error)
+	attach func([]params.StorageAttachmentId) ([]params.ErrorResult, error)
+}
+
+func (s *mockAttachAPI) Close() error {
+	return nil
+}
+
+func (s *mockAttachAPI) Detach(tags []names.StorageTag, force bool) ([]params.ErrorResult, error) {
+	return s.detach(tags, force)
+}
+
+func (s *mockAttachAPI) Attach(ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+	return s.attach(ids)
+}
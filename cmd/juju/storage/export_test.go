@@ -73,3 +73,75 @@ func NewFilesystemListCommand(api FilesystemListAPI, store jujuclient.ClientStor
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewCreateSnapshotCommandForTest(api SnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newAPIFunc = func() (SnapshotAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewListSnapshotsCommandForTest(api SnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listSnapshotsCommand{}
+	cmd.newAPIFunc = func() (SnapshotAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveSnapshotCommandForTest(api SnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeSnapshotCommand{}
+	cmd.newAPIFunc = func() (SnapshotAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRestoreSnapshotCommandForTest(api SnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &restoreSnapshotCommand{}
+	cmd.newAPIFunc = func() (SnapshotAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewResizeCommandForTest(api ResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeCommand{}
+	cmd.newAPIFunc = func() (ResizeAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewDetachCommandForTest(api AttachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &detachCommand{}
+	cmd.newAPIFunc = func() (AttachAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewAttachCommandForTest(api AttachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &attachCommand{}
+	cmd.newAPIFunc = func() (AttachAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewUsageCommandForTest(api UsageAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &usageCommand{}
+	cmd.newAPIFunc = func() (UsageAPI, error) {
+		return api, nil
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
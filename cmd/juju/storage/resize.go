@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// ResizeAPI defines the API methods that the resize-storage command
+// uses.
+type ResizeAPI interface {
+	Close() error
+	ResizeStorage(storages []params.StorageResizeParams) ([]params.ErrorResult, error)
+}
+
+const resizeCommandDoc = `
+Grows the volume or filesystem assigned to a storage instance to the
+specified size. Storage is grown online, asynchronously, by the storage
+provisioner; once the storage has been grown, the unit that it is
+attached to is notified with a "storage-attached" hook.
+
+Storage can only be grown, not shrunk. Only "loop" and "lvm" volumes,
+and filesystems created by Juju on such volumes, can be grown. Cloud
+volumes (e.g. "ebs", "cinder" and "gce") and "rootfs" and "tmpfs"
+filesystems cannot be grown; resizing them sets the status of the
+volume or filesystem to error.
+
+SIZE is a floating point number and multiplier from the set
+(M, G, T, P, E, Z, Y), which are all treated as powers of 1024.
+If no multiplier is specified, M is assumed.
+
+Examples:
+    juju resize-storage data/0 20G
+`
+
+// NewResizeCommand returns a command that grows storage instances.
+func NewResizeCommand() cmd.Command {
+	return modelcmd.Wrap(&resizeCommand{})
+}
+
+// resizeCommand grows the volume or filesystem assigned to a storage
+// instance.
+type resizeCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (ResizeAPI, error)
+
+	storageTag names.StorageTag
+	size       uint64
+}
+
+// Info implements Command.Info.
+func (c *resizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Args:    "<storage ID> <size>",
+		Purpose: "grow the volume or filesystem of a storage instance",
+		Doc:     resizeCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *resizeCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no storage ID specified")
+	case 1:
+		return errors.New("no size specified")
+	}
+	id, size, args := args[0], args[1], args[2:]
+	if !names.IsValidStorage(id) {
+		return errors.NotValidf("storage ID %q", id)
+	}
+	sizeMiB, err := utils.ParseSize(size)
+	if err != nil {
+		return errors.Annotatef(err, "invalid size %q", size)
+	}
+	if sizeMiB == 0 {
+		return errors.NotValidf("size %q", size)
+	}
+	c.storageTag = names.NewStorageTag(id)
+	c.size = sizeMiB
+	return cmd.CheckEmpty(args)
+}
+
+func (c *resizeCommand) getAPI() (ResizeAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	return c.NewStorageAPI()
+}
+
+// Run implements Command.Run.
+func (c *resizeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ResizeStorage([]params.StorageResizeParams{{
+		StorageTag: c.storageTag.String(),
+		Size:       c.size,
+	}})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type resizeSuite struct {
+	SubStorageSuite
+	mockAPI *mockResizeAPI
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockResizeAPI{}
+}
+
+func (s *resizeSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewResizeCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *resizeSuite) TestResizeNoArgs(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no storage ID specified")
+}
+
+func (s *resizeSuite) TestResizeNoSize(c *gc.C) {
+	_, err := s.run(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, "no size specified")
+}
+
+func (s *resizeSuite) TestResizeInvalidId(c *gc.C) {
+	_, err := s.run(c, "0/1", "2G")
+	c.Assert(err, gc.ErrorMatches, `storage ID "0/1" not valid`)
+}
+
+func (s *resizeSuite) TestResizeInvalidSize(c *gc.C) {
+	_, err := s.run(c, "data/0", "big")
+	c.Assert(err, gc.ErrorMatches, `invalid size "big": .*`)
+}
+
+func (s *resizeSuite) TestResizeTooManyArgs(c *gc.C) {
+	_, err := s.run(c, "data/0", "2G", "3G")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["3G"\]`)
+}
+
+func (s *resizeSuite) TestResize(c *gc.C) {
+	var called bool
+	s.mockAPI.resizeStorage = func(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+		called = true
+		c.Assert(storages, jc.DeepEquals, []params.StorageResizeParams{{
+			StorageTag: "storage-data-0",
+			Size:       2048,
+		}})
+		return make([]params.ErrorResult, 1), nil
+	}
+	ctx, err := s.run(c, "data/0", "2G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *resizeSuite) TestResizeError(c *gc.C) {
+	s.mockAPI.resizeStorage = func(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+		return []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}, nil
+	}
+	_, err := s.run(c, "data/0", "512")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockResizeAPI struct {
+	resizeStorage func([]params.StorageResizeParams) ([]params.ErrorResult, error)
+}
+
+func (s *mockResizeAPI) Close() error {
+	return nil
+}
+
+func (s *mockResizeAPI) ResizeStorage(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+	return s.resizeStorage(storages)
+}
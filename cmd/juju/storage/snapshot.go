@@ -0,0 +1,463 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SnapshotAPI defines the API methods that the storage snapshot
+// commands use.
+type SnapshotAPI interface {
+	Close() error
+	CreateVolumeSnapshots(tags []names.Tag) ([]params.StringResult, error)
+	ListVolumeSnapshots(volumes []names.VolumeTag) ([]params.VolumeSnapshotDetails, error)
+	DestroyVolumeSnapshots(ids []string) ([]params.ErrorResult, error)
+	RestoreVolumeSnapshots(snapshots []params.RestoreVolumeSnapshotParams) ([]params.StringResult, error)
+}
+
+// snapshotCommandBase is a helper base structure for the storage
+// snapshot commands.
+type snapshotCommandBase struct {
+	StorageCommandBase
+	newAPIFunc func() (SnapshotAPI, error)
+}
+
+func (c *snapshotCommandBase) getAPI() (SnapshotAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	return c.NewStorageAPI()
+}
+
+const createSnapshotCommandDoc = `
+Creates snapshots of the volumes backing the specified storage instances
+or volumes. Snapshots are created asynchronously by the storage
+provisioner; use "juju list-storage-snapshots" to follow their progress.
+
+Only volumes whose storage provider supports snapshots, such as "loop",
+"lvm" and "ebs", can be snapshotted. Snapshots of machine-scoped volumes are
+stored on the machine, and are removed along with it.
+
+Examples:
+    juju create-storage-snapshot data/0
+    juju create-storage-snapshot 0/1 2
+`
+
+// NewCreateSnapshotCommand returns a command that creates snapshots
+// of volumes.
+func NewCreateSnapshotCommand() cmd.Command {
+	return modelcmd.Wrap(&createSnapshotCommand{})
+}
+
+// createSnapshotCommand creates snapshots of volumes.
+type createSnapshotCommand struct {
+	snapshotCommandBase
+	ids []string
+}
+
+// Info implements Command.Info.
+func (c *createSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-storage-snapshot",
+		Args:    "<storage or volume ID> ...",
+		Purpose: "create snapshots of storage volumes",
+		Doc:     createSnapshotCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *createSnapshotCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no storage or volume IDs specified")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) && !names.IsValidVolume(id) {
+			return errors.NotValidf("storage or volume ID %q", id)
+		}
+	}
+	c.ids = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *createSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	tags := make([]names.Tag, len(c.ids))
+	for i, id := range c.ids {
+		// Storage IDs always begin with a letter, and
+		// volume IDs never do, so they are unambiguous.
+		if names.IsValidStorage(id) {
+			tags[i] = names.NewStorageTag(id)
+		} else {
+			tags[i] = names.NewVolumeTag(id)
+		}
+	}
+	results, err := api.CreateVolumeSnapshots(tags)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot snapshot %s: %v\n", names.ReadableString(tags[i]), result.Error)
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "%s\n", result.Result)
+	}
+	return nil
+}
+
+const listSnapshotsCommandDoc = `
+Lists volume snapshots in the model, optionally restricted to snapshots
+of the specified volumes.
+
+Examples:
+    juju list-storage-snapshots
+    juju list-storage-snapshots 0/1 --format yaml
+`
+
+// NewListSnapshotsCommand returns a command that lists volume snapshots.
+func NewListSnapshotsCommand() cmd.Command {
+	return modelcmd.Wrap(&listSnapshotsCommand{})
+}
+
+// listSnapshotsCommand lists volume snapshots.
+type listSnapshotsCommand struct {
+	snapshotCommandBase
+	volumeIds []string
+	out       cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-storage-snapshots",
+		Args:    "[<volume ID> ...]",
+		Purpose: "list volume snapshots",
+		Doc:     listSnapshotsCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listSnapshotsCommand) Init(args []string) error {
+	for _, id := range args {
+		if !names.IsValidVolume(id) {
+			return errors.NotValidf("volume ID %q", id)
+		}
+	}
+	c.volumeIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	volumes := make([]names.VolumeTag, len(c.volumeIds))
+	for i, id := range c.volumeIds {
+		volumes[i] = names.NewVolumeTag(id)
+	}
+	results, err := api.ListVolumeSnapshots(volumes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	info, err := convertToSnapshotInfo(results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var output interface{}
+	switch c.out.Name() {
+	case "json", "yaml":
+		output = map[string]map[string]SnapshotInfo{"snapshots": info}
+	default:
+		output = info
+	}
+	return c.out.Write(ctx, output)
+}
+
+// SnapshotInfo defines the serialization behaviour for volume snapshots.
+type SnapshotInfo struct {
+	// Volume is the ID of the volume that the snapshot was taken of.
+	Volume string `yaml:"volume" json:"volume"`
+
+	// Storage is the ID of the storage instance that the volume is
+	// assigned to, if any.
+	Storage string `yaml:"storage,omitempty" json:"storage,omitempty"`
+
+	// Pool is the storage pool of the snapshot's volume.
+	Pool string `yaml:"pool" json:"pool"`
+
+	// ProviderSnapshotId is the provider-supplied unique snapshot ID.
+	ProviderSnapshotId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+
+	// Size is the size of the snapshot, in MiB.
+	Size uint64 `yaml:"size,omitempty" json:"size,omitempty"`
+
+	// Life is the lifecycle state of the snapshot.
+	Life string `yaml:"life" json:"life"`
+
+	// Error is the error, if any, that occurred when creating
+	// the snapshot.
+	Error string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// convertToSnapshotInfo returns a map of snapshot IDs to snapshot info.
+func convertToSnapshotInfo(all []params.VolumeSnapshotDetails) (map[string]SnapshotInfo, error) {
+	result := make(map[string]SnapshotInfo)
+	for _, one := range all {
+		volumeTag, err := names.ParseVolumeTag(one.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := SnapshotInfo{
+			Volume: volumeTag.Id(),
+			Pool:   one.Pool,
+			Life:   string(one.Life),
+			Error:  one.Error,
+		}
+		if one.StorageTag != "" {
+			storageTag, err := names.ParseStorageTag(one.StorageTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.Storage = storageTag.Id()
+		}
+		if one.Info != nil {
+			info.ProviderSnapshotId = one.Info.SnapshotId
+			info.Size = one.Info.Size
+		}
+		result[one.Id] = info
+	}
+	return result, nil
+}
+
+// formatSnapshotListTabular returns a tabular summary of volume snapshots.
+func formatSnapshotListTabular(value interface{}) ([]byte, error) {
+	infos, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("ID", "VOLUME", "STORAGE", "POOL", "PROVIDER-ID", "SIZE", "LIFE", "MESSAGE")
+
+	ids := make([]string, 0, len(infos))
+	for id := range infos {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		info := infos[id]
+		var size string
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(
+			id, info.Volume, info.Storage, info.Pool,
+			info.ProviderSnapshotId, size, info.Life, info.Error,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+const removeSnapshotCommandDoc = `
+Removes the specified volume snapshots. Snapshots are destroyed
+asynchronously by the storage provisioner.
+
+Examples:
+    juju remove-storage-snapshot 0/1@0 2@1
+`
+
+// NewRemoveSnapshotCommand returns a command that removes volume
+// snapshots.
+func NewRemoveSnapshotCommand() cmd.Command {
+	return modelcmd.Wrap(&removeSnapshotCommand{})
+}
+
+// removeSnapshotCommand removes volume snapshots.
+type removeSnapshotCommand struct {
+	snapshotCommandBase
+	ids []string
+}
+
+// Info implements Command.Info.
+func (c *removeSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Args:    "<snapshot ID> ...",
+		Purpose: "remove volume snapshots",
+		Doc:     removeSnapshotCommandDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeSnapshotCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no snapshot IDs specified")
+	}
+	c.ids = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.DestroyVolumeSnapshots(c.ids)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot remove snapshot %s: %v\n", c.ids[i], result.Error)
+		}
+	}
+	return nil
+}
+
+const restoreSnapshotCommandDoc = `
+Creates a new volume from each specified volume snapshot, and attaches
+it to a machine. The new volume is created in the same storage pool as
+the snapshotted volume, with the size of the snapshot, and is not
+assigned to any storage instance.
+
+By default, the new volume is attached to the machine that the
+snapshotted volume is scoped to or attached to; use --to to attach it
+to another machine. Snapshots of machine-scoped volumes can only be
+restored to the same machine.
+
+Examples:
+    juju restore-storage-snapshot 0/1@0
+    juju restore-storage-snapshot 2@1 --to 3
+`
+
+// NewRestoreSnapshotCommand returns a command that restores volume
+// snapshots to new volumes.
+func NewRestoreSnapshotCommand() cmd.Command {
+	return modelcmd.Wrap(&restoreSnapshotCommand{})
+}
+
+// restoreSnapshotCommand restores volume snapshots to new volumes.
+type restoreSnapshotCommand struct {
+	snapshotCommandBase
+	ids     []string
+	machine string
+}
+
+// Info implements Command.Info.
+func (c *restoreSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore-storage-snapshot",
+		Args:    "<snapshot ID> ...",
+		Purpose: "create volumes from volume snapshots",
+		Doc:     restoreSnapshotCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *restoreSnapshotCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.machine, "to", "", "the machine to attach the new volumes to")
+}
+
+// Init implements Command.Init.
+func (c *restoreSnapshotCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no snapshot IDs specified")
+	}
+	if c.machine != "" && !names.IsValidMachine(c.machine) {
+		return errors.NotValidf("machine ID %q", c.machine)
+	}
+	c.ids = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *restoreSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	var machineTag string
+	if c.machine != "" {
+		machineTag = names.NewMachineTag(c.machine).String()
+	}
+	snapshots := make([]params.RestoreVolumeSnapshotParams, len(c.ids))
+	for i, id := range c.ids {
+		snapshots[i] = params.RestoreVolumeSnapshotParams{
+			Id:         id,
+			MachineTag: machineTag,
+		}
+	}
+	results, err := api.RestoreVolumeSnapshots(snapshots)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot restore snapshot %s: %v\n", c.ids[i], result.Error)
+			continue
+		}
+		volumeTag, err := names.ParseVolumeTag(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		fmt.Fprintf(ctx.Stdout, "%s\n", volumeTag.Id())
+	}
+	return nil
+}
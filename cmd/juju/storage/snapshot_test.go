@@ -0,0 +1,223 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type snapshotSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotAPI{}
+}
+
+func (s *snapshotSuite) run(c *gc.C, command cmd.Command, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *snapshotSuite) TestCreateSnapshotNoArgs(c *gc.C) {
+	_, err := s.run(c, storage.NewCreateSnapshotCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, gc.ErrorMatches, "no storage or volume IDs specified")
+}
+
+func (s *snapshotSuite) TestCreateSnapshotInvalidId(c *gc.C) {
+	_, err := s.run(c, storage.NewCreateSnapshotCommandForTest(s.mockAPI, s.store), "mysql/0/1")
+	c.Assert(err, gc.ErrorMatches, `storage or volume ID "mysql/0/1" not valid`)
+}
+
+func (s *snapshotSuite) TestCreateSnapshot(c *gc.C) {
+	s.mockAPI.createVolumeSnapshots = func(tags []names.Tag) ([]params.StringResult, error) {
+		c.Assert(tags, jc.DeepEquals, []names.Tag{
+			names.NewStorageTag("data/0"),
+			names.NewVolumeTag("0/1"),
+			names.NewVolumeTag("2"),
+		})
+		return []params.StringResult{
+			{Result: "1@0"},
+			{Result: "0/1@0"},
+			{Error: &params.Error{Message: "boom"}},
+		}, nil
+	}
+	ctx, err := s.run(c, storage.NewCreateSnapshotCommandForTest(s.mockAPI, s.store), "data/0", "0/1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "1@0\n0/1@0\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "cannot snapshot volume 2: boom\n")
+}
+
+func (s *snapshotSuite) TestCreateSnapshotError(c *gc.C) {
+	s.mockAPI.createVolumeSnapshots = func(tags []names.Tag) ([]params.StringResult, error) {
+		return nil, errors.New("just my luck")
+	}
+	_, err := s.run(c, storage.NewCreateSnapshotCommandForTest(s.mockAPI, s.store), "0/1")
+	c.Assert(err, gc.ErrorMatches, "just my luck")
+}
+
+func (s *snapshotSuite) TestListSnapshotsInvalidId(c *gc.C) {
+	_, err := s.run(c, storage.NewListSnapshotsCommandForTest(s.mockAPI, s.store), "data/0")
+	c.Assert(err, gc.ErrorMatches, `volume ID "data/0" not valid`)
+}
+
+func (s *snapshotSuite) TestListSnapshotsArgs(c *gc.C) {
+	var called bool
+	s.mockAPI.listVolumeSnapshots = func(volumes []names.VolumeTag) ([]params.VolumeSnapshotDetails, error) {
+		c.Assert(volumes, jc.DeepEquals, []names.VolumeTag{names.NewVolumeTag("0/1")})
+		called = true
+		return nil, nil
+	}
+	ctx, err := s.run(c, storage.NewListSnapshotsCommandForTest(s.mockAPI, s.store), "0/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *snapshotSuite) TestListSnapshotsTabular(c *gc.C) {
+	s.mockAPI.listVolumeSnapshots = func([]names.VolumeTag) ([]params.VolumeSnapshotDetails, error) {
+		return mockSnapshotDetails(), nil
+	}
+	ctx, err := s.run(c, storage.NewListSnapshotsCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, expectedSnapshotListTabular)
+}
+
+var expectedSnapshotListTabular = `
+ID     VOLUME  STORAGE  POOL  PROVIDER-ID  SIZE    LIFE   MESSAGE
+0/1@0  0/1     data/0   loop  snap-0       1.0GiB  alive  
+2@1    2                lvm                        alive  boom
+`[1:]
+
+func (s *snapshotSuite) TestListSnapshotsYaml(c *gc.C) {
+	s.mockAPI.listVolumeSnapshots = func([]names.VolumeTag) ([]params.VolumeSnapshotDetails, error) {
+		return mockSnapshotDetails(), nil
+	}
+	ctx, err := s.run(c, storage.NewListSnapshotsCommandForTest(s.mockAPI, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+snapshots:
+  0/1@0:
+    volume: 0/1
+    storage: data/0
+    pool: loop
+    provider-id: snap-0
+    size: 1024
+    life: alive
+  2@1:
+    volume: "2"
+    pool: lvm
+    life: alive
+    error: boom
+`[1:])
+}
+
+func (s *snapshotSuite) TestRemoveSnapshot(c *gc.C) {
+	s.mockAPI.destroyVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		c.Assert(ids, jc.DeepEquals, []string{"0/1@0", "2@1"})
+		return []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `volume snapshot "2@1" not found`, Code: params.CodeNotFound}},
+		}, nil
+	}
+	ctx, err := s.run(c, storage.NewRemoveSnapshotCommandForTest(s.mockAPI, s.store), "0/1@0", "2@1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, `cannot remove snapshot 2@1: volume snapshot "2@1" not found`+"\n")
+}
+
+func (s *snapshotSuite) TestRemoveSnapshotNoArgs(c *gc.C) {
+	_, err := s.run(c, storage.NewRemoveSnapshotCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, gc.ErrorMatches, "no snapshot IDs specified")
+}
+
+func (s *snapshotSuite) TestRestoreSnapshot(c *gc.C) {
+	s.mockAPI.restoreVolumeSnapshots = func(snapshots []params.RestoreVolumeSnapshotParams) ([]params.StringResult, error) {
+		c.Assert(snapshots, jc.DeepEquals, []params.RestoreVolumeSnapshotParams{
+			{Id: "0/1@0", MachineTag: "machine-3"},
+			{Id: "2@1", MachineTag: "machine-3"},
+		})
+		return []params.StringResult{
+			{Result: "volume-3"},
+			{Error: &params.Error{Message: "boom"}},
+		}, nil
+	}
+	ctx, err := s.run(c, storage.NewRestoreSnapshotCommandForTest(s.mockAPI, s.store), "0/1@0", "2@1", "--to", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "3\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "cannot restore snapshot 2@1: boom\n")
+}
+
+func (s *snapshotSuite) TestRestoreSnapshotDefaultMachine(c *gc.C) {
+	s.mockAPI.restoreVolumeSnapshots = func(snapshots []params.RestoreVolumeSnapshotParams) ([]params.StringResult, error) {
+		c.Assert(snapshots, jc.DeepEquals, []params.RestoreVolumeSnapshotParams{{Id: "0/1@0"}})
+		return []params.StringResult{{Result: "volume-0-2"}}, nil
+	}
+	ctx, err := s.run(c, storage.NewRestoreSnapshotCommandForTest(s.mockAPI, s.store), "0/1@0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "0/2\n")
+}
+
+func (s *snapshotSuite) TestRestoreSnapshotInvalidMachine(c *gc.C) {
+	_, err := s.run(c, storage.NewRestoreSnapshotCommandForTest(s.mockAPI, s.store), "0/1@0", "--to", "lxc")
+	c.Assert(err, gc.ErrorMatches, `machine ID "lxc" not valid`)
+}
+
+func mockSnapshotDetails() []params.VolumeSnapshotDetails {
+	return []params.VolumeSnapshotDetails{{
+		Id:        "2@1",
+		VolumeTag: "volume-2",
+		Pool:      "lvm",
+		Life:      params.Alive,
+		Error:     "boom",
+	}, {
+		Id:         "0/1@0",
+		VolumeTag:  "volume-0-1",
+		StorageTag: "storage-data-0",
+		Pool:       "loop",
+		Life:       params.Alive,
+		Info: &params.VolumeSnapshotInfo{
+			SnapshotId: "snap-0",
+			Size:       1024,
+		},
+	}}
+}
+
+type mockSnapshotAPI struct {
+	createVolumeSnapshots  func([]names.Tag) ([]params.StringResult, error)
+	listVolumeSnapshots    func([]names.VolumeTag) ([]params.VolumeSnapshotDetails, error)
+	destroyVolumeSnapshots func([]string) ([]params.ErrorResult, error)
+	restoreVolumeSnapshots func([]params.RestoreVolumeSnapshotParams) ([]params.StringResult, error)
+}
+
+func (s *mockSnapshotAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotAPI) CreateVolumeSnapshots(tags []names.Tag) ([]params.StringResult, error) {
+	return s.createVolumeSnapshots(tags)
+}
+
+func (s *mockSnapshotAPI) ListVolumeSnapshots(volumes []names.VolumeTag) ([]params.VolumeSnapshotDetails, error) {
+	return s.listVolumeSnapshots(volumes)
+}
+
+func (s *mockSnapshotAPI) DestroyVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	return s.destroyVolumeSnapshots(ids)
+}
+
+func (s *mockSnapshotAPI) RestoreVolumeSnapshots(snapshots []params.RestoreVolumeSnapshotParams) ([]params.StringResult, error) {
+	return s.restoreVolumeSnapshots(snapshots)
+}
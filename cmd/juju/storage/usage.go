@@ -0,0 +1,318 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// UsageAPI defines the API methods that the show-storage-usage command
+// uses.
+type UsageAPI interface {
+	Close() error
+	StorageUsage() (params.StorageUsage, error)
+}
+
+const usageCommandDoc = `
+Shows the storage capacity allocated in the model, and how much of it is
+in use, so that capacity can be planned before deployments fail.
+
+For each storage pool, the number of volumes and filesystems in the pool
+is shown, along with their total allocated size, the space used by the
+filesystems and, where known, the capacity still available in the pool.
+The available capacity of an lvm pool is the free space in its volume
+group on all machines.
+
+For each machine, the number of volumes and filesystems attached to the
+machine is shown, along with their total allocated size and used space,
+and the available capacity of the machine's disks that are unused and
+not backing any volume.
+
+For each unit, the size of each attached storage instance is shown,
+along with the space used by its filesystem.
+
+The space used by filesystems is as last reported by the machines that
+they are mounted on, and is only known for filesystems backed by block
+devices.
+
+Examples:
+    juju show-storage-usage
+    juju show-storage-usage --format yaml
+`
+
+// NewUsageCommand returns a command that shows storage capacity and
+// usage.
+func NewUsageCommand() cmd.Command {
+	return modelcmd.Wrap(&usageCommand{})
+}
+
+// usageCommand shows storage capacity and usage per storage pool,
+// per machine and per unit.
+type usageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (UsageAPI, error)
+	out        cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *usageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-storage-usage",
+		Purpose: "show storage capacity and usage per pool, machine and unit",
+		Doc:     usageCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *usageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatUsageTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *usageCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *usageCommand) getAPI() (UsageAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	return c.NewStorageAPI()
+}
+
+// Run implements Command.Run.
+func (c *usageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	usage, err := api.StorageUsage()
+	if errors.IsNotImplemented(err) {
+		return errors.New("this controller does not support showing storage usage")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := convertToUsageInfo(usage)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, info)
+}
+
+// UsageInfo defines the serialization behaviour for storage usage.
+type UsageInfo struct {
+	Pools    map[string]PoolUsageInfo               `yaml:"pools,omitempty" json:"pools,omitempty"`
+	Machines map[string]MachineUsageInfo            `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Units    map[string]map[string]StorageUsageInfo `yaml:"units,omitempty" json:"units,omitempty"`
+}
+
+// PoolUsageInfo defines the serialization behaviour for the usage
+// of a storage pool.
+type PoolUsageInfo struct {
+	Provider    string `yaml:"provider,omitempty" json:"provider,omitempty"`
+	Volumes     int    `yaml:"volumes" json:"volumes"`
+	Filesystems int    `yaml:"filesystems" json:"filesystems"`
+
+	// Allocated, Used and Available are sizes in MiB. Available is
+	// nil if the capacity available in the pool is not known.
+	Allocated uint64  `yaml:"allocated" json:"allocated"`
+	Used      uint64  `yaml:"used" json:"used"`
+	Available *uint64 `yaml:"available,omitempty" json:"available,omitempty"`
+}
+
+// MachineUsageInfo defines the serialization behaviour for the storage
+// usage of a machine.
+type MachineUsageInfo struct {
+	Volumes     int `yaml:"volumes" json:"volumes"`
+	Filesystems int `yaml:"filesystems" json:"filesystems"`
+
+	// Allocated, Used and Available are sizes in MiB.
+	Allocated uint64 `yaml:"allocated" json:"allocated"`
+	Used      uint64 `yaml:"used" json:"used"`
+	Available uint64 `yaml:"available" json:"available"`
+}
+
+// StorageUsageInfo defines the serialization behaviour for the usage
+// of a storage instance attached to a unit.
+type StorageUsageInfo struct {
+	Pool string `yaml:"pool,omitempty" json:"pool,omitempty"`
+
+	// Size and Used are sizes in MiB. Used is nil if the space
+	// used by the storage is not known.
+	Size uint64  `yaml:"size" json:"size"`
+	Used *uint64 `yaml:"used,omitempty" json:"used,omitempty"`
+}
+
+// convertToUsageInfo converts the storage usage returned by the API
+// into its serializable form, keyed by pool name, machine ID, unit
+// name and storage ID.
+func convertToUsageInfo(usage params.StorageUsage) (UsageInfo, error) {
+	info := UsageInfo{
+		Pools:    make(map[string]PoolUsageInfo),
+		Machines: make(map[string]MachineUsageInfo),
+		Units:    make(map[string]map[string]StorageUsageInfo),
+	}
+	for _, pool := range usage.Pools {
+		info.Pools[pool.Name] = PoolUsageInfo{
+			Provider:    pool.Provider,
+			Volumes:     pool.Volumes,
+			Filesystems: pool.Filesystems,
+			Allocated:   pool.Allocated,
+			Used:        pool.Used,
+			Available:   pool.Available,
+		}
+	}
+	for _, machine := range usage.Machines {
+		machineTag, err := names.ParseMachineTag(machine.MachineTag)
+		if err != nil {
+			return UsageInfo{}, errors.Trace(err)
+		}
+		info.Machines[machineTag.Id()] = MachineUsageInfo{
+			Volumes:     machine.Volumes,
+			Filesystems: machine.Filesystems,
+			Allocated:   machine.Allocated,
+			Used:        machine.Used,
+			Available:   machine.Available,
+		}
+	}
+	for _, unit := range usage.Units {
+		unitTag, err := names.ParseUnitTag(unit.UnitTag)
+		if err != nil {
+			return UsageInfo{}, errors.Trace(err)
+		}
+		storageTag, err := names.ParseStorageTag(unit.StorageTag)
+		if err != nil {
+			return UsageInfo{}, errors.Trace(err)
+		}
+		unitStorage, ok := info.Units[unitTag.Id()]
+		if !ok {
+			unitStorage = make(map[string]StorageUsageInfo)
+			info.Units[unitTag.Id()] = unitStorage
+		}
+		unitStorage[storageTag.Id()] = StorageUsageInfo{
+			Pool: unit.Pool,
+			Size: unit.Size,
+			Used: unit.Used,
+		}
+	}
+	return info, nil
+}
+
+// formatUsageTabular returns a tabular summary of storage usage, with
+// sections for pools, machines and units.
+func formatUsageTabular(value interface{}) ([]byte, error) {
+	info, ok := value.(UsageInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", info, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("POOL", "PROVIDER", "VOLUMES", "FILESYSTEMS", "ALLOCATED", "USED", "AVAILABLE")
+	poolNames := make([]string, 0, len(info.Pools))
+	for name := range info.Pools {
+		poolNames = append(poolNames, name)
+	}
+	sort.Strings(poolNames)
+	for _, name := range poolNames {
+		pool := info.Pools[name]
+		var available string
+		if pool.Available != nil {
+			available = formatSize(*pool.Available)
+		}
+		print(
+			name, pool.Provider,
+			fmt.Sprint(pool.Volumes), fmt.Sprint(pool.Filesystems),
+			formatSize(pool.Allocated), formatSize(pool.Used), available,
+		)
+	}
+	tw.Flush()
+
+	if len(info.Machines) > 0 {
+		fmt.Fprintln(&out)
+		print("MACHINE", "VOLUMES", "FILESYSTEMS", "ALLOCATED", "USED", "AVAILABLE")
+		machineIds := make([]string, 0, len(info.Machines))
+		for id := range info.Machines {
+			machineIds = append(machineIds, id)
+		}
+		sort.Strings(machineIds)
+		for _, id := range machineIds {
+			machine := info.Machines[id]
+			print(
+				id, fmt.Sprint(machine.Volumes), fmt.Sprint(machine.Filesystems),
+				formatSize(machine.Allocated), formatSize(machine.Used),
+				formatSize(machine.Available),
+			)
+		}
+		tw.Flush()
+	}
+
+	if len(info.Units) > 0 {
+		fmt.Fprintln(&out)
+		print("UNIT", "STORAGE", "POOL", "SIZE", "USED")
+		unitNames := make([]string, 0, len(info.Units))
+		for name := range info.Units {
+			unitNames = append(unitNames, name)
+		}
+		sort.Strings(unitNames)
+		for _, unitName := range unitNames {
+			unitStorage := info.Units[unitName]
+			storageIds := make([]string, 0, len(unitStorage))
+			for id := range unitStorage {
+				storageIds = append(storageIds, id)
+			}
+			sort.Strings(storageIds)
+			for _, id := range storageIds {
+				storage := unitStorage[id]
+				var used string
+				if storage.Used != nil {
+					used = formatSize(*storage.Used)
+				}
+				print(unitName, id, storage.Pool, formatSize(storage.Size), used)
+			}
+		}
+		tw.Flush()
+	}
+	return out.Bytes(), nil
+}
+
+// formatSize returns a human-readable representation of a size in
+// MiB, or the empty string if the size is zero.
+func formatSize(sizeMiB uint64) string {
+	if sizeMiB == 0 {
+		return ""
+	}
+	return humanize.IBytes(sizeMiB * humanize.MiByte)
+}
@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type usageSuite struct {
+	SubStorageSuite
+	mockAPI *mockUsageAPI
+}
+
+var _ = gc.Suite(&usageSuite{})
+
+func (s *usageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	used, available := uint64(512), uint64(1024)
+	s.mockAPI = &mockUsageAPI{
+		usage: params.StorageUsage{
+			Pools: []params.StoragePoolUsage{{
+				Name:        "ebs-ssd",
+				Provider:    "ebs",
+				Volumes:     1,
+				Filesystems: 1,
+				Allocated:   2048,
+				Used:        512,
+			}, {
+				Name:     "fast",
+				Provider: "ebs",
+			}, {
+				Name:      "lvm",
+				Provider:  "lvm",
+				Available: &available,
+			}},
+			Machines: []params.MachineStorageUsage{{
+				MachineTag: "machine-1",
+				Available:  2048,
+			}, {
+				MachineTag:  "machine-66",
+				Volumes:     1,
+				Filesystems: 1,
+				Allocated:   2048,
+				Used:        512,
+				Available:   4096,
+			}},
+			Units: []params.UnitStorageUsage{{
+				UnitTag:    "unit-mysql-0",
+				StorageTag: "storage-data-0",
+				Pool:       "ebs-ssd",
+				Size:       2000,
+				Used:       &used,
+			}},
+		},
+	}
+}
+
+func (s *usageSuite) runUsage(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewUsageCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *usageSuite) TestUsageTabular(c *gc.C) {
+	ctx, err := s.runUsage(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+POOL     PROVIDER  VOLUMES  FILESYSTEMS  ALLOCATED  USED    AVAILABLE
+ebs-ssd  ebs       1        1            2.0GiB     512MiB  
+fast     ebs       0        0                               
+lvm      lvm       0        0                               1.0GiB
+
+MACHINE  VOLUMES  FILESYSTEMS  ALLOCATED  USED    AVAILABLE
+1        0        0                               2.0GiB
+66       1        1            2.0GiB     512MiB  4.0GiB
+
+UNIT     STORAGE  POOL     SIZE    USED
+mysql/0  data/0   ebs-ssd  2.0GiB  512MiB
+`[1:])
+}
+
+func (s *usageSuite) TestUsageYaml(c *gc.C) {
+	ctx, err := s.runUsage(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+pools:
+  ebs-ssd:
+    provider: ebs
+    volumes: 1
+    filesystems: 1
+    allocated: 2048
+    used: 512
+  fast:
+    provider: ebs
+    volumes: 0
+    filesystems: 0
+    allocated: 0
+    used: 0
+  lvm:
+    provider: lvm
+    volumes: 0
+    filesystems: 0
+    allocated: 0
+    used: 0
+    available: 1024
+machines:
+  "1":
+    volumes: 0
+    filesystems: 0
+    allocated: 0
+    used: 0
+    available: 2048
+  "66":
+    volumes: 1
+    filesystems: 1
+    allocated: 2048
+    used: 512
+    available: 4096
+units:
+  mysql/0:
+    data/0:
+      pool: ebs-ssd
+      size: 2000
+      used: 512
+`[1:])
+}
+
+func (s *usageSuite) TestUsageTooManyArgs(c *gc.C) {
+	_, err := s.runUsage(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *usageSuite) TestUsageError(c *gc.C) {
+	s.mockAPI.err = errors.New("boom")
+	_, err := s.runUsage(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *usageSuite) TestUsageNotSupported(c *gc.C) {
+	s.mockAPI.err = errors.NotImplementedf("StorageUsage() (need V3+)")
+	_, err := s.runUsage(c)
+	c.Assert(err, gc.ErrorMatches, "this controller does not support showing storage usage")
+}
+
+type mockUsageAPI struct {
+	usage params.StorageUsage
+	err   error
+}
+
+func (s *mockUsageAPI) Close() error {
+	return nil
+}
+
+func (s *mockUsageAPI) StorageUsage() (params.StorageUsage, error) {
+	return s.usage, s.err
+}
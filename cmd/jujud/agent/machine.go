@@ -74,6 +74,7 @@ import (
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/conv2state"
+	"github.com/juju/juju/worker/crossmodelrelations"
 	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
@@ -801,6 +802,10 @@ func (a *MachineAgent) openStateForUpgrade() (*state.State, func(), error) {
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	if err := setSecretsKey(st, agentConfig); err != nil {
+		st.Close()
+		return nil, nil, errors.Trace(err)
+	}
 
 	// Ensure storage is available during upgrades.
 	stor := statestorage.NewStorage(st.ModelUUID(), st.MongoSession())
@@ -1111,6 +1116,7 @@ func (a *MachineAgent) startEnvWorkers(
 			Scope:       scope,
 			Volumes:     api,
 			Filesystems: api,
+			Snapshots:   api,
 			Life:        api,
 			Environ:     api,
 			Machines:    api,
@@ -1158,6 +1164,15 @@ func (a *MachineAgent) startEnvWorkers(
 		}
 		return w, nil
 	})
+	singularRunner.StartWorker("crossmodelrelations", func() (worker.Worker, error) {
+		w, err := crossmodelrelations.New(crossmodelrelations.Config{
+			Facade: apiSt.RemoteRelations(),
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot start cross-model relations worker")
+		}
+		return w, nil
+	})
 	singularRunner.StartWorker("addresserworker", func() (worker.Worker, error) {
 		w, err := newAddresser(apiSt.Addresser())
 		if err != nil {
@@ -1208,10 +1223,9 @@ func (a *MachineAgent) startEnvWorkers(
 	singularRunner.StartWorker("statushistorypruner", func() (worker.Worker, error) {
 		f := statushistory.NewFacade(apiSt)
 		conf := statushistorypruner.Config{
-			Facade:           f,
-			MaxLogsPerEntity: params.DefaultMaxLogsPerEntity,
-			PruneInterval:    params.DefaultPruneInterval,
-			NewTimer:         worker.NewTimer,
+			Facade:        f,
+			PruneInterval: params.DefaultPruneInterval,
+			NewTimer:      worker.NewTimer,
 		}
 		w, err := statushistorypruner.New(conf)
 		if err != nil {
@@ -1740,6 +1754,9 @@ func openState(agentConfig agent.Config, dialOpts mongo.DialOpts) (_ *state.Stat
 			st.Close()
 		}
 	}()
+	if err := setSecretsKey(st, agentConfig); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	m0, err := st.FindEntity(agentConfig.Tag())
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -1761,6 +1778,17 @@ func openState(agentConfig agent.Config, dialOpts mongo.DialOpts) (_ *state.Stat
 	return st, m, nil
 }
 
+// setSecretsKey sets the key in the agent's config with which state
+// encrypts the values of secrets. A controller which has no key, and
+// cannot derive one, cannot run.
+func setSecretsKey(st *state.State, agentConfig agent.Config) error {
+	key, err := upgrades.SecretsKey(agentConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(st.SetSecretsKey(key), "cannot set secrets key")
+}
+
 // startWorkerAfterUpgrade starts a worker to run the specified child worker
 // but only after waiting for upgrades to complete.
 func (a *MachineAgent) startWorkerAfterUpgrade(runner worker.Runner, name string, start func() (worker.Worker, error)) {
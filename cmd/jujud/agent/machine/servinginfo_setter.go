@@ -4,6 +4,8 @@
 package machine
 
 import (
+	"encoding/base64"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 
@@ -63,8 +65,16 @@ func ServingInfoSetterManifold(config ServingInfoSetterConfig) dependency.Manifo
 					if err != nil {
 						return nil, errors.Errorf("cannot get state serving info: %v", err)
 					}
+					// The secrets key is not in the database, so it
+					// must be copied from an existing controller. A
+					// controller cannot run without it.
+					secretsKey, err := apiState.SecretsKey()
+					if err != nil {
+						return nil, errors.Annotate(err, "cannot get secrets key")
+					}
 					err = agent.ChangeConfig(func(config coreagent.ConfigSetter) error {
 						config.SetStateServingInfo(info)
+						config.SetValue(coreagent.SecretsKey, base64.StdEncoding.EncodeToString(secretsKey))
 						return nil
 					})
 					if err != nil {
@@ -4,6 +4,8 @@
 package machine_test
 
 import (
+	"encoding/base64"
+
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -104,7 +106,7 @@ func (s *ServingInfoSetterSuite) TestJobManageEnviron(c *gc.C) {
 	const mockAPIPort = 1234
 
 	a := &mockAgent{}
-	apiCaller := basetesting.APICallerFunc(
+	apiCaller := versionedAPICaller{basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, args, response interface{}) error {
 			c.Assert(objType, gc.Equals, "Agent")
 			switch request {
@@ -119,12 +121,14 @@ func (s *ServingInfoSetterSuite) TestJobManageEnviron(c *gc.C) {
 				*result = params.StateServingInfo{
 					APIPort: mockAPIPort,
 				}
+			case "SecretsKey":
+				*response.(*params.BytesResult) = params.BytesResult{Result: []byte("key")}
 			default:
 				c.Fatalf("not sure how to handle: %q", request)
 			}
 			return nil
 		},
-	)
+	)}
 	w, err := s.manifold.Start(dt.StubGetResource(dt.StubResources{
 		"agent":      dt.StubResource{Output: a},
 		"api-caller": dt.StubResource{Output: apiCaller},
@@ -137,6 +141,68 @@ func (s *ServingInfoSetterSuite) TestJobManageEnviron(c *gc.C) {
 	c.Assert(a.conf.ssi.APIPort, gc.Equals, mockAPIPort)
 }
 
+func (s *ServingInfoSetterSuite) TestJobManageEnvironSecretsKey(c *gc.C) {
+	a := &mockAgent{}
+	apiCaller := versionedAPICaller{basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, response interface{}) error {
+			c.Assert(objType, gc.Equals, "Agent")
+			switch request {
+			case "GetEntities":
+				result := response.(*params.AgentGetEntitiesResults)
+				result.Entities = []params.AgentGetEntitiesResult{{
+					Jobs: []multiwatcher.MachineJob{multiwatcher.JobManageModel},
+				}}
+			case "StateServingInfo":
+				*response.(*params.StateServingInfo) = params.StateServingInfo{}
+			case "SecretsKey":
+				*response.(*params.BytesResult) = params.BytesResult{Result: []byte("key")}
+			default:
+				c.Fatalf("not sure how to handle: %q", request)
+			}
+			return nil
+		},
+	)}
+	w, err := s.manifold.Start(dt.StubGetResource(dt.StubResources{
+		"agent":      dt.StubResource{Output: a},
+		"api-caller": dt.StubResource{Output: apiCaller},
+	}))
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.Equals, dependency.ErrUninstall)
+	c.Assert(a.conf.values, jc.DeepEquals, map[string]string{
+		coreagent.SecretsKey: base64.StdEncoding.EncodeToString([]byte("key")),
+	})
+}
+
+func (s *ServingInfoSetterSuite) TestJobManageEnvironNoSecretsKey(c *gc.C) {
+	a := &mockAgent{}
+	apiCaller := versionedAPICaller{basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, response interface{}) error {
+			c.Assert(objType, gc.Equals, "Agent")
+			switch request {
+			case "GetEntities":
+				result := response.(*params.AgentGetEntitiesResults)
+				result.Entities = []params.AgentGetEntitiesResult{{
+					Jobs: []multiwatcher.MachineJob{multiwatcher.JobManageModel},
+				}}
+			case "StateServingInfo":
+				*response.(*params.StateServingInfo) = params.StateServingInfo{}
+			case "SecretsKey":
+				return &params.Error{Code: params.CodeNotFound, Message: "secrets key not found"}
+			default:
+				c.Fatalf("not sure how to handle: %q", request)
+			}
+			return nil
+		},
+	)}
+	w, err := s.manifold.Start(dt.StubGetResource(dt.StubResources{
+		"agent":      dt.StubResource{Output: a},
+		"api-caller": dt.StubResource{Output: apiCaller},
+	}))
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "cannot get secrets key: secrets key not found")
+	c.Assert(a.conf.ssiSet, jc.IsFalse)
+}
+
 func (s *ServingInfoSetterSuite) TestJobHostUnits(c *gc.C) {
 	// State serving info should not be set for JobHostUnits.
 	s.checkNotController(c, multiwatcher.JobHostUnits)
@@ -194,6 +260,24 @@ type mockConfig struct {
 	tag    names.Tag
 	ssiSet bool
 	ssi    params.StateServingInfo
+	values map[string]string
+}
+
+func (mc *mockConfig) SetValue(key, value string) {
+	if mc.values == nil {
+		mc.values = make(map[string]string)
+	}
+	mc.values[key] = value
+}
+
+// versionedAPICaller reports version 3 of every facade, so that the
+// Agent facade supports SecretsKey.
+type versionedAPICaller struct {
+	basetesting.APICallerFunc
+}
+
+func (versionedAPICaller) BestFacadeVersion(facade string) int {
+	return 3
 }
 
 func (mc *mockConfig) Tag() names.Tag {
@@ -8,6 +8,7 @@ import (
 
 	coreagent "github.com/juju/juju/agent"
 	msapi "github.com/juju/juju/api/meterstatus"
+	payloadhealth "github.com/juju/juju/payload/health"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
@@ -183,6 +184,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName:   APICallerName,
 			MetricSpoolName: MetricSpoolName,
 		}),
+
+		// The payload health worker runs the health checks which the
+		// charm has defined for its payloads, and reports the outcomes.
+		PayloadHealthName: payloadhealth.Manifold(payloadhealth.ManifoldConfig{
+			AgentName:     AgentName,
+			APICallerName: APICallerName,
+			NewReporter:   payloadhealth.NewReporter,
+		}),
 	}
 }
 
@@ -202,4 +211,5 @@ const (
 	MeterStatusName          = "meter-status"
 	MetricCollectName        = "metric-collect"
 	MetricSenderName         = "metric-sender"
+	PayloadHealthName        = "payload-health"
 )
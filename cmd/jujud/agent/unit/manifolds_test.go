@@ -53,6 +53,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		unit.MeterStatusName,
 		unit.MetricSenderName,
 		unit.CharmDirName,
+		unit.PayloadHealthName,
 	}
 	keys := make([]string, 0, len(manifolds))
 	for k := range manifolds {
@@ -5,6 +5,7 @@ package main
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
@@ -210,8 +211,16 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 	}
 	info.SharedSecret = sharedSecret
 	info.SystemIdentity = privateKey
+
+	// Generate the key with which secrets are encrypted. It is kept in
+	// the agent config, and never stored in the database.
+	secretsKey := make([]byte, state.SecretsKeyLength)
+	if _, err := rand.Read(secretsKey); err != nil {
+		return errors.Annotate(err, "cannot generate secrets key")
+	}
 	err = c.ChangeConfig(func(agentConfig agent.ConfigSetter) error {
 		agentConfig.SetStateServingInfo(info)
+		agentConfig.SetValue(agent.SecretsKey, base64.StdEncoding.EncodeToString(secretsKey))
 		return nil
 	})
 	if err != nil {
@@ -161,6 +161,8 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 
 	jujud.Register(NewUpgradeMongoCommand())
 
+	jujud.Register(&ReplayHookCommand{})
+
 	code = cmd.Main(jujud, ctx, args[1:])
 	return code, nil
 }
@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+// ReplayHookCommand replays a failed hook of a unit on this machine,
+// against the context captured when the hook failed.
+type ReplayHookCommand struct {
+	cmd.CommandBase
+	unit       names.UnitTag
+	hookName   string
+	relationId int
+}
+
+const replayHookDoc = `
+Run a failed hook of a unit on this machine again, with the hook tools
+answering from the context captured when the hook failed, rather than
+from the controller. If no hook is specified, the most recently failed
+hook is replayed. A relation hook is replayed for the relation in which
+it most recently failed, unless --relation is given.
+
+Hook contexts are only captured when the model's capture-failed-hooks
+setting is true. The replayed hook runs in a sandbox: changes it makes
+via the hook tools are not sent to the controller, and it runs in a
+copy of the charm directory, with its own home and temporary
+directories, which are discarded when it exits. Changes it makes
+elsewhere on the machine are not undone.
+
+unit-name can be either the unit tag:
+ i.e.  unit-ubuntu-0
+or the unit id:
+ i.e.  ubuntu/0
+`
+
+// Info returns usage information for the command.
+func (c *ReplayHookCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "replay-hook",
+		Args:    "<unit-name> [<hook-name>]",
+		Purpose: "replay a failed hook against its captured context",
+		Doc:     replayHookDoc,
+	}
+}
+
+// SetFlags adds the command's flags to the supplied FlagSet.
+func (c *ReplayHookCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.IntVar(&c.relationId, "relation", -1, "id of the relation for which to replay a relation hook")
+}
+
+func (c *ReplayHookCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing unit-name")
+	}
+	var unitName string
+	unitName, args = args[0], args[1:]
+	if names.IsValidUnit(unitName) {
+		c.unit = names.NewUnitTag(unitName)
+	} else {
+		var err error
+		c.unit, err = names.ParseUnitTag(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(args) > 0 {
+		c.hookName, args = args[0], args[1:]
+		if !replay.IsValidHookName(c.hookName) {
+			return errors.Errorf("invalid hook name %q", c.hookName)
+		}
+	}
+	if c.relationId >= 0 && c.hookName == "" {
+		return errors.New("--relation requires a hook name")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *ReplayHookCommand) Run(ctx *cmd.Context) error {
+	// The replayed hook's tools are served on a socket of their own, so
+	// that the unit agent can carry on while the hook is replayed.
+	paths := uniter.NewWorkerPaths(cmdutil.DataDir, c.unit, "replay")
+	if _, err := os.Stat(paths.State.BaseDir); os.IsNotExist(err) {
+		return errors.Errorf("unit %q not found on this machine", c.unit.Id())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	dir := paths.ComponentDir(replay.SnapshotsDir)
+	var path string
+	var err error
+	if c.relationId >= 0 {
+		path = replay.SnapshotPath(dir, c.hookName, c.relationId)
+	} else {
+		path, err = replay.LatestSnapshot(dir, c.hookName)
+	}
+	var snapshot *replay.Snapshot
+	if err == nil {
+		snapshot, err = replay.ReadSnapshot(path)
+	}
+	if errors.IsNotFound(err) {
+		if c.hookName == "" {
+			return errors.Errorf("no failed hooks captured for unit %q", c.unit.Id())
+		}
+		return errors.Errorf("no failed %q hook captured for unit %q", c.hookName, c.unit.Id())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Acquire the uniter hook execution lock, so that the replayed hook
+	// does not run at the same time as one of the agent's.
+	lock, err := cmdutil.HookExecutionLock(cmdutil.DataDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := lock.Lock("replay-hook"); err != nil {
+		return errors.Trace(err)
+	}
+	defer lock.Unlock()
+
+	ctx.Infof("replaying %q hook, which failed at %s", snapshot.HookName, snapshot.Captured)
+	err = replay.Replay(replay.Params{
+		Snapshot:   snapshot,
+		CharmDir:   paths.GetCharmDir(),
+		SocketPath: paths.GetJujucSocket(),
+		Stdout:     ctx.Stdout,
+		Stderr:     ctx.Stderr,
+	})
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return cmd.NewRcPassthroughError(status.ExitStatus())
+		}
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/cmd"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ReplayHookSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ReplayHookSuite{})
+
+func (s *ReplayHookSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.PatchValue(&cmdutil.DataDir, c.MkDir())
+}
+
+func (s *ReplayHookSuite) TestArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		args       []string
+		errMatch   string
+		unit       names.UnitTag
+		hookName   string
+		relationId int
+	}{{
+		errMatch: "missing unit-name",
+	}, {
+		args:     []string{"foo"},
+		errMatch: `"foo" is not a valid tag`,
+	}, {
+		args:       []string{"foo/2"},
+		unit:       names.NewUnitTag("foo/2"),
+		relationId: -1,
+	}, {
+		args:       []string{"unit-foo-2", "install"},
+		unit:       names.NewUnitTag("foo/2"),
+		hookName:   "install",
+		relationId: -1,
+	}, {
+		args:       []string{"--relation", "3", "foo/2", "db-relation-changed"},
+		unit:       names.NewUnitTag("foo/2"),
+		hookName:   "db-relation-changed",
+		relationId: 3,
+	}, {
+		args:     []string{"foo/2", "../../../etc/cron.d/x"},
+		errMatch: `invalid hook name "../../../etc/cron.d/x"`,
+	}, {
+		args:     []string{"--relation", "3", "foo/2"},
+		errMatch: "--relation requires a hook name",
+	}, {
+		args:     []string{"foo/2", "install", "start"},
+		errMatch: `unrecognized args: \["start"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := &ReplayHookCommand{}
+		err := testing.InitCommand(command, test.args)
+		if test.errMatch != "" {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(command.unit, gc.Equals, test.unit)
+		c.Check(command.hookName, gc.Equals, test.hookName)
+		c.Check(command.relationId, gc.Equals, test.relationId)
+	}
+}
+
+func (s *ReplayHookSuite) TestMissingUnit(c *gc.C) {
+	_, err := testing.RunCommand(c, &ReplayHookCommand{}, "foo/2")
+	c.Assert(err, gc.ErrorMatches, `unit "foo/2" not found on this machine`)
+}
+
+func (s *ReplayHookSuite) TestNoSnapshots(c *gc.C) {
+	s.makeUnit(c)
+	_, err := testing.RunCommand(c, &ReplayHookCommand{}, "foo/2")
+	c.Assert(err, gc.ErrorMatches, `no failed hooks captured for unit "foo/2"`)
+	_, err = testing.RunCommand(c, &ReplayHookCommand{}, "foo/2", "install")
+	c.Assert(err, gc.ErrorMatches, `no failed "install" hook captured for unit "foo/2"`)
+}
+
+func (s *ReplayHookSuite) TestReplay(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("replayed hooks are bash scripts in this test")
+	}
+	paths := s.makeUnit(c)
+	hooksDir := filepath.Join(paths.GetCharmDir(), "hooks")
+	err := os.MkdirAll(hooksDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	script := "#!/bin/bash\necho replayed $JUJU_UNIT_NAME\nexit 7\n"
+	err = ioutil.WriteFile(filepath.Join(hooksDir, "install"), []byte(script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	path := replay.SnapshotPath(paths.ComponentDir(replay.SnapshotsDir), "install", -1)
+	err = replay.WriteSnapshot(path, &replay.Snapshot{
+		HookName:   "install",
+		UnitName:   "foo/2",
+		Env:        []string{"JUJU_UNIT_NAME=foo/2"},
+		RelationId: -1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := testing.RunCommand(c, &ReplayHookCommand{}, "foo/2")
+	c.Assert(err, jc.DeepEquals, cmd.NewRcPassthroughError(7))
+	c.Assert(testing.Stdout(ctx), gc.Equals, "replayed foo/2\n")
+}
+
+func (s *ReplayHookSuite) makeUnit(c *gc.C) uniter.Paths {
+	paths := uniter.NewWorkerPaths(cmdutil.DataDir, names.NewUnitTag("foo/2"), "replay")
+	err := os.MkdirAll(paths.State.BaseDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	return paths
+}
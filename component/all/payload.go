@@ -28,7 +28,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
-const payloadsHookContextFacade = payload.ComponentName + "-hook-context"
+const payloadsHookContextFacade = payload.HookContextFacade
 
 type payloads struct{}
 
@@ -132,7 +132,7 @@ func (payloads) newHookContextFacade(st *state.State, unit *state.Unit) (interfa
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return internalserver.NewUnitFacade(up), nil
+	return internalserver.NewUnitFacade(up, unit), nil
 }
 
 func (c payloads) registerHookContextFacade() {
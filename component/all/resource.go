@@ -182,6 +182,14 @@ func (r resources) registerPublicCommands() {
 			},
 		})
 	})
+
+	commands.RegisterEnvCommand(func() modelcmd.ModelCommand {
+		return cmd.NewRollbackCommand(cmd.RollbackDeps{
+			NewClient: func(c *cmd.RollbackCommand) (cmd.RollbackClient, error) {
+				return resourceadapters.NewAPIClient(c.NewAPIRoot)
+			},
+		})
+	})
 }
 
 type apicommand interface {
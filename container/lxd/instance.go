@@ -46,17 +46,17 @@ func (lxd *lxdInstance) Status() string {
 }
 
 // OpenPorts implements instance.Instance.OpenPorts.
-func (lxd *lxdInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+func (lxd *lxdInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
 	return fmt.Errorf("not implemented")
 }
 
 // ClosePorts implements instance.Instance.ClosePorts.
-func (lxd *lxdInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+func (lxd *lxdInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
 	return fmt.Errorf("not implemented")
 }
 
-// Ports implements instance.Instance.Ports.
-func (lxd *lxdInstance) Ports(machineId string) ([]network.PortRange, error) {
+// IngressRules implements instance.Instance.IngressRules.
+func (lxd *lxdInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
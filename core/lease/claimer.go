@@ -30,6 +30,16 @@ type Claimer interface {
 	WaitUntilExpired(leaseName string) error
 }
 
+// Releaser exposes early lease release capabilities.
+type Releaser interface {
+
+	// Release gives up the named lease on behalf of the named holder, so
+	// that others may claim it without waiting for it to expire. If it
+	// returns ErrNotHeld, the holder did not have the lease. If it returns
+	// any other error, no reasonable inferences may be made.
+	Release(leaseName, holderName string) error
+}
+
 // Checker exposes facts about lease ownership.
 type Checker interface {
 
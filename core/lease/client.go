@@ -32,6 +32,12 @@ type Client interface {
 	// have passed. If it returns ErrInvalid, check Leases() for updated state.
 	ExpireLease(lease string) error
 
+	// ReleaseLease records the supplied holder's vacation of the supplied
+	// lease before its expiry time. It will fail if the lease is not held
+	// by the supplied holder. If it returns ErrInvalid, check Leases() for
+	// updated state.
+	ReleaseLease(lease, holder string) error
+
 	// Leases returns a recent snapshot of lease state. Expiry times are
 	// expressed according to the Clock the client was configured with.
 	Leases() map[string]Info
@@ -93,6 +93,16 @@ const (
 	// config setting. Only non-zero, positive integer values will
 	// have effect.
 	DefaultLXCDefaultMTU = 0
+
+	// DefaultMaxStatusHistoryAge is the maximum age of the status
+	// history entries kept for a model whose "max-status-history-age"
+	// is unset or zero.
+	DefaultMaxStatusHistoryAge = 336 * time.Hour
+
+	// DefaultMaxStatusHistorySizeMB is the maximum total size, in MiB,
+	// of the status history kept for a model whose
+	// "max-status-history-size" is unset or zero.
+	DefaultMaxStatusHistorySizeMB = 5 * 1024
 )
 
 // TODO(katco-): Please grow this over time.
@@ -208,6 +218,27 @@ const (
 	// machine worker not to discover any machine addresses
 	// on start up.
 	IgnoreMachineAddresses = "ignore-machine-addresses"
+
+	// MaxStatusHistoryAge is the maximum age, as a duration such
+	// as "336h", of the status history entries kept for the model.
+	MaxStatusHistoryAge = "max-status-history-age"
+
+	// MaxStatusHistorySize is the maximum total size, as a size
+	// such as "5G", of the status history kept for the model.
+	MaxStatusHistorySize = "max-status-history-size"
+
+	// MaxStatusHistoryEntries is the maximum number of status history
+	// entries kept for each entity in the model. If unset or zero,
+	// the history is bounded only by age and size.
+	MaxStatusHistoryEntries = "max-status-history-entries"
+
+	// HookTimeout is the maximum time, as a duration such as "30m",
+	// for which a charm hook may run before the unit agent kills it.
+	HookTimeout = "hook-timeout"
+
+	// CaptureFailedHooks determines whether unit agents capture the
+	// context of each hook that fails, so that it can be replayed.
+	CaptureFailedHooks = "capture-failed-hooks"
 )
 
 // ParseHarvestMode parses description of harvesting method and
@@ -667,6 +698,29 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaxStatusHistoryAge].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", MaxStatusHistoryAge)
+		} else if d < 0 {
+			return errors.Errorf("invalid %s in model configuration: negative duration %q", MaxStatusHistoryAge, v)
+		}
+	}
+	if v, ok := cfg.defined[MaxStatusHistorySize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", MaxStatusHistorySize)
+		}
+	}
+	if v, ok := cfg.defined[MaxStatusHistoryEntries].(int); ok && v < 0 {
+		return errors.Errorf("%s: expected non-negative integer, got %v", MaxStatusHistoryEntries, v)
+	}
+	if v, ok := cfg.defined[HookTimeout].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", HookTimeout)
+		} else if d < 0 {
+			return errors.Errorf("invalid %s in model configuration: negative duration %q", HookTimeout, v)
+		}
+	}
+
 	// Check LXCDefaultMTU is a positive integer, when set.
 	if lxcDefaultMTU, ok := cfg.LXCDefaultMTU(); ok && lxcDefaultMTU < 0 {
 		return errors.Errorf("%s: expected positive integer, got %v", LXCDefaultMTU, lxcDefaultMTU)
@@ -1167,6 +1221,61 @@ func (c *Config) IgnoreMachineAddresses() (bool, bool) {
 	return v, ok
 }
 
+// MaxStatusHistoryAge returns the maximum age of the status history
+// entries kept for the model. If it is unset or zero,
+// DefaultMaxStatusHistoryAge is returned.
+func (c *Config) MaxStatusHistoryAge() time.Duration {
+	v, _ := c.defined[MaxStatusHistoryAge].(string)
+	// Validate ensures the value parses.
+	d, _ := time.ParseDuration(v)
+	if d <= 0 {
+		return DefaultMaxStatusHistoryAge
+	}
+	return d
+}
+
+// MaxStatusHistorySizeMB returns the maximum total size, in MiB, of
+// the status history kept for the model. If it is unset or zero,
+// DefaultMaxStatusHistorySizeMB is returned.
+func (c *Config) MaxStatusHistorySizeMB() uint64 {
+	v, _ := c.defined[MaxStatusHistorySize].(string)
+	// Validate ensures the value parses.
+	size, _ := utils.ParseSize(v)
+	if size == 0 {
+		return DefaultMaxStatusHistorySizeMB
+	}
+	return size
+}
+
+// MaxStatusHistoryEntries returns the maximum number of status history
+// entries kept for each entity in the model, or zero if the history
+// of each entity is not limited separately.
+func (c *Config) MaxStatusHistoryEntries() int {
+	v, _ := c.defined[MaxStatusHistoryEntries].(int)
+	return v
+}
+
+// HookTimeout returns the maximum time for which a charm hook may run
+// in the model, and whether it was specified. Hooks are not timed out
+// if it was not, or if it is zero.
+func (c *Config) HookTimeout() (time.Duration, bool) {
+	v, ok := c.defined[HookTimeout].(string)
+	if !ok {
+		return 0, false
+	}
+	// Validate ensures the value parses.
+	d, _ := time.ParseDuration(v)
+	return d, true
+}
+
+// CaptureFailedHooks returns whether unit agents should capture the
+// context of each hook that fails, so that it can be replayed. By
+// default this is false.
+func (c *Config) CaptureFailedHooks() bool {
+	v, _ := c.defined[CaptureFailedHooks].(bool)
+	return v
+}
+
 // StorageDefaultBlockSource returns the default block storage
 // source for the environment.
 func (c *Config) StorageDefaultBlockSource() (string, bool) {
@@ -1323,6 +1432,11 @@ var alwaysOptional = schema.Defaults{
 	AllowLXCLoopMounts:           false,
 	ResourceTagsKey:              schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
+	MaxStatusHistoryAge:          schema.Omit,
+	MaxStatusHistorySize:         schema.Omit,
+	MaxStatusHistoryEntries:      schema.Omit,
+	HookTimeout:                  schema.Omit,
+	CaptureFailedHooks:           schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
@@ -1692,6 +1806,31 @@ var configSchema = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxStatusHistoryAge: {
+		Description: "The maximum age of status history entries kept for the model, e.g. 72h. If unset or zero, entries older than 336h are pruned.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxStatusHistorySize: {
+		Description: "The maximum total size of the status history kept for the model, e.g. 2G. If unset or zero, the history is pruned to 5G.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxStatusHistoryEntries: {
+		Description: "The maximum number of status history entries kept for each machine, service and unit, in addition to the age and size limits. If unset or zero, the history of each entity is not limited separately.",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	HookTimeout: {
+		Description: "The maximum time for which a charm hook may run before it is killed, e.g. 30m. Charms may set their own hook-timeout in metadata, which takes precedence. If unset, hooks are not timed out.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	CaptureFailedHooks: {
+		Description: "Whether unit agents capture the context of each failed hook, so that it can be replayed with juju replay-hook",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	"default-series": {
 		Description: "The default series of Ubuntu to use for deploying charms",
 		Type:        environschema.Tstring,
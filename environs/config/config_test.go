@@ -259,6 +259,59 @@ var configTests = []configTest{
 			"lxc-default-mtu": -42,
 		},
 		err: `lxc-default-mtu: expected positive integer, got -42`,
+	}, {
+		about:       "Status history limits set explicitly",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                    "my-type",
+			"name":                    "my-name",
+			"max-status-history-age":  "336h",
+			"max-status-history-size": "5G",
+		},
+	}, {
+		about:       "Status history age invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                   "my-type",
+			"name":                   "my-name",
+			"max-status-history-age": "a fortnight",
+		},
+		err: `invalid max-status-history-age in model configuration: time: invalid duration .*`,
+	}, {
+		about:       "Hook timeout set explicitly",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":         "my-type",
+			"name":         "my-name",
+			"hook-timeout": "30m",
+		},
+	}, {
+		about:       "Hook timeout negative",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":         "my-type",
+			"name":         "my-name",
+			"hook-timeout": "-1m",
+		},
+		err: `invalid hook-timeout in model configuration: negative duration "-1m"`,
+	}, {
+		about:       "Status history size invalid",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                    "my-type",
+			"name":                    "my-name",
+			"max-status-history-size": "lots",
+		},
+		err: `invalid max-status-history-size in model configuration: .*`,
+	}, {
+		about:       "Status history entries negative",
+		useDefaults: config.UseDefaults,
+		attrs: testing.Attrs{
+			"type":                       "my-type",
+			"name":                       "my-name",
+			"max-status-history-entries": -1,
+		},
+		err: `max-status-history-entries: expected non-negative integer, got -1`,
 	}, {
 		about:       "CA cert & key from path",
 		useDefaults: config.UseDefaults,
@@ -1756,6 +1809,57 @@ func (s *ConfigSuite) TestCloudImageBaseURLSet(c *gc.C) {
 	c.Assert(config.CloudImageBaseURL(), gc.Equals, "http://local.foo/query")
 }
 
+func (s *ConfigSuite) TestStatusHistoryLimitsDefault(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.MaxStatusHistoryAge(), gc.Equals, 336*time.Hour)
+	c.Assert(config.MaxStatusHistorySizeMB(), gc.Equals, uint64(5*1024))
+	c.Assert(config.MaxStatusHistoryEntries(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestStatusHistoryLimitsZero(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"max-status-history-age":  "0",
+		"max-status-history-size": "0",
+	})
+	c.Assert(config.MaxStatusHistoryAge(), gc.Equals, 336*time.Hour)
+	c.Assert(config.MaxStatusHistorySizeMB(), gc.Equals, uint64(5*1024))
+}
+
+func (s *ConfigSuite) TestStatusHistoryLimitsSet(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"max-status-history-age":     "72h",
+		"max-status-history-size":    "2G",
+		"max-status-history-entries": 500,
+	})
+	c.Assert(config.MaxStatusHistoryAge(), gc.Equals, 72*time.Hour)
+	c.Assert(config.MaxStatusHistorySizeMB(), gc.Equals, uint64(2*1024))
+	c.Assert(config.MaxStatusHistoryEntries(), gc.Equals, 500)
+}
+
+func (s *ConfigSuite) TestHookTimeout(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	_, ok := config.HookTimeout()
+	c.Assert(ok, jc.IsFalse)
+
+	config = newTestConfig(c, testing.Attrs{"hook-timeout": "30m"})
+	timeout, ok := config.HookTimeout()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(timeout, gc.Equals, 30*time.Minute)
+}
+
+func (s *ConfigSuite) TestCaptureFailedHooks(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.CaptureFailedHooks(), jc.IsFalse)
+
+	config = newTestConfig(c, testing.Attrs{"capture-failed-hooks": true})
+	c.Assert(config.CaptureFailedHooks(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
@@ -219,20 +219,22 @@ type Environ interface {
 
 // Firewaller exposes methods for managing network ports.
 type Firewaller interface {
-	// OpenPorts opens the given port ranges for the whole environment.
+	// OpenPorts opens the given ingress rules for the whole environment.
 	// Must only be used if the environment was setup with the
-	// FwGlobal firewall mode.
-	OpenPorts(ports []network.PortRange) error
+	// FwGlobal firewall mode. Providers that cannot restrict the
+	// source of traffic return an error satisfying errors.IsNotSupported
+	// for rules that are not open to anywhere.
+	OpenPorts(rules []network.IngressRule) error
 
-	// ClosePorts closes the given port ranges for the whole environment.
+	// ClosePorts closes the given ingress rules for the whole environment.
 	// Must only be used if the environment was setup with the
 	// FwGlobal firewall mode.
-	ClosePorts(ports []network.PortRange) error
+	ClosePorts(rules []network.IngressRule) error
 
-	// Ports returns the port ranges opened for the whole environment.
-	// Must only be used if the environment was setup with the
-	// FwGlobal firewall mode.
-	Ports() ([]network.PortRange, error)
+	// IngressRules returns the ingress rules opened for the whole
+	// environment. Must only be used if the environment was setup with
+	// the FwGlobal firewall mode.
+	IngressRules() ([]network.IngressRule, error)
 }
 
 // InstanceTagger is an interface that can be used for tagging instances.
@@ -277,87 +277,87 @@ func (t *LiveTests) TestPorts(c *gc.C) {
 	inst1, _ := jujutesting.AssertStartInstance(c, t.Env, "1")
 	c.Assert(inst1, gc.NotNil)
 	defer t.Env.StopInstances(inst1.Id())
-	ports, err := inst1.Ports("1")
+	rules, err := inst1.IngressRules("1")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.HasLen, 0)
+	c.Assert(rules, gc.HasLen, 0)
 
 	inst2, _ := jujutesting.AssertStartInstance(c, t.Env, "2")
 	c.Assert(inst2, gc.NotNil)
-	ports, err = inst2.Ports("2")
+	rules, err = inst2.IngressRules("2")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.HasLen, 0)
+	c.Assert(rules, gc.HasLen, 0)
 	defer t.Env.StopInstances(inst2.Id())
 
 	// Open some ports and check they're there.
-	err = inst1.OpenPorts("1", []network.PortRange{{67, 67, "udp"}, {45, 45, "tcp"}, {80, 100, "tcp"}})
+	err = inst1.OpenPorts("1", network.NewOpenIngressRules([]network.PortRange{{67, 67, "udp"}, {45, 45, "tcp"}, {80, 100, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
-	ports, err = inst1.Ports("1")
+	rules, err = inst1.IngressRules("1")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{45, 45, "tcp"}, {80, 100, "tcp"}, {67, 67, "udp"}})
-	ports, err = inst2.Ports("2")
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {80, 100, "tcp"}, {67, 67, "udp"}}))
+	rules, err = inst2.IngressRules("2")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.HasLen, 0)
+	c.Assert(rules, gc.HasLen, 0)
 
-	err = inst2.OpenPorts("2", []network.PortRange{{89, 89, "tcp"}, {45, 45, "tcp"}, {20, 30, "tcp"}})
+	err = inst2.OpenPorts("2", network.NewOpenIngressRules([]network.PortRange{{89, 89, "tcp"}, {45, 45, "tcp"}, {20, 30, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Check there's no crosstalk to another machine
-	ports, err = inst2.Ports("2")
+	rules, err = inst2.IngressRules("2")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{20, 30, "tcp"}, {45, 45, "tcp"}, {89, 89, "tcp"}})
-	ports, err = inst1.Ports("1")
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{20, 30, "tcp"}, {45, 45, "tcp"}, {89, 89, "tcp"}}))
+	rules, err = inst1.IngressRules("1")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{45, 45, "tcp"}, {80, 100, "tcp"}, {67, 67, "udp"}})
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {80, 100, "tcp"}, {67, 67, "udp"}}))
 
 	// Check that opening the same port again is ok.
-	oldPorts, err := inst2.Ports("2")
+	oldRules, err := inst2.IngressRules("2")
 	c.Assert(err, jc.ErrorIsNil)
-	err = inst2.OpenPorts("2", []network.PortRange{{45, 45, "tcp"}})
+	err = inst2.OpenPorts("2", network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
-	err = inst2.OpenPorts("2", []network.PortRange{{20, 30, "tcp"}})
+	err = inst2.OpenPorts("2", network.NewOpenIngressRules([]network.PortRange{{20, 30, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
-	ports, err = inst2.Ports("2")
+	rules, err = inst2.IngressRules("2")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, oldPorts)
+	c.Assert(rules, gc.DeepEquals, oldRules)
 
 	// Check that opening the same port again and another port is ok.
-	err = inst2.OpenPorts("2", []network.PortRange{{45, 45, "tcp"}, {99, 99, "tcp"}})
+	err = inst2.OpenPorts("2", network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {99, 99, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
-	ports, err = inst2.Ports("2")
+	rules, err = inst2.IngressRules("2")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{20, 30, "tcp"}, {45, 45, "tcp"}, {89, 89, "tcp"}, {99, 99, "tcp"}})
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{20, 30, "tcp"}, {45, 45, "tcp"}, {89, 89, "tcp"}, {99, 99, "tcp"}}))
 
-	err = inst2.ClosePorts("2", []network.PortRange{{45, 45, "tcp"}, {99, 99, "tcp"}, {20, 30, "tcp"}})
+	err = inst2.ClosePorts("2", network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {99, 99, "tcp"}, {20, 30, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Check that we can close ports and that there's no crosstalk.
-	ports, err = inst2.Ports("2")
+	rules, err = inst2.IngressRules("2")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{89, 89, "tcp"}})
-	ports, err = inst1.Ports("1")
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{89, 89, "tcp"}}))
+	rules, err = inst1.IngressRules("1")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{45, 45, "tcp"}, {80, 100, "tcp"}, {67, 67, "udp"}})
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {80, 100, "tcp"}, {67, 67, "udp"}}))
 
 	// Check that we can close multiple ports.
-	err = inst1.ClosePorts("1", []network.PortRange{{45, 45, "tcp"}, {67, 67, "udp"}, {80, 100, "tcp"}})
+	err = inst1.ClosePorts("1", network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {67, 67, "udp"}, {80, 100, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
-	ports, err = inst1.Ports("1")
-	c.Assert(ports, gc.HasLen, 0)
+	rules, err = inst1.IngressRules("1")
+	c.Assert(rules, gc.HasLen, 0)
 
 	// Check that we can close ports that aren't there.
-	err = inst2.ClosePorts("2", []network.PortRange{{111, 111, "tcp"}, {222, 222, "udp"}, {600, 700, "tcp"}})
+	err = inst2.ClosePorts("2", network.NewOpenIngressRules([]network.PortRange{{111, 111, "tcp"}, {222, 222, "udp"}, {600, 700, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
-	ports, err = inst2.Ports("2")
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{89, 89, "tcp"}})
+	rules, err = inst2.IngressRules("2")
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{89, 89, "tcp"}}))
 
 	// Check errors when acting on environment.
-	err = t.Env.OpenPorts([]network.PortRange{{80, 80, "tcp"}})
+	err = t.Env.OpenPorts(network.NewOpenIngressRules([]network.PortRange{{80, 80, "tcp"}}))
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for opening ports on model`)
 
-	err = t.Env.ClosePorts([]network.PortRange{{80, 80, "tcp"}})
+	err = t.Env.ClosePorts(network.NewOpenIngressRules([]network.PortRange{{80, 80, "tcp"}}))
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for closing ports on model`)
 
-	_, err = t.Env.Ports()
+	_, err = t.Env.IngressRules()
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for retrieving ports from model`)
 }
 
@@ -381,47 +381,47 @@ func (t *LiveTests) TestGlobalPorts(c *gc.C) {
 	// Create instances and check open ports on both instances.
 	inst1, _ := jujutesting.AssertStartInstance(c, t.Env, "1")
 	defer t.Env.StopInstances(inst1.Id())
-	ports, err := t.Env.Ports()
+	rules, err := t.Env.IngressRules()
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.HasLen, 0)
+	c.Assert(rules, gc.HasLen, 0)
 
 	inst2, _ := jujutesting.AssertStartInstance(c, t.Env, "2")
-	ports, err = t.Env.Ports()
+	rules, err = t.Env.IngressRules()
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.HasLen, 0)
+	c.Assert(rules, gc.HasLen, 0)
 	defer t.Env.StopInstances(inst2.Id())
 
-	err = t.Env.OpenPorts([]network.PortRange{{67, 67, "udp"}, {45, 45, "tcp"}, {89, 89, "tcp"}, {99, 99, "tcp"}, {100, 110, "tcp"}})
+	err = t.Env.OpenPorts(network.NewOpenIngressRules([]network.PortRange{{67, 67, "udp"}, {45, 45, "tcp"}, {89, 89, "tcp"}, {99, 99, "tcp"}, {100, 110, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
 
-	ports, err = t.Env.Ports()
+	rules, err = t.Env.IngressRules()
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{45, 45, "tcp"}, {89, 89, "tcp"}, {99, 99, "tcp"}, {100, 110, "tcp"}, {67, 67, "udp"}})
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {89, 89, "tcp"}, {99, 99, "tcp"}, {100, 110, "tcp"}, {67, 67, "udp"}}))
 
 	// Check closing some ports.
-	err = t.Env.ClosePorts([]network.PortRange{{99, 99, "tcp"}, {67, 67, "udp"}})
+	err = t.Env.ClosePorts(network.NewOpenIngressRules([]network.PortRange{{99, 99, "tcp"}, {67, 67, "udp"}}))
 	c.Assert(err, jc.ErrorIsNil)
 
-	ports, err = t.Env.Ports()
+	rules, err = t.Env.IngressRules()
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{45, 45, "tcp"}, {89, 89, "tcp"}, {100, 110, "tcp"}})
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {89, 89, "tcp"}, {100, 110, "tcp"}}))
 
 	// Check that we can close ports that aren't there.
-	err = t.Env.ClosePorts([]network.PortRange{{111, 111, "tcp"}, {222, 222, "udp"}, {2000, 2500, "tcp"}})
+	err = t.Env.ClosePorts(network.NewOpenIngressRules([]network.PortRange{{111, 111, "tcp"}, {222, 222, "udp"}, {2000, 2500, "tcp"}}))
 	c.Assert(err, jc.ErrorIsNil)
 
-	ports, err = t.Env.Ports()
+	rules, err = t.Env.IngressRules()
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.DeepEquals, []network.PortRange{{45, 45, "tcp"}, {89, 89, "tcp"}, {100, 110, "tcp"}})
+	c.Assert(rules, gc.DeepEquals, network.NewOpenIngressRules([]network.PortRange{{45, 45, "tcp"}, {89, 89, "tcp"}, {100, 110, "tcp"}}))
 
 	// Check errors when acting on instances.
-	err = inst1.OpenPorts("1", []network.PortRange{{80, 80, "tcp"}})
+	err = inst1.OpenPorts("1", network.NewOpenIngressRules([]network.PortRange{{80, 80, "tcp"}}))
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for opening ports on instance`)
 
-	err = inst1.ClosePorts("1", []network.PortRange{{80, 80, "tcp"}})
+	err = inst1.ClosePorts("1", network.NewOpenIngressRules([]network.PortRange{{80, 80, "tcp"}}))
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for closing ports on instance`)
 
-	_, err = inst1.Ports("1")
+	_, err = inst1.IngressRules("1")
 	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for retrieving ports from instance`)
 }
 
@@ -33,18 +33,21 @@ type Instance interface {
 	// associated with the instance.
 	Addresses() ([]network.Address, error)
 
-	// OpenPorts opens the given port ranges on the instance, which
+	// OpenPorts opens the given ingress rules on the instance, which
+	// should have been started with the given machine id. Providers
+	// that cannot restrict the source of traffic return an error
+	// satisfying errors.IsNotSupported for rules that are not open to
+	// anywhere.
+	OpenPorts(machineId string, rules []network.IngressRule) error
+
+	// ClosePorts closes the given ingress rules on the instance, which
 	// should have been started with the given machine id.
-	OpenPorts(machineId string, ports []network.PortRange) error
+	ClosePorts(machineId string, rules []network.IngressRule) error
 
-	// ClosePorts closes the given port ranges on the instance, which
-	// should have been started with the given machine id.
-	ClosePorts(machineId string, ports []network.PortRange) error
-
-	// Ports returns the set of port ranges open on the instance,
-	// which should have been started with the given machine id. The
-	// port ranges are returned as sorted by network.SortPortRanges().
-	Ports(machineId string) ([]network.PortRange, error)
+	// IngressRules returns the set of ingress rules open on the
+	// instance, which should have been started with the given machine
+	// id. The rules are returned as sorted by network.SortIngressRules().
+	IngressRules(machineId string) ([]network.IngressRule, error)
 }
 
 // HardwareCharacteristics represents the characteristics of the instance (if known).
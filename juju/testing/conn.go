@@ -414,6 +414,10 @@ func newState(environ environs.Environ, mongoInfo *mongo.MongoInfo) (*state.Stat
 		st.Close()
 		return nil, fmt.Errorf("unable to push secrets: %v", err)
 	}
+	if err := st.SetSecretsKey(testing.SecretsKey); err != nil {
+		st.Close()
+		return nil, err
+	}
 	return st, nil
 }
 
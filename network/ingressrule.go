@@ -0,0 +1,221 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// OpenCIDR is the source CIDR of an ingress rule that allows traffic
+// from anywhere.
+const OpenCIDR = "0.0.0.0/0"
+
+// IngressRule represents a range of ports, and the source CIDRs from
+// which traffic to those ports is allowed.
+type IngressRule struct {
+	PortRange
+
+	// SourceCIDRs holds the sorted source CIDRs of the rule. It always
+	// holds at least one CIDR; OpenCIDR allows traffic from anywhere.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the supplied protocol and
+// ports, allowing traffic from the supplied source CIDRs. If no source
+// CIDRs are supplied, traffic is allowed from anywhere.
+func NewIngressRule(protocol string, from, to int, sourceCIDRs ...string) (IngressRule, error) {
+	rule := IngressRule{
+		PortRange: PortRange{
+			FromPort: from,
+			ToPort:   to,
+			Protocol: protocol,
+		},
+	}
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{OpenCIDR}
+	}
+	for _, cidr := range sourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return IngressRule{}, errors.NotValidf("source CIDR %q", cidr)
+		}
+	}
+	rule.SourceCIDRs = append([]string(nil), sourceCIDRs...)
+	sort.Strings(rule.SourceCIDRs)
+	return rule, errors.Trace(rule.Validate())
+}
+
+// MustNewIngressRule returns an IngressRule for the supplied protocol,
+// ports and source CIDRs. If the arguments are invalid, the function
+// panics.
+func MustNewIngressRule(protocol string, from, to int, sourceCIDRs ...string) IngressRule {
+	rule, err := NewIngressRule(protocol, from, to, sourceCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// NewOpenIngressRule returns an IngressRule allowing traffic from
+// anywhere to the supplied port range.
+func NewOpenIngressRule(portRange PortRange) IngressRule {
+	return IngressRule{
+		PortRange:   portRange,
+		SourceCIDRs: []string{OpenCIDR},
+	}
+}
+
+// NewOpenIngressRules returns IngressRules allowing traffic from
+// anywhere to each of the supplied port ranges.
+func NewOpenIngressRules(portRanges []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = NewOpenIngressRule(portRange)
+	}
+	return rules
+}
+
+// IsOpen returns whether the rule allows traffic from anywhere.
+func (r IngressRule) IsOpen() bool {
+	for _, cidr := range r.SourceCIDRs {
+		if cidr == OpenCIDR {
+			return true
+		}
+	}
+	return len(r.SourceCIDRs) == 0
+}
+
+func (r IngressRule) String() string {
+	if len(r.SourceCIDRs) == 0 || (len(r.SourceCIDRs) == 1 && r.SourceCIDRs[0] == OpenCIDR) {
+		return r.PortRange.String()
+	}
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (s ingressRuleSlice) Len() int      { return len(s) }
+func (s ingressRuleSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s ingressRuleSlice) Less(i, j int) bool {
+	if s[i].PortRange != s[j].PortRange {
+		return portRangeSlice{s[i].PortRange, s[j].PortRange}.Less(0, 1)
+	}
+	return strings.Join(s[i].SourceCIDRs, ",") < strings.Join(s[j].SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules, first by protocol, then by
+// port number, then by source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
+
+// NormaliseIngressRules returns the supplied rules split into one rule
+// per port range and source CIDR, without duplicates, sorted. Rules that
+// allow the same traffic are equal once normalised, however they were
+// grouped; a provider may, for example, merge the source CIDRs of rules
+// for the same port range.
+func NormaliseIngressRules(rules []IngressRule) []IngressRule {
+	seen := make(map[string]bool)
+	var result []IngressRule
+	for _, rule := range rules {
+		sourceCIDRs := rule.SourceCIDRs
+		if rule.IsOpen() {
+			sourceCIDRs = []string{OpenCIDR}
+		}
+		for _, cidr := range sourceCIDRs {
+			single := IngressRule{
+				PortRange:   rule.PortRange,
+				SourceCIDRs: []string{cidr},
+			}
+			if key := single.String(); !seen[key] {
+				seen[key] = true
+				result = append(result, single)
+			}
+		}
+	}
+	SortIngressRules(result)
+	return result
+}
+
+// DiffIngressRules returns the rules in A that are not in B. The rules
+// are normalised first, so the result holds one rule per port range and
+// source CIDR, however the rules were grouped.
+func DiffIngressRules(A, B []IngressRule) (missing []IngressRule) {
+	have := make(map[string]bool)
+	for _, b := range NormaliseIngressRules(B) {
+		have[b.String()] = true
+	}
+	for _, a := range NormaliseIngressRules(A) {
+		if !have[a.String()] {
+			missing = append(missing, a)
+		}
+	}
+	return
+}
+
+// ExposedIngressRules returns the normalised ingress rules wanted for the
+// supplied port ranges, each mapped to the name of the unit that opened
+// it. The exposure function returns whether the unit's service is
+// exposed, and the source CIDRs to which it is exposed: nil allows
+// traffic from anywhere, while an empty slice (a service exposed only to
+// spaces without subnets) allows none.
+func ExposedIngressRules(
+	unitPorts map[PortRange]string,
+	exposure func(unitName string) (bool, []string, error),
+) ([]IngressRule, error) {
+	var rules []IngressRule
+	for portRange, unitName := range unitPorts {
+		exposed, sourceCIDRs, err := exposure(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !exposed || (sourceCIDRs != nil && len(sourceCIDRs) == 0) {
+			continue
+		}
+		rule, err := NewIngressRule(
+			portRange.Protocol, portRange.FromPort, portRange.ToPort,
+			sourceCIDRs...,
+		)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot expose %q", unitName)
+		}
+		rules = append(rules, rule)
+	}
+	return NormaliseIngressRules(rules), nil
+}
+
+// IngressRulesPortRanges returns the distinct port ranges of the supplied
+// rules, sorted.
+func IngressRulesPortRanges(rules []IngressRule) []PortRange {
+	seen := make(map[PortRange]bool)
+	var portRanges []PortRange
+	for _, rule := range rules {
+		if !seen[rule.PortRange] {
+			seen[rule.PortRange] = true
+			portRanges = append(portRanges, rule.PortRange)
+		}
+	}
+	SortPortRanges(portRanges)
+	return portRanges
+}
+
+// ValidateOpenIngressRules returns an error satisfying errors.IsNotSupported
+// if any of the supplied rules restricts the sources of traffic; it is
+// used by providers whose firewalls can only open ports to anywhere.
+func ValidateOpenIngressRules(rules []IngressRule) error {
+	for _, rule := range rules {
+		if !rule.IsOpen() {
+			return errors.NotSupportedf("ingress rule %q restricted to source CIDRs", rule)
+		}
+	}
+	return nil
+}
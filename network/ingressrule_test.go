@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRule(c *gc.C) {
+	rule, err := network.NewIngressRule("tcp", 80, 90, "192.168.0.0/16", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{
+		PortRange:   network.PortRange{80, 90, "tcp"},
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+	})
+	c.Assert(rule.IsOpen(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "80-90/tcp from 10.0.0.0/8,192.168.0.0/16")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleOpen(c *gc.C) {
+	rule, err := network.NewIngressRule("udp", 53, 53)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, network.NewOpenIngressRule(network.PortRange{53, 53, "udp"}))
+	c.Assert(rule.IsOpen(), jc.IsTrue)
+	c.Assert(rule.String(), gc.Equals, "53/udp")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleInvalid(c *gc.C) {
+	_, err := network.NewIngressRule("tcp", 80, 90, "10.0.0.0")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `source CIDR "10.0.0.0" not valid`)
+
+	_, err = network.NewIngressRule("tcp", 90, 80)
+	c.Assert(err, gc.ErrorMatches, `invalid port range 90-80/tcp`)
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("udp", 80, 80),
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 22, 22),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+		network.MustNewIngressRule("udp", 80, 80),
+	})
+	c.Assert(network.IngressRulesPortRanges(rules), jc.DeepEquals, []network.PortRange{
+		{22, 22, "tcp"}, {80, 80, "tcp"}, {80, 80, "udp"},
+	})
+}
+
+func (*IngressRuleSuite) TestNormaliseIngressRules(c *gc.C) {
+	rules := network.NormaliseIngressRules([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16", "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8", network.OpenCIDR),
+		{PortRange: network.PortRange{53, 53, "udp"}},
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+		network.MustNewIngressRule("udp", 53, 53),
+	})
+}
+
+func (*IngressRuleSuite) TestDiffIngressRulesMergedSourceCIDRs(c *gc.C) {
+	// Some providers merge the source CIDRs of rules for the same port
+	// range; the merged rule allows just what the separate rules do.
+	merged := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.0.0/16"),
+	}
+	separate := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	}
+	c.Assert(network.DiffIngressRules(merged, separate), gc.HasLen, 0)
+	c.Assert(network.DiffIngressRules(separate, merged), gc.HasLen, 0)
+
+	missing := network.DiffIngressRules(merged, separate[:1])
+	c.Assert(missing, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+}
+
+func (*IngressRuleSuite) TestExposedIngressRules(c *gc.C) {
+	exposure := map[string][]string{
+		"wordpress/0": nil,
+		"mysql/0":     {"192.168.0.0/16", "10.0.0.0/8"},
+		"empty/0":     {},
+	}
+	rules, err := network.ExposedIngressRules(
+		map[network.PortRange]string{
+			{80, 80, "tcp"}:     "wordpress/0",
+			{3306, 3306, "tcp"}: "mysql/0",
+			{53, 53, "udp"}:     "empty/0",
+			{22, 22, "tcp"}:     "hidden/0",
+		},
+		func(unitName string) (bool, []string, error) {
+			cidrs, exposed := exposure[unitName]
+			return exposed, cidrs, nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("tcp", 3306, 3306, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 3306, 3306, "192.168.0.0/16"),
+	})
+}
+
+func (*IngressRuleSuite) TestExposedIngressRulesError(c *gc.C) {
+	_, err := network.ExposedIngressRules(
+		map[network.PortRange]string{{80, 80, "tcp"}: "wordpress/0"},
+		func(string) (bool, []string, error) {
+			return false, nil, errors.New("boom")
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (*IngressRuleSuite) TestValidateOpenIngressRules(c *gc.C) {
+	err := network.ValidateOpenIngressRules([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = network.ValidateOpenIngressRules([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -89,6 +89,37 @@ func (c UnitFacadeClient) SetStatus(status string, fullIDs ...string) ([]payload
 	return api2results(rs)
 }
 
+// ReportHealth calls the ReportHealth API server method for the
+// identified payload.
+func (c UnitFacadeClient) ReportHealth(fullID string, healthy bool, message string, updateWorkloadStatus bool) error {
+	ids, err := c.lookUp([]string{fullID})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(ids) != 1 {
+		return errors.Errorf("expected 1 ID for %q, got %d", fullID, len(ids))
+	}
+	args := internal.ReportHealthArgs{
+		Args: []internal.ReportHealthArg{
+			internal.NewReportHealthArg(ids[0], healthy, message, updateWorkloadStatus),
+		},
+	}
+
+	var rs internal.PayloadResults
+	if err := c.FacadeCall("ReportHealth", &args, &rs); err != nil {
+		return errors.Trace(err)
+	}
+
+	results, err := api2results(rs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 1 && results[0].Error != nil {
+		return errors.Trace(results[0].Error)
+	}
+	return nil
+}
+
 // Untrack calls the Untrack API server method.
 func (c UnitFacadeClient) Untrack(fullIDs ...string) ([]payload.Result, error) {
 	logger.Tracef("Calling untrack API: %q", fullIDs)
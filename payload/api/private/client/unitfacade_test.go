@@ -321,6 +321,73 @@ func (s *clientSuite) TestSetStatus(c *gc.C) {
 	}})
 }
 
+func (s *clientSuite) TestReportHealth(c *gc.C) {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	responses := []interface{}{
+		&internal.PayloadResults{
+			Results: []internal.PayloadResult{{
+				Entity: params.Entity{
+					Tag: names.NewPayloadTag(id).String(),
+				},
+			}},
+		},
+		&internal.PayloadResults{
+			Results: []internal.PayloadResult{{
+				Entity: params.Entity{
+					Tag: names.NewPayloadTag(id).String(),
+				},
+			}},
+		},
+	}
+	s.facade.responses = append(s.facade.responses, responses...)
+
+	pclient := client.NewUnitFacadeClient(s.facade)
+	err := pclient.ReportHealth("idfoo/bar", false, "exit status 1", true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "FacadeCall", "FacadeCall")
+	s.stub.CheckCall(c, 1, "FacadeCall",
+		"ReportHealth",
+		&internal.ReportHealthArgs{
+			Args: []internal.ReportHealthArg{{
+				Entity: params.Entity{
+					Tag: names.NewPayloadTag(id).String(),
+				},
+				Healthy:              false,
+				Message:              "exit status 1",
+				UpdateWorkloadStatus: true,
+			}},
+		},
+		responses[1],
+	)
+}
+
+func (s *clientSuite) TestReportHealthFailed(c *gc.C) {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	s.facade.responses = append(s.facade.responses,
+		&internal.PayloadResults{
+			Results: []internal.PayloadResult{{
+				Entity: params.Entity{
+					Tag: names.NewPayloadTag(id).String(),
+				},
+			}},
+		},
+		&internal.PayloadResults{
+			Results: []internal.PayloadResult{{
+				Entity: params.Entity{
+					Tag: names.NewPayloadTag(id).String(),
+				},
+				Error: &params.Error{Message: "boom"},
+			}},
+		},
+	)
+
+	pclient := client.NewUnitFacadeClient(s.facade)
+	err := pclient.ReportHealth("idfoo/bar", true, "", false)
+
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
 func (s *clientSuite) TestUntrack(c *gc.C) {
 	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
 	responses := []interface{}{
@@ -441,7 +508,7 @@ type unitMethods struct{}
 
 func (m unitMethods) Handler(name string) (func(target, response interface{}), bool) {
 	switch name {
-	case "List", "LookUp", "SetStatus", "Untrack":
+	case "List", "LookUp", "SetStatus", "ReportHealth", "Untrack":
 		return m.generic, true
 	default:
 		return nil, false
@@ -46,6 +46,25 @@ type SetStatusArg struct {
 	Status string
 }
 
+// ReportHealthArgs are the arguments for the ReportHealth endpoint.
+type ReportHealthArgs struct {
+	// Args is the list of arguments to pass to this function.
+	Args []ReportHealthArg
+}
+
+// ReportHealthArg are the arguments for a single call to the
+// ReportHealth endpoint.
+type ReportHealthArg struct {
+	params.Entity
+	// Healthy indicates whether the payload passed its health check.
+	Healthy bool
+	// Message describes why the payload is unhealthy, if it is.
+	Message string
+	// UpdateWorkloadStatus indicates that the unit's workload status
+	// should reflect the payload's health.
+	UpdateWorkloadStatus bool
+}
+
 // Untrack uses params.Entities.
 
 // PayloadResults is the result for a call that makes one or more requests
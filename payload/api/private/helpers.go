@@ -135,6 +135,18 @@ func IDs2SetStatusArgs(ids []string, status string) SetStatusArgs {
 	return args
 }
 
+// NewReportHealthArg builds an argument for the ReportHealth API
+// endpoint from the provided payload ID and health check outcome.
+func NewReportHealthArg(id string, healthy bool, message string, updateWorkloadStatus bool) ReportHealthArg {
+	arg := ReportHealthArg{
+		Healthy:              healthy,
+		Message:              message,
+		UpdateWorkloadStatus: updateWorkloadStatus,
+	}
+	arg.Tag = names.NewPayloadTag(id).String()
+	return arg
+}
+
 // IDs2UntrackArgs converts the provided payload IDs into arguments
 // for the Untrack API endpoint.
 func IDs2UntrackArgs(ids []string) params.Entities {
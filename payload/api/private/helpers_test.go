@@ -266,3 +266,17 @@ func (internalHelpersSuite) TestResult2apiNotFound(c *gc.C) {
 		Error:    common.ServerError(err),
 	})
 }
+
+func (internalHelpersSuite) TestNewReportHealthArg(c *gc.C) {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	arg := private.NewReportHealthArg(id, false, "exit status 1", true)
+
+	c.Check(arg, jc.DeepEquals, private.ReportHealthArg{
+		Entity: params.Entity{
+			Tag: names.NewPayloadTag(id).String(),
+		},
+		Healthy:              false,
+		Message:              "exit status 1",
+		UpdateWorkloadStatus: true,
+	})
+}
@@ -12,8 +12,13 @@ import (
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/payload/api"
 	internal "github.com/juju/juju/payload/api/private"
+	"github.com/juju/juju/state"
 )
 
+// healthStatusKey is the key, in the unit's workload status data,
+// identifying the payload whose failed health check set the status.
+const healthStatusKey = "unhealthy-payload"
+
 // UnitPayloads exposes the State functionality for a unit's payloads.
 type UnitPayloads interface {
 	// Track tracks a payload for the unit and info.
@@ -28,15 +33,24 @@ type UnitPayloads interface {
 	Untrack(id string) error
 }
 
+// UnitStatus exposes the workload status of the unit whose payloads
+// are served.
+type UnitStatus interface {
+	state.StatusGetter
+	state.StatusSetter
+}
+
 // UnitFacade serves payload-specific API methods.
 type UnitFacade struct {
 	// State exposes the payload aspect of Juju's state.
 	State UnitPayloads
+	// Unit exposes the unit's workload status.
+	Unit UnitStatus
 }
 
-// NewUnitFacade builds a new facade for the given State.
-func NewUnitFacade(st UnitPayloads) *UnitFacade {
-	return &UnitFacade{State: st}
+// NewUnitFacade builds a new facade for the given State and unit.
+func NewUnitFacade(st UnitPayloads, unit UnitStatus) *UnitFacade {
+	return &UnitFacade{State: st, Unit: unit}
 }
 
 // Track stores a payload to be tracked in state.
@@ -150,6 +164,91 @@ func (uf UnitFacade) SetStatus(args internal.SetStatusArgs) (internal.PayloadRes
 	return r, nil
 }
 
+// ReportHealth records the outcome of the unit agent's health checks
+// of payloads. An unhealthy payload's status is set to "unhealthy",
+// and restored to "running" once it is healthy again. When requested, the unit's workload
+// status is set to blocked while the payload is unhealthy, and restored
+// to active once it recovers. Statuses are only set when they change.
+func (uf UnitFacade) ReportHealth(args internal.ReportHealthArgs) (internal.PayloadResults, error) {
+	var r internal.PayloadResults
+	for _, arg := range args.Args {
+		id, err := internal.API2ID(arg.Tag)
+		if err != nil {
+			return r, errors.Trace(err)
+		}
+
+		err = uf.reportHealth(id, arg)
+		res := internal.NewPayloadResult(id, err)
+		r.Results = append(r.Results, res)
+	}
+	return r, nil
+}
+
+func (uf UnitFacade) reportHealth(id string, arg internal.ReportHealthArg) error {
+	current, err := uf.payloadStatus(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Setting an unchanged status would still record it in the
+	// status history, so only transitions are set. A healthy check
+	// only moves the payload back from unhealthy to running, leaving
+	// any status set by the charm alone.
+	status := current
+	switch {
+	case !arg.Healthy:
+		status = payload.StateUnhealthy
+	case current == payload.StateUnhealthy:
+		status = payload.StateRunning
+	}
+	if current != status {
+		if err := uf.State.SetStatus(id, status); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !arg.UpdateWorkloadStatus || uf.Unit == nil {
+		return nil
+	}
+
+	workload, err := uf.Unit.Status()
+	if err != nil {
+		return errors.Annotate(err, "could not get workload status")
+	}
+	blockedByPayload := workload.Status == state.StatusBlocked && workload.Data[healthStatusKey] == id
+	if !arg.Healthy {
+		if blockedByPayload && workload.Message == arg.Message {
+			return nil
+		}
+		data := map[string]interface{}{healthStatusKey: id}
+		err := uf.Unit.SetStatus(state.StatusBlocked, arg.Message, data)
+		return errors.Annotate(err, "could not set workload status")
+	}
+
+	// Only clear a workload status that this payload set; the charm
+	// may have set another status since.
+	if !blockedByPayload {
+		return nil
+	}
+	err = uf.Unit.SetStatus(state.StatusActive, "", nil)
+	return errors.Annotate(err, "could not set workload status")
+}
+
+// payloadStatus returns the current status of the identified payload,
+// or "" if it is not found; setting the status of a missing payload
+// reports the error.
+func (uf UnitFacade) payloadStatus(id string) (string, error) {
+	results, err := uf.State.List(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results) != 1 || results[0].NotFound || results[0].Payload == nil {
+		return "", nil
+	}
+	if results[0].Error != nil {
+		return "", errors.Trace(results[0].Error)
+	}
+	return results[0].Payload.Status, nil
+}
+
 // Untrack marks the identified payload as no longer being tracked.
 func (uf UnitFacade) Untrack(args params.Entities) (internal.PayloadResults, error) {
 	var r internal.PayloadResults
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/payload/api"
 	internal "github.com/juju/juju/payload/api/private"
+	"github.com/juju/juju/state"
 )
 
 var _ = gc.Suite(&suite{})
@@ -38,7 +39,7 @@ func (s *suite) TestTrack(c *gc.C) {
 	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
 	s.state.stateIDs = []string{id}
 
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 
 	args := internal.TrackArgs{
 		Payloads: []api.Payload{{
@@ -93,7 +94,7 @@ func (s *suite) TestListOne(c *gc.C) {
 		},
 	}}
 
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 	args := params.Entities{
 		Entities: []params.Entity{{
 			Tag: names.NewPayloadTag(id).String(),
@@ -144,7 +145,7 @@ func (s *suite) TestListAll(c *gc.C) {
 		},
 	}}
 
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 	args := params.Entities{}
 	results, err := a.List(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -174,7 +175,7 @@ func (s *suite) TestLookUpOkay(c *gc.C) {
 	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
 	s.state.stateIDs = []string{id}
 
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 	args := internal.LookUpArgs{
 		Args: []internal.LookUpArg{{
 			Name: "fooID",
@@ -209,7 +210,7 @@ func (s *suite) TestLookUpMixed(c *gc.C) {
 	notFound := errors.NotFoundf("payload")
 	s.stub.SetErrors(nil, notFound, nil)
 
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 	args := internal.LookUpArgs{
 		Args: []internal.LookUpArg{{
 			Name: "fooID",
@@ -254,7 +255,7 @@ func (s *suite) TestSetStatus(c *gc.C) {
 	s.state.stateIDs = []string{id}
 	s.state.stateIDs = []string{"ce5bc2a7-65d8-4800-8199-a7c3356ab309"}
 
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 	args := internal.SetStatusArgs{
 		Args: []internal.SetStatusArg{{
 			Entity: params.Entity{
@@ -280,11 +281,145 @@ func (s *suite) TestSetStatus(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, expected)
 }
 
+func (s *suite) reportHealth(c *gc.C, unit UnitStatus, healthy bool, updateWorkloadStatus bool) internal.PayloadResults {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	a := UnitFacade{State: s.state, Unit: unit}
+	args := internal.ReportHealthArgs{
+		Args: []internal.ReportHealthArg{
+			internal.NewReportHealthArg(id, healthy, `payload "spam/id1" is unhealthy`, updateWorkloadStatus),
+		},
+	}
+	res, err := a.ReportHealth(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.state.id, gc.Equals, id)
+	return res
+}
+
+func (s *suite) TestReportHealthUnhealthy(c *gc.C) {
+	unit := &fakeUnitStatus{stub: s.stub}
+
+	res := s.reportHealth(c, unit, false, false)
+
+	c.Check(s.state.status, gc.Equals, payload.StateUnhealthy)
+	c.Check(res.Results, gc.HasLen, 1)
+	c.Check(res.Results[0].Error, gc.IsNil)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *suite) TestReportHealthUnhealthyWorkloadStatus(c *gc.C) {
+	unit := &fakeUnitStatus{stub: s.stub}
+
+	res := s.reportHealth(c, unit, false, true)
+
+	c.Check(s.state.status, gc.Equals, payload.StateUnhealthy)
+	c.Check(res.Results[0].Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Status", "SetStatus")
+	s.stub.CheckCall(c, 1, "SetStatus", state.StatusBlocked, `payload "spam/id1" is unhealthy`, map[string]interface{}{
+		healthStatusKey: "ce5bc2a7-65d8-4800-8199-a7c3356ab309",
+	})
+}
+
+func (s *suite) TestReportHealthUnchanged(c *gc.C) {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	s.state.payloads = []payload.Result{{
+		ID: id,
+		Payload: &payload.FullPayloadInfo{
+			Payload: payload.Payload{Status: payload.StateUnhealthy},
+		},
+	}}
+	unit := &fakeUnitStatus{stub: s.stub}
+	unit.status = state.StatusInfo{
+		Status:  state.StatusBlocked,
+		Message: `payload "spam/id1" is unhealthy`,
+		Data: map[string]interface{}{
+			healthStatusKey: id,
+		},
+	}
+	a := UnitFacade{State: s.state, Unit: unit}
+	args := internal.ReportHealthArgs{
+		Args: []internal.ReportHealthArg{
+			internal.NewReportHealthArg(id, false, `payload "spam/id1" is unhealthy`, true),
+		},
+	}
+
+	res, err := a.ReportHealth(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(res.Results[0].Error, gc.IsNil)
+	c.Check(s.state.ids, jc.DeepEquals, []string{id})
+	// Neither the payload's status nor the unit's is set again.
+	c.Check(s.state.status, gc.Equals, "")
+	s.stub.CheckCallNames(c, "Status")
+}
+
+// setPayloadStatus records the status of the payload whose health is
+// reported.
+func (s *suite) setPayloadStatus(status string) {
+	s.state.payloads = []payload.Result{{
+		ID: "ce5bc2a7-65d8-4800-8199-a7c3356ab309",
+		Payload: &payload.FullPayloadInfo{
+			Payload: payload.Payload{Status: status},
+		},
+	}}
+}
+
+func (s *suite) TestReportHealthRecovered(c *gc.C) {
+	s.setPayloadStatus(payload.StateUnhealthy)
+	unit := &fakeUnitStatus{stub: s.stub}
+	unit.status = state.StatusInfo{
+		Status: state.StatusBlocked,
+		Data: map[string]interface{}{
+			healthStatusKey: "ce5bc2a7-65d8-4800-8199-a7c3356ab309",
+		},
+	}
+
+	res := s.reportHealth(c, unit, true, true)
+
+	c.Check(s.state.status, gc.Equals, payload.StateRunning)
+	c.Check(res.Results[0].Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "Status", "SetStatus")
+	s.stub.CheckCall(c, 1, "SetStatus", state.StatusActive, "", map[string]interface{}(nil))
+}
+
+func (s *suite) TestReportHealthRecoveredCharmStatus(c *gc.C) {
+	s.setPayloadStatus(payload.StateUnhealthy)
+	unit := &fakeUnitStatus{stub: s.stub}
+	unit.status = state.StatusInfo{
+		Status:  state.StatusBlocked,
+		Message: "set by the charm",
+	}
+
+	s.reportHealth(c, unit, true, true)
+
+	c.Check(s.state.status, gc.Equals, payload.StateRunning)
+	s.stub.CheckCallNames(c, "Status")
+}
+
+func (s *suite) TestReportHealthHealthyKeepsCharmSetStatus(c *gc.C) {
+	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
+	s.setPayloadStatus(payload.StateStopped)
+	a := UnitFacade{State: s.state, Unit: &fakeUnitStatus{stub: s.stub}}
+	args := internal.ReportHealthArgs{
+		Args: []internal.ReportHealthArg{
+			internal.NewReportHealthArg(id, true, "", false),
+		},
+	}
+
+	res, err := a.ReportHealth(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(res.Results[0].Error, gc.IsNil)
+	c.Check(s.state.ids, jc.DeepEquals, []string{id})
+	// The status set by the charm is not replaced.
+	c.Check(s.state.status, gc.Equals, "")
+	s.stub.CheckNoCalls(c)
+}
+
 func (s *suite) TestUntrack(c *gc.C) {
 	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
 	s.state.stateIDs = []string{id}
 
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 	args := params.Entities{
 		Entities: []params.Entity{{
 			Tag: names.NewPayloadTag(id).String(),
@@ -307,7 +442,7 @@ func (s *suite) TestUntrack(c *gc.C) {
 }
 
 func (s *suite) TestUntrackEmptyID(c *gc.C) {
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 	args := params.Entities{
 		Entities: []params.Entity{{
 			Tag: "",
@@ -333,7 +468,7 @@ func (s *suite) TestUntrackNoIDs(c *gc.C) {
 	id := "ce5bc2a7-65d8-4800-8199-a7c3356ab309"
 	s.state.id = id
 
-	a := UnitFacade{s.state}
+	a := UnitFacade{State: s.state}
 	args := params.Entities{
 		Entities: []params.Entity{},
 	}
@@ -415,3 +550,26 @@ func (f *FakeState) Untrack(id string) error {
 
 	return nil
 }
+
+type fakeUnitStatus struct {
+	stub   *testing.Stub
+	status state.StatusInfo
+}
+
+func (f *fakeUnitStatus) Status() (state.StatusInfo, error) {
+	f.stub.AddCall("Status")
+	if err := f.stub.NextErr(); err != nil {
+		return state.StatusInfo{}, errors.Trace(err)
+	}
+
+	return f.status, nil
+}
+
+func (f *fakeUnitStatus) SetStatus(status state.Status, info string, data map[string]interface{}) error {
+	f.stub.AddCall("SetStatus", status, info, data)
+	if err := f.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
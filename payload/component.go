@@ -8,3 +8,7 @@ package payload
 
 // ComponentName is the name of the Juju component for payload management.
 const ComponentName = "payloads"
+
+// HookContextFacade is the name of the API facade through which unit
+// agents manage their payloads.
+const HookContextFacade = ComponentName + "-hook-context"
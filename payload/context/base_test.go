@@ -157,6 +157,15 @@ func (c *stubContextComponent) SetStatus(class, id, status string) error {
 	return nil
 }
 
+func (c *stubContextComponent) SetHealthCheck(class, id string, check payload.HealthCheck) error {
+	c.stub.AddCall("SetHealthCheck", class, id, check)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (c *stubContextComponent) Flush() error {
 	c.stub.AddCall("Flush")
 	if err := c.stub.NextErr(); err != nil {
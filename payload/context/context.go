@@ -39,6 +39,9 @@ type Component interface {
 	Untrack(class, id string) error
 	// SetStatus sets the status of the payload.
 	SetStatus(class, id, status string) error
+	// SetHealthCheck records the health check which the unit agent
+	// runs for the payload.
+	SetHealthCheck(class, id string, check payload.HealthCheck) error
 	// List returns the list of registered payload IDs.
 	List() ([]string, error)
 	// Flush pushes the hook context data out to state.
@@ -192,6 +195,11 @@ func (c *Context) Untrack(class, id string) error {
 	}
 	delete(c.payloads, id)
 
+	// The payload is gone, so its health is no longer of interest.
+	if err := c.removeHealthCheck(fullID); err != nil {
+		logger.Errorf("could not remove health check for %q: %v", fullID, err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/payload"
+)
+
+// HealthChecksFile is the name of the file, in the payload component's
+// data directory, which holds the unit's payload health checks. The
+// unit agent reads it to know which checks to run.
+const HealthChecksFile = "health-checks.json"
+
+// ReadHealthChecks returns the health checks recorded in the given
+// data directory, keyed by the payloads' full IDs.
+func ReadHealthChecks(dataDir string) (map[string]payload.HealthCheck, error) {
+	checks := make(map[string]payload.HealthCheck)
+	data, err := ioutil.ReadFile(filepath.Join(dataDir, HealthChecksFile))
+	if os.IsNotExist(err) {
+		return checks, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := json.Unmarshal(data, &checks); err != nil {
+		return nil, errors.Annotate(err, "bad health checks file")
+	}
+	return checks, nil
+}
+
+func writeHealthChecks(dataDir string, checks map[string]payload.HealthCheck) error {
+	data, err := json.Marshal(checks)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	return utils.AtomicWriteFile(filepath.Join(dataDir, HealthChecksFile), data, 0600)
+}
+
+// SetHealthCheck records the health check for the identified payload,
+// replacing any existing one.
+func (c *Context) SetHealthCheck(class, id string, check payload.HealthCheck) error {
+	fullID := payload.BuildID(class, id)
+	logger.Tracef("setting health check for %q in hook context", fullID)
+
+	if err := check.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	checks, err := ReadHealthChecks(c.dataDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	checks[fullID] = check
+	return errors.Trace(writeHealthChecks(c.dataDir, checks))
+}
+
+// removeHealthCheck removes any health check for the identified payload.
+func (c *Context) removeHealthCheck(fullID string) error {
+	checks, err := ReadHealthChecks(c.dataDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := checks[fullID]; !ok {
+		return nil
+	}
+	delete(checks, fullID)
+	return errors.Trace(writeHealthChecks(c.dataDir, checks))
+}
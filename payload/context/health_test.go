@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/payload/context"
+)
+
+type healthSuite struct {
+	baseSuite
+	apiClient *stubAPIClient
+	dataDir   string
+}
+
+var _ = gc.Suite(&healthSuite{})
+
+func (s *healthSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+
+	s.apiClient = newStubAPIClient(s.Stub)
+	s.dataDir = filepath.Join(c.MkDir(), "payload")
+}
+
+func (s *healthSuite) newCheck(command string) payload.HealthCheck {
+	check := payload.NewHealthCheck()
+	check.Command = command
+	return check
+}
+
+func (s *healthSuite) TestReadHealthChecksMissing(c *gc.C) {
+	checks, err := context.ReadHealthChecks(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(checks, gc.HasLen, 0)
+}
+
+func (s *healthSuite) TestReadHealthChecksBadFile(c *gc.C) {
+	dataDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dataDir, context.HealthChecksFile), []byte("{{"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = context.ReadHealthChecks(dataDir)
+
+	c.Check(err, gc.ErrorMatches, "bad health checks file: .*")
+}
+
+func (s *healthSuite) TestSetHealthCheck(c *gc.C) {
+	ctx := context.NewContext(s.apiClient, s.dataDir)
+	spam := s.newCheck("pgrep spam")
+	eggs := s.newCheck("pgrep eggs")
+	eggs.UpdateWorkloadStatus = true
+
+	err := ctx.SetHealthCheck("spam", "id1", spam)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.SetHealthCheck("eggs", "id2", eggs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	checks, err := context.ReadHealthChecks(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checks, jc.DeepEquals, map[string]payload.HealthCheck{
+		"spam/id1": spam,
+		"eggs/id2": eggs,
+	})
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *healthSuite) TestSetHealthCheckInvalid(c *gc.C) {
+	ctx := context.NewContext(s.apiClient, s.dataDir)
+
+	err := ctx.SetHealthCheck("spam", "id1", payload.NewHealthCheck())
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	checks, err := context.ReadHealthChecks(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checks, gc.HasLen, 0)
+}
+
+func (s *healthSuite) TestUntrackRemovesHealthCheck(c *gc.C) {
+	s.apiClient.setNew("spam/id1", "eggs/id2")
+	ctx := context.NewContext(s.apiClient, s.dataDir)
+	eggs := s.newCheck("pgrep eggs")
+	err := ctx.SetHealthCheck("spam", "id1", s.newCheck("pgrep spam"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.SetHealthCheck("eggs", "id2", eggs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = ctx.Untrack("spam", "id1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	checks, err := context.ReadHealthChecks(s.dataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(checks, jc.DeepEquals, map[string]payload.HealthCheck{
+		"eggs/id2": eggs,
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/payload"
 )
@@ -33,6 +34,9 @@ type RegisterCmd struct {
 	class  string
 	id     string
 	labels []string
+
+	health       payload.HealthCheck
+	healthChecks bool
 }
 
 // TODO(ericsnow) Change "tags" to "labels" in the help text?
@@ -51,10 +55,28 @@ provided when "register" is run.
 The payload class must correspond to one of the payloads defined in
 the charm's metadata.yaml.
 
+The unit agent can periodically check the payload's health, either by
+running a command (--health-command) or by probing a URL with an HTTP GET
+request (--health-url). Once --health-threshold consecutive checks have
+failed the payload's status becomes "unhealthy", and it returns to
+"running" when a check next succeeds. With --health-workload-status the
+unit's workload status reflects the payload's health too.
+
 		`,
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *RegisterCmd) SetFlags(f *gnuflag.FlagSet) {
+	c.health = payload.NewHealthCheck()
+	f.StringVar(&c.health.Command, "health-command", "", "shell command which exits zero while the payload is healthy")
+	f.StringVar(&c.health.URL, "health-url", "", "URL which responds successfully to GET while the payload is healthy")
+	f.DurationVar(&c.health.Interval, "health-interval", payload.DefaultHealthInterval, "time between health checks")
+	f.DurationVar(&c.health.Timeout, "health-timeout", payload.DefaultHealthTimeout, "time after which a health check fails")
+	f.IntVar(&c.health.Threshold, "health-threshold", payload.DefaultHealthThreshold, "consecutive failed checks before the payload is unhealthy")
+	f.BoolVar(&c.health.UpdateWorkloadStatus, "health-workload-status", false, "reflect the payload's health in the unit's workload status")
+}
+
 // Init implements cmd.Command.
 func (c *RegisterCmd) Init(args []string) error {
 	if len(args) < 3 {
@@ -64,6 +86,13 @@ func (c *RegisterCmd) Init(args []string) error {
 	c.class = args[1]
 	c.id = args[2]
 	c.labels = args[3:]
+
+	c.healthChecks = c.health.Command != "" || c.health.URL != ""
+	if c.healthChecks {
+		if err := c.health.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -92,6 +121,12 @@ func (c *RegisterCmd) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.healthChecks {
+		if err := c.hctx.SetHealthCheck(c.class, c.id, c.health); err != nil {
+			return errors.Annotate(err, "could not set health check")
+		}
+	}
+
 	// TODO(ericsnow) Print out the full ID.
 
 	return nil
@@ -6,6 +6,7 @@ package context
 import (
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -15,6 +16,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/payload"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type registerSuite struct {
@@ -122,12 +124,83 @@ func (registerSuite) TestRunFlushErr(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boo")
 }
 
+func (registerSuite) TestInitHealthCheck(c *gc.C) {
+	r := RegisterCmd{}
+	err := coretesting.InitCommand(&r, []string{
+		"--health-url", "http://localhost:8080/health",
+		"--health-interval", "1m",
+		"--health-workload-status",
+		"type", "class", "id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(r.healthChecks, jc.IsTrue)
+	c.Check(r.health, jc.DeepEquals, payload.HealthCheck{
+		URL:                  "http://localhost:8080/health",
+		Interval:             time.Minute,
+		Timeout:              payload.DefaultHealthTimeout,
+		Threshold:            payload.DefaultHealthThreshold,
+		UpdateWorkloadStatus: true,
+	})
+}
+
+func (registerSuite) TestInitHealthCheckInvalid(c *gc.C) {
+	r := RegisterCmd{}
+	err := coretesting.InitCommand(&r, []string{
+		"--health-command", "pgrep spam",
+		"--health-threshold", "0",
+		"type", "class", "id",
+	})
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (registerSuite) TestRunHealthCheck(c *gc.C) {
+	f := &stubRegisterContext{}
+	r := RegisterCmd{hctx: f}
+	err := coretesting.InitCommand(&r, []string{
+		"--health-command", "pgrep spam",
+		"type", "class", "id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := setupMetadata(c)
+	err = r.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := payload.NewHealthCheck()
+	expected.Command = "pgrep spam"
+	c.Check(f.healthID, gc.Equals, "class/id")
+	c.Check(f.health, jc.DeepEquals, expected)
+}
+
+func (registerSuite) TestRunNoHealthCheck(c *gc.C) {
+	f := &stubRegisterContext{}
+	r := RegisterCmd{hctx: f}
+	err := coretesting.InitCommand(&r, []string{"type", "class", "id"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := setupMetadata(c)
+	err = r.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(f.healthID, gc.Equals, "")
+}
+
 type stubRegisterContext struct {
 	Component
 	payload  payload.Payload
 	flushed  bool
 	trackerr error
 	flusherr error
+	healthID string
+	health   payload.HealthCheck
+}
+
+func (f *stubRegisterContext) SetHealthCheck(class, id string, check payload.HealthCheck) error {
+	f.healthID = payload.BuildID(class, id)
+	f.health = check
+	return nil
 }
 
 func (f *stubRegisterContext) Track(pl payload.Payload) error {
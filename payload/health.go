@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package payload
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// These are the defaults used for the optional parts of a health check.
+const (
+	DefaultHealthInterval  = 30 * time.Second
+	DefaultHealthTimeout   = 10 * time.Second
+	DefaultHealthThreshold = 3
+)
+
+// HealthCheck describes how the unit agent periodically checks that
+// a payload is healthy. Exactly one of Command and URL must be set.
+type HealthCheck struct {
+	// Command is a shell command run on the unit's machine. The
+	// payload is healthy if it exits with a zero status.
+	Command string `json:"command,omitempty"`
+
+	// URL is probed with an HTTP GET request. The payload is healthy
+	// if the response has a 2xx or 3xx status code.
+	URL string `json:"url,omitempty"`
+
+	// Interval is the time between successive checks.
+	Interval time.Duration `json:"interval"`
+
+	// Timeout is how long a single check may take before it is
+	// considered to have failed.
+	Timeout time.Duration `json:"timeout"`
+
+	// Threshold is the number of consecutive failed checks after
+	// which the payload is considered unhealthy.
+	Threshold int `json:"threshold"`
+
+	// UpdateWorkloadStatus indicates that the unit's workload status
+	// should reflect the payload's health, as well as the payload's
+	// own status.
+	UpdateWorkloadStatus bool `json:"update-workload-status,omitempty"`
+}
+
+// NewHealthCheck returns a health check with the default interval,
+// timeout and threshold. The command or URL must still be set.
+func NewHealthCheck() HealthCheck {
+	return HealthCheck{
+		Interval:  DefaultHealthInterval,
+		Timeout:   DefaultHealthTimeout,
+		Threshold: DefaultHealthThreshold,
+	}
+}
+
+// Validate checks the health check to ensure it is correct.
+func (hc HealthCheck) Validate() error {
+	switch {
+	case hc.Command == "" && hc.URL == "":
+		return errors.NotValidf("health check without command or URL")
+	case hc.Command != "" && hc.URL != "":
+		return errors.NotValidf("health check with both command and URL")
+	}
+	if hc.URL != "" {
+		u, err := url.Parse(hc.URL)
+		if err != nil {
+			return errors.NewNotValid(err, "bad health check URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("health check URL scheme %q", u.Scheme)
+		}
+	}
+
+	if hc.Interval <= 0 {
+		return errors.NotValidf("health check interval %v", hc.Interval)
+	}
+	if hc.Timeout <= 0 || hc.Timeout > hc.Interval {
+		return errors.NotValidf("health check timeout %v (interval %v)", hc.Timeout, hc.Interval)
+	}
+	if hc.Threshold < 1 {
+		return errors.NotValidf("health check threshold %d", hc.Threshold)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package health
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/payload/api/private/client"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/uniter"
+)
+
+// ManifoldConfig identifies the resource names upon which the payload
+// health manifold depends.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	// NewReporter returns the Reporter used by the worker.
+	NewReporter func(base.APICaller) Reporter
+}
+
+// Manifold returns a dependency manifold that runs a payload health
+// check worker, using the resource names defined in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: func(getResource dependency.GetResourceFunc) (worker.Worker, error) {
+			var agent agent.Agent
+			if err := getResource(config.AgentName, &agent); err != nil {
+				return nil, err
+			}
+			var apiCaller base.APICaller
+			if err := getResource(config.APICallerName, &apiCaller); err != nil {
+				return nil, err
+			}
+
+			agentConfig := agent.CurrentConfig()
+			unitTag, ok := agentConfig.Tag().(names.UnitTag)
+			if !ok {
+				return nil, errors.Errorf("expected unit tag, got %v", agentConfig.Tag())
+			}
+			paths := uniter.NewPaths(agentConfig.DataDir(), unitTag)
+
+			return NewWorker(Config{
+				DataDir:  paths.ComponentDir(payload.ComponentName),
+				Reporter: config.NewReporter(apiCaller),
+				Clock:    clock.WallClock,
+				Probe:    Probe,
+			})
+		},
+	}
+}
+
+// NewReporter returns a Reporter which reports payload health through
+// the payload hook context facade.
+func NewReporter(apiCaller base.APICaller) Reporter {
+	facadeCaller := base.NewFacadeCallerForVersion(apiCaller, payload.HookContextFacade, 0)
+	return client.NewUnitFacadeClient(facadeCaller)
+}
@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package health
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/payload"
+)
+
+// ErrAborted is returned by Probe when it is aborted before the check
+// completes.
+var ErrAborted = errors.New("health check aborted")
+
+// Probe runs the health check once, returning an error describing
+// the failure if the payload is not healthy.
+func Probe(check payload.HealthCheck, abort <-chan struct{}) error {
+	if check.URL != "" {
+		return probeURL(check.URL, check.Timeout, abort)
+	}
+	return probeCommand(check.Command, check.Timeout, abort)
+}
+
+func probeCommand(command string, timeout time.Duration, abort <-chan struct{}) error {
+	cmd := exec.Command("/bin/sh", "-c", command)
+	if err := cmd.Start(); err != nil {
+		return errors.Annotate(err, "could not run health check command")
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return errors.Annotate(err, "health check command failed")
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		<-done
+		return errors.Errorf("health check command timed out after %v", timeout)
+	case <-abort:
+		cmd.Process.Kill()
+		<-done
+		return ErrAborted
+	}
+}
+
+func probeURL(url string, timeout time.Duration, abort <-chan struct{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Cancel = abort
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		select {
+		case <-abort:
+			return ErrAborted
+		default:
+		}
+		return errors.Annotate(err, "health check request failed")
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.Errorf("health check request failed: %s", resp.Status)
+	}
+	return nil
+}
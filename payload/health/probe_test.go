@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package health_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/payload/health"
+)
+
+type probeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&probeSuite{})
+
+func (s *probeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	if runtime.GOOS == "windows" {
+		c.Skip("health check commands are run with /bin/sh")
+	}
+}
+
+func newCommandCheck(command string) payload.HealthCheck {
+	check := payload.NewHealthCheck()
+	check.Command = command
+	return check
+}
+
+func newURLCheck(url string) payload.HealthCheck {
+	check := payload.NewHealthCheck()
+	check.URL = url
+	return check
+}
+
+func (s *probeSuite) TestCommandHealthy(c *gc.C) {
+	err := health.Probe(newCommandCheck("exit 0"), nil)
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *probeSuite) TestCommandUnhealthy(c *gc.C) {
+	err := health.Probe(newCommandCheck("exit 3"), nil)
+
+	c.Check(err, gc.ErrorMatches, "health check command failed: exit status 3")
+}
+
+func (s *probeSuite) TestCommandTimeout(c *gc.C) {
+	check := newCommandCheck("sleep 10")
+	check.Timeout = 10 * time.Millisecond
+
+	err := health.Probe(check, nil)
+
+	c.Check(err, gc.ErrorMatches, "health check command timed out after 10ms")
+}
+
+func (s *probeSuite) TestCommandAborted(c *gc.C) {
+	abort := make(chan struct{})
+	close(abort)
+
+	err := health.Probe(newCommandCheck("sleep 10"), abort)
+
+	c.Check(err, gc.Equals, health.ErrAborted)
+}
+
+func (s *probeSuite) TestURLHealthy(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := health.Probe(newURLCheck(server.URL), nil)
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *probeSuite) TestURLUnhealthy(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := health.Probe(newURLCheck(server.URL), nil)
+
+	c.Check(err, gc.ErrorMatches, "health check request failed: 503 Service Unavailable")
+}
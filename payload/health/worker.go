@@ -0,0 +1,197 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package health provides a worker which runs the health checks that
+// charms define for their unit's payloads, and reports the outcomes to
+// the controller.
+package health
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/payload/context"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.payload.health")
+
+// PollInterval is how often the worker rereads the unit's health
+// checks and runs those which are due.
+const PollInterval = 5 * time.Second
+
+// Reporter records the outcome of payload health checks.
+type Reporter interface {
+	// ReportHealth records whether the identified payload is healthy.
+	ReportHealth(fullID string, healthy bool, message string, updateWorkloadStatus bool) error
+}
+
+// Config holds the dependencies and configuration for a health
+// check worker.
+type Config struct {
+	// DataDir is the payload component's data directory, in which the
+	// hook tools record the health checks.
+	DataDir string
+
+	// Reporter records the outcomes of the health checks.
+	Reporter Reporter
+
+	// Clock is used to schedule the health checks.
+	Clock clock.Clock
+
+	// Probe runs a single health check.
+	Probe func(check payload.HealthCheck, abort <-chan struct{}) error
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.DataDir == "" {
+		return errors.NotValidf("empty DataDir")
+	}
+	if config.Reporter == nil {
+		return errors.NotValidf("nil Reporter")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Probe == nil {
+		return errors.NotValidf("nil Probe")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which runs the health checks recorded in
+// the configured data directory.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := &checker{
+		config:   config,
+		payloads: make(map[string]*payloadHealth),
+	}
+	return worker.NewSimpleWorker(c.loop), nil
+}
+
+// payloadHealth tracks the health of a single payload.
+type payloadHealth struct {
+	check    payload.HealthCheck
+	next     time.Time
+	failures int
+	lastErr  error
+
+	// reported is the health most recently reported, if any.
+	reported *bool
+}
+
+type checker struct {
+	config   Config
+	payloads map[string]*payloadHealth
+}
+
+func (c *checker) loop(stop <-chan struct{}) error {
+	for {
+		if err := c.poll(stop); err != nil {
+			if err == ErrAborted {
+				return nil
+			}
+			return errors.Trace(err)
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-c.config.Clock.After(PollInterval):
+		}
+	}
+}
+
+// poll rereads the health checks and runs those which are due.
+func (c *checker) poll(abort <-chan struct{}) error {
+	checks, err := context.ReadHealthChecks(c.config.DataDir)
+	if err != nil {
+		// The file is written by hook tools; a bad write should
+		// not stop the agent.
+		logger.Errorf("could not read payload health checks: %v", err)
+		return nil
+	}
+	now := c.config.Clock.Now()
+	for fullID := range c.payloads {
+		if _, ok := checks[fullID]; !ok {
+			delete(c.payloads, fullID)
+		}
+	}
+
+	var fullIDs []string
+	for fullID, check := range checks {
+		ph, ok := c.payloads[fullID]
+		if !ok || ph.check != check {
+			// A new or changed check starts afresh.
+			ph = &payloadHealth{check: check, next: now}
+			c.payloads[fullID] = ph
+		}
+		fullIDs = append(fullIDs, fullID)
+	}
+	sort.Strings(fullIDs)
+
+	for _, fullID := range fullIDs {
+		ph := c.payloads[fullID]
+		if now.Before(ph.next) {
+			continue
+		}
+		ph.next = now.Add(ph.check.Interval)
+		err := c.config.Probe(ph.check, abort)
+		if err == ErrAborted {
+			return err
+		}
+		c.record(fullID, ph, err)
+	}
+	return nil
+}
+
+// record updates the payload's health with the outcome of a check,
+// and reports it if the payload has become healthy or unhealthy.
+func (c *checker) record(fullID string, ph *payloadHealth, err error) {
+	if err != nil {
+		ph.failures++
+		ph.lastErr = err
+		logger.Debugf("health check %d for payload %q failed: %v", ph.failures, fullID, err)
+	} else {
+		ph.failures = 0
+		ph.lastErr = nil
+	}
+
+	var healthy bool
+	switch {
+	case ph.failures == 0:
+		healthy = true
+	case ph.failures >= ph.check.Threshold:
+		healthy = false
+	default:
+		// Not enough failures yet to tell.
+		return
+	}
+	if ph.reported != nil && *ph.reported == healthy {
+		return
+	}
+
+	var message string
+	if !healthy {
+		message = fmt.Sprintf("payload %q is unhealthy: %v", fullID, ph.lastErr)
+		logger.Warningf("%s", message)
+	} else {
+		logger.Infof("payload %q is healthy", fullID)
+	}
+	if err := c.config.Reporter.ReportHealth(fullID, healthy, message, ph.check.UpdateWorkloadStatus); err != nil {
+		// We'll try again after the next check.
+		logger.Errorf("could not report health of payload %q: %v", fullID, err)
+		return
+	}
+	ph.reported = &healthy
+}
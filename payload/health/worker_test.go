@@ -0,0 +1,171 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package health_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/payload/context"
+	"github.com/juju/juju/payload/health"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	stub    *testing.Stub
+	clock   *coretesting.Clock
+	dataDir string
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.clock = coretesting.NewClock(time.Now())
+	s.dataDir = c.MkDir()
+}
+
+func (s *workerSuite) config() health.Config {
+	return health.Config{
+		DataDir:  s.dataDir,
+		Reporter: &stubReporter{stub: s.stub},
+		Clock:    s.clock,
+		Probe: func(check payload.HealthCheck, abort <-chan struct{}) error {
+			s.stub.AddCall("Probe", check.Command)
+			return s.stub.NextErr()
+		},
+	}
+}
+
+func (s *workerSuite) writeChecks(c *gc.C, checks map[string]payload.HealthCheck) {
+	data, err := json.Marshal(checks)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(s.dataDir, context.HealthChecksFile), data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := health.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		w.Kill()
+		c.Check(w.Wait(), jc.ErrorIsNil)
+	})
+	s.waitPolled(c)
+	return w
+}
+
+// waitPolled waits until the worker has finished a poll and is waiting
+// for the next one.
+func (s *workerSuite) waitPolled(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("worker did not poll")
+	}
+}
+
+func (s *workerSuite) advance(c *gc.C) {
+	s.clock.Advance(health.PollInterval)
+	s.waitPolled(c)
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Reporter = nil
+
+	_, err := health.NewWorker(config)
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil Reporter not valid")
+}
+
+func (s *workerSuite) TestNoChecks(c *gc.C) {
+	s.startWorker(c)
+	s.advance(c)
+
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *workerSuite) TestReportsChanges(c *gc.C) {
+	check := newCommandCheck("pgrep spam")
+	check.Interval = health.PollInterval
+	check.Timeout = time.Second
+	check.Threshold = 2
+	check.UpdateWorkloadStatus = true
+	s.writeChecks(c, map[string]payload.HealthCheck{"spam/id1": check})
+	failure := errors.New("exit status 1")
+	s.stub.SetErrors(
+		failure, // first probe; not yet unhealthy
+		failure, // second probe
+		nil,     // report unhealthy
+		failure, // third probe; already reported
+		nil,     // fourth probe
+		nil,     // report healthy
+	)
+
+	s.startWorker(c)
+	for i := 0; i < 3; i++ {
+		s.advance(c)
+	}
+
+	s.stub.CheckCallNames(c,
+		"Probe",
+		"Probe",
+		"ReportHealth",
+		"Probe",
+		"Probe",
+		"ReportHealth",
+	)
+	s.stub.CheckCall(c, 2, "ReportHealth", "spam/id1", false, `payload "spam/id1" is unhealthy: exit status 1`, true)
+	s.stub.CheckCall(c, 5, "ReportHealth", "spam/id1", true, "", true)
+}
+
+func (s *workerSuite) TestWaitsForInterval(c *gc.C) {
+	check := newCommandCheck("pgrep spam")
+	check.Interval = 3 * health.PollInterval
+	s.writeChecks(c, map[string]payload.HealthCheck{"spam/id1": check})
+
+	s.startWorker(c)
+	s.advance(c)
+	s.advance(c)
+	s.advance(c)
+
+	s.stub.CheckCallNames(c, "Probe", "ReportHealth", "Probe")
+}
+
+func (s *workerSuite) TestRetriesFailedReport(c *gc.C) {
+	check := newCommandCheck("pgrep spam")
+	check.Interval = health.PollInterval
+	s.writeChecks(c, map[string]payload.HealthCheck{"spam/id1": check})
+	s.stub.SetErrors(nil, errors.New("connection lost"))
+
+	s.startWorker(c)
+	s.advance(c)
+	s.advance(c)
+
+	s.stub.CheckCallNames(c, "Probe", "ReportHealth", "Probe", "ReportHealth", "Probe")
+}
+
+type stubReporter struct {
+	stub *testing.Stub
+}
+
+func (r *stubReporter) ReportHealth(fullID string, healthy bool, message string, updateWorkloadStatus bool) error {
+	r.stub.AddCall("ReportHealth", fullID, healthy, message, updateWorkloadStatus)
+	return r.stub.NextErr()
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package payload_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/payload"
+)
+
+var _ = gc.Suite(&healthSuite{})
+
+type healthSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *healthSuite) TestNewHealthCheck(c *gc.C) {
+	hc := payload.NewHealthCheck()
+
+	c.Check(hc, jc.DeepEquals, payload.HealthCheck{
+		Interval:  payload.DefaultHealthInterval,
+		Timeout:   payload.DefaultHealthTimeout,
+		Threshold: payload.DefaultHealthThreshold,
+	})
+}
+
+func (s *healthSuite) TestValidateCommand(c *gc.C) {
+	hc := payload.NewHealthCheck()
+	hc.Command = "pgrep spam"
+
+	err := hc.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *healthSuite) TestValidateURL(c *gc.C) {
+	hc := payload.NewHealthCheck()
+	hc.URL = "http://localhost:8080/health"
+
+	err := hc.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *healthSuite) TestValidateBad(c *gc.C) {
+	for i, test := range []struct {
+		about  string
+		modify func(*payload.HealthCheck)
+		err    string
+	}{{
+		about:  "no command or URL",
+		modify: func(hc *payload.HealthCheck) {},
+		err:    "health check without command or URL not valid",
+	}, {
+		about: "both command and URL",
+		modify: func(hc *payload.HealthCheck) {
+			hc.Command = "true"
+			hc.URL = "http://localhost/"
+		},
+		err: "health check with both command and URL not valid",
+	}, {
+		about: "unsupported scheme",
+		modify: func(hc *payload.HealthCheck) {
+			hc.URL = "ftp://localhost/"
+		},
+		err: `health check URL scheme "ftp" not valid`,
+	}, {
+		about: "zero interval",
+		modify: func(hc *payload.HealthCheck) {
+			hc.Command = "true"
+			hc.Interval = 0
+		},
+		err: "health check interval 0s not valid",
+	}, {
+		about: "timeout longer than interval",
+		modify: func(hc *payload.HealthCheck) {
+			hc.Command = "true"
+			hc.Timeout = time.Hour
+		},
+		err: `health check timeout 1h0m0s \(interval 30s\) not valid`,
+	}, {
+		about: "zero threshold",
+		modify: func(hc *payload.HealthCheck) {
+			hc.Command = "true"
+			hc.Threshold = 0
+		},
+		err: "health check threshold 0 not valid",
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		hc := payload.NewHealthCheck()
+		test.modify(&hc)
+
+		err := hc.Validate()
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -1075,18 +1075,18 @@ var errNoFwGlobal = errors.New("global firewall mode is not supported")
 
 // OpenPorts is specified in the Environ interface. However, Azure does not
 // support the global firewall mode.
-func (env *azureEnviron) OpenPorts(ports []jujunetwork.PortRange) error {
+func (env *azureEnviron) OpenPorts(rules []jujunetwork.IngressRule) error {
 	return errNoFwGlobal
 }
 
 // ClosePorts is specified in the Environ interface. However, Azure does not
 // support the global firewall mode.
-func (env *azureEnviron) ClosePorts(ports []jujunetwork.PortRange) error {
+func (env *azureEnviron) ClosePorts(rules []jujunetwork.IngressRule) error {
 	return errNoFwGlobal
 }
 
-// Ports is specified in the Environ interface.
-func (env *azureEnviron) Ports() ([]jujunetwork.PortRange, error) {
+// IngressRules is specified in the Environ interface.
+func (env *azureEnviron) IngressRules() ([]jujunetwork.IngressRule, error) {
 	return nil, errNoFwGlobal
 }
 
@@ -181,7 +181,7 @@ func (inst *azureInstance) internalNetworkAddress() (jujunetwork.Address, error)
 }
 
 // OpenPorts is specified in the Instance interface.
-func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortRange) error {
+func (inst *azureInstance) OpenPorts(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
@@ -209,70 +209,77 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 	// NSG in memory, so we can easily tell which priorities are available.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-
-		// Check if the rule already exists; OpenPorts must be idempotent.
-		var found bool
-		for _, rule := range securityRules {
-			if to.String(rule.Name) == ruleName {
-				found = true
-				break
+	for _, ingressRule := range rules {
+		ports := ingressRule.PortRange
+		for _, sourcePrefix := range securityRuleSourcePrefixes(ingressRule) {
+			ruleName := securityRuleName(prefix, ports, sourcePrefix)
+
+			// Check if the rule already exists; OpenPorts must be idempotent.
+			var found bool
+			for _, rule := range securityRules {
+				if to.String(rule.Name) == ruleName {
+					found = true
+					break
+				}
 			}
-		}
-		if found {
-			logger.Debugf("security rule %q already exists", ruleName)
-			continue
-		}
-		logger.Debugf("creating security rule %q", ruleName)
+			if found {
+				logger.Debugf("security rule %q already exists", ruleName)
+				continue
+			}
+			logger.Debugf("creating security rule %q", ruleName)
 
-		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
-		if err != nil {
-			return errors.Annotatef(err, "getting security rule priority for %s", ports)
-		}
+			priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
+			if err != nil {
+				return errors.Annotatef(err, "getting security rule priority for %s", ports)
+			}
 
-		var protocol network.SecurityRuleProtocol
-		switch ports.Protocol {
-		case "tcp":
-			protocol = network.SecurityRuleProtocolTCP
-		case "udp":
-			protocol = network.SecurityRuleProtocolUDP
-		default:
-			return errors.Errorf("invalid protocol %q", ports.Protocol)
-		}
+			var protocol network.SecurityRuleProtocol
+			switch ports.Protocol {
+			case "tcp":
+				protocol = network.SecurityRuleProtocolTCP
+			case "udp":
+				protocol = network.SecurityRuleProtocolUDP
+			default:
+				return errors.Errorf("invalid protocol %q", ports.Protocol)
+			}
 
-		var portRange string
-		if ports.FromPort != ports.ToPort {
-			portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
-		} else {
-			portRange = fmt.Sprint(ports.FromPort)
-		}
+			var portRange string
+			if ports.FromPort != ports.ToPort {
+				portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
+			} else {
+				portRange = fmt.Sprint(ports.FromPort)
+			}
 
-		rule := network.SecurityRule{
-			Properties: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(ports.String()),
-				Protocol:                 protocol,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr(portRange),
-				SourceAddressPrefix:      to.StringPtr("*"),
-				DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
-				Access:    network.Allow,
-				Priority:  to.IntPtr(priority),
-				Direction: network.Inbound,
-			},
-		}
-		if _, err := securityRuleClient.CreateOrUpdate(
-			inst.env.resourceGroup, securityGroupName, ruleName, rule,
-		); err != nil {
-			return errors.Annotatef(err, "creating security rule for %s", ports)
+			description := ports.String()
+			if sourcePrefix != "*" {
+				description += " from " + sourcePrefix
+			}
+			rule := network.SecurityRule{
+				Properties: &network.SecurityRulePropertiesFormat{
+					Description:              to.StringPtr(description),
+					Protocol:                 protocol,
+					SourcePortRange:          to.StringPtr("*"),
+					DestinationPortRange:     to.StringPtr(portRange),
+					SourceAddressPrefix:      to.StringPtr(sourcePrefix),
+					DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
+					Access:    network.Allow,
+					Priority:  to.IntPtr(priority),
+					Direction: network.Inbound,
+				},
+			}
+			if _, err := securityRuleClient.CreateOrUpdate(
+				inst.env.resourceGroup, securityGroupName, ruleName, rule,
+			); err != nil {
+				return errors.Annotatef(err, "creating security rule for %s", ports)
+			}
+			securityRules = append(securityRules, rule)
 		}
-		securityRules = append(securityRules, rule)
 	}
 	return nil
 }
 
 // ClosePorts is specified in the Instance interface.
-func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+func (inst *azureInstance) ClosePorts(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -282,23 +289,25 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	// on changes made by the provisioner.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-		logger.Debugf("deleting security rule %q", ruleName)
-		result, err := securityRuleClient.Delete(
-			inst.env.resourceGroup, securityGroupName, ruleName,
-		)
-		if err != nil {
-			if result.Response == nil || result.StatusCode != http.StatusNotFound {
-				return errors.Annotatef(err, "deleting security rule %q", ruleName)
+	for _, ingressRule := range rules {
+		for _, sourcePrefix := range securityRuleSourcePrefixes(ingressRule) {
+			ruleName := securityRuleName(prefix, ingressRule.PortRange, sourcePrefix)
+			logger.Debugf("deleting security rule %q", ruleName)
+			result, err := securityRuleClient.Delete(
+				inst.env.resourceGroup, securityGroupName, ruleName,
+			)
+			if err != nil {
+				if result.Response == nil || result.StatusCode != http.StatusNotFound {
+					return errors.Annotatef(err, "deleting security rule %q", ruleName)
+				}
 			}
 		}
 	}
 	return nil
 }
 
-// Ports is specified in the Instance interface.
-func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRange, err error) {
+// IngressRules is specified in the Instance interface.
+func (inst *azureInstance) IngressRules(machineId string) (rules []jujunetwork.IngressRule, err error) {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -312,6 +321,10 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		return nil, nil
 	}
 
+	// Security rules have a single source address prefix each, so the
+	// rules for the same port range are combined into one ingress rule.
+	var portRanges []jujunetwork.PortRange
+	sourceCIDRs := make(map[jujunetwork.PortRange][]string)
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
 	for _, rule := range *nsg.Properties.SecurityRules {
@@ -344,6 +357,11 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 			}
 		}
 
+		sourceCIDR := to.String(rule.Properties.SourceAddressPrefix)
+		if sourceCIDR == "" || sourceCIDR == "*" {
+			sourceCIDR = jujunetwork.OpenCIDR
+		}
+
 		var protocols []string
 		switch rule.Properties.Protocol {
 		case network.SecurityRuleProtocolTCP:
@@ -355,10 +373,23 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		}
 		for _, protocol := range protocols {
 			portRange.Protocol = protocol
-			ports = append(ports, portRange)
+			if _, ok := sourceCIDRs[portRange]; !ok {
+				portRanges = append(portRanges, portRange)
+			}
+			sourceCIDRs[portRange] = append(sourceCIDRs[portRange], sourceCIDR)
+		}
+	}
+	for _, portRange := range portRanges {
+		rule, err := jujunetwork.NewIngressRule(
+			portRange.Protocol, portRange.FromPort, portRange.ToPort,
+			sourceCIDRs[portRange]...,
+		)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing source address prefixes for %s", portRange)
 		}
+		rules = append(rules, rule)
 	}
-	return ports, nil
+	return rules, nil
 }
 
 // deleteInstanceNetworkSecurityRules deletes network security rules in the
@@ -405,12 +436,27 @@ func instanceNetworkSecurityRulePrefix(id instance.Id) string {
 	return string(id) + "-"
 }
 
-// securityRuleName returns the security rule name for the given port range,
-// and prefix returned by instanceNetworkSecurityRulePrefix.
-func securityRuleName(prefix string, ports jujunetwork.PortRange) string {
+// securityRuleName returns the security rule name for the given port range
+// and source address prefix, and prefix returned by
+// instanceNetworkSecurityRulePrefix.
+func securityRuleName(prefix string, ports jujunetwork.PortRange, sourcePrefix string) string {
 	ruleName := fmt.Sprintf("%s%s-%d", prefix, ports.Protocol, ports.FromPort)
 	if ports.FromPort != ports.ToPort {
 		ruleName += fmt.Sprintf("-%d", ports.ToPort)
 	}
+	if sourcePrefix != "*" {
+		// Security rule names may not contain slashes.
+		ruleName += "_" + strings.Replace(sourcePrefix, "/", "-", -1)
+	}
 	return ruleName
 }
+
+// securityRuleSourcePrefixes returns the source address prefixes of the
+// security rules that implement the given ingress rule: one for each of
+// its source CIDRs, or "*" if it allows traffic from anywhere.
+func securityRuleSourcePrefixes(rule jujunetwork.IngressRule) []string {
+	if rule.IsOpen() {
+		return []string{"*"}
+	}
+	return rule.SourceCIDRs
+}
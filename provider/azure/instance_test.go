@@ -243,16 +243,16 @@ func (s *instanceSuite) TestMultipleInstanceAddresses(c *gc.C) {
 	))
 }
 
-func (s *instanceSuite) TestInstancePortsEmpty(c *gc.C) {
+func (s *instanceSuite) TestInstanceIngressRulesEmpty(c *gc.C) {
 	inst := s.getInstance(c)
 	nsgSender := networkSecurityGroupSender(nil)
 	s.sender = azuretesting.Senders{nsgSender}
-	ports, err := inst.Ports("0")
+	rules, err := inst.IngressRules("0")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.HasLen, 0)
+	c.Assert(rules, gc.HasLen, 0)
 }
 
-func (s *instanceSuite) TestInstancePorts(c *gc.C) {
+func (s *instanceSuite) TestInstanceIngressRules(c *gc.C) {
 	inst := s.getInstance(c)
 	nsgSender := networkSecurityGroupSender([]network.SecurityRule{{
 		Name: to.StringPtr("machine-0-xyzzy"),
@@ -320,25 +320,46 @@ func (s *instanceSuite) TestInstancePorts(c *gc.C) {
 	}})
 	s.sender = azuretesting.Senders{nsgSender}
 
-	ports, err := inst.Ports("0")
+	rules, err := inst.IngressRules("0")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, jc.DeepEquals, []jujunetwork.PortRange{{
-		FromPort: 0,
-		ToPort:   65535,
-		Protocol: "udp",
-	}, {
-		FromPort: 1000,
-		ToPort:   2000,
-		Protocol: "tcp",
-	}, {
-		FromPort: 80,
-		ToPort:   80,
-		Protocol: "tcp",
+	c.Assert(rules, jc.DeepEquals, []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("udp", 0, 65535),
+		jujunetwork.MustNewIngressRule("tcp", 1000, 2000),
+		jujunetwork.MustNewIngressRule("tcp", 80, 80),
+		jujunetwork.MustNewIngressRule("udp", 80, 80),
+	})
+}
+
+func (s *instanceSuite) TestInstanceIngressRulesSourceCIDRs(c *gc.C) {
+	inst := s.getInstance(c)
+	nsgSender := networkSecurityGroupSender([]network.SecurityRule{{
+		Name: to.StringPtr("machine-0-tcp-80_10.0.0.0-8"),
+		Properties: &network.SecurityRulePropertiesFormat{
+			Protocol:             network.SecurityRuleProtocolTCP,
+			DestinationPortRange: to.StringPtr("80"),
+			SourceAddressPrefix:  to.StringPtr("10.0.0.0/8"),
+			Access:               network.Allow,
+			Priority:             to.IntPtr(200),
+			Direction:            network.Inbound,
+		},
 	}, {
-		FromPort: 80,
-		ToPort:   80,
-		Protocol: "udp",
+		Name: to.StringPtr("machine-0-tcp-80_192.168.0.0-16"),
+		Properties: &network.SecurityRulePropertiesFormat{
+			Protocol:             network.SecurityRuleProtocolTCP,
+			DestinationPortRange: to.StringPtr("80"),
+			SourceAddressPrefix:  to.StringPtr("192.168.0.0/16"),
+			Access:               network.Allow,
+			Priority:             to.IntPtr(201),
+			Direction:            network.Inbound,
+		},
 	}})
+	s.sender = azuretesting.Senders{nsgSender}
+
+	rules, err := inst.IngressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.0.0/16"),
+	})
 }
 
 func (s *instanceSuite) TestInstanceClosePorts(c *gc.C) {
@@ -348,15 +369,10 @@ func (s *instanceSuite) TestInstanceClosePorts(c *gc.C) {
 	notFoundSender.EmitStatus("rule not found", http.StatusNotFound)
 	s.sender = azuretesting.Senders{sender, notFoundSender}
 
-	err := inst.ClosePorts("0", []jujunetwork.PortRange{{
-		Protocol: "tcp",
-		FromPort: 1000,
-		ToPort:   1000,
-	}, {
-		Protocol: "udp",
-		FromPort: 1000,
-		ToPort:   2000,
-	}})
+	err := inst.ClosePorts("0", []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
+		jujunetwork.MustNewIngressRule("udp", 1000, 2000),
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(s.requests, gc.HasLen, 2)
@@ -390,15 +406,10 @@ func (s *instanceSuite) TestInstanceOpenPorts(c *gc.C) {
 	nsgSender := networkSecurityGroupSender(nil)
 	s.sender = azuretesting.Senders{nsgSender, okSender, okSender}
 
-	err := inst.OpenPorts("0", []jujunetwork.PortRange{{
-		Protocol: "tcp",
-		FromPort: 1000,
-		ToPort:   1000,
-	}, {
-		Protocol: "udp",
-		FromPort: 1000,
-		ToPort:   2000,
-	}})
+	err := inst.OpenPorts("0", []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
+		jujunetwork.MustNewIngressRule("udp", 1000, 2000),
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(s.requests, gc.HasLen, 3)
@@ -469,15 +480,10 @@ func (s *instanceSuite) TestInstanceOpenPortsAlreadyOpen(c *gc.C) {
 	}})
 	s.sender = azuretesting.Senders{nsgSender, okSender, okSender}
 
-	err := inst.OpenPorts("0", []jujunetwork.PortRange{{
-		Protocol: "tcp",
-		FromPort: 1000,
-		ToPort:   1000,
-	}, {
-		Protocol: "udp",
-		FromPort: 1000,
-		ToPort:   2000,
-	}})
+	err := inst.OpenPorts("0", []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("tcp", 1000, 1000),
+		jujunetwork.MustNewIngressRule("udp", 1000, 2000),
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(s.requests, gc.HasLen, 2)
@@ -500,6 +506,53 @@ func (s *instanceSuite) TestInstanceOpenPortsAlreadyOpen(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestInstanceOpenPortsSourceCIDRs(c *gc.C) {
+	internalSubnetId := path.Join(
+		"/subscriptions", fakeSubscriptionId,
+		"resourceGroups/arbitrary/providers/Microsoft.Network/virtualnetworks/juju-internal/subnets",
+		"juju-testenv-model-"+testing.ModelTag.Id(),
+	)
+	ipConfiguration := network.InterfaceIPConfiguration{
+		Properties: &network.InterfaceIPConfigurationPropertiesFormat{
+			PrivateIPAddress: to.StringPtr("10.0.0.4"),
+			Subnet: &network.SubResource{
+				ID: to.StringPtr(internalSubnetId),
+			},
+		},
+	}
+	s.networkInterfaces = []network.Interface{
+		makeNetworkInterface("nic-0", "machine-0", ipConfiguration),
+	}
+
+	inst := s.getInstance(c)
+	okSender := mocks.NewSender()
+	okSender.EmitContent("{}")
+	nsgSender := networkSecurityGroupSender(nil)
+	s.sender = azuretesting.Senders{nsgSender, okSender}
+
+	err := inst.OpenPorts("0", []jujunetwork.IngressRule{
+		jujunetwork.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Method, gc.Equals, "PUT")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, securityRulePath("machine-0-tcp-80_10.0.0.0-8"))
+	assertRequestBody(c, s.requests[1], &network.SecurityRule{
+		Properties: &network.SecurityRulePropertiesFormat{
+			Description:              to.StringPtr("80/tcp from 10.0.0.0/8"),
+			Protocol:                 network.SecurityRuleProtocolTCP,
+			SourcePortRange:          to.StringPtr("*"),
+			SourceAddressPrefix:      to.StringPtr("10.0.0.0/8"),
+			DestinationPortRange:     to.StringPtr("80"),
+			DestinationAddressPrefix: to.StringPtr("10.0.0.4"),
+			Access:    network.Allow,
+			Priority:  to.IntPtr(200),
+			Direction: network.Inbound,
+		},
+	})
+}
+
 func (s *instanceSuite) TestInstanceOpenPortsNoInternalAddress(c *gc.C) {
 	err := s.getInstance(c).OpenPorts("0", nil)
 	c.Assert(err, gc.ErrorMatches, "internal network address not found")
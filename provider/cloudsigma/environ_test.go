@@ -79,7 +79,7 @@ func (s *environSuite) TestBase(c *gc.C) {
 	c.Check(env.OpenPorts(nil), gc.IsNil)
 	c.Check(env.ClosePorts(nil), gc.IsNil)
 
-	ports, err := env.Ports()
-	c.Check(ports, gc.IsNil)
+	rules, err := env.IngressRules()
+	c.Check(rules, gc.IsNil)
 	c.Check(err, gc.IsNil)
 }
@@ -9,22 +9,22 @@ import "github.com/juju/juju/network"
 // cause `juju expose` to work when the firewall-mode is "global". If you
 // implement one of them, you should implement them all.
 
-// OpenPorts opens the given ports for the whole environment.
+// OpenPorts opens the given ingress rules for the whole environment.
 // Must only be used if the environment was setup with the FwGlobal firewall mode.
-func (env *environ) OpenPorts(ports []network.PortRange) error {
-	logger.Warningf("pretending to open ports %v for all instances", ports)
+func (env *environ) OpenPorts(rules []network.IngressRule) error {
+	logger.Warningf("pretending to open ingress rules %v for all instances", rules)
 	return nil
 }
 
-// ClosePorts closes the given ports for the whole environment.
+// ClosePorts closes the given ingress rules for the whole environment.
 // Must only be used if the environment was setup with the FwGlobal firewall mode.
-func (env *environ) ClosePorts(ports []network.PortRange) error {
-	logger.Warningf("pretending to close ports %v for all instances", ports)
+func (env *environ) ClosePorts(rules []network.IngressRule) error {
+	logger.Warningf("pretending to close ingress rules %v for all instances", rules)
 	return nil
 }
 
-// Ports returns the ports opened for the whole environment.
+// IngressRules returns the ingress rules opened for the whole environment.
 // Must only be used if the environment was setup with the FwGlobal firewall mode.
-func (env *environ) Ports() ([]network.PortRange, error) {
+func (env *environ) IngressRules() ([]network.IngressRule, error) {
 	return nil, nil
 }
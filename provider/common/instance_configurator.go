@@ -25,11 +25,11 @@ type InstanceConfigurator interface {
 	// Implementations should also configure this interface and initialise  ports state.
 	ConfigureExternalIpAddress(apiPort int) error
 
-	// Open or close ports.
-	ChangePorts(ipAddress string, insert bool, ports []network.PortRange) error
+	// Open or close ports, allowing traffic from the rules' source CIDRs.
+	ChangeIngressRules(ipAddress string, insert bool, rules []network.IngressRule) error
 
-	// List all opened ports.
-	FindOpenPorts() ([]network.PortRange, error)
+	// List all ingress rules.
+	FindIngressRules() ([]network.IngressRule, error)
 
 	// Add Ip address.
 	AddIpAddress(nic string, addr string) error
@@ -93,19 +93,28 @@ sudo iptables -i eth1 -I INPUT -m state --state NEW -j DROP`
 	return nil
 }
 
-// ChangePorts implements InstanceConfigurator interface.
-func (c *sshInstanceConfigurator) ChangePorts(ipAddress string, insert bool, ports []network.PortRange) error {
+// ChangeIngressRules implements InstanceConfigurator interface.
+func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bool, rules []network.IngressRule) error {
 	cmd := ""
 	insertArg := "-I"
 	if !insert {
 		insertArg = "-D"
 	}
-	for _, port := range ports {
-		if port.ToPort-port.FromPort > 0 {
-			cmd += fmt.Sprintf("sudo iptables -d %s %s INPUT -p %s --match multiport --dports %d:%d -j ACCEPT\n", ipAddress, insertArg, port.Protocol, port.FromPort, port.ToPort)
-		} else {
+	for _, rule := range rules {
+		sourceArgs := []string{""}
+		if !rule.IsOpen() {
+			sourceArgs = nil
+			for _, cidr := range rule.SourceCIDRs {
+				sourceArgs = append(sourceArgs, fmt.Sprintf(" -s %s", cidr))
+			}
+		}
+		for _, sourceArg := range sourceArgs {
+			if rule.ToPort-rule.FromPort > 0 {
+				cmd += fmt.Sprintf("sudo iptables -d %s%s %s INPUT -p %s --match multiport --dports %d:%d -j ACCEPT\n", ipAddress, sourceArg, insertArg, rule.Protocol, rule.FromPort, rule.ToPort)
+			} else {
 
-			cmd += fmt.Sprintf("sudo iptables -d %s %s INPUT -p %s --dport %d -j ACCEPT\n", ipAddress, insertArg, port.Protocol, port.FromPort)
+				cmd += fmt.Sprintf("sudo iptables -d %s%s %s INPUT -p %s --dport %d -j ACCEPT\n", ipAddress, sourceArg, insertArg, rule.Protocol, rule.FromPort)
+			}
 		}
 	}
 	cmd += "sudo /etc/init.d/iptables-persistent save\n"
@@ -119,8 +128,8 @@ func (c *sshInstanceConfigurator) ChangePorts(ipAddress string, insert bool, por
 	return nil
 }
 
-// FindOpenPorts implements InstanceConfigurator interface.
-func (c *sshInstanceConfigurator) FindOpenPorts() ([]network.PortRange, error) {
+// FindIngressRules implements InstanceConfigurator interface.
+func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, error) {
 	cmd := "sudo iptables -L INPUT -n"
 	command := c.client.Command(c.host, []string{"/bin/bash"}, c.options)
 	command.Stdin = strings.NewReader(cmd)
@@ -136,7 +145,14 @@ func (c *sshInstanceConfigurator) FindOpenPorts() ([]network.PortRange, error) {
 	//ACCEPT     tcp  --  0.0.0.0/0            192.168.0.1  multiport dports 3456:3458
 	//ACCEPT     tcp  --  0.0.0.0/0            192.168.0.2  tcp dpt:12345
 
-	res := make([]network.PortRange, 0)
+	var portRanges []network.PortRange
+	sourceCIDRs := make(map[network.PortRange][]string)
+	var addPortRange = func(portRange network.PortRange, source string) {
+		if _, ok := sourceCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		sourceCIDRs[portRange] = append(sourceCIDRs[portRange], source)
+	}
 	var addSinglePortRange = func(items []string) {
 		ports := strings.Split(items[6], ":")
 		if len(ports) != 2 {
@@ -147,11 +163,11 @@ func (c *sshInstanceConfigurator) FindOpenPorts() ([]network.PortRange, error) {
 			return
 		}
 
-		res = append(res, network.PortRange{
+		addPortRange(network.PortRange{
 			Protocol: items[1],
 			FromPort: int(to),
 			ToPort:   int(to),
-		})
+		}, items[3])
 	}
 	var addMultiplePortRange = func(items []string) {
 		ports := strings.Split(items[7], ":")
@@ -167,11 +183,11 @@ func (c *sshInstanceConfigurator) FindOpenPorts() ([]network.PortRange, error) {
 			return
 		}
 
-		res = append(res, network.PortRange{
+		addPortRange(network.PortRange{
 			Protocol: items[1],
 			FromPort: int(from),
 			ToPort:   int(to),
-		})
+		}, items[3])
 	}
 
 	for i, line := range strings.Split(string(output), "\n") {
@@ -179,13 +195,25 @@ func (c *sshInstanceConfigurator) FindOpenPorts() ([]network.PortRange, error) {
 			continue
 		}
 		items := strings.Split(line, " ")
-		if len(items) == 7 && items[0] == "ACCEPT" && items[3] == "0.0.0.0/0" {
+		if len(items) == 7 && items[0] == "ACCEPT" {
 			addSinglePortRange(items)
 		}
-		if len(items) == 8 && items[0] == "ACCEPT" && items[3] == "0.0.0.0/0" && items[5] != "multiport" && items[6] != "dports" {
+		if len(items) == 8 && items[0] == "ACCEPT" && items[5] != "multiport" && items[6] != "dports" {
 			addMultiplePortRange(items)
 		}
 	}
+	res := make([]network.IngressRule, 0, len(portRanges))
+	for _, portRange := range portRanges {
+		rule, err := network.NewIngressRule(
+			portRange.Protocol, portRange.FromPort, portRange.ToPort,
+			sourceCIDRs[portRange]...,
+		)
+		if err != nil {
+			logger.Warningf("skipping unexpected ingress rule for %v: %v", portRange, err)
+			continue
+		}
+		res = append(res, rule)
+	}
 	return res, nil
 }
 
@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"fmt"
 	"regexp"
 	"sync"
 	"time"
@@ -55,6 +56,7 @@ const (
 	deviceInUse        = "InvalidDevice.InUse"
 	volumeInUse        = "VolumeInUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 	incorrectState     = "IncorrectState"
 )
 
@@ -240,6 +242,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -337,6 +340,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -536,6 +540,89 @@ func (v *ebsVolumeSource) destroyVolume(volumeId string) error {
 	return nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter
+// interface.
+//
+// EBS snapshots are point-in-time as of the request, and volumes may be
+// created from them while they are still pending. A snapshot of a volume
+// that is in use is only crash-consistent.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume snapshot")
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createVolumeSnapshot(p storage.VolumeSnapshotParams) (_ *storage.VolumeSnapshot, err error) {
+	name := storage.VolumeSnapshotName(p.Id)
+	resp, err := v.ec2.CreateSnapshot(p.VolumeId, resourceName(p.Volume, v.envName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotId := resp.Snapshot.Id
+	defer func() {
+		if err == nil {
+			return
+		}
+		if _, err := v.ec2.DeleteSnapshots([]string{snapshotId}); err != nil {
+			logger.Warningf("error cleaning up snapshot %v: %v", snapshotId, err)
+		}
+	}()
+
+	// Tag the snapshot with the model UUID, so that ListVolumeSnapshots
+	// can tell which snapshots belong to the model.
+	resourceTags := map[string]string{
+		tags.JujuModel: v.modelUUID,
+		tagName:        fmt.Sprintf("juju-%s-%s", v.envName, name),
+	}
+	if err := tagResources(v.ec2, resourceTags, snapshotId); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	return &storage.VolumeSnapshot{
+		p.Id,
+		p.Volume,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       p.Size,
+		},
+	}, nil
+}
+
+// ListVolumeSnapshots is specified on the storage.VolumeSnapshotter
+// interface.
+func (v *ebsVolumeSource) ListVolumeSnapshots() ([]string, error) {
+	filter := ec2.NewFilter()
+	filter.Add("tag:"+tags.JujuModel, v.modelUUID)
+	resp, err := v.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	snapshotIds := make([]string, len(resp.Snapshots))
+	for i, snapshot := range resp.Snapshots {
+		snapshotIds[i] = snapshot.Id
+	}
+	return snapshotIds, nil
+}
+
+// DestroyVolumeSnapshots is specified on the storage.VolumeSnapshotter
+// interface.
+func (v *ebsVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		_, err := v.ec2.DeleteSnapshots([]string{snapshotId})
+		if err != nil && ec2ErrCode(err) != snapshotNotFound {
+			results[i] = errors.Annotatef(err, "destroying snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
 // ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	vol, err := parseVolumeOptions(params.Size, params.Attributes)
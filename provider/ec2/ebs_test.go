@@ -391,6 +391,12 @@ func (s *ebsVolumeSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	c.Assert(vols[0].Error, gc.ErrorMatches, "vol-42 not found")
 }
 
+func (s *ebsVolumeSuite) TestVolumeSourceSupportsSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	_, ok := vs.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+}
+
 func (s *ebsVolumeSuite) TestListVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
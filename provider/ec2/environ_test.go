@@ -92,18 +92,14 @@ func pInt(i uint64) *uint64 {
 	return &i
 }
 
-func (*Suite) TestPortsToIPPerms(c *gc.C) {
+func (*Suite) TestRulesToIPPerms(c *gc.C) {
 	testCases := []struct {
 		about    string
-		ports    []network.PortRange
+		rules    []network.IngressRule
 		expected []amzec2.IPPerm
 	}{{
 		about: "single port",
-		ports: []network.PortRange{{
-			FromPort: 80,
-			ToPort:   80,
-			Protocol: "tcp",
-		}},
+		rules: []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)},
 		expected: []amzec2.IPPerm{{
 			Protocol:  "tcp",
 			FromPort:  80,
@@ -112,11 +108,7 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 		}},
 	}, {
 		about: "multiple ports",
-		ports: []network.PortRange{{
-			FromPort: 80,
-			ToPort:   82,
-			Protocol: "tcp",
-		}},
+		rules: []network.IngressRule{network.MustNewIngressRule("tcp", 80, 82)},
 		expected: []amzec2.IPPerm{{
 			Protocol:  "tcp",
 			FromPort:  80,
@@ -125,15 +117,10 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 		}},
 	}, {
 		about: "multiple port ranges",
-		ports: []network.PortRange{{
-			FromPort: 80,
-			ToPort:   82,
-			Protocol: "tcp",
-		}, {
-			FromPort: 100,
-			ToPort:   120,
-			Protocol: "tcp",
-		}},
+		rules: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 82),
+			network.MustNewIngressRule("tcp", 100, 120),
+		},
 		expected: []amzec2.IPPerm{{
 			Protocol:  "tcp",
 			FromPort:  80,
@@ -145,11 +132,22 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 			ToPort:    120,
 			SourceIPs: []string{"0.0.0.0/0"},
 		}},
+	}, {
+		about: "source CIDRs",
+		rules: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16", "10.0.0.0/8"),
+		},
+		expected: []amzec2.IPPerm{{
+			Protocol:  "tcp",
+			FromPort:  80,
+			ToPort:    80,
+			SourceIPs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		}},
 	}}
 
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
-		ipperms := portsToIPPerms(t.ports)
+		ipperms := rulesToIPPerms(t.rules)
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
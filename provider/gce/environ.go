@@ -26,9 +26,9 @@ type gceConnection interface {
 	AddInstance(spec google.InstanceSpec, zones ...string) (*google.Instance, error)
 	RemoveInstances(prefix string, ids ...string) error
 
-	Ports(fwname string) ([]network.PortRange, error)
-	OpenPorts(fwname string, ports ...network.PortRange) error
-	ClosePorts(fwname string, ports ...network.PortRange) error
+	IngressRules(fwname string) ([]network.IngressRule, error)
+	OpenPorts(fwname string, rules ...network.IngressRule) error
+	ClosePorts(fwname string, rules ...network.IngressRule) error
 
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 
@@ -165,13 +165,13 @@ var destroyEnv = common.Destroy
 // Destroy shuts down all known machines and destroys the rest of the
 // known environment.
 func (env *environ) Destroy() error {
-	ports, err := env.Ports()
+	rules, err := env.IngressRules()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	if len(ports) > 0 {
-		if err := env.ClosePorts(ports); err != nil {
+	if len(rules) > 0 {
+		if err := env.ClosePorts(rules); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -67,12 +67,12 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 	// on the network, not just for the specific node of the state
 	// server). See LP bug #1436191 for details.
 	if isController(args.InstanceConfig) {
-		ports := network.PortRange{
+		rule := network.NewOpenIngressRule(network.PortRange{
 			FromPort: args.InstanceConfig.StateServingInfo.APIPort,
 			ToPort:   args.InstanceConfig.StateServingInfo.APIPort,
 			Protocol: "tcp",
-		}
-		if err := env.gce.OpenPorts(env.globalFirewallName(), ports); err != nil {
+		})
+		if err := env.gce.OpenPorts(env.globalFirewallName(), rule); err != nil {
 			return nil, errors.Trace(err)
 		}
 	}
@@ -15,26 +15,26 @@ func (env *environ) globalFirewallName() string {
 	return common.EnvFullName(env)
 }
 
-// OpenPorts opens the given port ranges for the whole environment.
+// OpenPorts opens the given ingress rules for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) OpenPorts(ports []network.PortRange) error {
-	err := env.gce.OpenPorts(env.globalFirewallName(), ports...)
+func (env *environ) OpenPorts(rules []network.IngressRule) error {
+	err := env.gce.OpenPorts(env.globalFirewallName(), rules...)
 	return errors.Trace(err)
 }
 
-// ClosePorts closes the given port ranges for the whole environment.
+// ClosePorts closes the given ingress rules for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) ClosePorts(ports []network.PortRange) error {
-	err := env.gce.ClosePorts(env.globalFirewallName(), ports...)
+func (env *environ) ClosePorts(rules []network.IngressRule) error {
+	err := env.gce.ClosePorts(env.globalFirewallName(), rules...)
 	return errors.Trace(err)
 }
 
-// Ports returns the port ranges opened for the whole environment.
+// IngressRules returns the ingress rules opened for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) Ports() ([]network.PortRange, error) {
-	ports, err := env.gce.Ports(env.globalFirewallName())
-	return ports, errors.Trace(err)
+func (env *environ) IngressRules() ([]network.IngressRule, error) {
+	rules, err := env.gce.IngressRules(env.globalFirewallName())
+	return rules, errors.Trace(err)
 }
@@ -31,10 +31,10 @@ type rawConnectionWrapper interface {
 	// with the provided ID (in the specified zone). The call blocks until
 	// the instance is removed (or the request fails).
 	RemoveInstance(projectID, id, zone string) error
-	// GetFirewall sends an API request to GCE for the information about
-	// the named firewall and returns it. If the firewall is not found,
-	// errors.NotFound is returned.
-	GetFirewall(projectID, name string) (*compute.Firewall, error)
+	// GetFirewalls sends an API request to GCE for the information about
+	// the firewalls whose names start with the provided prefix and
+	// returns them. If none are found the list is empty.
+	GetFirewalls(projectID, prefix string) ([]*compute.Firewall, error)
 	// AddFirewall requests GCE to add a firewall with the provided info.
 	// If the firewall already exists then an error will be returned.
 	// The call blocks until the firewall is added or the request fails.
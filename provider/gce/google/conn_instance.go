@@ -102,11 +102,7 @@ func (gce *Connection) removeInstance(id, zone string) error {
 	}
 
 	fwname := id
-	err = gce.raw.RemoveFirewall(gce.projectID, fwname)
-	if errors.IsNotFound(err) {
-		return nil
-	}
-	if err != nil {
+	if err := gce.removeFirewalls(fwname); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
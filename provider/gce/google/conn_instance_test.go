@@ -4,6 +4,8 @@
 package google_test
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"google.golang.org/api/compute/v1"
@@ -206,17 +208,32 @@ func (s *connSuite) TestConnectionRemoveInstance(c *gc.C) {
 }
 
 func (s *connSuite) TestConnectionRemoveInstanceAPI(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		SourceRanges: []string{"0.0.0.0/0"},
+	}, {
+		Name:         google.FirewallName("spam", []string{"10.0.0.0/8"}),
+		SourceRanges: []string{"10.0.0.0/8"},
+	}}
+
 	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "a-zone")
 	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "GetFirewalls")
 	c.Check(s.FakeConn.Calls[1].ProjectID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam")
+	removed := []string{s.FakeConn.Calls[2].Name, s.FakeConn.Calls[3].Name}
+	sort.Strings(removed)
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(removed, jc.DeepEquals, []string{
+		"spam", google.FirewallName("spam", []string{"10.0.0.0/8"}),
+	})
 }
 
 func (s *connSuite) TestConnectionRemoveInstanceFailed(c *gc.C) {
@@ -249,16 +266,19 @@ func (s *connSuite) TestConnectionRemoveInstances(c *gc.C) {
 
 func (s *connSuite) TestConnectionRemoveInstancesAPI(c *gc.C) {
 	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+	s.FakeConn.Firewalls = []*compute.Firewall{{Name: "spam"}}
 
 	err := s.Conn.RemoveInstances("sp", "spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "GetFirewalls")
 	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].Name, gc.Equals, "spam")
 }
 
 func (s *connSuite) TestConnectionRemoveInstancesMultiple(c *gc.C) {
@@ -270,19 +290,23 @@ func (s *connSuite) TestConnectionRemoveInstancesMultiple(c *gc.C) {
 		},
 	}
 
+	s.FakeConn.Firewalls = []*compute.Firewall{{Name: "spam"}, {Name: "special"}}
+
 	err := s.Conn.RemoveInstances("", "spam", "special")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 5)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 7)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
-	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveInstance")
-	c.Check(s.FakeConn.Calls[3].ID, gc.Equals, "special")
-	c.Check(s.FakeConn.Calls[4].FuncName, gc.Equals, "RemoveFirewall")
-	c.Check(s.FakeConn.Calls[4].Name, gc.Equals, "special")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[4].FuncName, gc.Equals, "RemoveInstance")
+	c.Check(s.FakeConn.Calls[4].ID, gc.Equals, "special")
+	c.Check(s.FakeConn.Calls[5].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[6].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[6].Name, gc.Equals, "special")
 }
 
 func (s *connSuite) TestConnectionRemoveInstancesPartialMatch(c *gc.C) {
@@ -294,15 +318,18 @@ func (s *connSuite) TestConnectionRemoveInstancesPartialMatch(c *gc.C) {
 		},
 	}
 
+	s.FakeConn.Firewalls = []*compute.Firewall{{Name: "spam"}}
+
 	err := s.Conn.RemoveInstances("", "spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
-	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].Name, gc.Equals, "spam")
 }
 
 func (s *connSuite) TestConnectionRemoveInstancesListFailed(c *gc.C) {
@@ -4,23 +4,53 @@
 package google
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
 
-// Ports build a list of all open port ranges for a given firewall name
-// (within the Connection's project) and returns it. If the firewall
-// does not exist then the list will be empty and no error is returned.
-func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
-	firewall, err := gce.raw.GetFirewall(gce.projectID, fwname)
-	if errors.IsNotFound(err) {
-		return nil, nil
+// firewallName returns the name of the firewall, for the named set of
+// firewalls, that allows traffic from the provided source CIDRs. The
+// firewall allowing traffic from anywhere is given the unadorned name;
+// GCE firewalls have a single set of source ranges, so each other set
+// of source CIDRs gets its own firewall, named after a hash of them.
+func firewallName(fwname string, sourceCIDRs []string) string {
+	if len(sourceCIDRs) == 0 || (len(sourceCIDRs) == 1 && sourceCIDRs[0] == network.OpenCIDR) {
+		return fwname
 	}
+	hash := sha256.Sum256([]byte(strings.Join(sourceCIDRs, ",")))
+	return fmt.Sprintf("%s-%x", fwname, hash[:4])
+}
+
+// firewalls returns the firewalls of the named set of firewalls, keyed
+// by their names.
+func (gce Connection) firewalls(fwname string) (map[string]*compute.Firewall, error) {
+	firewalls, err := gce.raw.GetFirewalls(gce.projectID, fwname)
 	if err != nil {
 		return nil, errors.Annotate(err, "while getting ports from GCE")
 	}
+	result := make(map[string]*compute.Firewall)
+	for _, firewall := range firewalls {
+		sourceRanges := append([]string(nil), firewall.SourceRanges...)
+		sort.Strings(sourceRanges)
+		if firewall.Name != firewallName(fwname, sourceRanges) {
+			// The firewall belongs to a different set that
+			// shares the prefix.
+			continue
+		}
+		result[firewall.Name] = firewall
+	}
+	return result, nil
+}
 
+// firewallPorts returns the port ranges opened by the firewall.
+func firewallPorts(firewall *compute.Firewall) ([]network.PortRange, error) {
 	var ports []network.PortRange
 	for _, allowed := range firewall.Allowed {
 		for _, portRangeStr := range allowed.Ports {
@@ -32,82 +62,162 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 			ports = append(ports, portRange)
 		}
 	}
-
 	return ports, nil
 }
 
-// OpenPorts sends a request to the GCE API to open the provided port
-// ranges on the named firewall. If the firewall does not exist yet it
-// is created, with the provided port ranges opened. Otherwise the
-// existing firewall is updated to add the provided port ranges to the
-// ports it already has open. The call blocks until the ports are
-// opened or the request fails.
-func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error {
-	// TODO(ericsnow) Short-circuit if ports is empty.
-
-	// Compose the full set of open ports.
-	currentPorts, err := gce.Ports(fwname)
-	if err != nil {
-		return errors.Trace(err)
+// ingressRuleGroup holds the ports of a set of ingress rules that share
+// the same source CIDRs, and so belong in the same firewall.
+type ingressRuleGroup struct {
+	sourceCIDRs []string
+	ports       []network.PortRange
+}
+
+// groupIngressRules groups the provided rules by their source CIDRs,
+// keyed by the name of the firewall in the named set that holds them.
+func groupIngressRules(fwname string, rules []network.IngressRule) map[string]*ingressRuleGroup {
+	groups := make(map[string]*ingressRuleGroup)
+	for _, rule := range rules {
+		name := firewallName(fwname, rule.SourceCIDRs)
+		group, ok := groups[name]
+		if !ok {
+			group = &ingressRuleGroup{sourceCIDRs: rule.SourceCIDRs}
+			groups[name] = group
+		}
+		group.ports = append(group.ports, rule.PortRange)
 	}
-	inputPortsSet := network.NewPortSet(ports...)
-	if inputPortsSet.IsEmpty() {
-		return nil
+	return groups
+}
+
+// IngressRules builds a list of all ingress rules opened by the named
+// set of firewalls (within the Connection's project) and returns it. If
+// no firewalls exist then the list will be empty and no error is
+// returned.
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	firewalls, err := gce.firewalls(fwname)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
-	currentPortsSet := network.NewPortSet(currentPorts...)
-
-	// Send the request, depending on the current ports.
-	if currentPortsSet.IsEmpty() {
-		// Create a new firewall.
-		firewall := firewallSpec(fwname, inputPortsSet)
-		if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
-			return errors.Annotatef(err, "opening port(s) %+v", ports)
+
+	var rules []network.IngressRule
+	for _, firewall := range firewalls {
+		ports, err := firewallPorts(firewall)
+		if err != nil {
+			return rules, errors.Trace(err)
+		}
+		for _, portRange := range ports {
+			rule, err := network.NewIngressRule(
+				portRange.Protocol, portRange.FromPort, portRange.ToPort,
+				firewall.SourceRanges...,
+			)
+			if err != nil {
+				return rules, errors.Annotate(err, "bad source ranges from GCE")
+			}
+			rules = append(rules, rule)
 		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// OpenPorts sends a request to the GCE API to open the provided ingress
+// rules on the named set of firewalls. Rules are held in the firewall of
+// the set for their source CIDRs. If that firewall does not exist yet it
+// is created, with the rules' port ranges opened. Otherwise the existing
+// firewall is updated to add the port ranges to the ports it already has
+// open. The call blocks until the ports are opened or the request fails.
+func (gce Connection) OpenPorts(fwname string, rules ...network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
+	firewalls, err := gce.firewalls(fwname)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for name, group := range groupIngressRules(fwname, rules) {
+		inputPortsSet := network.NewPortSet(group.ports...)
+		firewall, ok := firewalls[name]
+		if !ok {
+			// Create a new firewall.
+			spec := firewallSpec(name, fwname, group.sourceCIDRs, inputPortsSet)
+			if err := gce.raw.AddFirewall(gce.projectID, spec); err != nil {
+				return errors.Annotatef(err, "opening port(s) %+v", rules)
+			}
+			continue
+		}
 
-	// Update an existing firewall.
-	newPortsSet := currentPortsSet.Union(inputPortsSet)
-	firewall := firewallSpec(fwname, newPortsSet)
-	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
-		return errors.Annotatef(err, "opening port(s) %+v", ports)
+		// Update an existing firewall.
+		currentPorts, err := firewallPorts(firewall)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		newPortsSet := network.NewPortSet(currentPorts...).Union(inputPortsSet)
+		spec := firewallSpec(name, fwname, group.sourceCIDRs, newPortsSet)
+		if err := gce.raw.UpdateFirewall(gce.projectID, name, spec); err != nil {
+			return errors.Annotatef(err, "opening port(s) %+v", rules)
+		}
 	}
 	return nil
 }
 
-// ClosePorts sends a request to the GCE API to close the provided port
-// ranges on the named firewall. If the firewall does not exist nothing
-// happens. If the firewall is left with no ports then it is removed.
-// Otherwise it will be left with just the open ports it has that do not
-// match the provided port ranges. The call blocks until the ports are
-// closed or the request fails.
-func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) error {
-	// Compose the full set of open ports.
-	currentPorts, err := gce.Ports(fwname)
+// ClosePorts sends a request to the GCE API to close the provided
+// ingress rules on the named set of firewalls. If the firewall for the
+// rules' source CIDRs does not exist nothing happens. If the firewall is
+// left with no ports then it is removed. Otherwise it will be left with
+// just the open ports it has that do not match the rules' port ranges.
+// The call blocks until the ports are closed or the request fails.
+func (gce Connection) ClosePorts(fwname string, rules ...network.IngressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	firewalls, err := gce.firewalls(fwname)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	inputPortsSet := network.NewPortSet(ports...)
-	if inputPortsSet.IsEmpty() {
-		return nil
-	}
-	currentPortsSet := network.NewPortSet(currentPorts...)
-	newPortsSet := currentPortsSet.Difference(inputPortsSet)
-
-	// Send the request, depending on the current ports.
-	if newPortsSet.IsEmpty() {
-		// Delete a firewall.
-		// TODO(ericsnow) Handle case where firewall does not exist.
-		if err := gce.raw.RemoveFirewall(gce.projectID, fwname); err != nil {
-			return errors.Annotatef(err, "closing port(s) %+v", ports)
+
+	for name, group := range groupIngressRules(fwname, rules) {
+		firewall, ok := firewalls[name]
+		if !ok {
+			continue
+		}
+		currentPorts, err := firewallPorts(firewall)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		inputPortsSet := network.NewPortSet(group.ports...)
+		newPortsSet := network.NewPortSet(currentPorts...).Difference(inputPortsSet)
+
+		// Send the request, depending on the current ports.
+		if newPortsSet.IsEmpty() {
+			// Delete a firewall.
+			if err := gce.raw.RemoveFirewall(gce.projectID, name); err != nil {
+				return errors.Annotatef(err, "closing port(s) %+v", rules)
+			}
+			continue
+		}
+
+		// Update an existing firewall.
+		spec := firewallSpec(name, fwname, group.sourceCIDRs, newPortsSet)
+		if err := gce.raw.UpdateFirewall(gce.projectID, name, spec); err != nil {
+			return errors.Annotatef(err, "closing port(s) %+v", rules)
 		}
-		return nil
 	}
+	return nil
+}
 
-	// Update an existing firewall.
-	firewall := firewallSpec(fwname, newPortsSet)
-	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
-		return errors.Annotatef(err, "closing port(s) %+v", ports)
+// removeFirewalls sends requests to the GCE API to remove all firewalls
+// of the named set. The call blocks until the firewalls are removed or
+// a request fails.
+func (gce Connection) removeFirewalls(fwname string) error {
+	firewalls, err := gce.firewalls(fwname)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for name := range firewalls {
+		err := gce.raw.RemoveFirewall(gce.projectID, name)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
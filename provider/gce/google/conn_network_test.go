@@ -12,10 +12,11 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
 )
 
-func (s *connSuite) TestConnectionPorts(c *gc.C) {
-	s.FakeConn.Firewall = &compute.Firewall{
+func (s *connSuite) TestConnectionIngressRules(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
 		Name:         "spam",
 		TargetTags:   []string{"spam"},
 		SourceRanges: []string{"0.0.0.0/0"},
@@ -23,51 +24,51 @@ func (s *connSuite) TestConnectionPorts(c *gc.C) {
 			IPProtocol: "tcp",
 			Ports:      []string{"80-81"},
 		}},
-	}
-
-	ports, err := s.Conn.Ports("spam")
-	c.Assert(err, jc.ErrorIsNil)
-
-	c.Check(ports, jc.DeepEquals, []network.PortRange{{
-		FromPort: 80,
-		ToPort:   81,
-		Protocol: "tcp",
-	}})
-}
-
-func (s *connSuite) TestConnectionPortsAPI(c *gc.C) {
-	s.FakeConn.Firewall = &compute.Firewall{
-		Name:         "spam",
+	}, {
+		Name:         google.FirewallName("spam", []string{"10.0.0.0/8"}),
 		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}, {
+		// A firewall of a different set sharing the prefix.
+		Name:         "spam-machine-0",
+		TargetTags:   []string{"spam-machine-0"},
 		SourceRanges: []string{"0.0.0.0/0"},
 		Allowed: []*compute.FirewallAllowed{{
 			IPProtocol: "tcp",
-			Ports:      []string{"80-81"},
+			Ports:      []string{"22"},
 		}},
-	}
+	}}
 
-	_, err := s.Conn.Ports("eggs")
+	rules, err := s.Conn.IngressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 81),
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+}
+
+func (s *connSuite) TestConnectionIngressRulesAPI(c *gc.C) {
+	_, err := s.Conn.IngressRules("eggs")
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
-	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
 	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "eggs")
 }
 
 func (s *connSuite) TestConnectionOpenPortsAdd(c *gc.C) {
-	s.FakeConn.Err = errors.NotFoundf("spam")
-
-	ports := network.PortRange{
-		FromPort: 80,
-		ToPort:   81,
-		Protocol: "tcp",
-	}
-	err := s.Conn.OpenPorts("spam", ports)
+	rule := network.MustNewIngressRule("tcp", 80, 81)
+	err := s.Conn.OpenPorts("spam", rule)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
-	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
 	sort.Strings(s.FakeConn.Calls[1].Firewall.Allowed[0].Ports)
 	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
@@ -81,8 +82,37 @@ func (s *connSuite) TestConnectionOpenPortsAdd(c *gc.C) {
 	})
 }
 
+func (s *connSuite) TestConnectionOpenPortsAddSourceCIDRs(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80-81"},
+		}},
+	}}
+
+	rule := network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8")
+	err := s.Conn.OpenPorts("spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:         google.FirewallName("spam", []string{"10.0.0.0/8"}),
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	})
+}
+
 func (s *connSuite) TestConnectionOpenPortsUpdate(c *gc.C) {
-	s.FakeConn.Firewall = &compute.Firewall{
+	s.FakeConn.Firewalls = []*compute.Firewall{{
 		Name:         "spam",
 		TargetTags:   []string{"spam"},
 		SourceRanges: []string{"0.0.0.0/0"},
@@ -90,18 +120,14 @@ func (s *connSuite) TestConnectionOpenPortsUpdate(c *gc.C) {
 			IPProtocol: "tcp",
 			Ports:      []string{"80-81"},
 		}},
-	}
-
-	ports := network.PortRange{
-		FromPort: 443,
-		ToPort:   443,
-		Protocol: "tcp",
-	}
-	err := s.Conn.OpenPorts("spam", ports)
+	}}
+
+	rule := network.MustNewIngressRule("tcp", 443, 443)
+	err := s.Conn.OpenPorts("spam", rule)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
-	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "UpdateFirewall")
 	sort.Strings(s.FakeConn.Calls[1].Firewall.Allowed[0].Ports)
 	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
@@ -116,7 +142,7 @@ func (s *connSuite) TestConnectionOpenPortsUpdate(c *gc.C) {
 }
 
 func (s *connSuite) TestConnectionClosePortsRemove(c *gc.C) {
-	s.FakeConn.Firewall = &compute.Firewall{
+	s.FakeConn.Firewalls = []*compute.Firewall{{
 		Name:         "spam",
 		TargetTags:   []string{"spam"},
 		SourceRanges: []string{"0.0.0.0/0"},
@@ -124,24 +150,46 @@ func (s *connSuite) TestConnectionClosePortsRemove(c *gc.C) {
 			IPProtocol: "tcp",
 			Ports:      []string{"443"},
 		}},
-	}
-
-	ports := network.PortRange{
-		FromPort: 443,
-		ToPort:   443,
-		Protocol: "tcp",
-	}
-	err := s.Conn.ClosePorts("spam", ports)
+	}}
+
+	rule := network.MustNewIngressRule("tcp", 443, 443)
+	err := s.Conn.ClosePorts("spam", rule)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
-	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
 	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam")
 }
 
+func (s *connSuite) TestConnectionClosePortsRemoveSourceCIDRs(c *gc.C) {
+	name := google.FirewallName("spam", []string{"10.0.0.0/8"})
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         name,
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+
+	// Closing the port open to anywhere leaves the rule for the
+	// source CIDRs alone.
+	err := s.Conn.ClosePorts("spam", network.MustNewIngressRule("tcp", 443, 443))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+
+	err = s.Conn.ClosePorts("spam", network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, name)
+}
+
 func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
-	s.FakeConn.Firewall = &compute.Firewall{
+	s.FakeConn.Firewalls = []*compute.Firewall{{
 		Name:         "spam",
 		TargetTags:   []string{"spam"},
 		SourceRanges: []string{"0.0.0.0/0"},
@@ -149,18 +197,14 @@ func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 			IPProtocol: "tcp",
 			Ports:      []string{"80-81", "443"},
 		}},
-	}
-
-	ports := network.PortRange{
-		FromPort: 443,
-		ToPort:   443,
-		Protocol: "tcp",
-	}
-	err := s.Conn.ClosePorts("spam", ports)
+	}}
+
+	rule := network.MustNewIngressRule("tcp", 443, 443)
+	err := s.Conn.ClosePorts("spam", rule)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
-	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "UpdateFirewall")
 	sort.Strings(s.FakeConn.Calls[1].Firewall.Allowed[0].Ports)
 	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
@@ -173,3 +217,12 @@ func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 		}},
 	})
 }
+
+func (s *connSuite) TestConnectionIngressRulesFailed(c *gc.C) {
+	failure := errors.New("<unknown>")
+	s.FakeConn.Err = failure
+
+	_, err := s.Conn.IngressRules("spam")
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+}
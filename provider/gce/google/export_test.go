@@ -15,6 +15,7 @@ var (
 	UnpackMetadata    = unpackMetadata
 	FormatMachineType = formatMachineType
 	FirewallSpec      = firewallSpec
+	FirewallName      = firewallName
 	ExtractAddresses  = extractAddresses
 )
 
@@ -56,16 +56,21 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 }
 
 // firewallSpec expands a port range set in to compute.FirewallAllowed
-// and returns a compute.Firewall for the provided name.
-func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
+// and returns a compute.Firewall for the provided name, applying to
+// instances tagged with target and allowing traffic from the provided
+// source CIDRs.
+func firewallSpec(name, target string, sourceCIDRs []string, ps network.PortSet) *compute.Firewall {
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{network.OpenCIDR}
+	}
 	firewall := compute.Firewall{
 		// Allowed is set below.
 		// Description is not set.
 		Name: name,
 		// Network: (defaults to global)
 		// SourceTags is not set.
-		TargetTags:   []string{name},
-		SourceRanges: []string{"0.0.0.0/0"},
+		TargetTags:   []string{target},
+		SourceRanges: sourceCIDRs,
 	}
 
 	for _, protocol := range ps.Protocols() {
@@ -62,7 +62,7 @@ func (s *networkSuite) TestFirewallSpec(c *gc.C) {
 		network.MustParsePortRange("8888/tcp"),
 		network.MustParsePortRange("1234/udp"),
 	)
-	fw := google.FirewallSpec("spam", ports)
+	fw := google.FirewallSpec("spam", "spam", nil, ports)
 
 	allowed := []*compute.FirewallAllowed{{
 		IPProtocol: "tcp",
@@ -83,6 +83,21 @@ func (s *networkSuite) TestFirewallSpec(c *gc.C) {
 	})
 }
 
+func (s *networkSuite) TestFirewallSpecSourceCIDRs(c *gc.C) {
+	ports := network.NewPortSet(network.MustParsePortRange("80/tcp"))
+	fw := google.FirewallSpec("spam-cidrs", "spam", []string{"10.0.0.0/8"}, ports)
+
+	c.Check(fw, jc.DeepEquals, &compute.Firewall{
+		Name:         "spam-cidrs",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	})
+}
+
 func (s *networkSuite) TestExtractAddresses(c *gc.C) {
 	addresses := google.ExtractAddresses(&s.NetworkInterface)
 
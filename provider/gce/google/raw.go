@@ -119,18 +119,14 @@ func (rc *rawConn) RemoveInstance(projectID, zone, id string) error {
 	return errors.Trace(err)
 }
 
-func (rc *rawConn) GetFirewall(projectID, name string) (*compute.Firewall, error) {
+func (rc *rawConn) GetFirewalls(projectID, prefix string) ([]*compute.Firewall, error) {
 	call := rc.Firewalls.List(projectID)
-	call = call.Filter("name eq " + name)
+	call = call.Filter("name eq " + prefix + ".*")
 	firewallList, err := call.Do()
 	if err != nil {
-		return nil, errors.Annotate(err, "while getting firewall from GCE")
-	}
-
-	if len(firewallList.Items) == 0 {
-		return nil, errors.NotFoundf("firewall %q", name)
+		return nil, errors.Annotate(err, "while getting firewalls from GCE")
 	}
-	return firewallList.Items[0], nil
+	return firewallList.Items, nil
 }
 
 func (rc *rawConn) AddFirewall(projectID string, firewall *compute.Firewall) error {
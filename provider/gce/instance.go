@@ -54,29 +54,29 @@ func findInst(id instance.Id, instances []instance.Instance) instance.Instance {
 
 // OpenPorts opens the given ports on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) OpenPorts(machineID string, ports []network.PortRange) error {
+func (inst *environInstance) OpenPorts(machineID string, rules []network.IngressRule) error {
 	// TODO(ericsnow) Make sure machineId matches inst.Id()?
 	name := common.MachineFullName(inst.env, machineID)
 	env := inst.env.getSnapshot()
-	err := env.gce.OpenPorts(name, ports...)
+	err := env.gce.OpenPorts(name, rules...)
 	return errors.Trace(err)
 }
 
 // ClosePorts closes the given ports on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) ClosePorts(machineID string, ports []network.PortRange) error {
+func (inst *environInstance) ClosePorts(machineID string, rules []network.IngressRule) error {
 	name := common.MachineFullName(inst.env, machineID)
 	env := inst.env.getSnapshot()
-	err := env.gce.ClosePorts(name, ports...)
+	err := env.gce.ClosePorts(name, rules...)
 	return errors.Trace(err)
 }
 
-// Ports returns the set of ports open on the instance, which
-// should have been started with the given machine id.
-// The ports are returned as sorted by SortPorts.
-func (inst *environInstance) Ports(machineID string) ([]network.PortRange, error) {
+// IngressRules returns the set of ingress rules open on the instance,
+// which should have been started with the given machine id.
+// The rules are returned as sorted by SortIngressRules.
+func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRule, error) {
 	name := common.MachineFullName(inst.env, machineID)
 	env := inst.env.getSnapshot()
-	ports, err := env.gce.Ports(name)
-	return ports, errors.Trace(err)
+	rules, err := env.gce.IngressRules(name)
+	return rules, errors.Trace(err)
 }
@@ -49,39 +49,39 @@ func (s *instanceSuite) TestAddresses(c *gc.C) {
 }
 
 func (s *instanceSuite) TestOpenPortsAPI(c *gc.C) {
-	err := s.Instance.OpenPorts("spam", s.Ports)
+	err := s.Instance.OpenPorts("spam", s.Rules)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "OpenPorts")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
-	c.Check(s.FakeConn.Calls[0].PortRanges, jc.DeepEquals, s.Ports)
+	c.Check(s.FakeConn.Calls[0].Rules, jc.DeepEquals, s.Rules)
 }
 
 func (s *instanceSuite) TestClosePortsAPI(c *gc.C) {
-	err := s.Instance.ClosePorts("spam", s.Ports)
+	err := s.Instance.ClosePorts("spam", s.Rules)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ClosePorts")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
-	c.Check(s.FakeConn.Calls[0].PortRanges, jc.DeepEquals, s.Ports)
+	c.Check(s.FakeConn.Calls[0].Rules, jc.DeepEquals, s.Rules)
 }
 
-func (s *instanceSuite) TestPorts(c *gc.C) {
-	s.FakeConn.PortRanges = s.Ports
+func (s *instanceSuite) TestIngressRules(c *gc.C) {
+	s.FakeConn.Rules = s.Rules
 
-	ports, err := s.Instance.Ports("spam")
+	rules, err := s.Instance.IngressRules("spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(ports, jc.DeepEquals, s.Ports)
+	c.Check(rules, jc.DeepEquals, s.Rules)
 }
 
-func (s *instanceSuite) TestPortsAPI(c *gc.C) {
-	_, err := s.Instance.Ports("spam")
+func (s *instanceSuite) TestIngressRulesAPI(c *gc.C) {
+	_, err := s.Instance.IngressRules("spam")
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
-	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "IngressRules")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
 }
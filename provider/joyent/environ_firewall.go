@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/joyent/gosdc/cloudapi"
+	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
@@ -50,8 +51,8 @@ func ruleExists(rules []cloudapi.FirewallRule, rule string) (bool, string) {
 	return false, ""
 }
 
-// Helper method to get port from the given firewall rules
-func getPorts(envName string, rules []cloudapi.FirewallRule) []network.PortRange {
+// Helper method to get ingress rules from the given firewall rules
+func getRules(envName string, rules []cloudapi.FirewallRule) []network.IngressRule {
 	portRanges := []network.PortRange{}
 	for _, r := range rules {
 		rule := r.Rule
@@ -83,21 +84,24 @@ func getPorts(envName string, rules []cloudapi.FirewallRule) []network.PortRange
 	}
 
 	network.SortPortRanges(portRanges)
-	return portRanges
+	return network.NewOpenIngressRules(portRanges)
 }
 
-func (env *joyentEnviron) OpenPorts(ports []network.PortRange) error {
+func (env *joyentEnviron) OpenPorts(rules []network.IngressRule) error {
 	if env.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", env.Config().FirewallMode())
 	}
+	if err := network.ValidateOpenIngressRules(rules); err != nil {
+		return errors.Trace(err)
+	}
 
 	fwRules, err := env.compute.cloudapi.ListFirewallRules()
 	if err != nil {
 		return fmt.Errorf("cannot get firewall rules: %v", err)
 	}
 
-	for _, p := range ports {
-		rule := createFirewallRuleAll(env.Config().Name(), p)
+	for _, r := range rules {
+		rule := createFirewallRuleAll(env.Config().Name(), r.PortRange)
 		if e, id := ruleExists(fwRules, rule); e {
 			_, err := env.compute.cloudapi.EnableFirewallRule(id)
 			if err != nil {
@@ -114,23 +118,26 @@ func (env *joyentEnviron) OpenPorts(ports []network.PortRange) error {
 		}
 	}
 
-	logger.Infof("ports %v opened in model", ports)
+	logger.Infof("ingress rules %v opened in model", rules)
 
 	return nil
 }
 
-func (env *joyentEnviron) ClosePorts(ports []network.PortRange) error {
+func (env *joyentEnviron) ClosePorts(rules []network.IngressRule) error {
 	if env.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model", env.Config().FirewallMode())
 	}
+	if err := network.ValidateOpenIngressRules(rules); err != nil {
+		return errors.Trace(err)
+	}
 
 	fwRules, err := env.compute.cloudapi.ListFirewallRules()
 	if err != nil {
 		return fmt.Errorf("cannot get firewall rules: %v", err)
 	}
 
-	for _, p := range ports {
-		rule := createFirewallRuleAll(env.Config().Name(), p)
+	for _, r := range rules {
+		rule := createFirewallRuleAll(env.Config().Name(), r.PortRange)
 		if e, id := ruleExists(fwRules, rule); e {
 			_, err := env.compute.cloudapi.DisableFirewallRule(id)
 			if err != nil {
@@ -147,12 +154,12 @@ func (env *joyentEnviron) ClosePorts(ports []network.PortRange) error {
 		}
 	}
 
-	logger.Infof("ports %v closed in model", ports)
+	logger.Infof("ingress rules %v closed in model", rules)
 
 	return nil
 }
 
-func (env *joyentEnviron) Ports() ([]network.PortRange, error) {
+func (env *joyentEnviron) IngressRules() ([]network.IngressRule, error) {
 	if env.Config().FirewallMode() != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", env.Config().FirewallMode())
 	}
@@ -162,5 +169,5 @@ func (env *joyentEnviron) Ports() ([]network.PortRange, error) {
 		return nil, fmt.Errorf("cannot get firewall rules: %v", err)
 	}
 
-	return getPorts(env.Config().Name(), fwRules), nil
+	return getRules(env.Config().Name(), fwRules), nil
 }
@@ -14,12 +14,12 @@ type FirewallSuite struct{}
 
 var _ = gc.Suite(&FirewallSuite{})
 
-func (s *FirewallSuite) TestGetPorts(c *gc.C) {
+func (s *FirewallSuite) TestGetRules(c *gc.C) {
 	testCases := []struct {
 		about    string
 		envName  string
 		rules    []cloudapi.FirewallRule
-		expected []network.PortRange
+		expected []network.IngressRule
 	}{
 		{
 			"single port model rule",
@@ -29,11 +29,9 @@ func (s *FirewallSuite) TestGetPorts(c *gc.C) {
 				true,
 				"FROM tag switch TO tag juju ALLOW tcp PORT 80",
 			}},
-			[]network.PortRange{{
-				FromPort: 80,
-				ToPort:   80,
-				Protocol: "tcp",
-			}},
+			[]network.IngressRule{
+				network.MustNewIngressRule("tcp", 80, 80),
+			},
 		},
 		{
 			"port range model rule",
@@ -43,16 +41,14 @@ func (s *FirewallSuite) TestGetPorts(c *gc.C) {
 				true,
 				"FROM tag switch TO tag juju ALLOW tcp (PORT 80 AND PORT 81 AND PORT 82 AND PORT 83)",
 			}},
-			[]network.PortRange{{
-				FromPort: 80,
-				ToPort:   83,
-				Protocol: "tcp",
-			}},
+			[]network.IngressRule{
+				network.MustNewIngressRule("tcp", 80, 83),
+			},
 		},
 	}
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
-		c.Assert(joyent.GetPorts(t.envName, t.rules), gc.DeepEquals, t.expected)
+		c.Assert(joyent.GetRules(t.envName, t.rules), gc.DeepEquals, t.expected)
 	}
 
 }
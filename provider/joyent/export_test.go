@@ -230,7 +230,7 @@ func MakeStorage(c *gc.C, attrs testing.Attrs) storage.Storage {
 	return stor
 }
 
-var GetPorts = getPorts
+var GetRules = getRules
 
 var CreateFirewallRuleAll = createFirewallRuleAll
 
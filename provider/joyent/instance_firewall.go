@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/joyent/gosdc/cloudapi"
+	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
@@ -32,10 +33,13 @@ func createFirewallRuleVm(envName string, machineId string, portRange network.Po
 	return fmt.Sprintf(firewallRuleVm, envName, machineId, strings.ToLower(portRange.Protocol), portList)
 }
 
-func (inst *joyentInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+func (inst *joyentInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
 	if inst.env.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance", inst.env.Config().FirewallMode())
 	}
+	if err := network.ValidateOpenIngressRules(rules); err != nil {
+		return errors.Trace(err)
+	}
 
 	fwRules, err := inst.env.compute.cloudapi.ListFirewallRules()
 	if err != nil {
@@ -43,8 +47,8 @@ func (inst *joyentInstance) OpenPorts(machineId string, ports []network.PortRang
 	}
 
 	machineId = string(inst.Id())
-	for _, p := range ports {
-		rule := createFirewallRuleVm(inst.env.Config().Name(), machineId, p)
+	for _, r := range rules {
+		rule := createFirewallRuleVm(inst.env.Config().Name(), machineId, r.PortRange)
 		if e, id := ruleExists(fwRules, rule); e {
 			_, err := inst.env.compute.cloudapi.EnableFirewallRule(id)
 			if err != nil {
@@ -61,15 +65,18 @@ func (inst *joyentInstance) OpenPorts(machineId string, ports []network.PortRang
 		}
 	}
 
-	logger.Infof("ports %v opened for instance %q", ports, machineId)
+	logger.Infof("ingress rules %v opened for instance %q", rules, machineId)
 
 	return nil
 }
 
-func (inst *joyentInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+func (inst *joyentInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
 	if inst.env.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance", inst.env.Config().FirewallMode())
 	}
+	if err := network.ValidateOpenIngressRules(rules); err != nil {
+		return errors.Trace(err)
+	}
 
 	fwRules, err := inst.env.compute.cloudapi.ListFirewallRules()
 	if err != nil {
@@ -77,8 +84,8 @@ func (inst *joyentInstance) ClosePorts(machineId string, ports []network.PortRan
 	}
 
 	machineId = string(inst.Id())
-	for _, p := range ports {
-		rule := createFirewallRuleVm(inst.env.Config().Name(), machineId, p)
+	for _, r := range rules {
+		rule := createFirewallRuleVm(inst.env.Config().Name(), machineId, r.PortRange)
 		if e, id := ruleExists(fwRules, rule); e {
 			_, err := inst.env.compute.cloudapi.DisableFirewallRule(id)
 			if err != nil {
@@ -95,12 +102,12 @@ func (inst *joyentInstance) ClosePorts(machineId string, ports []network.PortRan
 		}
 	}
 
-	logger.Infof("ports %v closed for instance %q", ports, machineId)
+	logger.Infof("ingress rules %v closed for instance %q", rules, machineId)
 
 	return nil
 }
 
-func (inst *joyentInstance) Ports(machineId string) ([]network.PortRange, error) {
+func (inst *joyentInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
 	if inst.env.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance", inst.env.Config().FirewallMode())
 	}
@@ -111,5 +118,5 @@ func (inst *joyentInstance) Ports(machineId string) ([]network.PortRange, error)
 		return nil, fmt.Errorf("cannot get firewall rules: %v", err)
 	}
 
-	return getPorts(inst.env.Config().Name(), fwRules), nil
+	return getRules(inst.env.Config().Name(), fwRules), nil
 }
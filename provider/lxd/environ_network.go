@@ -17,11 +17,11 @@ func (env *environ) globalFirewallName() string {
 	return common.EnvFullName(env)
 }
 
-// OpenPorts opens the given port ranges for the whole environment.
+// OpenPorts opens the given ingress rules for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) OpenPorts(ports []network.PortRange) error {
-	err := env.raw.OpenPorts(env.globalFirewallName(), ports...)
+func (env *environ) OpenPorts(rules []network.IngressRule) error {
+	err := env.raw.OpenPorts(env.globalFirewallName(), rules...)
 	if errors.IsNotImplemented(err) {
 		// TODO(ericsnow) for now...
 		return nil
@@ -29,11 +29,11 @@ func (env *environ) OpenPorts(ports []network.PortRange) error {
 	return errors.Trace(err)
 }
 
-// ClosePorts closes the given port ranges for the whole environment.
+// ClosePorts closes the given ingress rules for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) ClosePorts(ports []network.PortRange) error {
-	err := env.raw.ClosePorts(env.globalFirewallName(), ports...)
+func (env *environ) ClosePorts(rules []network.IngressRule) error {
+	err := env.raw.ClosePorts(env.globalFirewallName(), rules...)
 	if errors.IsNotImplemented(err) {
 		// TODO(ericsnow) for now...
 		return nil
@@ -41,14 +41,14 @@ func (env *environ) ClosePorts(ports []network.PortRange) error {
 	return errors.Trace(err)
 }
 
-// Ports returns the port ranges opened for the whole environment.
+// IngressRules returns the ingress rules opened for the whole environment.
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
-func (env *environ) Ports() ([]network.PortRange, error) {
-	ports, err := env.raw.Ports(env.globalFirewallName())
+func (env *environ) IngressRules() ([]network.IngressRule, error) {
+	rules, err := env.raw.IngressRules(env.globalFirewallName())
 	if errors.IsNotImplemented(err) {
 		// TODO(ericsnow) for now...
 		return nil, nil
 	}
-	return ports, errors.Trace(err)
+	return rules, errors.Trace(err)
 }
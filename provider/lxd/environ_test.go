@@ -111,7 +111,7 @@ func (s *environSuite) TestDestroyAPI(c *gc.C) {
 
 	fwname := s.Prefix[:len(s.Prefix)-1]
 	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
-		FuncName: "Ports",
+		FuncName: "IngressRules",
 		Args: []interface{}{
 			fwname,
 		},
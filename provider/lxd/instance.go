@@ -56,11 +56,11 @@ func findInst(id instance.Id, instances []instance.Instance) instance.Instance {
 
 // OpenPorts opens the given ports on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) OpenPorts(machineID string, ports []network.PortRange) error {
+func (inst *environInstance) OpenPorts(machineID string, rules []network.IngressRule) error {
 	// TODO(ericsnow) Make sure machineId matches inst.Id()?
 	name := common.MachineFullName(inst.env, machineID)
 	env := inst.env.getSnapshot()
-	err := env.raw.OpenPorts(name, ports...)
+	err := env.raw.OpenPorts(name, rules...)
 	if errors.IsNotImplemented(err) {
 		// TODO(ericsnow) for now...
 		return nil
@@ -70,10 +70,10 @@ func (inst *environInstance) OpenPorts(machineID string, ports []network.PortRan
 
 // ClosePorts closes the given ports on the instance, which
 // should have been started with the given machine id.
-func (inst *environInstance) ClosePorts(machineID string, ports []network.PortRange) error {
+func (inst *environInstance) ClosePorts(machineID string, rules []network.IngressRule) error {
 	name := common.MachineFullName(inst.env, machineID)
 	env := inst.env.getSnapshot()
-	err := env.raw.ClosePorts(name, ports...)
+	err := env.raw.ClosePorts(name, rules...)
 	if errors.IsNotImplemented(err) {
 		// TODO(ericsnow) for now...
 		return nil
@@ -81,16 +81,16 @@ func (inst *environInstance) ClosePorts(machineID string, ports []network.PortRa
 	return errors.Trace(err)
 }
 
-// Ports returns the set of ports open on the instance, which
-// should have been started with the given machine id.
-// The ports are returned as sorted by SortPorts.
-func (inst *environInstance) Ports(machineID string) ([]network.PortRange, error) {
+// IngressRules returns the set of ingress rules applied to the instance,
+// which should have been started with the given machine id.
+// The rules are returned as sorted by SortIngressRules.
+func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRule, error) {
 	name := common.MachineFullName(inst.env, machineID)
 	env := inst.env.getSnapshot()
-	ports, err := env.raw.Ports(name)
+	rules, err := env.raw.IngressRules(name)
 	if errors.IsNotImplemented(err) {
 		// TODO(ericsnow) for now...
 		return nil, nil
 	}
-	return ports, errors.Trace(err)
+	return rules, errors.Trace(err)
 }
@@ -51,46 +51,46 @@ func (s *instanceSuite) TestAddresses(c *gc.C) {
 }
 
 func (s *instanceSuite) TestOpenPortsAPI(c *gc.C) {
-	err := s.Instance.OpenPorts("spam", s.Ports)
+	err := s.Instance.OpenPorts("spam", s.Rules)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
 		FuncName: "OpenPorts",
 		Args: []interface{}{
 			s.InstName,
-			s.Ports,
+			s.Rules,
 		},
 	}})
 }
 
 func (s *instanceSuite) TestClosePortsAPI(c *gc.C) {
-	err := s.Instance.ClosePorts("spam", s.Ports)
+	err := s.Instance.ClosePorts("spam", s.Rules)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
 		FuncName: "ClosePorts",
 		Args: []interface{}{
 			s.InstName,
-			s.Ports,
+			s.Rules,
 		},
 	}})
 }
 
-func (s *instanceSuite) TestPortsOkay(c *gc.C) {
-	s.Firewaller.PortRanges = s.Ports
+func (s *instanceSuite) TestIngressRulesOkay(c *gc.C) {
+	s.Firewaller.Rules = s.Rules
 
-	ports, err := s.Instance.Ports("spam")
+	rules, err := s.Instance.IngressRules("spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(ports, jc.DeepEquals, s.Ports)
+	c.Check(rules, jc.DeepEquals, s.Rules)
 }
 
-func (s *instanceSuite) TestPortsAPI(c *gc.C) {
-	_, err := s.Instance.Ports("spam")
+func (s *instanceSuite) TestIngressRulesAPI(c *gc.C) {
+	_, err := s.Instance.IngressRules("spam")
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
-		FuncName: "Ports",
+		FuncName: "IngressRules",
 		Args: []interface{}{
 			s.InstName,
 		},
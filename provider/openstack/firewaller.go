@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	gooseerrors "gopkg.in/goose.v1/errors"
 	"gopkg.in/goose.v1/nova"
 
@@ -29,14 +30,14 @@ type FirewallerFactory interface {
 // Firewaller allows custom openstack provider behaviour.
 // This is used in other providers that embed the openstack provider.
 type Firewaller interface {
-	// OpenPorts opens the given port ranges for the whole environment.
-	OpenPorts(ports []network.PortRange) error
+	// OpenPorts opens the given ingress rules for the whole environment.
+	OpenPorts(rules []network.IngressRule) error
 
-	// ClosePorts closes the given port ranges for the whole environment.
-	ClosePorts(ports []network.PortRange) error
+	// ClosePorts closes the given ingress rules for the whole environment.
+	ClosePorts(rules []network.IngressRule) error
 
-	// Ports returns the port ranges opened for the whole environment.
-	Ports() ([]network.PortRange, error)
+	// IngressRules returns the ingress rules opened for the whole environment.
+	IngressRules() ([]network.IngressRule, error)
 
 	// Implementations shoud delete all global security groups.
 	DeleteGlobalGroups() error
@@ -50,14 +51,14 @@ type Firewaller interface {
 	// Set of initial networks, that should be added by default to all new instances.
 	InitialNetworks() []nova.ServerNetworks
 
-	// OpenInstancePorts opens the given port ranges for the specified  instance.
-	OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error
+	// OpenInstancePorts opens the given ingress rules for the specified  instance.
+	OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error
 
-	// CloseInstancePorts closes the given port ranges for the specified  instance.
-	CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error
+	// CloseInstancePorts closes the given ingress rules for the specified  instance.
+	CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error
 
-	// InstancePorts returns the port ranges opened for the specified  instance.
-	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
+	// InstanceIngressRules returns the ingress rules opened for the specified  instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
 }
 
 type firewallerFactory struct {
@@ -278,91 +279,91 @@ func deleteSecurityGroup(novaclient *nova.Client, name, id string) {
 }
 
 // OpenPorts implements Firewaller interface.
-func (c *defaultFirewaller) OpenPorts(ports []network.PortRange) error {
+func (c *defaultFirewaller) OpenPorts(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.openPortsInGroup(c.globalGroupName(), ports); err != nil {
+	if err := c.openPortsInGroup(c.globalGroupName(), rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ports in global group: %v", rules)
 	return nil
 }
 
 // ClosePorts implements Firewaller interface.
-func (c *defaultFirewaller) ClosePorts(ports []network.PortRange) error {
+func (c *defaultFirewaller) ClosePorts(rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
 			c.environ.Config().FirewallMode())
 	}
-	if err := c.closePortsInGroup(c.globalGroupName(), ports); err != nil {
+	if err := c.closePortsInGroup(c.globalGroupName(), rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ports in global group: %v", rules)
 	return nil
 }
 
-// Ports implements Firewaller interface.
-func (c *defaultFirewaller) Ports() ([]network.PortRange, error) {
+// IngressRules implements Firewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
 			c.environ.Config().FirewallMode())
 	}
-	return c.portsInGroup(c.globalGroupName())
+	return c.ingressRulesInGroup(c.globalGroupName())
 }
 
 // OpenInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+func (c *defaultFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	name := c.machineGroupName(machineId)
-	if err := c.openPortsInGroup(name, ports); err != nil {
+	if err := c.openPortsInGroup(name, rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in security group %s: %v", name, ports)
+	logger.Infof("opened ports in security group %s: %v", name, rules)
 	return nil
 }
 
 // CloseInstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, ports []network.PortRange) error {
+func (c *defaultFirewaller) CloseInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			c.environ.Config().FirewallMode())
 	}
 	name := c.machineGroupName(machineId)
-	if err := c.closePortsInGroup(name, ports); err != nil {
+	if err := c.closePortsInGroup(name, rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in security group %s: %v", name, ports)
+	logger.Infof("closed ports in security group %s: %v", name, rules)
 	return nil
 }
 
-// InstancePorts implements Firewaller interface.
-func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error) {
+// InstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
 	if c.environ.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			c.environ.Config().FirewallMode())
 	}
 	name := c.machineGroupName(machineId)
-	portRanges, err := c.portsInGroup(name)
+	rules, err := c.ingressRulesInGroup(name)
 	if err != nil {
 		return nil, err
 	}
-	return portRanges, nil
+	return rules, nil
 }
 
-func (c *defaultFirewaller) openPortsInGroup(name string, portRanges []network.PortRange) error {
+func (c *defaultFirewaller) openPortsInGroup(name string, rules []network.IngressRule) error {
 	novaclient := c.environ.nova()
 	group, err := novaclient.SecurityGroupByName(name)
 	if err != nil {
 		return err
 	}
-	rules := portsToRuleInfo(group.Id, portRanges)
-	for _, rule := range rules {
-		_, err := novaclient.CreateSecurityGroupRule(rule)
+	ruleInfos := rulesToRuleInfo(group.Id, rules)
+	for _, ruleInfo := range ruleInfos {
+		_, err := novaclient.CreateSecurityGroupRule(ruleInfo)
 		if err != nil {
 			// TODO: if err is not rule already exists, raise?
 			logger.Debugf("error creating security group rule: %v", err.Error())
@@ -381,8 +382,17 @@ func ruleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.PortRan
 		*rule.ToPort == portRange.ToPort
 }
 
-func (c *defaultFirewaller) closePortsInGroup(name string, portRanges []network.PortRange) error {
-	if len(portRanges) == 0 {
+// ruleSourceCIDR returns the source CIDR of the supplied nova security
+// group rule.
+func ruleSourceCIDR(rule nova.SecurityGroupRule) string {
+	if cidr := rule.IPRange["cidr"]; cidr != "" {
+		return cidr
+	}
+	return network.OpenCIDR
+}
+
+func (c *defaultFirewaller) closePortsInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
 	novaclient := c.environ.nova()
@@ -391,36 +401,59 @@ func (c *defaultFirewaller) closePortsInGroup(name string, portRanges []network.
 		return err
 	}
 	// TODO: Hey look ma, it's quadratic
-	for _, portRange := range portRanges {
+	for _, rule := range rules {
+		sourceCIDRs := set.NewStrings(rule.SourceCIDRs...)
 		for _, p := range (*group).Rules {
-			if !ruleMatchesPortRange(p, portRange) {
+			if !ruleMatchesPortRange(p, rule.PortRange) {
+				continue
+			}
+			if !sourceCIDRs.IsEmpty() && !sourceCIDRs.Contains(ruleSourceCIDR(p)) {
 				continue
 			}
 			err := novaclient.DeleteSecurityGroupRule(p.Id)
 			if err != nil {
 				return err
 			}
-			break
 		}
 	}
 	return nil
 }
 
-func (c *defaultFirewaller) portsInGroup(name string) (portRanges []network.PortRange, err error) {
+// ingressRulesInGroup returns the ingress rules of the named security
+// group. Nova rules have a single source CIDR each, so the rules for the
+// same port range are combined into one ingress rule.
+func (c *defaultFirewaller) ingressRulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := c.environ.nova().SecurityGroupByName(name)
 	if err != nil {
 		return nil, err
 	}
+	var portRanges []network.PortRange
+	sourceCIDRs := make(map[network.PortRange][]string)
 	for _, p := range (*group).Rules {
-		portRanges = append(portRanges, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: *p.IPProtocol,
 			FromPort: *p.FromPort,
 			ToPort:   *p.ToPort,
-		})
+		}
+		if _, ok := sourceCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		sourceCIDRs[portRange] = append(sourceCIDRs[portRange], ruleSourceCIDR(p))
+	}
+	for _, portRange := range portRanges {
+		rule, err := network.NewIngressRule(
+			portRange.Protocol, portRange.FromPort, portRange.ToPort,
+			sourceCIDRs[portRange]...,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
 	}
-	network.SortPortRanges(portRanges)
-	return portRanges, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
+
 func (c *defaultFirewaller) globalGroupName() string {
 	return fmt.Sprintf("%s-global", c.jujuGroupName())
 }
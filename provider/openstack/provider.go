@@ -378,16 +378,16 @@ func convertNovaAddresses(publicIP string, addresses map[string][]nova.IPAddress
 	return machineAddresses
 }
 
-func (inst *openstackInstance) OpenPorts(machineId string, ports []network.PortRange) error {
-	return inst.e.firewaller.OpenInstancePorts(inst, machineId, ports)
+func (inst *openstackInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.OpenInstancePorts(inst, machineId, rules)
 }
 
-func (inst *openstackInstance) ClosePorts(machineId string, ports []network.PortRange) error {
-	return inst.e.firewaller.CloseInstancePorts(inst, machineId, ports)
+func (inst *openstackInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.CloseInstancePorts(inst, machineId, rules)
 }
 
-func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, error) {
-	return inst.e.firewaller.InstancePorts(inst, machineId)
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
 }
 
 func (e *Environ) ecfg() *environConfig {
@@ -1160,31 +1160,38 @@ func (e *Environ) machinesFilter() *nova.Filter {
 	return filter
 }
 
-// portsToRuleInfo maps port ranges to nova rules
-func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo {
-	rules := make([]nova.RuleInfo, len(ports))
-	for i, portRange := range ports {
-		rules[i] = nova.RuleInfo{
-			ParentGroupId: groupId,
-			FromPort:      portRange.FromPort,
-			ToPort:        portRange.ToPort,
-			IPProtocol:    portRange.Protocol,
-			Cidr:          "0.0.0.0/0",
+// rulesToRuleInfo maps ingress rules to nova rules, one for each
+// source CIDR of each rule.
+func rulesToRuleInfo(groupId string, rules []network.IngressRule) []nova.RuleInfo {
+	var ruleInfos []nova.RuleInfo
+	for _, rule := range rules {
+		sourceCIDRs := rule.SourceCIDRs
+		if len(sourceCIDRs) == 0 {
+			sourceCIDRs = []string{network.OpenCIDR}
+		}
+		for _, sourceCIDR := range sourceCIDRs {
+			ruleInfos = append(ruleInfos, nova.RuleInfo{
+				ParentGroupId: groupId,
+				FromPort:      rule.FromPort,
+				ToPort:        rule.ToPort,
+				IPProtocol:    rule.Protocol,
+				Cidr:          sourceCIDR,
+			})
 		}
 	}
-	return rules
+	return ruleInfos
 }
 
-func (e *Environ) OpenPorts(ports []network.PortRange) error {
-	return e.firewaller.OpenPorts(ports)
+func (e *Environ) OpenPorts(rules []network.IngressRule) error {
+	return e.firewaller.OpenPorts(rules)
 }
 
-func (e *Environ) ClosePorts(ports []network.PortRange) error {
-	return e.firewaller.ClosePorts(ports)
+func (e *Environ) ClosePorts(rules []network.IngressRule) error {
+	return e.firewaller.ClosePorts(rules)
 }
 
-func (e *Environ) Ports() ([]network.PortRange, error) {
-	return e.firewaller.Ports()
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	return e.firewaller.IngressRules()
 }
 
 func (e *Environ) Provider() environs.EnvironProvider {
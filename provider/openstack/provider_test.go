@@ -214,19 +214,15 @@ func (t *localTests) TestGetServerAddresses(c *gc.C) {
 	}
 }
 
-func (*localTests) TestPortsToRuleInfo(c *gc.C) {
+func (*localTests) TestRulesToRuleInfo(c *gc.C) {
 	groupId := "groupid"
 	testCases := []struct {
 		about    string
-		ports    []network.PortRange
+		rules    []network.IngressRule
 		expected []nova.RuleInfo
 	}{{
 		about: "single port",
-		ports: []network.PortRange{{
-			FromPort: 80,
-			ToPort:   80,
-			Protocol: "tcp",
-		}},
+		rules: []network.IngressRule{network.MustNewIngressRule("tcp", 80, 80)},
 		expected: []nova.RuleInfo{{
 			IPProtocol:    "tcp",
 			FromPort:      80,
@@ -236,11 +232,7 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 		}},
 	}, {
 		about: "multiple ports",
-		ports: []network.PortRange{{
-			FromPort: 80,
-			ToPort:   82,
-			Protocol: "tcp",
-		}},
+		rules: []network.IngressRule{network.MustNewIngressRule("tcp", 80, 82)},
 		expected: []nova.RuleInfo{{
 			IPProtocol:    "tcp",
 			FromPort:      80,
@@ -250,15 +242,10 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 		}},
 	}, {
 		about: "multiple port ranges",
-		ports: []network.PortRange{{
-			FromPort: 80,
-			ToPort:   82,
-			Protocol: "tcp",
-		}, {
-			FromPort: 100,
-			ToPort:   120,
-			Protocol: "tcp",
-		}},
+		rules: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 82),
+			network.MustNewIngressRule("tcp", 100, 120),
+		},
 		expected: []nova.RuleInfo{{
 			IPProtocol:    "tcp",
 			FromPort:      80,
@@ -272,11 +259,29 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 			Cidr:          "0.0.0.0/0",
 			ParentGroupId: groupId,
 		}},
+	}, {
+		about: "multiple source CIDRs",
+		rules: []network.IngressRule{
+			network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16", "10.0.0.0/8"),
+		},
+		expected: []nova.RuleInfo{{
+			IPProtocol:    "tcp",
+			FromPort:      80,
+			ToPort:        80,
+			Cidr:          "10.0.0.0/8",
+			ParentGroupId: groupId,
+		}, {
+			IPProtocol:    "tcp",
+			FromPort:      80,
+			ToPort:        80,
+			Cidr:          "192.168.0.0/16",
+			ParentGroupId: groupId,
+		}},
 	}}
 
 	for i, t := range testCases {
 		c.Logf("test %d: %s", i, t.about)
-		rules := openstack.PortsToRuleInfo(groupId, t.ports)
+		rules := openstack.RulesToRuleInfo(groupId, t.rules)
 		c.Check(len(rules), gc.Equals, len(t.expected))
 		c.Check(rules, gc.DeepEquals, t.expected)
 	}
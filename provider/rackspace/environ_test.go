@@ -170,18 +170,18 @@ func (e *fakeEnviron) Destroy() error {
 	return nil
 }
 
-func (e *fakeEnviron) OpenPorts(ports []network.PortRange) error {
-	e.Push("OpenPorts", ports)
+func (e *fakeEnviron) OpenPorts(rules []network.IngressRule) error {
+	e.Push("OpenPorts", rules)
 	return nil
 }
 
-func (e *fakeEnviron) ClosePorts(ports []network.PortRange) error {
-	e.Push("ClosePorts", ports)
+func (e *fakeEnviron) ClosePorts(rules []network.IngressRule) error {
+	e.Push("ClosePorts", rules)
 	return nil
 }
 
-func (e *fakeEnviron) Ports() ([]network.PortRange, error) {
-	e.Push("Ports")
+func (e *fakeEnviron) IngressRules() ([]network.IngressRule, error) {
+	e.Push("IngressRules")
 	return nil, nil
 }
 
@@ -219,13 +219,13 @@ func (e *fakeConfigurator) ConfigureExternalIpAddress(apiPort int) error {
 	return nil
 }
 
-func (e *fakeConfigurator) ChangePorts(ipAddress string, insert bool, ports []network.PortRange) error {
-	e.Push("ChangePorts", ipAddress, insert, ports)
+func (e *fakeConfigurator) ChangeIngressRules(ipAddress string, insert bool, rules []network.IngressRule) error {
+	e.Push("ChangeIngressRules", ipAddress, insert, rules)
 	return nil
 }
 
-func (e *fakeConfigurator) FindOpenPorts() ([]network.PortRange, error) {
-	e.Push("FindOpenPorts")
+func (e *fakeConfigurator) FindIngressRules() ([]network.IngressRule, error) {
+	e.Push("FindIngressRules")
 	return nil, nil
 }
 
@@ -277,17 +277,17 @@ func (e *fakeInstance) Addresses() ([]network.Address, error) {
 	}}, nil
 }
 
-func (e *fakeInstance) OpenPorts(machineId string, ports []network.PortRange) error {
-	e.Push("OpenPorts", machineId, ports)
+func (e *fakeInstance) OpenPorts(machineId string, rules []network.IngressRule) error {
+	e.Push("OpenPorts", machineId, rules)
 	return nil
 }
 
-func (e *fakeInstance) ClosePorts(machineId string, ports []network.PortRange) error {
-	e.Push("ClosePorts", machineId, ports)
+func (e *fakeInstance) ClosePorts(machineId string, rules []network.IngressRule) error {
+	e.Push("ClosePorts", machineId, rules)
 	return nil
 }
 
-func (e *fakeInstance) Ports(machineId string) ([]network.PortRange, error) {
-	e.Push("Ports", machineId)
+func (e *fakeInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	e.Push("IngressRules", machineId)
 	return nil, nil
 }
@@ -13,6 +13,7 @@ import (
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 )
@@ -84,25 +85,161 @@ func (c Client) ListResources(services []string) ([]resource.ServiceResources, e
 	return results, nil
 }
 
+// ListResourceHistory calls the ListResourceHistory API server method
+// with the given service name.
+func (c Client) ListResourceHistory(service string) ([]resource.HistoricalResource, error) {
+	args, err := api.NewListResourcesArgs([]string{service})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiResults api.ResourceHistoryResults
+	if err := c.FacadeCall("ListResourceHistory", &args, &apiResults); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if len(apiResults.Results) != 1 {
+		return nil, errors.Errorf("got invalid data from server (expected 1 result, got %d)", len(apiResults.Results))
+	}
+
+	history, err := api.APIResult2ResourceHistory(apiResults.Results[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return history, nil
+}
+
+// RollbackResource calls the RollbackResource API server method,
+// restoring the identified retained revision of the resource.
+func (c Client) RollbackResource(service, name string, serial int) (resource.Resource, error) {
+	args, err := api.NewRollbackResourceArgs(service, name, serial)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	var result api.RollbackResourceResult
+	if err := c.FacadeCall("RollbackResource", &args, &result); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		err := common.RestoreError(result.Error)
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	res, err := api.API2Resource(result.Resource)
+	if err != nil {
+		return resource.Resource{}, errors.Annotate(err, "got bad data from server")
+	}
+	return res, nil
+}
+
 // Upload sends the provided resource blob up to Juju.
 func (c Client) Upload(service, name string, reader io.ReadSeeker) error {
+	return c.UploadWithProgress(service, name, reader, nil)
+}
+
+// UploadWithProgress sends the provided resource blob up to Juju,
+// calling progress (if not nil) as the data is sent. The controller
+// verifies the uploaded data against the size and fingerprint
+// computed here before storing it.
+func (c Client) UploadWithProgress(service, name string, reader io.ReadSeeker, progress resource.ProgressFunc) error {
 	uReq, err := api.NewUploadRequest(service, name, reader)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return errors.Trace(c.upload(uReq, reader, progress))
+}
+
+// maxUploadAttempts is the number of times a resource upload is
+// attempted, resuming from where the previous attempt left off,
+// before giving up.
+const maxUploadAttempts = 3
+
+// upload sends the data for the upload request. If the connection
+// fails part way through, the controller is asked how much of the
+// data it received and the upload resumes from there.
+func (c Client) upload(uReq api.UploadRequest, reader io.ReadSeeker, progress resource.ProgressFunc) error {
+	var body io.ReadSeeker = reader
+	if progress != nil {
+		body = resource.NewProgressReader(reader, uReq.Size, progress)
+	}
+	for attempt := 1; ; attempt++ {
+		err := c.sendUpload(uReq, body)
+		if err == nil {
+			return nil
+		}
+		if _, ok := errors.Cause(err).(*params.Error); ok {
+			// The controller received the request and refused it;
+			// sending it again won't help.
+			return errors.Trace(err)
+		}
+		if attempt >= maxUploadAttempts {
+			return errors.Annotatef(err, "resource upload failed after %d attempts", attempt)
+		}
+		offset, statusErr := c.uploadOffset(uReq)
+		if statusErr != nil {
+			logger.Debugf("cannot resume upload of resource %q: %v", uReq.Name, statusErr)
+			return errors.Trace(err)
+		}
+		logger.Infof("resuming upload of resource %q at %d of %d bytes: %v", uReq.Name, offset, uReq.Size, err)
+		if _, err := body.Seek(offset, 0); err != nil {
+			return errors.Trace(err)
+		}
+		uReq.Offset = offset
+	}
+}
+
+// sendUpload sends the resource data from the request's offset
+// onwards. The body must already be positioned at the offset.
+func (c Client) sendUpload(uReq api.UploadRequest, body io.ReadSeeker) error {
 	req, err := uReq.HTTPRequest()
 	if err != nil {
 		return errors.Trace(err)
 	}
-
+	if uReq.Offset > 0 {
+		body = &offsetReadSeeker{ReadSeeker: body, offset: uReq.Offset}
+	}
 	var response api.UploadResult // ignored
-	if err := c.doer.Do(req, reader, &response); err != nil {
+	if err := c.doer.Do(req, body, &response); err != nil {
 		return errors.Trace(err)
 	}
-
 	return nil
 }
 
+// uploadOffset asks the controller how much of the upload's data it
+// has already received.
+func (c Client) uploadOffset(uReq api.UploadRequest) (int64, error) {
+	req, err := uReq.HTTPStatusRequest()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var response api.UploadResult
+	if err := c.doer.Do(req, nil, &response); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if response.Offset < 0 || response.Offset > uReq.Size {
+		return 0, errors.Errorf("bad data from server: upload offset %d of %d bytes", response.Offset, uReq.Size)
+	}
+	return response.Offset, nil
+}
+
+// offsetReadSeeker presents the data of the wrapped ReadSeeker from
+// the given offset onwards, so that seeking to the start (as HTTP
+// clients do before sending a body) skips the data already sent.
+type offsetReadSeeker struct {
+	io.ReadSeeker
+	offset int64
+}
+
+// Seek implements io.Seeker.
+func (r *offsetReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	if whence == 0 {
+		offset += r.offset
+	}
+	pos, err := r.ReadSeeker.Seek(offset, whence)
+	return pos - r.offset, err
+}
+
 // AddPendingResources sends the provided resource info up to Juju
 // without making it available yet.
 func (c Client) AddPendingResources(serviceID string, resources []charmresource.Resource) (pendingIDs []string, err error) {
@@ -149,13 +286,7 @@ func (c Client) AddPendingResource(serviceID string, res charmresource.Resource,
 			return "", errors.Trace(err)
 		}
 		uReq.PendingID = pendingID
-		req, err := uReq.HTTPRequest()
-		if err != nil {
-			return "", errors.Trace(err)
-		}
-
-		var response api.UploadResult // ignored
-		if err := c.doer.Do(req, reader, &response); err != nil {
+		if err := c.upload(uReq, reader, nil); err != nil {
 			return "", errors.Trace(err)
 		}
 	}
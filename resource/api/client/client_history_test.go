@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryOkay(c *gc.C) {
+	res, apiRes := newResource(c, "spam", "a-user", "spamspamspam")
+	s.facade.historyResult = api.ResourceHistoryResult{
+		Resources: []api.HistoricalResource{
+			{Resource: apiRes, Serial: 3},
+		},
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	history, err := cl.ListResourceHistory("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(history, jc.DeepEquals, []resource.HistoricalResource{
+		{Resource: res, Serial: 3},
+	})
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall",
+		"ListResourceHistory",
+		&api.ListResourcesArgs{[]params.Entity{{
+			Tag: "service-a-service",
+		}}},
+		&api.ResourceHistoryResults{
+			Results: []api.ResourceHistoryResult{
+				s.facade.historyResult,
+			},
+		},
+	)
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryBadService(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ListResourceHistory("???")
+
+	c.Check(err, gc.ErrorMatches, `.*invalid service.*`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceOkay(c *gc.C) {
+	res, apiRes := newResource(c, "spam", "a-user", "spamspamspam")
+	s.facade.rollbackResult = api.RollbackResourceResult{
+		Resource: apiRes,
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	restored, err := cl.RollbackResource("a-service", "spam", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(restored, jc.DeepEquals, res)
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall",
+		"RollbackResource",
+		&api.RollbackResourceArgs{
+			Entity: params.Entity{Tag: "service-a-service"},
+			Name:   "spam",
+			Serial: 3,
+		},
+		&s.facade.rollbackResult,
+	)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceNotFound(c *gc.C) {
+	s.facade.rollbackResult = api.RollbackResourceResult{
+		ErrorResult: params.ErrorResult{
+			Error: &params.Error{
+				Message: `revision 3 of resource "spam" not found`,
+				Code:    params.CodeNotFound,
+			},
+		},
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.RollbackResource("a-service", "spam", 3)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceBadSerial(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.RollbackResource("a-service", "spam", 0)
+
+	c.Check(err, gc.ErrorMatches, `invalid revision 0`)
+	s.stub.CheckNoCalls(c)
+}
@@ -6,6 +6,7 @@ package client_test
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -16,6 +17,7 @@ import (
 	gc "gopkg.in/check.v1"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/resource/api/client"
 )
 
@@ -47,6 +49,26 @@ func (s *UploadSuite) TestOkay(c *gc.C) {
 	s.stub.CheckCall(c, 3, "Do", req, reader, s.response)
 }
 
+func (s *UploadSuite) TestWithProgress(c *gc.C) {
+	data := "<data>"
+	_, s.response.Resource = newResource(c, "spam", "a-user", data)
+	var reported []int64
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.UploadWithProgress("a-service", "spam", strings.NewReader(data), func(transferred, total int64) {
+		c.Check(total, gc.Equals, int64(len(data)))
+		reported = append(reported, transferred)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do")
+	body := s.stub.Calls()[0].Args[1].(io.Reader)
+	sent, err := ioutil.ReadAll(body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(sent), gc.Equals, data)
+	c.Check(reported, jc.DeepEquals, []int64{int64(len(data))})
+}
+
 func (s *UploadSuite) TestBadService(c *gc.C) {
 	cl := client.NewClient(s.facade, s, s.facade)
 
@@ -72,7 +94,7 @@ func (s *UploadSuite) TestRequestFailed(c *gc.C) {
 	reader := &stubFile{stub: s.stub}
 	reader.returnRead = strings.NewReader("<data>")
 	cl := client.NewClient(s.facade, s, s.facade)
-	failure := errors.New("<failure>")
+	failure := &params.Error{Message: "<failure>"}
 	s.stub.SetErrors(nil, nil, nil, failure)
 
 	err := cl.Upload("a-service", "spam", reader)
@@ -81,6 +103,54 @@ func (s *UploadSuite) TestRequestFailed(c *gc.C) {
 	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do")
 }
 
+func (s *UploadSuite) TestResume(c *gc.C) {
+	reader := &stubFile{stub: s.stub}
+	reader.returnRead = strings.NewReader("<data>")
+	s.response.Offset = 2
+	cl := client.NewClient(s.facade, s, s.facade)
+	failure := errors.New("<connection reset>")
+	s.stub.SetErrors(nil, nil, nil, failure)
+
+	err := cl.Upload("a-service", "spam", reader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do", "Do", "Seek", "Do")
+	statusReq := s.stub.Calls()[4].Args[0].(*http.Request)
+	c.Check(statusReq.Method, gc.Equals, "GET")
+	c.Check(statusReq.URL.Path, gc.Equals, "/services/a-service/resources/spam")
+	s.stub.CheckCall(c, 5, "Seek", int64(2), 0)
+	resumeReq := s.stub.Calls()[6].Args[0].(*http.Request)
+	c.Check(resumeReq.Method, gc.Equals, "PUT")
+	c.Check(resumeReq.Header.Get("Content-Range"), gc.Equals, "bytes 2-5/6")
+	c.Check(resumeReq.ContentLength, gc.Equals, int64(4))
+}
+
+func (s *UploadSuite) TestResumeGivesUp(c *gc.C) {
+	reader := &stubFile{stub: s.stub}
+	reader.returnRead = strings.NewReader("<data>")
+	cl := client.NewClient(s.facade, s, s.facade)
+	failure := errors.New("<connection reset>")
+	s.stub.SetErrors(nil, nil, nil, failure, nil, nil, failure, nil, nil, failure)
+
+	err := cl.Upload("a-service", "spam", reader)
+
+	c.Check(err, gc.ErrorMatches, "resource upload failed after 3 attempts: <connection reset>")
+	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do", "Do", "Seek", "Do", "Do", "Seek", "Do")
+}
+
+func (s *UploadSuite) TestResumeNotSupported(c *gc.C) {
+	reader := &stubFile{stub: s.stub}
+	reader.returnRead = strings.NewReader("<data>")
+	cl := client.NewClient(s.facade, s, s.facade)
+	failure := errors.New("<connection reset>")
+	s.stub.SetErrors(nil, nil, nil, failure, &params.Error{Message: `unsupported method: "GET"`})
+
+	err := cl.Upload("a-service", "spam", reader)
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do", "Do")
+}
+
 func (s *UploadSuite) TestPendingOkay(c *gc.C) {
 	res, apiResult := newResourceResult(c, "a-service", "spam")
 	uuid, err := utils.NewUUID()
@@ -166,7 +236,7 @@ func (s *UploadSuite) TestPendingRequestFailed(c *gc.C) {
 	reader.returnRead = strings.NewReader("<data>")
 	s.facade.pendingIDs = []string{"some-unique-id"}
 	cl := client.NewClient(s.facade, s, s.facade)
-	failure := errors.New("<failure>")
+	failure := &params.Error{Message: "<failure>"}
 	s.stub.SetErrors(nil, nil, nil, nil, failure)
 
 	_, err := cl.AddPendingResource("a-service", res[0].Resource, reader)
@@ -119,6 +119,79 @@ type UploadResult struct {
 
 	// Resource describes the resource that was stored in the model.
 	Resource Resource
+
+	// Offset is the number of bytes of the resource data that the
+	// controller holds for an interrupted upload, in response to an
+	// upload status request. The upload is resumed from there.
+	Offset int64
+}
+
+// RollbackResourceArgs holds the arguments to the RollbackResource
+// API endpoint.
+type RollbackResourceArgs struct {
+	params.Entity
+
+	// Name identifies the resource to roll back.
+	Name string `json:"name"`
+
+	// Serial identifies the retained revision of the resource to
+	// restore.
+	Serial int `json:"serial"`
+}
+
+// NewRollbackResourceArgs returns the arguments for the
+// RollbackResource API endpoint.
+func NewRollbackResourceArgs(service, name string, serial int) (RollbackResourceArgs, error) {
+	var args RollbackResourceArgs
+	if !names.IsValidService(service) {
+		return args, errors.Errorf("invalid service %q", service)
+	}
+	if name == "" {
+		return args, errors.New("missing resource name")
+	}
+	if serial <= 0 {
+		return args, errors.Errorf("invalid revision %d", serial)
+	}
+	args.Tag = names.NewServiceTag(service).String()
+	args.Name = name
+	args.Serial = serial
+	return args, nil
+}
+
+// RollbackResourceResult holds the result of the RollbackResource
+// API endpoint.
+type RollbackResourceResult struct {
+	params.ErrorResult
+
+	// Resource describes the restored revision of the resource.
+	Resource Resource
+}
+
+// ResourceHistoryResults holds the retained resource revisions that
+// result from a bulk API call.
+type ResourceHistoryResults struct {
+	// Results is the list of resource history results.
+	Results []ResourceHistoryResult
+}
+
+// ResourceHistoryResult holds the retained resource revisions that
+// result from an API call for a single service.
+type ResourceHistoryResult struct {
+	params.ErrorResult
+
+	// Resources is the list of retained revisions of the service's
+	// resources.
+	Resources []HistoricalResource
+}
+
+// HistoricalResource contains info about a retained revision of
+// a resource.
+type HistoricalResource struct {
+	Resource
+
+	// Serial identifies the revision among the retained revisions
+	// of the resource.
+	Serial int `json:"serial"`
 }
 
 // Resource contains info about a Resource.
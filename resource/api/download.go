@@ -6,6 +6,8 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
@@ -21,6 +23,22 @@ func NewHTTPDownloadRequest(resourceName string) (*http.Request, error) {
 	return http.NewRequest("GET", "/resources/"+resourceName, nil)
 }
 
+// NewHTTPDownloadRangeRequest creates a new HTTP download request for
+// the given resource which asks for the data from the given offset
+// onwards. It is used to resume an interrupted download.
+//
+// Intended for use on the client side.
+func NewHTTPDownloadRangeRequest(resourceName string, offset int64) (*http.Request, error) {
+	req, err := NewHTTPDownloadRequest(resourceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	return req, nil
+}
+
 // ExtractDownloadRequest pulls the download request info out of the
 // given HTTP request.
 //
@@ -29,6 +47,24 @@ func ExtractDownloadRequest(req *http.Request) string {
 	return req.URL.Query().Get(":resource")
 }
 
+// ExtractDownloadRange returns the offset from which the client asked
+// for the resource data to be sent. Only ranges of the form "bytes=N-"
+// are supported; any other Range header is ignored, as permitted by
+// RFC 7233, and the offset is 0.
+//
+// Intended for use on the server side.
+func ExtractDownloadRange(req *http.Request) int64 {
+	spec := req.Header.Get("Range")
+	if !strings.HasPrefix(spec, "bytes=") || !strings.HasSuffix(spec, "-") {
+		return 0
+	}
+	offset, err := strconv.ParseInt(spec[len("bytes="):len(spec)-1], 10, 64)
+	if err != nil || offset < 0 {
+		return 0
+	}
+	return offset
+}
+
 // UpdateDownloadResponse sets the appropriate headers in the response
 // to an HTTP download request.
 //
@@ -37,14 +73,47 @@ func UpdateDownloadResponse(resp http.ResponseWriter, resource resource.Resource
 	resp.Header().Set("Content-Type", ContentTypeRaw)
 	resp.Header().Set("Content-Length", fmt.Sprint(resource.Size))
 	resp.Header().Set("Content-Sha384", resource.Fingerprint.String())
+	resp.Header().Set("Accept-Ranges", "bytes")
 }
 
-// ExtractDownloadResponse pulls the download size and checksum
-// from the HTTP response.
-func ExtractDownloadResponse(resp *http.Response) (int64, charmresource.Fingerprint, error) {
-	var fp charmresource.Fingerprint
+// UpdateRangeDownloadResponse sets the appropriate headers in the
+// response to an HTTP download request for the resource data from
+// the given offset onwards. The fingerprint is always that of the
+// complete resource.
+//
+// Intended for use on the server side.
+func UpdateRangeDownloadResponse(resp http.ResponseWriter, resource resource.Resource, offset int64) {
+	UpdateDownloadResponse(resp, resource)
+	resp.Header().Set("Content-Length", fmt.Sprint(resource.Size-offset))
+	resp.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, resource.Size-1, resource.Size))
+}
+
+// ExtractDownloadResponse pulls the size and checksum of the complete
+// resource from the HTTP response, along with the offset at which the
+// data in the body starts.
+//
+// Intended for use on the client side.
+func ExtractDownloadResponse(resp *http.Response) (offset, size int64, fp charmresource.Fingerprint, err error) {
+	fp, err = charmresource.ParseFingerprint(resp.Header.Get("Content-Sha384"))
+	if err != nil {
+		return 0, 0, fp, errors.Annotate(err, "invalid fingerprint")
+	}
+
+	length, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return 0, 0, fp, errors.Annotate(err, "invalid size")
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, length, fp, nil
+	}
 
-	// TODO(ericsnow) Finish!
-	// See UpdateDownloadResponse for the data to extract.
-	return 0, fp, errors.New("not finished")
+	var last int64
+	contentRange := resp.Header.Get("Content-Range")
+	if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &offset, &last, &size); err != nil {
+		return 0, 0, fp, errors.Annotatef(err, "invalid content range %q", contentRange)
+	}
+	if offset+length != size {
+		return 0, 0, fp, errors.Errorf("content range %q does not match size %d", contentRange, length)
+	}
+	return offset, size, fp, nil
 }
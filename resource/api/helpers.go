@@ -130,6 +130,41 @@ func API2Resource(apiRes Resource) (resource.Resource, error) {
 	return res, nil
 }
 
+// HistoricalResource2API converts a resource.HistoricalResource into
+// a HistoricalResource struct.
+func HistoricalResource2API(res resource.HistoricalResource) HistoricalResource {
+	return HistoricalResource{
+		Resource: Resource2API(res.Resource),
+		Serial:   res.Serial,
+	}
+}
+
+// APIResult2ResourceHistory converts a ResourceHistoryResult into
+// a list of resource.HistoricalResource.
+func APIResult2ResourceHistory(apiResult ResourceHistoryResult) ([]resource.HistoricalResource, error) {
+	if apiResult.Error != nil {
+		err := common.RestoreError(apiResult.Error)
+		return nil, errors.Trace(err)
+	}
+
+	var history []resource.HistoricalResource
+	for _, apiRes := range apiResult.Resources {
+		res, err := API2Resource(apiRes.Resource)
+		if err != nil {
+			return nil, errors.Annotate(err, "got bad data from server")
+		}
+		hist := resource.HistoricalResource{
+			Resource: res,
+			Serial:   apiRes.Serial,
+		}
+		if err := hist.Validate(); err != nil {
+			return nil, errors.Annotate(err, "got bad data from server")
+		}
+		history = append(history, hist)
+	}
+	return history, nil
+}
+
 // CharmResource2API converts a charm resource into
 // a CharmResource struct.
 func CharmResource2API(res charmresource.Resource) CharmResource {
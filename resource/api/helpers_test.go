@@ -577,3 +577,59 @@ func (HelpersSuite) TestServiceResource2API(c *gc.C) {
 	})
 
 }
+
+func (HelpersSuite) TestHistoricalResource2API(c *gc.C) {
+	res := resourcetesting.NewResource(c, nil, "spam", "a-service", "spamspamspam").Resource
+	hist := resource.HistoricalResource{
+		Resource: res,
+		Serial:   2,
+	}
+
+	apiRes := api.HistoricalResource2API(hist)
+
+	c.Check(apiRes, jc.DeepEquals, api.HistoricalResource{
+		Resource: api.Resource2API(res),
+		Serial:   2,
+	})
+}
+
+func (HelpersSuite) TestAPIResult2ResourceHistoryOkay(c *gc.C) {
+	res := resourcetesting.NewResource(c, nil, "spam", "a-service", "spamspamspam").Resource
+	expected := []resource.HistoricalResource{{
+		Resource: res,
+		Serial:   2,
+	}}
+
+	history, err := api.APIResult2ResourceHistory(api.ResourceHistoryResult{
+		Resources: []api.HistoricalResource{
+			api.HistoricalResource2API(expected[0]),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(history, jc.DeepEquals, expected)
+}
+
+func (HelpersSuite) TestAPIResult2ResourceHistoryMissingSerial(c *gc.C) {
+	res := resourcetesting.NewResource(c, nil, "spam", "a-service", "spamspamspam").Resource
+
+	_, err := api.APIResult2ResourceHistory(api.ResourceHistoryResult{
+		Resources: []api.HistoricalResource{{
+			Resource: api.Resource2API(res),
+		}},
+	})
+
+	c.Check(err, gc.ErrorMatches, `got bad data from server: .*missing serial.*`)
+}
+
+func (HelpersSuite) TestAPIResult2ResourceHistoryFailure(c *gc.C) {
+	_, err := api.APIResult2ResourceHistory(api.ResourceHistoryResult{
+		ErrorResult: params.ErrorResult{
+			Error: &params.Error{
+				Message: "<failure>",
+			},
+		},
+	})
+
+	c.Check(err, gc.ErrorMatches, "<failure>")
+}
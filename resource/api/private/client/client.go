@@ -4,11 +4,13 @@
 package client
 
 import (
+	"bytes"
 	"io"
 	"net/http"
 	"path"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/resource"
@@ -16,6 +18,8 @@ import (
 	"github.com/juju/juju/resource/api/private"
 )
 
+var logger = loggo.GetLogger("juju.resource.api.private.client")
+
 // FacadeCaller exposes the raw API caller functionality needed here.
 type FacadeCaller interface {
 	// FacadeCall makes an API request.
@@ -54,28 +58,126 @@ type UnitFacadeClient struct {
 	HTTPClient
 }
 
+// maxDownloadAttempts is the number of times a resource download is
+// attempted, resuming from where the previous attempt left off, before
+// giving up.
+const maxDownloadAttempts = 3
+
 // GetResource opens the resource (metadata/blob), if it exists, via
 // the HTTP API and returns it. If it does not exist or hasn't been
 // uploaded yet then errors.NotFound is returned.
+//
+// The returned reader resumes the download if the connection fails
+// part way through. Its data is not otherwise verified, so the caller
+// must check it against the returned resource's fingerprint.
 func (c *UnitFacadeClient) GetResource(resourceName string) (resource.Resource, io.ReadCloser, error) {
-	var response *http.Response
-	req, err := api.NewHTTPDownloadRequest(resourceName)
+	response, err := c.download(resourceName, 0)
 	if err != nil {
-		return resource.Resource{}, nil, errors.Annotate(err, "failed to build API request")
-	}
-	if err := c.Do(req, nil, &response); err != nil {
-		return resource.Resource{}, nil, errors.Annotate(err, "HTTP request failed")
+		return resource.Resource{}, nil, errors.Trace(err)
 	}
 
 	// HACK(katco): Combine this into one request?
 	resourceInfo, err := c.getResourceInfo(resourceName)
 	if err != nil {
+		response.Body.Close()
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+
+	if err := checkDownloadResponse(response, resourceInfo, 0); err != nil {
+		response.Body.Close()
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
+	reader := &resumingReader{
+		client: c,
+		info:   resourceInfo,
+		body:   response.Body,
+	}
+	return resourceInfo, reader, nil
+}
+
+// download requests the resource's data from the given offset onwards.
+func (c *UnitFacadeClient) download(resourceName string, offset int64) (*http.Response, error) {
+	var response *http.Response
+	req, err := api.NewHTTPDownloadRangeRequest(resourceName, offset)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to build API request")
+	}
+	if err := c.Do(req, nil, &response); err != nil {
+		return nil, errors.Annotate(err, "HTTP request failed")
+	}
+	return response, nil
+}
+
+// checkDownloadResponse ensures that the response describes the
+// expected resource and holds its data from the given offset.
+func checkDownloadResponse(response *http.Response, info resource.Resource, offset int64) error {
+	respOffset, size, fp, err := api.ExtractDownloadResponse(response)
+	if err != nil {
+		return errors.Annotate(err, "got bad response from API server")
+	}
+	if respOffset != offset {
+		return errors.Errorf("resource download started at offset %d, expected %d", respOffset, offset)
+	}
+	if size != info.Size {
+		return errors.Errorf("resource size does not match expected (%d != %d)", size, info.Size)
+	}
+	if !bytes.Equal(fp.Bytes(), info.Fingerprint.Bytes()) {
+		return errors.Errorf("resource fingerprint does not match expected (%q != %q)", fp, info.Fingerprint)
+	}
+	return nil
+}
+
+// resumingReader reads a resource's data from the HTTP API, asking
+// for the remainder of the data whenever the connection fails before
+// all of it has been read.
+type resumingReader struct {
+	client   *UnitFacadeClient
+	info     resource.Resource
+	body     io.ReadCloser
+	read     int64
+	attempts int
+}
+
+// Read implements io.Reader.
+func (r *resumingReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.body.Read(p)
+		r.read += int64(n)
+		if err == nil || n > 0 {
+			return n, nil
+		}
+		if err == io.EOF && r.read >= r.info.Size {
+			return 0, io.EOF
+		}
+		if err := r.resume(err); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+}
+
+// resume replaces the failed response body with a new one holding
+// the unread remainder of the data.
+func (r *resumingReader) resume(cause error) error {
+	r.attempts++
+	if r.attempts >= maxDownloadAttempts {
+		return errors.Annotatef(cause, "resource download failed after %d attempts", r.attempts)
+	}
+	logger.Infof("resuming download of resource %q at %d of %d bytes: %v", r.info.Name, r.read, r.info.Size, cause)
+	r.body.Close()
+	response, err := r.client.download(r.info.Name, r.read)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	r.body = response.Body
+	if err := checkDownloadResponse(response, r.info, r.read); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
 
-	// TODO(katco): Check headers against resource info
-	// TODO(katco): Check in on all the response headers
-	return resourceInfo, response.Body, nil
+// Close implements io.Closer.
+func (r *resumingReader) Close() error {
+	return r.body.Close()
 }
 
 func (c *UnitFacadeClient) getResourceInfo(resourceName string) (resource.Resource, error) {
@@ -4,8 +4,11 @@
 package client_test
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -57,7 +60,66 @@ func (s *UnitFacadeClientSuite) TestGetResource(c *gc.C) {
 
 	s.stub.CheckCallNames(c, "Do", "FacadeCall")
 	c.Check(info, jc.DeepEquals, opened.Resource)
-	c.Check(content, jc.DeepEquals, opened)
+	data, err := ioutil.ReadAll(content)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "some data")
+}
+
+func (s *UnitFacadeClientSuite) TestGetResourceBadFingerprint(c *gc.C) {
+	opened := resourcetesting.NewResource(c, s.stub, "spam", "a-service", "some data")
+	s.api.setResource(opened.Resource, opened)
+	other := resourcetesting.NewResource(c, s.stub, "spam", "a-service", "other data")
+	s.api.ReturnDo.Header.Set("Content-Sha384", other.Fingerprint.String())
+	cl := client.NewUnitFacadeClient(s.api, s.api)
+
+	_, _, err := cl.GetResource("spam")
+
+	c.Check(err, gc.ErrorMatches, `resource fingerprint does not match expected .*`)
+	s.stub.CheckCallNames(c, "Do", "FacadeCall", "Close")
+}
+
+func (s *UnitFacadeClientSuite) TestGetResourceResumes(c *gc.C) {
+	opened := resourcetesting.NewResource(c, s.stub, "spam", "a-service", "some data")
+	s.api.setResource(opened.Resource, &brokenBody{
+		Reader: strings.NewReader("some "),
+		err:    errors.New("connection reset"),
+	})
+	resumed := &http.Response{
+		StatusCode: http.StatusPartialContent,
+		Header: http.Header{
+			"Content-Length": []string{"4"},
+			"Content-Range":  []string{"bytes 5-8/9"},
+			"Content-Sha384": []string{opened.Fingerprint.String()},
+		},
+		Body: ioutil.NopCloser(strings.NewReader("data")),
+	}
+	s.api.ReturnDos = []*http.Response{s.api.ReturnDo, resumed}
+	cl := client.NewUnitFacadeClient(s.api, s.api)
+
+	_, content, err := cl.GetResource("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(content)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(string(data), gc.Equals, "some data")
+	s.stub.CheckCallNames(c, "Do", "FacadeCall", "Do")
+	req := s.stub.Calls()[2].Args[0].(*http.Request)
+	c.Check(req.Header.Get("Range"), gc.Equals, "bytes=5-")
+}
+
+func (s *UnitFacadeClientSuite) TestGetResourceGivesUp(c *gc.C) {
+	opened := resourcetesting.NewResource(c, s.stub, "spam", "a-service", "some data")
+	s.api.setResource(opened.Resource, &brokenBody{
+		Reader: strings.NewReader(""),
+		err:    errors.New("connection reset"),
+	})
+	cl := client.NewUnitFacadeClient(s.api, s.api)
+
+	_, content, err := cl.GetResource("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ioutil.ReadAll(content)
+
+	c.Check(err, gc.ErrorMatches, `resource download failed after 3 attempts: connection reset`)
 }
 
 func (s *UnitFacadeClientSuite) TestUnitDoer(c *gc.C) {
@@ -81,6 +143,7 @@ type stubAPI struct {
 	ReturnFacadeCall private.ResourcesResult
 	ReturnUnit       string
 	ReturnDo         *http.Response
+	ReturnDos        []*http.Response
 }
 
 func (s *stubAPI) setResource(info resource.Resource, reader io.ReadCloser) {
@@ -90,6 +153,11 @@ func (s *stubAPI) setResource(info resource.Resource, reader io.ReadCloser) {
 		}},
 	}
 	s.ReturnDo = &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Length": []string{fmt.Sprint(info.Size)},
+			"Content-Sha384": []string{info.Fingerprint.String()},
+		},
 		Body: reader,
 	}
 }
@@ -120,5 +188,28 @@ func (s *stubAPI) Do(req *http.Request, body io.ReadSeeker, response interface{}
 
 	resp := response.(**http.Response)
 	*resp = s.ReturnDo
+	if len(s.ReturnDos) > 0 {
+		*resp = s.ReturnDos[0]
+		s.ReturnDos = s.ReturnDos[1:]
+	}
+	return nil
+}
+
+// brokenBody is a response body whose connection fails after
+// the reader's data has been read.
+type brokenBody struct {
+	io.Reader
+	err error
+}
+
+func (b *brokenBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		err = b.err
+	}
+	return n, err
+}
+
+func (b *brokenBody) Close() error {
 	return nil
 }
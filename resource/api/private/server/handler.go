@@ -7,6 +7,7 @@ package server
 
 import (
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/juju/errors"
@@ -55,9 +56,23 @@ func (h *LegacyHTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Reques
 		}
 		defer opened.Close()
 
-		h.UpdateDownloadResponse(resp, opened.Resource)
-
-		resp.WriteHeader(http.StatusOK)
+		// A client resuming an interrupted download asks for the
+		// data from where it left off. We still read (and discard)
+		// the skipped data so that the unit's copy of the resource
+		// is recorded once the whole of it has been streamed.
+		offset := api.ExtractDownloadRange(req)
+		if offset > 0 && offset < opened.Size {
+			if _, err := io.CopyN(ioutil.Discard, opened, offset); err != nil {
+				logger.Errorf("cannot skip to offset %d of resource: %v", offset, err)
+				h.SendHTTPError(resp, err)
+				return
+			}
+			api.UpdateRangeDownloadResponse(resp, opened.Resource, offset)
+			resp.WriteHeader(http.StatusPartialContent)
+		} else {
+			h.UpdateDownloadResponse(resp, opened.Resource)
+			resp.WriteHeader(http.StatusOK)
+		}
 		if err := h.Copy(resp, opened); err != nil {
 			// We cannot use api.SendHTTPError here, so we log the error
 			// and move on.
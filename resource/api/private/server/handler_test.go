@@ -63,9 +63,42 @@ func (s *LegacyHTTPHandlerSuite) TestIntegration(c *gc.C) {
 		"Content-Type":   []string{api.ContentTypeRaw},
 		"Content-Length": []string{"9"}, // len("some data")
 		"Content-Sha384": []string{opened.Fingerprint.String()},
+		"Accept-Ranges":  []string{"bytes"},
 	})
 }
 
+func (s *LegacyHTTPHandlerSuite) TestIntegrationRange(c *gc.C) {
+	opened := resourcetesting.NewResource(c, s.stub, "spam", "a-service", "some data")
+	s.opener.ReturnOpenResource = opened
+	s.deps.ReturnNewResourceOpener = s.opener
+	deps := server.NewLegacyHTTPHandlerDeps(s.deps)
+	h := server.NewLegacyHTTPHandler(deps)
+	req, err := api.NewHTTPDownloadRangeRequest("spam", 5)
+	c.Assert(err, jc.ErrorIsNil)
+	req.URL, err = url.ParseRequestURI("https://api:17018/units/eggs/1/resources/spam?:resource=spam")
+	c.Assert(err, jc.ErrorIsNil)
+	resp := &fakeResponseWriter{
+		stubResponseWriter: s.resp,
+	}
+
+	h.ServeHTTP(resp, req)
+
+	resp.checkWritten(c, "data", http.Header{
+		"Content-Type":   []string{api.ContentTypeRaw},
+		"Content-Length": []string{"4"}, // len("data")
+		"Content-Range":  []string{"bytes 5-8/9"},
+		"Content-Sha384": []string{opened.Fingerprint.String()},
+		"Accept-Ranges":  []string{"bytes"},
+	})
+	var codes []interface{}
+	for _, call := range s.stub.Calls() {
+		if call.FuncName == "WriteHeader" {
+			codes = append(codes, call.Args[0])
+		}
+	}
+	c.Check(codes, jc.DeepEquals, []interface{}{http.StatusPartialContent})
+}
+
 func (s *LegacyHTTPHandlerSuite) TestNewLegacyHTTPHandler(c *gc.C) {
 	h := server.NewLegacyHTTPHandler(s.deps)
 
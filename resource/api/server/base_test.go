@@ -69,6 +69,8 @@ type stubDataStore struct {
 	ReturnSetResource           resource.Resource
 	ReturnUpdatePendingResource resource.Resource
 	ReturnUnits                 []names.UnitTag
+	ReturnListResourceHistory   []resource.HistoricalResource
+	ReturnRollbackResource      resource.Resource
 }
 
 func (s *stubDataStore) ListResources(service string) (resource.ServiceResources, error) {
@@ -133,3 +135,21 @@ func (s *stubDataStore) Units(serviceID string) ([]names.UnitTag, error) {
 
 	return s.ReturnUnits, nil
 }
+
+func (s *stubDataStore) ListResourceHistory(serviceID string) ([]resource.HistoricalResource, error) {
+	s.stub.AddCall("ListResourceHistory", serviceID)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceHistory, nil
+}
+
+func (s *stubDataStore) RollbackResource(serviceID, name string, serial int) (resource.Resource, error) {
+	s.stub.AddCall("RollbackResource", serviceID, name, serial)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	return s.ReturnRollbackResource, nil
+}
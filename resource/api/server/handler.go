@@ -24,21 +24,36 @@ type LegacyHTTPHandler struct {
 
 	// HandleUpload provides the upload functionality.
 	HandleUpload func(username string, st DataStore, req *http.Request) (*api.UploadResult, error)
+
+	// HandleUploadStatus reports how much of an interrupted upload
+	// has been received.
+	HandleUploadStatus func(username string, st DataStore, req *http.Request) (*api.UploadResult, error)
 }
 
 // TODO(ericsnow) Can username be extracted from the request?
 
-// NewLegacyHTTPHandler creates a new http.Handler for the resources endpoint.
-func NewLegacyHTTPHandler(connect func(*http.Request) (DataStore, names.Tag, error)) *LegacyHTTPHandler {
+// NewLegacyHTTPHandler creates a new http.Handler for the resources
+// endpoint. Uploads are staged until complete, so that interrupted
+// uploads may be resumed.
+func NewLegacyHTTPHandler(connect func(*http.Request) (DataStore, names.Tag, error), staging UploadStaging) *LegacyHTTPHandler {
 	return &LegacyHTTPHandler{
 		Connect: connect,
 		HandleUpload: func(username string, st DataStore, req *http.Request) (*api.UploadResult, error) {
 			uh := UploadHandler{
 				Username: username,
 				Store:    st,
+				Staging:  staging,
 			}
 			return uh.HandleRequest(req)
 		},
+		HandleUploadStatus: func(username string, st DataStore, req *http.Request) (*api.UploadResult, error) {
+			uh := UploadHandler{
+				Username: username,
+				Store:    st,
+				Staging:  staging,
+			}
+			return uh.HandleStatusRequest(req)
+		},
 	}
 }
 
@@ -71,6 +86,13 @@ func (h *LegacyHTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Reques
 		}
 		api.SendHTTPStatusAndJSON(resp, http.StatusOK, &response)
 		logger.Infof("resource upload request successful")
+	case "GET":
+		response, err := h.HandleUploadStatus(username, st, req)
+		if err != nil {
+			api.SendHTTPError(resp, err)
+			return
+		}
+		api.SendHTTPStatusAndJSON(resp, http.StatusOK, &response)
 	default:
 		api.SendHTTPError(resp, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
 	}
@@ -70,6 +70,15 @@ func (s *LegacyHTTPHandlerSuite) handleUpload(username string, st server.DataSto
 	return s.result, nil
 }
 
+func (s *LegacyHTTPHandlerSuite) handleUploadStatus(username string, st server.DataStore, req *http.Request) (*api.UploadResult, error) {
+	s.stub.AddCall("HandleUploadStatus", username, st, req)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.result, nil
+}
+
 func (s *LegacyHTTPHandlerSuite) TestServeHTTPConnectFailure(c *gc.C) {
 	s.username = "youknowwho"
 	handler := server.LegacyHTTPHandler{
@@ -197,6 +206,35 @@ func (s *LegacyHTTPHandlerSuite) TestServeHTTPPutHandleUploadFailure(c *gc.C) {
 	})
 }
 
+func (s *LegacyHTTPHandlerSuite) TestServeHTTPGetUploadStatus(c *gc.C) {
+	s.result.Offset = 42
+	expected, err := json.Marshal(s.result)
+	c.Assert(err, jc.ErrorIsNil)
+	s.username = "youknowwho"
+	handler := server.LegacyHTTPHandler{
+		Connect:            s.connect,
+		HandleUpload:       s.handleUpload,
+		HandleUploadStatus: s.handleUploadStatus,
+	}
+	s.req.Method = "GET"
+	copied := *s.req
+	req := &copied
+
+	handler.ServeHTTP(s.resp, req)
+
+	s.stub.CheckCallNames(c,
+		"Connect",
+		"HandleUploadStatus",
+		"Header",
+		"Header",
+		"WriteHeader",
+		"Write",
+	)
+	s.stub.CheckCall(c, 1, "HandleUploadStatus", "youknowwho", s.data, req)
+	s.stub.CheckCall(c, 4, "WriteHeader", http.StatusOK)
+	s.stub.CheckCall(c, 5, "Write", string(expected))
+}
+
 func apiFailure(c *gc.C, msg, code string) (error, string) {
 	failure := errors.New(msg)
 
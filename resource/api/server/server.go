@@ -57,6 +57,14 @@ type resourceInfoStore interface {
 
 	// Units returns the tags for all units in the given service.
 	Units(serviceID string) (units []names.UnitTag, err error)
+
+	// ListResourceHistory returns the retained previous revisions of
+	// the service's uploaded resources.
+	ListResourceHistory(serviceID string) ([]resource.HistoricalResource, error)
+
+	// RollbackResource restores the identified retained revision
+	// of the resource as the service's current revision.
+	RollbackResource(serviceID, name string, serial int) (resource.Resource, error)
 }
 
 // ListResources returns the list of resources for the given service.
@@ -137,6 +145,53 @@ func (f Facade) addPendingResource(serviceID string, apiRes api.CharmResource) (
 	return pendingID, nil
 }
 
+// ListResourceHistory returns the retained previous revisions of the
+// uploaded resources of each of the given services.
+func (f Facade) ListResourceHistory(args api.ListResourcesArgs) (api.ResourceHistoryResults, error) {
+	var r api.ResourceHistoryResults
+	r.Results = make([]api.ResourceHistoryResult, len(args.Entities))
+
+	for i, e := range args.Entities {
+		logger.Tracef("Listing resource history for %q", e.Tag)
+		tag, apierr := parseServiceTag(e.Tag)
+		if apierr != nil {
+			r.Results[i].Error = apierr
+			continue
+		}
+
+		history, err := f.store.ListResourceHistory(tag.Id())
+		if err != nil {
+			r.Results[i].Error = common.ServerError(err)
+			continue
+		}
+
+		for _, res := range history {
+			r.Results[i].Resources = append(r.Results[i].Resources, api.HistoricalResource2API(res))
+		}
+	}
+	return r, nil
+}
+
+// RollbackResource restores a retained previous revision of a
+// service's resource as its current revision.
+func (f Facade) RollbackResource(args api.RollbackResourceArgs) (api.RollbackResourceResult, error) {
+	var result api.RollbackResourceResult
+
+	tag, apiErr := parseServiceTag(args.Tag)
+	if apiErr != nil {
+		result.Error = apiErr
+		return result, nil
+	}
+
+	res, err := f.store.RollbackResource(tag.Id(), args.Name, args.Serial)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	result.Resource = api.Resource2API(res)
+	return result, nil
+}
+
 func parseServiceTag(tagStr string) (names.ServiceTag, *params.Error) { // note the concrete error type
 	serviceTag, err := names.ParseServiceTag(tagStr)
 	if err != nil {
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/server"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryOkay(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "spam")
+	s.data.ReturnListResourceHistory = []resource.HistoricalResource{
+		{Resource: res1, Serial: 1},
+		{Resource: res2, Serial: 2},
+	}
+	facade := server.NewFacade(s.data)
+
+	results, err := facade.ListResourceHistory(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "service-a-service",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, api.ResourceHistoryResults{
+		Results: []api.ResourceHistoryResult{{
+			Resources: []api.HistoricalResource{
+				{Resource: apiRes1, Serial: 1},
+				{Resource: apiRes2, Serial: 2},
+			},
+		}},
+	})
+	s.stub.CheckCallNames(c, "ListResourceHistory")
+	s.stub.CheckCall(c, 0, "ListResourceHistory", "a-service")
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryBadTag(c *gc.C) {
+	facade := server.NewFacade(s.data)
+
+	results, err := facade.ListResourceHistory(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "unit-a-service-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.NotNil)
+	c.Check(results.Results[0].Error.Code, gc.Equals, params.CodeBadRequest)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+	facade := server.NewFacade(s.data)
+
+	results, err := facade.ListResourceHistory(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "service-a-service",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, api.ResourceHistoryResults{
+		Results: []api.ResourceHistoryResult{{
+			ErrorResult: params.ErrorResult{Error: &params.Error{
+				Message: "<failure>",
+			}},
+		}},
+	})
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceOkay(c *gc.C) {
+	res, apiRes := newResource(c, "spam", "a-user", "spamspamspam")
+	s.data.ReturnRollbackResource = res
+	facade := server.NewFacade(s.data)
+
+	result, err := facade.RollbackResource(api.RollbackResourceArgs{
+		Entity: params.Entity{Tag: "service-a-service"},
+		Name:   "spam",
+		Serial: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result, jc.DeepEquals, api.RollbackResourceResult{
+		Resource: apiRes,
+	})
+	s.stub.CheckCallNames(c, "RollbackResource")
+	s.stub.CheckCall(c, 0, "RollbackResource", "a-service", "spam", 2)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceNotFound(c *gc.C) {
+	s.stub.SetErrors(errors.NotFoundf("revision 2 of resource %q", "spam"))
+	facade := server.NewFacade(s.data)
+
+	result, err := facade.RollbackResource(api.RollbackResourceArgs{
+		Entity: params.Entity{Tag: "service-a-service"},
+		Name:   "spam",
+		Serial: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, params.CodeNotFound)
+}
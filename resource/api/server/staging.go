@@ -0,0 +1,376 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+)
+
+// UploadStaging holds the data received for uploads which have not
+// yet been stored, so that an interrupted upload can be resumed
+// rather than restarted.
+type UploadStaging interface {
+	// Size returns the number of bytes staged for the identified
+	// upload, which is 0 if nothing is staged.
+	Size(key string) (int64, error)
+
+	// Append adds the data read from r to that staged for the
+	// identified upload, which must be exactly offset bytes long.
+	// It returns the number of bytes then staged, which includes
+	// any data read before an error.
+	Append(key string, offset int64, r io.Reader) (int64, error)
+
+	// Tee returns a reader which reads from r, staging the data
+	// read through it as a new upload with the given key, replacing
+	// any already staged. The data remains staged after the reader
+	// is closed.
+	Tee(key string, r io.Reader) (io.ReadCloser, error)
+
+	// Open returns the data staged for the identified upload.
+	Open(key string) (io.ReadCloser, error)
+
+	// Remove discards the data staged for the identified upload.
+	Remove(key string) error
+}
+
+// SharedStaging holds copies of the data of interrupted uploads
+// where every controller can read them, so that an upload may be
+// resumed on a different controller to the one it started on.
+// It is satisfied by state/storage.Storage.
+type SharedStaging interface {
+	// Get returns the data at the given path and its length.
+	Get(path string) (io.ReadCloser, int64, error)
+
+	// Put stores the data read from r at the given path.
+	Put(path string, r io.Reader, length int64) error
+
+	// Remove removes the data at the given path.
+	Remove(path string) error
+}
+
+// DefaultUploadExpiry is how long the data of an interrupted upload
+// is kept for the upload to be resumed.
+const DefaultUploadExpiry = 24 * time.Hour
+
+// NewDirUploadStaging returns an UploadStaging which keeps the
+// staged data in files in the given directory, discarding that of
+// uploads which have not been resumed within the given expiry. If
+// shared is not nil, the data of interrupted uploads is also copied
+// to it.
+func NewDirUploadStaging(dir string, shared SharedStaging, expiry time.Duration, clock clock.Clock) UploadStaging {
+	return &dirUploadStaging{
+		dir:    dir,
+		shared: shared,
+		expiry: expiry,
+		clock:  clock,
+		locks:  make(map[string]*keyLock),
+	}
+}
+
+type dirUploadStaging struct {
+	dir    string
+	shared SharedStaging
+	expiry time.Duration
+	clock  clock.Clock
+
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+// keyLock serialises the operations on the data staged for one key.
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the data staged for the given key, returning the
+// function which unlocks it.
+func (s *dirUploadStaging) lock(key string) func() {
+	s.mu.Lock()
+	l, ok := s.locks[key]
+	if !ok {
+		l = &keyLock{}
+		s.locks[key] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		s.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(s.locks, key)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *dirUploadStaging) name(key string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))
+}
+
+func (s *dirUploadStaging) path(key string) string {
+	return filepath.Join(s.dir, s.name(key))
+}
+
+func sharedPath(name string) string {
+	return "resource-uploads/" + name
+}
+
+// expired returns whether staged data last written at the given
+// time should be discarded.
+func (s *dirUploadStaging) expired(modTime time.Time) bool {
+	return s.clock.Now().Sub(modTime) > s.expiry
+}
+
+// stat returns the size of the data staged locally for the given
+// key, discarding it if it has expired.
+func (s *dirUploadStaging) stat(key string) (int64, bool, error) {
+	info, err := os.Stat(s.path(key))
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, errors.Trace(err)
+	}
+	if s.expired(info.ModTime()) {
+		if err := s.remove(s.name(key)); err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		return 0, false, nil
+	}
+	return info.Size(), true, nil
+}
+
+// removeExpired discards the data of every upload which has not
+// been resumed within the expiry.
+func (s *dirUploadStaging) removeExpired() error {
+	infos, err := ioutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, info := range infos {
+		if !s.expired(info.ModTime()) {
+			continue
+		}
+		if err := s.remove(info.Name()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// remove discards the data staged with the given name, both locally
+// and in the shared staging.
+func (s *dirUploadStaging) remove(name string) error {
+	err := os.Remove(filepath.Join(s.dir, name))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	if s.shared != nil {
+		err := s.shared.Remove(sharedPath(name))
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// fetchShared copies the data of an upload interrupted on another
+// controller to the local staging, returning its size.
+func (s *dirUploadStaging) fetchShared(key string) (int64, error) {
+	if s.shared == nil {
+		return 0, nil
+	}
+	r, _, err := s.shared.Get(sharedPath(s.name(key)))
+	if errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer r.Close()
+	f, err := os.OpenFile(s.path(key), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+	n, err := io.Copy(f, r)
+	return n, errors.Trace(err)
+}
+
+// share copies the data staged locally for an interrupted upload to
+// the shared staging.
+func (s *dirUploadStaging) share(key string) error {
+	if s.shared == nil {
+		return nil
+	}
+	f, err := os.Open(s.path(key))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.shared.Put(sharedPath(s.name(key)), f, info.Size()))
+}
+
+// Size implements UploadStaging.
+func (s *dirUploadStaging) Size(key string) (int64, error) {
+	unlock := s.lock(key)
+	defer unlock()
+
+	size, ok, err := s.stat(key)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if ok || s.shared == nil {
+		return size, nil
+	}
+	r, size, err := s.shared.Get(sharedPath(s.name(key)))
+	if errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	r.Close()
+	return size, nil
+}
+
+// Append implements UploadStaging.
+func (s *dirUploadStaging) Append(key string, offset int64, r io.Reader) (int64, error) {
+	if err := s.removeExpired(); err != nil {
+		return 0, errors.Trace(err)
+	}
+	unlock := s.lock(key)
+	defer unlock()
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return 0, errors.Trace(err)
+	}
+	size, ok, err := s.stat(key)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if !ok {
+		if size, err = s.fetchShared(key); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	if size != offset {
+		return size, errors.NotValidf("upload offset %d with %d bytes staged", offset, size)
+	}
+	f, err := os.OpenFile(s.path(key), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+	n, err := io.Copy(f, r)
+	if err != nil {
+		if err := s.share(key); err != nil {
+			logger.Errorf("cannot share interrupted upload: %v", err)
+		}
+	}
+	return offset + n, errors.Trace(err)
+}
+
+// Tee implements UploadStaging.
+func (s *dirUploadStaging) Tee(key string, r io.Reader) (io.ReadCloser, error) {
+	if err := s.removeExpired(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	unlock := s.lock(key)
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		unlock()
+		return nil, errors.Trace(err)
+	}
+	f, err := os.OpenFile(s.path(key), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		unlock()
+		return nil, errors.Trace(err)
+	}
+	return &teeReader{
+		staging: s,
+		key:     key,
+		r:       r,
+		f:       f,
+		unlock:  unlock,
+	}, nil
+}
+
+// teeReader stages the data read through it, holding the lock on
+// the staged data until it is closed.
+type teeReader struct {
+	staging *dirUploadStaging
+	key     string
+	r       io.Reader
+	f       *os.File
+	unlock  func()
+	err     error
+}
+
+// Read implements io.Reader.
+func (t *teeReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if n > 0 {
+		if _, err := t.f.Write(p[:n]); err != nil {
+			t.err = err
+			return n, errors.Trace(err)
+		}
+	}
+	if err != nil && err != io.EOF {
+		t.err = err
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (t *teeReader) Close() error {
+	defer t.unlock()
+	if err := t.f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if t.err != nil {
+		if err := t.staging.share(t.key); err != nil {
+			logger.Errorf("cannot share interrupted upload: %v", err)
+		}
+	}
+	return nil
+}
+
+// Open implements UploadStaging.
+func (s *dirUploadStaging) Open(key string) (io.ReadCloser, error) {
+	unlock := s.lock(key)
+	defer unlock()
+
+	f, err := os.Open(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("staged upload")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// Remove implements UploadStaging.
+func (s *dirUploadStaging) Remove(key string) error {
+	unlock := s.lock(key)
+	defer unlock()
+
+	return errors.Trace(s.remove(s.name(key)))
+}
@@ -0,0 +1,206 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/resource/api/server"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type StagingSuite struct {
+	testing.IsolationSuite
+
+	clock *coretesting.Clock
+}
+
+var _ = gc.Suite(&StagingSuite{})
+
+func (s *StagingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Now())
+}
+
+func (s *StagingSuite) newStaging(c *gc.C, shared server.SharedStaging) server.UploadStaging {
+	return server.NewDirUploadStaging(c.MkDir(), shared, time.Hour, s.clock)
+}
+
+func (s *StagingSuite) TestAppend(c *gc.C) {
+	staging := s.newStaging(c, nil)
+
+	size, err := staging.Size("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(0))
+
+	size, err = staging.Append("a-key", 0, strings.NewReader("<some "))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(6))
+	size, err = staging.Append("a-key", 6, strings.NewReader("data>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(11))
+
+	size, err = staging.Size("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(11))
+	size, err = staging.Size("another-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(0))
+
+	data, err := staging.Open("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	defer data.Close()
+	content, err := ioutil.ReadAll(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "<some data>")
+}
+
+func (s *StagingSuite) TestAppendWrongOffset(c *gc.C) {
+	staging := s.newStaging(c, nil)
+	_, err := staging.Append("a-key", 0, strings.NewReader("<some "))
+	c.Assert(err, jc.ErrorIsNil)
+
+	size, err := staging.Append("a-key", 2, strings.NewReader("data>"))
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(size, gc.Equals, int64(6))
+}
+
+func (s *StagingSuite) TestRemove(c *gc.C) {
+	staging := s.newStaging(c, nil)
+	_, err := staging.Append("a-key", 0, strings.NewReader("<some data>"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = staging.Remove("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	err = staging.Remove("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+
+	size, err := staging.Size("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(0))
+	_, err = staging.Open("a-key")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StagingSuite) TestTee(c *gc.C) {
+	staging := s.newStaging(c, nil)
+	_, err := staging.Append("a-key", 0, strings.NewReader("<old data>"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := staging.Tee("a-key", strings.NewReader("<some data>"))
+	c.Assert(err, jc.ErrorIsNil)
+	content, err := ioutil.ReadAll(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "<some data>")
+	err = data.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	staged, err := staging.Open("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	defer staged.Close()
+	content, err = ioutil.ReadAll(staged)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "<some data>")
+}
+
+func (s *StagingSuite) TestExpiry(c *gc.C) {
+	staging := s.newStaging(c, nil)
+	_, err := staging.Append("a-key", 0, strings.NewReader("<some "))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(2 * time.Hour)
+
+	size, err := staging.Size("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(0))
+	_, err = staging.Append("a-key", 6, strings.NewReader("data>"))
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *StagingSuite) TestExpiryRemovesOtherUploads(c *gc.C) {
+	shared := newSharedStaging()
+	staging := s.newStaging(c, shared)
+	_, err := staging.Append("a-key", 0, io.MultiReader(strings.NewReader("<some "), interruptedReader{}))
+	c.Assert(err, gc.ErrorMatches, "<connection reset>")
+	c.Check(shared.data, gc.HasLen, 1)
+
+	s.clock.Advance(2 * time.Hour)
+	_, err = staging.Append("another-key", 0, strings.NewReader("<some data>"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(shared.data, gc.HasLen, 0)
+}
+
+func (s *StagingSuite) TestResumeOnAnotherController(c *gc.C) {
+	shared := newSharedStaging()
+	staging := s.newStaging(c, shared)
+	size, err := staging.Append("a-key", 0, io.MultiReader(strings.NewReader("<some "), interruptedReader{}))
+	c.Assert(err, gc.ErrorMatches, "<connection reset>")
+	c.Check(size, gc.Equals, int64(6))
+
+	other := s.newStaging(c, shared)
+	size, err = other.Size("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(6))
+	size, err = other.Append("a-key", 6, strings.NewReader("data>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(11))
+
+	data, err := other.Open("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	defer data.Close()
+	content, err := ioutil.ReadAll(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "<some data>")
+
+	err = other.Remove("a-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(shared.data, gc.HasLen, 0)
+}
+
+// sharedStaging is an in-memory server.SharedStaging.
+type sharedStaging struct {
+	data map[string][]byte
+}
+
+func newSharedStaging() *sharedStaging {
+	return &sharedStaging{data: make(map[string][]byte)}
+}
+
+func (s *sharedStaging) Get(path string) (io.ReadCloser, int64, error) {
+	data, ok := s.data[path]
+	if !ok {
+		return nil, 0, errors.NotFoundf("%q", path)
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+func (s *sharedStaging) Put(path string, r io.Reader, length int64) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if int64(len(data)) != length {
+		return errors.Errorf("expected %d bytes, got %d", length, len(data))
+	}
+	s.data[path] = data
+	return nil
+}
+
+func (s *sharedStaging) Remove(path string) error {
+	if _, ok := s.data[path]; !ok {
+		return errors.NotFoundf("%q", path)
+	}
+	delete(s.data, path)
+	return nil
+}
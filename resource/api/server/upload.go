@@ -4,6 +4,7 @@
 package server
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 
@@ -46,6 +47,10 @@ type UploadedResource struct {
 
 	// Data holds the resource blob.
 	Data io.ReadCloser
+
+	// Offset is the position in the resource data at which Data
+	// starts. It is non-zero when an interrupted upload is resumed.
+	Offset int64
 }
 
 // UploadHandler provides the functionality to handle upload requests.
@@ -55,6 +60,11 @@ type UploadHandler struct {
 
 	// Store is the data store into which the resource will be stored.
 	Store UploadDataStore
+
+	// Staging, if not nil, holds the data of uploads until it is
+	// complete, so that interrupted uploads may be resumed. If it
+	// is nil, uploads must be sent in full.
+	Staging UploadStaging
 }
 
 // HandleRequest handles a resource upload request.
@@ -66,17 +76,23 @@ func (uh UploadHandler) HandleRequest(req *http.Request) (*api.UploadResult, err
 		return nil, errors.Trace(err)
 	}
 
+	// Uploads are staged as they are received, so that an interrupted
+	// upload can be resumed. A resumed upload is only stored once all
+	// of its data has been staged.
+	key := uploadKey(req, uploaded.Service, uploaded.Resource.Name, uploaded.PendingID, uploaded.Resource.Fingerprint)
 	var stored resource.Resource
-	if uploaded.PendingID != "" {
-		stored, err = uh.Store.UpdatePendingResource(uploaded.Service, uploaded.PendingID, uh.Username, uploaded.Resource, uploaded.Data)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-	} else {
-		stored, err = uh.Store.SetResource(uploaded.Service, uh.Username, uploaded.Resource, uploaded.Data)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
+	switch {
+	case uh.Staging == nil && uploaded.Offset > 0:
+		return nil, errors.NotSupportedf("resuming uploads")
+	case uh.Staging == nil:
+		stored, err = uh.store(uploaded)
+	case uploaded.Offset == 0:
+		stored, err = uh.storeStreamed(key, uploaded)
+	default:
+		stored, err = uh.storeStaged(key, uploaded)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	result := &api.UploadResult{
@@ -85,6 +101,120 @@ func (uh UploadHandler) HandleRequest(req *http.Request) (*api.UploadResult, err
 	return result, nil
 }
 
+// store adds the uploaded resource to the data store.
+func (uh UploadHandler) store(uploaded *UploadedResource) (resource.Resource, error) {
+	if uploaded.PendingID != "" {
+		stored, err := uh.Store.UpdatePendingResource(uploaded.Service, uploaded.PendingID, uh.Username, uploaded.Resource, uploaded.Data)
+		return stored, errors.Trace(err)
+	}
+	stored, err := uh.Store.SetResource(uploaded.Service, uh.Username, uploaded.Resource, uploaded.Data)
+	return stored, errors.Trace(err)
+}
+
+// storeStreamed stores a new upload as its data is received, staging
+// the data at the same time so that the upload can be resumed if it
+// is interrupted.
+func (uh UploadHandler) storeStreamed(key string, uploaded *UploadedResource) (resource.Resource, error) {
+	body := &countingReader{r: uploaded.Data}
+	data, err := uh.Staging.Tee(key, body)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	streamed := *uploaded
+	streamed.Data = data
+	stored, err := uh.store(&streamed)
+	data.Close()
+	if body.err != nil {
+		return resource.Resource{}, errors.Annotatef(body.err, "upload interrupted at %d of %d bytes", body.n, uploaded.Resource.Size)
+	}
+	// Data which was received in full but not stored cannot be
+	// completed by resuming.
+	uh.Staging.Remove(key)
+	return stored, errors.Trace(err)
+}
+
+// storeStaged adds the data of a resumed upload to that already
+// staged, storing all of it once the upload is complete.
+func (uh UploadHandler) storeStaged(key string, uploaded *UploadedResource) (resource.Resource, error) {
+	data, err := uh.stage(key, uploaded)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	defer data.Close()
+	// The staged data is discarded whether or not it is stored
+	// successfully: data which fails verification cannot be
+	// completed by resuming.
+	defer uh.Staging.Remove(key)
+	staged := *uploaded
+	staged.Data = data
+	stored, err := uh.store(&staged)
+	return stored, errors.Trace(err)
+}
+
+// countingReader records how much data was read from the wrapped
+// reader, and the error which stopped it, if any.
+type countingReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if err != nil && err != io.EOF {
+		c.err = err
+	}
+	return n, err
+}
+
+// HandleStatusRequest handles a request for the status of an upload,
+// returning how much of its data has been received.
+func (uh UploadHandler) HandleStatusRequest(req *http.Request) (*api.UploadResult, error) {
+	if uh.Staging == nil {
+		return nil, errors.NotSupportedf("resuming uploads")
+	}
+	uReq, err := api.ExtractUploadStatusRequest(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key := uploadKey(req, uReq.Service, uReq.Name, uReq.PendingID, uReq.Fingerprint)
+	offset, err := uh.Staging.Size(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &api.UploadResult{Offset: offset}, nil
+}
+
+// stage adds the uploaded data to that already staged, returning all
+// of the staged data once the upload is complete. If the upload is
+// interrupted, the data received so far remains staged.
+func (uh UploadHandler) stage(key string, uploaded *UploadedResource) (io.ReadCloser, error) {
+	size := uploaded.Resource.Size
+	staged, err := uh.Staging.Append(key, uploaded.Offset, uploaded.Data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "upload interrupted at %d of %d bytes", staged, size)
+	}
+	if staged != size {
+		uh.Staging.Remove(key)
+		return nil, errors.Errorf("upload size does not match expected (%d != %d)", staged, size)
+	}
+	data, err := uh.Staging.Open(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+// uploadKey returns the key identifying the staged data of an upload.
+// An upload is only resumed with more of the same data, for the same
+// resource in the same model.
+func uploadKey(req *http.Request, service, name, pendingID string, fp charmresource.Fingerprint) string {
+	modelUUID := req.URL.Query().Get(":modeluuid")
+	return fmt.Sprintf("%s/%s/%s/%s/%s", modelUUID, service, name, pendingID, fp)
+}
+
 // ReadResource extracts the relevant info from the request.
 func (uh UploadHandler) ReadResource(req *http.Request) (*UploadedResource, error) {
 	uReq, err := api.ExtractUploadRequest(req)
@@ -114,6 +244,7 @@ func (uh UploadHandler) ReadResource(req *http.Request) (*UploadedResource, erro
 		PendingID: uReq.PendingID,
 		Resource:  chRes,
 		Data:      req.Body,
+		Offset:    uReq.Offset,
 	}
 	return uploaded, nil
 }
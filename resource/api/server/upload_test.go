@@ -9,12 +9,15 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 	"github.com/juju/juju/resource/api/server"
 )
@@ -113,6 +116,70 @@ func (s *UploadSuite) TestHandleRequestSetResourceFailure(c *gc.C) {
 	s.stub.CheckCallNames(c, "GetResource", "SetResource")
 }
 
+func (s *UploadSuite) TestHandleRequestResumed(c *gc.C) {
+	content := "<some data>"
+	res, _ := newResource(c, "spam", "a-user", content)
+	stored, _ := newResource(c, "spam", "", "")
+	s.data.ReturnGetResource = stored
+	s.data.ReturnSetResource = res
+	store := &readingDataStore{stubDataStore: s.data}
+	uh := server.UploadHandler{
+		Username: "a-user",
+		Store:    store,
+		Staging:  server.NewDirUploadStaging(c.MkDir(), nil, time.Hour, clock.WallClock),
+	}
+
+	// The first attempt is interrupted part way through.
+	req, _ := newUploadRequest(c, "spam", "a-service", content)
+	req.Body = ioutil.NopCloser(io.MultiReader(strings.NewReader(content[:4]), interruptedReader{}))
+	_, err := uh.HandleRequest(req)
+	c.Check(err, gc.ErrorMatches, "upload interrupted at 4 of 11 bytes: <connection reset>")
+
+	statusReq := newUploadStatusRequest(c, req)
+	result, err := uh.HandleStatusRequest(statusReq)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Offset, gc.Equals, int64(4))
+
+	// The second sends the remainder.
+	req, _ = newUploadRequest(c, "spam", "a-service", content)
+	req.Body = ioutil.NopCloser(strings.NewReader(content[4:]))
+	req.Header.Set("Content-Length", "7")
+	req.Header.Set("Content-Range", "bytes 4-10/11")
+	result, err = uh.HandleRequest(req)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "GetResource", "GetResource", "SetResource")
+	c.Check(s.stub.Calls()[2].Args[2], jc.DeepEquals, res.Resource)
+	c.Check(store.data, gc.Equals, content)
+	c.Check(result, jc.DeepEquals, &api.UploadResult{
+		Resource: api.Resource2API(res),
+	})
+
+	// The staged data has been discarded.
+	result, err = uh.HandleStatusRequest(statusReq)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Offset, gc.Equals, int64(0))
+}
+
+func (s *UploadSuite) TestHandleRequestResumeNotStaged(c *gc.C) {
+	stored, _ := newResource(c, "spam", "", "")
+	s.data.ReturnGetResource = stored
+	uh := server.UploadHandler{
+		Username: "a-user",
+		Store:    s.data,
+	}
+	req, _ := newUploadRequest(c, "spam", "a-service", "<some data>")
+	req.Header.Set("Content-Length", "7")
+	req.Header.Set("Content-Range", "bytes 4-10/11")
+
+	_, err := uh.HandleRequest(req)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = uh.HandleStatusRequest(newUploadStatusRequest(c, req))
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	s.stub.CheckCallNames(c, "GetResource")
+}
+
 func (s *UploadSuite) TestReadResourceOkay(c *gc.C) {
 	content := "<some data>"
 	expected, _ := newResource(c, "spam", "a-user", content)
@@ -240,3 +307,34 @@ func newUploadRequest(c *gc.C, name, service, content string) (*http.Request, io
 
 	return req, body
 }
+
+// newUploadStatusRequest returns a request for the status of the
+// given upload request.
+func newUploadStatusRequest(c *gc.C, uploadReq *http.Request) *http.Request {
+	req, err := http.NewRequest("GET", uploadReq.URL.String(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.Header.Set("Content-SHA384", uploadReq.Header.Get("Content-SHA384"))
+	return req
+}
+
+// interruptedReader fails as a dropped connection would.
+type interruptedReader struct{}
+
+func (interruptedReader) Read([]byte) (int, error) {
+	return 0, errors.New("<connection reset>")
+}
+
+// readingDataStore records the data stored by SetResource.
+type readingDataStore struct {
+	*stubDataStore
+	data string
+}
+
+func (s *readingDataStore) SetResource(serviceID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	s.data = string(data)
+	return s.stubDataStore.SetResource(serviceID, userID, res, r)
+}
@@ -32,6 +32,11 @@ type UploadRequest struct {
 
 	// PendingID is the pending ID to associate with this upload, if any.
 	PendingID string
+
+	// Offset is the position in the resource data at which the data
+	// sent with the request starts. It is non-zero when resuming an
+	// interrupted upload.
+	Offset int64
 }
 
 // NewUploadRequest generates a new upload request for the given resource.
@@ -82,12 +87,47 @@ func ExtractUploadRequest(req *http.Request) (UploadRequest, error) {
 		return ur, errors.Annotate(err, "invalid size")
 	}
 
+	// A resumed upload sends only the remainder of the data, and
+	// describes where it fits in the whole.
+	var offset int64
+	if contentRange := req.Header.Get("Content-Range"); contentRange != "" {
+		var last, total int64
+		if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &offset, &last, &total); err != nil {
+			return ur, errors.Annotatef(err, "invalid content range %q", contentRange)
+		}
+		if offset < 0 || last != total-1 || offset+size != total {
+			return ur, errors.Errorf("content range %q does not match size %d", contentRange, size)
+		}
+		size = total
+	}
+
 	ur = UploadRequest{
 		Service:     service,
 		Name:        name,
 		Size:        size,
 		Fingerprint: fp,
 		PendingID:   pendingID,
+		Offset:      offset,
+	}
+	return ur, nil
+}
+
+// ExtractUploadStatusRequest pulls the info identifying an upload
+// from an HTTP upload status request.
+func ExtractUploadStatusRequest(req *http.Request) (UploadRequest, error) {
+	var ur UploadRequest
+
+	service, name := ExtractEndpointDetails(req.URL)
+	fp, err := charmresource.ParseFingerprint(req.Header.Get("Content-Sha384"))
+	if err != nil {
+		return ur, errors.Annotate(err, "invalid fingerprint")
+	}
+
+	ur = UploadRequest{
+		Service:     service,
+		Name:        name,
+		Fingerprint: fp,
+		PendingID:   req.URL.Query().Get("pendingid"),
 	}
 	return ur, nil
 }
@@ -103,14 +143,36 @@ func (ur UploadRequest) HTTPRequest() (*http.Request, error) {
 
 	req.Header.Set("Content-Type", ContentTypeRaw)
 	req.Header.Set("Content-Sha384", ur.Fingerprint.String())
-	req.Header.Set("Content-Length", fmt.Sprint(ur.Size))
-	req.ContentLength = ur.Size
+	req.Header.Set("Content-Length", fmt.Sprint(ur.Size-ur.Offset))
+	req.ContentLength = ur.Size - ur.Offset
+	if ur.Offset > 0 {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", ur.Offset, ur.Size-1, ur.Size))
+	}
+
+	ur.setPendingID(req)
+	return req, nil
+}
 
+// HTTPStatusRequest generates a new HTTP request asking how much of
+// the upload's data the controller already holds, so that an
+// interrupted upload may be resumed.
+func (ur UploadRequest) HTTPStatusRequest() (*http.Request, error) {
+	urlStr := NewEndpointPath(ur.Service, ur.Name)
+	req, err := http.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	req.Header.Set("Content-Sha384", ur.Fingerprint.String())
+
+	ur.setPendingID(req)
+	return req, nil
+}
+
+func (ur UploadRequest) setPendingID(req *http.Request) {
 	if ur.PendingID != "" {
 		query := req.URL.Query()
 		query.Set("pendingid", ur.PendingID)
 		req.URL.RawQuery = query.Encode()
 	}
-
-	return req, nil
 }
@@ -71,3 +71,18 @@ type FormattedServiceDetails struct {
 // FormattedDetailResource is the data for the tabular output for juju resources
 // <unit> --details.
 type FormattedUnitDetails []FormattedDetailResource
+
+// FormattedHistoricalResource holds the formatted representation of
+// a retained revision of a resource.
+type FormattedHistoricalResource struct {
+	Name        string    `json:"name" yaml:"name"`
+	Serial      int       `json:"serial" yaml:"serial"`
+	Fingerprint string    `json:"fingerprint" yaml:"fingerprint"`
+	Size        int64     `json:"size" yaml:"size"`
+	Timestamp   time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Username    string    `json:"username,omitempty" yaml:"username,omitempty"`
+}
+
+// FormattedResourceHistory is the data for the output of
+// juju resources <service> --history.
+type FormattedResourceHistory []FormattedHistoricalResource
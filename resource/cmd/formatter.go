@@ -77,6 +77,23 @@ func FormatSvcResource(res resource.Resource) FormattedSvcResource {
 	}
 }
 
+// FormatResourceHistory converts the retained revisions of a service's
+// resources into a FormattedResourceHistory.
+func FormatResourceHistory(history []resource.HistoricalResource) FormattedResourceHistory {
+	formatted := make(FormattedResourceHistory, len(history))
+	for i, res := range history {
+		formatted[i] = FormattedHistoricalResource{
+			Name:        res.Name,
+			Serial:      res.Serial,
+			Fingerprint: res.Fingerprint.String(),
+			Size:        res.Size,
+			Timestamp:   res.Timestamp,
+			Username:    res.Username,
+		}
+	}
+	return formatted
+}
+
 func formatServiceResources(sr resource.ServiceResources) (FormattedServiceInfo, error) {
 	var formatted FormattedServiceInfo
 	updates, err := sr.Updates()
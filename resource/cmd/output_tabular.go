@@ -54,6 +54,8 @@ func FormatSvcTabular(value interface{}) ([]byte, error) {
 		return formatServiceDetailTabular(resources), nil
 	case FormattedUnitDetails:
 		return formatUnitDetailTabular(resources), nil
+	case FormattedResourceHistory:
+		return formatHistoryTabular(resources), nil
 	default:
 		return nil, errors.Errorf("unexpected type for data: %T", resources)
 	}
@@ -185,6 +187,29 @@ func formatUnitDetailTabular(resources FormattedUnitDetails) []byte {
 	return out.Bytes()
 }
 
+func formatHistoryTabular(resources FormattedResourceHistory) []byte {
+	var out bytes.Buffer
+	fmt.Fprintln(&out, "[History]")
+
+	// To format things into columns.
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+
+	// Write the header.
+	fmt.Fprintln(tw, "RESOURCE\tSERIAL\tSIZE\tUPLOADED BY\tUPLOADED AT")
+
+	for _, r := range resources {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n",
+			r.Name,
+			r.Serial,
+			r.Size,
+			r.Username,
+			r.Timestamp.Format("2006-01-02T15:04"),
+		)
+	}
+	tw.Flush()
+	return out.Bytes()
+}
+
 type byUnitID []FormattedDetailResource
 
 func (b byUnitID) Len() int      { return len(b) }
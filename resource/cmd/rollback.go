@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/resource"
+)
+
+// RollbackClient has the API client methods needed by RollbackCommand.
+type RollbackClient interface {
+	// RollbackResource restores the identified retained revision
+	// of the resource.
+	RollbackResource(service, name string, serial int) (resource.Resource, error)
+
+	// Close closes the client.
+	Close() error
+}
+
+// RollbackDeps is a type that contains external functions that
+// RollbackCommand depends on to function.
+type RollbackDeps struct {
+	// NewClient returns the value that wraps the API for rolling back
+	// resources on the server.
+	NewClient func(*RollbackCommand) (RollbackClient, error)
+}
+
+// RollbackCommand implements the rollback-resource command.
+type RollbackCommand struct {
+	modelcmd.ModelCommandBase
+
+	deps     RollbackDeps
+	service  string
+	resource string
+	serial   int
+}
+
+// NewRollbackCommand returns a new command that restores a previously
+// uploaded revision of a service's resource.
+func NewRollbackCommand(deps RollbackDeps) *RollbackCommand {
+	return &RollbackCommand{deps: deps}
+}
+
+// Info implements cmd.Command.Info.
+func (c *RollbackCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rollback-resource",
+		Args:    "service resource serial",
+		Purpose: "restore a previously uploaded revision of a resource",
+		Doc: `
+This command restores one of the previously uploaded revisions of a service's
+resource, as shown by "juju list-resources --history", as the service's
+current revision. The revision being replaced is itself kept, so the rollback
+may be undone. The service's units are notified and fetch the restored
+revision, just as they would after a new upload.
+`,
+	}
+}
+
+// Init implements cmd.Command.Init. It will return an error satisfying
+// errors.BadRequest if you give it an incorrect number of arguments.
+func (c *RollbackCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.BadRequestf("missing service name")
+	case 1:
+		return errors.BadRequestf("missing resource name")
+	case 2:
+		return errors.BadRequestf("missing revision serial")
+	}
+
+	if !names.IsValidService(args[0]) {
+		return errors.NewNotValid(nil, fmt.Sprintf("invalid service name %q", args[0]))
+	}
+	c.service = args[0]
+	c.resource = args[1]
+
+	serial, err := strconv.Atoi(args[2])
+	if err != nil || serial <= 0 {
+		return errors.NewNotValid(nil, fmt.Sprintf("invalid revision serial %q", args[2]))
+	}
+	c.serial = serial
+
+	if err := cmd.CheckEmpty(args[3:]); err != nil {
+		return errors.NewBadRequest(err, "")
+	}
+	return nil
+}
+
+// Run implements cmd.Command.Run.
+func (c *RollbackCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.deps.NewClient(c)
+	if err != nil {
+		return errors.Annotatef(err, "can't connect to %s", c.ConnectionName())
+	}
+	defer apiclient.Close()
+
+	res, err := apiclient.RollbackResource(c.service, c.resource, c.serial)
+	if err != nil {
+		return errors.Annotatef(err, "failed to roll back resource %q", c.resource)
+	}
+	ctx.Infof("resource %q of service %q restored (fingerprint %s)", res.Name, c.service, res.Fingerprint)
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/resource"
+)
+
+var _ = gc.Suite(&RollbackSuite{})
+
+type RollbackSuite struct {
+	testing.IsolationSuite
+
+	stub   *testing.Stub
+	client *stubRollbackClient
+}
+
+func (s *RollbackSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.client = &stubRollbackClient{stub: s.stub}
+}
+
+func (s *RollbackSuite) newClient(c *RollbackCommand) (RollbackClient, error) {
+	s.stub.AddCall("NewClient", c)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.client, nil
+}
+
+func (*RollbackSuite) TestInitMissingArgs(c *gc.C) {
+	for _, args := range [][]string{{}, {"svc"}, {"svc", "spam"}} {
+		var command RollbackCommand
+
+		err := command.Init(args)
+		c.Check(err, jc.Satisfies, errors.IsBadRequest)
+	}
+}
+
+func (*RollbackSuite) TestInitBadSerial(c *gc.C) {
+	for _, serial := range []string{"0", "-1", "two"} {
+		var command RollbackCommand
+
+		err := command.Init([]string{"svc", "spam", serial})
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (*RollbackSuite) TestInitTooManyArgs(c *gc.C) {
+	var command RollbackCommand
+
+	err := command.Init([]string{"svc", "spam", "2", "eggs"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*RollbackSuite) TestInitGood(c *gc.C) {
+	var command RollbackCommand
+
+	err := command.Init([]string{"svc", "spam", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(command.service, gc.Equals, "svc")
+	c.Check(command.resource, gc.Equals, "spam")
+	c.Check(command.serial, gc.Equals, 2)
+}
+
+func (*RollbackSuite) TestInfo(c *gc.C) {
+	var command RollbackCommand
+	info := command.Info()
+
+	c.Check(info.Name, gc.Equals, "rollback-resource")
+	c.Check(info.Args, gc.Equals, "service resource serial")
+}
+
+func (s *RollbackSuite) TestRun(c *gc.C) {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("spamspamspam"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.ReturnRollbackResource = resource.Resource{
+		Resource: charmresource.Resource{
+			Meta:        charmresource.Meta{Name: "spam"},
+			Fingerprint: fp,
+		},
+	}
+	command := NewRollbackCommand(RollbackDeps{
+		NewClient: s.newClient,
+	})
+
+	code, _, stderr := runCmd(c, command, "svc", "spam", "2")
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(stderr, gc.Equals, `resource "spam" of service "svc" restored (fingerprint `+fp.String()+")\n")
+	s.stub.CheckCallNames(c, "NewClient", "RollbackResource", "Close")
+	s.stub.CheckCall(c, 1, "RollbackResource", "svc", "spam", 2)
+}
+
+func (s *RollbackSuite) TestRunFailed(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotFoundf("revision 2 of resource %q", "spam"))
+	command := NewRollbackCommand(RollbackDeps{
+		NewClient: s.newClient,
+	})
+
+	code, _, stderr := runCmd(c, command, "svc", "spam", "2")
+
+	c.Check(code, gc.Equals, 1)
+	c.Check(stderr, gc.Matches, `(?s).*failed to roll back resource "spam": revision 2 of resource "spam" not found.*`)
+	s.stub.CheckCallNames(c, "NewClient", "RollbackResource", "Close")
+}
+
+type stubRollbackClient struct {
+	stub *testing.Stub
+
+	ReturnRollbackResource resource.Resource
+}
+
+func (s *stubRollbackClient) RollbackResource(service, name string, serial int) (resource.Resource, error) {
+	s.stub.AddCall("RollbackResource", service, name, serial)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	return s.ReturnRollbackResource, nil
+}
+
+func (s *stubRollbackClient) Close() error {
+	s.stub.AddCall("Close")
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"strings"
 	"time"
 
 	jujucmd "github.com/juju/cmd"
@@ -69,10 +70,28 @@ func (s *ShowServiceSuite) TestInfo(c *gc.C) {
 This command shows the resources required by and those in use by an existing
 service or unit in your model.  When run for a service, it will also show any
 updates available for resources from the charmstore.
+
+With --history, the previously uploaded revisions of the service's resources
+which are kept by the controller are shown instead. Any of them may be
+restored with "juju rollback-resource".
 `,
 	})
 }
 
+func (*ShowServiceSuite) TestInitHistoryUnit(c *gc.C) {
+	s := ShowServiceCommand{history: true}
+
+	err := s.Init([]string{"foo/0"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*ShowServiceSuite) TestInitHistoryDetails(c *gc.C) {
+	s := ShowServiceCommand{history: true, details: true}
+
+	err := s.Init([]string{"foo"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
 func (s *ShowServiceSuite) TestRun(c *gc.C) {
 	data := []resource.ServiceResources{
 		{
@@ -534,6 +553,46 @@ charlie  2011-11-11T11:11 2012-12-12T12:12
 	s.stubDeps.stub.CheckCall(c, 1, "ListResources", []string{"svc"})
 }
 
+func (s *ShowServiceSuite) TestRunHistory(c *gc.C) {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("spamspamspam"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.stubDeps.client.ReturnHistory = []resource.HistoricalResource{{
+		Resource: resource.Resource{
+			Resource: charmresource.Resource{
+				Meta: charmresource.Meta{
+					Name: "website",
+				},
+				Origin:      charmresource.OriginUpload,
+				Fingerprint: fp,
+				Size:        12,
+			},
+			Username:  "Bill User",
+			Timestamp: time.Date(2012, 12, 12, 12, 12, 12, 0, time.UTC),
+		},
+		Serial: 2,
+	}}
+
+	cmd := &ShowServiceCommand{
+		deps: ShowServiceDeps{
+			NewClient: s.stubDeps.NewClient,
+		},
+	}
+
+	code, stdout, stderr := runCmd(c, cmd, "svc", "--history")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(stderr, gc.Equals, "")
+
+	c.Check(stdout, gc.Equals, `
+[History]
+RESOURCE SERIAL SIZE UPLOADED BY UPLOADED AT
+website  2      12   Bill User   2012-12-12T12:12
+
+`[1:])
+
+	s.stubDeps.stub.CheckCallNames(c, "NewClient", "ListResourceHistory", "Close")
+	s.stubDeps.stub.CheckCall(c, 1, "ListResourceHistory", "svc")
+}
+
 type stubShowServiceDeps struct {
 	stub   *testing.Stub
 	client *stubServiceClient
@@ -551,6 +610,7 @@ func (s *stubShowServiceDeps) NewClient(c *ShowServiceCommand) (ShowServiceClien
 type stubServiceClient struct {
 	stub            *testing.Stub
 	ReturnResources []resource.ServiceResources
+	ReturnHistory   []resource.HistoricalResource
 }
 
 func (s *stubServiceClient) ListResources(services []string) ([]resource.ServiceResources, error) {
@@ -561,6 +621,14 @@ func (s *stubServiceClient) ListResources(services []string) ([]resource.Service
 	return s.ReturnResources, nil
 }
 
+func (s *stubServiceClient) ListResourceHistory(service string) ([]resource.HistoricalResource, error) {
+	s.stub.AddCall("ListResourceHistory", service)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.ReturnHistory, nil
+}
+
 func (s *stubServiceClient) Close() error {
 	s.stub.AddCall("Close")
 	if err := s.stub.NextErr(); err != nil {
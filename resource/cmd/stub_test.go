@@ -10,6 +10,8 @@ import (
 	"github.com/juju/testing"
 	"gopkg.in/juju/charm.v6-unstable"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/resource"
 )
 
 type stubCharmStore struct {
@@ -47,14 +49,19 @@ func (s *stubCharmStore) Close() error {
 
 type stubAPIClient struct {
 	stub *testing.Stub
+
+	uploadSize int64
 }
 
-func (s *stubAPIClient) Upload(service, name string, resource io.ReadSeeker) error {
-	s.stub.AddCall("Upload", service, name, resource)
+func (s *stubAPIClient) UploadWithProgress(service, name string, reader io.ReadSeeker, progress resource.ProgressFunc) error {
+	s.stub.AddCall("UploadWithProgress", service, name, reader)
 	if err := s.stub.NextErr(); err != nil {
 		return errors.Trace(err)
 	}
 
+	if progress != nil {
+		progress(s.uploadSize, s.uploadSize)
+	}
 	return nil
 }
 
@@ -4,18 +4,21 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/resource"
 )
 
 // UploadClient has the API client methods needed by UploadCommand.
 type UploadClient interface {
-	// Upload sends the resource to Juju.
-	Upload(service, name string, resource io.ReadSeeker) error
+	// UploadWithProgress sends the resource to Juju, reporting
+	// progress as it goes.
+	UploadWithProgress(service, name string, reader io.ReadSeeker, progress resource.ProgressFunc) error
 
 	// Close closes the client.
 	Close() error
@@ -59,7 +62,9 @@ func (c *UploadCommand) Info() *cmd.Info {
 		Purpose: "upload a file as a resource for a service",
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
-used as a resource for a service.
+used as a resource for a service. The progress of the upload is reported as
+it goes, an interrupted upload is resumed from where it left off, and the
+controller verifies the file's size and fingerprint before storing it.
 `,
 	}
 }
@@ -107,14 +112,14 @@ func (c *UploadCommand) addResourceFile(arg string) error {
 }
 
 // Run implements cmd.Command.Run.
-func (c *UploadCommand) Run(*cmd.Context) error {
+func (c *UploadCommand) Run(ctx *cmd.Context) error {
 	apiclient, err := c.deps.NewClient(c)
 	if err != nil {
 		return errors.Annotatef(err, "can't connect to %s", c.ConnectionName())
 	}
 	defer apiclient.Close()
 
-	if err := c.upload(c.resourceFile, apiclient); err != nil {
+	if err := c.upload(ctx, c.resourceFile, apiclient); err != nil {
 		return errors.Annotatef(err, "failed to upload resource %q", c.resourceFile.name)
 	}
 	return nil
@@ -122,12 +127,23 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 
 // upload opens the given file and calls the apiclient to upload it to the given
 // service with the given name.
-func (c *UploadCommand) upload(rf resourceFile, client UploadClient) error {
+func (c *UploadCommand) upload(ctx *cmd.Context, rf resourceFile, client UploadClient) error {
 	f, err := c.deps.OpenResource(rf.filename)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer f.Close()
-	err = client.Upload(rf.service, rf.name, f)
+	err = client.UploadWithProgress(rf.service, rf.name, f, func(transferred, total int64) {
+		reportUploadProgress(ctx.Stderr, rf.name, transferred, total)
+	})
 	return errors.Trace(err)
 }
+
+// reportUploadProgress rewrites the progress line for the resource
+// being uploaded, ending the line once the upload is complete.
+func reportUploadProgress(w io.Writer, name string, transferred, total int64) {
+	fmt.Fprintf(w, "\ruploading %s: %s", name, resource.FormatProgress(transferred, total))
+	if transferred >= total {
+		fmt.Fprintln(w)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
 )
 
 var _ = gc.Suite(&UploadSuite{})
@@ -115,18 +117,22 @@ func (s *UploadSuite) TestRun(c *gc.C) {
 		service: "svc",
 	}
 
-	err := u.Run(nil)
+	s.stubDeps.client.(*stubAPIClient).uploadSize = 1024
+	ctx := coretesting.Context(c)
+
+	err := u.Run(ctx)
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.stub.CheckCallNames(c,
 		"NewClient",
 		"OpenResource",
-		"Upload",
+		"UploadWithProgress",
 		"FileClose",
 		"Close",
 	)
 	s.stub.CheckCall(c, 1, "OpenResource", "bar")
-	s.stub.CheckCall(c, 2, "Upload", "svc", "foo", file)
+	s.stub.CheckCall(c, 2, "UploadWithProgress", "svc", "foo", file)
+	c.Check(coretesting.Stderr(ctx), gc.Matches, `\ruploading foo: 100% \(.* of .*\)\n`)
 }
 
 type stubUploadDeps struct {
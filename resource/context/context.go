@@ -112,9 +112,16 @@ func (deps *contextDeps) NewTempDirSpec() (internal.DownloadTempTarget, error) {
 }
 
 func (deps *contextDeps) WriteContent(target io.Writer, content internal.Content) error {
+	content.Data = resource.NewProgressReader(content.Data, content.Size, deps.reportProgress)
 	return internal.WriteContent(target, content, deps)
 }
 
+// reportProgress logs how much of the resource has been downloaded,
+// so that the progress of large downloads is visible in the unit log.
+func (deps *contextDeps) reportProgress(transferred, total int64) {
+	logger.Infof("downloading resource %q: %s", deps.name, resource.FormatProgress(transferred, total))
+}
+
 func (deps contextDeps) CloseAndLog(closer io.Closer, label string) {
 	internal.CloseAndLog(closer, label, logger)
 }
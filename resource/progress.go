@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dustin/go-humanize"
+)
+
+// ProgressStep is the minimum change in percentage complete between
+// successive progress reports.
+const ProgressStep = 10
+
+// ProgressFunc is called with the number of bytes transferred so far
+// and the total number of bytes expected.
+type ProgressFunc func(transferred, total int64)
+
+// ProgressReader wraps a reader and reports on how much of the
+// expected data has been read through it.
+type ProgressReader struct {
+	io.Reader
+
+	// Total is the number of bytes expected from the reader.
+	Total int64
+
+	// Report is called as data is read.
+	Report ProgressFunc
+
+	transferred int64
+	reported    int64
+}
+
+// NewProgressReader returns a reader which passes data through from
+// the provided reader, calling report each time a further ProgressStep
+// percent of the total has been read, and once when the data has all
+// been read.
+func NewProgressReader(reader io.Reader, total int64, report ProgressFunc) *ProgressReader {
+	return &ProgressReader{
+		Reader:   reader,
+		Total:    total,
+		Report:   report,
+		reported: -1,
+	}
+}
+
+// Read implements io.Reader.
+func (pr *ProgressReader) Read(p []byte) (int, error) {
+	n, err := pr.Reader.Read(p)
+	pr.transferred += int64(n)
+	if pr.Report != nil && pr.due() {
+		pr.reported = pr.transferred
+		pr.Report(pr.transferred, pr.Total)
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker if the wrapped reader does. Progress
+// is reported relative to the new offset.
+func (pr *ProgressReader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := pr.Reader.(io.Seeker)
+	if !ok {
+		return 0, fmt.Errorf("seeking %T not supported", pr.Reader)
+	}
+	pos, err := seeker.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	pr.transferred = pos
+	pr.reported = -1
+	return pos, nil
+}
+
+// Transferred returns the number of bytes read so far.
+func (pr *ProgressReader) Transferred() int64 {
+	return pr.transferred
+}
+
+func (pr *ProgressReader) due() bool {
+	if pr.transferred == pr.reported {
+		return false
+	}
+	if pr.reported < 0 || pr.transferred >= pr.Total || pr.Total <= 0 {
+		return true
+	}
+	return percent(pr.transferred, pr.Total)-percent(pr.reported, pr.Total) >= ProgressStep
+}
+
+func percent(n, total int64) int64 {
+	if total <= 0 {
+		return 100
+	}
+	return n * 100 / total
+}
+
+// FormatProgress returns a human readable description of a transfer's
+// progress, e.g. "40% (4.0MB of 10MB)".
+func FormatProgress(transferred, total int64) string {
+	return fmt.Sprintf("%d%% (%s of %s)",
+		percent(transferred, total),
+		humanize.Bytes(uint64(transferred)),
+		humanize.Bytes(uint64(total)),
+	)
+}
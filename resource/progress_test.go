@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/resource"
+)
+
+type ProgressSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ProgressSuite{})
+
+type progressReport struct {
+	transferred, total int64
+}
+
+func (ProgressSuite) TestReportsEachStep(c *gc.C) {
+	var reports []progressReport
+	data := strings.Repeat("x", 100)
+	reader := resource.NewProgressReader(bytes.NewBufferString(data), 100, func(transferred, total int64) {
+		reports = append(reports, progressReport{transferred, total})
+	})
+
+	buf := make([]byte, 5)
+	for {
+		if _, err := reader.Read(buf); err != nil {
+			break
+		}
+	}
+
+	c.Check(reports, jc.DeepEquals, []progressReport{
+		{5, 100}, {15, 100}, {25, 100}, {35, 100}, {45, 100},
+		{55, 100}, {65, 100}, {75, 100}, {85, 100}, {95, 100},
+		{100, 100},
+	})
+	c.Check(reader.Transferred(), gc.Equals, int64(100))
+}
+
+func (ProgressSuite) TestSeekResetsProgress(c *gc.C) {
+	var reports []progressReport
+	reader := resource.NewProgressReader(strings.NewReader("some data"), 9, func(transferred, total int64) {
+		reports = append(reports, progressReport{transferred, total})
+	})
+	_, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pos, err := reader.Seek(5, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pos, gc.Equals, int64(5))
+	c.Check(reader.Transferred(), gc.Equals, int64(5))
+	_, err = ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(reports, jc.DeepEquals, []progressReport{{9, 9}, {9, 9}})
+}
+
+func (ProgressSuite) TestSeekNotSupported(c *gc.C) {
+	reader := resource.NewProgressReader(bytes.NewBufferString("data"), 4, nil)
+
+	_, err := reader.Seek(0, os.SEEK_SET)
+
+	c.Check(err, gc.ErrorMatches, `seeking \*bytes.Buffer not supported`)
+}
+
+func (ProgressSuite) TestFormatProgress(c *gc.C) {
+	c.Check(resource.FormatProgress(40, 100), gc.Matches, `40% \(40 ?B of 100 ?B\)`)
+}
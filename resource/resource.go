@@ -196,3 +196,28 @@ type UnitResources struct {
 	// Resources are the resource versions currently in use by this unit.
 	Resources []Resource
 }
+
+// HistoricalResource is a previously uploaded revision of a service's
+// resource. Such revisions are kept, up to a limited number, so that
+// the service may be rolled back to one of them.
+type HistoricalResource struct {
+	Resource
+
+	// Serial identifies the revision amongst the retained revisions
+	// of the resource. Later revisions have higher serials.
+	Serial int
+}
+
+// Validate ensures that the historical resource is valid.
+func (res HistoricalResource) Validate() error {
+	if err := res.Resource.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if res.Serial <= 0 {
+		return errors.NewNotValid(nil, "missing serial")
+	}
+	if res.IsPlaceholder() {
+		return errors.NewNotValid(nil, "placeholder resources have no history")
+	}
+	return nil
+}
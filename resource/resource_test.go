@@ -300,3 +300,53 @@ func newStoreResource(c *gc.C, name, serviceID string, revision int) resource.Re
 	c.Assert(err, jc.ErrorIsNil)
 	return res
 }
+
+func (ResourceSuite) TestHistoricalResourceValidateOkay(c *gc.C) {
+	res := resource.HistoricalResource{
+		Resource: resource.Resource{
+			Resource:  newFullCharmResource(c, "spam"),
+			ID:        "a-service/spam",
+			ServiceID: "a-service",
+			Username:  "a-user",
+			Timestamp: time.Now(),
+		},
+		Serial: 1,
+	}
+
+	err := res.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (ResourceSuite) TestHistoricalResourceValidateMissingSerial(c *gc.C) {
+	res := resource.HistoricalResource{
+		Resource: resource.Resource{
+			Resource:  newFullCharmResource(c, "spam"),
+			ID:        "a-service/spam",
+			ServiceID: "a-service",
+			Username:  "a-user",
+			Timestamp: time.Now(),
+		},
+	}
+
+	err := res.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `missing serial`)
+}
+
+func (ResourceSuite) TestHistoricalResourceValidatePlaceholder(c *gc.C) {
+	res := resource.HistoricalResource{
+		Resource: resource.Resource{
+			Resource:  newFullCharmResource(c, "spam"),
+			ID:        "a-service/spam",
+			ServiceID: "a-service",
+		},
+		Serial: 1,
+	}
+
+	err := res.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `placeholder resources have no history`)
+}
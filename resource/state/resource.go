@@ -6,9 +6,11 @@ package state
 // TODO(ericsnow) Figure out a way to drop the txn dependency here?
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"path"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -51,6 +53,21 @@ type resourcePersistence interface {
 	// NewResolvePendingResourceOps generates mongo transaction operations
 	// to set the identified resource as active.
 	NewResolvePendingResourceOps(resID, pendingID string) ([]txn.Op, error)
+
+	// ListResourceHistory returns the retained revisions of each of
+	// the service's resources.
+	ListResourceHistory(serviceID string) ([]resource.HistoricalResource, error)
+
+	// GetResourceHistory returns the identified retained revision of
+	// the resource, along with the path to its content in storage.
+	GetResourceHistory(id string, serial int) (res resource.HistoricalResource, storagePath string, _ error)
+
+	// AddResourceHistory records the retained revision of a resource.
+	AddResourceHistory(res resource.HistoricalResource, storagePath string) error
+
+	// RemoveResourceHistory removes the record of the identified
+	// retained revision of a resource.
+	RemoveResourceHistory(id string, serial int) error
 }
 
 // StagedResource represents resource info that has been added to the
@@ -88,6 +105,10 @@ type resourceState struct {
 
 	newPendingID     func() (string, error)
 	currentTimestamp func() time.Time
+
+	// historyRetention is the number of previously uploaded
+	// revisions kept for each resource.
+	historyRetention int
 }
 
 // ListResources returns the resource data for the given service ID.
@@ -190,15 +211,189 @@ func (st resourceState) setResource(pendingID, serviceID, userID string, chRes c
 		if err := st.persist.SetResource(res); err != nil {
 			return res, errors.Trace(err)
 		}
-	} else {
-		if err := st.storeResource(res, r); err != nil {
-			return res, errors.Trace(err)
+		return res, nil
+	}
+
+	// The current revision's data is copied aside before it is
+	// overwritten, but it is only recorded as a retained revision
+	// once the new revision has been stored.
+	var previous *previousRevision
+	if pendingID == "" {
+		var err error
+		previous, err = st.copyPreviousRevision(res)
+		if err != nil {
+			return res, errors.Annotate(err, "could not keep previous revision")
+		}
+	}
+	if err := st.storeResource(res, r); err != nil {
+		if previous != nil {
+			st.discardPreviousRevision(previous)
+		}
+		return res, errors.Trace(err)
+	}
+	if previous != nil {
+		// Failing to retain or prune old revisions does not
+		// invalidate the new one, so we only log the failure.
+		if err := st.keepPreviousRevision(previous); err != nil {
+			logger.Errorf("could not keep previous revision of resource %q (service %q): %v", res.Name, res.ServiceID, err)
+		} else if err := st.pruneResourceHistory(res); err != nil {
+			logger.Errorf("could not prune revisions of resource %q (service %q): %v", res.Name, res.ServiceID, err)
 		}
 	}
 
 	return res, nil
 }
 
+// previousRevision is a copy of a service's uploaded revision of a
+// resource, taken before the revision is replaced.
+type previousRevision struct {
+	hist        resource.HistoricalResource
+	storagePath string
+}
+
+// copyPreviousRevision copies the data of the service's currently
+// uploaded revision of the resource aside, if there is one and it is
+// about to be replaced with different content. It returns nil if no
+// copy was needed.
+func (st resourceState) copyPreviousRevision(res resource.Resource) (*previousRevision, error) {
+	current, currentPath, err := st.persist.GetResource(res.ID)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if current.IsPlaceholder() || current.Origin != charmresource.OriginUpload {
+		return nil, nil
+	}
+	if bytes.Equal(current.Fingerprint.Bytes(), res.Fingerprint.Bytes()) {
+		return nil, nil
+	}
+
+	history, err := st.resourceHistory(res.ServiceID, res.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	serial := 1
+	if len(history) > 0 {
+		serial = history[len(history)-1].Serial + 1
+	}
+
+	reader, size, err := st.storage.Get(currentPath)
+	if err != nil {
+		return nil, errors.Annotate(err, "while retrieving resource data")
+	}
+	defer reader.Close()
+	historyPath := historyStoragePath(res.Name, res.ServiceID, serial)
+	if err := st.storage.PutAndCheckHash(historyPath, reader, size, current.Fingerprint.String()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &previousRevision{
+		hist: resource.HistoricalResource{
+			Resource: current,
+			Serial:   serial,
+		},
+		storagePath: historyPath,
+	}, nil
+}
+
+// keepPreviousRevision records the copied revision as a retained
+// revision of the resource.
+func (st resourceState) keepPreviousRevision(previous *previousRevision) error {
+	if err := st.persist.AddResourceHistory(previous.hist, previous.storagePath); err != nil {
+		st.discardPreviousRevision(previous)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// discardPreviousRevision removes the copied revision's data from
+// storage, when it is not to be retained.
+func (st resourceState) discardPreviousRevision(previous *previousRevision) {
+	if err := st.storage.Remove(previous.storagePath); err != nil {
+		logger.Errorf("could not remove revision %d of resource %q (service %q) from storage: %v", previous.hist.Serial, previous.hist.Name, previous.hist.ServiceID, err)
+	}
+}
+
+// pruneResourceHistory removes the oldest retained revisions of the
+// resource beyond the retention limit, both from the model and from
+// storage.
+func (st resourceState) pruneResourceHistory(res resource.Resource) error {
+	history, err := st.resourceHistory(res.ServiceID, res.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for len(history) > st.historyRetention {
+		oldest := history[0]
+		history = history[1:]
+		if err := st.persist.RemoveResourceHistory(oldest.ID, oldest.Serial); err != nil {
+			return errors.Trace(err)
+		}
+		if err := st.storage.Remove(historyStoragePath(oldest.Name, oldest.ServiceID, oldest.Serial)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// resourceHistory returns the retained revisions of the named
+// resource, oldest first.
+func (st resourceState) resourceHistory(serviceID, name string) ([]resource.HistoricalResource, error) {
+	all, err := st.persist.ListResourceHistory(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var history []resource.HistoricalResource
+	for _, res := range all {
+		if res.Name == name {
+			history = append(history, res)
+		}
+	}
+	sort.Sort(bySerial(history))
+	return history, nil
+}
+
+// ListResourceHistory returns the retained revisions of the service's
+// resources, ordered by resource name and then oldest first.
+func (st resourceState) ListResourceHistory(serviceID string) ([]resource.HistoricalResource, error) {
+	history, err := st.persist.ListResourceHistory(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(bySerial(history))
+	return history, nil
+}
+
+// RollbackResource makes the identified retained revision of the
+// resource the service's current revision once more. The revision
+// being replaced is itself retained, so the rollback may be undone.
+// As the service's resource content changes, its units are notified
+// and will fetch the restored revision.
+func (st resourceState) RollbackResource(serviceID, name string, serial int) (resource.Resource, error) {
+	id := newResourceID(serviceID, name)
+	hist, historyPath, err := st.persist.GetResourceHistory(id, serial)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	reader, size, err := st.storage.Get(historyPath)
+	if err != nil {
+		return resource.Resource{}, errors.Annotate(err, "while retrieving resource data")
+	}
+	defer reader.Close()
+	if size != hist.Size {
+		msg := "storage returned a size (%d) which doesn't match resource metadata (%d)"
+		return resource.Resource{}, errors.Errorf(msg, size, hist.Size)
+	}
+
+	logger.Tracef("rolling back resource %q for service %q to revision %d", name, serviceID, serial)
+	res, err := st.setResource("", serviceID, hist.Username, hist.Resource.Resource, reader)
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+	return res, nil
+}
+
 func (st resourceState) storeResource(res resource.Resource, r io.Reader) error {
 	// We use a staging approach for adding the resource metadata
 	// to the model. This is necessary because the resource data
@@ -357,6 +552,24 @@ func storagePath(name, serviceID, pendingID string) string {
 	return path.Join("service-"+serviceID, "resources", id)
 }
 
+// historyStoragePath returns the path at which a retained revision
+// of the resource is stored in state storage.
+func historyStoragePath(name, serviceID string, serial int) string {
+	return path.Join("service-"+serviceID, "resources", "history", fmt.Sprintf("%s-%d", name, serial))
+}
+
+type bySerial []resource.HistoricalResource
+
+func (b bySerial) Len() int      { return len(b) }
+func (b bySerial) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+
+func (b bySerial) Less(i, j int) bool {
+	if b[i].Name != b[j].Name {
+		return b[i].Name < b[j].Name
+	}
+	return b[i].Serial < b[j].Serial
+}
+
 // unitSetter records the resource as in use by a unit when the wrapped
 // reader has been fully read.
 type unitSetter struct {
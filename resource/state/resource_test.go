@@ -5,6 +5,7 @@ package state
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"strings"
@@ -135,12 +136,13 @@ func (s *ResourceSuite) TestSetResourceOkay(c *gc.C) {
 
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"GetResource",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, res.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, res.Size, hash)
 	c.Check(res, jc.DeepEquals, resource.Resource{
 		Resource:  chRes,
 		ID:        "a-service/" + res.Name,
@@ -198,13 +200,13 @@ func (s *ResourceSuite) TestSetResourceStagingFailure(c *gc.C) {
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, failure, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, failure, nil, nil, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
-	s.stub.CheckCallNames(c, "currentTimestamp", "StageResource")
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
+	s.stub.CheckCallNames(c, "currentTimestamp", "GetResource", "StageResource")
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
 }
 
 func (s *ResourceSuite) TestSetResourcePutFailureBasic(c *gc.C) {
@@ -218,19 +220,20 @@ func (s *ResourceSuite) TestSetResourcePutFailureBasic(c *gc.C) {
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, failure, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, failure, nil, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"GetResource",
 		"StageResource",
 		"PutAndCheckHash",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
 }
 
 func (s *ResourceSuite) TestSetResourcePutFailureExtra(c *gc.C) {
@@ -245,19 +248,20 @@ func (s *ResourceSuite) TestSetResourcePutFailureExtra(c *gc.C) {
 	failure := errors.New("<failure>")
 	extraErr := errors.New("<just not your day>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, failure, extraErr, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, failure, extraErr, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"GetResource",
 		"StageResource",
 		"PutAndCheckHash",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
 }
 
 func (s *ResourceSuite) TestSetResourceSetFailureBasic(c *gc.C) {
@@ -271,22 +275,23 @@ func (s *ResourceSuite) TestSetResourceSetFailureBasic(c *gc.C) {
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, failure, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, failure, nil, nil, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"GetResource",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 		"Remove",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
-	s.stub.CheckCall(c, 4, "Remove", path)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 5, "Remove", path)
 }
 
 func (s *ResourceSuite) TestSetResourceSetFailureExtra(c *gc.C) {
@@ -302,22 +307,195 @@ func (s *ResourceSuite) TestSetResourceSetFailureExtra(c *gc.C) {
 	extraErr1 := errors.New("<just not your day>")
 	extraErr2 := errors.New("<wow...just wow>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, failure, extraErr1, extraErr2, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, failure, extraErr1, extraErr2, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"GetResource",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 		"Remove",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
-	s.stub.CheckCall(c, 4, "Remove", path)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 5, "Remove", path)
+}
+
+func (s *ResourceSuite) TestSetResourceArchivesPrevious(c *gc.C) {
+	current := newUploadResource(c, "spam", "old data")
+	s.persist.ReturnGetResource = current
+	s.persist.ReturnGetResourcePath = "service-a-service/resources/spam"
+	s.persist.ReturnListResourceHistory = []resource.HistoricalResource{
+		{Resource: newUploadResource(c, "spam", "older data"), Serial: 2},
+		{Resource: newUploadResource(c, "spam", "oldest data"), Serial: 1},
+		{Resource: newUploadResource(c, "eggs", "other data"), Serial: 7},
+	}
+	oldData := ioutil.NopCloser(strings.NewReader("old data"))
+	s.storage.ReturnGet = resource.Content{Data: oldData, Size: current.Size}
+	expected := newUploadResource(c, "spam", "spamspamspam")
+	file := &stubReader{stub: s.stub}
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	st.historyRetention = 1
+	s.stub.ResetCalls()
+
+	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"currentTimestamp",
+		"GetResource",
+		"ListResourceHistory",
+		"Get",
+		"PutAndCheckHash",
+		"StageResource",
+		"PutAndCheckHash",
+		"Activate",
+		"AddResourceHistory",
+		"ListResourceHistory",
+		"RemoveResourceHistory",
+		"Remove",
+	)
+	historyPath := "service-a-service/resources/history/spam-3"
+	s.stub.CheckCall(c, 3, "Get", "service-a-service/resources/spam")
+	s.stub.CheckCall(c, 4, "PutAndCheckHash", historyPath, oldData, current.Size, current.Fingerprint.String())
+	s.stub.CheckCall(c, 8, "AddResourceHistory", resource.HistoricalResource{
+		Resource: current,
+		Serial:   3,
+	}, historyPath)
+	// The stub does not record the newly kept revision, so only
+	// the oldest one exceeds the retention limit.
+	s.stub.CheckCall(c, 10, "RemoveResourceHistory", "a-service/spam", 1)
+	s.stub.CheckCall(c, 11, "Remove", "service-a-service/resources/history/spam-1")
+}
+
+func (s *ResourceSuite) TestSetResourceFailureNotArchived(c *gc.C) {
+	current := newUploadResource(c, "spam", "old data")
+	s.persist.ReturnGetResource = current
+	s.persist.ReturnGetResourcePath = "service-a-service/resources/spam"
+	oldData := ioutil.NopCloser(strings.NewReader("old data"))
+	s.storage.ReturnGet = resource.Content{Data: oldData, Size: current.Size}
+	expected := newUploadResource(c, "spam", "spamspamspam")
+	file := &stubReader{stub: s.stub}
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, failure)
+
+	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
+	c.Check(errors.Cause(err), gc.Equals, failure)
+
+	// The copy of the current revision is discarded rather than
+	// recorded, as the current revision has not been replaced.
+	s.stub.CheckCallNames(c,
+		"currentTimestamp",
+		"GetResource",
+		"ListResourceHistory",
+		"Get",
+		"PutAndCheckHash",
+		"StageResource",
+		"PutAndCheckHash",
+		"Unstage",
+		"Remove",
+	)
+	s.stub.CheckCall(c, 8, "Remove", "service-a-service/resources/history/spam-1")
+}
+
+func (s *ResourceSuite) TestSetResourceSameContentNotArchived(c *gc.C) {
+	expected := newUploadResource(c, "spam", "spamspamspam")
+	s.persist.ReturnGetResource = expected
+	file := &stubReader{stub: s.stub}
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+
+	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"currentTimestamp",
+		"GetResource",
+		"StageResource",
+		"PutAndCheckHash",
+		"Activate",
+	)
+}
+
+func (s *ResourceSuite) TestListResourceHistory(c *gc.C) {
+	s.persist.ReturnListResourceHistory = []resource.HistoricalResource{
+		{Resource: newUploadResource(c, "spam", "older data"), Serial: 2},
+		{Resource: newUploadResource(c, "spam", "oldest data"), Serial: 1},
+		{Resource: newUploadResource(c, "eggs", "other data"), Serial: 7},
+	}
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	history, err := st.ListResourceHistory("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "ListResourceHistory")
+	s.stub.CheckCall(c, 0, "ListResourceHistory", "a-service")
+	var serials []string
+	for _, res := range history {
+		serials = append(serials, fmt.Sprintf("%s-%d", res.Name, res.Serial))
+	}
+	c.Check(serials, jc.DeepEquals, []string{"eggs-7", "spam-1", "spam-2"})
+}
+
+func (s *ResourceSuite) TestRollbackResource(c *gc.C) {
+	hist := resource.HistoricalResource{
+		Resource: newUploadResource(c, "spam", "old data"),
+		Serial:   2,
+	}
+	hist.Username = "other-user"
+	historyPath := "service-a-service/resources/history/spam-2"
+	s.persist.ReturnGetResourceHistory = hist
+	s.persist.ReturnGetResourceHistoryPath = historyPath
+	oldData := ioutil.NopCloser(strings.NewReader("old data"))
+	s.storage.ReturnGet = resource.Content{Data: oldData, Size: hist.Size}
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+
+	res, err := st.RollbackResource("a-service", "spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"GetResourceHistory",
+		"Get",
+		"currentTimestamp",
+		"GetResource",
+		"StageResource",
+		"PutAndCheckHash",
+		"Activate",
+	)
+	s.stub.CheckCall(c, 0, "GetResourceHistory", "a-service/spam", 2)
+	s.stub.CheckCall(c, 1, "Get", historyPath)
+	s.stub.CheckCall(c, 5, "PutAndCheckHash", "service-a-service/resources/spam", oldData, hist.Size, hist.Fingerprint.String())
+	c.Check(res, jc.DeepEquals, resource.Resource{
+		Resource:  hist.Resource.Resource,
+		ID:        "a-service/spam",
+		ServiceID: "a-service",
+		Username:  "other-user",
+		Timestamp: s.timestamp,
+	})
+}
+
+func (s *ResourceSuite) TestRollbackResourceNotFound(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	s.stub.SetErrors(errors.NotFoundf("revision 2 of resource"))
+
+	_, err := st.RollbackResource("a-service", "spam", 2)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	s.stub.CheckCallNames(c, "GetResourceHistory")
 }
 
 func (s *ResourceSuite) TestUpdatePendingResourceOkay(c *gc.C) {
@@ -11,6 +11,11 @@ import (
 
 var logger = loggo.GetLogger("juju.resource.state")
 
+// DefaultHistoryRetention is the number of previously uploaded
+// revisions of each resource which are kept, so that a service
+// may be rolled back to them.
+const DefaultHistoryRetention = 5
+
 // Persistence is the state persistence functionality needed for resources.
 type Persistence interface {
 	resourcePersistence
@@ -51,6 +56,7 @@ func NewState(raw RawState) *State {
 			currentTimestamp: func() time.Time {
 				return time.Now().UTC()
 			},
+			historyRetention: DefaultHistoryRetention,
 		},
 	}
 	return st
@@ -46,6 +46,9 @@ type stubPersistence struct {
 	ReturnGetResourcePath              string
 	ReturnStageResource                *stubStagedResource
 	ReturnNewResolvePendingResourceOps [][]txn.Op
+	ReturnListResourceHistory          []resource.HistoricalResource
+	ReturnGetResourceHistory           resource.HistoricalResource
+	ReturnGetResourceHistoryPath       string
 
 	CallsForNewResolvePendingResourceOps map[string]string
 }
@@ -132,6 +135,42 @@ func (s *stubPersistence) NewResolvePendingResourceOps(resID, pendingID string)
 	return ops, nil
 }
 
+func (s *stubPersistence) ListResourceHistory(serviceID string) ([]resource.HistoricalResource, error) {
+	s.stub.AddCall("ListResourceHistory", serviceID)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceHistory, nil
+}
+
+func (s *stubPersistence) GetResourceHistory(id string, serial int) (resource.HistoricalResource, string, error) {
+	s.stub.AddCall("GetResourceHistory", id, serial)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.HistoricalResource{}, "", errors.Trace(err)
+	}
+
+	return s.ReturnGetResourceHistory, s.ReturnGetResourceHistoryPath, nil
+}
+
+func (s *stubPersistence) AddResourceHistory(res resource.HistoricalResource, storagePath string) error {
+	s.stub.AddCall("AddResourceHistory", res, storagePath)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubPersistence) RemoveResourceHistory(id string, serial int) error {
+	s.stub.AddCall("RemoveResourceHistory", id, serial)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 type stubStagedResource struct {
 	stub *testing.Stub
 }
@@ -581,8 +581,55 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments to existing filesystems and volumes, e.g. those
+	// of storage instances previously attached to another unit.
+	for filesystemTag, params := range args.filesystemAttachments {
+		f, err := st.filesystemByTag(filesystemTag)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		if f.Life() != Alive {
+			return nil, nil, nil, errors.Errorf("filesystem %q is not alive", filesystemTag.Id())
+		}
+		if _, err := f.Volume(); err != ErrNoBackingVolume {
+			// Volume-backed filesystems are machine-scoped,
+			// and so cannot be attached to another machine.
+			return nil, nil, nil, errors.NotSupportedf(
+				"attaching existing volume-backed filesystem %q", filesystemTag.Id(),
+			)
+		}
+		storageTag, err := f.Storage()
+		if err != nil && !errors.IsNotAssigned(err) {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, txn.Op{
+			C:      filesystemsC,
+			Id:     filesystemTag.Id(),
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		})
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			filesystemTag, storageTag, params,
+		})
+	}
+	for volumeTag, params := range args.volumeAttachments {
+		v, err := st.volumeByTag(volumeTag)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, nil, nil, errors.Errorf("volume %q is not alive", volumeTag.Id())
+		}
+		volumeOps = append(volumeOps, txn.Op{
+			C:      volumesC,
+			Id:     volumeTag.Id(),
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		})
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			volumeTag, params,
+		})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
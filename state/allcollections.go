@@ -186,6 +186,10 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds operator instructions, such as pins and
+		// transfers, that override the usual service leader election.
+		leadershipDirectivesC: {},
+
 		// -----
 
 		// These collections hold information associated with services.
@@ -207,6 +211,41 @@ func allCollections() collectionSchema {
 		// AssignUnitWorker.
 		assignUnitC: {},
 
+		// This collection holds a record of the operations, such as hooks,
+		// recently executed by each unit agent.
+		hookHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "started"},
+			}},
+		},
+
+		// This collection holds the secrets owned by services, and the
+		// services granted access to them.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}, {
+				Key: []string{"model-uuid", "grants"},
+			}},
+		},
+
+		// This collection holds the encrypted values of each revision of
+		// each secret.
+		secretRevisionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+
+		// This collection holds the services that this model offers for
+		// consumption by other models, and the users allowed to consume them.
+		offersC: {},
+
+		// This collection holds the services in other models that are
+		// related to services in this model: those consumed from offers,
+		// and the consumers of this model's offers.
+		remoteServicesC: {},
+
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC:  {},
 		settingsrefsC: {},
@@ -259,6 +298,11 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "volumeid"},
+			}},
+		},
 
 		// -----
 
@@ -358,6 +402,8 @@ func allCollections() collectionSchema {
 		statusesHistoryC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "globalkey"},
+			}, {
+				Key: []string{"model-uuid", "updated"},
 			}},
 		},
 
@@ -393,8 +439,10 @@ const (
 	controllersC             = "controllers"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
+	hookHistoryC             = "hookhistory"
 	instanceDataC            = "instanceData"
 	ipaddressesC             = "ipaddresses"
+	leadershipDirectivesC    = "leadershipdirectives"
 	leaseC                   = "lease"
 	leasesC                  = "leases"
 	machinesC                = "machines"
@@ -410,12 +458,16 @@ const (
 	modelsC                  = "models"
 	networkInterfacesC       = "networkinterfaces"
 	networksC                = "networks"
+	offersC                  = "offers"
 	openedPortsC             = "openedPorts"
 	rebootC                  = "reboot"
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
+	remoteServicesC          = "remoteservices"
 	requestedNetworksC       = "requestednetworks"
 	restoreInfoC             = "restoreInfo"
+	secretRevisionsC         = "secretrevisions"
+	secretsC                 = "secrets"
 	sequenceC                = "sequence"
 	servicesC                = "services"
 	endpointBindingsC        = "endpointbindings"
@@ -437,6 +489,7 @@ const (
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
+	volumeSnapshotsC         = "volumesnapshots"
 	volumesC                 = "volumes"
 	// "payloads" (see payload/persistence/mongo.go)
 	// "resources" (see resource/persistence/mongo.go)
@@ -261,6 +261,9 @@ func (u *backingUnit) updated(st *State, store *multiwatcherStore, id string) er
 		MachineId:   u.MachineId,
 		Subordinate: u.Principal != "",
 		StatusData:  make(map[string]interface{}),
+
+		WorkloadVersion: u.WorkloadVersion,
+		WorkloadInfo:    u.WorkloadInfo,
 	}
 	if u.CharmURL != nil {
 		info.CharmURL = u.CharmURL.String()
@@ -2185,6 +2185,10 @@ func testChangeUnits(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []
 			c.Assert(err, jc.ErrorIsNil)
 			err = u.OpenPort("udp", 17070)
 			c.Assert(err, jc.ErrorIsNil)
+			err = u.SetWorkloadVersion("4.2")
+			c.Assert(err, jc.ErrorIsNil)
+			err = u.SetWorkloadInfo(map[string]string{"edition": "community"})
+			c.Assert(err, jc.ErrorIsNil)
 
 			return changeTestCase{
 				about: "unit is updated if it's in backing and in multiwatcher.Store",
@@ -2235,6 +2239,8 @@ func testChangeUnits(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []
 							Message: "another failure",
 							Data:    map[string]interface{}{},
 						},
+						WorkloadVersion: "4.2",
+						WorkloadInfo:    map[string]string{"edition": "community"},
 					}}}
 		},
 		func(c *gc.C, st *State) changeTestCase {
@@ -43,6 +43,9 @@ type BlockDeviceInfo struct {
 	FilesystemType string   `bson:"fstype,omitempty"`
 	InUse          bool     `bson:"inuse"`
 	MountPoint     string   `bson:"mountpoint,omitempty"`
+	UsedSize       uint64   `bson:"usedsize,omitempty"`
+	VolumeGroup    string   `bson:"volumegroup,omitempty"`
+	FreeSize       uint64   `bson:"freesize,omitempty"`
 }
 
 // WatchBlockDevices returns a new NotifyWatcher watching for
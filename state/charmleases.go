@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// CharmLeaseMaxDuration is the longest time for which a unit may claim
+// or extend a charm lease in a single request.
+const CharmLeaseMaxDuration = time.Hour
+
+var validCharmLeaseName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// CharmLeaseName returns the name of the lease that backs the named charm
+// lease in the supplied scope. A service-scoped lease is only contended by
+// units of that service; a model-scoped lease by every unit in the model.
+func CharmLeaseName(scope names.Tag, name string) (string, error) {
+	switch scope.(type) {
+	case names.ServiceTag, names.ModelTag:
+	default:
+		return "", errors.NotValidf("charm lease scope %q", scope)
+	}
+	if !validCharmLeaseName.MatchString(name) {
+		return "", errors.NotValidf("charm lease name %q", name)
+	}
+	return scope.String() + ":" + name, nil
+}
+
+// charmLeaseSecretary implements lease.Secretary; it checks that leases are
+// charm lease names scoped to a service or to the model, and holders are
+// unit names.
+type charmLeaseSecretary struct {
+	uuid string
+}
+
+// CheckLease is part of the lease.Secretary interface.
+func (s charmLeaseSecretary) CheckLease(name string) error {
+	parts := strings.SplitN(name, ":", 2)
+	if len(parts) != 2 || !validCharmLeaseName.MatchString(parts[1]) {
+		return errors.NewNotValid(nil, "not a charm lease name")
+	}
+	scope, err := names.ParseTag(parts[0])
+	if err != nil {
+		return errors.NewNotValid(nil, "not a charm lease scope")
+	}
+	switch scope := scope.(type) {
+	case names.ServiceTag:
+	case names.ModelTag:
+		if scope.Id() != s.uuid {
+			return errors.NewNotValid(nil, "scope is not this model")
+		}
+	default:
+		return errors.NewNotValid(nil, "not a charm lease scope")
+	}
+	return nil
+}
+
+// CheckHolder is part of the lease.Secretary interface.
+func (charmLeaseSecretary) CheckHolder(name string) error {
+	if !names.IsValidUnit(name) {
+		return errors.NewNotValid(nil, "not a unit name")
+	}
+	return nil
+}
+
+// CheckDuration is part of the lease.Secretary interface.
+func (charmLeaseSecretary) CheckDuration(duration time.Duration) error {
+	if duration <= 0 {
+		return errors.NewNotValid(nil, "non-positive")
+	}
+	if duration > CharmLeaseMaxDuration {
+		return errors.NewNotValid(nil, "longer than "+CharmLeaseMaxDuration.String())
+	}
+	return nil
+}
+
+// CharmLeaseClaimer returns a lease.Claimer for the charm leases in the
+// state's model. Lease names must be built with CharmLeaseName.
+func (st *State) CharmLeaseClaimer() lease.Claimer {
+	return st.charmLeaseManager
+}
+
+// CharmLeaseReleaser returns a lease.Releaser for the charm leases in the
+// state's model.
+func (st *State) CharmLeaseReleaser() lease.Releaser {
+	return st.charmLeaseManager
+}
+
+// CharmLeaseChecker returns a lease.Checker for the charm leases in the
+// state's model.
+func (st *State) CharmLeaseChecker() lease.Checker {
+	return st.charmLeaseManager
+}
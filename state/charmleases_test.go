@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmLeasesSuite struct {
+	ConnSuite
+	clock *coretesting.Clock
+}
+
+var _ = gc.Suite(&CharmLeasesSuite{})
+
+func (s *CharmLeasesSuite) SetUpTest(c *gc.C) {
+	s.clock = coretesting.NewClock(time.Now())
+	s.PatchValue(&state.GetClock, func() clock.Clock {
+		return s.clock
+	})
+	s.ConnSuite.SetUpTest(c)
+}
+
+func (s *CharmLeasesSuite) TestCharmLeaseName(c *gc.C) {
+	name, err := state.CharmLeaseName(names.NewServiceTag("mysql"), "nightly-compaction")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "service-mysql:nightly-compaction")
+
+	name, err = state.CharmLeaseName(s.modelTag, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, s.modelTag.String()+":backup")
+}
+
+func (s *CharmLeasesSuite) TestCharmLeaseNameInvalid(c *gc.C) {
+	_, err := state.CharmLeaseName(names.NewUnitTag("mysql/0"), "backup")
+	c.Check(err, gc.ErrorMatches, `charm lease scope "unit-mysql-0" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	for _, name := range []string{"", "Backup", "back up", "back.up", "-backup", "backup-", "9lives"} {
+		_, err = state.CharmLeaseName(s.modelTag, name)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("name %q", name))
+	}
+}
+
+func (s *CharmLeasesSuite) TestClaimValidatesLeaseName(c *gc.C) {
+	err := s.State.CharmLeaseClaimer().Claim("model-deadbeef:backup", "mysql/0", time.Minute)
+	c.Check(err, gc.ErrorMatches, `cannot claim lease "model-deadbeef:backup": scope is not this model`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	err = s.State.CharmLeaseClaimer().Claim("mysql", "mysql/0", time.Minute)
+	c.Check(err, gc.ErrorMatches, `cannot claim lease "mysql": not a charm lease name`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CharmLeasesSuite) TestClaimValidatesDuration(c *gc.C) {
+	err := s.State.CharmLeaseClaimer().Claim("service-mysql:backup", "mysql/0", 2*time.Hour)
+	c.Check(err, gc.ErrorMatches, `cannot claim lease for 2h0m0s: longer than 1h0m0s`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CharmLeasesSuite) TestClaimCheckRelease(c *gc.C) {
+	claimer := s.State.CharmLeaseClaimer()
+	checker := s.State.CharmLeaseChecker()
+	releaser := s.State.CharmLeaseReleaser()
+	leaseName, err := state.CharmLeaseName(names.NewServiceTag("mysql"), "backup")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = claimer.Claim(leaseName, "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = checker.Token(leaseName, "mysql/0").Check(nil)
+	c.Check(err, jc.ErrorIsNil)
+
+	// Nobody else can have it, or release it...
+	err = claimer.Claim(leaseName, "mysql/1", time.Minute)
+	c.Check(err, gc.Equals, lease.ErrClaimDenied)
+	err = releaser.Release(leaseName, "mysql/1")
+	c.Check(errors.Cause(err), gc.Equals, lease.ErrNotHeld)
+
+	// ...until the holder gives it up, well before it would expire.
+	err = releaser.Release(leaseName, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = checker.Token(leaseName, "mysql/0").Check(nil)
+	c.Check(errors.Cause(err), gc.Equals, lease.ErrNotHeld)
+	err = claimer.Claim(leaseName, "mysql/1", time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *CharmLeasesSuite) TestLeasesIndependentOfLeadership(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	leaseName, err := state.CharmLeaseName(names.NewServiceTag("mysql"), "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CharmLeaseClaimer().Claim(leaseName, "mysql/1", time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+}
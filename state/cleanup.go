@@ -30,6 +30,8 @@ const (
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
 	cleanupModelsForDyingController      cleanupKind = "models"
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
+	cleanupSecretsForRemovedService      cleanupKind = "secrets"
+	cleanupStorageForRemovedService      cleanupKind = "serviceStorage"
 )
 
 // cleanupDoc represents a potentially large set of documents that should be
@@ -105,6 +107,10 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupModelsForDyingController()
 		case cleanupMachinesForDyingModel:
 			err = st.cleanupMachinesForDyingModel()
+		case cleanupSecretsForRemovedService:
+			err = st.cleanupSecretsForRemovedService(doc.Prefix)
+		case cleanupStorageForRemovedService:
+			err = st.cleanupStorageForRemovedService(doc.Prefix)
 		default:
 			err = fmt.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
@@ -303,7 +309,7 @@ func (st *State) cleanupRemovedUnit(unitId string) error {
 			return err
 		}
 	}
-	return nil
+	return removeHookHistory(st, unitId)
 }
 
 // cleanupDyingMachine marks resources owned by the machine as dying, to ensure
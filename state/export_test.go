@@ -492,3 +492,15 @@ func AssertEndpointBindingsNotFoundForService(c *gc.C, service *Service) {
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("endpoint bindings for %q not found", globalKey))
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+var DefaultStatusHistorySize = &defaultStatusHistorySize
+
+// OfferingModels returns the pool of States with which st syncs its
+// cross-model relations.
+func OfferingModels(st *State) *StatePool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.offeringModels
+}
+
+var LockModelPair = lockModelPair
@@ -59,6 +59,11 @@ type Filesystem interface {
 	// if it needs to be provisioned. Params returns true if the returned
 	// parameters are usable for provisioning, otherwise false.
 	Params() (FilesystemParams, bool)
+
+	// PendingResize returns the size, in MiB, that the filesystem has
+	// been requested to grow to, if any. PendingResize returns true if
+	// a resize is pending, otherwise false.
+	PendingResize() (uint64, bool)
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -110,6 +115,7 @@ type filesystemDoc struct {
 	Binding         string            `bson:"binding,omitempty"`
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
+	ResizeSize      uint64            `bson:"resizesize,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -268,6 +274,11 @@ func (f *filesystem) Params() (FilesystemParams, bool) {
 	return *f.doc.Params, true
 }
 
+// PendingResize is required to implement Filesystem.
+func (f *filesystem) PendingResize() (uint64, bool) {
+	return f.doc.ResizeSize, f.doc.ResizeSize != 0
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (StatusInfo, error) {
 	return f.st.FilesystemStatus(f.FilesystemTag())
@@ -0,0 +1,195 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// MaxHookHistory is the number of hook records kept for each unit
+	// by PruneHookHistory.
+	MaxHookHistory = 100
+
+	// MaxHookRecordStderr is the maximum length in bytes of the standard
+	// error output kept in a hook record. Longer output is truncated from
+	// the start, on a rune boundary, so that the final output is kept.
+	MaxHookRecordStderr = 4096
+)
+
+// HookRecord describes a single operation executed by a unit agent,
+// such as running a hook or an action.
+type HookRecord struct {
+	// Operation describes the operation, e.g. "run install hook".
+	Operation string
+
+	// Hook is the kind of hook run by the operation, if any.
+	Hook string
+
+	// RelationId and RemoteUnit identify the relation, and the remote
+	// unit within it, of a relation hook. RelationId is -1 otherwise.
+	RelationId int
+	RemoteUnit string
+
+	// Started is the time at which the operation started, and Duration
+	// is the time it took.
+	Started  time.Time
+	Duration time.Duration
+
+	// ExitCode is the exit code of the charm code run by the operation.
+	// It is -1 if the code did not exit normally, and zero if none was
+	// run.
+	ExitCode int
+
+	// Stderr holds the final standard error output of the charm code.
+	Stderr string
+
+	// Error describes how the operation failed, if it did.
+	Error string
+}
+
+type hookRecordDoc struct {
+	ModelUUID  string `bson:"model-uuid"`
+	Unit       string `bson:"unit"`
+	Operation  string `bson:"operation"`
+	Hook       string `bson:"hook,omitempty"`
+	RelationId int    `bson:"relation-id"`
+	RemoteUnit string `bson:"remote-unit,omitempty"`
+	Started    int64  `bson:"started"`
+	Duration   int64  `bson:"duration"`
+	ExitCode   int    `bson:"exit-code"`
+	Stderr     string `bson:"stderr,omitempty"`
+	Error      string `bson:"error,omitempty"`
+}
+
+// AddHookRecords records operations executed by the unit's agent. Old
+// records are discarded by PruneHookHistory, not here, and all of them
+// once the unit is removed.
+func (u *Unit) AddHookRecords(records ...HookRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(records))
+	for i, record := range records {
+		if record.Operation == "" {
+			return errors.NotValidf("hook record with no operation")
+		}
+		docs[i] = &hookRecordDoc{
+			Unit:       u.Name(),
+			Operation:  record.Operation,
+			Hook:       record.Hook,
+			RelationId: record.RelationId,
+			RemoteUnit: record.RemoteUnit,
+			Started:    record.Started.UnixNano(),
+			Duration:   int64(record.Duration),
+			ExitCode:   record.ExitCode,
+			Stderr:     truncateStderr(record.Stderr),
+			Error:      record.Error,
+		}
+	}
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+	if err := history.Writeable().Insert(docs...); err != nil {
+		return errors.Annotatef(err, "cannot record hook history for unit %q", u)
+	}
+	return nil
+}
+
+// truncateStderr returns the final MaxHookRecordStderr bytes of stderr,
+// or fewer so as to start on a rune boundary.
+func truncateStderr(stderr string) string {
+	if len(stderr) <= MaxHookRecordStderr {
+		return stderr
+	}
+	stderr = stderr[len(stderr)-MaxHookRecordStderr:]
+	// Skip the continuation bytes of a partial rune, but no more: the
+	// output need not be valid UTF-8 at all.
+	for i := 0; i < utf8.UTFMax-1 && len(stderr) > 0 && !utf8.RuneStart(stderr[0]); i++ {
+		stderr = stderr[1:]
+	}
+	return stderr
+}
+
+// removeHookHistory removes all the hook records of the named unit.
+func removeHookHistory(st *State, unitName string) error {
+	history, closer := st.getCollection(hookHistoryC)
+	defer closer()
+
+	_, err := history.Writeable().RemoveAll(bson.D{{"unit", unitName}})
+	if err != nil {
+		return errors.Annotatef(err, "cannot remove hook history for unit %q", unitName)
+	}
+	return nil
+}
+
+// PruneHookHistory removes hook records until only the maxPerUnit
+// most recent records for each unit in the model remain.
+func PruneHookHistory(st *State, maxPerUnit int) error {
+	if maxPerUnit < 1 {
+		return errors.NotValidf("hook history size %d", maxPerUnit)
+	}
+	history, closer := st.getCollection(hookHistoryC)
+	defer closer()
+
+	historyW := history.Writeable()
+	var units []string
+	if err := history.Find(nil).Distinct("unit", &units); err != nil {
+		return errors.Annotate(err, "cannot get units with hook history")
+	}
+	for _, unit := range units {
+		// Discard anything older than the oldest record we keep.
+		var oldest hookRecordDoc
+		err := history.Find(bson.D{{"unit", unit}}).
+			Sort("-started").Skip(maxPerUnit - 1).One(&oldest)
+		if err == mgo.ErrNotFound {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "cannot prune hook history for unit %q", unit)
+		}
+		_, err = historyW.RemoveAll(bson.D{
+			{"unit", unit},
+			{"started", bson.D{{"$lt", oldest.Started}}},
+		})
+		if err != nil {
+			return errors.Annotatef(err, "cannot prune hook history for unit %q", unit)
+		}
+	}
+	return nil
+}
+
+// HookHistory returns up to size of the most recent operations executed
+// by the unit's agent, most recent first.
+func (u *Unit) HookHistory(size int) ([]HookRecord, error) {
+	if size < 1 {
+		return nil, errors.NotValidf("hook history size %d", size)
+	}
+	history, closer := u.st.getCollection(hookHistoryC)
+	defer closer()
+
+	var docs []hookRecordDoc
+	err := history.Find(bson.D{{"unit", u.Name()}}).Sort("-started").Limit(size).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u)
+	}
+	records := make([]HookRecord, len(docs))
+	for i, doc := range docs {
+		records[i] = HookRecord{
+			Operation:  doc.Operation,
+			Hook:       doc.Hook,
+			RelationId: doc.RelationId,
+			RemoteUnit: doc.RemoteUnit,
+			Started:    time.Unix(0, doc.Started).UTC(),
+			Duration:   time.Duration(doc.Duration),
+			ExitCode:   doc.ExitCode,
+			Stderr:     doc.Stderr,
+			Error:      doc.Error,
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type HookHistorySuite struct {
+	statetesting.StateSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *HookHistorySuite) TestEmpty(c *gc.C) {
+	history, err := s.unit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestInvalidSize(c *gc.C) {
+	_, err := s.unit.HookHistory(0)
+	c.Assert(err, gc.ErrorMatches, "hook history size 0 not valid")
+}
+
+func (s *HookHistorySuite) TestMissingOperation(c *gc.C) {
+	err := s.unit.AddHookRecords(state.HookRecord{Hook: "install"})
+	c.Assert(err, gc.ErrorMatches, "hook record with no operation not valid")
+}
+
+func (s *HookHistorySuite) TestAddAndRead(c *gc.C) {
+	started := time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)
+	install := state.HookRecord{
+		Operation:  "run install hook",
+		Hook:       "install",
+		RelationId: -1,
+		Started:    started,
+		Duration:   3 * time.Second,
+	}
+	joined := state.HookRecord{
+		Operation:  "run relation-joined (0; mysql/0) hook",
+		Hook:       "relation-joined",
+		RelationId: 0,
+		RemoteUnit: "mysql/0",
+		Started:    started.Add(time.Minute),
+		Duration:   time.Second,
+		ExitCode:   1,
+		Stderr:     "no database\n",
+		Error:      "exit status 1",
+	}
+	err := s.unit.AddHookRecords(install, joined)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRecord{joined, install})
+
+	history, err = s.unit.HookHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookRecord{joined})
+}
+
+func (s *HookHistorySuite) TestStderrTruncated(c *gc.C) {
+	stderr := "lost" + strings.Repeat("x", state.MaxHookRecordStderr)
+	err := s.unit.AddHookRecords(state.HookRecord{
+		Operation: "run install hook",
+		Started:   time.Now(),
+		Stderr:    stderr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Stderr, gc.Equals, stderr[4:])
+}
+
+func (s *HookHistorySuite) TestStderrTruncatedOnRuneBoundary(c *gc.C) {
+	// The cut falls in the middle of the first "é".
+	stderr := "lost" + strings.Repeat("é", state.MaxHookRecordStderr/2) + "x"
+	err := s.unit.AddHookRecords(state.HookRecord{
+		Operation: "run install hook",
+		Started:   time.Now(),
+		Stderr:    stderr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Stderr, gc.Equals, strings.Repeat("é", state.MaxHookRecordStderr/2-1)+"x")
+}
+
+func (s *HookHistorySuite) TestRemovedWithUnit(c *gc.C) {
+	err := s.unit.AddHookRecords(state.HookRecord{
+		Operation: "run install hook",
+		Started:   time.Now(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestPruned(c *gc.C) {
+	started := time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)
+	var records []state.HookRecord
+	for i := 0; i < state.MaxHookHistory+5; i++ {
+		records = append(records, state.HookRecord{
+			Operation: fmt.Sprintf("run update-status hook %d", i),
+			Started:   started.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	err := s.unit.AddHookRecords(records...)
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := s.unit.HookHistory(state.MaxHookHistory * 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxHookHistory+5)
+
+	err = state.PruneHookHistory(s.State, state.MaxHookHistory)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err = s.unit.HookHistory(state.MaxHookHistory * 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxHookHistory)
+	c.Assert(history[0].Operation, gc.Equals, "run update-status hook 104")
+	c.Assert(history[state.MaxHookHistory-1].Operation, gc.Equals, "run update-status hook 5")
+}
+
+func (s *HookHistorySuite) TestPruneInvalidSize(c *gc.C) {
+	err := state.PruneHookHistory(s.State, 0)
+	c.Assert(err, gc.ErrorMatches, "hook history size 0 not valid")
+}
+
+func (s *HookHistorySuite) TestUnitsIndependent(c *gc.C) {
+	other := s.Factory.MakeUnit(c, nil)
+	err := other.AddHookRecords(state.HookRecord{
+		Operation: "run install hook",
+		Started:   time.Now(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/core/leadership"
 	corelease "github.com/juju/juju/core/lease"
+	statelease "github.com/juju/juju/state/lease"
 	"github.com/juju/juju/worker/lease"
 )
 
@@ -34,6 +35,23 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	return leadershipChecker{st.leadershipManager}
 }
 
+// LeadershipLeases returns a snapshot of the service leadership leases in
+// the state's model, as seen by this state's lease client, for use when
+// diagnosing disagreements about leadership.
+func (st *State) LeadershipLeases() (statelease.Snapshot, error) {
+	snapshot, err := statelease.ReadSnapshot(statelease.ClientConfig{
+		Id:         st.leaseClientId,
+		Namespace:  serviceLeadershipNamespace,
+		Collection: leasesC,
+		Mongo:      &environMongo{st},
+		Clock:      GetClock(),
+	})
+	if err != nil {
+		return statelease.Snapshot{}, errors.Annotatef(err, "cannot read leadership leases")
+	}
+	return snapshot, nil
+}
+
 // HackLeadership stops the state's internal leadership manager to prevent it
 // from interfering with apiserver shutdown.
 func (st *State) HackLeadership() {
@@ -52,6 +70,7 @@ func (st *State) HackLeadership() {
 	// in parallel, of their own accord.)
 	st.leadershipManager.Kill()
 	st.singularManager.Kill()
+	st.charmLeaseManager.Kill()
 }
 
 // buildTxnWithLeadership returns a transaction source that combines the supplied source
@@ -0,0 +1,246 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/worker/lease"
+)
+
+// LeadershipTransferTimeout is how long a requested leadership transfer
+// stands before normal leader election resumes. It exists so that a
+// transfer to a unit whose agent never claims leadership does not leave
+// the service without a leader indefinitely.
+const LeadershipTransferTimeout = 5 * time.Minute
+
+// leadershipDirectiveDoc records operator instructions that override the
+// usual leader election for a service.
+type leadershipDirectiveDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Service   string `bson:"service"`
+
+	// Pinned prevents the service's leadership lease from expiring.
+	Pinned bool `bson:"pinned"`
+
+	// Successor, if set, is the only unit that may claim leadership
+	// until TransferDeadline.
+	Successor        string `bson:"successor,omitempty"`
+	TransferDeadline int64  `bson:"transfer-deadline,omitempty"`
+}
+
+// successor returns the unit to which leadership is being transferred at
+// the supplied time, or "" if there is none.
+func (doc leadershipDirectiveDoc) successor(now time.Time) string {
+	if doc.Successor == "" || now.UnixNano() >= doc.TransferDeadline {
+		return ""
+	}
+	return doc.Successor
+}
+
+// TransferLeadership arranges for leadership of the named service to pass
+// to the named unit. The current leader will be refused when it next
+// tries to extend its lease, and no unit other than the target will be
+// allowed to claim leadership until the target has done so, or until
+// LeadershipTransferTimeout has passed.
+func (st *State) TransferLeadership(serviceName, unitName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot transfer leadership of service %q to %q", serviceName, unitName)
+	if _, err := st.Service(serviceName); err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := st.Unit(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if unit.ServiceName() != serviceName {
+		return errors.NotValidf("unit of service %q", unit.ServiceName())
+	}
+	if unit.Life() != Alive {
+		return errors.New("unit is not alive")
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, found, err := st.leadershipDirective(serviceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Pinned {
+			return nil, errors.New("leadership is pinned")
+		}
+		doc.Successor = unitName
+		doc.TransferDeadline = GetClock().Now().Add(LeadershipTransferTimeout).UnixNano()
+		return upsertLeadershipDirectiveOps(st, serviceName, doc, found), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return st.refreshLeadershipDirectives()
+}
+
+// PinLeadership prevents the named service's current leader from losing
+// leadership through lease expiry, for example because its agent is down
+// during maintenance. It remains leader until UnpinLeadership is called.
+func (st *State) PinLeadership(serviceName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot pin leadership of service %q", serviceName)
+	if _, err := st.Service(serviceName); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, found, err := st.leadershipDirective(serviceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Pinned {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if successor := doc.successor(GetClock().Now()); successor != "" {
+			return nil, errors.Errorf("leadership is being transferred to %q", successor)
+		}
+		doc.Pinned = true
+		return upsertLeadershipDirectiveOps(st, serviceName, doc, found), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return st.refreshLeadershipDirectives()
+}
+
+// UnpinLeadership reverses the effect of PinLeadership.
+func (st *State) UnpinLeadership(serviceName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot unpin leadership of service %q", serviceName)
+	if _, err := st.Service(serviceName); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, found, err := st.leadershipDirective(serviceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !doc.Pinned {
+			return nil, jujutxn.ErrNoOperations
+		}
+		doc.Pinned = false
+		return upsertLeadershipDirectiveOps(st, serviceName, doc, found), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return st.refreshLeadershipDirectives()
+}
+
+// leadershipDirective returns the directive doc for the named service, and
+// whether it exists. A missing doc is reported as a zero value.
+func (st *State) leadershipDirective(serviceName string) (leadershipDirectiveDoc, bool, error) {
+	directives, closer := st.getCollection(leadershipDirectivesC)
+	defer closer()
+
+	var doc leadershipDirectiveDoc
+	err := directives.FindId(serviceName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return leadershipDirectiveDoc{}, false, nil
+	} else if err != nil {
+		return leadershipDirectiveDoc{}, false, errors.Annotatef(err, "cannot read leadership directive")
+	}
+	return doc, true, nil
+}
+
+// upsertLeadershipDirectiveOps returns the operations required to record
+// the supplied directive doc for the named service, creating it if it was
+// not found.
+func upsertLeadershipDirectiveOps(st *State, serviceName string, doc leadershipDirectiveDoc, found bool) []txn.Op {
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     st.docID(serviceName),
+		Assert: isAliveDoc,
+	}}
+	if found {
+		return append(ops, txn.Op{
+			C:      leadershipDirectivesC,
+			Id:     st.docID(serviceName),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"pinned", doc.Pinned},
+				{"successor", doc.Successor},
+				{"transfer-deadline", doc.TransferDeadline},
+			}}},
+		})
+	}
+	doc.Service = serviceName
+	return append(ops, txn.Op{
+		C:      leadershipDirectivesC,
+		Id:     st.docID(serviceName),
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	})
+}
+
+// removeLeadershipDirectiveOp returns the operation required to remove the
+// named service's directive doc, if any.
+func removeLeadershipDirectiveOp(st *State, serviceName string) txn.Op {
+	return txn.Op{
+		C:      leadershipDirectivesC,
+		Id:     st.docID(serviceName),
+		Remove: true,
+	}
+}
+
+// refreshLeadershipDirectives makes the leadership lease manager read the
+// directives again, so that a change to them takes effect immediately.
+func (st *State) refreshLeadershipDirectives() error {
+	if st.leadershipManager == nil {
+		return nil
+	}
+	return errors.Trace(st.leadershipManager.RefreshDirectives())
+}
+
+// leadershipDirectives implements worker/lease.Directives by reading the
+// directive docs written by TransferLeadership and PinLeadership.
+type leadershipDirectives struct {
+	st *State
+}
+
+// Directives is part of the lease.Directives interface.
+func (d leadershipDirectives) Directives() (map[string]lease.Directive, error) {
+	directives, closer := d.st.getCollection(leadershipDirectivesC)
+	defer closer()
+
+	var docs []leadershipDirectiveDoc
+	if err := directives.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read leadership directives")
+	}
+	result := make(map[string]lease.Directive, len(docs))
+	for _, doc := range docs {
+		result[doc.Service] = lease.Directive{
+			Pinned:           doc.Pinned,
+			Successor:        doc.Successor,
+			TransferDeadline: time.Unix(0, doc.TransferDeadline),
+		}
+	}
+	return result, nil
+}
+
+// Transferred is part of the lease.Directives interface.
+func (d leadershipDirectives) Transferred(serviceName string) error {
+	ops := []txn.Op{{
+		C:      leadershipDirectivesC,
+		Id:     d.st.docID(serviceName),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$unset", bson.D{
+			{"successor", nil},
+			{"transfer-deadline", nil},
+		}}},
+	}}
+	err := d.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		// The service has been removed; nothing left to record.
+		return nil
+	}
+	return errors.Trace(err)
+}
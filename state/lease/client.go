@@ -173,6 +173,46 @@ func (client *client) ExpireLease(name string) error {
 	return nil
 }
 
+// ReleaseLease is part of the Client interface.
+func (client *client) ReleaseLease(name, holder string) error {
+	if err := lease.ValidateString(name); err != nil {
+		return errors.Annotatef(err, "invalid name")
+	}
+	if err := lease.ValidateString(holder); err != nil {
+		return errors.Annotatef(err, "invalid holder")
+	}
+
+	// No cache updates needed, only deletes; no closure here.
+	err := client.config.Mongo.RunTransaction(func(attempt int) ([]txn.Op, error) {
+		client.logger.Tracef("releasing lease %q for %s (attempt %d)", name, holder, attempt)
+
+		// On the first attempt, assume cache is good.
+		if attempt > 0 {
+			if err := client.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+
+		// No special error handling here.
+		ops, err := client.releaseLeaseOps(name, holder)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	})
+
+	if err != nil {
+		if errors.Cause(err) == lease.ErrInvalid {
+			return lease.ErrInvalid
+		}
+		return errors.Trace(err)
+	}
+
+	// Uncache this lease entry.
+	delete(client.entries, name)
+	return nil
+}
+
 // Refresh is part of the Client interface.
 func (client *client) Refresh() error {
 	client.logger.Tracef("refreshing")
@@ -318,9 +358,10 @@ func (client *client) claimLeaseOps(name string, request lease.Request) ([]txn.O
 	now := client.config.Clock.Now()
 	expiry := now.Add(request.Duration)
 	nextEntry := entry{
-		holder: request.Holder,
-		expiry: expiry,
-		writer: client.config.Id,
+		holder:  request.Holder,
+		expiry:  expiry,
+		writer:  client.config.Id,
+		claimed: now,
 	}
 
 	// We need to write the entry to the database in a specific format.
@@ -385,9 +426,10 @@ func (client *client) extendLeaseOps(name string, request lease.Request) ([]txn.
 	// We know we need to write a lease; we know when it needs to expire; we
 	// know what needs to go into the local cache:
 	nextEntry := entry{
-		holder: lastEntry.holder,
-		expiry: expiry,
-		writer: client.config.Id,
+		holder:  lastEntry.holder,
+		expiry:  expiry,
+		writer:  client.config.Id,
+		claimed: now,
 	}
 
 	// ...and what needs to change in the database, and how to ensure the
@@ -401,8 +443,9 @@ func (client *client) extendLeaseOps(name string, request lease.Request) ([]txn.
 			fieldLeaseWriter: lastEntry.writer,
 		},
 		Update: bson.M{"$set": bson.M{
-			fieldLeaseExpiry: toInt64(expiry),
-			fieldLeaseWriter: client.config.Id,
+			fieldLeaseExpiry:  toInt64(expiry),
+			fieldLeaseWriter:  client.config.Id,
+			fieldLeaseClaimed: toInt64(now),
 		}},
 	}
 
@@ -451,6 +494,43 @@ func (client *client) expireLeaseOps(name string) ([]txn.Op, error) {
 	return ops, nil
 }
 
+// releaseLeaseOps returns the []txn.Op necessary to vacate the lease on
+// behalf of its holder. Unlike expireLeaseOps, it does not care about the
+// expiry time: the holder is entitled to give up its own lease early. If
+// the release would conflict with cached state, it will return an error
+// with a Cause of ErrInvalid.
+func (client *client) releaseLeaseOps(name, holder string) ([]txn.Op, error) {
+
+	// We can't release a lease that doesn't exist, or isn't held by the
+	// supplied holder.
+	lastEntry, found := client.entries[name]
+	if !found {
+		return nil, lease.ErrInvalid
+	}
+	if lastEntry.holder != holder {
+		return nil, errors.Annotatef(lease.ErrInvalid, "lease %q not held by %s", name, holder)
+	}
+
+	// As with expiry, the change depends on the lease doc being untouched
+	// since we looked.
+	releaseLeaseOp := txn.Op{
+		C:  client.config.Collection,
+		Id: client.leaseDocId(name),
+		Assert: bson.M{
+			fieldLeaseHolder: lastEntry.holder,
+			fieldLeaseExpiry: toInt64(lastEntry.expiry),
+			fieldLeaseWriter: lastEntry.writer,
+		},
+		Remove: true,
+	}
+
+	// We always write a clock-update operation *before* writing lease info.
+	// Removing a lease document counts as writing lease info.
+	writeClockOp := client.writeClockOp(client.config.Clock.Now())
+	ops := []txn.Op{writeClockOp, releaseLeaseOp}
+	return ops, nil
+}
+
 // writeClockOp returns a txn.Op which writes the supplied time to the writer's
 // field in the skew doc, and aborts if a more recent time has been recorded for
 // that writer.
@@ -514,6 +594,11 @@ type entry struct {
 
 	// writer identifies the client that wrote the lease.
 	writer string
+
+	// claimed is the (writer-local) time at which the lease was last
+	// claimed or extended. It may be zero for leases written by older
+	// clients.
+	claimed time.Time
 }
 
 // errNoExtension is used internally to avoid running unnecessary transactions.
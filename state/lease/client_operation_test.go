@@ -164,3 +164,35 @@ func (s *ClientOperationSuite) TestCannotExpireUnheldLease(c *gc.C) {
 	err := fix.Client.ExpireLease("name")
 	c.Assert(err, gc.Equals, lease.ErrInvalid)
 }
+
+func (s *ClientOperationSuite) TestReleaseLeaseBeforeExpiry(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The holder can give it up at any time...
+	err = fix.Client.ReleaseLease("name", "holder")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check("name", fix.Holder(), "")
+
+	// ...and it's then free for anyone to claim.
+	err = fix.Client.ClaimLease("name", lease.Request{"other-holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check("name", fix.Holder(), "other-holder")
+}
+
+func (s *ClientOperationSuite) TestCannotReleaseOtherHoldersLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = fix.Client.ReleaseLease("name", "other-holder")
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+	c.Check("name", fix.Holder(), "holder")
+}
+
+func (s *ClientOperationSuite) TestCannotReleaseUnheldLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ReleaseLease("name", "holder")
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
@@ -95,3 +95,15 @@ func (s *ClientValidationSuite) TestExpireLeaseName(c *gc.C) {
 	err := fix.Client.ExpireLease("$name")
 	c.Check(err, gc.ErrorMatches, "invalid name: string contains forbidden characters")
 }
+
+func (s *ClientValidationSuite) TestReleaseLeaseName(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ReleaseLease("$name", "holder")
+	c.Check(err, gc.ErrorMatches, "invalid name: string contains forbidden characters")
+}
+
+func (s *ClientValidationSuite) TestReleaseLeaseHolder(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ReleaseLease("name", "hol.der")
+	c.Check(err, gc.ErrorMatches, "invalid holder: string contains forbidden characters")
+}
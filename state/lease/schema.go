@@ -27,10 +27,11 @@ const (
 	typeClock = "clock"
 
 	// fieldLease* identify the fields in a leaseDoc.
-	fieldLeaseName   = "name"
-	fieldLeaseHolder = "holder"
-	fieldLeaseExpiry = "expiry"
-	fieldLeaseWriter = "writer"
+	fieldLeaseName    = "name"
+	fieldLeaseHolder  = "holder"
+	fieldLeaseExpiry  = "expiry"
+	fieldLeaseWriter  = "writer"
+	fieldLeaseClaimed = "claimed"
 
 	// fieldClock* identify the fields in a clockDoc.
 	fieldClockWriters = "writers"
@@ -65,10 +66,11 @@ type leaseDoc struct {
 	Namespace string `bson:"namespace"`
 	Name      string `bson:"name"`
 
-	// Holder, Expiry, and Writer map directly to entry.
-	Holder string `bson:"holder"`
-	Expiry int64  `bson:"expiry"`
-	Writer string `bson:"writer"`
+	// Holder, Expiry, Writer, and Claimed map directly to entry.
+	Holder  string `bson:"holder"`
+	Expiry  int64  `bson:"expiry"`
+	Writer  string `bson:"writer"`
+	Claimed int64  `bson:"claimed,omitempty"`
 }
 
 // validate returns an error if any fields are invalid or inconsistent.
@@ -104,6 +106,10 @@ func (doc leaseDoc) entry() (string, entry, error) {
 		expiry: toTime(doc.Expiry),
 		writer: doc.Writer,
 	}
+	if doc.Claimed != 0 {
+		// Leases written by older clients don't record this.
+		entry.claimed = toTime(doc.Claimed)
+	}
 	return doc.Name, entry, nil
 }
 
@@ -118,6 +124,7 @@ func newLeaseDoc(namespace, name string, entry entry) (*leaseDoc, error) {
 		Holder:    entry.holder,
 		Expiry:    toInt64(entry.expiry),
 		Writer:    entry.writer,
+		Claimed:   toInt64(entry.claimed),
 	}
 	if err := doc.validate(); err != nil {
 		return nil, errors.Trace(err)
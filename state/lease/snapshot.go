@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// Snapshot holds a point-in-time view of the leases in a namespace, as seen
+// by a particular client. It exists to help diagnose disagreements about
+// who holds a lease; it must not be used to make decisions about leases.
+type Snapshot struct {
+	// Leases holds the details of every lease in the namespace, by name.
+	Leases map[string]Detail
+
+	// Skews holds what is known about each writer's clock, by writer id.
+	Skews map[string]Skew
+}
+
+// Detail holds everything recorded about a single lease.
+type Detail struct {
+	// Holder is the name of the current leaseholder.
+	Holder string
+
+	// Writer identifies the client that last wrote the lease.
+	Writer string
+
+	// Expiry is the time, according to the writer's clock, at which the
+	// lease is safe to remove.
+	Expiry time.Time
+
+	// EarliestExpiry and LatestExpiry bound the time, according to the
+	// reading client's clock, at which the writer considers the lease
+	// to expire.
+	EarliestExpiry time.Time
+	LatestExpiry   time.Time
+
+	// Claimed is the time, according to the writer's clock, at which the
+	// lease was last claimed or extended. It is zero if the writer did not
+	// record it.
+	Claimed time.Time
+}
+
+// ReadSnapshot reads the current state of the leases in the configured
+// namespace, interpreting remote clocks from the point of view of the
+// configured client id. It neither needs nor affects any running Client.
+func ReadSnapshot(config ClientConfig) (Snapshot, error) {
+	if err := config.validate(); err != nil {
+		return Snapshot{}, errors.Trace(err)
+	}
+	loggerName := fmt.Sprintf("state.lease.%s.%s", config.Namespace, config.Id)
+	reader := &client{
+		config: config,
+		logger: loggo.GetLogger(loggerName),
+	}
+	collection, closer := config.Mongo.GetCollection(config.Collection)
+	defer closer()
+	entries, err := reader.readEntries(collection)
+	if err != nil {
+		return Snapshot{}, errors.Trace(err)
+	}
+	skews, err := reader.readSkews(collection)
+	if err != nil {
+		return Snapshot{}, errors.Trace(err)
+	}
+
+	leases := make(map[string]Detail)
+	for name, entry := range entries {
+		skew := skews[entry.writer]
+		leases[name] = Detail{
+			Holder:         entry.holder,
+			Writer:         entry.writer,
+			Expiry:         entry.expiry,
+			EarliestExpiry: skew.Earliest(entry.expiry),
+			LatestExpiry:   skew.Latest(entry.expiry),
+			Claimed:        entry.claimed,
+		}
+	}
+	return Snapshot{
+		Leases: leases,
+		Skews:  skews,
+	}, nil
+}
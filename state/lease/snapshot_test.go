@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state/lease"
+)
+
+// SnapshotSuite verifies the diagnostic view of a namespace's leases.
+type SnapshotSuite struct {
+	FixtureSuite
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
+
+func (s *SnapshotSuite) TestEmpty(c *gc.C) {
+	fix := s.EasyFixture(c)
+
+	snapshot, err := lease.ReadSnapshot(fix.Config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.Leases, gc.HasLen, 0)
+	c.Check(snapshot.Skews, jc.DeepEquals, map[string]lease.Skew{
+		"default-client": lease.Skew{},
+	})
+}
+
+func (s *SnapshotSuite) TestOwnLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", corelease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err := lease.ReadSnapshot(fix.Config)
+	c.Assert(err, jc.ErrorIsNil)
+	expiry := fix.Zero.Add(time.Minute)
+	c.Check(snapshot.Leases, gc.HasLen, 1)
+	detail := snapshot.Leases["name"]
+	c.Check(detail.Holder, gc.Equals, "holder")
+	c.Check(detail.Writer, gc.Equals, "default-client")
+	c.Check(detail.Expiry.Equal(expiry), jc.IsTrue)
+	c.Check(detail.EarliestExpiry.Equal(expiry), jc.IsTrue)
+	c.Check(detail.LatestExpiry.Equal(expiry), jc.IsTrue)
+	c.Check(detail.Claimed.Equal(fix.Zero), jc.IsTrue)
+}
+
+func (s *SnapshotSuite) TestExtensionUpdatesClaimed(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", corelease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	fix.Clock.Advance(time.Minute)
+	err = fix.Client.ExtendLease("name", corelease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err := lease.ReadSnapshot(fix.Config)
+	c.Assert(err, jc.ErrorIsNil)
+	detail := snapshot.Leases["name"]
+	c.Check(detail.Claimed.Equal(fix.Zero.Add(time.Minute)), jc.IsTrue)
+	c.Check(detail.Expiry.Equal(fix.Zero.Add(2*time.Minute)), jc.IsTrue)
+}
+
+func (s *SnapshotSuite) TestRemoteLease(c *gc.C) {
+	fix1 := s.EasyFixture(c)
+	err := fix1.Client.ClaimLease("name", corelease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The reader's clock is an hour ahead of the writer's.
+	fix2 := s.NewFixture(c, FixtureParams{
+		Id:         "other-client",
+		ClockStart: fix1.Zero.Add(time.Hour),
+	})
+	snapshot, err := lease.ReadSnapshot(fix2.Config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	detail := snapshot.Leases["name"]
+	c.Check(detail.Writer, gc.Equals, "default-client")
+	c.Check(detail.Expiry.Equal(fix1.Zero.Add(time.Minute)), jc.IsTrue)
+	c.Check(detail.LatestExpiry.Equal(fix2.Zero.Add(time.Minute)), jc.IsTrue)
+
+	skew := snapshot.Skews["default-client"]
+	c.Check(skew.LastWrite.Equal(fix1.Zero), jc.IsTrue)
+	c.Check(skew.Beginning.Equal(fix2.Zero), jc.IsTrue)
+	c.Check(snapshot.Skews["other-client"], gc.Equals, lease.Skew{})
+}
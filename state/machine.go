@@ -908,11 +908,16 @@ func (m *Machine) Remove() (err error) {
 	if err != nil {
 		return err
 	}
+	volumeSnapshotOps, err := m.st.removeMachineVolumeSnapshotsOps(m.MachineTag())
+	if err != nil {
+		return err
+	}
 	ops = append(ops, ifacesOps...)
 	ops = append(ops, portsOps...)
 	ops = append(ops, removeContainerRefOps(m.st, m.Id())...)
 	ops = append(ops, filesystemOps...)
 	ops = append(ops, volumeOps...)
+	ops = append(ops, volumeSnapshotOps...)
 	ipAddresses, err := m.st.AllocatedIPAddresses(m.Id())
 	if err != nil {
 		return errors.Trace(err)
@@ -200,6 +200,9 @@ type UnitInfo struct {
 	// Workload and agent state are modelled separately.
 	WorkloadStatus StatusInfo
 	AgentStatus    StatusInfo
+	// WorkloadVersion and WorkloadInfo are reported by the charm.
+	WorkloadVersion string
+	WorkloadInfo    map[string]string
 }
 
 // EntityId returns a unique identifier for a unit across
@@ -0,0 +1,245 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// Offer represents a service that a model makes available for
+// consumption by services in other models on the same controller. Only
+// the offer's endpoints may be related to by consumers, and only the
+// model's owner and the offer's users may consume it.
+type Offer struct {
+	st  *State
+	doc offerDoc
+}
+
+// offerDoc represents the internal state of an offer in MongoDB.
+type offerDoc struct {
+	DocID       string   `bson:"_id"`
+	ModelUUID   string   `bson:"model-uuid"`
+	Name        string   `bson:"name"`
+	ServiceName string   `bson:"service"`
+	Endpoints   []string `bson:"endpoints"`
+	Users       []string `bson:"users"`
+}
+
+// String returns the offer name.
+func (o *Offer) String() string {
+	return o.doc.Name
+}
+
+// Name returns the name of the offer, which is unique within the model.
+func (o *Offer) Name() string {
+	return o.doc.Name
+}
+
+// ServiceName returns the name of the offered service.
+func (o *Offer) ServiceName() string {
+	return o.doc.ServiceName
+}
+
+// Users returns the tags of the users, other than the model owner, that
+// may consume the offer.
+func (o *Offer) Users() []names.UserTag {
+	users := make([]names.UserTag, len(o.doc.Users))
+	for i, user := range o.doc.Users {
+		users[i] = names.NewUserTag(user)
+	}
+	return users
+}
+
+// CanConsume reports whether the supplied user may consume the offer.
+func (o *Offer) CanConsume(user names.UserTag) (bool, error) {
+	env, err := o.st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if env.Owner().Canonical() == user.Canonical() {
+		return true, nil
+	}
+	for _, u := range o.doc.Users {
+		if names.NewUserTag(u).Canonical() == user.Canonical() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Endpoints returns the offered endpoints of the service.
+func (o *Offer) Endpoints() ([]Endpoint, error) {
+	svc, err := o.st.Service(o.doc.ServiceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	eps := make([]Endpoint, len(o.doc.Endpoints))
+	for i, name := range o.doc.Endpoints {
+		if eps[i], err = svc.Endpoint(name); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return eps, nil
+}
+
+// Remove removes the offer, so that it can no longer be consumed. Models
+// that have already consumed the offer are not affected.
+func (o *Offer) Remove() error {
+	ops := []txn.Op{{
+		C:      offersC,
+		Id:     o.doc.DocID,
+		Remove: true,
+	}}
+	return errors.Annotatef(o.st.runTransaction(ops), "cannot remove offer %q", o)
+}
+
+// AddOfferArgs defines the arguments for the AddOffer method.
+type AddOfferArgs struct {
+	// Name is the name of the offer; it defaults to the service name.
+	Name string
+
+	// ServiceName is the name of the service to offer.
+	ServiceName string
+
+	// Endpoints holds the names of the service's endpoints that may be
+	// related to by consumers. If empty, all of the service's provider
+	// and requirer endpoints are offered.
+	Endpoints []string
+
+	// Users holds the users, in addition to the model owner, that may
+	// consume the offer.
+	Users []names.UserTag
+}
+
+// AddOffer offers a service for consumption by other models.
+func (st *State) AddOffer(args AddOfferArgs) (_ *Offer, err error) {
+	if args.Name == "" {
+		args.Name = args.ServiceName
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot add offer %q", args.Name)
+
+	if !names.IsValidService(args.Name) {
+		return nil, errors.NotValidf("offer name %q", args.Name)
+	}
+	svc, err := st.Service(args.ServiceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if svc.Life() != Alive {
+		return nil, errors.Errorf("service %q is not alive", args.ServiceName)
+	}
+	if len(args.Endpoints) == 0 {
+		eps, err := svc.Endpoints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range eps {
+			if ep.Role != charm.RolePeer && !ep.IsImplicit() && ep.Scope == charm.ScopeGlobal {
+				args.Endpoints = append(args.Endpoints, ep.Name)
+			}
+		}
+		if len(args.Endpoints) == 0 {
+			return nil, errors.Errorf("service %q has no endpoints to offer", args.ServiceName)
+		}
+	}
+	for _, name := range args.Endpoints {
+		ep, err := svc.Endpoint(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ep.Role == charm.RolePeer || ep.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("endpoint %q cannot be offered", name)
+		}
+	}
+	users := make([]string, len(args.Users))
+	for i, user := range args.Users {
+		users[i] = user.Canonical()
+	}
+
+	doc := &offerDoc{
+		DocID:       st.docID(args.Name),
+		ModelUUID:   st.ModelUUID(),
+		Name:        args.Name,
+		ServiceName: args.ServiceName,
+		Endpoints:   args.Endpoints,
+		Users:       users,
+	}
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     st.docID(args.ServiceName),
+		Assert: isAliveDoc,
+	}, {
+		C:      offersC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if err := svc.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		} else if svc.Life() != Alive {
+			return nil, errors.Errorf("service %q is not alive", args.ServiceName)
+		}
+		return nil, errors.AlreadyExistsf("offer")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Offer{st: st, doc: *doc}, nil
+}
+
+// Offer returns the offer with the supplied name.
+func (st *State) Offer(name string) (*Offer, error) {
+	offers, closer := st.getCollection(offersC)
+	defer closer()
+
+	var doc offerDoc
+	err := offers.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("offer %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get offer %q", name)
+	}
+	return &Offer{st: st, doc: doc}, nil
+}
+
+// AllOffers returns all the offers made by the model.
+func (st *State) AllOffers() ([]*Offer, error) {
+	offers, closer := st.getCollection(offersC)
+	defer closer()
+
+	var docs []offerDoc
+	if err := offers.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all offers")
+	}
+	result := make([]*Offer, len(docs))
+	for i, doc := range docs {
+		result[i] = &Offer{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// removeOffersOps returns the operations necessary to remove the offers
+// of the named service.
+func (st *State) removeOffersOps(serviceName string) ([]txn.Op, error) {
+	offers, closer := st.getCollection(offersC)
+	defer closer()
+
+	var docs []offerDoc
+	if err := offers.Find(bson.D{{"service", serviceName}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      offersC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
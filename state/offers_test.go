@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type offerSuite struct {
+	ConnSuite
+	mysql *state.Service
+}
+
+var _ = gc.Suite(&offerSuite{})
+
+func (s *offerSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *offerSuite) TestAddOfferDefaults(c *gc.C) {
+	offer, err := s.State.AddOffer(state.AddOfferArgs{ServiceName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Name(), gc.Equals, "mysql")
+	c.Assert(offer.ServiceName(), gc.Equals, "mysql")
+	c.Assert(offer.Users(), gc.HasLen, 0)
+	eps, err := offer.Endpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eps, gc.HasLen, 1)
+	c.Assert(eps[0].Name, gc.Equals, "server")
+}
+
+func (s *offerSuite) TestAddOffer(c *gc.C) {
+	_, err := s.State.AddOffer(state.AddOfferArgs{
+		Name:        "db",
+		ServiceName: "mysql",
+		Endpoints:   []string{"server"},
+		Users:       []names.UserTag{names.NewUserTag("bob")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	offer, err := s.State.Offer("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Name(), gc.Equals, "db")
+	c.Assert(offer.Users(), jc.DeepEquals, []names.UserTag{names.NewUserTag("bob@local")})
+	offers, err := s.State.AllOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, gc.HasLen, 1)
+	c.Assert(offers[0].Name(), gc.Equals, "db")
+
+	_, err = s.State.AddOffer(state.AddOfferArgs{Name: "db", ServiceName: "mysql"})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer "db": offer already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *offerSuite) TestAddOfferErrors(c *gc.C) {
+	for i, t := range []struct {
+		args state.AddOfferArgs
+		err  string
+	}{{
+		args: state.AddOfferArgs{ServiceName: "wordpress"},
+		err:  `cannot add offer "wordpress": service "wordpress" not found`,
+	}, {
+		args: state.AddOfferArgs{ServiceName: "mysql", Endpoints: []string{"nope"}},
+		err:  `cannot add offer "mysql": service "mysql" has no "nope" relation`,
+	}, {
+		args: state.AddOfferArgs{Name: "no/good", ServiceName: "mysql"},
+		err:  `cannot add offer "no/good": offer name "no/good" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddOffer(t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *offerSuite) TestCanConsume(c *gc.C) {
+	offer, err := s.State.AddOffer(state.AddOfferArgs{
+		ServiceName: "mysql",
+		Users:       []names.UserTag{names.NewUserTag("bob")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, t := range []struct {
+		user names.UserTag
+		ok   bool
+	}{
+		{s.Owner, true},
+		{names.NewUserTag("bob@local"), true},
+		{names.NewUserTag("mary"), false},
+	} {
+		ok, err := offer.CanConsume(t.user)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(ok, gc.Equals, t.ok, gc.Commentf("user %q", t.user.Canonical()))
+	}
+}
+
+func (s *offerSuite) TestRemove(c *gc.C) {
+	offer, err := s.State.AddOffer(state.AddOfferArgs{ServiceName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = offer.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Offer("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerSuite) TestDestroyServiceRemovesOffers(c *gc.C) {
+	_, err := s.State.AddOffer(state.AddOfferArgs{ServiceName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	offers, err := s.State.AllOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, gc.HasLen, 0)
+}
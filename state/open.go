@@ -288,6 +288,10 @@ func (st *State) Close() (err error) {
 		st.singularManager.Kill()
 		handle("singular manager", st.singularManager.Wait())
 	}
+	if st.charmLeaseManager != nil {
+		st.charmLeaseManager.Kill()
+		handle("charm lease manager", st.charmLeaseManager.Wait())
+	}
 	st.mu.Lock()
 	if st.allManager != nil {
 		handle("allwatcher manager", st.allManager.Stop())
@@ -298,6 +302,9 @@ func (st *State) Close() (err error) {
 	if st.allModelWatcherBacking != nil {
 		handle("allModelWatcher backing", st.allModelWatcherBacking.Release())
 	}
+	if st.offeringModels != nil {
+		handle("offering model states", st.offeringModels.Close())
+	}
 	st.session.Close()
 	st.mu.Unlock()
 
@@ -44,6 +44,7 @@ type relationDoc struct {
 	Endpoints []Endpoint
 	Life      Life
 	UnitCount int
+	Suspended bool `bson:"suspended,omitempty"`
 }
 
 // Relation represents a relation between one or two service endpoints.
@@ -98,6 +99,67 @@ func (r *Relation) Life() Life {
 	return r.doc.Life
 }
 
+// notSuspendedDoc matches relations that are not suspended.
+var notSuspendedDoc = bson.D{{"suspended", bson.D{{"$ne", true}}}}
+
+// Suspended returns whether the relation is suspended. The units of a
+// suspended relation leave its scope, but the relation and its settings
+// remain until it is resumed.
+func (r *Relation) Suspended() bool {
+	return r.doc.Suspended
+}
+
+// SetSuspended suspends or resumes the relation. While a relation is
+// suspended, no units can enter its scope; units already in scope are
+// expected to leave it, running the relation-departed and relation-broken
+// hooks as they would if the relation were destroyed. Once the relation
+// is resumed, the units re-enter its scope with the settings they had.
+func (r *Relation) SetSuspended(suspended bool) (err error) {
+	if suspended {
+		defer errors.DeferredAnnotatef(&err, "cannot suspend relation %q", r)
+	} else {
+		defer errors.DeferredAnnotatef(&err, "cannot resume relation %q", r)
+	}
+	if len(r.doc.Endpoints) == 1 && r.doc.Endpoints[0].Role == charm.RolePeer {
+		return errors.Errorf("is a peer relation")
+	}
+	for _, ep := range r.doc.Endpoints {
+		if ep.Scope == charm.ScopeContainer {
+			// Leaving the scope of a container-scoped relation
+			// causes subordinate units to be destroyed.
+			return errors.Errorf("is a container-scoped relation")
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if r.doc.Life != Alive {
+			return nil, errors.New("relation is not alive")
+		}
+		if r.doc.Suspended == suspended {
+			return nil, jujutxn.ErrNoOperations
+		}
+		assert := notSuspendedDoc
+		if r.doc.Suspended {
+			assert = bson.D{{"suspended", true}}
+		}
+		return []txn.Op{{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: append(assert, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"suspended", suspended}}}},
+		}}, nil
+	}
+	if err := r.st.run(buildTxn); err != nil {
+		return err
+	}
+	r.doc.Suspended = suspended
+	return nil
+}
+
 // Destroy ensures that the relation will be removed at some point; if no units
 // are currently in scope, it will be removed immediately.
 func (r *Relation) Destroy() (err error) {
@@ -147,7 +209,7 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 		return nil, false, errAlreadyDying
 	}
 	if r.doc.UnitCount == 0 {
-		removeOps, err := r.removeOps(ignoreService, nil)
+		removeOps, err := r.removeOps(ignoreService, "")
 		if err != nil {
 			return nil, false, err
 		}
@@ -163,33 +225,50 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 
 // removeOps returns the operations necessary to remove the relation. If
 // ignoreService is not empty, no operations affecting that service will be
-// included; if departingUnit is not nil, this implies that the relation's
-// services may be Dying and otherwise unreferenced, and may thus require
-// removal themselves.
-func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.Op, error) {
+// included; if departingUnitName is not empty, this implies that the
+// relation's services may be Dying and otherwise unreferenced, and may thus
+// require removal themselves.
+func (r *Relation) removeOps(ignoreService string, departingUnitName string) ([]txn.Op, error) {
 	relOp := txn.Op{
 		C:      relationsC,
 		Id:     r.doc.DocID,
 		Remove: true,
 	}
-	if departingUnit != nil {
+	if departingUnitName != "" {
 		relOp.Assert = bson.D{{"life", Dying}, {"unitcount", 1}}
 	} else {
 		relOp.Assert = bson.D{{"life", Alive}, {"unitcount", 0}}
 	}
 	ops := []txn.Op{relOp}
+	var departingService string
+	if departingUnitName != "" {
+		var err error
+		if departingService, err = names.UnitService(departingUnitName); err != nil {
+			return nil, err
+		}
+	}
 	for _, ep := range r.doc.Endpoints {
 		if ep.ServiceName == ignoreService {
 			continue
 		}
+		if isRemote, err := isRemoteService(r.st, ep.ServiceName); err != nil {
+			return nil, err
+		} else if isRemote {
+			remoteOps, err := r.removeRemoteServiceOps(ep.ServiceName, departingUnitName)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, remoteOps...)
+			continue
+		}
 		var asserts bson.D
 		hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
-		if departingUnit == nil {
+		if departingUnitName == "" {
 			// We're constructing a destroy operation, either of the relation
 			// or one of its services, and can therefore be assured that both
 			// services are Alive.
 			asserts = append(hasRelation, isAliveDoc...)
-		} else if ep.ServiceName == departingUnit.ServiceName() {
+		} else if ep.ServiceName == departingService {
 			// This service must have at least one unit -- the one that's
 			// departing the relation -- so it cannot be ready for removal.
 			cannotDieYet := bson.D{{"unitcount", bson.D{{"$gt", 0}}}}
@@ -223,10 +302,51 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 			Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
 		})
 	}
+	secretOps, err := r.revokeSecretGrantsOps()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
 	cleanupOp := r.st.newCleanupOp(cleanupRelationSettings, fmt.Sprintf("r#%d#", r.Id()))
 	return append(ops, cleanupOp), nil
 }
 
+// removeRemoteServiceOps returns the operations necessary to remove the
+// relation's reference to the named remote service, as part of removeOps.
+// Remote services have no units of their own, so a Dying remote service
+// can be removed along with its last relation.
+func (r *Relation) removeRemoteServiceOps(serviceName, departingUnitName string) ([]txn.Op, error) {
+	hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
+	if departingUnitName == "" {
+		return []txn.Op{{
+			C:      remoteServicesC,
+			Id:     r.st.docID(serviceName),
+			Assert: append(hasRelation, isAliveDoc...),
+			Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+		}}, nil
+	}
+	services, closer := r.st.getCollection(remoteServicesC)
+	defer closer()
+
+	svc := &RemoteService{st: r.st}
+	hasLastRef := bson.D{{"life", Dying}, {"relationcount", 1}}
+	removable := append(bson.D{{"_id", serviceName}}, hasLastRef...)
+	if err := services.Find(removable).One(&svc.doc); err == nil {
+		return []txn.Op{svc.removeOps(hasLastRef)}, nil
+	} else if err != mgo.ErrNotFound {
+		return nil, err
+	}
+	return []txn.Op{{
+		C:  remoteServicesC,
+		Id: r.st.docID(serviceName),
+		Assert: bson.D{{"$or", []bson.D{
+			{{"life", Alive}},
+			{{"relationcount", bson.D{{"$gt", 1}}}},
+		}}},
+		Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+	}}, nil
+}
+
 // Id returns the integer internal relation key. This is exposed
 // because the unit agent needs to expose a value derived from this
 // (as JUJU_RELATION_ID) to allow relation hooks to differentiate
@@ -290,7 +410,77 @@ func (r *Relation) Unit(u *Unit) (*RelationUnit, error) {
 		st:       r.st,
 		relation: r,
 		unit:     u,
+		unitName: u.doc.Name,
 		endpoint: ep,
 		scope:    strings.Join(scope, "#"),
 	}, nil
 }
+
+// UnitsInScope returns a RelationUnit for every unit that has entered
+// one of the relation's scopes and not yet left it, ordered by unit name.
+func (r *Relation) UnitsInScope() ([]*RelationUnit, error) {
+	relationScopes, closer := r.st.getCollection(relationScopesC)
+	defer closer()
+
+	prefix := fmt.Sprintf("r#%d#", r.doc.Id)
+	sel := bson.D{{"key", bson.D{{"$regex", "^" + prefix}}}}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get units in scope of relation %q", r)
+	}
+	unitNames := make([]string, len(docs))
+	for i, doc := range docs {
+		unitNames[i] = doc.unitName()
+	}
+	sort.Strings(unitNames)
+
+	result := make([]*RelationUnit, len(unitNames))
+	for i, unitName := range unitNames {
+		serviceName, err := names.UnitService(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isRemote, err := isRemoteService(r.st, serviceName); err != nil {
+			return nil, errors.Trace(err)
+		} else if isRemote {
+			if result[i], err = r.RemoteUnit(unitName); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		}
+		unit, err := r.st.Unit(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if result[i], err = r.Unit(unit); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+// RemoteUnit returns a RelationUnit for the named unit of a remote service
+// in the relation. Remote units are entered into and removed from scope on
+// behalf of the units of the service in the remote service's source model.
+func (r *Relation) RemoteUnit(unitName string) (*RelationUnit, error) {
+	serviceName, err := names.UnitService(unitName)
+	if err != nil {
+		return nil, err
+	}
+	if isRemote, err := isRemoteService(r.st, serviceName); err != nil {
+		return nil, err
+	} else if !isRemote {
+		return nil, errors.Errorf("%q is not a remote service", serviceName)
+	}
+	ep, err := r.Endpoint(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return &RelationUnit{
+		st:       r.st,
+		relation: r,
+		unitName: unitName,
+		endpoint: ep,
+		scope:    "r#" + strconv.Itoa(r.doc.Id),
+	}, nil
+}
@@ -286,6 +286,135 @@ func (s *RelationSuite) TestDestroyPeerRelation(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *RelationSuite) TestUnitsInScope(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := rel.UnitsInScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 0)
+
+	enterScope := func(svc *state.Service) *state.RelationUnit {
+		unit, err := svc.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		ru, err := rel.Unit(unit)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+		return ru
+	}
+	enterScope(wordpress)
+	departing := enterScope(mysql)
+	enterScope(wordpress)
+	err = departing.PrepareLeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	// Units that have not entered scope are not included.
+	_, err = wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err = rel.UnitsInScope()
+	c.Assert(err, jc.ErrorIsNil)
+	var unitNames []string
+	for _, ru := range units {
+		unitNames = append(unitNames, ru.UnitName())
+		c.Check(ru.Relation().Id(), gc.Equals, rel.Id())
+	}
+	c.Assert(unitNames, jc.DeepEquals, []string{"mysql/0", "wordpress/0", "wordpress/1"})
+	joined, err := units[0].Joined()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(joined, jc.IsFalse)
+	c.Assert(units[0].Endpoint().Name, gc.Equals, "server")
+}
+
+func (s *RelationSuite) TestSetSuspended(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsFalse)
+
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Suspending the relation does not force units out of scope, but
+	// prevents them from entering it again.
+	err = rel.SetSuspended(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsTrue)
+	err = rel.SetSuspended(true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, gc.Equals, state.ErrCannotEnterScope)
+
+	// The relation and its settings persist while it is suspended.
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsTrue)
+	c.Assert(rel.Life(), gc.Equals, state.Alive)
+	settings, err := ru.ReadSettings("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"foo": "bar"})
+
+	// Once resumed, units can enter scope again.
+	err = rel.SetSuspended(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Suspended(), jc.IsFalse)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RelationSuite) TestSetSuspendedNotAlive(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rel.SetSuspended(true)
+	c.Assert(err, gc.ErrorMatches, `cannot suspend relation "wordpress:db mysql:server": relation is not alive`)
+}
+
+func (s *RelationSuite) TestSetSuspendedPeerRelation(c *gc.C) {
+	riak := s.AddTestingService(c, "riak", s.AddTestingCharm(c, "riak"))
+	riakEP, err := riak.Endpoint("ring")
+	c.Assert(err, jc.ErrorIsNil)
+	rel := assertOneRelation(c, riak, 0, riakEP)
+	err = rel.SetSuspended(true)
+	c.Assert(err, gc.ErrorMatches, `cannot suspend relation "riak:ring": is a peer relation`)
+}
+
+func (s *RelationSuite) TestSetSuspendedContainerRelation(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSuspended(true)
+	c.Assert(err, gc.ErrorMatches, `cannot suspend relation "logging:info wordpress:juju-info": is a container-scoped relation`)
+}
+
 func assertNoRelations(c *gc.C, srv *state.Service) {
 	rels, err := srv.Relations()
 	c.Assert(err, jc.ErrorIsNil)
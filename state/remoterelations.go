@@ -0,0 +1,519 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ConsumeOfferArgs defines the arguments for the ConsumeOffer method.
+type ConsumeOfferArgs struct {
+	// Offer is the offer to consume, as obtained from the State of the
+	// offering model.
+	Offer *Offer
+
+	// Name is the name by which the offered service will be known in
+	// the consuming model; it defaults to the offer name.
+	Name string
+
+	// User is the user consuming the offer.
+	User names.UserTag
+}
+
+// ConsumeOffer adds a remote service to the model, representing the
+// service offered by another model on the same controller. Once consumed,
+// the remote service may be related to the model's services through the
+// offered endpoints, just like any other service.
+func (st *State) ConsumeOffer(args ConsumeOfferArgs) (_ *RemoteService, err error) {
+	offer := args.Offer
+	defer errors.DeferredAnnotatef(&err, "cannot consume offer %q", offer)
+	if args.Name == "" {
+		args.Name = offer.Name()
+	}
+	if offer.st.ModelUUID() == st.ModelUUID() {
+		return nil, errors.Errorf("offer is made by this model")
+	}
+	if ok, err := offer.CanConsume(args.User); err != nil {
+		return nil, errors.Trace(err)
+	} else if !ok {
+		return nil, errors.Unauthorizedf("user %q may not consume offer", args.User.Canonical())
+	}
+	eps, err := offer.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rels := make([]charm.Relation, len(eps))
+	for i, ep := range eps {
+		rels[i] = ep.Relation
+	}
+	return st.AddRemoteService(AddRemoteServiceArgs{
+		Name:          args.Name,
+		SourceModel:   names.NewModelTag(offer.st.ModelUUID()),
+		SourceService: offer.ServiceName(),
+		OfferName:     offer.Name(),
+		Endpoints:     rels,
+	})
+}
+
+// SyncRemoteRelations brings the relations between this model's services
+// and the services it has consumed from other models into agreement with
+// their mirrors in the offering models.
+//
+// Each such relation is mirrored in the offering model by a relation
+// between the offered service and a remote service, named
+// <service>-m<model-uuid>, that represents the consuming service. The units of
+// each side that have joined the relation are entered into the other
+// side's relation as units of the remote service, and their settings are
+// copied. Relations destroyed in the consuming model are destroyed in the
+// offering model, and vice versa; and if the offered service is removed,
+// the remote service consumed from it is destroyed.
+func (st *State) SyncRemoteRelations() error {
+	consumed, err := st.AllRemoteServices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	bySource := make(map[string][]*RemoteService)
+	for _, rsvc := range consumed {
+		if rsvc.IsConsumed() {
+			uuid := rsvc.doc.SourceModelUUID
+			bySource[uuid] = append(bySource[uuid], rsvc)
+		}
+	}
+	// Models that hold mirrors of this model's relations must be visited
+	// even if nothing is consumed from them any longer, so that stale
+	// mirrors can be removed.
+	uuids, err := st.mirroringModels()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for uuid := range bySource {
+		uuids.Add(uuid)
+	}
+	var firstErr error
+	for _, uuid := range uuids.SortedValues() {
+		if err := st.syncOfferingModel(names.NewModelTag(uuid), bySource[uuid]); err != nil {
+			logger.Errorf("cannot sync relations with model %q: %v", uuid, err)
+			if firstErr == nil {
+				firstErr = errors.Annotatef(err, "cannot sync relations with model %q", uuid)
+			}
+		}
+	}
+	return firstErr
+}
+
+// mirroringModels returns the UUIDs of the models holding remote services
+// that represent this model's services.
+func (st *State) mirroringModels() (set.Strings, error) {
+	services, closer := st.getRawCollection(remoteServicesC)
+	defer closer()
+
+	var uuids []string
+	sel := bson.D{
+		{"source-model-uuid", st.ModelUUID()},
+		{"offer-name", bson.D{{"$exists", false}}},
+	}
+	if err := services.Find(sel).Distinct("model-uuid", &uuids); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return set.NewStrings(uuids...), nil
+}
+
+// consumerRemoteServices returns the remote services in the model that
+// represent the services of the model with the supplied UUID.
+func (st *State) consumerRemoteServices(modelUUID string) ([]*RemoteService, error) {
+	services, closer := st.getCollection(remoteServicesC)
+	defer closer()
+
+	var docs []remoteServiceDoc
+	sel := bson.D{
+		{"source-model-uuid", modelUUID},
+		{"offer-name", bson.D{{"$exists", false}}},
+	}
+	if err := services.Find(sel).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*RemoteService, len(docs))
+	for i := range docs {
+		result[i] = newRemoteService(st, &docs[i])
+	}
+	return result, nil
+}
+
+// syncOfferingModel syncs the relations of the supplied remote services,
+// all consumed from the model with the supplied tag, and removes any
+// mirrors in that model of relations that no longer exist.
+func (st *State) syncOfferingModel(tag names.ModelTag, consumed []*RemoteService) error {
+	// The offering model may be syncing its own relations with this
+	// one, which touches the same documents.
+	unlock := lockModelPair(st.ModelUUID(), tag.Id())
+	defer unlock()
+
+	offerSt, err := st.openLiveModel(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	mirrors := make(set.Strings)
+	for _, rsvc := range consumed {
+		rels, err := rsvc.Relations()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, rel := range rels {
+			key, err := st.syncRemoteRelation(offerSt, rsvc, rel)
+			if err != nil {
+				return errors.Annotatef(err, "cannot sync relation %q", rel)
+			}
+			if key != "" {
+				mirrors.Add(key)
+			}
+		}
+		if rsvc.Life() != Alive {
+			continue
+		}
+		removed := offerSt == nil
+		if !removed {
+			_, err := offerSt.Service(rsvc.doc.SourceService)
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+			removed = errors.IsNotFound(err)
+		}
+		if removed {
+			// The offering model or the offered service has been removed.
+			if err := rsvc.Destroy(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	if offerSt == nil {
+		return nil
+	}
+
+	proxies, err := offerSt.consumerRemoteServices(st.ModelUUID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, proxy := range proxies {
+		rels, err := proxy.Relations()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		mirrored := 0
+		for _, rel := range rels {
+			if mirrors.Contains(rel.String()) {
+				mirrored++
+				continue
+			}
+			if err := rel.Destroy(); err != nil {
+				return errors.Trace(err)
+			}
+			if err := syncRemoteUnits(nil, Endpoint{}, rel, proxy.Name(), false); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if mirrored == 0 {
+			if err := proxy.Destroy(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// openLiveModel returns a State for the model with the supplied tag, or
+// nil if the model no longer exists or is not alive. The State is kept
+// for later syncs, and closed with st.
+func (st *State) openLiveModel(tag names.ModelTag) (*State, error) {
+	env, err := st.GetModel(tag)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else if env.Life() != Alive {
+		return nil, nil
+	}
+	st.mu.Lock()
+	if st.offeringModels == nil {
+		st.offeringModels = NewStatePool(st)
+	}
+	pool := st.offeringModels
+	st.mu.Unlock()
+	return pool.Get(tag.Id())
+}
+
+// modelPairLocks serialises the syncing of the cross-model relations
+// between each pair of models.
+var modelPairLocks = struct {
+	sync.Mutex
+	locks map[string]*modelPairLock
+}{locks: make(map[string]*modelPairLock)}
+
+type modelPairLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lockModelPair locks the cross-model relations between the models with
+// the supplied UUIDs, returning the function which unlocks them.
+func lockModelPair(uuid0, uuid1 string) func() {
+	if uuid1 < uuid0 {
+		uuid0, uuid1 = uuid1, uuid0
+	}
+	key := uuid0 + ":" + uuid1
+
+	modelPairLocks.Lock()
+	l, ok := modelPairLocks.locks[key]
+	if !ok {
+		l = &modelPairLock{}
+		modelPairLocks.locks[key] = l
+	}
+	l.refs++
+	modelPairLocks.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		modelPairLocks.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(modelPairLocks.locks, key)
+		}
+		modelPairLocks.Unlock()
+	}
+}
+
+// syncRemoteRelation syncs the supplied relation between a service in
+// this model and the remote service, consumed from the model of offerSt,
+// with its mirror. It returns the key of the mirror relation, if it
+// exists. If offerSt is nil, the relation is destroyed.
+func (st *State) syncRemoteRelation(offerSt *State, rsvc *RemoteService, rel *Relation) (string, error) {
+	remoteEp, err := rel.Endpoint(rsvc.Name())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	localEps, err := rel.RelatedEndpoints(rsvc.Name())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	localEp := localEps[0]
+	if offerSt == nil {
+		if err := rel.Destroy(); err != nil {
+			return "", errors.Trace(err)
+		}
+		return "", syncRemoteUnits(nil, Endpoint{}, rel, rsvc.Name(), false)
+	}
+
+	proxyName := consumerProxyName(st.ModelUUID(), localEp.ServiceName)
+	offeredEp := Endpoint{ServiceName: rsvc.doc.SourceService, Relation: remoteEp.Relation}
+	proxyEp := Endpoint{ServiceName: proxyName, Relation: localEp.Relation}
+	mirror, err := offerSt.EndpointsRelation(offeredEp, proxyEp)
+	if errors.IsNotFound(err) {
+		mirror = nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	switch {
+	case rel.Life() == Alive && mirror == nil:
+		svc, err := offerSt.Service(offeredEp.ServiceName)
+		if errors.IsNotFound(err) || (err == nil && svc.Life() != Alive) {
+			if err := rel.Destroy(); err != nil {
+				return "", errors.Trace(err)
+			}
+			break
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		if err := st.ensureConsumerRemoteService(offerSt, proxyName, localEp.ServiceName); err != nil {
+			return "", errors.Trace(err)
+		}
+		if mirror, err = offerSt.AddRelation(offeredEp, proxyEp); err != nil {
+			return "", errors.Trace(err)
+		}
+	case rel.Life() == Alive && mirror.Life() != Alive:
+		if err := rel.Destroy(); err != nil {
+			return "", errors.Trace(err)
+		}
+	case rel.Life() != Alive && mirror != nil && mirror.Life() == Alive:
+		if err := mirror.Destroy(); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+
+	if mirror == nil {
+		return "", syncRemoteUnits(nil, Endpoint{}, rel, rsvc.Name(), false)
+	}
+	// The units of a suspended relation leave both it and its mirror.
+	join := rel.Life() == Alive && mirror.Life() == Alive && !rel.Suspended() && !mirror.Suspended()
+	if err := syncRemoteUnits(rel, localEp, mirror, proxyName, join); err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := syncRemoteUnits(mirror, offeredEp, rel, rsvc.Name(), join); err != nil {
+		return "", errors.Trace(err)
+	}
+	return mirror.String(), nil
+}
+
+// consumerProxyName returns the name of the remote service that
+// represents, in an offering model, the named service of the consuming
+// model with the supplied UUID. Model names are only unique per owner,
+// so the model UUID is used instead.
+func consumerProxyName(modelUUID, serviceName string) string {
+	return fmt.Sprintf("%s-m%s", serviceName, strings.Replace(modelUUID, "-", "", -1))
+}
+
+// ensureConsumerRemoteService ensures that the model of offerSt holds a
+// remote service with the supplied name, representing the named service
+// of this model.
+func (st *State) ensureConsumerRemoteService(offerSt *State, name, serviceName string) error {
+	proxy, err := offerSt.RemoteService(name)
+	if err == nil {
+		if proxy.doc.SourceModelUUID != st.ModelUUID() || proxy.doc.SourceService != serviceName || proxy.IsConsumed() {
+			return errors.Errorf("remote service %q already exists in offering model", name)
+		}
+		if proxy.Life() != Alive {
+			return errors.Errorf("remote service %q is not alive in offering model", name)
+		}
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	svc, err := st.Service(serviceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	eps, err := svc.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var rels []charm.Relation
+	for _, ep := range eps {
+		if ep.Role != charm.RolePeer && ep.Scope == charm.ScopeGlobal {
+			rels = append(rels, ep.Relation)
+		}
+	}
+	_, err = offerSt.AddRemoteService(AddRemoteServiceArgs{
+		Name:          name,
+		SourceModel:   names.NewModelTag(st.ModelUUID()),
+		SourceService: serviceName,
+		Endpoints:     rels,
+	})
+	return errors.Trace(err)
+}
+
+// syncRemoteUnits enters the units of sourceEp's service that have joined
+// the source relation into the target relation, as units of the named
+// remote service with the same unit numbers and settings; and causes any
+// other units of the remote service to leave the target relation. If join
+// is false, all units of the remote service leave the target relation.
+func syncRemoteUnits(source *Relation, sourceEp Endpoint, target *Relation, remoteService string, join bool) error {
+	sourceUnits := make(map[string]string)
+	if join {
+		var err error
+		if sourceUnits, err = source.joinedUnits(sourceEp, true); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	targetEp, err := target.Endpoint(remoteService)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	targetUnits, err := target.joinedUnits(targetEp, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	wanted := make(set.Strings)
+	for unitName, key := range sourceUnits {
+		remoteName := remoteService + "/" + unitName[strings.Index(unitName, "/")+1:]
+		wanted.Add(remoteName)
+		values, err := readSettings(source.st, key)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ru, err := target.RemoteUnit(remoteName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := targetUnits[remoteName]; !ok {
+			if err := ru.EnterScope(values.Map()); err == ErrCannotEnterScope {
+				// The target relation is no longer alive.
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		settings, err := ru.Settings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if settingsEqual(settings.Map(), values.Map()) {
+			continue
+		}
+		for _, key := range settings.Keys() {
+			if _, ok := values.Get(key); !ok {
+				settings.Delete(key)
+			}
+		}
+		settings.Update(values.Map())
+		if _, err := settings.Write(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for remoteName := range targetUnits {
+		if wanted.Contains(remoteName) {
+			continue
+		}
+		ru, err := target.RemoteUnit(remoteName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func settingsEqual(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// joinedUnits returns the names of the units of the supplied endpoint's
+// service that are in the relation's global scope, mapped to their keys
+// in the settings and relationScopes collections. If joinedOnly is true,
+// units that have prepared to leave scope are excluded.
+func (r *Relation) joinedUnits(ep Endpoint, joinedOnly bool) (map[string]string, error) {
+	relationScopes, closer := r.st.getCollection(relationScopesC)
+	defer closer()
+
+	prefix := fmt.Sprintf("r#%d#%s#%s/", r.doc.Id, ep.Role, ep.ServiceName)
+	sel := bson.D{{"key", bson.D{{"$regex", "^" + prefix}}}}
+	if joinedOnly {
+		sel = append(sel, bson.DocElem{"departing", bson.D{{"$ne", true}}})
+	}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	units := make(map[string]string)
+	for _, doc := range docs {
+		units[doc.unitName()] = doc.Key
+	}
+	return units, nil
+}
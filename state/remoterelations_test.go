@@ -0,0 +1,281 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type remoteRelationsSuite struct {
+	ConnSuite
+	offerState *state.State
+	mysql      *state.Service
+	wordpress  *state.Service
+	proxyName  string
+}
+
+var _ = gc.Suite(&remoteRelationsSuite{})
+
+func (s *remoteRelationsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.offerState = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.offerState.Close() })
+
+	s.mysql = state.AddTestingService(c, s.offerState, "mysql", state.AddTestingCharm(c, s.offerState, "mysql"), s.Owner)
+	offer, err := s.offerState.AddOffer(state.AddOfferArgs{Name: "db", ServiceName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ConsumeOffer(state.ConsumeOfferArgs{Offer: offer, User: s.Owner})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.proxyName = proxyName(s.State, "wordpress")
+}
+
+func proxyName(st *state.State, serviceName string) string {
+	return serviceName + "-m" + strings.Replace(st.ModelUUID(), "-", "", -1)
+}
+
+func (s *remoteRelationsSuite) addRelation(c *gc.C) *state.Relation {
+	eps, err := s.State.InferEndpoints("wordpress", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *remoteRelationsSuite) mirror(c *gc.C) *state.Relation {
+	proxy, err := s.offerState.RemoteService(s.proxyName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(proxy.SourceModel(), gc.Equals, s.State.ModelTag())
+	c.Assert(proxy.SourceService(), gc.Equals, "wordpress")
+	c.Assert(proxy.IsConsumed(), jc.IsFalse)
+	rels, err := proxy.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].String(), gc.Equals, s.proxyName+":db mysql:server")
+	return rels[0]
+}
+
+func (s *remoteRelationsSuite) TestConsumeOfferErrors(c *gc.C) {
+	offer, err := s.offerState.Offer("db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.offerState.ConsumeOffer(state.ConsumeOfferArgs{Offer: offer, Name: "other", User: s.Owner})
+	c.Assert(err, gc.ErrorMatches, `cannot consume offer "db": offer is made by this model`)
+	_, err = s.State.ConsumeOffer(state.ConsumeOfferArgs{Offer: offer, Name: "other", User: names.NewUserTag("mary")})
+	c.Assert(err, gc.ErrorMatches, `cannot consume offer "db": user "mary@local" may not consume offer`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *remoteRelationsSuite) TestSyncCreatesMirror(c *gc.C) {
+	s.addRelation(c)
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	s.mirror(c)
+
+	// Syncing again changes nothing.
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	s.mirror(c)
+}
+
+func (s *remoteRelationsSuite) TestSyncReusesOfferingModelState(c *gc.C) {
+	s.addRelation(c)
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	pool := state.OfferingModels(s.State)
+	c.Assert(pool, gc.NotNil)
+	offerSt, err := pool.Get(s.offerState.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.OfferingModels(s.State), gc.Equals, pool)
+	offerSt2, err := pool.Get(s.offerState.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offerSt2, gc.Equals, offerSt)
+}
+
+func (s *remoteRelationsSuite) TestLockModelPair(c *gc.C) {
+	unlock := state.LockModelPair("a", "b")
+	locked := make(chan struct{})
+	go func() {
+		// The pair is locked whichever way round it is named.
+		unlock := state.LockModelPair("b", "a")
+		close(locked)
+		unlock()
+	}()
+	select {
+	case <-locked:
+		c.Fatalf("model pair locked twice")
+	case <-time.After(coretesting.ShortWait):
+	}
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("model pair not unlocked")
+	}
+}
+
+func (s *remoteRelationsSuite) TestSyncMirrorsUnits(c *gc.C) {
+	rel := s.addRelation(c)
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	mirror := s.mirror(c)
+
+	wpUnit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	wpru, err := rel.Unit(wpUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wpru.EnterScope(map[string]interface{}{"user": "wp"})
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlUnit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlru, err := mirror.Unit(mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlru.EnterScope(map[string]interface{}{"host": "db.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := mysqlru.ReadSettings(s.proxyName + "/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"user": "wp"})
+	settings, err = wpru.ReadSettings("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"host": "db.example.com"})
+
+	// Changed settings are copied.
+	node, err := mysqlru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	node.Set("host", "db2.example.com")
+	_, err = node.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = wpru.ReadSettings("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"host": "db2.example.com"})
+
+	// Departed units leave the other side.
+	err = mysqlru.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	remoteru, err := rel.RemoteUnit("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := remoteru.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsFalse)
+}
+
+func (s *remoteRelationsSuite) TestSyncDestroysMirror(c *gc.C) {
+	rel := s.addRelation(c)
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	mirror := s.mirror(c)
+
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = mirror.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.offerState.RemoteService(s.proxyName)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteRelationsSuite) TestSyncDestroysConsumerRelation(c *gc.C) {
+	rel := s.addRelation(c)
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	mirror := s.mirror(c)
+
+	err = mirror.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteRelationsSuite) TestSyncOfferedServiceRemoved(c *gc.C) {
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RemoteService("db")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteRelationsSuite) TestSyncSameModelNameDifferentOwner(c *gc.C) {
+	env, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	owner := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"}).UserTag()
+	otherState := s.Factory.MakeModel(c, &factory.ModelParams{Name: env.Name(), Owner: owner})
+	defer otherState.Close()
+	state.AddTestingService(c, otherState, "wordpress", state.AddTestingCharm(c, otherState, "wordpress"), owner)
+	offer, err := s.offerState.Offer("db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = otherState.ConsumeOffer(state.ConsumeOfferArgs{Offer: offer, User: s.Owner})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := otherState.InferEndpoints("wordpress", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = otherState.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.addRelation(c)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = otherState.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.mirror(c)
+	proxy, err := s.offerState.RemoteService(proxyName(otherState, "wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(proxy.SourceModel(), gc.Equals, otherState.ModelTag())
+}
+
+func (s *remoteRelationsSuite) TestWatchRemoteRelations(c *gc.C) {
+	w := s.State.WatchRemoteRelations()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Adding a relation with the consumed service is noticed.
+	s.addRelation(c)
+	wc.AssertOneChange()
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Units entering the mirror relation in the offering model are noticed.
+	mysqlUnit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlru, err := s.mirror(c).Unit(mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlru.EnterScope(map[string]interface{}{"host": "db.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes in unrelated models are not.
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	state.AddTestingService(c, otherState, "mysql", state.AddTestingCharm(c, otherState, "mysql"), s.Owner)
+	wc.AssertNoChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
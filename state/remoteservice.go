@@ -0,0 +1,407 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RemoteService represents a service in another model on the same
+// controller, with which services in this model may be related. A remote
+// service is created in the consuming model when an offer is consumed,
+// and in the offering model to represent each consumer of the offer.
+// Remote services have no units of their own in the model; the units of
+// the service they represent are entered into relation scopes by the
+// cross-model relations worker, which also copies their settings.
+type RemoteService struct {
+	st  *State
+	doc remoteServiceDoc
+}
+
+// remoteServiceDoc represents the internal state of a remote service in
+// MongoDB.
+type remoteServiceDoc struct {
+	DocID           string           `bson:"_id"`
+	Name            string           `bson:"name"`
+	ModelUUID       string           `bson:"model-uuid"`
+	SourceModelUUID string           `bson:"source-model-uuid"`
+	SourceService   string           `bson:"source-service"`
+	OfferName       string           `bson:"offer-name,omitempty"`
+	Endpoints       []charm.Relation `bson:"endpoints"`
+	Life            Life             `bson:"life"`
+	RelationCount   int              `bson:"relationcount"`
+}
+
+func newRemoteService(st *State, doc *remoteServiceDoc) *RemoteService {
+	return &RemoteService{
+		st:  st,
+		doc: *doc,
+	}
+}
+
+// String returns the remote service name.
+func (s *RemoteService) String() string {
+	return s.doc.Name
+}
+
+// Name returns the name by which the remote service is known in this
+// model.
+func (s *RemoteService) Name() string {
+	return s.doc.Name
+}
+
+// SourceModel returns the tag of the model holding the service that the
+// remote service represents.
+func (s *RemoteService) SourceModel() names.ModelTag {
+	return names.NewModelTag(s.doc.SourceModelUUID)
+}
+
+// SourceService returns the name of the service that the remote service
+// represents, within the source model.
+func (s *RemoteService) SourceService() string {
+	return s.doc.SourceService
+}
+
+// OfferName returns the name of the offer from which the remote service
+// was consumed. It is empty if the remote service represents a consumer
+// of one of this model's offers.
+func (s *RemoteService) OfferName() string {
+	return s.doc.OfferName
+}
+
+// IsConsumed reports whether the remote service was consumed from an offer
+// made by its source model.
+func (s *RemoteService) IsConsumed() bool {
+	return s.doc.OfferName != ""
+}
+
+// Life returns whether the remote service is Alive, Dying or Dead.
+func (s *RemoteService) Life() Life {
+	return s.doc.Life
+}
+
+// Endpoints returns the remote service's currently available relation
+// endpoints.
+func (s *RemoteService) Endpoints() ([]Endpoint, error) {
+	eps := make([]Endpoint, len(s.doc.Endpoints))
+	for i, rel := range s.doc.Endpoints {
+		eps[i] = Endpoint{
+			ServiceName: s.doc.Name,
+			Relation:    rel,
+		}
+	}
+	sort.Sort(epSlice(eps))
+	return eps, nil
+}
+
+// Endpoint returns the relation endpoint with the supplied name, if it
+// exists.
+func (s *RemoteService) Endpoint(relationName string) (Endpoint, error) {
+	for _, rel := range s.doc.Endpoints {
+		if rel.Name == relationName {
+			return Endpoint{ServiceName: s.doc.Name, Relation: rel}, nil
+		}
+	}
+	return Endpoint{}, errors.Errorf("remote service %q has no %q relation", s, relationName)
+}
+
+// Relations returns a Relation for every relation the remote service is
+// in.
+func (s *RemoteService) Relations() ([]*Relation, error) {
+	return serviceRelations(s.st, s.doc.Name)
+}
+
+// Refresh refreshes the contents of the remote service from the
+// underlying state. It returns an error that satisfies errors.IsNotFound
+// if the remote service has been removed.
+func (s *RemoteService) Refresh() error {
+	services, closer := s.st.getCollection(remoteServicesC)
+	defer closer()
+
+	err := services.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("remote service %q", s)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh remote service %q", s)
+	}
+	return nil
+}
+
+// Destroy ensures that the remote service and all its relations will be
+// removed at some point; if no relation involving the remote service has
+// any units in scope, they are all removed immediately.
+func (s *RemoteService) Destroy() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy remote service %q", s)
+	defer func() {
+		if err == nil {
+			// This is a white lie; the document might actually be removed.
+			s.doc.Life = Dying
+		}
+	}()
+	svc := &RemoteService{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := svc.Refresh(); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		switch ops, err := svc.destroyOps(); err {
+		case errRefresh:
+		case errAlreadyDying:
+			return nil, jujutxn.ErrNoOperations
+		case nil:
+			return ops, nil
+		default:
+			return nil, err
+		}
+		return nil, jujutxn.ErrTransientFailure
+	}
+	return s.st.run(buildTxn)
+}
+
+// destroyOps returns the operations required to destroy the remote
+// service. If it returns errRefresh, the remote service should be
+// refreshed and the destruction operations recalculated.
+func (s *RemoteService) destroyOps() ([]txn.Op, error) {
+	if s.doc.Life == Dying {
+		return nil, errAlreadyDying
+	}
+	rels, err := s.Relations()
+	if err != nil {
+		return nil, err
+	}
+	if len(rels) != s.doc.RelationCount {
+		return nil, errRefresh
+	}
+	var ops []txn.Op
+	removeCount := 0
+	for _, rel := range rels {
+		relOps, isRemove, err := rel.destroyOps(s.doc.Name)
+		if err == errAlreadyDying {
+			relOps = []txn.Op{{
+				C:      relationsC,
+				Id:     rel.doc.DocID,
+				Assert: bson.D{{"life", Dying}},
+			}}
+		} else if err != nil {
+			return nil, err
+		}
+		if isRemove {
+			removeCount++
+		}
+		ops = append(ops, relOps...)
+	}
+	// If all its known relations will be removed, the remote service can
+	// also be removed; otherwise, removal will be handled as a consequence
+	// of the removal of the last relation referencing it.
+	if s.doc.RelationCount == removeCount {
+		hasLastRefs := bson.D{{"life", Alive}, {"relationcount", removeCount}}
+		return append(ops, s.removeOps(hasLastRefs)), nil
+	}
+	update := bson.D{{"$set", bson.D{{"life", Dying}}}}
+	if removeCount != 0 {
+		decref := bson.D{{"$inc", bson.D{{"relationcount", -removeCount}}}}
+		update = append(update, decref...)
+	}
+	return append(ops, txn.Op{
+		C:      remoteServicesC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"life", Alive}, {"relationcount", s.doc.RelationCount}},
+		Update: update,
+	}), nil
+}
+
+// removeOps returns the operation required to remove the remote service.
+// Supplied asserts will be included in the operation on the remote
+// service document.
+func (s *RemoteService) removeOps(asserts bson.D) txn.Op {
+	return txn.Op{
+		C:      remoteServicesC,
+		Id:     s.doc.DocID,
+		Assert: asserts,
+		Remove: true,
+	}
+}
+
+// AddRemoteServiceArgs defines the arguments for the AddRemoteService
+// method.
+type AddRemoteServiceArgs struct {
+	// Name is the name by which the remote service will be known in
+	// this model.
+	Name string
+
+	// SourceModel identifies the model holding the service represented
+	// by the remote service.
+	SourceModel names.ModelTag
+
+	// SourceService is the name of the represented service within the
+	// source model.
+	SourceService string
+
+	// OfferName is the name of the offer from which the service is being
+	// consumed; it is empty when recording a consumer of an offer.
+	OfferName string
+
+	// Endpoints holds the relations that the remote service provides
+	// or requires.
+	Endpoints []charm.Relation
+}
+
+// AddRemoteService creates a new remote service record, with the
+// supplied name and endpoints. The name must not be used by any service
+// or remote service in the model.
+func (st *State) AddRemoteService(args AddRemoteServiceArgs) (_ *RemoteService, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add remote service %q", args.Name)
+
+	if !names.IsValidService(args.Name) {
+		return nil, errors.Errorf("invalid name")
+	}
+	if !names.IsValidService(args.SourceService) {
+		return nil, errors.Errorf("invalid source service %q", args.SourceService)
+	}
+	if len(args.Endpoints) == 0 {
+		return nil, errors.Errorf("no endpoints specified")
+	}
+	for _, rel := range args.Endpoints {
+		if rel.Role == charm.RolePeer {
+			return nil, errors.Errorf("peer relation %q cannot be used remotely", rel.Name)
+		}
+		if rel.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("container scoped relation %q cannot be used remotely", rel.Name)
+		}
+	}
+	env, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if env.Life() != Alive {
+		return nil, errors.Errorf("model is no longer alive")
+	}
+	if args.SourceModel.Id() == env.UUID() {
+		return nil, errors.Errorf("source model is this model")
+	}
+	if exists, err := isNotDead(st, servicesC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("service already exists")
+	}
+	if exists, err := isNotDead(st, remoteServicesC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("remote service already exists")
+	}
+
+	docID := st.docID(args.Name)
+	doc := &remoteServiceDoc{
+		DocID:           docID,
+		Name:            args.Name,
+		ModelUUID:       env.UUID(),
+		SourceModelUUID: args.SourceModel.Id(),
+		SourceService:   args.SourceService,
+		OfferName:       args.OfferName,
+		Endpoints:       args.Endpoints,
+		Life:            Alive,
+	}
+	ops := []txn.Op{
+		env.assertAliveOp(),
+		{
+			C:      servicesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+		}, {
+			C:      remoteServicesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if err := checkModeLife(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Errorf("service already exists")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newRemoteService(st, doc), nil
+}
+
+// RemoteService returns a remote service state by name.
+func (st *State) RemoteService(name string) (_ *RemoteService, err error) {
+	if !names.IsValidService(name) {
+		return nil, errors.NotValidf("remote service name %q", name)
+	}
+	services, closer := st.getCollection(remoteServicesC)
+	defer closer()
+
+	doc := &remoteServiceDoc{}
+	err = services.FindId(name).One(doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("remote service %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get remote service %q", name)
+	}
+	return newRemoteService(st, doc), nil
+}
+
+// AllRemoteServices returns all the remote services in the model.
+func (st *State) AllRemoteServices() (services []*RemoteService, err error) {
+	servicesCollection, closer := st.getCollection(remoteServicesC)
+	defer closer()
+
+	docs := []remoteServiceDoc{}
+	err = servicesCollection.Find(nil).Sort("name").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get all remote services")
+	}
+	for _, v := range docs {
+		services = append(services, newRemoteService(st, &v))
+	}
+	return services, nil
+}
+
+// isRemoteService reports whether the named service is a remote service
+// in the model.
+func isRemoteService(st *State, name string) (bool, error) {
+	services, closer := st.getCollection(remoteServicesC)
+	defer closer()
+
+	count, err := services.FindId(name).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
+
+// addRemoteRelationOps returns the operations necessary to record a new
+// relation on the supplied remote service's endpoint.
+func addRemoteRelationOps(rsvc *RemoteService, ep Endpoint) ([]txn.Op, error) {
+	if rsvc.doc.Life != Alive {
+		return nil, errors.Errorf("remote service %q is not alive", ep.ServiceName)
+	}
+	if ep.Scope == charm.ScopeContainer {
+		return nil, errors.Errorf("container scoped relation cannot include remote service %q", ep.ServiceName)
+	}
+	remoteEp, err := rsvc.Endpoint(ep.Name)
+	if err != nil || remoteEp.Interface != ep.Interface || remoteEp.Role != ep.Role {
+		return nil, errors.Errorf("%q does not implement %q", ep.ServiceName, ep)
+	}
+	return []txn.Op{{
+		C:      remoteServicesC,
+		Id:     rsvc.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
+	}}, nil
+}
@@ -0,0 +1,234 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type remoteServiceSuite struct {
+	ConnSuite
+	rsvc *state.RemoteService
+}
+
+var _ = gc.Suite(&remoteServiceSuite{})
+
+var (
+	sourceModelTag = names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	mysqlServerEp  = charm.Relation{
+		Name:      "server",
+		Role:      charm.RoleProvider,
+		Interface: "mysql",
+		Scope:     charm.ScopeGlobal,
+	}
+)
+
+func (s *remoteServiceSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.rsvc, err = s.State.AddRemoteService(state.AddRemoteServiceArgs{
+		Name:          "mysql",
+		SourceModel:   sourceModelTag,
+		SourceService: "db",
+		OfferName:     "db",
+		Endpoints:     []charm.Relation{mysqlServerEp},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *remoteServiceSuite) TestAttributes(c *gc.C) {
+	c.Assert(s.rsvc.Name(), gc.Equals, "mysql")
+	c.Assert(s.rsvc.SourceModel(), gc.Equals, sourceModelTag)
+	c.Assert(s.rsvc.SourceService(), gc.Equals, "db")
+	c.Assert(s.rsvc.OfferName(), gc.Equals, "db")
+	c.Assert(s.rsvc.IsConsumed(), jc.IsTrue)
+	c.Assert(s.rsvc.Life(), gc.Equals, state.Alive)
+	eps, err := s.rsvc.Endpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(eps, jc.DeepEquals, []state.Endpoint{{
+		ServiceName: "mysql",
+		Relation:    mysqlServerEp,
+	}})
+	_, err = s.rsvc.Endpoint("admin")
+	c.Assert(err, gc.ErrorMatches, `remote service "mysql" has no "admin" relation`)
+
+	rsvc, err := s.State.RemoteService("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rsvc.SourceService(), gc.Equals, "db")
+	all, err := s.State.AllRemoteServices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Name(), gc.Equals, "mysql")
+}
+
+func (s *remoteServiceSuite) TestAddRemoteServiceErrors(c *gc.C) {
+	peerEp := mysqlServerEp
+	peerEp.Role = charm.RolePeer
+	containerEp := mysqlServerEp
+	containerEp.Scope = charm.ScopeContainer
+	for i, t := range []struct {
+		args state.AddRemoteServiceArgs
+		err  string
+	}{{
+		args: state.AddRemoteServiceArgs{Name: "mysql", SourceService: "db", Endpoints: []charm.Relation{mysqlServerEp}},
+		err:  `cannot add remote service "mysql": remote service already exists`,
+	}, {
+		args: state.AddRemoteServiceArgs{Name: "db", SourceService: "db"},
+		err:  `cannot add remote service "db": no endpoints specified`,
+	}, {
+		args: state.AddRemoteServiceArgs{Name: "db", SourceService: "db", Endpoints: []charm.Relation{peerEp}},
+		err:  `cannot add remote service "db": peer relation "server" cannot be used remotely`,
+	}, {
+		args: state.AddRemoteServiceArgs{Name: "db", SourceService: "db", Endpoints: []charm.Relation{containerEp}},
+		err:  `cannot add remote service "db": container scoped relation "server" cannot be used remotely`,
+	}, {
+		args: state.AddRemoteServiceArgs{Name: "db", SourceModel: s.State.ModelTag(), SourceService: "db", Endpoints: []charm.Relation{mysqlServerEp}},
+		err:  `cannot add remote service "db": source model is this model`,
+	}, {
+		args: state.AddRemoteServiceArgs{Name: "bad/name", SourceService: "db", Endpoints: []charm.Relation{mysqlServerEp}},
+		err:  `cannot add remote service "bad/name": invalid name`,
+	}} {
+		c.Logf("test %d", i)
+		if t.args.SourceModel.Id() == "" {
+			t.args.SourceModel = sourceModelTag
+		}
+		_, err := s.State.AddRemoteService(t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *remoteServiceSuite) TestNameClashesWithService(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := s.State.AddRemoteService(state.AddRemoteServiceArgs{
+		Name:          "wordpress",
+		SourceModel:   sourceModelTag,
+		SourceService: "db",
+		Endpoints:     []charm.Relation{mysqlServerEp},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add remote service "wordpress": service already exists`)
+
+	_, err = s.State.AddService(state.AddServiceArgs{
+		Name:  "mysql",
+		Owner: s.Owner.String(),
+		Charm: s.AddTestingCharm(c, "mysql"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add service "mysql": remote service already exists`)
+}
+
+func (s *remoteServiceSuite) TestAddRelation(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.String(), gc.Equals, "wordpress:db mysql:server")
+
+	rels, err := s.rsvc.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].Id(), gc.Equals, rel.Id())
+	rels, err = wordpress.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+}
+
+func (s *remoteServiceSuite) TestAddRelationBetweenRemoteServices(c *gc.C) {
+	_, err := s.State.AddRemoteService(state.AddRemoteServiceArgs{
+		Name:          "wordpress",
+		SourceModel:   sourceModelTag,
+		SourceService: "wordpress",
+		Endpoints: []charm.Relation{{
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Interface: "mysql",
+			Limit:     1,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, gc.ErrorMatches, `cannot add relation "wordpress:db mysql:server": cannot relate remote services to each other`)
+}
+
+func (s *remoteServiceSuite) TestDestroyWithoutRelations(c *gc.C) {
+	err := s.rsvc.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.rsvc.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteServiceSuite) TestDestroyWithRelationInScope(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.rsvc.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.rsvc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rsvc.Life(), gc.Equals, state.Dying)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Life(), gc.Equals, state.Dying)
+
+	// Once the last unit leaves scope, both the relation and the remote
+	// service are removed.
+	err = ru.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.rsvc.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteServiceSuite) TestRemoteUnitScope(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	wpru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = rel.RemoteUnit("wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `"wordpress" is not a remote service`)
+	ru, err := rel.RemoteUnit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"host": "db.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := ru.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsTrue)
+
+	settings, err := wpru.ReadSettings("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"host": "db.example.com"})
+
+	_, err = ru.PrivateAddress()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	err = ru.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err = ru.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsFalse)
+}
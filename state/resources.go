@@ -50,6 +50,14 @@ type Resources interface {
 	// NewResolvePendingResourcesOps generates mongo transaction operations
 	// to set the identified resources as active.
 	NewResolvePendingResourcesOps(serviceID string, pendingIDs map[string]string) ([]txn.Op, error)
+
+	// ListResourceHistory returns the retained previous revisions of
+	// the service's uploaded resources.
+	ListResourceHistory(serviceID string) ([]resource.HistoricalResource, error)
+
+	// RollbackResource restores the identified retained revision
+	// of the resource as the service's current revision.
+	RollbackResource(serviceID, name string, serial int) (resource.Resource, error)
 }
 
 var newResources func(Persistence) Resources
@@ -46,6 +46,10 @@ func unitResourceID(id, unitID string) string {
 	return resourceID(id, "unit", unitID)
 }
 
+func historyResourceID(id string, serial int) string {
+	return resourceID(id, "history", fmt.Sprint(serial))
+}
+
 // stagedResourceID converts an external resource ID into an internal
 // staged one.
 func stagedResourceID(id string) string {
@@ -171,6 +175,30 @@ func newUpdateUnitResourceOps(unitID string, stored storedResource) []txn.Op {
 	}}, newInsertUnitResourceOps(unitID, stored)...)
 }
 
+// newInsertHistoryResourceOps generates transaction operations that
+// will add a doc recording a past revision of a resource.
+func newInsertHistoryResourceOps(stored storedResource, serial int) []txn.Op {
+	doc := newHistoryResourceDoc(stored, serial)
+
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+}
+
+// newRemoveHistoryResourceOps generates transaction operations that
+// will remove the doc recording a past revision of a resource.
+func newRemoveHistoryResourceOps(id string, serial int) []txn.Op {
+	// We don't assert that it exists. We want "missing" to be a noop.
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     historyResourceID(id, serial),
+		Remove: true,
+	}}
+}
+
 // newResolvePendingResourceOps generates transaction operations that
 // will resolve a pending resource doc and make it active.
 //
@@ -208,6 +236,15 @@ func newUnitResourceDoc(unitID string, stored storedResource) *resourceDoc {
 	return unitResource2Doc(fullID, unitID, stored)
 }
 
+// newHistoryResourceDoc generates a doc that represents a retained
+// revision of the given resource.
+func newHistoryResourceDoc(stored storedResource, serial int) *resourceDoc {
+	fullID := historyResourceID(stored.ID, serial)
+	doc := resource2doc(fullID, stored)
+	doc.HistorySerial = serial
+	return doc
+}
+
 // newResourceDoc generates a doc that represents the given resource.
 func newResourceDoc(stored storedResource) *resourceDoc {
 	fullID := serviceResourceID(stored.ID)
@@ -236,6 +273,33 @@ func (p ResourcePersistence) resources(serviceID string) ([]resourceDoc, error)
 	return docs, nil
 }
 
+// history returns the docs for the retained revisions of the given
+// service's resources.
+func (p ResourcePersistence) history(serviceID string) ([]resourceDoc, error) {
+	logger.Tracef("querying db for resource history for %q", serviceID)
+	var docs []resourceDoc
+	query := bson.D{
+		{"service-id", serviceID},
+		{"history-serial", bson.D{{"$gt", 0}}},
+	}
+	if err := p.base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
+// getOneHistory returns the retained revision of the resource with
+// the provided model ID and serial.
+func (p ResourcePersistence) getOneHistory(resID string, serial int) (resourceDoc, error) {
+	logger.Tracef("querying db for resource %q (history %d)", resID, serial)
+	id := historyResourceID(resID, serial)
+	var doc resourceDoc
+	if err := p.base.One(resourcesC, id, &doc); err != nil {
+		return doc, errors.Trace(err)
+	}
+	return doc, nil
+}
+
 // getOne returns the resource that matches the provided model ID.
 func (p ResourcePersistence) getOne(resID string) (resourceDoc, error) {
 	logger.Tracef("querying db for resource %q", resID)
@@ -284,6 +348,8 @@ type resourceDoc struct {
 	StoragePath string `bson:"storage-path"`
 
 	LastPolled time.Time `bson:"timestamp-when-last-polled"`
+
+	HistorySerial int `bson:"history-serial,omitempty"`
 }
 
 func charmStoreResource2Doc(id string, res charmStoreResource) *resourceDoc {
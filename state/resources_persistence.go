@@ -65,7 +65,7 @@ func (p ResourcePersistence) ListResources(serviceID string) (resource.ServiceRe
 
 	var results resource.ServiceResources
 	for _, doc := range docs {
-		if doc.PendingID != "" {
+		if doc.PendingID != "" || doc.HistorySerial != 0 {
 			continue
 		}
 
@@ -107,7 +107,7 @@ func (p ResourcePersistence) ListPendingResources(serviceID string) ([]resource.
 
 	var resources []resource.Resource
 	for _, doc := range docs {
-		if doc.PendingID == "" {
+		if doc.PendingID == "" || doc.HistorySerial != 0 {
 			continue
 		}
 		// doc.UnitID will always be empty here.
@@ -137,6 +137,94 @@ func (p ResourcePersistence) GetResource(id string) (res resource.Resource, stor
 	return stored.Resource, stored.storagePath, nil
 }
 
+// ListResourceHistory returns the retained revisions of each of the
+// identified service's resources.
+func (p ResourcePersistence) ListResourceHistory(serviceID string) ([]resource.HistoricalResource, error) {
+	docs, err := p.history(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var resources []resource.HistoricalResource
+	for _, doc := range docs {
+		res, err := doc2basicResource(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resources = append(resources, resource.HistoricalResource{
+			Resource: res,
+			Serial:   doc.HistorySerial,
+		})
+	}
+	return resources, nil
+}
+
+// GetResourceHistory returns the identified retained revision of the
+// resource, along with the path to its content in storage.
+func (p ResourcePersistence) GetResourceHistory(id string, serial int) (res resource.HistoricalResource, storagePath string, _ error) {
+	doc, err := p.getOneHistory(id, serial)
+	if errors.IsNotFound(err) {
+		err = errors.NotFoundf("revision %d of resource %q", serial, id)
+	}
+	if err != nil {
+		return res, "", errors.Trace(err)
+	}
+
+	stored, err := doc2resource(doc)
+	if err != nil {
+		return res, "", errors.Trace(err)
+	}
+
+	res = resource.HistoricalResource{
+		Resource: stored.Resource,
+		Serial:   doc.HistorySerial,
+	}
+	return res, stored.storagePath, nil
+}
+
+// AddResourceHistory records the retained revision of a resource,
+// the content of which is held in storage at the given path.
+func (p ResourcePersistence) AddResourceHistory(res resource.HistoricalResource, storagePath string) error {
+	if storagePath == "" {
+		return errors.Errorf("missing storage path")
+	}
+	if err := res.Validate(); err != nil {
+		return errors.Annotate(err, "bad resource")
+	}
+
+	stored := storedResource{
+		Resource:    res.Resource,
+		storagePath: storagePath,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			return nil, errors.AlreadyExistsf("revision %d of resource %q", res.Serial, res.ID)
+		}
+		return newInsertHistoryResourceOps(stored, res.Serial), nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RemoveResourceHistory removes the record of the identified retained
+// revision of a resource. If there is no such revision then this is
+// a noop.
+func (p ResourcePersistence) RemoveResourceHistory(id string, serial int) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			// The op has no assert so we should not get here.
+			return nil, errors.New("removing the resource revision failed")
+		}
+		return newRemoveHistoryResourceOps(id, serial), nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // StageResource adds the resource in a separate staging area
 // if the resource isn't already staged. If it is then
 // errors.AlreadyExists is returned. A wrapper around the staged
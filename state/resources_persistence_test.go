@@ -179,6 +179,127 @@ func (s *ResourcePersistenceSuite) TestGetResourceOkay(c *gc.C) {
 	c.Check(storagePath, gc.Equals, expected.storagePath)
 }
 
+func (s *ResourcePersistenceSuite) TestListResourcesIgnoreHistory(c *gc.C) {
+	expected, docs := newPersistenceResources(c, "a-service", "spam", "eggs")
+	expected.Resources = expected.Resources[:1]
+	docs[2].HistorySerial = 1
+	s.base.ReturnAll = docs
+	p := NewResourcePersistence(s.base)
+
+	resources, err := p.ListResources("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	checkResources(c, resources, expected)
+}
+
+func (s *ResourcePersistenceSuite) TestListResourceHistoryOkay(c *gc.C) {
+	res, doc := newPersistenceResource(c, "a-service", "spam")
+	doc.DocID = "resource#a-service/spam#history-1"
+	doc.HistorySerial = 1
+	s.base.ReturnAll = []resourceDoc{doc}
+	p := NewResourcePersistence(s.base)
+
+	history, err := p.ListResourceHistory("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All")
+	s.stub.CheckCall(c, 0, "All",
+		"resources",
+		bson.D{
+			{"service-id", "a-service"},
+			{"history-serial", bson.D{{"$gt", 0}}},
+		},
+		&[]resourceDoc{doc},
+	)
+	c.Check(history, jc.DeepEquals, []resource.HistoricalResource{{
+		Resource: res.Resource,
+		Serial:   1,
+	}})
+}
+
+func (s *ResourcePersistenceSuite) TestGetResourceHistoryOkay(c *gc.C) {
+	res, doc := newPersistenceResource(c, "a-service", "spam")
+	doc.DocID = "resource#a-service/spam#history-2"
+	doc.HistorySerial = 2
+	doc.StoragePath = "service-a-service/resources/history/spam-2"
+	s.base.ReturnOne = doc
+	p := NewResourcePersistence(s.base)
+
+	hist, storagePath, err := p.GetResourceHistory("a-service/spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One")
+	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-service/spam#history-2", &doc)
+	c.Check(hist, jc.DeepEquals, resource.HistoricalResource{
+		Resource: res.Resource,
+		Serial:   2,
+	})
+	c.Check(storagePath, gc.Equals, "service-a-service/resources/history/spam-2")
+}
+
+func (s *ResourcePersistenceSuite) TestGetResourceHistoryNotFound(c *gc.C) {
+	p := NewResourcePersistence(s.base)
+	s.stub.SetErrors(errors.NewNotFound(nil, ""))
+
+	_, _, err := p.GetResourceHistory("a-service/spam", 2)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `revision 2 of resource "a-service/spam" not found`)
+}
+
+func (s *ResourcePersistenceSuite) TestAddResourceHistoryOkay(c *gc.C) {
+	res, doc := newPersistenceResource(c, "a-service", "spam")
+	doc.DocID = "resource#a-service/spam#history-3"
+	doc.HistorySerial = 3
+	doc.StoragePath = "service-a-service/resources/history/spam-3"
+	p := NewResourcePersistence(s.base)
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, ignoredErr)
+
+	err := p.AddResourceHistory(resource.HistoricalResource{
+		Resource: res.Resource,
+		Serial:   3,
+	}, doc.StoragePath)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "RunTransaction")
+	s.stub.CheckCall(c, 1, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-3",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}})
+}
+
+func (s *ResourcePersistenceSuite) TestAddResourceHistoryBadResource(c *gc.C) {
+	res, _ := newPersistenceResource(c, "a-service", "spam")
+	p := NewResourcePersistence(s.base)
+
+	err := p.AddResourceHistory(resource.HistoricalResource{
+		Resource: res.Resource,
+	}, "service-a-service/resources/history/spam-3")
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `bad resource.*`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ResourcePersistenceSuite) TestRemoveResourceHistoryOkay(c *gc.C) {
+	p := NewResourcePersistence(s.base)
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, ignoredErr)
+
+	err := p.RemoveResourceHistory("a-service/spam", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "RunTransaction")
+	s.stub.CheckCall(c, 1, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-3",
+		Remove: true,
+	}})
+}
+
 func (s *ResourcePersistenceSuite) TestStageResourceOkay(c *gc.C) {
 	res, doc := newPersistenceResource(c, "a-service", "spam")
 	doc.DocID += "#staged"
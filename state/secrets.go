@@ -0,0 +1,468 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"golang.org/x/crypto/nacl/secretbox"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// SecretsKeyLength is the length of the key with which secret
+	// values are encrypted.
+	SecretsKeyLength = secretsKeyLength
+
+	secretsKeyLength  = 32
+	secretNonceLength = 24
+)
+
+var validSecretName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// Secret represents a named set of values owned by a service, such as
+// the credentials of a database. Each change to the values creates a new
+// revision; the values of every revision are stored encrypted with a key
+// held by the controller, and may be read by units of the owning service
+// and of the services it has granted access to.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+type secretDoc struct {
+	DocID     string   `bson:"_id"`
+	ModelUUID string   `bson:"model-uuid"`
+	Owner     string   `bson:"owner"`
+	Name      string   `bson:"name"`
+	Revision  int      `bson:"revision"`
+	Grants    []string `bson:"grants"`
+}
+
+type secretRevisionDoc struct {
+	DocID      string `bson:"_id"`
+	ModelUUID  string `bson:"model-uuid"`
+	Owner      string `bson:"owner"`
+	Name       string `bson:"name"`
+	Revision   int    `bson:"revision"`
+	Nonce      []byte `bson:"nonce"`
+	Ciphertext []byte `bson:"ciphertext"`
+}
+
+func secretGlobalKey(owner, name string) string {
+	return owner + "/" + name
+}
+
+func secretRevisionKey(owner, name string, revision int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, name, revision)
+}
+
+// Owner returns the tag of the service that owns the secret.
+func (s *Secret) Owner() names.ServiceTag {
+	return names.NewServiceTag(s.doc.Owner)
+}
+
+// Name returns the name of the secret, which is unique within the
+// owning service.
+func (s *Secret) Name() string {
+	return s.doc.Name
+}
+
+// Revision returns the latest revision of the secret's values.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// Grants returns the tags of the services, other than the owner, that
+// may read the secret.
+func (s *Secret) Grants() []names.ServiceTag {
+	grants := make([]names.ServiceTag, len(s.doc.Grants))
+	for i, service := range s.doc.Grants {
+		grants[i] = names.NewServiceTag(service)
+	}
+	return grants
+}
+
+// CanRead reports whether units of the supplied service may read the
+// secret's values.
+func (s *Secret) CanRead(service names.ServiceTag) bool {
+	if service.Id() == s.doc.Owner {
+		return true
+	}
+	for _, grant := range s.doc.Grants {
+		if grant == service.Id() {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns the decrypted values of the supplied revision of the
+// secret; revision 0 denotes the latest.
+func (s *Secret) Values(revision int) (map[string]string, error) {
+	if revision == 0 {
+		revision = s.doc.Revision
+	}
+	revisions, closer := s.st.getCollection(secretRevisionsC)
+	defer closer()
+	var doc secretRevisionDoc
+	err := revisions.FindId(secretRevisionKey(s.doc.Owner, s.doc.Name, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("revision %d of secret %q", revision, s)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get revision %d of secret %q", revision, s)
+	}
+	key, err := s.st.secretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values, err := decryptSecretValues(key, doc.Nonce, doc.Ciphertext)
+	return values, errors.Annotatef(err, "cannot get revision %d of secret %q", revision, s)
+}
+
+// Grant allows units of the supplied service to read the secret.
+func (s *Secret) Grant(service names.ServiceTag) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.CanRead(service) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if err := checkServiceAlive(s.st, service.Id()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      servicesC,
+			Id:     service.Id(),
+			Assert: isAliveDoc,
+		}, {
+			C:      secretsC,
+			Id:     s.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$addToSet", bson.D{{"grants", service.Id()}}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot grant secret %q to %q", s, service.Id())
+	}
+	if !s.CanRead(service) {
+		s.doc.Grants = append(s.doc.Grants, service.Id())
+	}
+	return nil
+}
+
+// Refresh refreshes the contents of the secret from the underlying
+// state. It returns an error that satisfies errors.IsNotFound if the
+// secret has been removed.
+func (s *Secret) Refresh() error {
+	secrets, closer := s.st.getCollection(secretsC)
+	defer closer()
+	err := secrets.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("secret %q", s)
+	}
+	return errors.Annotatef(err, "cannot refresh secret %q", s)
+}
+
+// String returns the secret's identifier, which is the owning service's
+// name and the secret's name separated by a slash.
+func (s *Secret) String() string {
+	return secretGlobalKey(s.doc.Owner, s.doc.Name)
+}
+
+// Secret returns the named secret owned by the supplied service.
+func (st *State) Secret(owner names.ServiceTag, name string) (*Secret, error) {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+	id := secretGlobalKey(owner.Id(), name)
+	var doc secretDoc
+	err := secrets.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// AddSecretRevision stores the supplied values as a new revision of the
+// named secret owned by the supplied service, creating the secret if it
+// does not exist, and returns the new revision.
+func (st *State) AddSecretRevision(owner names.ServiceTag, name string, values map[string]string) (int, error) {
+	id := secretGlobalKey(owner.Id(), name)
+	if !validSecretName.MatchString(name) {
+		return 0, errors.NotValidf("secret name %q", name)
+	}
+	if len(values) == 0 {
+		return 0, errors.NotValidf("secret %q with no values", id)
+	}
+	key, err := st.secretsKey()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	nonce, ciphertext, err := encryptSecretValues(key, values)
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot add revision to secret %q", id)
+	}
+
+	var revision int
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := checkServiceAlive(st, owner.Id()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      servicesC,
+			Id:     owner.Id(),
+			Assert: isAliveDoc,
+		}}
+		secret, err := st.Secret(owner, name)
+		switch {
+		case errors.IsNotFound(err):
+			revision = 1
+			ops = append(ops, txn.Op{
+				C:      secretsC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &secretDoc{
+					DocID:    id,
+					Owner:    owner.Id(),
+					Name:     name,
+					Revision: revision,
+				},
+			})
+		case err != nil:
+			return nil, errors.Trace(err)
+		default:
+			revision = secret.doc.Revision + 1
+			ops = append(ops, txn.Op{
+				C:      secretsC,
+				Id:     id,
+				Assert: bson.D{{"revision", secret.doc.Revision}},
+				Update: bson.D{{"$set", bson.D{{"revision", revision}}}},
+			})
+		}
+		revisionId := secretRevisionKey(owner.Id(), name, revision)
+		return append(ops, txn.Op{
+			C:      secretRevisionsC,
+			Id:     revisionId,
+			Assert: txn.DocMissing,
+			Insert: &secretRevisionDoc{
+				DocID:      revisionId,
+				Owner:      owner.Id(),
+				Name:       name,
+				Revision:   revision,
+				Nonce:      nonce,
+				Ciphertext: ciphertext,
+			},
+		}), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return 0, errors.Annotatef(err, "cannot add revision to secret %q", id)
+	}
+	return revision, nil
+}
+
+// checkServiceAlive returns an error if the named service does not exist
+// or is not alive.
+func checkServiceAlive(st *State, name string) error {
+	service, err := st.Service(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if service.Life() != Alive {
+		return errors.Errorf("service %q is not alive", name)
+	}
+	return nil
+}
+
+// SetSecretsKey sets the key with which the values of secrets are
+// encrypted. The key is not stored in the database, so that a copy of
+// the database alone does not reveal the values; it is kept in the
+// configuration of the controller agents, which set it on opening state.
+// States for other models returned by ForModel share the key.
+func (st *State) SetSecretsKey(key []byte) error {
+	if len(key) != secretsKeyLength {
+		return errors.NotValidf("secrets key of length %d", len(key))
+	}
+	var k [secretsKeyLength]byte
+	copy(k[:], key)
+	st.secretsKeyValue = &k
+	return nil
+}
+
+// SecretsKey returns the key set by SetSecretsKey, so that it can be
+// passed to other controllers.
+func (st *State) SecretsKey() ([]byte, error) {
+	key, err := st.secretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]byte, secretsKeyLength)
+	copy(result, key[:])
+	return result, nil
+}
+
+// secretsKey returns the controller's key for encrypting secret values,
+// as set by SetSecretsKey.
+func (st *State) secretsKey() (*[secretsKeyLength]byte, error) {
+	if st.secretsKeyValue == nil {
+		return nil, errors.NotFoundf("secrets key")
+	}
+	return st.secretsKeyValue, nil
+}
+
+// ownsOrReadsSecrets reports whether the named service owns any secrets,
+// or has been granted access to any. If that cannot be determined, it
+// errs on the side of true.
+func (st *State) ownsOrReadsSecrets(service string) bool {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+	n, err := secrets.Find(bson.D{{"$or", []bson.D{
+		{{"owner", service}},
+		{{"grants", service}},
+	}}}).Count()
+	return err != nil || n > 0
+}
+
+// revokeSecretGrantsOps returns the operations necessary to revoke the
+// access each service in the relation has to secrets owned by the others,
+// as part of removing the relation. Access is kept while another relation
+// between the same services remains.
+func (r *Relation) revokeSecretGrantsOps() ([]txn.Op, error) {
+	if len(r.doc.Endpoints) < 2 {
+		return nil, nil
+	}
+	relations, closer := r.st.getCollection(relationsC)
+	defer closer()
+	secrets, closer := r.st.getCollection(secretsC)
+	defer closer()
+
+	var ops []txn.Op
+	for _, owner := range r.doc.Endpoints {
+		for _, grantee := range r.doc.Endpoints {
+			if owner.ServiceName == grantee.ServiceName {
+				continue
+			}
+			n, err := relations.Find(bson.D{
+				{"_id", bson.D{{"$ne", r.doc.DocID}}},
+				{"endpoints.servicename", bson.D{{"$all", []string{owner.ServiceName, grantee.ServiceName}}}},
+			}).Count()
+			if err != nil {
+				return nil, errors.Annotate(err, "cannot read relations")
+			}
+			if n > 0 {
+				continue
+			}
+			var doc secretDoc
+			iter := secrets.Find(bson.D{
+				{"owner", owner.ServiceName},
+				{"grants", grantee.ServiceName},
+			}).Iter()
+			for iter.Next(&doc) {
+				ops = append(ops, txn.Op{
+					C:      secretsC,
+					Id:     secretGlobalKey(doc.Owner, doc.Name),
+					Update: bson.D{{"$pull", bson.D{{"grants", grantee.ServiceName}}}},
+				})
+			}
+			if err := iter.Close(); err != nil {
+				return nil, errors.Annotate(err, "cannot read secrets")
+			}
+		}
+	}
+	return ops, nil
+}
+
+// cleanupSecretsForRemovedService removes the secrets owned by the named
+// service, and its access to secrets owned by others, so that a later
+// service with the same name does not inherit either.
+func (st *State) cleanupSecretsForRemovedService(service string) error {
+	secrets, closer := st.getCollection(secretsC)
+	defer closer()
+	revisions, closer := st.getCollection(secretRevisionsC)
+	defer closer()
+
+	var ops []txn.Op
+	var doc secretDoc
+	iter := secrets.Find(bson.D{{"$or", []bson.D{
+		{{"owner", service}},
+		{{"grants", service}},
+	}}}).Iter()
+	for iter.Next(&doc) {
+		id := secretGlobalKey(doc.Owner, doc.Name)
+		if doc.Owner != service {
+			ops = append(ops, txn.Op{
+				C:      secretsC,
+				Id:     id,
+				Update: bson.D{{"$pull", bson.D{{"grants", service}}}},
+			})
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      secretsC,
+			Id:     id,
+			Remove: true,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "cannot read secrets")
+	}
+	var revisionDoc secretRevisionDoc
+	iter = revisions.Find(bson.D{{"owner", service}}).Iter()
+	for iter.Next(&revisionDoc) {
+		ops = append(ops, txn.Op{
+			C:      secretRevisionsC,
+			Id:     secretRevisionKey(revisionDoc.Owner, revisionDoc.Name, revisionDoc.Revision),
+			Remove: true,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "cannot read secret revisions")
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Annotate(st.runTransaction(ops), "cannot remove secrets")
+}
+
+func encryptSecretValues(key *[secretsKeyLength]byte, values map[string]string) (nonce, ciphertext []byte, _ error) {
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var n [secretNonceLength]byte
+	if _, err := io.ReadFull(rand.Reader, n[:]); err != nil {
+		return nil, nil, errors.Annotate(err, "cannot generate nonce")
+	}
+	return n[:], secretbox.Seal(nil, plaintext, &n, key), nil
+}
+
+func decryptSecretValues(key *[secretsKeyLength]byte, nonce, ciphertext []byte) (map[string]string, error) {
+	if len(nonce) != secretNonceLength {
+		return nil, errors.Errorf("nonce has invalid length %d", len(nonce))
+	}
+	var n [secretNonceLength]byte
+	copy(n[:], nonce)
+	plaintext, ok := secretbox.Open(nil, ciphertext, &n, key)
+	if !ok {
+		return nil, errors.New("cannot decrypt values")
+	}
+	var values map[string]string
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return values, nil
+}
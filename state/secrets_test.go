@@ -0,0 +1,179 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	mysql     *state.Service
+	wordpress *state.Service
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	err := s.State.SetSecretsKey(testing.SecretsKey)
+	c.Assert(err, jc.ErrorIsNil)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *SecretsSuite) TestAddSecretRevision(c *gc.C) {
+	revision, err := s.State.AddSecretRevision(s.mysql.ServiceTag(), "root-password", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revision, gc.Equals, 1)
+	revision, err = s.State.AddSecretRevision(s.mysql.ServiceTag(), "root-password", map[string]string{"password": "sekrit2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revision, gc.Equals, 2)
+
+	secret, err := s.State.Secret(s.mysql.ServiceTag(), "root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.String(), gc.Equals, "mysql/root-password")
+	c.Check(secret.Owner(), gc.Equals, s.mysql.ServiceTag())
+	c.Check(secret.Name(), gc.Equals, "root-password")
+	c.Check(secret.Revision(), gc.Equals, 2)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+
+	values, err := secret.Values(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values, jc.DeepEquals, map[string]string{"password": "sekrit2"})
+	values, err = secret.Values(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values, jc.DeepEquals, map[string]string{"password": "sekrit"})
+	_, err = secret.Values(3)
+	c.Check(err, gc.ErrorMatches, `revision 3 of secret "mysql/root-password" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestAddSecretRevisionInvalid(c *gc.C) {
+	for _, name := range []string{"", "Password", "root password", "-password", "9lives"} {
+		_, err := s.State.AddSecretRevision(s.mysql.ServiceTag(), name, map[string]string{"a": "b"})
+		c.Check(err, gc.ErrorMatches, `secret name ".*" not valid`)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+	_, err := s.State.AddSecretRevision(s.mysql.ServiceTag(), "password", nil)
+	c.Check(err, gc.ErrorMatches, `secret "mysql/password" with no values not valid`)
+	_, err = s.State.AddSecretRevision(names.NewServiceTag("nope"), "password", map[string]string{"a": "b"})
+	c.Check(err, gc.ErrorMatches, `cannot add revision to secret "nope/password": service "nope" not found`)
+}
+
+func (s *SecretsSuite) TestValuesEncrypted(c *gc.C) {
+	_, err := s.State.AddSecretRevision(s.mysql.ServiceTag(), "root-password", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	revisions := s.State.MongoSession().DB("juju").C("secretrevisions")
+	err = revisions.Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 1)
+	ciphertext, ok := docs[0]["ciphertext"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(bytes.Contains(ciphertext, []byte("sekrit")), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestSecretsKeyNotSet(c *gc.C) {
+	st, err := state.Open(s.modelTag, statetesting.NewMongoInfo(), statetesting.NewDialOpts(), state.Policy(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	_, err = st.AddSecretRevision(s.mysql.ServiceTag(), "root-password", map[string]string{"password": "sekrit"})
+	c.Check(err, gc.ErrorMatches, `.*secrets key not found`)
+}
+
+func (s *SecretsSuite) TestSetSecretsKeyInvalid(c *gc.C) {
+	err := s.State.SetSecretsKey([]byte("short"))
+	c.Check(err, gc.ErrorMatches, "secrets key of length 5 not valid")
+}
+
+func (s *SecretsSuite) TestSecretNotFound(c *gc.C) {
+	_, err := s.State.Secret(s.mysql.ServiceTag(), "root-password")
+	c.Check(err, gc.ErrorMatches, `secret "mysql/root-password" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestGrant(c *gc.C) {
+	_, err := s.State.AddSecretRevision(s.mysql.ServiceTag(), "root-password", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(s.mysql.ServiceTag(), "root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead(s.mysql.ServiceTag()), jc.IsTrue)
+	c.Check(secret.CanRead(s.wordpress.ServiceTag()), jc.IsFalse)
+
+	err = secret.Grant(s.wordpress.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Grant(s.wordpress.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), jc.DeepEquals, []names.ServiceTag{s.wordpress.ServiceTag()})
+	c.Check(secret.CanRead(s.wordpress.ServiceTag()), jc.IsTrue)
+}
+
+func (s *SecretsSuite) TestGrantServiceNotFound(c *gc.C) {
+	_, err := s.State.AddSecretRevision(s.mysql.ServiceTag(), "root-password", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(s.mysql.ServiceTag(), "root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Grant(names.NewServiceTag("nope"))
+	c.Check(err, gc.ErrorMatches, `cannot grant secret "mysql/root-password" to "nope": service "nope" not found`)
+}
+
+func (s *SecretsSuite) TestRemoveRelationRevokesGrants(c *gc.C) {
+	_, err := s.State.AddSecretRevision(s.mysql.ServiceTag(), "root-password", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(s.mysql.ServiceTag(), "root-password")
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Grant(s.wordpress.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+	c.Check(secret.CanRead(s.wordpress.ServiceTag()), jc.IsFalse)
+}
+
+func (s *SecretsSuite) TestRemoveServiceCleansUpSecrets(c *gc.C) {
+	_, err := s.State.AddSecretRevision(s.mysql.ServiceTag(), "root-password", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecretRevision(s.wordpress.ServiceTag(), "admin-password", map[string]string{"password": "hunter2"})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(s.wordpress.ServiceTag(), "admin-password")
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Grant(s.mysql.ServiceTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Secret(s.mysql.ServiceTag(), "root-password")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = secret.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), gc.HasLen, 0)
+	count, err := s.State.MongoSession().DB("juju").C("secretrevisions").Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 1)
+}
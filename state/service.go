@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/names"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -45,6 +47,8 @@ type serviceDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedCIDRs         []string   `bson:"exposed-cidrs,omitempty"`
+	ExposedSpaces        []string   `bson:"exposed-spaces,omitempty"`
 	MinUnits             int        `bson:"minunits"`
 	OwnerTag             string     `bson:"ownertag"`
 	TxnRevno             int64      `bson:"txn-revno"`
@@ -162,6 +166,11 @@ func (s *Service) destroyOps() ([]txn.Op, error) {
 		return nil, errRefresh
 	}
 	ops := []txn.Op{minUnitsRemoveOp(s.st, s.doc.Name)}
+	offerOps, err := s.st.removeOffersOps(s.doc.Name)
+	if err != nil {
+		return nil, err
+	}
+	ops = append(ops, offerOps...)
 	removeCount := 0
 	for _, rel := range rels {
 		relOps, isRemove, err := rel.destroyOps(s.doc.Name)
@@ -244,8 +253,15 @@ func (s *Service) removeOps(asserts bson.D) []txn.Op {
 		removeConstraintsOp(s.st, s.globalKey()),
 		annotationRemoveOp(s.st, s.globalKey()),
 		removeLeadershipSettingsOp(s.Tag().Id()),
+		removeLeadershipDirectiveOp(s.st, s.Name()),
 		removeStatusOp(s.st, s.globalKey()),
 	}
+	if s.st.ownsOrReadsSecrets(s.Name()) {
+		ops = append(ops, s.st.newCleanupOp(cleanupSecretsForRemovedService, s.Name()))
+	}
+	if s.st.ownsStorage(s.Tag()) {
+		ops = append(ops, s.st.newCleanupOp(cleanupStorageForRemovedService, s.Name()))
+	}
 	return ops
 }
 
@@ -256,29 +272,115 @@ func (s *Service) IsExposed() bool {
 	return s.doc.Exposed
 }
 
-// SetExposed marks the service as exposed.
+// ExposedCIDRs returns the source CIDRs, other than those of the subnets
+// in ExposedSpaces, from which the open ports of an exposed service may be
+// accessed. See SetExposedTo and ExposedSourceCIDRs.
+func (s *Service) ExposedCIDRs() []string {
+	return s.doc.ExposedCIDRs
+}
+
+// ExposedSpaces returns the spaces from whose subnets the open ports of an
+// exposed service may be accessed. See SetExposedTo and ExposedSourceCIDRs.
+func (s *Service) ExposedSpaces() []string {
+	return s.doc.ExposedSpaces
+}
+
+// ExposedSourceCIDRs returns the source CIDRs from which the open ports of
+// an exposed service may currently be accessed: the ExposedCIDRs, and the
+// CIDRs of the subnets now in the ExposedSpaces. A nil result means the
+// ports may be accessed from anywhere; an empty, non-nil result means the
+// exposure is restricted to spaces that hold no subnets, so the ports may
+// not be accessed at all.
+func (s *Service) ExposedSourceCIDRs() ([]string, error) {
+	if len(s.doc.ExposedCIDRs) == 0 && len(s.doc.ExposedSpaces) == 0 {
+		return nil, nil
+	}
+	cidrs := set.NewStrings(s.doc.ExposedCIDRs...)
+	for _, spaceName := range s.doc.ExposedSpaces {
+		space, err := s.st.Space(spaceName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnets, err := space.Subnets()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			cidrs.Add(subnet.CIDR())
+		}
+	}
+	return append([]string{}, cidrs.SortedValues()...), nil
+}
+
+// SetExposed marks the service as exposed to anywhere.
 // See ClearExposed and IsExposed.
 func (s *Service) SetExposed() error {
-	return s.setExposed(true)
+	return s.SetExposedTo(nil, nil)
+}
+
+// SetExposedTo marks the service as exposed, with its open ports
+// accessible only from the supplied source CIDRs and from the subnets in
+// the supplied spaces. If neither are supplied, the ports are accessible
+// from anywhere. The subnets of the spaces are resolved whenever the
+// exposure is read, so subnets later added to the spaces are included.
+// See ClearExposed, IsExposed and ExposedSourceCIDRs.
+func (s *Service) SetExposedTo(cidrs, spaces []string) error {
+	var sortedCIDRs []string
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("cannot expose service %q: invalid source CIDR %q", s, cidr)
+		}
+		sortedCIDRs = append(sortedCIDRs, cidr)
+	}
+	sort.Strings(sortedCIDRs)
+	var sortedSpaces []string
+	for _, spaceName := range spaces {
+		if _, err := s.st.Space(spaceName); err != nil {
+			return errors.Annotatef(err, "cannot expose service %q", s)
+		}
+		sortedSpaces = append(sortedSpaces, spaceName)
+	}
+	sort.Strings(sortedSpaces)
+	return s.setExposed(true, sortedCIDRs, sortedSpaces)
 }
 
 // ClearExposed removes the exposed flag from the service.
 // See SetExposed and IsExposed.
 func (s *Service) ClearExposed() error {
-	return s.setExposed(false)
+	return s.setExposed(false, nil, nil)
 }
 
-func (s *Service) setExposed(exposed bool) (err error) {
+func (s *Service) setExposed(exposed bool, cidrs, spaces []string) (err error) {
+	setFields := bson.D{{"exposed", exposed}}
+	unsetFields := bson.D{}
+	if len(cidrs) > 0 {
+		setFields = append(setFields, bson.DocElem{"exposed-cidrs", cidrs})
+	} else {
+		unsetFields = append(unsetFields, bson.DocElem{"exposed-cidrs", nil})
+	}
+	if len(spaces) > 0 {
+		setFields = append(setFields, bson.DocElem{"exposed-spaces", spaces})
+	} else {
+		unsetFields = append(unsetFields, bson.DocElem{"exposed-spaces", nil})
+	}
+	update := bson.D{{"$set", setFields}}
+	if len(unsetFields) > 0 {
+		update = append(update, bson.DocElem{"$unset", unsetFields})
+	}
 	ops := []txn.Op{{
 		C:      servicesC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for service %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	s.doc.ExposedCIDRs = cidrs
+	s.doc.ExposedSpaces = spaces
 	return nil
 }
 
@@ -1343,6 +1343,93 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestServiceExposedTo(c *gc.C) {
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	err := s.mysql.SetExposedTo([]string{"192.168.0.0/16", "10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+
+	// Exposing to anywhere drops the source CIDRs.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+
+	// So does clearing the exposed flag.
+	err = s.mysql.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestServiceExposedToSpaces(c *gc.C) {
+	_, err := s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("empty", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "internal"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposedTo([]string{"192.168.0.0/16"}, []string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedSpaces(), jc.DeepEquals, []string{"internal"})
+	cidrs, err := s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/24", "192.168.0.0/16"})
+
+	// Subnets added to the space later are included.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24", SpaceName: "internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err = s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/24", "10.0.1.0/24", "192.168.0.0/16"})
+
+	// A space with no subnets allows access from nowhere.
+	err = s.mysql.SetExposedTo(nil, []string{"empty"})
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err = s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.NotNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	// Exposing to anywhere drops the spaces.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedSpaces(), gc.HasLen, 0)
+	cidrs, err = s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.IsNil)
+}
+
+func (s *ServiceSuite) TestServiceExposedToUnknownSpace(c *gc.C) {
+	err := s.mysql.SetExposedTo(nil, []string{"missing"})
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "mysql": space "missing" not found`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ServiceSuite) TestServiceExposedToInvalidCIDR(c *gc.C) {
+	err := s.mysql.SetExposedTo([]string{"10.0.0.0"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "mysql": invalid source CIDR "10.0.0.0"`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -1986,6 +2073,33 @@ func (s *ServiceSuite) TestWatchRelations(c *gc.C) {
 	wpxWatcherC.AssertNoChange()
 }
 
+func (s *ServiceSuite) TestWatchRelationsSuspended(c *gc.C) {
+	wpch := s.AddTestingCharm(c, "wordpress")
+	wp := s.AddTestingService(c, "wordpress", wpch)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := wp.WatchRelations()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(rel.String())
+	wc.AssertNoChange()
+
+	// Suspend the relation; check change.
+	err = rel.SetSuspended(true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(rel.String())
+	wc.AssertNoChange()
+
+	// Resume the relation; check change.
+	err = rel.SetSuspended(false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(rel.String())
+	wc.AssertNoChange()
+}
+
 func removeAllUnits(c *gc.C, s *state.Service) {
 	us, err := s.AllUnits()
 	c.Assert(err, jc.ErrorIsNil)
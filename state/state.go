@@ -63,6 +63,10 @@ const (
 	// singularControllerNamespace is the name of the lease.Client namespace
 	// used by the singular manager
 	singularControllerNamespace = "singular-controller"
+
+	// charmLeasesNamespace is the name of the lease.Client namespace
+	// used by the charm lease manager.
+	charmLeasesNamespace = "charm-leases"
 )
 
 // State represents the state of an model
@@ -85,15 +89,29 @@ type State struct {
 	// singularManager keeps track of which controller machine is responsible
 	// for managing this state's environment.
 	singularManager *lease.Manager
-
-	// mu guards allManager, allModelManager & allModelWatcherBacking
+	// charmLeaseManager keeps track of the named leases that units
+	// claim on behalf of their charms.
+	charmLeaseManager *lease.Manager
+	// leaseClientId identifies this state's lease clients when they write
+	// to the leases collection.
+	leaseClientId string
+
+	// mu guards allManager, allModelManager, allModelWatcherBacking
+	// & offeringModels
 	mu                     sync.Mutex
 	allManager             *storeManager
 	allModelManager        *storeManager
 	allModelWatcherBacking Backing
 
+	// offeringModels holds the States of the models with which this
+	// model's cross-model relations are synced.
+	offeringModels *StatePool
+
 	// TODO(anastasiamac 2015-07-16) As state gets broken up, remove this.
 	CloudImageMetadataStorage cloudimagemetadata.Storage
+
+	// secretsKeyValue holds the key set by SetSecretsKey.
+	secretsKeyValue *[secretsKeyLength]byte
 }
 
 // StateServingInfo holds information needed by a controller.
@@ -179,6 +197,7 @@ func (st *State) ForModel(env names.ModelTag) (*State, error) {
 	if err := newState.start(st.controllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
+	newState.secretsKeyValue = st.secretsKeyValue
 	return newState, nil
 }
 
@@ -207,6 +226,7 @@ func (st *State) start(controllerTag names.ModelTag) error {
 	}
 
 	logger.Infof("creating lease clients as %s", clientId)
+	st.leaseClientId = clientId
 	clock := GetClock()
 	datastore := &environMongo{st}
 	leadershipClient, err := statelease.NewClient(statelease.ClientConfig{
@@ -221,10 +241,11 @@ func (st *State) start(controllerTag names.ModelTag) error {
 	}
 	logger.Infof("starting leadership lease manager")
 	leadershipManager, err := lease.NewManager(lease.ManagerConfig{
-		Secretary: leadershipSecretary{},
-		Client:    leadershipClient,
-		Clock:     clock,
-		MaxSleep:  time.Minute,
+		Secretary:  leadershipSecretary{},
+		Client:     leadershipClient,
+		Clock:      clock,
+		MaxSleep:   time.Minute,
+		Directives: leadershipDirectives{st},
 	})
 	if err != nil {
 		return errors.Annotatef(err, "cannot create leadership lease manager")
@@ -253,6 +274,28 @@ func (st *State) start(controllerTag names.ModelTag) error {
 	}
 	st.singularManager = singularManager
 
+	charmLeaseClient, err := statelease.NewClient(statelease.ClientConfig{
+		Id:         clientId,
+		Namespace:  charmLeasesNamespace,
+		Collection: leasesC,
+		Mongo:      datastore,
+		Clock:      clock,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot create charm lease client")
+	}
+	logger.Infof("starting charm lease manager")
+	charmLeaseManager, err := lease.NewManager(lease.ManagerConfig{
+		Secretary: charmLeaseSecretary{st.modelTag.Id()},
+		Client:    charmLeaseClient,
+		Clock:     clock,
+		MaxSleep:  time.Minute,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot create charm lease manager")
+	}
+	st.charmLeaseManager = charmLeaseManager
+
 	logger.Infof("creating cloud image metadata storage")
 	st.CloudImageMetadataStorage = cloudimagemetadata.NewStorage(st.ModelUUID(), cloudimagemetadataC, datastore)
 
@@ -1175,6 +1218,11 @@ func (st *State) AddService(args AddServiceArgs) (service *Service, err error) {
 	} else if exists {
 		return nil, errors.Errorf("service already exists")
 	}
+	if exists, err := isNotDead(st, remoteServicesC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("remote service already exists")
+	}
 	env, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1316,6 +1364,11 @@ func (st *State) AddService(args AddServiceArgs) (service *Service, err error) {
 		[]txn.Op{
 			env.assertAliveOp(),
 			endpointBindingsOp,
+			{
+				C:      remoteServicesC,
+				Id:     serviceID,
+				Assert: txn.DocMissing,
+			},
 		},
 		addServiceOps(st, addServiceOpsArgs{
 			serviceDoc:       svcDoc,
@@ -1958,7 +2011,7 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	} else {
 		return nil, errors.Errorf("invalid endpoint %q", name)
 	}
-	svc, err := st.Service(svcName)
+	svc, err := st.relatable(svcName)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1984,6 +2037,30 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	return final, nil
 }
 
+// relatable is implemented by the services and remote services that may
+// be named in a relation.
+type relatable interface {
+	Endpoint(relationName string) (Endpoint, error)
+	Endpoints() ([]Endpoint, error)
+}
+
+// relatable returns the service or remote service with the supplied name.
+func (st *State) relatable(name string) (relatable, error) {
+	svc, err := st.Service(name)
+	if err == nil {
+		return svc, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	rsvc, err := st.RemoteService(name)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("service %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rsvc, nil
+}
+
 // AddRelation creates a new relation with the given endpoints.
 func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 	key := relationKey(eps)
@@ -2023,9 +2100,22 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		}
 		// Collect per-service operations, checking sanity as we go.
 		var ops []txn.Op
-		var subordinateCount int
+		var subordinateCount, remoteCount int
 		series := map[string]bool{}
 		for _, ep := range eps {
+			if rsvc, err := st.RemoteService(ep.ServiceName); err == nil {
+				if remoteCount++; remoteCount > 1 {
+					return nil, errors.Errorf("cannot relate remote services to each other")
+				}
+				remoteOps, err := addRemoteRelationOps(rsvc, ep)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, remoteOps...)
+				continue
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
 			svc, err := st.Service(ep.ServiceName)
 			if errors.IsNotFound(err) {
 				return nil, errors.Errorf("service %q does not exist", ep.ServiceName)
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type LeadershipSuite struct {
@@ -131,6 +132,122 @@ func (s *LeadershipSuite) TestHackLeadershipUnblocksClaimer(c *gc.C) {
 	}
 }
 
+func (s *LeadershipSuite) TestTransferLeadership(c *gc.C) {
+	service := s.Factory.MakeService(c, &factory.ServiceParams{Name: "blah"})
+	for i := 0; i < 3; i++ {
+		s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+	}
+	err := s.claimer.ClaimLeadership("blah", "blah/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.TransferLeadership("blah", "blah/1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The current leader can no longer extend its lease...
+	err = s.claimer.ClaimLeadership("blah", "blah/0", time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+
+	// ...and once it expires, only the target can claim it.
+	s.expire(c, "blah")
+	err = s.claimer.ClaimLeadership("blah", "blah/2", time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+	err = s.claimer.ClaimLeadership("blah", "blah/1", time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+
+	// The transfer is complete, so normal extension resumes.
+	err = s.claimer.ClaimLeadership("blah", "blah/1", time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipTimesOut(c *gc.C) {
+	service := s.Factory.MakeService(c, &factory.ServiceParams{Name: "blah"})
+	for i := 0; i < 3; i++ {
+		s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+	}
+	err := s.State.TransferLeadership("blah", "blah/1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.claimer.ClaimLeadership("blah", "blah/2", time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+
+	s.clock.Advance(state.LeadershipTransferTimeout)
+	err = s.claimer.ClaimLeadership("blah", "blah/2", time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipUnitOfOtherService(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{Name: "blah"})
+	unit := s.Factory.MakeUnit(c, nil)
+
+	err := s.State.TransferLeadership("blah", unit.Name())
+	c.Check(err, gc.ErrorMatches, `cannot transfer leadership of service "blah" to ".*": unit of service ".*" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipPinned(c *gc.C) {
+	service := s.Factory.MakeService(c, &factory.ServiceParams{Name: "blah"})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+	err := s.State.PinLeadership("blah")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.TransferLeadership("blah", "blah/0")
+	c.Check(err, gc.ErrorMatches, `cannot transfer leadership of service "blah" to "blah/0": leadership is pinned`)
+}
+
+func (s *LeadershipSuite) TestPinLeadershipDuringTransfer(c *gc.C) {
+	service := s.Factory.MakeService(c, &factory.ServiceParams{Name: "blah"})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+	err := s.State.TransferLeadership("blah", "blah/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.PinLeadership("blah")
+	c.Check(err, gc.ErrorMatches, `cannot pin leadership of service "blah": leadership is being transferred to "blah/0"`)
+}
+
+func (s *LeadershipSuite) TestPinLeadership(c *gc.C) {
+	s.Factory.MakeService(c, &factory.ServiceParams{Name: "blah"})
+	err := s.claimer.ClaimLeadership("blah", "blah/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.claimer.ClaimLeadership("other", "other/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.PinLeadership("blah")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.PinLeadership("blah")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unpinned lease expires, but the pinned one does not.
+	s.expire(c, "other")
+	err = s.claimer.ClaimLeadership("blah", "blah/1", time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+
+	err = s.State.UnpinLeadership("blah")
+	c.Assert(err, jc.ErrorIsNil)
+	s.expire(c, "blah")
+	err = s.claimer.ClaimLeadership("blah", "blah/1", time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *LeadershipSuite) TestPinLeadershipServiceNotFound(c *gc.C) {
+	err := s.State.PinLeadership("blah")
+	c.Check(err, gc.ErrorMatches, `cannot pin leadership of service "blah": service "blah" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *LeadershipSuite) TestLeadershipLeases(c *gc.C) {
+	claimed := s.clock.Now()
+	err := s.claimer.ClaimLeadership("blah", "blah/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err := s.State.LeadershipLeases()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.Leases, gc.HasLen, 1)
+	detail := snapshot.Leases["blah"]
+	c.Check(detail.Holder, gc.Equals, "blah/0")
+	c.Check(detail.Claimed.Equal(claimed), jc.IsTrue)
+	c.Check(detail.Expiry.Equal(claimed.Add(time.Minute)), jc.IsTrue)
+	c.Check(snapshot.Skews, gc.Not(gc.HasLen), 0)
+}
+
 func (s *LeadershipSuite) expire(c *gc.C, serviceName string) {
 	s.clock.Advance(time.Hour)
 	select {
@@ -144,7 +261,7 @@ func (s *LeadershipSuite) expire(c *gc.C, serviceName string) {
 func (s *LeadershipSuite) expiryChan(serviceName string) <-chan error {
 	expired := make(chan error, 1)
 	go func() {
-		expired <- s.claimer.BlockUntilLeadershipReleased("blah")
+		expired <- s.claimer.BlockUntilLeadershipReleased(serviceName)
 	}()
 	return expired
 }
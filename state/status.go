@@ -4,9 +4,12 @@
 package state
 
 import (
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -242,6 +245,160 @@ func statusHistory(st *State, globalKey string, size int) ([]StatusInfo, error)
 	return results, nil
 }
 
+// StatusHistoryKind identifies which status of an entity a
+// status history entry records.
+type StatusHistoryKind string
+
+const (
+	// StatusHistoryMachine records the status of a machine.
+	StatusHistoryMachine StatusHistoryKind = "machine"
+
+	// StatusHistoryService records the status of a service.
+	StatusHistoryService StatusHistoryKind = "service"
+
+	// StatusHistoryWorkload records the workload status of a unit.
+	StatusHistoryWorkload StatusHistoryKind = "workload"
+
+	// StatusHistoryAgent records the agent status of a unit.
+	StatusHistoryAgent StatusHistoryKind = "agent"
+)
+
+// StatusHistoryEntry holds a status recorded for an entity in
+// the model.
+type StatusHistoryEntry struct {
+	StatusInfo
+	Entity names.Tag
+	Kind   StatusHistoryKind
+}
+
+// StatusHistoryFilter restricts the entries returned by
+// ModelStatusHistory.
+type StatusHistoryFilter struct {
+	// From and To bound the times of the entries returned.
+	// A zero time leaves that end of the range unbounded.
+	From time.Time
+	To   time.Time
+
+	// Entities holds the tags of the machines, services and
+	// units whose history is wanted. If empty, the history of
+	// every entity in the model is returned.
+	Entities []names.Tag
+
+	// Size, if positive, limits the result to the most
+	// recent Size entries. If Size is not positive and no
+	// time range is given, only the 100 most recent entries
+	// are returned.
+	Size int
+}
+
+// defaultStatusHistorySize is the number of most recent entries
+// returned by ModelStatusHistory when neither a size nor a time
+// range is given, so that a bare query never reads the whole
+// collection.
+var defaultStatusHistorySize = 100
+
+// statusHistoryGlobalKeys returns the global keys under which the
+// status history of the entity with the given tag is recorded.
+func statusHistoryGlobalKeys(tag names.Tag) ([]string, error) {
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		return []string{machineGlobalKey(tag.Id())}, nil
+	case names.ServiceTag:
+		return []string{serviceGlobalKey(tag.Id())}, nil
+	case names.UnitTag:
+		return []string{unitGlobalKey(tag.Id()), unitAgentGlobalKey(tag.Id())}, nil
+	}
+	return nil, errors.NotValidf("status history for %q", tag)
+}
+
+// statusHistoryEntity returns the tag and history kind of the entity
+// whose status is recorded under the given global key. The returned
+// bool is false if the key does not belong to a machine, service or
+// unit.
+func statusHistoryEntity(globalKey string) (names.Tag, StatusHistoryKind, bool) {
+	parts := strings.Split(globalKey, "#")
+	switch {
+	case len(parts) == 2 && parts[0] == "m" && names.IsValidMachine(parts[1]):
+		return names.NewMachineTag(parts[1]), StatusHistoryMachine, true
+	case len(parts) == 2 && parts[0] == "s" && names.IsValidService(parts[1]):
+		return names.NewServiceTag(parts[1]), StatusHistoryService, true
+	case len(parts) == 2 && parts[0] == "u" && names.IsValidUnit(parts[1]):
+		return names.NewUnitTag(parts[1]), StatusHistoryAgent, true
+	case len(parts) == 3 && parts[0] == "u" && parts[2] == "charm" && names.IsValidUnit(parts[1]):
+		return names.NewUnitTag(parts[1]), StatusHistoryWorkload, true
+	}
+	return nil, "", false
+}
+
+// ModelStatusHistory returns the status history of the entities in
+// the model which match the filter, ordered from oldest to newest.
+func (st *State) ModelStatusHistory(filter StatusHistoryFilter) ([]StatusHistoryEntry, error) {
+	query := bson.D{}
+	if len(filter.Entities) > 0 {
+		var globalKeys []string
+		for _, tag := range filter.Entities {
+			keys, err := statusHistoryGlobalKeys(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			globalKeys = append(globalKeys, keys...)
+		}
+		query = append(query, bson.DocElem{"globalkey", bson.D{{"$in", globalKeys}}})
+	}
+	updated := bson.D{}
+	if !filter.From.IsZero() {
+		updated = append(updated, bson.DocElem{"$gte", filter.From.UnixNano()})
+	}
+	if !filter.To.IsZero() {
+		updated = append(updated, bson.DocElem{"$lte", filter.To.UnixNano()})
+	}
+	if len(updated) > 0 {
+		query = append(query, bson.DocElem{"updated", updated})
+	}
+
+	history, closer := st.getCollection(statusesHistoryC)
+	defer closer()
+
+	size := filter.Size
+	if size <= 0 && filter.From.IsZero() && filter.To.IsZero() {
+		size = defaultStatusHistorySize
+	}
+	var docs []historicalStatusDoc
+	q := history.Find(query)
+	if size > 0 {
+		q = q.Sort("-updated").Limit(size)
+	} else {
+		q = q.Sort("updated")
+	}
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get status history")
+	}
+	if size > 0 {
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
+
+	results := make([]StatusHistoryEntry, 0, len(docs))
+	for _, doc := range docs {
+		tag, kind, ok := statusHistoryEntity(doc.GlobalKey)
+		if !ok {
+			continue
+		}
+		results = append(results, StatusHistoryEntry{
+			StatusInfo: StatusInfo{
+				Status:  doc.Status,
+				Message: doc.StatusInfo,
+				Data:    unescapeKeys(doc.StatusData),
+				Since:   unixNanoToTime(doc.Updated),
+			},
+			Entity: tag,
+			Kind:   kind,
+		})
+	}
+	return results, nil
+}
+
 // PruneStatusHistory removes status history entries until
 // only the maxLogsPerEntity newest records per unit remain.
 func PruneStatusHistory(st *State, maxLogsPerEntity int) error {
@@ -283,6 +440,97 @@ func PruneStatusHistory(st *State, maxLogsPerEntity int) error {
 	return nil
 }
 
+// PruneStatusHistoryByAge removes the model's status history entries
+// recorded before minHistoryTime.
+func PruneStatusHistoryByAge(st *State, minHistoryTime time.Time) error {
+	if minHistoryTime.IsZero() {
+		return errors.NotValidf("zero status history time limit")
+	}
+	history, closer := st.getCollection(statusesHistoryC)
+	defer closer()
+
+	info, err := history.Writeable().RemoveAll(bson.D{
+		{"updated", bson.M{"$lt": minHistoryTime.UnixNano()}},
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot prune status history by age")
+	}
+	if info.Removed > 0 {
+		logger.Debugf("pruned %d status history entries older than %v", info.Removed, minHistoryTime)
+	}
+	return nil
+}
+
+// PruneStatusHistoryBySize removes the model's oldest status history
+// entries until its history is estimated to occupy no more than
+// maxHistoryMB. The estimate is based on the average size of the
+// documents in the status history collection, which is shared by
+// all models.
+func PruneStatusHistoryBySize(st *State, maxHistoryMB int) error {
+	if maxHistoryMB <= 0 {
+		return errors.NotValidf("status history size limit %d", maxHistoryMB)
+	}
+	history, closer := st.getCollection(statusesHistoryC)
+	defer closer()
+	historyW := history.Writeable()
+
+	avgObjSize, err := getAverageObjectSize(historyW.Underlying())
+	if err != nil {
+		return errors.Annotate(err, "cannot get status history size")
+	}
+	if avgObjSize <= 0 {
+		return nil
+	}
+	count, err := history.Count()
+	if err != nil {
+		return errors.Annotate(err, "cannot count status history")
+	}
+	keep := int(int64(maxHistoryMB) * humanize.MiByte / int64(avgObjSize))
+	if count <= keep {
+		return nil
+	}
+	if keep == 0 {
+		// Always keep the newest entry, rather than wiping the
+		// history of a model whose limit is below one document.
+		keep = 1
+	}
+
+	// Find the time of the oldest entry to keep, and remove
+	// everything older.
+	var doc historicalStatusDoc
+	err = history.Find(nil).Sort("-updated").Skip(keep - 1).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot find oldest status history entry to keep")
+	}
+	info, err := historyW.RemoveAll(bson.D{
+		{"updated", bson.M{"$lt": doc.Updated}},
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot prune status history by size")
+	}
+	if info.Removed > 0 {
+		logger.Debugf("pruned %d status history entries to fit in %dMB", info.Removed, maxHistoryMB)
+	}
+	return nil
+}
+
+// getAverageObjectSize returns the average size, in bytes, of the
+// documents in a MongoDB collection.
+func getAverageObjectSize(coll *mgo.Collection) (int, error) {
+	var result struct {
+		AvgObjSize float64 `bson:"avgObjSize"`
+	}
+	err := coll.Database.Run(bson.D{
+		{"collStats", coll.Name},
+	}, &result)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return int(result.AvgObjSize), nil
+}
+
 // getOldestTimeToKeep returns the create time for the oldest
 // status log to be kept.
 func getOldestTimeToKeep(coll mongo.Collection, globalKey string, size int) (int64, bool, error) {
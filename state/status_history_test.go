@@ -4,6 +4,9 @@
 package state_test
 
 import (
+	"time"
+
+	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -92,3 +95,139 @@ func (s *StatusHistorySuite) TestPruneStatusHistory(c *gc.C) {
 		checkPrimedUnitAgentStatus(c, statusInfo, 9-i)
 	}
 }
+
+func (s *StatusHistorySuite) TestModelStatusHistory(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Service: service})
+	start := time.Now()
+	primeUnitStatusHistory(c, unit, 3)
+	primeUnitAgentStatusHistory(c, unit.Agent(), 2)
+
+	history, err := s.State.ModelStatusHistory(state.StatusHistoryFilter{
+		From:     start,
+		Entities: []names.Tag{unit.UnitTag()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 5)
+	for i, entry := range history[:3] {
+		c.Check(entry.Entity, gc.Equals, unit.Tag())
+		c.Check(entry.Kind, gc.Equals, state.StatusHistoryWorkload)
+		checkPrimedUnitStatus(c, entry.StatusInfo, i)
+	}
+	for i, entry := range history[3:] {
+		c.Check(entry.Kind, gc.Equals, state.StatusHistoryAgent)
+		checkPrimedUnitAgentStatus(c, entry.StatusInfo, i)
+	}
+	for i := 1; i < len(history); i++ {
+		c.Check(history[i].Since.Before(*history[i-1].Since), jc.IsFalse)
+	}
+}
+
+func (s *StatusHistorySuite) TestModelStatusHistorySize(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	primeUnitStatusHistory(c, unit, 10)
+
+	history, err := s.State.ModelStatusHistory(state.StatusHistoryFilter{
+		Entities: []names.Tag{unit.UnitTag()},
+		Size:     2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	checkPrimedUnitStatus(c, history[0].StatusInfo, 8)
+	checkPrimedUnitStatus(c, history[1].StatusInfo, 9)
+}
+
+func (s *StatusHistorySuite) TestModelStatusHistoryDefaultSize(c *gc.C) {
+	s.PatchValue(state.DefaultStatusHistorySize, 3)
+	unit := s.Factory.MakeUnit(c, nil)
+	primeUnitStatusHistory(c, unit, 10)
+
+	history, err := s.State.ModelStatusHistory(state.StatusHistoryFilter{
+		Entities: []names.Tag{unit.UnitTag()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	checkPrimedUnitStatus(c, history[0].StatusInfo, 7)
+	checkPrimedUnitStatus(c, history[2].StatusInfo, 9)
+}
+
+func (s *StatusHistorySuite) TestModelStatusHistoryTimeRange(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.SetStatus(state.StatusStarted, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	end := time.Now()
+	unit := s.Factory.MakeUnit(c, nil)
+	primeUnitStatusHistory(c, unit, 1)
+
+	history, err := s.State.ModelStatusHistory(state.StatusHistoryFilter{To: end})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.Not(gc.HasLen), 0)
+	for _, entry := range history {
+		c.Check(entry.Since.After(end), jc.IsFalse)
+		c.Check(entry.Entity, gc.Not(gc.Equals), unit.Tag())
+	}
+	last := history[len(history)-1]
+	c.Check(last.Entity, gc.Equals, machine.Tag())
+	c.Check(last.Kind, gc.Equals, state.StatusHistoryMachine)
+	c.Check(last.Status, gc.Equals, state.StatusStarted)
+}
+
+func (s *StatusHistorySuite) TestModelStatusHistoryInvalidEntity(c *gc.C) {
+	_, err := s.State.ModelStatusHistory(state.StatusHistoryFilter{
+		Entities: []names.Tag{names.NewUserTag("bob")},
+	})
+	c.Assert(err, gc.ErrorMatches, `status history for "user-bob" not valid`)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryByAge(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	primeUnitStatusHistory(c, unit, 5)
+	threshold := time.Now()
+	primeUnitStatusHistory(c, unit, 3)
+
+	err := state.PruneStatusHistoryByAge(s.State, threshold)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(50)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	for i, statusInfo := range history {
+		checkPrimedUnitStatus(c, statusInfo, 2-i)
+	}
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryBySizeUnderLimit(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	primeUnitStatusHistory(c, unit, 5)
+
+	err := state.PruneStatusHistoryBySize(s.State, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(50)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 6)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryBySizeZero(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	primeUnitStatusHistory(c, unit, 5)
+
+	err := state.PruneStatusHistoryBySize(s.State, 0)
+	c.Assert(err, gc.ErrorMatches, "status history size limit 0 not valid")
+
+	history, err := unit.StatusHistory(50)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 6)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryByAgeZero(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	primeUnitStatusHistory(c, unit, 5)
+
+	err := state.PruneStatusHistoryByAge(s.State, time.Time{})
+	c.Assert(err, gc.ErrorMatches, "zero status history time limit not valid")
+
+	history, err := unit.StatusHistory(50)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 6)
+}
@@ -701,6 +701,16 @@ func removeStorageAttachmentOps(
 			{"life", Alive},
 			{"attachmentcount", bson.D{{"$gt", 0}}},
 		}
+		if si.doc.AttachmentCount == 1 {
+			// The storage instance will outlive the attachment,
+			// so detach its volume or filesystem from the unit's
+			// machine, so that it may be attached to another.
+			detachOps, err := detachStorageMachineOps(st, si, s.Unit())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, detachOps...)
+		}
 	} else {
 		// If it's not the last reference when we checked, we want to
 		// allow for concurrent attachment removals but want to ensure
@@ -0,0 +1,407 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// DetachStorage detaches the specified storage instance from the unit
+// that owns it, without destroying the storage. Ownership of the storage
+// instance is transferred to the unit's service, and the storage
+// attachment is marked Dying; when the attachment is removed, the volume
+// or filesystem assigned to the storage instance will be detached from
+// the unit's machine. The storage instance may then be attached to
+// another unit of the same service with AttachStorage.
+//
+// Storage whose volume or filesystem is scoped to a machine cannot be
+// detached, as it cannot be attached to any other machine.
+func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %s from unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if si.doc.Owner != unit.String() {
+			return nil, errors.New("storage is not owned by the unit")
+		}
+		sa, err := st.storageAttachment(storage, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if sa.Life() != Alive {
+			return nil, errors.New("storage attachment is not alive")
+		}
+		if err := st.validateStorageDetachable(si); err != nil {
+			return nil, errors.Trace(err)
+		}
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		serviceTag := names.NewServiceTag(u.ServiceName())
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: append(bson.D{{"owner", unit.String()}}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"owner", serviceTag.String()}}}},
+		}}
+		ops = append(ops, destroyStorageAttachmentOps(storage, unit)...)
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// ForceDetachStorage detaches the specified storage instance from the
+// unit as DetachStorage does, but removes the storage attachment
+// immediately rather than waiting for the unit agent to run the
+// storage-detaching hook. It is intended for use when the unit agent is
+// down, and may also complete an earlier DetachStorage that is still
+// waiting for the unit agent.
+func (st *State) ForceDetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot force detach storage %s from unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		serviceName, err := names.UnitService(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		serviceTag := names.NewServiceTag(serviceName)
+		switch si.doc.Owner {
+		case unit.String():
+			if err := st.validateStorageDetachable(si); err != nil {
+				return nil, errors.Trace(err)
+			}
+		case serviceTag.String():
+			// The storage has been detached already, but the
+			// unit agent has not removed the storage attachment.
+		default:
+			return nil, errors.New("storage is not owned by the unit")
+		}
+		if _, err := st.storageAttachment(storage, unit); errors.IsNotFound(err) && si.doc.Owner == serviceTag.String() {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      storageAttachmentsC,
+			Id:     storageAttachmentId(unit.Id(), storage.Id()),
+			Assert: txn.DocExists,
+			Remove: true,
+		}, {
+			C:      unitsC,
+			Id:     unit.Id(),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", -1}}}},
+		}, {
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: append(bson.D{
+				{"owner", si.doc.Owner},
+				{"attachmentcount", si.doc.AttachmentCount},
+			}, isAliveDoc...),
+			Update: bson.D{
+				{"$set", bson.D{{"owner", serviceTag.String()}}},
+				{"$inc", bson.D{{"attachmentcount", -1}}},
+			},
+		}}
+		if si.doc.AttachmentCount == 1 {
+			// Detach the volume or filesystem from the unit's
+			// machine, so that it may be attached to another.
+			detachOps, err := detachStorageMachineOps(st, si, unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, detachOps...)
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// AttachStorage attaches the specified storage instance, previously
+// detached with DetachStorage, to the specified unit. The unit must
+// belong to the service that owns the detached storage instance, and
+// its charm must declare storage of the same name and kind. If the unit
+// is assigned to a machine, the storage instance's volume or filesystem
+// will be attached to the machine.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach storage %s to unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, unitNotAliveErr
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		serviceTag := names.NewServiceTag(u.ServiceName())
+		if si.doc.Owner != serviceTag.String() || si.doc.AttachmentCount != 0 {
+			return nil, errors.Errorf("storage is not detached from service %q", serviceTag.Id())
+		}
+
+		s, err := u.Service()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := s.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		charmStorage, ok := ch.Meta().Storage[si.StorageName()]
+		if !ok {
+			return nil, errors.NotFoundf("charm storage %q", si.StorageName())
+		}
+		var kind StorageKind
+		switch charmStorage.Type {
+		case charm.StorageBlock:
+			kind = StorageKindBlock
+		case charm.StorageFilesystem:
+			kind = StorageKindFilesystem
+		}
+		if kind != si.Kind() {
+			return nil, errors.Errorf(
+				"charm storage %q type %q does not match storage kind",
+				si.StorageName(), charmStorage.Type,
+			)
+		}
+		if charmStorage.CountMax >= 0 {
+			count, err := st.countEntityStorageInstancesForName(unit, si.StorageName())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if count+1 > uint64(charmStorage.CountMax) {
+				return nil, errors.Errorf(
+					"charm %q store %q: at most %d instances supported, %d specified",
+					ch.Meta().Name, si.StorageName(), charmStorage.CountMax, count+1,
+				)
+			}
+		}
+
+		ops, err := st.detachedStorageMachineAsserts(si)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: append(bson.D{
+				{"owner", serviceTag.String()},
+				{"attachmentcount", 0},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{
+				{"owner", unit.String()},
+				{"attachmentcount", 1},
+			}}},
+		}, createStorageAttachmentOp(storage, unit), txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+		})
+
+		// If the unit is assigned to a machine, attach the storage
+		// instance's volume or filesystem to the machine. Otherwise
+		// they will be attached when the unit is assigned.
+		cons, err := u.StorageConstraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attached := *si
+		attached.doc.Owner = unit.String()
+		machineOps, err := unitAssignedMachineStorageOps(
+			st, unit, ch.Meta(), cons, u.Series(), &attached,
+		)
+		if err == nil {
+			ops = append(ops, machineOps...)
+		} else if !errors.IsNotAssigned(err) {
+			return nil, errors.Annotate(err, "attaching machine storage")
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateStorageDetachable returns an error if the volume or filesystem
+// assigned to the specified storage instance is scoped to a machine, and
+// so could not be attached to another unit's machine.
+func (st *State) validateStorageDetachable(si *storageInstance) error {
+	switch si.Kind() {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := names.VolumeMachine(v.VolumeTag()); ok {
+			return errors.NotSupportedf("detaching machine-scoped volume %q", v.VolumeTag().Id())
+		}
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := names.FilesystemMachine(f.FilesystemTag()); ok {
+			return errors.NotSupportedf("detaching machine-scoped filesystem %q", f.FilesystemTag().Id())
+		}
+	}
+	return nil
+}
+
+// detachedStorageMachineAsserts returns txn.Ops asserting that the
+// volume or filesystem assigned to the specified detached storage
+// instance, if any, is alive and no longer attached to any machine.
+func (st *State) detachedStorageMachineAsserts(si *storageInstance) ([]txn.Op, error) {
+	notAttached := append(bson.D{{"attachmentcount", 0}}, isAliveDoc...)
+	switch si.Kind() {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.Errorf("volume %q is not alive", v.VolumeTag().Id())
+		}
+		if v.doc.AttachmentCount != 0 {
+			return nil, errors.Errorf("volume %q is still attached to a machine", v.VolumeTag().Id())
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: notAttached,
+		}}, nil
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if f.Life() != Alive {
+			return nil, errors.Errorf("filesystem %q is not alive", f.FilesystemTag().Id())
+		}
+		if f.doc.AttachmentCount != 0 {
+			return nil, errors.Errorf("filesystem %q is still attached to a machine", f.FilesystemTag().Id())
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     f.doc.FilesystemId,
+			Assert: notAttached,
+		}}, nil
+	}
+	return nil, nil
+}
+
+// detachStorageMachineOps returns txn.Ops to detach the volume or
+// filesystem assigned to the specified storage instance from the
+// machine that the specified unit is assigned to.
+func detachStorageMachineOps(st *State, si *storageInstance, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineTag := names.NewMachineTag(machineId)
+	switch si.Kind() {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		va, err := st.VolumeAttachment(machineTag, v.VolumeTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if va.Life() != Alive {
+			return nil, nil
+		}
+		return detachVolumeOps(machineTag, v.VolumeTag()), nil
+	case StorageKindFilesystem:
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fa, err := st.FilesystemAttachment(machineTag, f.FilesystemTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fa.Life() != Alive {
+			return nil, nil
+		}
+		return detachFilesystemOps(machineTag, f.FilesystemTag()), nil
+	}
+	return nil, nil
+}
+
+// ownsStorage returns whether the entity with the specified tag owns any
+// storage instances. If this cannot be determined, it returns true, so
+// that the storage is cleaned up regardless.
+func (st *State) ownsStorage(owner names.Tag) bool {
+	coll, closer := st.getCollection(storageInstancesC)
+	defer closer()
+	n, err := coll.Find(bson.D{{"owner", owner.String()}}).Count()
+	return err != nil || n > 0
+}
+
+// cleanupStorageForRemovedService destroys the storage instances owned by
+// the named service, which were detached from its units and not attached
+// to another, so that they are not leaked. Any volumes and filesystems
+// bound to the storage are destroyed along with it.
+func (st *State) cleanupStorageForRemovedService(service string) error {
+	coll, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	owner := names.NewServiceTag(service).String()
+	err := coll.Find(bson.D{{"owner", owner}}).Select(bson.D{{"id", true}}).All(&docs)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get storage instances for service %q", service)
+	}
+	for _, doc := range docs {
+		if err := st.DestroyStorageInstance(names.NewStorageTag(doc.Id)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type StorageAttachSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageAttachSuite{})
+
+// assignUnit assigns the unit to a new, clean machine and returns the
+// machine's tag.
+func (s *StorageAttachSuite) assignUnit(c *gc.C, u *state.Unit) names.MachineTag {
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	return names.NewMachineTag(machineId)
+}
+
+// detachStorage detaches the storage from the unit, and removes the
+// storage attachment as the unit agent would.
+func (s *StorageAttachSuite) detachStorage(c *gc.C, storageTag names.StorageTag, u *state.Unit) {
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageAttachSuite) TestDetachStorage(c *gc.C) {
+	service, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Owner(), gc.Equals, service.Tag())
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	attachment, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+
+	// Removing the storage attachment does not remove
+	// the storage instance, since it is owned by the service.
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageAttachSuite) TestDetachStorageDetachesFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	machineTag := s.assignUnit(c, u)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	s.detachStorage(c, storageTag, u)
+	attachment := s.filesystemAttachment(c, machineTag, filesystemTag)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+
+	// Removing the filesystem attachment leaves the filesystem alive.
+	err := s.State.RemoveFilesystemAttachment(machineTag, filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.filesystem(c, filesystemTag)
+	c.Assert(filesystem.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageAttachSuite) TestDetachStorageMachineScoped(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	s.assignUnit(c, u)
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/0 from unit storage-block/0: detaching machine-scoped volume "0/0" not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageAttachSuite) TestDetachStorageNotOwned(c *gc.C) {
+	service, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	s.detachStorage(c, storageTag, u)
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DetachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/0 from unit storage-filesystem/1: storage is not owned by the unit`)
+}
+
+func (s *StorageAttachSuite) TestForceDetachStorage(c *gc.C) {
+	service, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	machineTag := s.assignUnit(c, u)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	// The storage attachment is removed without waiting
+	// for the unit agent.
+	err := s.State.ForceDetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Owner(), gc.Equals, service.Tag())
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	attachment := s.filesystemAttachment(c, machineTag, filesystemTag)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+}
+
+func (s *StorageAttachSuite) TestForceDetachStorageAfterDetach(c *gc.C) {
+	service, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	s.assignUnit(c, u)
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit agent is down, so the storage attachment
+	// is never removed; forcing the detachment removes it.
+	for i := 0; i < 2; i++ {
+		err = s.State.ForceDetachStorage(storageTag, u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err = s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Owner(), gc.Equals, service.Tag())
+}
+
+func (s *StorageAttachSuite) TestForceDetachStorageNotOwned(c *gc.C) {
+	service, _, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ForceDetachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot force detach storage data/0 from unit storage-filesystem/1: storage is not owned by the unit`)
+}
+
+func (s *StorageAttachSuite) TestRemoveServiceDestroysDetachedStorage(c *gc.C) {
+	service, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	s.detachStorage(c, storageTag, u)
+	s.obliterateUnit(c, u.UnitTag())
+
+	err := service.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageAttachSuite) TestAttachStorage(c *gc.C) {
+	service, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	machineTag := s.assignUnit(c, u)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+	s.detachStorage(c, storageTag, u)
+	err := s.State.RemoveFilesystemAttachment(machineTag, filesystemTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag2 := s.assignUnit(c, u2)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Owner(), gc.Equals, u2.Tag())
+	attachment, err := s.State.StorageAttachment(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	// The existing filesystem is attached to the new unit's machine.
+	filesystemAttachment := s.filesystemAttachment(c, machineTag2, filesystemTag)
+	c.Assert(filesystemAttachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageAttachSuite) TestAttachStorageUnassignedUnit(c *gc.C) {
+	ch := s.createStorageCharm(c, "storage-block-multi", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageBlock,
+		CountMin: 1,
+		CountMax: -1,
+	})
+	service := s.AddTestingServiceWithStorage(c, "storage-block-multi", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("environscoped", 1024, 1),
+	})
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	storageTag := names.NewStorageTag("data/0")
+	machineTag := s.assignUnit(c, u)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	s.detachStorage(c, storageTag, u)
+	err = s.State.RemoveVolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The existing volume is attached to the unit's machine
+	// when the unit is assigned, rather than a new one created.
+	machineTag2 := s.assignUnit(c, u2)
+	c.Assert(s.storageInstanceVolume(c, storageTag).VolumeTag(), gc.Equals, volumeTag)
+	volumeAttachment := s.volumeAttachment(c, machineTag2, volumeTag)
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageAttachSuite) TestAttachStorageStillAttached(c *gc.C) {
+	service, u, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	s.assignUnit(c, u)
+	s.detachStorage(c, storageTag, u)
+
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage data/0 to unit storage-filesystem/1: filesystem "0" is still attached to a machine`)
+}
+
+func (s *StorageAttachSuite) TestAttachStorageNotDetached(c *gc.C) {
+	service, _, storageTag := s.setupSingleStorage(c, "filesystem", "environscoped")
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage data/0 to unit storage-filesystem/1: storage is not detached from service "storage-filesystem"`)
+}
+
+func (s *StorageAttachSuite) TestAttachStorageExceedsCount(c *gc.C) {
+	service, u, storageTag := s.setupSingleStorage(c, "block", "environscoped")
+	s.detachStorage(c, storageTag, u)
+
+	// The new unit has its own storage instance, and the charm
+	// permits only one.
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage data/0 to unit storage-block/1: charm "storage-block" store "data": at most 1 instances supported, 2 specified`)
+}
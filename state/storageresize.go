@@ -0,0 +1,236 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ResizeStorageInstance requests that the volume or filesystem assigned
+// to the specified storage instance be grown to the given size, in MiB.
+// See ResizeVolume and ResizeFilesystem for details.
+func (st *State) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     s.doc.Id,
+			Assert: isAliveDoc,
+		}}
+		var resizeOps []txn.Op
+		switch s.Kind() {
+		case StorageKindBlock:
+			v, err := st.storageInstanceVolume(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			resizeOps, err = resizeVolumeOps(v, size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		case StorageKindFilesystem:
+			f, err := st.storageInstanceFilesystem(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			resizeOps, err = st.resizeFilesystemOps(f, size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		default:
+			return nil, errors.Errorf("cannot resize storage of kind %d", s.Kind())
+		}
+		return append(ops, resizeOps...), nil
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeVolume requests that the specified volume be grown to the
+// given size, in MiB. The volume must be alive and provisioned, and
+// the new size must be larger than the current size; volumes cannot
+// be shrunk.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return resizeVolumeOps(v, size)
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeFilesystem requests that the specified filesystem be grown to
+// the given size, in MiB. If the filesystem is volume-backed, then the
+// backing volume will be grown too if it is not already large enough.
+// The filesystem must be alive and provisioned, and the new size must
+// be larger than the current size; filesystems cannot be shrunk.
+func (st *State) ResizeFilesystem(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize filesystem %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return st.resizeFilesystemOps(f, size)
+	}
+	return st.run(buildTxn)
+}
+
+// resizeVolumeOps returns txn.Ops to record a pending resize of the
+// given volume to the specified size.
+func resizeVolumeOps(v *volume, size uint64) ([]txn.Op, error) {
+	if v.Life() != Alive {
+		return nil, errors.Errorf("volume %q is not alive", v.doc.Name)
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size <= info.Size {
+		return nil, errors.Errorf(
+			"new size %dMiB is not larger than volume %q size %dMiB",
+			size, v.doc.Name, info.Size,
+		)
+	}
+	return []txn.Op{{
+		C:      volumesC,
+		Id:     v.doc.Name,
+		Assert: append(bson.D{{"info.size", info.Size}}, isAliveDoc...),
+		Update: bson.D{{"$set", bson.D{{"resizesize", size}}}},
+	}}, nil
+}
+
+// resizeFilesystemOps returns txn.Ops to record a pending resize of the
+// given filesystem, and if necessary its backing volume, to the specified
+// size.
+func (st *State) resizeFilesystemOps(f *filesystem, size uint64) ([]txn.Op, error) {
+	if f.Life() != Alive {
+		return nil, errors.Errorf("filesystem %q is not alive", f.doc.FilesystemId)
+	}
+	info, err := f.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size <= info.Size {
+		return nil, errors.Errorf(
+			"new size %dMiB is not larger than filesystem %q size %dMiB",
+			size, f.doc.FilesystemId, info.Size,
+		)
+	}
+	ops := []txn.Op{{
+		C:      filesystemsC,
+		Id:     f.doc.FilesystemId,
+		Assert: append(bson.D{{"info.size", info.Size}}, isAliveDoc...),
+		Update: bson.D{{"$set", bson.D{{"resizesize", size}}}},
+	}}
+	volumeTag, err := f.Volume()
+	if err == ErrNoBackingVolume {
+		return ops, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	v, err := st.volumeByTag(volumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The backing volume may already be large enough
+	// to accommodate the grown filesystem.
+	if volumeInfo, err := v.Info(); err == nil && volumeInfo.Size >= size {
+		return ops, nil
+	}
+	volumeOps, err := resizeVolumeOps(v, size)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, volumeOps...), nil
+}
+
+// SetVolumeSize records that the specified volume has been grown to
+// the given size, in MiB. If the volume has a pending resize that is
+// satisfied by the new size, the pending resize is cleared.
+func (st *State) SetVolumeSize(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set size of volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size < info.Size {
+			return nil, errors.Errorf("cannot shrink volume from %dMiB to %dMiB", info.Size, size)
+		}
+		resizeSize, pending := v.PendingResize()
+		if size == info.Size && !(pending && size >= resizeSize) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return setStorageSizeOps(volumesC, tag.Id(), info.Size, size, resizeSize), nil
+	}
+	return st.run(buildTxn)
+}
+
+// SetFilesystemSize records that the specified filesystem has been
+// grown to the given size, in MiB. If the filesystem has a pending
+// resize that is satisfied by the new size, the pending resize is
+// cleared.
+func (st *State) SetFilesystemSize(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set size of filesystem %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size < info.Size {
+			return nil, errors.Errorf("cannot shrink filesystem from %dMiB to %dMiB", info.Size, size)
+		}
+		resizeSize, pending := f.PendingResize()
+		if size == info.Size && !(pending && size >= resizeSize) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return setStorageSizeOps(filesystemsC, tag.Id(), info.Size, size, resizeSize), nil
+	}
+	return st.run(buildTxn)
+}
+
+// setStorageSizeOps returns txn.Ops to update the size recorded in the
+// info of the volume or filesystem with the given ID, clearing the
+// pending resize if the new size satisfies it.
+func setStorageSizeOps(collection, id string, oldSize, newSize, resizeSize uint64) []txn.Op {
+	asserts := append(bson.D{
+		{"info.size", oldSize},
+		{"resizesize", resizeSize},
+	}, isAliveDoc...)
+	if resizeSize == 0 {
+		asserts[1] = bson.DocElem{"resizesize", bson.D{{"$exists", false}}}
+	}
+	update := bson.D{{"$set", bson.D{{"info.size", newSize}}}}
+	if resizeSize != 0 && newSize >= resizeSize {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"resizesize", nil}}})
+	}
+	return []txn.Op{{
+		C:      collection,
+		Id:     id,
+		Assert: asserts,
+		Update: update,
+	}}
+}
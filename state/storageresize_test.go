@@ -0,0 +1,200 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type StorageResizeSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageResizeSuite{})
+
+// setupProvisionedStorage adds a unit with a single storage instance of
+// the given kind in the specified pool, assigns it to a provisioned
+// machine, and sets the info for its volume and/or filesystem.
+func (s *StorageResizeSuite) setupProvisionedStorage(c *gc.C, kind, pool string) names.StorageTag {
+	_, u, storageTag := s.setupSingleStorage(c, kind, pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.machine(c, machineId)
+	err = machine.SetProvisioned("inst-id", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var volumeTag names.VolumeTag
+	var filesystemTag names.FilesystemTag
+	if kind == "block" {
+		volumeTag = s.storageInstanceVolume(c, storageTag).VolumeTag()
+	} else {
+		filesystem := s.storageInstanceFilesystem(c, storageTag)
+		filesystemTag = filesystem.FilesystemTag()
+		volumeTag, err = filesystem.Volume()
+		if errors.Cause(err) != state.ErrNoBackingVolume {
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+	if volumeTag != (names.VolumeTag{}) {
+		err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-0"})
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.SetVolumeAttachmentInfo(
+			machine.MachineTag(), volumeTag, state.VolumeAttachmentInfo{DeviceName: "sdb"},
+		)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	if filesystemTag != (names.FilesystemTag{}) {
+		err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{Size: 1024, FilesystemId: "fs-0"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return storageTag
+}
+
+func (s *StorageResizeSuite) TestResizeBlockStorage(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "block", "loop-pool")
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volume := s.storageInstanceVolume(c, storageTag)
+	size, ok := volume.PendingResize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+}
+
+func (s *StorageResizeSuite) TestResizeFilesystemStorage(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "filesystem", "rootfs")
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	size, ok := filesystem.PendingResize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+}
+
+func (s *StorageResizeSuite) TestResizeVolumeBackedFilesystemStorage(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "filesystem", "loop-pool")
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	size, ok := filesystem.PendingResize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	volume := s.filesystemVolume(c, filesystem.FilesystemTag())
+	size, ok = volume.PendingResize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+}
+
+func (s *StorageResizeSuite) TestResizeStorageNotLarger(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "block", "loop-pool")
+	err := s.State.ResizeStorageInstance(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": new size 1024MiB is not larger than volume "0/0" size 1024MiB`)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": volume "0/0" not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageNotFound(c *gc.C) {
+	err := s.State.ResizeStorageInstance(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": storage instance "data/0" not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageResizeSuite) TestSetVolumeSize(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "block", "loop-pool")
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	// Growing the volume part of the way does not clear the
+	// pending resize.
+	err = s.State.SetVolumeSize(volumeTag, 1536)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.volume(c, volumeTag)
+	_, ok := volume.PendingResize()
+	c.Assert(ok, jc.IsTrue)
+
+	err = s.State.SetVolumeSize(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	_, ok = volume.PendingResize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertVolumeInfo(c, volumeTag, state.VolumeInfo{
+		Size: 2048, VolumeId: "vol-0", Pool: "loop-pool",
+	})
+}
+
+func (s *StorageResizeSuite) TestSetVolumeSizeShrink(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "block", "loop-pool")
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err := s.State.SetVolumeSize(volumeTag, 512)
+	c.Assert(err, gc.ErrorMatches, `cannot set size of volume "0/0": cannot shrink volume from 1024MiB to 512MiB`)
+}
+
+func (s *StorageResizeSuite) TestSetFilesystemSize(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "filesystem", "rootfs")
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	err = s.State.SetFilesystemSize(filesystemTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.filesystem(c, filesystemTag)
+	_, ok := filesystem.PendingResize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertFilesystemInfo(c, filesystemTag, state.FilesystemInfo{
+		Size: 4096, FilesystemId: "fs-0", Pool: "rootfs",
+	})
+}
+
+func (s *StorageResizeSuite) TestWatchModelVolumesResize(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "block", "environscoped-block")
+	w := s.State.WatchModelVolumes()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+
+	err := s.State.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+
+	err = s.State.SetVolumeSize(names.NewVolumeTag("0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
+func (s *StorageResizeSuite) TestResizeVolume(c *gc.C) {
+	storageTag := s.setupProvisionedStorage(c, "block", "loop-pool")
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err := s.State.ResizeVolume(volumeTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).PendingResize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(4096))
+}
+
+func (s *StorageResizeSuite) TestResizeVolumeNotFound(c *gc.C) {
+	err := s.State.ResizeVolume(names.NewVolumeTag("42"), 4096)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "42": volume "42" not found`)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type SubnetSuite struct {
@@ -458,3 +459,19 @@ func (s *SubnetSuite) TestPickNewAddressRace(c *gc.C) {
 	expected := []string{"192.168.1.0", "192.168.1.1"}
 	c.Assert(ipAddresses, jc.DeepEquals, expected)
 }
+
+func (s *SubnetSuite) TestWatchSubnets(c *gc.C) {
+	w := s.State.WatchSubnets()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.0.0.0/24")
+	wc.AssertNoChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
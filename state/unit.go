@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/juju/errors"
@@ -100,6 +101,11 @@ type unitDoc struct {
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
 
+	// WorkloadVersion and WorkloadInfo are reported by the charm, and
+	// describe the software the unit runs.
+	WorkloadVersion string            `bson:"workloadversion,omitempty"`
+	WorkloadInfo    map[string]string `bson:"workloadinfo,omitempty"`
+
 	// TODO(mue) No longer actively used, only in upgrades.go.
 	// To be removed later.
 	Ports          []port `bson:"ports"`
@@ -228,6 +234,88 @@ func (u *Unit) SetAgentVersion(v version.Binary) (err error) {
 	return nil
 }
 
+// WorkloadVersion returns the version of the software the unit runs, as
+// reported by its charm. It is empty if the charm has not reported one.
+func (u *Unit) WorkloadVersion() string {
+	return u.doc.WorkloadVersion
+}
+
+// SetWorkloadVersion records the version of the software the unit runs.
+func (u *Unit) SetWorkloadVersion(version string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set workload version for unit %q", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"workloadversion", version}}}},
+	}}
+	if err := u.st.runTransaction(ops); err != nil {
+		return onAbort(err, ErrDead)
+	}
+	u.doc.WorkloadVersion = version
+	return nil
+}
+
+// WorkloadInfo returns the information about the software the unit runs
+// that was reported by its charm.
+func (u *Unit) WorkloadInfo() map[string]string {
+	info := make(map[string]string)
+	for k, v := range u.doc.WorkloadInfo {
+		info[k] = v
+	}
+	return info
+}
+
+var validWorkloadInfoKey = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// SetWorkloadInfo updates the information about the software the unit
+// runs with the supplied changes. Keys with empty values are removed.
+func (u *Unit) SetWorkloadInfo(changes map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set workload info for unit %q", u)
+	var set, unset bson.D
+	for k, v := range changes {
+		if !validWorkloadInfoKey.MatchString(k) {
+			return errors.NotValidf("key %q", k)
+		}
+		field := "workloadinfo." + k
+		if v == "" {
+			unset = append(unset, bson.DocElem{field, 1})
+		} else {
+			set = append(set, bson.DocElem{field, v})
+		}
+	}
+	var update bson.D
+	if len(set) > 0 {
+		update = append(update, bson.DocElem{"$set", set})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
+	}
+	if len(update) == 0 {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: update,
+	}}
+	if err := u.st.runTransaction(ops); err != nil {
+		return onAbort(err, ErrDead)
+	}
+	if u.doc.WorkloadInfo == nil {
+		u.doc.WorkloadInfo = make(map[string]string)
+	}
+	for k, v := range changes {
+		if v == "" {
+			delete(u.doc.WorkloadInfo, k)
+		} else {
+			u.doc.WorkloadInfo[k] = v
+		}
+	}
+	return nil
+}
+
 // SetPassword sets the password for the machine's agent.
 func (u *Unit) SetPassword(password string) error {
 	if len(password) < utils.MinAgentPasswordLength {
@@ -1688,6 +1776,16 @@ func machineStorageParamsForStorageInstance(
 			charmStorage.ReadOnly,
 		}
 		if unit == storage.Owner() {
+			volume, err := st.storageInstanceVolume(storage.StorageTag())
+			if err == nil {
+				// The storage instance was previously attached to
+				// another unit, and already has a volume, for which
+				// we will just add an attachment.
+				volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
+				break
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
+			}
 			// The storage instance is owned by the unit, so we'll need
 			// to create a volume.
 			cons := allCons[storage.StorageName()]
@@ -1724,6 +1822,16 @@ func machineStorageParamsForStorageInstance(
 			charmStorage.ReadOnly,
 		}
 		if unit == storage.Owner() {
+			filesystem, err := st.storageInstanceFilesystem(storage.StorageTag())
+			if err == nil {
+				// The storage instance was previously attached to
+				// another unit, and already has a filesystem, for
+				// which we will just add an attachment.
+				filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
+				break
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
+			}
 			// The storage instance is owned by the unit, so we'll need
 			// to create a filesystem.
 			cons := allCons[storage.StorageName()]
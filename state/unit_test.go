@@ -846,6 +846,54 @@ func (s *UnitSuite) TestTag(c *gc.C) {
 	c.Assert(s.unit.Tag().String(), gc.Equals, "unit-wordpress-0")
 }
 
+func (s *UnitSuite) TestSetWorkloadVersion(c *gc.C) {
+	c.Assert(s.unit.WorkloadVersion(), gc.Equals, "")
+	err := s.unit.SetWorkloadVersion("9.5.2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.WorkloadVersion(), gc.Equals, "9.5.2")
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.WorkloadVersion(), gc.Equals, "9.5.2")
+}
+
+func (s *UnitSuite) TestSetWorkloadVersionDead(c *gc.C) {
+	preventUnitDestroyRemove(c, s.unit)
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetWorkloadVersion("9.5.2")
+	c.Assert(err, gc.ErrorMatches, `cannot set workload version for unit "wordpress/0": not found or dead`)
+}
+
+func (s *UnitSuite) TestSetWorkloadInfo(c *gc.C) {
+	c.Assert(s.unit.WorkloadInfo(), gc.HasLen, 0)
+	err := s.unit.SetWorkloadInfo(map[string]string{
+		"edition":   "enterprise",
+		"data-size": "42G",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetWorkloadInfo(map[string]string{
+		"edition": "",
+		"role":    "primary",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := map[string]string{
+		"data-size": "42G",
+		"role":      "primary",
+	}
+	c.Assert(s.unit.WorkloadInfo(), jc.DeepEquals, expected)
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.WorkloadInfo(), jc.DeepEquals, expected)
+}
+
+func (s *UnitSuite) TestSetWorkloadInfoInvalidKey(c *gc.C) {
+	err := s.unit.SetWorkloadInfo(map[string]string{"bad.key": "x"})
+	c.Assert(err, gc.ErrorMatches, `cannot set workload info for unit "wordpress/0": key "bad.key" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *UnitSuite) TestSetPassword(c *gc.C) {
 	preventUnitDestroyRemove(c, s.unit)
 	testSetPassword(c, func() (state.Authenticator, error) {
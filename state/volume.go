@@ -45,6 +45,11 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// PendingResize returns the size, in MiB, that the volume has been
+	// requested to grow to, if any. PendingResize returns true if a
+	// resize is pending, otherwise false.
+	PendingResize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -90,6 +95,7 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+	ResizeSize      uint64        `bson:"resizesize,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -116,6 +122,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the storage provider's ID for
+	// the volume snapshot from which the volume is to be created.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -235,6 +245,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// PendingResize is required to implement Volume.
+func (v *volume) PendingResize() (uint64, bool) {
+	return v.doc.ResizeSize, v.doc.ResizeSize != 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -0,0 +1,430 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot interface {
+	Lifer
+
+	// Id returns the ID of the snapshot, which has the form
+	// "<volume-id>@<sequence>".
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot
+	// was taken from.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool of the volume
+	// that the snapshot was taken from. Volumes restored from
+	// the snapshot are created in the same pool.
+	Pool() string
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been
+	// created by the storage provider.
+	Info() (VolumeSnapshotInfo, error)
+
+	// Error returns the error, if any, that occurred when the
+	// storage provider last attempted to create the snapshot.
+	Error() string
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot
+// in the model.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Id        string              `bson:"id"`
+	ModelUUID string              `bson:"model-uuid"`
+	Volume    string              `bson:"volumeid"`
+	Pool      string              `bson:"pool"`
+	Life      Life                `bson:"life"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+	Error     string              `bson:"error,omitempty"`
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is the storage provider's unique identifier
+	// for the snapshot.
+	SnapshotId string `bson:"snapshotid"`
+
+	// Size is the size of the snapshot, in MiB.
+	Size uint64 `bson:"size"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Life returns the volume snapshot's current lifecycle state.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Id)
+	}
+	return *s.doc.Info, nil
+}
+
+// Error is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Error() string {
+	return s.doc.Error
+}
+
+// IsValidVolumeSnapshot reports whether the string is a valid
+// volume snapshot ID.
+func IsValidVolumeSnapshot(id string) bool {
+	_, _, err := parseVolumeSnapshotId(id)
+	return err == nil
+}
+
+// VolumeSnapshotVolume returns the tag of the volume that the snapshot
+// with the specified ID was taken from.
+func VolumeSnapshotVolume(id string) (names.VolumeTag, error) {
+	volumeTag, _, err := parseVolumeSnapshotId(id)
+	return volumeTag, err
+}
+
+func parseVolumeSnapshotId(id string) (names.VolumeTag, string, error) {
+	at := strings.LastIndex(id, "@")
+	if at == -1 || !names.IsValidVolume(id[:at]) {
+		return names.VolumeTag{}, "", errors.NotValidf("volume snapshot ID %q", id)
+	}
+	if _, err := strconv.ParseUint(id[at+1:], 10, 64); err != nil {
+		return names.VolumeTag{}, "", errors.NotValidf("volume snapshot ID %q", id)
+	}
+	return names.NewVolumeTag(id[:at]), id[at+1:], nil
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := st.volumeSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (st *State) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var doc volumeSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshots returns all of the snapshots of the volume with
+// the specified tag.
+func (st *State) VolumeSnapshots(volume names.VolumeTag) ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(bson.D{{"volumeid", volume.Id()}})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshots of volume %q", volume.Id())
+	}
+	return snapshots, nil
+}
+
+// AllVolumeSnapshots returns all of the volume snapshots in the model.
+func (st *State) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	snapshots, err := st.volumeSnapshots(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	return snapshots, nil
+}
+
+func (st *State) volumeSnapshots(query interface{}) ([]VolumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// AddVolumeSnapshot adds a snapshot of the volume with the specified
+// tag, to be created by the storage provisioner, and returns the ID of
+// the new snapshot. The volume must be alive and provisioned.
+func (st *State) AddVolumeSnapshot(volume names.VolumeTag) (_ string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add snapshot of volume %s", volume.Id())
+	seq, err := st.sequence("volumesnapshot-" + volume.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := fmt.Sprintf("%s@%d", volume.Id(), seq)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(volume)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     volume.Id(),
+			Assert: append(bson.D{{"info", bson.D{{"$exists", true}}}}, isAliveDoc...),
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &volumeSnapshotDoc{
+				Id:     id,
+				Volume: volume.Id(),
+				Pool:   info.Pool,
+			},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// SetVolumeSnapshotInfo sets the VolumeSnapshotInfo for the specified
+// volume snapshot, and clears any error recorded for it.
+func (st *State) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		assert := notDeadDoc
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo.SnapshotId != info.SnapshotId {
+				return nil, errors.Errorf(
+					"cannot change snapshot ID from %q to %q",
+					oldInfo.SnapshotId, info.SnapshotId,
+				)
+			}
+			assert = append(bson.D{{"info.snapshotid", oldInfo.SnapshotId}}, assert...)
+		} else {
+			assert = append(bson.D{{"info", bson.D{{"$exists", false}}}}, assert...)
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: assert,
+			Update: bson.D{
+				{"$set", bson.D{{"info", &info}}},
+				{"$unset", bson.D{{"error", nil}}},
+			},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// SetVolumeSnapshotError records the error that occurred when the
+// storage provider attempted to create the specified volume snapshot.
+func (st *State) SetVolumeSnapshotError(id string, message string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set error for volume snapshot %q", id)
+	ops := []txn.Op{{
+		C:      volumeSnapshotsC,
+		Id:     id,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"error", message}}}},
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if _, err := st.volumeSnapshot(id); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.New("volume snapshot is dead")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// DestroyVolumeSnapshot ensures that the volume snapshot will be
+// destroyed by the storage provisioner, and removed from state, at
+// some point in the future.
+func (st *State) DestroyVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "destroying volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// RemoveVolumeSnapshot removes the volume snapshot from state.
+// RemoveVolumeSnapshot will fail if the snapshot is Alive.
+func (st *State) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "removing volume snapshot %q", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Alive {
+			return nil, errors.New("volume snapshot is not dying")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// RestoreVolumeSnapshot adds a new volume, created from the specified
+// volume snapshot, and attaches it to the specified machine. The new
+// volume is created in the same pool as the snapshot's volume, with
+// the size of the snapshot. Snapshots of machine-scoped volumes can
+// only be restored to the same machine.
+func (st *State) RestoreVolumeSnapshot(id string, machine names.MachineTag) (_ names.VolumeTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot restore volume snapshot %q to machine %s", id, machine.Id())
+	var volumeTag names.VolumeTag
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("volume snapshot is not alive")
+		}
+		info, err := s.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if scope := volumeMachineScope(s.Volume()); scope != "" && scope != machine.Id() {
+			return nil, errors.Errorf(
+				"snapshot of volume scoped to machine %s cannot be restored to another machine", scope,
+			)
+		}
+		m, err := st.Machine(machine.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.Life() != Alive {
+			return nil, errors.New("machine is not alive")
+		}
+		volumeOps, tag, err := st.addVolumeOps(VolumeParams{
+			Pool:       s.doc.Pool,
+			Size:       info.Size,
+			SnapshotId: info.SnapshotId,
+		}, machine.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachments := []volumeAttachmentTemplate{{tag, VolumeAttachmentParams{}}}
+		machineOps, err := addMachineStorageAttachmentsOps(m, attachments, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeTag = tag
+		ops := []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: append(bson.D{{"info", bson.D{{"$exists", true}}}}, isAliveDoc...),
+		}}
+		ops = append(ops, volumeOps...)
+		ops = append(ops, createMachineVolumeAttachmentsOps(machine.Id(), attachments)...)
+		ops = append(ops, machineOps...)
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return names.VolumeTag{}, err
+	}
+	return volumeTag, nil
+}
+
+// removeMachineVolumeSnapshotsOps returns txn.Ops to remove the snapshots
+// of volumes scoped to the specified machine. Such snapshots are stored
+// on the machine, and are removed along with it.
+func (st *State) removeMachineVolumeSnapshotsOps(machine names.MachineTag) ([]txn.Op, error) {
+	pattern := fmt.Sprintf("^%s/%s@", machine.Id(), names.NumberSnippet)
+	snapshots, err := st.volumeSnapshots(bson.D{{"id", bson.D{{"$regex", pattern}}}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(snapshots))
+	for i, s := range snapshots {
+		ops[i] = txn.Op{
+			C:      volumeSnapshotsC,
+			Id:     s.Id(),
+			Assert: txn.DocExists,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// volumeMachineScope returns the ID of the machine that the volume
+// with the specified tag is scoped to, or "" if the volume is not
+// machine-scoped.
+func volumeMachineScope(tag names.VolumeTag) string {
+	id := tag.Id()
+	if slash := strings.LastIndex(id, "/"); slash != -1 {
+		return id[:slash]
+	}
+	return ""
+}
@@ -0,0 +1,280 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotStateSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotStateSuite{})
+
+// addMachineWithVolume adds a machine with a single volume in the
+// specified pool, and sets the volume's info.
+func (s *VolumeSnapshotStateSuite) addMachineWithVolume(c *gc.C, pool string) (*state.Machine, names.VolumeTag) {
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: pool, Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.State.MachineVolumeAttachments(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	volumeTag := attachments[0].Volume()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	return machine, volumeTag
+}
+
+func (s *VolumeSnapshotStateSuite) volumeSnapshot(c *gc.C, id string) state.VolumeSnapshot {
+	snapshot, err := s.State.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	return snapshot
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshot(c *gc.C) {
+	_, volumeTag := s.addMachineWithVolume(c, "loop-pool")
+	c.Assert(volumeTag, gc.Equals, names.NewVolumeTag("0/0"))
+
+	id, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "0/0@0")
+	id, err = s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "0/0@1")
+
+	snapshot := s.volumeSnapshot(c, "0/0@0")
+	c.Assert(snapshot.Id(), gc.Equals, "0/0@0")
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.Error(), gc.Equals, "")
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	snapshots, err := s.State.VolumeSnapshots(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+	snapshots, err = s.State.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshotVolumeNotProvisioned(c *gc.C) {
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "loop-pool", Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume 0/0: volume "0/0" not provisioned`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestAddVolumeSnapshotVolumeNotFound(c *gc.C) {
+	_, err := s.State.AddVolumeSnapshot(names.NewVolumeTag("42"))
+	c.Assert(err, gc.ErrorMatches, `cannot add snapshot of volume 42: volume "42" not found`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	_, volumeTag := s.addMachineWithVolume(c, "loop-pool")
+	id, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetVolumeSnapshotError(id, "boom")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volumeSnapshot(c, id).Error(), gc.Equals, "boom")
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024}
+	err = s.State.SetVolumeSnapshotInfo(id, info)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot := s.volumeSnapshot(c, id)
+	c.Assert(snapshot.Error(), gc.Equals, "")
+	snapshotInfo, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotInfo, jc.DeepEquals, info)
+
+	// The snapshot ID cannot change once set.
+	err = s.State.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0@0": cannot change snapshot ID from "snap-0" to "snap-1"`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestSetVolumeSnapshotInfoNoSnapshotId(c *gc.C) {
+	err := s.State.SetVolumeSnapshotInfo("0/0@0", state.VolumeSnapshotInfo{Size: 1024})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0@0": snapshot ID not set`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestDestroyVolumeSnapshot(c *gc.C) {
+	_, volumeTag := s.addMachineWithVolume(c, "loop-pool")
+	id, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveVolumeSnapshot(id)
+	c.Assert(err, gc.ErrorMatches, `removing volume snapshot "0/0@0": volume snapshot is not dying`)
+
+	err = s.State.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volumeSnapshot(c, id).Life(), gc.Equals, state.Dying)
+	err = s.State.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeSnapshot(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotStateSuite) TestRestoreVolumeSnapshot(c *gc.C) {
+	machine, volumeTag := s.addMachineWithVolume(c, "loop-pool")
+	id, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 512})
+	c.Assert(err, jc.ErrorIsNil)
+
+	restoredTag, err := s.State.RestoreVolumeSnapshot(id, machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restoredTag, gc.Equals, names.NewVolumeTag("0/1"))
+
+	restored := s.volume(c, restoredTag)
+	params, ok := restored.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "loop-pool")
+	c.Assert(params.Size, gc.Equals, uint64(512))
+	c.Assert(params.SnapshotId, gc.Equals, "snap-0")
+
+	attachment := s.volumeAttachment(c, machine.MachineTag(), restoredTag)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+	assertMachineStorageRefs(c, s.State, machine.MachineTag())
+}
+
+func (s *VolumeSnapshotStateSuite) TestRestoreVolumeSnapshotOtherMachine(c *gc.C) {
+	_, volumeTag := s.addMachineWithVolume(c, "loop-pool")
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 512})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.RestoreVolumeSnapshot(id, other.MachineTag())
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "0/0@0" to machine 1: `+
+		`snapshot of volume scoped to machine 0 cannot be restored to another machine`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestRestoreVolumeSnapshotModelScoped(c *gc.C) {
+	_, volumeTag := s.addMachineWithVolume(c, "persistent-block")
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	restoredTag, err := s.State.RestoreVolumeSnapshot(id, other.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restoredTag, gc.Equals, names.NewVolumeTag("1"))
+	s.volumeAttachment(c, other.MachineTag(), restoredTag)
+}
+
+func (s *VolumeSnapshotStateSuite) TestRestoreVolumeSnapshotNotProvisioned(c *gc.C) {
+	machine, volumeTag := s.addMachineWithVolume(c, "loop-pool")
+	id, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.RestoreVolumeSnapshot(id, machine.MachineTag())
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "0/0@0" to machine 0: volume snapshot "0/0@0" not provisioned`)
+}
+
+func (s *VolumeSnapshotStateSuite) TestRemoveMachineRemovesVolumeSnapshots(c *gc.C) {
+	machine, volumeTag := s.addMachineWithVolume(c, "loop-pool")
+	id, err := s.State.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(machine.Destroy(), jc.ErrorIsNil)
+	c.Assert(machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(machine.Remove(), jc.ErrorIsNil)
+
+	_, err = s.State.VolumeSnapshot(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotStateSuite) TestWatchModelVolumeSnapshots(c *gc.C) {
+	_, modelVolume := s.addMachineWithVolume(c, "persistent-block")
+	_, machineVolume := s.addMachineWithVolume(c, "loop-pool")
+
+	w := s.State.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	_, err := s.State.AddVolumeSnapshot(machineVolume)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	id, err := s.State.AddVolumeSnapshot(modelVolume)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id)
+	wc.AssertNoChange()
+
+	err = s.State.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id) // dying
+	wc.AssertNoChange()
+
+	err = s.State.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id) // removed
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotStateSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	_, modelVolume := s.addMachineWithVolume(c, "persistent-block")
+	machine, machineVolume := s.addMachineWithVolume(c, "loop-pool")
+
+	w := s.State.WatchMachineVolumeSnapshots(machine.MachineTag())
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	_, err := s.State.AddVolumeSnapshot(modelVolume)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	id, err := s.State.AddVolumeSnapshot(machineVolume)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id)
+	wc.AssertNoChange()
+
+	err = s.State.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id) // dying
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotStateSuite) TestIsValidVolumeSnapshot(c *gc.C) {
+	c.Assert(state.IsValidVolumeSnapshot("0@0"), jc.IsTrue)
+	c.Assert(state.IsValidVolumeSnapshot("0/1@2"), jc.IsTrue)
+	c.Assert(state.IsValidVolumeSnapshot("0/1"), jc.IsFalse)
+	c.Assert(state.IsValidVolumeSnapshot("0/1@"), jc.IsFalse)
+	c.Assert(state.IsValidVolumeSnapshot("0/1@x"), jc.IsFalse)
+	c.Assert(state.IsValidVolumeSnapshot("x@1"), jc.IsFalse)
+}
@@ -174,6 +174,12 @@ type lifecycleWatcher struct {
 	transform func(string) string
 	// life holds the most recent known life states of interesting entities.
 	life map[string]Life
+	// suspended holds the interesting entities known to be suspended;
+	// only relations can be suspended.
+	suspended set.Strings
+	// resizes holds the pending resize sizes of interesting entities;
+	// only volumes and filesystems can be resized.
+	resizes map[string]uint64
 }
 
 func collFactory(st *State, collName string) func() (mongo.Collection, func()) {
@@ -195,13 +201,13 @@ func (st *State) WatchIPAddresses() StringsWatcher {
 }
 
 // WatchModelVolumes returns a StringsWatcher that notifies of changes to
-// the lifecycles of all model-scoped volumes.
+// the lifecycles, or pending resizes, of all model-scoped volumes.
 func (st *State) WatchModelVolumes() StringsWatcher {
 	return st.watchModelMachinestorage(volumesC)
 }
 
 // WatchModelFilesystems returns a StringsWatcher that notifies of changes
-// to the lifecycles of all model-scoped filesystems.
+// to the lifecycles, or pending resizes, of all model-scoped filesystems.
 func (st *State) WatchModelFilesystems() StringsWatcher {
 	return st.watchModelMachinestorage(filesystemsC)
 }
@@ -220,13 +226,15 @@ func (st *State) watchModelMachinestorage(collection string) StringsWatcher {
 }
 
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
-// the lifecycles of all volumes scoped to the specified machine.
+// the lifecycles, or pending resizes, of all volumes scoped to the
+// specified machine.
 func (st *State) WatchMachineVolumes(m names.MachineTag) StringsWatcher {
 	return st.watchMachineStorage(m, volumesC)
 }
 
 // WatchMachineFilesystems returns a StringsWatcher that notifies of changes
-// to the lifecycles of all filesystems scoped to the specified machine.
+// to the lifecycles, or pending resizes, of all filesystems scoped to the
+// specified machine.
 func (st *State) WatchMachineFilesystems(m names.MachineTag) StringsWatcher {
 	return st.watchMachineStorage(m, filesystemsC)
 }
@@ -245,6 +253,42 @@ func (st *State) watchMachineStorage(m names.MachineTag, collection string) Stri
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of model-scoped volumes.
+func (st *State) WatchModelVolumeSnapshots() StringsWatcher {
+	pattern := fmt.Sprintf("^%s@%s$", st.docID(names.NumberSnippet), names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newLifecycleWatcher(st, volumeSnapshotsC, members, filter, nil)
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of volumes scoped to the
+// specified machine.
+func (st *State) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	pattern := fmt.Sprintf("^%s/%s@%s$", st.docID(m.Id()), names.NumberSnippet, names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		at := strings.LastIndex(k, "@")
+		if at == -1 || !strings.HasPrefix(k, prefix) {
+			return false
+		}
+		return !strings.Contains(k[len(prefix):at], "/")
+	}
+	return newLifecycleWatcher(st, volumeSnapshotsC, members, filter, nil)
+}
+
 // WatchEnvironVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to environ-
 // scoped volumes.
@@ -346,7 +390,7 @@ func (s *Service) WatchUnits() StringsWatcher {
 }
 
 // WatchRelations returns a StringsWatcher that notifies of changes to the
-// lifecycles of relations involving s.
+// lifecycles, and to the suspension, of relations involving s.
 func (s *Service) WatchRelations() StringsWatcher {
 	prefix := s.doc.Name + ":"
 	infix := " " + prefix
@@ -423,6 +467,8 @@ func newLifecycleWatcher(
 		filter:        filter,
 		transform:     transform,
 		life:          make(map[string]Life),
+		suspended:     make(set.Strings),
+		resizes:       make(map[string]uint64),
 		out:           make(chan []string),
 	}
 	go func() {
@@ -434,11 +480,13 @@ func newLifecycleWatcher(
 }
 
 type lifeDoc struct {
-	Id   string `bson:"_id"`
-	Life Life
+	Id         string `bson:"_id"`
+	Life       Life
+	Suspended  bool   `bson:"suspended,omitempty"`
+	ResizeSize uint64 `bson:"resizesize,omitempty"`
 }
 
-var lifeFields = bson.D{{"_id", 1}, {"life", 1}}
+var lifeFields = bson.D{{"_id", 1}, {"life", 1}, {"suspended", 1}, {"resizesize", 1}}
 
 // Changes returns the event channel for the LifecycleWatcher.
 func (w *lifecycleWatcher) Changes() <-chan []string {
@@ -457,6 +505,12 @@ func (w *lifecycleWatcher) initial() (set.Strings, error) {
 		ids.Add(id)
 		if doc.Life != Dead {
 			w.life[id] = doc.Life
+			if doc.Suspended {
+				w.suspended.Add(id)
+			}
+			if doc.ResizeSize != 0 {
+				w.resizes[id] = doc.ResizeSize
+			}
 		}
 	}
 	return ids, iter.Close()
@@ -468,14 +522,14 @@ func (w *lifecycleWatcher) merge(ids set.Strings, updates map[interface{}]bool)
 
 	// Separate ids into those thought to exist and those known to be removed.
 	var changed []string
-	latest := make(map[string]Life)
+	latest := make(map[string]lifeDoc)
 	for docID, exists := range updates {
 		switch docID := docID.(type) {
 		case string:
 			if exists {
 				changed = append(changed, docID)
 			} else {
-				latest[w.st.localID(docID)] = Dead
+				latest[w.st.localID(docID)] = lifeDoc{Life: Dead}
 			}
 		default:
 			return errors.Errorf("id is not of type string, got %T", docID)
@@ -489,14 +543,17 @@ func (w *lifecycleWatcher) merge(ids set.Strings, updates map[interface{}]bool)
 	iter := coll.Find(bson.D{{"_id", bson.D{{"$in", changed}}}}).Select(lifeFields).Iter()
 	var doc lifeDoc
 	for iter.Next(&doc) {
-		latest[w.st.localID(doc.Id)] = doc.Life
+		latest[w.st.localID(doc.Id)] = doc
+		doc = lifeDoc{}
 	}
 	if err := iter.Close(); err != nil {
 		return err
 	}
 
-	// Add to ids any whose life state is known to have changed.
-	for id, newLife := range latest {
+	// Add to ids any whose life state, suspension, or pending resize
+	// is known to have changed.
+	for id, newDoc := range latest {
+		newLife := newDoc.Life
 		gone := newLife == Dead
 		oldLife, known := w.life[id]
 		switch {
@@ -506,9 +563,21 @@ func (w *lifecycleWatcher) merge(ids set.Strings, updates map[interface{}]bool)
 			w.life[id] = newLife
 		case known && newLife != oldLife:
 			w.life[id] = newLife
+		case known && newDoc.Suspended != w.suspended.Contains(id):
+		case known && newDoc.ResizeSize != w.resizes[id]:
 		default:
 			continue
 		}
+		if newDoc.Suspended && !gone {
+			w.suspended.Add(id)
+		} else {
+			w.suspended.Remove(id)
+		}
+		if newDoc.ResizeSize != 0 && !gone {
+			w.resizes[id] = newDoc.ResizeSize
+		} else {
+			delete(w.resizes, id)
+		}
 		ids.Add(id)
 	}
 	return nil
@@ -1452,6 +1521,12 @@ func (st *State) WatchForUnitAssignment() StringsWatcher {
 	return newcollectionWatcher(st, colWCfg{col: assignUnitC})
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to the
+// subnets of the model, including changes to the spaces they are in.
+func (st *State) WatchSubnets() StringsWatcher {
+	return newcollectionWatcher(st, colWCfg{col: subnetsC})
+}
+
 // WatchAPIHostPorts returns a NotifyWatcher that notifies
 // when the set of API addresses changes.
 func (st *State) WatchAPIHostPorts() NotifyWatcher {
@@ -1479,6 +1554,12 @@ func (st *State) WatchFilesystemAttachment(m names.MachineTag, f names.Filesyste
 	return newEntityWatcher(st, filesystemAttachmentsC, st.docID(id))
 }
 
+// WatchFilesystem returns a watcher for observing changes
+// to a filesystem.
+func (st *State) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(st, filesystemsC, st.docID(f.Id()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's service configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -1878,6 +1959,127 @@ func (w *cleanupWatcher) loop() (err error) {
 	}
 }
 
+// remoteRelationsCollections holds the collections whose documents, in a
+// model or in the models it shares cross-model relations with, are read
+// when syncing the model's cross-model relations.
+var remoteRelationsCollections = []string{
+	remoteServicesC,
+	servicesC,
+	relationsC,
+	relationScopesC,
+	settingsC,
+	modelsC,
+}
+
+// remoteRelationsWatcher notifies of changes that may require the
+// cross-model relations of a model to be synced.
+type remoteRelationsWatcher struct {
+	commonWatcher
+	out    chan struct{}
+	models set.Strings
+}
+
+var _ Watcher = (*remoteRelationsWatcher)(nil)
+
+// WatchRemoteRelations returns a NotifyWatcher that notifies of changes
+// to the model's relations with services consumed from other models, and
+// to their mirrors in the offering models.
+func (st *State) WatchRemoteRelations() NotifyWatcher {
+	return newRemoteRelationsWatcher(st)
+}
+
+func newRemoteRelationsWatcher(st *State) NotifyWatcher {
+	w := &remoteRelationsWatcher{
+		commonWatcher: commonWatcher{st: st},
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *remoteRelationsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *remoteRelationsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	for _, coll := range remoteRelationsCollections {
+		w.st.watcher.WatchCollection(coll, in)
+		defer w.st.watcher.UnwatchCollection(coll, in)
+	}
+	if err := w.refreshModels(); err != nil {
+		return errors.Trace(err)
+	}
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.st.watcher.Dead():
+			return stateWatcherDeadError(w.st.watcher.Err())
+		case ch := <-in:
+			if !w.relevant(ch) {
+				continue
+			}
+			if ch.C == remoteServicesC {
+				if err := w.refreshModels(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// refreshModels records the UUIDs of the models whose documents are read
+// when syncing the model's cross-model relations: the model itself, the
+// models it has consumed services from, and the models holding mirrors
+// of its relations.
+func (w *remoteRelationsWatcher) refreshModels() error {
+	models, err := w.st.mirroringModels()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	consumed, err := w.st.AllRemoteServices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rsvc := range consumed {
+		if rsvc.IsConsumed() {
+			models.Add(rsvc.doc.SourceModelUUID)
+		}
+	}
+	models.Add(w.st.ModelUUID())
+	w.models = models
+	return nil
+}
+
+// relevant returns whether the supplied change is to a document in one
+// of the models recorded by refreshModels. Of the settings documents,
+// only those of relation units are relevant.
+func (w *remoteRelationsWatcher) relevant(ch watcher.Change) bool {
+	id, ok := ch.Id.(string)
+	if !ok {
+		return false
+	}
+	if ch.C == modelsC {
+		return w.models.Contains(id)
+	}
+	modelUUID, localID, ok := splitDocID(id)
+	if !ok || !w.models.Contains(modelUUID) {
+		return false
+	}
+	return ch.C != settingsC || strings.HasPrefix(localID, "r#")
+}
+
 // actionStatusWatcher is a StringsWatcher that filters notifications
 // to Action Id's that match the ActionReceiver and ActionStatus set
 // provided.
@@ -57,4 +57,20 @@ type BlockDevice struct {
 
 	// MountPoint is the path at which the block devices is mounted.
 	MountPoint string `yaml:"mountpoint,omitempty"`
+
+	// UsedSize is the amount of space used in the filesystem mounted
+	// from the block device, in MiB. This is only set if the block
+	// device is mounted.
+	UsedSize uint64 `yaml:"usedsize,omitempty"`
+
+	// VolumeGroup is the name of the LVM volume group that the block
+	// device is a physical volume of, if any.
+	VolumeGroup string `yaml:"volumegroup,omitempty"`
+
+	// FreeSize is the amount of unallocated space in the LVM physical
+	// volume on the block device, in MiB. The unused data space of
+	// any thin pool in the volume group is included in the free size
+	// of the volume group's first physical volume. This is only set
+	// if the block device is a physical volume of a volume group.
+	FreeSize uint64 `yaml:"freesize,omitempty"`
 }
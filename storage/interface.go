@@ -108,6 +108,36 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeSnapshotter is an interface that may be implemented by a
+// VolumeSource that supports snapshotting volumes. A VolumeSource
+// implementing VolumeSnapshotter must also support creating volumes
+// from snapshots, as identified by VolumeParams.SnapshotId.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots creates snapshots of volumes with the
+	// specified parameters.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// ListVolumeSnapshots lists the provider snapshot IDs for every
+	// volume snapshot created by this volume source.
+	ListVolumeSnapshots() ([]string, error)
+
+	// DestroyVolumeSnapshots destroys the volume snapshots with the
+	// specified provider snapshot IDs.
+	DestroyVolumeSnapshots(snapshotIds []string) ([]error, error)
+}
+
+// VolumeResizer is an interface that may be implemented by a
+// VolumeSource that supports growing volumes in place. Only the loop
+// and LVM volume sources implement it; cloud volumes cannot be grown.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters
+	// to at least the requested sizes. Volumes are never shrunk.
+	//
+	// ResizeVolumes must be idempotent; it may be called again for
+	// volumes that have already been grown.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -141,6 +171,18 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemResizer is an interface that may be implemented by a
+// FilesystemSource that supports growing filesystems in place.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to at least the requested sizes. Filesystems are
+	// never shrunk.
+	//
+	// ResizeFilesystems must be idempotent; it may be called again
+	// for filesystems that have already been grown.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -175,6 +217,58 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId is the provider ID of the volume snapshot from which
+	// the volume should be created, if any. Only volume sources that
+	// implement VolumeSnapshotter support creating volumes from
+	// snapshots.
+	SnapshotId string
+}
+
+// VolumeSnapshotParams is a set of parameters for snapshotting a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju to the requested snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju for the volume to
+	// snapshot.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume to
+	// snapshot.
+	VolumeId string
+
+	// Size is the size of the volume to snapshot, in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that is to be used
+	// to snapshot the volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the volume was created from.
+	Attributes map[string]interface{}
+}
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume to grow.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume
+	// to grow.
+	VolumeId string
+
+	// Size is the size that the volume is to be grown to, in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that is to be
+	// used to grow the volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the volume was created from.
+	Attributes map[string]interface{}
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -239,6 +333,31 @@ type FilesystemParams struct {
 	ResourceTags map[string]string
 }
 
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the filesystem to grow.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem, if any.
+	// The backing volume must be grown before the filesystem.
+	Volume names.VolumeTag
+
+	// FilesystemId is the unique provider-supplied ID for the
+	// filesystem to grow.
+	FilesystemId string
+
+	// Size is the size that the filesystem is to be grown to, in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that is to be
+	// used to grow the filesystem.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool that the filesystem was created from.
+	Attributes map[string]interface{}
+}
+
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
 // or detachment.
 type FilesystemAttachmentParams struct {
@@ -266,6 +385,21 @@ type CreateVolumesResult struct {
 	Error            error
 }
 
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one snapshot.
+// VolumeSnapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	VolumeSnapshot *VolumeSnapshot
+	Error          error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Volume should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	Volume *Volume
+	Error  error
+}
+
 // DescribeVolumesResult contains the result of a VolumeSource.DescribeVolumes call
 // for one volume. Volume should only be used if Error is nil.
 type DescribeVolumesResult struct {
@@ -287,6 +421,14 @@ type CreateFilesystemsResult struct {
 	Error      error
 }
 
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// Filesystem should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	Filesystem *Filesystem
+	Error      error
+}
+
 // DescribeFilesystemsResult contains the result of a FilesystemSource.DescribeFilesystems call
 // for one filesystem. Filesystem should only be used if Error is nil.
 type DescribeFilesystemsResult struct {
@@ -13,13 +13,16 @@ import (
 type VolumeSource struct {
 	testing.Stub
 
-	CreateVolumesFunc        func([]storage.VolumeParams) ([]storage.CreateVolumesResult, error)
-	ListVolumesFunc          func() ([]string, error)
-	DescribeVolumesFunc      func([]string) ([]storage.DescribeVolumesResult, error)
-	DestroyVolumesFunc       func([]string) ([]error, error)
-	ValidateVolumeParamsFunc func(storage.VolumeParams) error
-	AttachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
-	DetachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]error, error)
+	CreateVolumesFunc          func([]storage.VolumeParams) ([]storage.CreateVolumesResult, error)
+	ListVolumesFunc            func() ([]string, error)
+	DescribeVolumesFunc        func([]string) ([]storage.DescribeVolumesResult, error)
+	DestroyVolumesFunc         func([]string) ([]error, error)
+	ValidateVolumeParamsFunc   func(storage.VolumeParams) error
+	AttachVolumesFunc          func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
+	DetachVolumesFunc          func([]storage.VolumeAttachmentParams) ([]error, error)
+	CreateVolumeSnapshotsFunc  func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	ListVolumeSnapshotsFunc    func() ([]string, error)
+	DestroyVolumeSnapshotsFunc func([]string) ([]error, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -84,3 +87,30 @@ func (s *VolumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// CreateVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", params)
+	if s.CreateVolumeSnapshotsFunc != nil {
+		return s.CreateVolumeSnapshotsFunc(params)
+	}
+	return nil, errors.NotImplementedf("CreateVolumeSnapshots")
+}
+
+// ListVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) ListVolumeSnapshots() ([]string, error) {
+	s.MethodCall(s, "ListVolumeSnapshots")
+	if s.ListVolumeSnapshotsFunc != nil {
+		return s.ListVolumeSnapshotsFunc()
+	}
+	return nil, nil
+}
+
+// DestroyVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	s.MethodCall(s, "DestroyVolumeSnapshots", snapshotIds)
+	if s.DestroyVolumeSnapshotsFunc != nil {
+		return s.DestroyVolumeSnapshotsFunc(snapshotIds)
+	}
+	return nil, errors.NotImplementedf("DestroyVolumeSnapshots")
+}
@@ -140,3 +140,19 @@ func MountedDirs(fsSource storage.FilesystemSource) set.Strings {
 	}
 	panic("unexpectd type")
 }
+
+func LVMProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &lvmProvider{run}
+}
+
+func LVMVolumeSource(run func(string, ...string) (string, error)) storage.VolumeSource {
+	return &lvmVolumeSource{run}
+}
+
+func ResizeLVMVolume(source storage.VolumeSource, volumeId string, size uint64) (uint64, error) {
+	return source.(*lvmVolumeSource).resizeVolume(volumeId, size)
+}
+
+func CreateLVMSnapshot(source storage.VolumeSource, volumeId, name string) (string, error) {
+	return source.(*lvmVolumeSource).createSnapshot(volumeId, name)
+}
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -100,6 +101,8 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -120,6 +123,17 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.SnapshotId != "" {
+		snapshotFilePath, err := lvs.snapshotFilePath(params.SnapshotId)
+		if err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotate(err, "could not restore block file from snapshot")
+		}
+	}
+	// If the volume was restored from a snapshot, createBlockFile
+	// extends the copied file to the requested size.
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -136,6 +150,17 @@ func (lvs *loopVolumeSource) volumeFilePath(tag names.VolumeTag) string {
 	return filepath.Join(lvs.storageDir, tag.String())
 }
 
+func (lvs *loopVolumeSource) snapshotsDir() string {
+	return filepath.Join(lvs.storageDir, "snapshots")
+}
+
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) (string, error) {
+	if snapshotId == "" || snapshotId != filepath.Base(snapshotId) {
+		return "", errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	return filepath.Join(lvs.snapshotsDir(), snapshotId), nil
+}
+
 // ListVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) ListVolumes() ([]string, error) {
 	// TODO(axw) implement this when we need it.
@@ -239,6 +264,126 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+//
+// Loop volumes are grown by extending their backing files, and then
+// refreshing the capacity of any loop devices attached to them.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := lvs.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "resizing volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(params storage.VolumeResizeParams) (*storage.Volume, error) {
+	loopFilePath := lvs.volumeFilePath(params.Tag)
+	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
+		return nil, errors.Annotate(err, "could not extend block file")
+	}
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := refreshLoopDeviceCapacity(lvs.run, deviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: params.VolumeId,
+			Size:     params.Size,
+		},
+	}, nil
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+//
+// Loop volume snapshots are sparse copies of the volumes' backing files.
+// A snapshot of a volume that is in use is only crash-consistent.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume snapshot")
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(params storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	snapshotId := storage.VolumeSnapshotName(params.Id)
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ensureDir(lvs.dirFuncs, lvs.snapshotsDir()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	loopFilePath := lvs.volumeFilePath(params.Volume)
+	if err := copyBlockFile(lvs.run, loopFilePath, snapshotFilePath); err != nil {
+		return nil, errors.Annotate(err, "could not copy block file")
+	}
+	return &storage.VolumeSnapshot{
+		params.Id,
+		params.Volume,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       params.Size,
+		},
+	}, nil
+}
+
+// ListVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) ListVolumeSnapshots() ([]string, error) {
+	infos, err := ioutil.ReadDir(lvs.snapshotsDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	snapshotIds := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.Mode().IsRegular() {
+			snapshotIds = append(snapshotIds, info.Name())
+		}
+	}
+	return snapshotIds, nil
+}
+
+// DestroyVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.destroyVolumeSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) destroyVolumeSnapshot(snapshotId string) error {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(snapshotFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot file")
+	}
+	return nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -250,6 +395,17 @@ func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) erro
 	return nil
 }
 
+// copyBlockFile copies the file at the source path to the destination
+// path, preserving holes so that the copy uses no more disk space than
+// the original.
+func copyBlockFile(run runCommandFunc, sourcePath, destPath string) error {
+	_, err := run("cp", "--sparse=always", sourcePath, destPath)
+	if err != nil {
+		return errors.Annotatef(err, "copying %q to %q", sourcePath, destPath)
+	}
+	return nil
+}
+
 // attachLoopDevice attaches a loop device to the file with the
 // specified path, and returns the loop device's name (e.g. "loop0").
 // losetup will create additional loop devices as necessary.
@@ -289,6 +445,16 @@ func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	return err
 }
 
+// refreshLoopDeviceCapacity updates the size of the loop device with
+// the specified name to match that of its backing file.
+func refreshLoopDeviceCapacity(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "refreshing capacity of loop device %q", deviceName)
+	}
+	return nil
+}
+
 // associatedLoopDevices returns the device names of the loop devices
 // associated with the specified file path.
 func associatedLoopDevices(run runCommandFunc, filePath string) ([]string, error) {
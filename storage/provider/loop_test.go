@@ -272,3 +272,187 @@ func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	_, err = os.Stat(fileName)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	s.commands.expect(
+		"cp", "--sparse=always",
+		filepath.Join(s.storageDir, "snapshots", "volume-0-snapshot-1"),
+		filepath.Join(s.storageDir, "volume-2"),
+	)
+	s.commands.expect("fallocate", "-l", "4MiB", filepath.Join(s.storageDir, "volume-2"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("2"),
+		Size:       4,
+		SnapshotId: "volume-0-snapshot-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("2"),
+		storage.VolumeInfo{
+			VolumeId: "volume-2",
+			Size:     4,
+		},
+	})
+}
+
+func (s *loopSuite) TestCreateVolumesFromSnapshotInvalidSnapshotId(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("2"),
+		Size:       4,
+		SnapshotId: "../../etc/passwd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume: invalid loop snapshot ID "\.\./\.\./etc/passwd"`)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source, dirFuncs := s.loopVolumeSource(c)
+	s.commands.expect(
+		"cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-0-1"),
+		filepath.Join(s.storageDir, "snapshots", "volume-0-1-snapshot-2"),
+	)
+
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0/1@2",
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "volume-0-1",
+		Size:     3,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		"0/1@2",
+		names.NewVolumeTag("0/1"),
+		storage.VolumeSnapshotInfo{
+			SnapshotId: "volume-0-1-snapshot-2",
+			Size:       3,
+		},
+	})
+	c.Assert(dirFuncs.Dirs.Contains(filepath.Join(s.storageDir, "snapshots")), jc.IsTrue)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshotsCopyFails(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	cmd := s.commands.expect(
+		"cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-0"),
+		filepath.Join(s.storageDir, "snapshots", "volume-0-snapshot-0"),
+	)
+	cmd.respond("", errors.New("no space left on device"))
+
+	results, err := source.(storage.VolumeSnapshotter).CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0@0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     3,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating volume snapshot: could not copy block file: .*: no space left on device")
+}
+
+func (s *loopSuite) TestListVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotter := source.(storage.VolumeSnapshotter)
+	snapshotIds, err := snapshotter.ListVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, gc.HasLen, 0)
+
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err = os.Mkdir(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(snapshotsDir, "volume-0-snapshot-0"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(snapshotsDir, "volume-1-snapshot-2"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshotIds, err = snapshotter.ListVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.SameContents, []string{"volume-0-snapshot-0", "volume-1-snapshot-2"})
+}
+
+func (s *loopSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	snapshotsDir := filepath.Join(s.storageDir, "snapshots")
+	err := os.Mkdir(snapshotsDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(snapshotsDir, "volume-0-snapshot-0")
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := source.(storage.VolumeSnapshotter).DestroyVolumeSnapshots([]string{
+		"volume-0-snapshot-0", "volume-0-snapshot-1", "../volume-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	// Destroying a snapshot that does not exist succeeds.
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying snapshot "\.\./volume-0": invalid loop snapshot ID "\.\./volume-0"`)
+
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	s.commands.expect("fallocate", "-l", "4MiB", filepath.Join(s.storageDir, "volume-0"))
+	cmd := s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-0"))
+	cmd.respond("/dev/loop42: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop42")
+	s.commands.expect("fallocate", "-l", "8MiB", filepath.Join(s.storageDir, "volume-1"))
+	cmd = s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-1"))
+	cmd.respond("", nil) // not attached
+
+	resizer, ok := source.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     8,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("0"),
+			storage.VolumeInfo{VolumeId: "volume-0", Size: 4},
+		},
+	}, {
+		Volume: &storage.Volume{
+			names.NewVolumeTag("1"),
+			storage.VolumeInfo{VolumeId: "volume-1", Size: 8},
+		},
+	}})
+}
+
+func (s *loopSuite) TestResizeVolumesRefreshFails(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	s.commands.expect("fallocate", "-l", "4MiB", filepath.Join(s.storageDir, "volume-0"))
+	cmd := s.commands.expect("losetup", "-j", filepath.Join(s.storageDir, "volume-0"))
+	cmd.respond("/dev/loop42: foo\n", nil)
+	cmd = s.commands.expect("losetup", "-c", "/dev/loop42")
+	cmd.respond("", errors.New("oy"))
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume: refreshing capacity of loop device "loop42": oy`)
+}
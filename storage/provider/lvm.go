@@ -0,0 +1,654 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/schema"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// LVMProviderType is the storage provider type for volumes carved
+	// from an LVM volume group on the machine.
+	LVMProviderType = storage.ProviderType("lvm")
+
+	// LVMVolumeGroup is the name of the LVM volume group from which
+	// logical volumes are created. The volume group must already
+	// exist on the machine.
+	LVMVolumeGroup = "volume-group"
+
+	// LVMThinPool is the name of the thin pool logical volume from
+	// which thin volumes are allocated. The thin pool is created from
+	// the free space in the volume group if it does not exist.
+	LVMThinPool = "thin-pool"
+
+	// LVMThin determines whether logical volumes are thin-provisioned
+	// from the thin pool, or fully allocated from the volume group.
+	LVMThin = "thin"
+
+	defaultVolumeGroup = "juju"
+	defaultThinPool    = "juju-thinpool"
+
+	// thinPoolExtents is the proportion of the volume group's free
+	// space allocated to a new thin pool. Some space is left for the
+	// pool's metadata volume, and for growing the pool later.
+	thinPoolExtents = "90%FREE"
+)
+
+var lvmConfigFields = schema.Fields{
+	LVMVolumeGroup: schema.String(),
+	LVMThinPool:    schema.String(),
+	LVMThin:        schema.Bool(),
+}
+
+var lvmConfigChecker = schema.FieldMap(
+	lvmConfigFields,
+	schema.Defaults{
+		LVMVolumeGroup: defaultVolumeGroup,
+		LVMThinPool:    defaultThinPool,
+		LVMThin:        true,
+	},
+)
+
+type lvmConfig struct {
+	volumeGroup string
+	thinPool    string
+	thin        bool
+}
+
+func newLVMConfig(attrs map[string]interface{}) (*lvmConfig, error) {
+	out, err := lvmConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating LVM storage config")
+	}
+	coerced := out.(map[string]interface{})
+	lvmConfig := &lvmConfig{
+		volumeGroup: coerced[LVMVolumeGroup].(string),
+		thinPool:    coerced[LVMThinPool].(string),
+		thin:        coerced[LVMThin].(bool),
+	}
+	if lvmConfig.volumeGroup == "" {
+		return nil, errors.New("volume group not specified")
+	}
+	if lvmConfig.thin && lvmConfig.thinPool == "" {
+		return nil, errors.New("thin pool not specified")
+	}
+	return lvmConfig, nil
+}
+
+// LVMPoolVolumeGroup returns the name of the volume group that an lvm
+// storage pool with the specified attributes creates volumes in.
+func LVMPoolVolumeGroup(attrs map[string]interface{}) (string, error) {
+	cfg, err := newLVMConfig(attrs)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.volumeGroup, nil
+}
+
+// lvmProvider creates volume sources which use LVM logical volumes.
+type lvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*lvmProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lvmProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newLVMConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lvmProvider) VolumeSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.VolumeSource, error) {
+	// The volume group and thin pool are taken from the
+	// volume parameters, so the source is not bound to a
+	// particular pool.
+	return &lvmVolumeSource{p.run}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *lvmProvider) FilesystemSource(
+	environConfig *config.Config,
+	providerConfig *storage.Config,
+) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*lvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*lvmProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lvmProvider) Dynamic() bool {
+	return true
+}
+
+// lvmVolumeSource creates, attaches and destroys logical volumes
+// in volume groups on the local machine. Volume IDs have the form
+// "<volume-group>/<logical-volume>".
+type lvmVolumeSource struct {
+	run runCommandFunc
+}
+
+var _ storage.VolumeSource = (*lvmVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*lvmVolumeSource)(nil)
+var _ storage.VolumeResizer = (*lvmVolumeSource)(nil)
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) createVolume(params storage.VolumeParams) (*storage.Volume, error) {
+	cfg, err := newLVMConfig(params.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	lvName := params.Tag.String()
+	existing, err := logicalVolumes(s.run, cfg.volumeGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !existing.Contains(lvName) && params.SnapshotId != "" {
+		if err := s.createVolumeFromSnapshot(cfg, lvName, params); err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else if !existing.Contains(lvName) {
+		size := fmt.Sprintf("%dM", params.Size)
+		if cfg.thin {
+			if !existing.Contains(cfg.thinPool) {
+				if err := createThinPool(s.run, cfg.volumeGroup, cfg.thinPool); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			_, err = s.run(
+				"lvcreate", "--thin", "-V", size, "-n", lvName,
+				path.Join(cfg.volumeGroup, cfg.thinPool),
+			)
+		} else {
+			_, err = s.run("lvcreate", "-L", size, "-n", lvName, cfg.volumeGroup)
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating logical volume %q", lvName)
+		}
+	} else {
+		logger.Debugf("logical volume %s/%s already exists", cfg.volumeGroup, lvName)
+	}
+	volumeId := lvmVolumeId(cfg.volumeGroup, lvName)
+	size, err := logicalVolumeSize(s.run, volumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId:   volumeId,
+			Size:       size,
+			Persistent: true,
+		},
+	}, nil
+}
+
+// createVolumeFromSnapshot creates a logical volume with the given
+// name as a thin snapshot of the snapshot specified in the volume
+// parameters, and grows it to the requested size.
+func (s *lvmVolumeSource) createVolumeFromSnapshot(cfg *lvmConfig, lvName string, params storage.VolumeParams) error {
+	if !cfg.thin {
+		return errors.NotSupportedf("restoring snapshots to fully allocated volumes")
+	}
+	volumeGroup, _, err := parseLVMVolumeId(params.SnapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if volumeGroup != cfg.volumeGroup {
+		return errors.Errorf(
+			"snapshot %q is not in volume group %q",
+			params.SnapshotId, cfg.volumeGroup,
+		)
+	}
+	volumeId, err := s.createSnapshot(params.SnapshotId, lvName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = s.resizeVolume(volumeId, params.Size)
+	return errors.Trace(err)
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ListVolumes() ([]string, error) {
+	stdout, err := s.run("lvs", "--noheadings", "--separator", "/", "-o", "vg_name,lv_name")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing logical volumes")
+	}
+	var volumeIds []string
+	for _, line := range strings.Split(stdout, "\n") {
+		volumeId := strings.TrimSpace(line)
+		if volumeId == "" {
+			continue
+		}
+		_, lvName, err := parseLVMVolumeId(volumeId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := names.ParseVolumeTag(lvName); err != nil {
+			// Only logical volumes named after volume
+			// tags are managed by this volume source.
+			continue
+		}
+		volumeIds = append(volumeIds, volumeId)
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		size, err := logicalVolumeSize(s.run, volumeId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "describing volume %q", volumeId)
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId:   volumeId,
+			Size:       size,
+			Persistent: true,
+		}
+	}
+	return results, nil
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) destroyVolume(volumeId string) error {
+	volumeGroup, lvName, err := parseLVMVolumeId(volumeId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	existing, err := logicalVolumes(s.run, volumeGroup)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !existing.Contains(lvName) {
+		logger.Debugf("logical volume %s already removed", volumeId)
+		return nil
+	}
+	if !isVolumeSnapshotName(lvName) {
+		// Removing the origin of snapshots which are not thin
+		// would silently remove the snapshots too.
+		snapshots, err := cowSnapshots(s.run, volumeGroup, lvName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(snapshots) > 0 {
+			return errors.Errorf(
+				"logical volume has snapshots %s, which must be destroyed first",
+				strings.Join(snapshots, ", "),
+			)
+		}
+	}
+	if _, err := s.run("lvremove", "-f", volumeId); err != nil {
+		return errors.Annotate(err, "removing logical volume")
+	}
+	return nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// ValidateVolumeParams may be called on a machine other than the
+	// machine where the logical volume will be created, so we cannot
+	// check the volume group until we get to CreateVolumes.
+	_, err := newLVMConfig(params.Attributes)
+	return errors.Trace(err)
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	if _, _, err := parseLVMVolumeId(arg.VolumeId); err != nil {
+		return nil, errors.Trace(err)
+	}
+	permission := "rw"
+	if arg.ReadOnly {
+		permission = "r"
+	}
+	if _, err := s.run("lvchange", "-p", permission, arg.VolumeId); err != nil {
+		// lvchange fails if the permission is already set,
+		// so we only log the error here; activation will
+		// fail if there is a real problem.
+		logger.Debugf("setting permission on %s: %v", arg.VolumeId, err)
+	}
+	// -K activates thin snapshots, which are
+	// flagged to be skipped on activation.
+	if _, err := s.run("lvchange", "-a", "y", "-K", arg.VolumeId); err != nil {
+		return nil, errors.Annotate(err, "activating logical volume")
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceLink: path.Join("/dev", arg.VolumeId),
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachVolume(arg.VolumeId); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) detachVolume(volumeId string) error {
+	if _, _, err := parseLVMVolumeId(volumeId); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.run("lvchange", "-a", "n", volumeId); err != nil {
+		return errors.Annotate(err, "deactivating logical volume")
+	}
+	return nil
+}
+
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (s *lvmVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeVolume(arg.VolumeId, arg.Size)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "resizing volume")
+			continue
+		}
+		results[i].Volume = &storage.Volume{
+			arg.Tag,
+			storage.VolumeInfo{
+				VolumeId:   arg.VolumeId,
+				Size:       size,
+				Persistent: true,
+			},
+		}
+	}
+	return results, nil
+}
+
+// resizeVolume grows the logical volume with the specified ID to
+// the given size in mebibytes, and returns the new size. Logical
+// volumes are never shrunk.
+func (s *lvmVolumeSource) resizeVolume(volumeId string, size uint64) (uint64, error) {
+	if _, _, err := parseLVMVolumeId(volumeId); err != nil {
+		return 0, errors.Trace(err)
+	}
+	current, err := logicalVolumeSize(s.run, volumeId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if size <= current {
+		return current, nil
+	}
+	if _, err := s.run("lvextend", "-L", fmt.Sprintf("%dM", size), volumeId); err != nil {
+		return 0, errors.Annotatef(err, "extending logical volume %q", volumeId)
+	}
+	return logicalVolumeSize(s.run, volumeId)
+}
+
+// createSnapshot creates a snapshot with the given name of the
+// logical volume with the specified ID, and returns the ID of
+// the snapshot. Snapshots of thin volumes are themselves thin,
+// and share the thin pool with their origin; snapshots of fully
+// allocated volumes are allocated the size of their origin.
+func (s *lvmVolumeSource) createSnapshot(volumeId, name string) (string, error) {
+	volumeGroup, _, err := parseLVMVolumeId(volumeId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	stdout, err := s.run("lvs", "--noheadings", "-o", "segtype", volumeId)
+	if err != nil {
+		return "", errors.Annotatef(err, "getting segment type of %q", volumeId)
+	}
+	args := []string{"-s", "-n", name}
+	if strings.TrimSpace(stdout) != "thin" {
+		args = append(args, "-l", "100%ORIGIN")
+	}
+	args = append(args, volumeId)
+	if _, err := s.run("lvcreate", args...); err != nil {
+		return "", errors.Annotatef(err, "creating snapshot of %q", volumeId)
+	}
+	return lvmVolumeId(volumeGroup, name), nil
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (s *lvmVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume snapshot")
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) createVolumeSnapshot(params storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	volumeGroup, _, err := parseLVMVolumeId(params.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	name := storage.VolumeSnapshotName(params.Id)
+	if name == "" {
+		return nil, errors.Errorf("invalid volume snapshot ID %q", params.Id)
+	}
+	existing, err := logicalVolumes(s.run, volumeGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotId := lvmVolumeId(volumeGroup, name)
+	if !existing.Contains(name) {
+		if _, err := s.createSnapshot(params.VolumeId, name); err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		logger.Debugf("snapshot %s already exists", snapshotId)
+	}
+	size, err := logicalVolumeSize(s.run, snapshotId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeSnapshot{
+		params.Id,
+		params.Volume,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       size,
+		},
+	}, nil
+}
+
+// ListVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (s *lvmVolumeSource) ListVolumeSnapshots() ([]string, error) {
+	stdout, err := s.run("lvs", "--noheadings", "--separator", "/", "-o", "vg_name,lv_name")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing logical volumes")
+	}
+	var snapshotIds []string
+	for _, line := range strings.Split(stdout, "\n") {
+		snapshotId := strings.TrimSpace(line)
+		if snapshotId == "" {
+			continue
+		}
+		_, lvName, err := parseLVMVolumeId(snapshotId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !isVolumeSnapshotName(lvName) {
+			continue
+		}
+		snapshotIds = append(snapshotIds, snapshotId)
+	}
+	return snapshotIds, nil
+}
+
+// DestroyVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (s *lvmVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := s.destroyVolume(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+// isVolumeSnapshotName reports whether the logical volume name is
+// one given to volume snapshots by this volume source.
+func isVolumeSnapshotName(lvName string) bool {
+	i := strings.LastIndex(lvName, "-snapshot-")
+	if i < 0 {
+		return false
+	}
+	if _, err := names.ParseVolumeTag(lvName[:i]); err != nil {
+		return false
+	}
+	_, err := strconv.ParseUint(lvName[i+len("-snapshot-"):], 10, 64)
+	return err == nil
+}
+
+// createThinPool creates a thin pool with the given name in the
+// specified volume group, using the group's free space.
+func createThinPool(run runCommandFunc, volumeGroup, thinPool string) error {
+	_, err := run(
+		"lvcreate", "--type", "thin-pool",
+		"-l", thinPoolExtents, "-n", thinPool, volumeGroup,
+	)
+	if err != nil {
+		return errors.Annotatef(err, "creating thin pool %q", thinPool)
+	}
+	return nil
+}
+
+// logicalVolumes returns the names of the logical volumes in the
+// specified volume group.
+func logicalVolumes(run runCommandFunc, volumeGroup string) (set.Strings, error) {
+	stdout, err := run("lvs", "--noheadings", "-o", "lv_name", volumeGroup)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing logical volumes in %q", volumeGroup)
+	}
+	lvNames := set.NewStrings()
+	for _, line := range strings.Split(stdout, "\n") {
+		if lvName := strings.TrimSpace(line); lvName != "" {
+			lvNames.Add(lvName)
+		}
+	}
+	return lvNames, nil
+}
+
+// cowSnapshots returns the names of the snapshots of the specified
+// logical volume that are not thin, and so depend on their origin.
+func cowSnapshots(run runCommandFunc, volumeGroup, lvName string) ([]string, error) {
+	stdout, err := run("lvs", "--noheadings", "-o", "lv_name,origin,lv_attr", volumeGroup)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing snapshots in %q", volumeGroup)
+	}
+	var snapshots []string
+	for _, line := range strings.Split(stdout, "\n") {
+		// Logical volumes without an origin have only two fields.
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[1] != lvName {
+			continue
+		}
+		// The first attribute of a snapshot which is not thin is "s".
+		if strings.HasPrefix(fields[2], "s") {
+			snapshots = append(snapshots, fields[0])
+		}
+	}
+	return snapshots, nil
+}
+
+// logicalVolumeSize returns the size of the logical volume with
+// the specified ID, in mebibytes.
+func logicalVolumeSize(run runCommandFunc, volumeId string) (uint64, error) {
+	stdout, err := run(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m",
+		"-o", "lv_size", volumeId,
+	)
+	if err != nil {
+		return 0, errors.Annotatef(err, "getting size of %q", volumeId)
+	}
+	size, err := strconv.ParseFloat(strings.TrimSpace(stdout), 64)
+	if err != nil {
+		return 0, errors.Annotatef(err, "parsing size of %q", volumeId)
+	}
+	return uint64(math.Ceil(size)), nil
+}
+
+func lvmVolumeId(volumeGroup, lvName string) string {
+	return volumeGroup + "/" + lvName
+}
+
+// parseLVMVolumeId splits an LVM volume ID into its volume group
+// and logical volume names.
+func parseLVMVolumeId(volumeId string) (volumeGroup, lvName string, _ error) {
+	parts := strings.Split(volumeId, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("invalid LVM volume ID %q", volumeId)
+	}
+	return parts[0], parts[1], nil
+}
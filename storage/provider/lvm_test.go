@@ -0,0 +1,603 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lvmSuite{})
+
+type lvmSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+}
+
+func (s *lvmSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.commands = &mockRunCommand{c: c}
+}
+
+func (s *lvmSuite) TearDownTest(c *gc.C) {
+	s.commands.assertDrained()
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lvmSuite) lvmProvider() storage.Provider {
+	return provider.LVMProvider(s.commands.run)
+}
+
+func (s *lvmSuite) lvmVolumeSource() storage.VolumeSource {
+	return provider.LVMVolumeSource(s.commands.run)
+}
+
+func (s *lvmSuite) TestValidateConfig(c *gc.C) {
+	p := s.lvmProvider()
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "vg0",
+		"thin":         false,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestValidateConfigInvalid(c *gc.C) {
+	p := s.lvmProvider()
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, "volume group not specified")
+
+	cfg, err = storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"thin": "maybe",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating LVM storage config: thin: expected bool, got string\("maybe"\)`)
+}
+
+func (s *lvmSuite) TestPoolVolumeGroup(c *gc.C) {
+	volumeGroup, err := provider.LVMPoolVolumeGroup(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeGroup, gc.Equals, "juju")
+
+	volumeGroup, err = provider.LVMPoolVolumeGroup(map[string]interface{}{
+		"volume-group": "data",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeGroup, gc.Equals, "data")
+
+	_, err = provider.LVMPoolVolumeGroup(map[string]interface{}{
+		"volume-group": "",
+	})
+	c.Assert(err, gc.ErrorMatches, "volume group not specified")
+}
+
+func (s *lvmSuite) TestSupports(c *gc.C) {
+	p := s.lvmProvider()
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *lvmSuite) TestScope(c *gc.C) {
+	p := s.lvmProvider()
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *lvmSuite) TestFilesystemSource(c *gc.C) {
+	p := s.lvmProvider()
+	_, err := p.FilesystemSource(nil, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *lvmSuite) TestCreateVolumesThin(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond("  other\n", nil)
+	s.commands.expect("lvcreate", "--type", "thin-pool", "-l", "90%FREE", "-n", "juju-thinpool", "juju")
+	s.commands.expect("lvcreate", "--thin", "-V", "2M", "-n", "volume-0", "juju/juju-thinpool")
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0",
+	).respond("  2.00\n", nil)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       2,
+		Provider:   provider.LVMProviderType,
+		Attributes: map[string]interface{}{},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId:   "juju/volume-0",
+			Size:       2,
+			Persistent: true,
+		},
+	})
+}
+
+func (s *lvmSuite) TestCreateVolumesThinPoolExists(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "vg0").respond("  pool0\n", nil)
+	s.commands.expect("lvcreate", "--thin", "-V", "2M", "-n", "volume-0-1", "vg0/pool0")
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/volume-0-1",
+	).respond("  2.00\n", nil)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0/1"),
+		Size:     2,
+		Provider: provider.LVMProviderType,
+		Attributes: map[string]interface{}{
+			"volume-group": "vg0",
+			"thin-pool":    "pool0",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, "vg0/volume-0-1")
+}
+
+func (s *lvmSuite) TestCreateVolumesThick(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "vg0")
+	s.commands.expect("lvcreate", "-L", "3M", "-n", "volume-0", "vg0")
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/volume-0",
+	).respond("  4.00\n", nil)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     3,
+		Provider: provider.LVMProviderType,
+		Attributes: map[string]interface{}{
+			"volume-group": "vg0",
+			"thin":         false,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	// The volume's size is reported as allocated, rounded up
+	// to the volume group's extent size.
+	c.Assert(results[0].Volume.Size, gc.Equals, uint64(4))
+}
+
+func (s *lvmSuite) TestCreateVolumesExisting(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond(
+		"  juju-thinpool\n  volume-0\n", nil,
+	)
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0",
+	).respond("  2.00\n", nil)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     2,
+		Provider: provider.LVMProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, "juju/volume-0")
+}
+
+func (s *lvmSuite) TestCreateVolumesError(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond(
+		"", errors.New("Volume group \"juju\" not found"),
+	)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     2,
+		Provider: provider.LVMProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`creating volume: listing logical volumes in "juju": Volume group "juju" not found`,
+	)
+	c.Assert(results[0].Volume, gc.IsNil)
+}
+
+func (s *lvmSuite) TestListVolumes(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect(
+		"lvs", "--noheadings", "--separator", "/", "-o", "vg_name,lv_name",
+	).respond("  juju/juju-thinpool\n  juju/volume-0\n  vg0/volume-1-2\n  vg0/root\n", nil)
+
+	volumeIds, err := source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"juju/volume-0", "vg0/volume-1-2"})
+}
+
+func (s *lvmSuite) TestDescribeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0",
+	).respond("  1024.00\n", nil)
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-1",
+	).respond("", errors.New("Failed to find logical volume"))
+
+	results, err := source.DescribeVolumes([]string{"juju/volume-0", "juju/volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   "juju/volume-0",
+		Size:       1024,
+		Persistent: true,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		`describing volume "juju/volume-1": getting size of "juju/volume-1": Failed to find logical volume`,
+	)
+}
+
+func (s *lvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond("  volume-0\n", nil)
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name,origin,lv_attr", "juju").respond(
+		"  volume-0             -wi-a-----\n", nil,
+	)
+	s.commands.expect("lvremove", "-f", "juju/volume-0")
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond("", nil)
+
+	errs, err := source.DestroyVolumes([]string{"juju/volume-0", "juju/volume-1", "volume-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	// Destroying a logical volume that no longer exists succeeds.
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying "volume-2": invalid LVM volume ID "volume-2"`)
+}
+
+func (s *lvmSuite) TestDestroyVolumesWithSnapshots(c *gc.C) {
+	source := s.lvmVolumeSource()
+	lvs := "" +
+		"  volume-0                       -wi-ao----\n" +
+		"  volume-0-snapshot-0 volume-0   swi-a-s---\n" +
+		"  volume-1                       Vwi-a-tz--\n" +
+		"  volume-1-snapshot-0 volume-1   Vwi---tz-k\n"
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond("  volume-0\n  volume-1\n", nil)
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name,origin,lv_attr", "juju").respond(lvs, nil)
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond("  volume-0\n  volume-1\n", nil)
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name,origin,lv_attr", "juju").respond(lvs, nil)
+	// Thin snapshots do not depend on their origin.
+	s.commands.expect("lvremove", "-f", "juju/volume-1")
+
+	errs, err := source.DestroyVolumes([]string{"juju/volume-0", "juju/volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], gc.ErrorMatches, `destroying "juju/volume-0": logical volume has snapshots volume-0-snapshot-0, which must be destroyed first`)
+	c.Assert(errs[1], jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestValidateVolumeParams(c *gc.C) {
+	source := s.lvmVolumeSource()
+	err := source.ValidateVolumeParams(storage.VolumeParams{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       2,
+		Attributes: map[string]interface{}{"thin-pool": ""},
+	})
+	c.Assert(err, gc.ErrorMatches, "thin pool not specified")
+}
+
+func (s *lvmSuite) TestAttachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvchange", "-p", "rw", "juju/volume-0")
+	s.commands.expect("lvchange", "-a", "y", "-K", "juju/volume-0")
+	s.commands.expect("lvchange", "-p", "r", "juju/volume-1")
+	s.commands.expect("lvchange", "-a", "y", "-K", "juju/volume-1")
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju/volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "juju/volume-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/juju/volume-0",
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/juju/volume-1",
+				ReadOnly:   true,
+			},
+		},
+	}})
+}
+
+func (s *lvmSuite) TestAttachVolumesActivateError(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvchange", "-p", "rw", "juju/volume-0").respond(
+		"", errors.New(`Logical volume "volume-0" is already writable`),
+	)
+	s.commands.expect("lvchange", "-a", "y", "-K", "juju/volume-0").respond(
+		"", errors.New("Failed to find logical volume"),
+	)
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju/volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		"attaching volume 0: activating logical volume: Failed to find logical volume",
+	)
+}
+
+func (s *lvmSuite) TestDetachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvchange", "-a", "n", "juju/volume-0")
+
+	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju/volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
+func (s *lvmSuite) TestResizeVolume(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0",
+	).respond("  1024.00\n", nil)
+	s.commands.expect("lvextend", "-L", "2048M", "juju/volume-0")
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0",
+	).respond("  2048.00\n", nil)
+
+	size, err := provider.ResizeLVMVolume(source, "juju/volume-0", 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, uint64(2048))
+}
+
+func (s *lvmSuite) TestResizeVolumeNoShrink(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0",
+	).respond("  1024.00\n", nil)
+
+	size, err := provider.ResizeLVMVolume(source, "juju/volume-0", 512)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, uint64(1024))
+}
+
+func (s *lvmSuite) TestResizeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0",
+	).respond("  1024.00\n", nil)
+	s.commands.expect("lvextend", "-L", "2048M", "juju/volume-0")
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0",
+	).respond("  2048.00\n", nil)
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "juju/volume-0",
+		Size:     2048,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeVolumesResult{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("0"),
+			storage.VolumeInfo{
+				VolumeId:   "juju/volume-0",
+				Size:       2048,
+				Persistent: true,
+			},
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `resizing volume: invalid LVM volume ID "volume-1"`)
+}
+
+func (s *lvmSuite) TestCreateSnapshotThin(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "segtype", "juju/volume-0").respond("  thin\n", nil)
+	s.commands.expect("lvcreate", "-s", "-n", "snap0", "juju/volume-0")
+
+	snapshotId, err := provider.CreateLVMSnapshot(source, "juju/volume-0", "snap0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotId, gc.Equals, "juju/snap0")
+}
+
+func (s *lvmSuite) TestCreateSnapshotThick(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "segtype", "vg0/volume-0").respond("  linear\n", nil)
+	s.commands.expect("lvcreate", "-s", "-n", "snap0", "-l", "100%ORIGIN", "vg0/volume-0")
+
+	snapshotId, err := provider.CreateLVMSnapshot(source, "vg0/volume-0", "snap0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotId, gc.Equals, "vg0/snap0")
+}
+
+func (s *lvmSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond(
+		"  juju-thinpool\n  volume-0-snapshot-1\n", nil,
+	)
+	s.commands.expect("lvs", "--noheadings", "-o", "segtype", "juju/volume-0-snapshot-1").respond("  thin\n", nil)
+	s.commands.expect("lvcreate", "-s", "-n", "volume-1", "juju/volume-0-snapshot-1")
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-1",
+	).respond("  1024.00\n", nil)
+	s.commands.expect("lvextend", "-L", "2048M", "juju/volume-1")
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-1",
+	).respond("  2048.00\n", nil)
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-1",
+	).respond("  2048.00\n", nil)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("1"),
+		Size:       2048,
+		Provider:   provider.LVMProviderType,
+		SnapshotId: "juju/volume-0-snapshot-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("1"),
+		storage.VolumeInfo{
+			VolumeId:   "juju/volume-1",
+			Size:       2048,
+			Persistent: true,
+		},
+	})
+}
+
+func (s *lvmSuite) TestCreateVolumesFromSnapshotThick(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "vg0").respond("  volume-0-snapshot-1\n", nil)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		Size:     2048,
+		Provider: provider.LVMProviderType,
+		Attributes: map[string]interface{}{
+			"volume-group": "vg0",
+			"thin":         false,
+		},
+		SnapshotId: "vg0/volume-0-snapshot-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating volume: restoring snapshots to fully allocated volumes not supported")
+}
+
+func (s *lvmSuite) TestCreateVolumesFromSnapshotOtherVolumeGroup(c *gc.C) {
+	source := s.lvmVolumeSource()
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond("  juju-thinpool\n", nil)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("1"),
+		Size:       2048,
+		Provider:   provider.LVMProviderType,
+		SnapshotId: "vg0/volume-0-snapshot-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume: snapshot "vg0/volume-0-snapshot-1" is not in volume group "juju"`)
+}
+
+func (s *lvmSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	snapshotter, ok := s.lvmVolumeSource().(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond("  volume-0-1\n", nil)
+	s.commands.expect("lvs", "--noheadings", "-o", "segtype", "juju/volume-0-1").respond("  thin\n", nil)
+	s.commands.expect("lvcreate", "-s", "-n", "volume-0-1-snapshot-2", "juju/volume-0-1")
+	s.commands.expect(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "juju/volume-0-1-snapshot-2",
+	).respond("  1024.00\n", nil)
+
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0/1@2",
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "juju/volume-0-1",
+		Size:     1024,
+		Provider: provider.LVMProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		"0/1@2",
+		names.NewVolumeTag("0/1"),
+		storage.VolumeSnapshotInfo{
+			SnapshotId: "juju/volume-0-1-snapshot-2",
+			Size:       1024,
+		},
+	})
+}
+
+func (s *lvmSuite) TestCreateVolumeSnapshotsInvalidId(c *gc.C) {
+	snapshotter := s.lvmVolumeSource().(storage.VolumeSnapshotter)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0/1",
+		Volume:   names.NewVolumeTag("0/1"),
+		VolumeId: "juju/volume-0-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume snapshot: invalid volume snapshot ID "0/1"`)
+}
+
+func (s *lvmSuite) TestListVolumeSnapshots(c *gc.C) {
+	snapshotter := s.lvmVolumeSource().(storage.VolumeSnapshotter)
+	s.commands.expect(
+		"lvs", "--noheadings", "--separator", "/", "-o", "vg_name,lv_name",
+	).respond(
+		"  juju/juju-thinpool\n  juju/volume-0\n  juju/volume-0-snapshot-0\n"+
+			"  vg0/volume-1-2-snapshot-3\n  vg0/root-snapshot-1\n", nil,
+	)
+
+	snapshotIds, err := snapshotter.ListVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotIds, jc.DeepEquals, []string{"juju/volume-0-snapshot-0", "vg0/volume-1-2-snapshot-3"})
+}
+
+func (s *lvmSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	snapshotter := s.lvmVolumeSource().(storage.VolumeSnapshotter)
+	s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju").respond("  volume-0-snapshot-0\n", nil)
+	s.commands.expect("lvremove", "-f", "juju/volume-0-snapshot-0")
+
+	errs, err := snapshotter.DestroyVolumeSnapshots([]string{"juju/volume-0-snapshot-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
@@ -47,4 +47,9 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the storage attachment, in MiB: the size
+	// of the filesystem for a filesystem-kind storage attachment,
+	// and the size of the block device for a block-kind.
+	Size uint64
 }
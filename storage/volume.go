@@ -3,7 +3,12 @@
 
 package storage
 
-import "github.com/juju/names"
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/names"
+)
 
 // Volume identifies and describes a volume (disk, logical volume, etc.)
 type Volume struct {
@@ -73,3 +78,41 @@ type VolumeAttachmentInfo struct {
 	// ReadOnly signifies whether the volume is read only or writable.
 	ReadOnly bool
 }
+
+// VolumeSnapshot identifies and describes a snapshot of a volume.
+type VolumeSnapshot struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju for the volume
+	// that the snapshot was taken of.
+	Volume names.VolumeTag
+
+	VolumeSnapshotInfo
+}
+
+// VolumeSnapshotInfo describes a snapshot of a volume.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the volume that the snapshot was taken
+	// of, in MiB. Volumes created from the snapshot must be at least
+	// this size.
+	Size uint64
+}
+
+// VolumeSnapshotName returns a name for the volume snapshot with the
+// specified Juju-assigned ID, suitable for naming provider resources.
+// Volume snapshot IDs have the form "<volume-id>@<sequence>"; e.g. the
+// name of the snapshot "0/1@2" is "volume-0-1-snapshot-2".
+func VolumeSnapshotName(id string) string {
+	at := strings.LastIndex(id, "@")
+	if at == -1 || !names.IsValidVolume(id[:at]) {
+		return ""
+	}
+	if _, err := strconv.ParseUint(id[at+1:], 10, 64); err != nil {
+		return ""
+	}
+	return names.NewVolumeTag(id[:at]).String() + "-snapshot-" + id[at+1:]
+}
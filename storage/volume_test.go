@@ -0,0 +1,31 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+)
+
+type VolumeSnapshotNameSuite struct{}
+
+var _ = gc.Suite(&VolumeSnapshotNameSuite{})
+
+func (s *VolumeSnapshotNameSuite) TestVolumeSnapshotName(c *gc.C) {
+	for _, test := range []struct {
+		id, name string
+	}{
+		{"0@0", "volume-0-snapshot-0"},
+		{"0/1@2", "volume-0-1-snapshot-2"},
+		{"0/1", ""},
+		{"0/1@", ""},
+		{"0/1@x", ""},
+		{"foo@1", ""},
+		{"", ""},
+	} {
+		c.Logf("%q", test.id)
+		c.Check(storage.VolumeSnapshotName(test.id), gc.Equals, test.name)
+	}
+}
@@ -37,6 +37,10 @@ var (
 
 	// Other valid test certs different from the default.
 	OtherCACert, OtherCAKey = mustNewCA()
+
+	// SecretsKey holds the key with which state encrypts the values
+	// of secrets in tests.
+	SecretsKey = []byte("0123456789abcdef0123456789abcdef")
 )
 
 func verifyCertificates() error {
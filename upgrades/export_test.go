@@ -20,3 +20,5 @@ func UpgradeModelConfig(
 ) error {
 	return upgradeModelConfig(reader, updater, registry)
 }
+
+var EnsureSecretsKey = ensureSecretsKey
@@ -19,6 +19,10 @@ var stateUpgradeOperations = func() []Operation {
 			version.MustParse("1.26.0"),
 			stateStepsFor126(),
 		},
+		upgradeToVersion{
+			version.MustParse("2.0.0"),
+			stateStepsFor20(),
+		},
 	}
 	return steps
 }
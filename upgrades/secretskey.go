@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/agent"
+)
+
+// secretsKeyLabel distinguishes the secrets key from anything else
+// which might be derived from the replica set's shared secret.
+const secretsKeyLabel = "juju secrets key"
+
+// SecretsKey returns the key with which the controller with the given
+// agent config encrypts the values of secrets. Controllers bootstrapped
+// before secrets were introduced have no key in their config, so for
+// them it is derived from the replica set's shared secret, which every
+// controller holds and which is never stored in the database. That way
+// every controller arrives at the same key without it being copied
+// between them.
+func SecretsKey(agentConfig agent.Config) ([]byte, error) {
+	if value := agentConfig.Value(agent.SecretsKey); value != "" {
+		key, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot decode secrets key")
+		}
+		return key, nil
+	}
+	info, ok := agentConfig.StateServingInfo()
+	if !ok || info.SharedSecret == "" {
+		return nil, errors.New("controller has no secrets key and no shared secret to derive one from")
+	}
+	mac := hmac.New(sha256.New, []byte(info.SharedSecret))
+	mac.Write([]byte(secretsKeyLabel))
+	return mac.Sum(nil), nil
+}
+
+// ensureSecretsKey writes the secrets key to the agent config of a
+// controller which has none.
+func ensureSecretsKey(context Context) error {
+	agentConfig := context.AgentConfig()
+	if agentConfig.Value(agent.SecretsKey) != "" {
+		return nil
+	}
+	key, err := SecretsKey(agentConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	agentConfig.SetValue(agent.SecretsKey, base64.StdEncoding.EncodeToString(key))
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	"encoding/base64"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/upgrades"
+)
+
+type secretsKeySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&secretsKeySuite{})
+
+func (s *secretsKeySuite) TestSecretsKeyFromConfig(c *gc.C) {
+	config := &mockAgentConfig{values: map[string]string{
+		agent.SecretsKey: base64.StdEncoding.EncodeToString(testing.SecretsKey),
+	}}
+	key, err := upgrades.SecretsKey(config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, jc.DeepEquals, testing.SecretsKey)
+}
+
+func (s *secretsKeySuite) TestSecretsKeyDerivedFromSharedSecret(c *gc.C) {
+	config0 := &mockAgentConfig{servingInfo: params.StateServingInfo{SharedSecret: "foo"}}
+	key0, err := upgrades.SecretsKey(config0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key0, gc.HasLen, state.SecretsKeyLength)
+
+	// Every controller shares the secret, so derives the same key.
+	config1 := &mockAgentConfig{servingInfo: params.StateServingInfo{SharedSecret: "foo"}}
+	key1, err := upgrades.SecretsKey(config1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key1, jc.DeepEquals, key0)
+
+	config2 := &mockAgentConfig{servingInfo: params.StateServingInfo{SharedSecret: "bar"}}
+	key2, err := upgrades.SecretsKey(config2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key2, gc.Not(jc.DeepEquals), key0)
+}
+
+func (s *secretsKeySuite) TestSecretsKeyMissing(c *gc.C) {
+	_, err := upgrades.SecretsKey(&mockAgentConfig{})
+	c.Assert(err, gc.ErrorMatches, "controller has no secrets key and no shared secret to derive one from")
+}
+
+func (s *secretsKeySuite) TestEnsureSecretsKey(c *gc.C) {
+	config := &mockAgentConfig{servingInfo: params.StateServingInfo{SharedSecret: "foo"}}
+	expected, err := upgrades.SecretsKey(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = upgrades.EnsureSecretsKey(upgrades.NewContext(config, nil, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.values, jc.DeepEquals, map[string]string{
+		agent.SecretsKey: base64.StdEncoding.EncodeToString(expected),
+	})
+
+	// The key is only written once.
+	config.servingInfo.SharedSecret = "bar"
+	err = upgrades.EnsureSecretsKey(upgrades.NewContext(config, nil, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.values, jc.DeepEquals, map[string]string{
+		agent.SecretsKey: base64.StdEncoding.EncodeToString(expected),
+	})
+}
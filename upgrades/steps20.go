@@ -0,0 +1,15 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+// stateStepsFor20 returns upgrade steps for Juju 2.0 that manipulate state directly.
+func stateStepsFor20() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "add secrets key to controller agent config",
+			targets:     []Target{Controller},
+			run:         ensureSecretsKey,
+		},
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodelrelations provides a worker that keeps the relations
+// between a model's services and the services it has consumed from other
+// models in agreement with their mirrors in the offering models.
+package crossmodelrelations
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.crossmodelrelations")
+
+// Facade represents the ability to watch for and sync changes to a
+// model's cross-model relations. It is implemented by
+// *api/remoterelations.API.
+type Facade interface {
+	SyncRemoteRelations() error
+	WatchRemoteRelations() (watcher.NotifyWatcher, error)
+}
+
+// Config holds all necessary attributes to start a cross-model
+// relations worker.
+type Config struct {
+	Facade Facade
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that syncs the model's cross-model
+// relations whenever they, or their mirrors, change. A failure to sync
+// stops the worker with an error, so that the sync is retried when the
+// worker is restarted; the relations with other offering models will
+// have been synced regardless.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &handler{facade: conf.Facade},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// handler implements watcher.NotifyHandler.
+type handler struct {
+	facade Facade
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *handler) SetUp() (watcher.NotifyWatcher, error) {
+	return h.facade.WatchRemoteRelations()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *handler) Handle(_ <-chan struct{}) error {
+	if err := h.facade.SyncRemoteRelations(); err != nil {
+		return errors.Annotate(err, "cannot sync cross-model relations")
+	}
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *handler) TearDown() error {
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelrelations_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"launchpad.net/tomb"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/crossmodelrelations"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	_, err := crossmodelrelations.New(crossmodelrelations.Config{})
+	c.Check(err, gc.ErrorMatches, "missing Facade")
+}
+
+func (s *workerSuite) TestWorkerSyncsOnChange(c *gc.C) {
+	facade := &fakeFacade{
+		calls:   make(chan string, 1),
+		watcher: newMockNotifyWatcher(),
+	}
+	w, err := crossmodelrelations.New(crossmodelrelations.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	})
+	facade.assertCall(c, "WatchRemoteRelations")
+
+	for i := 0; i < 2; i++ {
+		facade.watcher.changes <- struct{}{}
+		facade.assertCall(c, "SyncRemoteRelations")
+	}
+}
+
+func (s *workerSuite) TestSyncError(c *gc.C) {
+	facade := &fakeFacade{
+		calls:   make(chan string, 1),
+		err:     errors.New("boom"),
+		watcher: newMockNotifyWatcher(),
+	}
+	w, err := crossmodelrelations.New(crossmodelrelations.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	facade.assertCall(c, "WatchRemoteRelations")
+
+	facade.watcher.changes <- struct{}{}
+	facade.assertCall(c, "SyncRemoteRelations")
+	err = w.Wait()
+	c.Assert(err, gc.ErrorMatches, "cannot sync cross-model relations: boom")
+}
+
+func (s *workerSuite) TestWatchError(c *gc.C) {
+	facade := &fakeFacade{
+		calls:    make(chan string, 1),
+		watchErr: errors.New("boom"),
+	}
+	w, err := crossmodelrelations.New(crossmodelrelations.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	facade.assertCall(c, "WatchRemoteRelations")
+	err = worker.Stop(w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockNotifyWatcher struct {
+	watcher.NotifyWatcher
+
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{})}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+type fakeFacade struct {
+	calls    chan string
+	err      error
+	watchErr error
+	watcher  *mockNotifyWatcher
+}
+
+// SyncRemoteRelations implements Facade.
+func (f *fakeFacade) SyncRemoteRelations() error {
+	f.calls <- "SyncRemoteRelations"
+	return f.err
+}
+
+// WatchRemoteRelations implements Facade.
+func (f *fakeFacade) WatchRemoteRelations() (watcher.NotifyWatcher, error) {
+	f.calls <- "WatchRemoteRelations"
+	if f.watchErr != nil {
+		return nil, f.watchErr
+	}
+	return f.watcher, nil
+}
+
+func (f *fakeFacade) assertCall(c *gc.C, expect string) {
+	select {
+	case call := <-f.calls:
+		c.Assert(call, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s", expect)
+	}
+}
@@ -23,6 +23,11 @@ const (
 
 	// bytesInMiB is the number of bytes in a MiB.
 	bytesInMiB = 1024 * 1024
+
+	// usedSizeChangeThreshold is the fraction of a block device's size
+	// by which the used size of its filesystem must change before the
+	// block devices are recorded again.
+	usedSizeChangeThreshold = 0.01
 )
 
 // BlockDeviceSetter is an interface that is supplied to
@@ -55,7 +60,7 @@ func doWork(listf ListBlockDevicesFunc, b BlockDeviceSetter, old *[]storage.Bloc
 		return err
 	}
 	storage.SortBlockDevices(blockDevices)
-	if reflect.DeepEqual(blockDevices, *old) {
+	if !blockDevicesChanged(blockDevices, *old) {
 		logger.Tracef("no changes to block devices detected")
 		return nil
 	}
@@ -66,3 +71,41 @@ func doWork(listf ListBlockDevicesFunc, b BlockDeviceSetter, old *[]storage.Bloc
 	*old = blockDevices
 	return nil
 }
+
+// blockDevicesChanged reports whether the block devices have changed
+// since they were last recorded. Changes to the used size of mounted
+// filesystems are only considered significant if they exceed
+// usedSizeChangeThreshold of the block device's size, so that usage
+// changes do not cause block devices to be recorded at every listing.
+func blockDevicesChanged(new, old []storage.BlockDevice) bool {
+	if len(new) != len(old) {
+		return true
+	}
+	for i, dev := range new {
+		oldDev := old[i]
+		if !usedSizeChanged(dev, oldDev) {
+			// Ignore insignificant usage changes.
+			oldDev.UsedSize = dev.UsedSize
+		}
+		if !reflect.DeepEqual(dev, oldDev) {
+			return true
+		}
+	}
+	return false
+}
+
+// usedSizeChanged reports whether the used size of the block device
+// has changed significantly.
+func usedSizeChanged(new, old storage.BlockDevice) bool {
+	if new.UsedSize == old.UsedSize {
+		return false
+	}
+	if new.UsedSize == 0 || old.UsedSize == 0 {
+		return true
+	}
+	delta := new.UsedSize - old.UsedSize
+	if old.UsedSize > new.UsedSize {
+		delta = old.UsedSize - new.UsedSize
+	}
+	return float64(delta) >= float64(new.Size)*usedSizeChangeThreshold
+}
@@ -82,6 +82,33 @@ func (s *DiskManagerWorkerSuite) TestBlockDeviceChanges(c *gc.C) {
 	}})
 }
 
+func (s *DiskManagerWorkerSuite) TestBlockDeviceUsedSizeChanges(c *gc.C) {
+	var oldDevices []storage.BlockDevice
+	var devicesSet [][]storage.BlockDevice
+	var setDevices BlockDeviceSetterFunc = func(devices []storage.BlockDevice) error {
+		devicesSet = append(devicesSet, devices)
+		return nil
+	}
+
+	// Changes to the used size of less than 1% of the
+	// block device's size are ignored.
+	for _, usedSize := range []uint64{1000, 1009, 991, 1010} {
+		var listDevices diskmanager.ListBlockDevicesFunc = func() ([]storage.BlockDevice, error) {
+			return []storage.BlockDevice{{
+				DeviceName: "sda",
+				Size:       1000,
+				MountPoint: "/srv",
+				UsedSize:   usedSize,
+			}}, nil
+		}
+		err := diskmanager.DoWork(listDevices, setDevices, &oldDevices)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(devicesSet, gc.HasLen, 2)
+	c.Assert(devicesSet[0][0].UsedSize, gc.Equals, uint64(1000))
+	c.Assert(devicesSet[1][0].UsedSize, gc.Equals, uint64(1010))
+}
+
 func (s *DiskManagerWorkerSuite) TestBlockDevicesSorted(c *gc.C) {
 	var devicesSet [][]storage.BlockDevice
 	var setDevices BlockDeviceSetterFunc = func(devices []storage.BlockDevice) error {
@@ -15,6 +15,10 @@ var blockDeviceInUse = func(storage.BlockDevice) (bool, error) {
 	panic("not supported")
 }
 
+var filesystemUsedSize = func(string) (uint64, error) {
+	panic("not supported")
+}
+
 func listBlockDevices() ([]storage.BlockDevice, error) {
 	// Return an empty list each time.
 	return nil, nil
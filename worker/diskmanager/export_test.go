@@ -4,8 +4,9 @@
 package diskmanager
 
 var (
-	ListBlockDevices = listBlockDevices
-	BlockDeviceInUse = &blockDeviceInUse
-	DoWork           = doWork
-	NewWorkerFunc    = newWorker
+	ListBlockDevices   = listBlockDevices
+	BlockDeviceInUse   = &blockDeviceInUse
+	FilesystemUsedSize = &filesystemUsedSize
+	DoWork             = doWork
+	NewWorkerFunc      = newWorker
 )
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -27,7 +28,9 @@ const (
 	// values for the TYPE column that we care about
 
 	typeDisk = "disk"
+	typePart = "part"
 	typeLoop = "loop"
+	typeLVM  = "lvm"
 )
 
 func init() {
@@ -92,11 +95,15 @@ func listBlockDevices() ([]storage.BlockDevice, error) {
 			}
 		}
 
-		// We may later want to expand this, e.g. to handle lvm,
+		// We may later want to expand this, e.g. to handle
 		// dmraid, crypt, etc., but this is enough to cover bases
-		// for now.
+		// for now. Partitions are reported so that partitioned
+		// disks, and physical volumes on partitions, can be
+		// identified; logical volumes so that volumes created by
+		// the lvm storage provider can be matched by their device
+		// links.
 		switch deviceType {
-		case typeDisk, typeLoop:
+		case typeDisk, typePart, typeLoop, typeLVM:
 		default:
 			logger.Tracef("ignoring %q type device: %+v", deviceType, dev)
 			continue
@@ -126,11 +133,30 @@ func listBlockDevices() ([]storage.BlockDevice, error) {
 				dev.DeviceName, err,
 			)
 		}
+
+		// Record the space used in the mounted filesystem, so that
+		// storage usage can be reported.
+		if dev.MountPoint != "" {
+			dev.UsedSize, err = filesystemUsedSize(dev.MountPoint)
+			if err != nil {
+				logger.Errorf(
+					"error getting used size of %q mounted at %q: %v",
+					dev.DeviceName, dev.MountPoint, err,
+				)
+			}
+		}
 		devices = append(devices, dev)
 	}
 	if err := s.Err(); err != nil {
 		return nil, errors.Annotate(err, "cannot parse lsblk output")
 	}
+
+	// Record the volume group and free space of LVM physical
+	// volumes, so that the capacity available to the lvm storage
+	// provider can be reported.
+	if err := addPhysicalVolumeInfo(devices); err != nil {
+		logger.Debugf("cannot get LVM physical volume info: %v", err)
+	}
 	return devices, nil
 }
 
@@ -160,6 +186,16 @@ var blockDeviceInUse = func(dev storage.BlockDevice) (bool, error) {
 	return false, err
 }
 
+// filesystemUsedSize returns the amount of space used in the filesystem
+// mounted at the specified path, in MiB.
+var filesystemUsedSize = func(mountPoint string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(mountPoint, &st); err != nil {
+		return 0, err
+	}
+	return (st.Blocks - st.Bfree) * uint64(st.Bsize) / bytesInMiB, nil
+}
+
 // addHardwareInfo adds additional information about the hardware, and how it is
 // attached to the machine, to the given BlockDevice.
 func addHardwareInfo(dev *storage.BlockDevice) error {
@@ -242,3 +278,109 @@ func addHardwareInfo(dev *storage.BlockDevice) error {
 
 	return nil
 }
+
+// addPhysicalVolumeInfo adds the volume group and free space of the
+// LVM physical volumes on the machine to the corresponding block
+// devices.
+func addPhysicalVolumeInfo(devices []storage.BlockDevice) error {
+	logger.Tracef("executing pvs")
+	output, err := exec.Command(
+		"pvs",
+		"--noheadings",
+		"--nosuffix",
+		"--units", "b", // output size in bytes
+		"--separator", ":",
+		"-o", "pv_name,vg_name,pv_free",
+	).Output()
+	if err != nil {
+		return errors.Annotate(err, "pvs failed")
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(output))
+	for s.Scan() {
+		fields := strings.Split(strings.TrimSpace(s.Text()), ":")
+		if len(fields) != 3 || fields[1] == "" {
+			// Physical volumes that are not in a volume
+			// group have no space available to the provider.
+			continue
+		}
+		pvName, volumeGroup := fields[0], fields[1]
+		free, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			logger.Errorf("invalid free size %q from pvs: %v", fields[2], err)
+			continue
+		}
+		// The physical volume may be named by a symlink to the
+		// device, e.g. /dev/mapper/<name> for a dm device.
+		if path, err := filepath.EvalSymlinks(pvName); err == nil {
+			pvName = path
+		}
+		deviceName := strings.TrimPrefix(pvName, "/dev/")
+		for i, dev := range devices {
+			if dev.DeviceName == deviceName {
+				devices[i].VolumeGroup = volumeGroup
+				devices[i].FreeSize = free / bytesInMiB
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return errors.Annotate(err, "cannot parse pvs output")
+	}
+	if err := addThinPoolInfo(devices); err != nil {
+		logger.Debugf("cannot get LVM thin pool info: %v", err)
+	}
+	return nil
+}
+
+// addThinPoolInfo adds the unused data space of the LVM thin pools
+// on the machine to the free space of the first physical volume of
+// each thin pool's volume group. Thin volumes are allocated from
+// the thin pool rather than the volume group, so the space left in
+// the thin pool is available to the provider too.
+func addThinPoolInfo(devices []storage.BlockDevice) error {
+	logger.Tracef("executing lvs")
+	output, err := exec.Command(
+		"lvs",
+		"--noheadings",
+		"--nosuffix",
+		"--units", "b", // output size in bytes
+		"--separator", ":",
+		"-o", "vg_name,segtype,data_percent,lv_size",
+	).Output()
+	if err != nil {
+		return errors.Annotate(err, "lvs failed")
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(output))
+	for s.Scan() {
+		fields := strings.Split(strings.TrimSpace(s.Text()), ":")
+		if len(fields) != 4 || fields[1] != "thin-pool" {
+			continue
+		}
+		volumeGroup := fields[0]
+		dataPercent, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			logger.Errorf("invalid data percent %q from lvs: %v", fields[2], err)
+			continue
+		}
+		size, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			logger.Errorf("invalid size %q from lvs: %v", fields[3], err)
+			continue
+		}
+		if dataPercent > 100 {
+			dataPercent = 100
+		}
+		free := uint64(float64(size) * (100 - dataPercent) / 100)
+		for i, dev := range devices {
+			if dev.VolumeGroup == volumeGroup {
+				devices[i].FreeSize += free / bytesInMiB
+				break
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return errors.Annotate(err, "cannot parse lvs output")
+	}
+	return nil
+}
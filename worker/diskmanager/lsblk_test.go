@@ -30,7 +30,12 @@ func (s *ListBlockDevicesSuite) SetUpTest(c *gc.C) {
 	s.PatchValue(diskmanager.BlockDeviceInUse, func(storage.BlockDevice) (bool, error) {
 		return false, nil
 	})
+	s.PatchValue(diskmanager.FilesystemUsedSize, func(string) (uint64, error) {
+		return 0, nil
+	})
 	testing.PatchExecutable(c, s, "udevadm", `#!/bin/bash --norc`)
+	testing.PatchExecutable(c, s, "pvs", `#!/bin/bash --norc`)
+	testing.PatchExecutable(c, s, "lvs", `#!/bin/bash --norc`)
 }
 
 func (s *ListBlockDevicesSuite) TestListBlockDevices(c *gc.C) {
@@ -175,6 +180,140 @@ EOF`)
 	}})
 }
 
+func (s *ListBlockDevicesSuite) TestListBlockDevicesUsedSize(c *gc.C) {
+	var mountPoints []string
+	s.PatchValue(diskmanager.FilesystemUsedSize, func(mountPoint string) (uint64, error) {
+		mountPoints = append(mountPoints, mountPoint)
+		if mountPoint == "/srv/broken" {
+			return 0, errors.New("badness")
+		}
+		return 42, nil
+	})
+	testing.PatchExecutable(c, s, "lsblk", `#!/bin/bash --norc
+cat <<EOF
+KNAME="sda" SIZE="240057409536" LABEL="" UUID="" TYPE="disk"
+KNAME="sdb" SIZE="1048576" LABEL="" UUID="" MOUNTPOINT="/srv/data" TYPE="disk"
+KNAME="sdc" SIZE="1048576" LABEL="" UUID="" MOUNTPOINT="/srv/broken" TYPE="disk"
+EOF`)
+
+	// The used size is only recorded for mounted block devices;
+	// errors are logged, and the used size left unset.
+	devices, err := diskmanager.ListBlockDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mountPoints, jc.DeepEquals, []string{"/srv/data", "/srv/broken"})
+	c.Assert(devices, jc.DeepEquals, []storage.BlockDevice{{
+		DeviceName: "sda",
+		Size:       228936,
+	}, {
+		DeviceName: "sdb",
+		Size:       1,
+		MountPoint: "/srv/data",
+		UsedSize:   42,
+	}, {
+		DeviceName: "sdc",
+		Size:       1,
+		MountPoint: "/srv/broken",
+	}})
+}
+
+func (s *ListBlockDevicesSuite) TestListBlockDevicesPhysicalVolumes(c *gc.C) {
+	testing.PatchExecutable(c, s, "lsblk", `#!/bin/bash --norc
+cat <<EOF
+KNAME="sda" SIZE="240057409536" LABEL="" UUID="" TYPE="disk"
+KNAME="sdb" SIZE="10737418240" LABEL="" UUID="" FSTYPE="LVM2_member" TYPE="disk"
+KNAME="sdc" SIZE="10737418240" LABEL="" UUID="" FSTYPE="LVM2_member" TYPE="disk"
+KNAME="sdd" SIZE="10737418240" LABEL="" UUID="" FSTYPE="LVM2_member" TYPE="disk"
+EOF`)
+	testing.PatchExecutable(c, s, "pvs", `#!/bin/bash --norc
+cat <<EOF
+  /dev/sdb:juju:4294967296
+  /dev/sdc:juju:eleventy
+  /dev/sdd::10737418240
+EOF`)
+
+	// Physical volumes not in a volume group, or with invalid
+	// free sizes, are left unset.
+	devices, err := diskmanager.ListBlockDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, jc.DeepEquals, []storage.BlockDevice{{
+		DeviceName: "sda",
+		Size:       228936,
+	}, {
+		DeviceName:     "sdb",
+		Size:           10240,
+		FilesystemType: "LVM2_member",
+		VolumeGroup:    "juju",
+		FreeSize:       4096,
+	}, {
+		DeviceName:     "sdc",
+		Size:           10240,
+		FilesystemType: "LVM2_member",
+	}, {
+		DeviceName:     "sdd",
+		Size:           10240,
+		FilesystemType: "LVM2_member",
+	}})
+}
+
+func (s *ListBlockDevicesSuite) TestListBlockDevicesPartitionThinPool(c *gc.C) {
+	testing.PatchExecutable(c, s, "lsblk", `#!/bin/bash --norc
+cat <<EOF
+KNAME="sda" SIZE="240057409536" LABEL="" UUID="" TYPE="disk"
+KNAME="sda1" SIZE="10737418240" LABEL="" UUID="" FSTYPE="LVM2_member" TYPE="part"
+KNAME="sda2" SIZE="10737418240" LABEL="" UUID="" FSTYPE="LVM2_member" TYPE="part"
+EOF`)
+	testing.PatchExecutable(c, s, "pvs", `#!/bin/bash --norc
+cat <<EOF
+  /dev/sda1:juju:1073741824
+  /dev/sda2:juju:0
+EOF`)
+	testing.PatchExecutable(c, s, "lvs", `#!/bin/bash --norc
+cat <<EOF
+  juju:linear::1073741824
+  juju:thin-pool:25.00:8589934592
+  other:thin-pool:0.00:8589934592
+EOF`)
+
+	// Partitions that are physical volumes are reported, and the
+	// unused data space of the thin pool is added to the free size
+	// of the volume group's first physical volume.
+	devices, err := diskmanager.ListBlockDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, jc.DeepEquals, []storage.BlockDevice{{
+		DeviceName: "sda",
+		Size:       228936,
+	}, {
+		DeviceName:     "sda1",
+		Size:           10240,
+		FilesystemType: "LVM2_member",
+		VolumeGroup:    "juju",
+		FreeSize:       1024 + 6144,
+	}, {
+		DeviceName:     "sda2",
+		Size:           10240,
+		FilesystemType: "LVM2_member",
+		VolumeGroup:    "juju",
+	}})
+}
+
+func (s *ListBlockDevicesSuite) TestListBlockDevicesPvsFails(c *gc.C) {
+	testing.PatchExecutable(c, s, "lsblk", `#!/bin/bash --norc
+cat <<EOF
+KNAME="sdb" SIZE="10737418240" LABEL="" UUID="" FSTYPE="LVM2_member" TYPE="disk"
+EOF`)
+	testing.PatchExecutable(c, s, "pvs", `#!/bin/bash --norc
+exit 5`)
+
+	// Failure to list physical volumes is not fatal.
+	devices, err := diskmanager.ListBlockDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, jc.DeepEquals, []storage.BlockDevice{{
+		DeviceName:     "sdb",
+		Size:           10240,
+		FilesystemType: "LVM2_member",
+	}})
+}
+
 func (s *ListBlockDevicesSuite) TestListBlockDevicesLsblkBadOutput(c *gc.C) {
 	// Extra key/value pairs should be ignored; invalid sizes should
 	// be logged and ignored (Size will be set to zero).
@@ -225,8 +364,14 @@ EOF`)
 	c.Assert(devices, jc.DeepEquals, []storage.BlockDevice{{
 		DeviceName: "sda",
 		Size:       228936,
+	}, {
+		DeviceName: "sda1",
+		Size:       243,
 	}, {
 		DeviceName: "loop0",
 		Size:       243,
+	}, {
+		DeviceName: "whatever",
+		Size:       243,
 	}})
 }
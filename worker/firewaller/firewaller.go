@@ -21,8 +21,6 @@ import (
 	"github.com/juju/juju/worker/environ"
 )
 
-type machineRanges map[network.PortRange]bool
-
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
 // Uses Firewaller API V1.
@@ -33,14 +31,14 @@ type Firewaller struct {
 	modelWatcher    watcher.NotifyWatcher
 	machinesWatcher watcher.StringsWatcher
 	portsWatcher    watcher.StringsWatcher
+	subnetsWatcher  watcher.StringsWatcher
 	machineds       map[names.MachineTag]*machineData
 	unitsChange     chan *unitsChange
 	unitds          map[names.UnitTag]*unitData
 	serviceds       map[names.ServiceTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
-	machinePorts    map[names.MachineTag]machineRanges
+	globalRuleRef   map[string]int
 }
 
 // NewFirewaller returns a new Firewaller or a new FirewallerV0,
@@ -53,7 +51,6 @@ func NewFirewaller(st *firewaller.State) (worker.Worker, error) {
 		unitds:        make(map[names.UnitTag]*unitData),
 		serviceds:     make(map[names.ServiceTag]*serviceData),
 		exposedChange: make(chan *exposedChange),
-		machinePorts:  make(map[names.MachineTag]machineRanges),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &fw.catacomb,
@@ -88,7 +85,7 @@ func (fw *Firewaller) setUp() error {
 	switch fw.environ.Config().FirewallMode() {
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[string]int)
 	case config.FwNone:
 		logger.Warningf("stopping firewaller - firewall-mode is %q", config.FwNone)
 		// XXX(fwereade): shouldn't this be nil? Nothing wrong, nothing to do,
@@ -113,6 +110,17 @@ func (fw *Firewaller) setUp() error {
 	}
 
 	logger.Debugf("started watching opened port ranges for the environment")
+
+	fw.subnetsWatcher, err = fw.st.WatchSubnets()
+	if errors.IsNotImplemented(err) {
+		logger.Warningf("not watching subnets: %v", err)
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	}
+	if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -122,6 +130,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var subnetsChange watcher.StringsChannel
+	if fw.subnetsWatcher != nil {
+		subnetsChange = fw.subnetsWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -174,12 +186,22 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			// The source CIDRs of services exposed to spaces are
+			// resolved from the subnets in those spaces.
+			for _, serviced := range fw.serviceds {
+				serviced.subnetsChanged()
+			}
 		case change := <-fw.unitsChange:
 			if err := fw.unitsChanged(change); err != nil {
 				return errors.Trace(err)
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.exposedCIDRs = change.exposedCIDRs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -198,7 +220,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		ingressRules: make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -309,16 +331,22 @@ func (fw *Firewaller) startService(service *firewaller.Service) error {
 	if err != nil {
 		return err
 	}
+	exposedCIDRs, err := service.ExposedCIDRs()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
-		fw:      fw,
-		service: service,
-		exposed: exposed,
-		unitds:  make(map[names.UnitTag]*unitData),
+		fw:           fw,
+		service:      service,
+		exposed:      exposed,
+		exposedCIDRs: exposedCIDRs,
+		unitds:       make(map[names.UnitTag]*unitData),
+		subnets:      make(chan struct{}, 1),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, exposedCIDRs)
 		},
 	})
 	if err != nil {
@@ -335,43 +363,34 @@ func (fw *Firewaller) startService(service *firewaller.Service) error {
 // units and services with the opened and closed ports globally and
 // opens and closes the appropriate ports for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	initialRules, err := fw.environ.IngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	var wantedRules []network.IngressRule
 	for _, machined := range fw.machineds {
-		for portRange, unitTag := range machined.definedPorts {
-			unitd, known := machined.unitds[unitTag]
-			if !known {
-				delete(machined.unitds, unitTag)
-				continue
-			}
-			if unitd.serviced.exposed {
-				collector[portRange] = true
-			}
+		rules, err := machined.wantedRules()
+		if err != nil {
+			return err
 		}
-	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+		wantedRules = append(wantedRules, rules...)
 	}
 	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	toOpen := network.DiffIngressRules(wantedRules, initialRules)
+	toClose := network.DiffIngressRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
 		logger.Infof("opening global ports %v", toOpen)
 		if err := fw.environ.OpenPorts(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
+		network.SortIngressRules(toOpen)
 	}
 	if len(toClose) > 0 {
 		logger.Infof("closing global ports %v", toClose)
 		if err := fw.environ.ClosePorts(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
+		network.SortIngressRules(toClose)
 	}
 	return nil
 }
@@ -405,14 +424,14 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instances[0].IngressRules(machineId)
 		if err != nil {
 			return err
 		}
 
 		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		toOpen := network.DiffIngressRules(machined.ingressRules, initialRules)
+		toClose := network.DiffIngressRules(initialRules, machined.ingressRules)
 		if len(toOpen) > 0 {
 			logger.Infof("opening instance port ranges %v for %q",
 				toOpen, machined.tag)
@@ -420,7 +439,7 @@ func (fw *Firewaller) reconcileInstances() error {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
+			network.SortIngressRules(toOpen)
 		}
 		if len(toClose) > 0 {
 			logger.Infof("closing instance port ranges %v for %q",
@@ -429,7 +448,7 @@ func (fw *Firewaller) reconcileInstances() error {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
+			network.SortIngressRules(toClose)
 		}
 	}
 	return nil
@@ -551,20 +570,13 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
 	// Gather ports to open and close.
-	want := []network.PortRange{}
-	for portRange, unitTag := range machined.definedPorts {
-		unitd, known := machined.unitds[unitTag]
-		if !known {
-			delete(machined.unitds, unitTag)
-			continue
-		}
-		if unitd.serviced.exposed {
-			want = append(want, portRange)
-		}
+	want, err := machined.wantedRules()
+	if err != nil {
+		return err
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	toOpen := network.DiffIngressRules(want, machined.ingressRules)
+	toClose := network.DiffIngressRules(machined.ingressRules, want)
+	machined.ingressRules = want
 	if fw.globalMode {
 		return fw.flushGlobalPorts(toOpen, toClose)
 	}
@@ -572,22 +584,24 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 }
 
 // flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
+// It keeps a reference count for ingress rules so that only 0-to-1 and
+// 1-to-0 events modify the environment.
+func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule) error {
 	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		key := rule.String()
+		if fw.globalRuleRef[key] == 0 {
+			toOpen = append(toOpen, rule)
 		}
-		fw.globalPortRef[portRange]++
+		fw.globalRuleRef[key]++
 	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+	for _, rule := range rawClose {
+		key := rule.String()
+		fw.globalRuleRef[key]--
+		if fw.globalRuleRef[key] == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalRuleRef, key)
 		}
 	}
 	// Open and close the ports.
@@ -596,7 +610,7 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) er
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
+		network.SortIngressRules(toOpen)
 		logger.Infof("opened port ranges %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
@@ -604,14 +618,14 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) er
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
+		network.SortIngressRules(toClose)
 		logger.Infof("closed port ranges %v in environment", toClose)
 	}
 	return nil
 }
 
 // flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -641,7 +655,7 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
+		network.SortIngressRules(toOpen)
 		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
@@ -649,7 +663,7 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
+		network.SortIngressRules(toClose)
 		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
 	}
 	return nil
@@ -745,11 +759,11 @@ type unitsChange struct {
 
 // machineData holds machine details and watches units added or removed.
 type machineData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	tag         names.MachineTag
-	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	tag          names.MachineTag
+	unitds       map[names.UnitTag]*unitData
+	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -758,7 +772,23 @@ func (md *machineData) machine() (*firewaller.Machine, error) {
 	return md.fw.st.Machine(md.tag)
 }
 
-// watchLoop watches the machine for units added or removed.
+// wantedRules returns the ingress rules that should be open for the
+// machine: the ports defined by the units of exposed services, each open
+// to the source CIDRs to which the unit's service is exposed.
+func (md *machineData) wantedRules() ([]network.IngressRule, error) {
+	unitPorts := make(map[network.PortRange]string)
+	for portRange, unitTag := range md.definedPorts {
+		unitPorts[portRange] = unitTag.Id()
+	}
+	return network.ExposedIngressRules(unitPorts, func(unitName string) (bool, []string, error) {
+		unitd, known := md.unitds[names.NewUnitTag(unitName)]
+		if !known {
+			return false, nil, nil
+		}
+		return unitd.serviced.exposed, unitd.serviced.exposedCIDRs, nil
+	})
+}
+
 func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
 	if err := md.catacomb.Add(unitw); err != nil {
 		return errors.Trace(err)
@@ -799,23 +829,39 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag, and the source CIDRs
+// to which the service is exposed, for one specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced     *serviceData
+	exposed      bool
+	exposedCIDRs []string
 }
 
 // serviceData holds service details and watches exposure changes.
 type serviceData struct {
-	catacomb catacomb.Catacomb
-	fw       *Firewaller
-	service  *firewaller.Service
-	exposed  bool
-	unitds   map[names.UnitTag]*unitData
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	service      *firewaller.Service
+	exposed      bool
+	exposedCIDRs []string
+	unitds       map[names.UnitTag]*unitData
+	subnets      chan struct{}
+}
+
+// subnetsChanged notifies the watchLoop that the subnets of the model
+// have changed, so the source CIDRs to which the service is exposed must
+// be read again. It does not block.
+func (sd *serviceData) subnetsChanged() {
+	select {
+	case sd.subnets <- struct{}{}:
+	default:
+	}
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag, and the source CIDRs to
+// which it is exposed, for changes. The source CIDRs are also read again
+// whenever the subnets of the model change.
+func (sd *serviceData) watchLoop(exposed bool, exposedCIDRs []string) error {
 	serviceWatcher, err := sd.service.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -837,20 +883,29 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := sd.service.IsExposed()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				continue
-			}
+		case <-sd.subnets:
+		}
+		change, err := sd.service.IsExposed()
+		if params.IsCodeNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		changeCIDRs, err := sd.service.ExposedCIDRs()
+		if params.IsCodeNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if change == exposed && stringsEqual(changeCIDRs, exposedCIDRs) {
+			continue
+		}
 
-			exposed = change
-			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
-			case <-sd.catacomb.Dying():
-				return sd.catacomb.ErrDying()
-			}
+		exposed, exposedCIDRs = change, changeCIDRs
+		select {
+		case sd.fw.exposedChange <- &exposedChange{sd, change, changeCIDRs}:
+		case <-sd.catacomb.Dying():
+			return sd.catacomb.ErrDying()
 		}
 	}
 }
@@ -865,18 +920,18 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
-next:
-	for _, a := range A {
-		for _, b := range B {
-			if a == b {
-				continue next
-			}
+// stringsEqual returns whether the two slices hold the same strings in
+// the same order.
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
 		}
-		missing = append(missing, a)
 	}
-	return
+	return true
 }
 
 // parsePortsKey parses a ports document global key coming from the
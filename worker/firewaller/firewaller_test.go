@@ -64,44 +64,43 @@ func (s *firewallerBaseSuite) setUpTest(c *gc.C, firewallMode string) {
 }
 
 // assertPorts retrieves the open ports of the instance and compares them
-// to the expected.
+// to the expected, which are open to anywhere.
 func (s *firewallerBaseSuite) assertPorts(c *gc.C, inst instance.Instance, machineId string, expected []network.PortRange) {
-	s.BackingState.StartSync()
-	start := time.Now()
-	for {
-		got, err := inst.Ports(machineId)
-		if err != nil {
-			c.Fatal(err)
-			return
-		}
-		network.SortPortRanges(got)
-		network.SortPortRanges(expected)
-		if reflect.DeepEqual(got, expected) {
-			c.Succeed()
-			return
-		}
-		if time.Since(start) > coretesting.LongWait {
-			c.Fatalf("timed out: expected %q; got %q", expected, got)
-			return
-		}
-		time.Sleep(coretesting.ShortWait)
-	}
+	s.assertIngressRules(c, inst, machineId, network.NewOpenIngressRules(expected))
+}
+
+// assertIngressRules retrieves the ingress rules of the instance and
+// compares them to the expected.
+func (s *firewallerBaseSuite) assertIngressRules(c *gc.C, inst instance.Instance, machineId string, expected []network.IngressRule) {
+	s.waitIngressRules(c, expected, func() ([]network.IngressRule, error) {
+		return inst.IngressRules(machineId)
+	})
 }
 
 // assertEnvironPorts retrieves the open ports of environment and compares them
-// to the expected.
+// to the expected, which are open to anywhere.
 func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.PortRange) {
+	s.assertEnvironIngressRules(c, network.NewOpenIngressRules(expected))
+}
+
+// assertEnvironIngressRules retrieves the ingress rules of the environment
+// and compares them to the expected.
+func (s *firewallerBaseSuite) assertEnvironIngressRules(c *gc.C, expected []network.IngressRule) {
+	s.waitIngressRules(c, expected, s.Environ.IngressRules)
+}
+
+func (s *firewallerBaseSuite) waitIngressRules(c *gc.C, expected []network.IngressRule, ingressRules func() ([]network.IngressRule, error)) {
 	s.BackingState.StartSync()
 	start := time.Now()
 	for {
-		got, err := s.Environ.Ports()
+		got, err := ingressRules()
 		if err != nil {
 			c.Fatal(err)
 			return
 		}
-		network.SortPortRanges(got)
-		network.SortPortRanges(expected)
-		if reflect.DeepEqual(got, expected) {
+		network.SortIngressRules(got)
+		network.SortIngressRules(expected)
+		if len(got) == 0 && len(expected) == 0 || reflect.DeepEqual(got, expected) {
 			c.Succeed()
 			return
 		}
@@ -199,6 +198,74 @@ func (s *InstanceModeSuite) TestExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), []network.PortRange{{8080, 8080, "tcp"}})
 }
 
+func (s *InstanceModeSuite) TestServiceExposedToCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+
+	err = svc.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+
+	// Changing the source CIDRs replaces the rules.
+	err = svc.SetExposedTo([]string{"10.0.0.0/8", "192.168.0.0/16"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/16"),
+	})
+
+	// Exposing to anywhere opens the port to anywhere.
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{80, 80, "tcp"}})
+
+	err = svc.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestServiceExposedToSpace(c *gc.C) {
+	_, err := s.State.AddSpace("internal", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "internal"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc.SetExposedTo(nil, []string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// Subnets added to the space are allowed too.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24", SpaceName: "internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertIngressRules(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.1.0/24"),
+	})
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedServices(c *gc.C) {
 	fw, err := firewaller.NewFirewaller(s.firewaller)
 	c.Assert(err, jc.ErrorIsNil)
@@ -669,6 +736,40 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestServicesExposedToDifferentCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc1 := s.AddTestingService(c, "wordpress", s.charm)
+	err = svc1.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, svc1)
+	s.startInstance(c, m1)
+	err = u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc2 := s.AddTestingService(c, "moinmoin", s.charm)
+	err = svc2.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u2, m2 := s.addUnit(c, svc2)
+	s.startInstance(c, m2)
+	err = u2.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEnvironIngressRules(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+
+	// Unexposing one service leaves the other's rule in place.
+	err = svc2.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironIngressRules(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedService(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
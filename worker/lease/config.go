@@ -26,6 +26,32 @@ type Secretary interface {
 	CheckDuration(duration time.Duration) error
 }
 
+// Directives exposes operator instructions that override a Manager's usual
+// first-come-first-served handling of particular leases. The Manager reads
+// them at every tick, and whenever RefreshDirectives is called.
+type Directives interface {
+	// Directives returns the current directives, keyed by lease name.
+	Directives() (map[string]Directive, error)
+
+	// Transferred records that the named lease is now held by its successor.
+	Transferred(leaseName string) error
+}
+
+// Directive holds the operator instructions for a single lease.
+type Directive struct {
+	// Pinned is true if the lease must not be expired, even once its
+	// holder has stopped extending it.
+	Pinned bool
+
+	// Successor is the holder to which the lease is being transferred,
+	// or "" if no transfer is in progress.
+	Successor string
+
+	// TransferDeadline is the time at which a transfer to Successor
+	// stops standing.
+	TransferDeadline time.Time
+}
+
 // ManagerConfig contains the resources and information required to create a
 // Manager.
 type ManagerConfig struct {
@@ -42,6 +68,10 @@ type ManagerConfig struct {
 	// MaxSleep is the longest time the Manager should sleep before
 	// refreshing its client's leases and checking for expiries.
 	MaxSleep time.Duration
+
+	// Directives, if not nil, is consulted for operator instructions that
+	// affect how leases are claimed and expired.
+	Directives Directives
 }
 
 // Validate returns an error if the configuration contains invalid information
@@ -68,6 +68,9 @@ type Fixture struct {
 	// to the extent that it returns an error on Wait(); tests that don't set
 	// this flag will check that the manager's shutdown error is nil.
 	expectDirty bool
+
+	// directives, if not nil, will be used as the manager's Directives.
+	directives *Directives
 }
 
 // RunTest sets up a Manager and a Clock and passes them into the supplied
@@ -75,12 +78,16 @@ type Fixture struct {
 func (fix *Fixture) RunTest(c *gc.C, test func(*lease.Manager, *testing.Clock)) {
 	clock := testing.NewClock(defaultClockStart)
 	client := NewClient(fix.leases, fix.expectCalls)
-	manager, err := lease.NewManager(lease.ManagerConfig{
+	config := lease.ManagerConfig{
 		Clock:     clock,
 		Client:    client,
 		Secretary: Secretary{},
 		MaxSleep:  defaultMaxSleep,
-	})
+	}
+	if fix.directives != nil {
+		config.Directives = fix.directives
+	}
+	manager, err := lease.NewManager(config)
 	c.Assert(err, jc.ErrorIsNil)
 	defer func() {
 		// Dirty tests will probably have stopped the manager anyway, but no
@@ -28,10 +28,13 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		return nil, errors.Trace(err)
 	}
 	manager := &Manager{
-		config: config,
-		claims: make(chan claim),
-		checks: make(chan check),
-		blocks: make(chan block),
+		config:    config,
+		claims:    make(chan claim),
+		checks:    make(chan check),
+		blocks:    make(chan block),
+		releases:  make(chan release),
+		refreshes: make(chan refresh),
+		pinned:    make(map[string]bool),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &manager.catacomb,
@@ -43,7 +46,8 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 	return manager, nil
 }
 
-// Manager implements lease.Claimer, lease.Checker, and worker.Worker.
+// Manager implements lease.Claimer, lease.Releaser, lease.Checker, and
+// worker.Worker.
 type Manager struct {
 	catacomb catacomb.Catacomb
 
@@ -58,6 +62,23 @@ type Manager struct {
 
 	// blocks is used to deliver expiry block requests to the loop.
 	blocks chan block
+
+	// releases is used to deliver lease release requests to the loop.
+	releases chan release
+
+	// refreshes is used to deliver directive refresh requests to the loop.
+	refreshes chan refresh
+
+	// directives holds the directives read at the last tick or refresh,
+	// and directivesErr the error, if any, from reading them. They are
+	// only used by the loop goroutine.
+	directives    map[string]Directive
+	directivesErr error
+
+	// pinned holds the names of leases that were due to expire at the last
+	// tick, but were left alone because they were pinned. It is only used
+	// by the loop goroutine.
+	pinned map[string]bool
 }
 
 // Kill is part of the worker.Worker interface.
@@ -73,6 +94,7 @@ func (manager *Manager) Wait() error {
 // loop runs until the manager is stopped.
 func (manager *Manager) loop() error {
 	blocks := make(blocks)
+	manager.readDirectives()
 	for {
 		if err := manager.choose(blocks); err != nil {
 			return errors.Trace(err)
@@ -101,6 +123,12 @@ func (manager *Manager) choose(blocks blocks) error {
 	case block := <-manager.blocks:
 		blocks.add(block)
 		return nil
+	case release := <-manager.releases:
+		return manager.handleRelease(release, blocks)
+	case refresh := <-manager.refreshes:
+		manager.readDirectives()
+		refresh.respond()
+		return nil
 	}
 }
 
@@ -130,6 +158,13 @@ func (manager *Manager) Claim(leaseName, holderName string, duration time.Durati
 func (manager *Manager) handleClaim(claim claim) error {
 	client := manager.config.Client
 	request := lease.Request{claim.holderName, claim.duration}
+	successor := manager.successor(claim.leaseName)
+	if successor != "" && successor != claim.holderName {
+		// The lease is being handed over to someone else; the current
+		// holder may not extend it, and nobody else may pick it up.
+		claim.respond(false)
+		return nil
+	}
 	err := lease.ErrInvalid
 	for err == lease.ErrInvalid {
 		select {
@@ -151,10 +186,116 @@ func (manager *Manager) handleClaim(claim claim) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if successor != "" {
+		if err := manager.config.Directives.Transferred(claim.leaseName); err != nil {
+			logger.Errorf("cannot record transfer of lease %q: %v", claim.leaseName, err)
+		}
+		directive := manager.directives[claim.leaseName]
+		directive.Successor = ""
+		manager.directives[claim.leaseName] = directive
+	}
 	claim.respond(true)
 	return nil
 }
 
+// readDirectives reads the directives, if any, which are then consulted
+// until the next tick or refresh.
+func (manager *Manager) readDirectives() {
+	if manager.config.Directives == nil {
+		return
+	}
+	directives, err := manager.config.Directives.Directives()
+	if err != nil {
+		logger.Errorf("cannot read lease directives: %v", err)
+	}
+	manager.directives = directives
+	manager.directivesErr = err
+}
+
+// successor returns the holder to which the named lease is being
+// transferred, if any. Failure to read the directives is not fatal;
+// the lease is then treated as if no transfer had been requested.
+func (manager *Manager) successor(leaseName string) string {
+	directive := manager.directives[leaseName]
+	if !manager.config.Clock.Now().Before(directive.TransferDeadline) {
+		return ""
+	}
+	return directive.Successor
+}
+
+// isPinned returns true if the named lease must not be expired. Failure
+// to read the directives is treated as a pin, because an expiry that
+// should not have happened cannot be undone.
+func (manager *Manager) isPinned(leaseName string) bool {
+	if manager.directivesErr != nil {
+		return true
+	}
+	return manager.directives[leaseName].Pinned
+}
+
+// RefreshDirectives causes the manager to read its directives again
+// before handling any further requests. It should be called after the
+// directives are changed, so that they take effect immediately rather
+// than at the next tick.
+func (manager *Manager) RefreshDirectives() error {
+	return refresh{
+		response: make(chan struct{}),
+		abort:    manager.catacomb.Dying(),
+	}.invoke(manager.refreshes)
+}
+
+// Release is part of the lease.Releaser interface.
+func (manager *Manager) Release(leaseName, holderName string) error {
+	if err := manager.config.Secretary.CheckLease(leaseName); err != nil {
+		return errors.Annotatef(err, "cannot release lease %q", leaseName)
+	}
+	if err := manager.config.Secretary.CheckHolder(holderName); err != nil {
+		return errors.Annotatef(err, "cannot release lease for holder %q", holderName)
+	}
+	return release{
+		leaseName:  leaseName,
+		holderName: holderName,
+		response:   make(chan error),
+		abort:      manager.catacomb.Dying(),
+	}.invoke(manager.releases)
+}
+
+// handleRelease processes and responds to the supplied release. It will only
+// return unrecoverable errors; failure to release because the lease is not
+// held is communicated back to the release's originator. Once the lease is
+// released, anyone waiting for it to expire is unblocked immediately rather
+// than on the next tick.
+func (manager *Manager) handleRelease(release release, blocks blocks) error {
+	client := manager.config.Client
+	err := lease.ErrInvalid
+	for err == lease.ErrInvalid {
+		select {
+		case <-manager.catacomb.Dying():
+			return manager.catacomb.ErrDying()
+		default:
+			info, found := client.Leases()[release.leaseName]
+			if !found || info.Holder != release.holderName {
+				// Our view may be stale; be sure before refusing.
+				if err := client.Refresh(); err != nil {
+					return errors.Trace(err)
+				}
+				info, found = client.Leases()[release.leaseName]
+			}
+			if !found || info.Holder != release.holderName {
+				release.respond(lease.ErrNotHeld)
+				return nil
+			}
+			err = client.ReleaseLease(release.leaseName, release.holderName)
+		}
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	blocks.unblock(release.leaseName)
+	release.respond(nil)
+	return nil
+}
+
 // Token is part of the lease.Checker interface.
 func (manager *Manager) Token(leaseName, holderName string) lease.Token {
 	return token{
@@ -208,7 +349,11 @@ func (manager *Manager) WaitUntilExpired(leaseName string) error {
 func (manager *Manager) nextTick() <-chan time.Time {
 	now := manager.config.Clock.Now()
 	nextTick := now.Add(manager.config.MaxSleep)
-	for _, info := range manager.config.Client.Leases() {
+	for name, info := range manager.config.Client.Leases() {
+		if manager.pinned[name] {
+			// Pinned leases are only checked again after MaxSleep.
+			continue
+		}
 		if info.Expiry.After(nextTick) {
 			continue
 		}
@@ -223,7 +368,8 @@ func (manager *Manager) nextTick() <-chan time.Time {
 // have been extended or expired by someone else; so ErrInvalid is
 // expected, and ignored, comfortable that the client will have been
 // updated in the background; and that we'll see fresh info when we
-// subsequently check nextWake().
+// subsequently check nextWake(). Pinned leases are left in place, however
+// long ago they should have expired.
 //
 // It will return only unrecoverable errors.
 func (manager *Manager) tick() error {
@@ -233,6 +379,7 @@ func (manager *Manager) tick() error {
 		return errors.Trace(err)
 	}
 	leases := client.Leases()
+	manager.readDirectives()
 
 	// Sort lease names so we expire in a predictable order for the tests.
 	names := make([]string, 0, len(leases))
@@ -243,10 +390,16 @@ func (manager *Manager) tick() error {
 
 	logger.Tracef("expiring leases...")
 	now := manager.config.Clock.Now()
+	manager.pinned = make(map[string]bool)
 	for _, name := range names {
 		if leases[name].Expiry.After(now) {
 			continue
 		}
+		if manager.isPinned(name) {
+			logger.Debugf("not expiring pinned lease %q", name)
+			manager.pinned[name] = true
+			continue
+		}
 		switch err := client.ExpireLease(name); err {
 		case nil, lease.ErrInvalid:
 		default:
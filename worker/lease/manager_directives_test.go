@@ -0,0 +1,190 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/lease"
+)
+
+type DirectivesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&DirectivesSuite{})
+
+func (s *DirectivesSuite) TestTransfer_HolderCannotExtend(c *gc.C) {
+	directives := &Directives{
+		successors: map[string]string{"redis": "redis/1"},
+	}
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		directives: directives,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Claim("redis", "redis/0", time.Minute)
+		c.Check(err, gc.Equals, corelease.ErrClaimDenied)
+		c.Check(directives.transferred, gc.HasLen, 0)
+	})
+}
+
+func (s *DirectivesSuite) TestTransfer_OthersCannotClaim(c *gc.C) {
+	directives := &Directives{
+		successors: map[string]string{"redis": "redis/1"},
+	}
+	fix := &Fixture{
+		directives: directives,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Claim("redis", "redis/2", time.Minute)
+		c.Check(err, gc.Equals, corelease.ErrClaimDenied)
+		c.Check(directives.transferred, gc.HasLen, 0)
+	})
+}
+
+func (s *DirectivesSuite) TestTransfer_SuccessorClaims(c *gc.C) {
+	directives := &Directives{
+		successors: map[string]string{"redis": "redis/1"},
+	}
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "ClaimLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/1", time.Minute}},
+		}},
+		directives: directives,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Claim("redis", "redis/1", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(directives.transferred, jc.DeepEquals, []string{"redis"})
+	})
+}
+
+func (s *DirectivesSuite) TestTransfer_ErrorIgnored(c *gc.C) {
+	directives := &Directives{
+		successors: map[string]string{"redis": "redis/1"},
+		err:        errors.New("boom"),
+	}
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "ClaimLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/2", time.Minute}},
+		}},
+		directives: directives,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Claim("redis", "redis/2", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(directives.transferred, gc.HasLen, 0)
+	})
+}
+
+func (s *DirectivesSuite) TestPinned_NotExpired(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{Expiry: offset(-time.Second)},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+		directives: &Directives{
+			pinned: map[string]bool{"redis": true},
+		},
+	}
+	fix.RunTest(c, func(_ *lease.Manager, clock *coretesting.Clock) {
+		// The pinned lease does not cause the manager to spin.
+		waitAlarms(c, clock, 1)
+		clock.Advance(almostSeconds(3600))
+	})
+}
+
+func (s *DirectivesSuite) TestPinned_ErrorTreatedAsPinned(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{Expiry: offset(-time.Second)},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+		directives: &Directives{
+			err: errors.New("boom"),
+		},
+	}
+	fix.RunTest(c, func(_ *lease.Manager, clock *coretesting.Clock) {
+		waitAlarms(c, clock, 1)
+		clock.Advance(almostSeconds(3600))
+	})
+}
+
+func (s *DirectivesSuite) TestPinned_OthersExpired(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{Expiry: offset(-time.Second)},
+			"store": corelease.Info{Expiry: offset(-time.Second)},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}, {
+			method: "ExpireLease",
+			args:   []interface{}{"store"},
+			callback: func(leases map[string]corelease.Info) {
+				delete(leases, "store")
+			},
+		}},
+		directives: &Directives{
+			pinned: map[string]bool{"redis": true},
+		},
+	}
+	fix.RunTest(c, func(_ *lease.Manager, _ *coretesting.Clock) {})
+}
+
+func (s *DirectivesSuite) TestDirectivesReadOnce(c *gc.C) {
+	directives := &Directives{}
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "ClaimLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/0", time.Minute}},
+		}, {
+			method: "ClaimLease",
+			args:   []interface{}{"store", corelease.Request{"store/0", time.Minute}},
+		}},
+		directives: directives,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Claim("redis", "redis/0", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+		err = manager.Claim("store", "store/0", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(directives.reads, gc.Equals, 1)
+	})
+}
+
+func (s *DirectivesSuite) TestRefreshDirectives(c *gc.C) {
+	directives := &Directives{}
+	fix := &Fixture{
+		directives: directives,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		directives.successors = map[string]string{"redis": "redis/1"}
+		err := manager.RefreshDirectives()
+		c.Assert(err, jc.ErrorIsNil)
+
+		err = manager.Claim("redis", "redis/2", time.Minute)
+		c.Check(err, gc.Equals, corelease.ErrClaimDenied)
+		c.Check(directives.reads, gc.Equals, 2)
+	})
+}
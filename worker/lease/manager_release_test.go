@@ -0,0 +1,203 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/lease"
+)
+
+type ReleaseSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReleaseSuite{})
+
+func (s *ReleaseSuite) TestSuccess(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "ReleaseLease",
+			args:   []interface{}{"redis", "redis/0"},
+			callback: func(leases map[string]corelease.Info) {
+				delete(leases, "redis")
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Release("redis", "redis/0")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *ReleaseSuite) TestMissingRefresh_Success(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "Refresh",
+			callback: func(leases map[string]corelease.Info) {
+				leases["redis"] = corelease.Info{
+					Holder: "redis/0",
+					Expiry: offset(time.Minute),
+				}
+			},
+		}, {
+			method: "ReleaseLease",
+			args:   []interface{}{"redis", "redis/0"},
+			callback: func(leases map[string]corelease.Info) {
+				delete(leases, "redis")
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Release("redis", "redis/0")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *ReleaseSuite) TestMissingRefresh_NotHeld(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Release("redis", "redis/0")
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrNotHeld)
+	})
+}
+
+func (s *ReleaseSuite) TestOtherHolder_NotHeld(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/1",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Release("redis", "redis/0")
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrNotHeld)
+	})
+}
+
+func (s *ReleaseSuite) TestInvalid_NotHeld(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "ReleaseLease",
+			args:   []interface{}{"redis", "redis/0"},
+			err:    corelease.ErrInvalid,
+			callback: func(leases map[string]corelease.Info) {
+				leases["redis"] = corelease.Info{
+					Holder: "redis/1",
+					Expiry: offset(time.Minute),
+				}
+			},
+		}, {
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Release("redis", "redis/0")
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrNotHeld)
+	})
+}
+
+func (s *ReleaseSuite) TestError(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "ReleaseLease",
+			args:   []interface{}{"redis", "redis/0"},
+			err:    errors.New("lol borken"),
+		}},
+		expectDirty: true,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Release("redis", "redis/0")
+		c.Check(err, gc.ErrorMatches, "lease manager stopped")
+		err = manager.Wait()
+		c.Check(err, gc.ErrorMatches, "lol borken")
+	})
+}
+
+func (s *ReleaseSuite) TestUnblocksWaiters(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "ReleaseLease",
+			args:   []interface{}{"redis", "redis/0"},
+			callback: func(leases map[string]corelease.Info) {
+				delete(leases, "redis")
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		blockTest := newBlockTest(manager, "redis")
+		blockTest.assertBlocked(c)
+
+		err := manager.Release("redis", "redis/0")
+		c.Check(err, jc.ErrorIsNil)
+		err = blockTest.assertUnblocked(c)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *ReleaseSuite) TestUnblocksWaitersWithStaleCache(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "ReleaseLease",
+			args:   []interface{}{"redis", "redis/0"},
+			// The lease is not removed from the client's view, as if
+			// its cache had not caught up with the release.
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		blockTest := newBlockTest(manager, "redis")
+		blockTest.assertBlocked(c)
+
+		err := manager.Release("redis", "redis/0")
+		c.Check(err, jc.ErrorIsNil)
+		err = blockTest.assertUnblocked(c)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
@@ -105,6 +105,24 @@ func (s *ValidationSuite) TestClaim_Duration(c *gc.C) {
 	})
 }
 
+func (s *ValidationSuite) TestRelease_LeaseName(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Release("INVALID", "bar/0")
+		c.Check(err, gc.ErrorMatches, `cannot release lease "INVALID": name not valid`)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	})
+}
+
+func (s *ValidationSuite) TestRelease_HolderName(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Release("foo", "INVALID")
+		c.Check(err, gc.ErrorMatches, `cannot release lease for holder "INVALID": name not valid`)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	})
+}
+
 func (s *ValidationSuite) TestToken_LeaseName(c *gc.C) {
 	fix := &Fixture{}
 	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
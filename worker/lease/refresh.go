@@ -0,0 +1,34 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+// refresh is used to deliver directive-refresh requests to a manager's
+// loop goroutine on behalf of RefreshDirectives.
+type refresh struct {
+	response chan struct{}
+	abort    <-chan struct{}
+}
+
+// invoke sends the refresh on the supplied channel and waits for the
+// directives to have been read.
+func (r refresh) invoke(ch chan<- refresh) error {
+	for {
+		select {
+		case <-r.abort:
+			return errStopped
+		case ch <- r:
+			ch = nil
+		case <-r.response:
+			return nil
+		}
+	}
+}
+
+// respond causes invoke to return.
+func (r refresh) respond() {
+	select {
+	case <-r.abort:
+	case r.response <- struct{}{}:
+	}
+}
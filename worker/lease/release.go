@@ -0,0 +1,40 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"github.com/juju/errors"
+)
+
+// release is used to deliver lease-release requests to a manager's loop
+// goroutine on behalf of Release.
+type release struct {
+	leaseName  string
+	holderName string
+	response   chan error
+	abort      <-chan struct{}
+}
+
+// invoke sends the release on the supplied channel and waits for an error
+// response.
+func (r release) invoke(ch chan<- release) error {
+	for {
+		select {
+		case <-r.abort:
+			return errStopped
+		case ch <- r:
+			ch = nil
+		case err := <-r.response:
+			return errors.Trace(err)
+		}
+	}
+}
+
+// respond notifies the originating invoke of completion status.
+func (r release) respond(err error) {
+	select {
+	case <-r.abort:
+	case r.response <- err:
+	}
+}
@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/lease"
+	workerlease "github.com/juju/juju/worker/lease"
 )
 
 // Secretary implements lease.Secretary for testing purposes.
@@ -42,6 +43,44 @@ func (Secretary) CheckDuration(duration time.Duration) error {
 	return nil
 }
 
+// Directives implements lease.Directives for testing purposes. Transfers
+// to successors stand for an hour from defaultClockStart.
+type Directives struct {
+	pinned      map[string]bool
+	successors  map[string]string
+	transferred []string
+	reads       int
+	err         error
+}
+
+// Directives is part of the lease.Directives interface.
+func (d *Directives) Directives() (map[string]workerlease.Directive, error) {
+	d.reads++
+	if d.err != nil {
+		return nil, d.err
+	}
+	directives := make(map[string]workerlease.Directive)
+	for name, pinned := range d.pinned {
+		directive := directives[name]
+		directive.Pinned = pinned
+		directives[name] = directive
+	}
+	for name, successor := range d.successors {
+		directive := directives[name]
+		directive.Successor = successor
+		directive.TransferDeadline = defaultClockStart.Add(time.Hour)
+		directives[name] = directive
+	}
+	return directives, nil
+}
+
+// Transferred is part of the lease.Directives interface.
+func (d *Directives) Transferred(name string) error {
+	d.transferred = append(d.transferred, name)
+	delete(d.successors, name)
+	return nil
+}
+
 // Client implements corelease.Client for testing purposes.
 type Client struct {
 	leases map[string]lease.Info
@@ -135,6 +174,11 @@ func (client *Client) ExpireLease(name string) error {
 	return client.call("ExpireLease", []interface{}{name})
 }
 
+// ReleaseLease is part of the corelease.Client interface.
+func (client *Client) ReleaseLease(name, holder string) error {
+	return client.call("ReleaseLease", []interface{}{name, holder})
+}
+
 // Refresh is part of the lease.Client interface.
 func (client *Client) Refresh() error {
 	return client.call("Refresh", nil)
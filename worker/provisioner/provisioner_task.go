@@ -554,6 +554,7 @@ func constructStartInstanceParams(
 				},
 				Volume: volumeTag,
 			},
+			v.SnapshotId,
 		}
 	}
 
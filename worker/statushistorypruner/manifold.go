@@ -17,10 +17,9 @@ import (
 // ManifoldConfig describes the resources and configuration on which the
 // statushistorypruner worker depends.
 type ManifoldConfig struct {
-	APICallerName    string
-	MaxLogsPerEntity uint
-	PruneInterval    time.Duration
-	NewTimer         worker.NewTimerFunc
+	APICallerName string
+	PruneInterval time.Duration
+	NewTimer      worker.NewTimerFunc
 }
 
 // Manifold returns a Manifold that encapsulates the statushistorypruner worker.
@@ -35,10 +34,9 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 
 			facade := statushistory.NewFacade(apiCaller)
 			prunerConfig := Config{
-				Facade:           facade,
-				MaxLogsPerEntity: config.MaxLogsPerEntity,
-				PruneInterval:    config.PruneInterval,
-				NewTimer:         config.NewTimer,
+				Facade:        facade,
+				PruneInterval: config.PruneInterval,
+				NewTimer:      config.NewTimer,
 			}
 			w, err := New(prunerConfig)
 			if err != nil {
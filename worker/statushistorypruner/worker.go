@@ -8,27 +8,29 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker"
 )
 
 // HistoryPrunerParams specifies how history logs should be prunned.
 type HistoryPrunerParams struct {
-	// TODO(perrito666) We might want to have some sort of limitation of the collection size too.
 	MaxLogsPerEntity int
+	MaxHistoryTime   time.Duration
+	MaxHistoryMB     int
 	PruneInterval    time.Duration
 }
 
 // Facade represents an API that implements status history pruning.
 type Facade interface {
-	Prune(int) error
+	Prune(maxHistoryTime time.Duration, maxHistoryMB, maxLogsPerEntity int) error
+	ModelConfig() (*config.Config, error)
 }
 
 // Config holds all necessary attributes to start a pruner worker.
 type Config struct {
-	Facade           Facade
-	MaxLogsPerEntity uint
-	PruneInterval    time.Duration
-	NewTimer         worker.NewTimerFunc
+	Facade        Facade
+	PruneInterval time.Duration
+	NewTimer      worker.NewTimerFunc
 }
 
 // Validate will err unless basic requirements for a valid
@@ -49,7 +51,11 @@ func New(conf Config) (worker.Worker, error) {
 		return nil, errors.Trace(err)
 	}
 	doPruning := func(stop <-chan struct{}) error {
-		err := conf.Facade.Prune(int(conf.MaxLogsPerEntity))
+		p, err := pruneParams(conf)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = conf.Facade.Prune(p.MaxHistoryTime, p.MaxHistoryMB, p.MaxLogsPerEntity)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -58,3 +64,21 @@ func New(conf Config) (worker.Worker, error) {
 
 	return worker.NewPeriodicWorker(doPruning, conf.PruneInterval, conf.NewTimer), nil
 }
+
+// pruneParams returns the limits to prune with, read afresh from the
+// model config each time so that changes take effect without a
+// restart. The history of each entity is only limited separately if
+// the model config says so; otherwise frequently-changing entities
+// would lose their recent history well within the age limit.
+func pruneParams(conf Config) (HistoryPrunerParams, error) {
+	cfg, err := conf.Facade.ModelConfig()
+	if err != nil {
+		return HistoryPrunerParams{}, errors.Annotate(err, "cannot read model config")
+	}
+	return HistoryPrunerParams{
+		MaxLogsPerEntity: cfg.MaxStatusHistoryEntries(),
+		MaxHistoryTime:   cfg.MaxStatusHistoryAge(),
+		MaxHistoryMB:     int(cfg.MaxStatusHistorySizeMB()),
+		PruneInterval:    conf.PruneInterval,
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs/config"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/statushistorypruner"
@@ -30,12 +31,11 @@ func (s *statusHistoryPrunerSuite) TestWorkerCallsPrune(c *gc.C) {
 		c.Assert(d, gc.Equals, 0*time.Nanosecond)
 		return fakeTimer
 	}
-	facade := newFakeFacade()
+	facade := newFakeFacade(coretesting.ModelConfig(c))
 	conf := statushistorypruner.Config{
-		Facade:           facade,
-		MaxLogsPerEntity: 3,
-		PruneInterval:    coretesting.ShortWait,
-		NewTimer:         fakeTimerFunc,
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
 	}
 
 	pruner, err := statushistorypruner.New(conf)
@@ -47,13 +47,16 @@ func (s *statusHistoryPrunerSuite) TestWorkerCallsPrune(c *gc.C) {
 	err = fakeTimer.fire()
 	c.Check(err, jc.ErrorIsNil)
 
-	var passedLogs int
+	var passed pruneArgs
 	select {
-	case passedLogs = <-facade.passedMaxLogs:
+	case passed = <-facade.passedArgs:
 	case <-time.After(coretesting.LongWait):
 		c.Fatal("timed out waiting for passed logs to pruner")
 	}
-	c.Assert(passedLogs, gc.Equals, 3)
+	c.Assert(passed, gc.Equals, pruneArgs{
+		maxHistoryTime: config.DefaultMaxStatusHistoryAge,
+		maxHistoryMB:   config.DefaultMaxStatusHistorySizeMB,
+	})
 
 	// Reset will have been called with the actual PruneInterval
 	var period time.Duration
@@ -74,12 +77,11 @@ func (s *statusHistoryPrunerSuite) TestWorkerWontCallPruneBeforeFiringTimer(c *g
 		c.Assert(d, gc.Equals, 0*time.Nanosecond)
 		return fakeTimer
 	}
-	facade := newFakeFacade()
+	facade := newFakeFacade(coretesting.ModelConfig(c))
 	conf := statushistorypruner.Config{
-		Facade:           facade,
-		MaxLogsPerEntity: 3,
-		PruneInterval:    coretesting.ShortWait,
-		NewTimer:         fakeTimerFunc,
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
 	}
 
 	pruner, err := statushistorypruner.New(conf)
@@ -89,12 +91,52 @@ func (s *statusHistoryPrunerSuite) TestWorkerWontCallPruneBeforeFiringTimer(c *g
 	})
 
 	select {
-	case <-facade.passedMaxLogs:
+	case <-facade.passedArgs:
 		c.Fatal("called before firing timer.")
 	case <-time.After(coretesting.LongWait):
 	}
 }
 
+func (s *statusHistoryPrunerSuite) TestWorkerUsesModelConfigLimits(c *gc.C) {
+	fakeTimer := newMockTimer(coretesting.LongWait)
+	fakeTimerFunc := func(d time.Duration) worker.PeriodicTimer {
+		return fakeTimer
+	}
+	cfg, err := coretesting.ModelConfig(c).Apply(map[string]interface{}{
+		config.MaxStatusHistoryAge:     "48h",
+		config.MaxStatusHistorySize:    "2G",
+		config.MaxStatusHistoryEntries: 500,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	facade := newFakeFacade(cfg)
+	conf := statushistorypruner.Config{
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
+	}
+
+	pruner, err := statushistorypruner.New(conf)
+	c.Check(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		c.Assert(worker.Stop(pruner), jc.ErrorIsNil)
+	})
+
+	err = fakeTimer.fire()
+	c.Check(err, jc.ErrorIsNil)
+
+	var passed pruneArgs
+	select {
+	case passed = <-facade.passedArgs:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for passed logs to pruner")
+	}
+	c.Assert(passed, gc.Equals, pruneArgs{
+		maxHistoryTime:   48 * time.Hour,
+		maxHistoryMB:     2048,
+		maxLogsPerEntity: 500,
+	})
+}
+
 type mockTimer struct {
 	period chan time.Duration
 	c      chan time.Time
@@ -128,22 +170,35 @@ func newMockTimer(d time.Duration) *mockTimer {
 	}
 }
 
+type pruneArgs struct {
+	maxHistoryTime   time.Duration
+	maxHistoryMB     int
+	maxLogsPerEntity int
+}
+
 type fakeFacade struct {
-	passedMaxLogs chan int
+	cfg        *config.Config
+	passedArgs chan pruneArgs
 }
 
-func newFakeFacade() *fakeFacade {
+func newFakeFacade(cfg *config.Config) *fakeFacade {
 	return &fakeFacade{
-		passedMaxLogs: make(chan int, 1),
+		cfg:        cfg,
+		passedArgs: make(chan pruneArgs, 1),
 	}
 }
 
 // Prune implements Facade
-func (f *fakeFacade) Prune(maxLogs int) error {
+func (f *fakeFacade) Prune(maxHistoryTime time.Duration, maxHistoryMB, maxLogsPerEntity int) error {
 	select {
-	case f.passedMaxLogs <- maxLogs:
+	case f.passedArgs <- pruneArgs{maxHistoryTime, maxHistoryMB, maxLogsPerEntity}:
 	case <-time.After(coretesting.LongWait):
 		return errors.New("timed out waiting for facade call Prune to run")
 	}
 	return nil
 }
+
+// ModelConfig implements Facade
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	return f.cfg, nil
+}
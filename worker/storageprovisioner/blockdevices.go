@@ -13,9 +13,10 @@ import (
 
 // machineBlockDevicesChanged is called when the block devices of the scoped
 // machine have been seen to have changed. This triggers a refresh of all
-// block devices for attached volumes backing pending filesystems.
+// block devices for attached volumes backing pending filesystems, and
+// pending filesystem resizes.
 func machineBlockDevicesChanged(ctx *context) error {
-	if len(ctx.incompleteFilesystemParams) == 0 {
+	if len(ctx.incompleteFilesystemParams)+len(ctx.incompleteFilesystemResizeParams) == 0 {
 		return nil
 	}
 	volumeTags := make([]names.VolumeTag, 0, len(ctx.incompleteFilesystemParams))
@@ -37,6 +38,12 @@ func machineBlockDevicesChanged(ctx *context) error {
 		}
 		volumeTags = append(volumeTags, params.Volume)
 	}
+	// The block devices of volumes backing filesystems that are
+	// pending resize must be refreshed even if they are already
+	// known, as we are waiting for them to grow.
+	for _, params := range ctx.incompleteFilesystemResizeParams {
+		volumeTags = append(volumeTags, params.Volume)
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
@@ -97,6 +104,11 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 					updatePendingFilesystemAttachment(ctx, id, params)
 				}
 			}
+			for _, params := range ctx.incompleteFilesystemResizeParams {
+				if params.Volume == volumeTags[i] {
+					updatePendingFilesystemResize(ctx, params)
+				}
+			}
 		} else if params.IsCodeNotProvisioned(result.Error) || params.IsCodeNotFound(result.Error) {
 			// Either the volume (attachment) isn't provisioned,
 			// or the corresponding block device is not yet known.
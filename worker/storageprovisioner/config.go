@@ -15,6 +15,7 @@ type Config struct {
 	StorageDir  string
 	Volumes     VolumeAccessor
 	Filesystems FilesystemAccessor
+	Snapshots   VolumeSnapshotAccessor
 	Life        LifecycleManager
 	Environ     ModelAccessor
 	Machines    MachineAccessor
@@ -44,6 +45,9 @@ func (config Config) Validate() error {
 	if config.Filesystems == nil {
 		return errors.NotValidf("nil Filesystems")
 	}
+	if config.Snapshots == nil {
+		return errors.NotValidf("nil Snapshots")
+	}
 	if config.Life == nil {
 		return errors.NotValidf("nil Life")
 	}
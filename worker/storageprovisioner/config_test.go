@@ -61,6 +61,11 @@ func (s *ConfigSuite) TestNilFilesystems(c *gc.C) {
 	s.checkNotValid(c, "nil Filesystems not valid")
 }
 
+func (s *ConfigSuite) TestNilSnapshots(c *gc.C) {
+	s.config.Snapshots = nil
+	s.checkNotValid(c, "nil Snapshots not valid")
+}
+
 func (s *ConfigSuite) TestNilLife(c *gc.C) {
 	s.config.Life = nil
 	s.checkNotValid(c, "nil Life not valid")
@@ -115,6 +120,9 @@ func almostValidConfig() storageprovisioner.Config {
 		Filesystems: struct {
 			storageprovisioner.FilesystemAccessor
 		}{},
+		Snapshots: struct {
+			storageprovisioner.VolumeSnapshotAccessor
+		}{},
 		Life: struct {
 			storageprovisioner.LifecycleManager
 		}{},
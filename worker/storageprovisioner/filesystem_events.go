@@ -146,6 +146,7 @@ func updatePendingFilesystem(ctx *context, params storage.FilesystemParams) {
 func removePendingFilesystem(ctx *context, tag names.FilesystemTag) {
 	delete(ctx.incompleteFilesystemParams, tag)
 	ctx.schedule.Remove(tag)
+	removePendingFilesystemResize(ctx, tag)
 }
 
 // updatePendingFilesystemAttachment adds the given filesystem attachment params to
@@ -278,10 +279,12 @@ func processDyingFilesystemAttachments(
 }
 
 // processAliveFilesystems processes the FilesystemResults for Alive filesystems,
-// provisioning filesystems and setting the info in state as necessary.
+// provisioning and resizing filesystems and setting the info in state as
+// necessary.
 func processAliveFilesystems(ctx *context, tags []names.FilesystemTag, filesystemResults []params.FilesystemResult) error {
 	// Filter out the already-provisioned filesystems.
 	pending := make([]names.FilesystemTag, 0, len(tags))
+	provisioned := make([]names.FilesystemTag, 0, len(tags))
 	for i, result := range filesystemResults {
 		tag := tags[i]
 		if result.Error == nil {
@@ -298,6 +301,8 @@ func processAliveFilesystems(ctx *context, tags []names.FilesystemTag, filesyste
 				// filesystem, so that attachments can be made.
 				maybeAddPendingVolumeBlockDevice(ctx, filesystem.Volume)
 			}
+			removePendingFilesystemResize(ctx, tag)
+			provisioned = append(provisioned, tag)
 			continue
 		}
 		if !params.IsCodeNotProvisioned(result.Error) {
@@ -309,6 +314,10 @@ func processAliveFilesystems(ctx *context, tags []names.FilesystemTag, filesyste
 		// to enquire about parameters below.
 		pending = append(pending, tag)
 	}
+	// Already-provisioned filesystems may need to be grown.
+	if err := processFilesystemResizes(ctx, provisioned); err != nil {
+		return errors.Annotate(err, "processing filesystem resizes")
+	}
 	if len(pending) == 0 {
 		return nil
 	}
@@ -48,6 +48,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			StorageDir:  storageDir,
 			Volumes:     api,
 			Filesystems: api,
+			Snapshots:   api,
 			Life:        api,
 			Environ:     api,
 			Machines:    api,
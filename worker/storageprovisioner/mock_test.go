@@ -129,9 +129,12 @@ type mockVolumeAccessor struct {
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	resizeParams           map[string]params.VolumeResizeParams
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSizes          func([]params.StorageSize) ([]params.ErrorResult, error)
+	volumeResizeParams      func([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -258,6 +261,30 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return make([]params.ErrorResult, len(volumeAttachments)), nil
 }
 
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	if v.volumeResizeParams != nil {
+		return v.volumeResizeParams(volumes)
+	}
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range volumes {
+		if p, ok := v.resizeParams[tag.String()]; ok {
+			result = append(result, params.VolumeResizeParamsResult{Result: p})
+		} else {
+			result = append(result, params.VolumeResizeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("pending resize for volume %q", tag.Id())),
+			})
+		}
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSizes(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+	if v.setVolumeSizes != nil {
+		return v.setVolumeSizes(sizes)
+	}
+	return make([]params.ErrorResult, len(sizes)), nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
@@ -267,6 +294,7 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		resizeParams:           make(map[string]params.VolumeResizeParams),
 	}
 }
 
@@ -276,9 +304,11 @@ type mockFilesystemAccessor struct {
 	provisionedMachines    map[string]instance.Id
 	provisionedFilesystems map[string]params.Filesystem
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
+	resizeParams           map[string]params.FilesystemResizeParams
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+	setFilesystemSizes          func([]params.StorageSize) ([]params.ErrorResult, error)
 }
 
 func (m *mockFilesystemAccessor) provisionFilesystem(tag names.FilesystemTag) params.Filesystem {
@@ -382,6 +412,27 @@ func (f *mockFilesystemAccessor) SetFilesystemAttachmentInfo(filesystemAttachmen
 	return make([]params.ErrorResult, len(filesystemAttachments)), nil
 }
 
+func (f *mockFilesystemAccessor) FilesystemResizeParams(filesystems []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	var result []params.FilesystemResizeParamsResult
+	for _, tag := range filesystems {
+		if p, ok := f.resizeParams[tag.String()]; ok {
+			result = append(result, params.FilesystemResizeParamsResult{Result: p})
+		} else {
+			result = append(result, params.FilesystemResizeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("pending resize for filesystem %q", tag.Id())),
+			})
+		}
+	}
+	return result, nil
+}
+
+func (f *mockFilesystemAccessor) SetFilesystemSizes(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+	if f.setFilesystemSizes != nil {
+		return f.setFilesystemSizes(sizes)
+	}
+	return make([]params.ErrorResult, len(sizes)), nil
+}
+
 func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
@@ -389,6 +440,64 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
+		resizeParams:           make(map[string]params.FilesystemResizeParams),
+	}
+}
+
+type mockVolumeSnapshotAccessor struct {
+	snapshotsWatcher *mockStringsWatcher
+	snapshots        map[string]params.VolumeSnapshotParams
+	watchErr         error
+
+	setVolumeSnapshotInfo   func([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+	setVolumeSnapshotErrors func([]params.VolumeSnapshotError) ([]params.ErrorResult, error)
+	removeVolumeSnapshots   func([]string) ([]params.ErrorResult, error)
+}
+
+func (m *mockVolumeSnapshotAccessor) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	if m.watchErr != nil {
+		return nil, m.watchErr
+	}
+	return m.snapshotsWatcher, nil
+}
+
+func (m *mockVolumeSnapshotAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	results := make([]params.VolumeSnapshotParamsResult, len(ids))
+	for i, id := range ids {
+		if snapshotParams, ok := m.snapshots[id]; ok {
+			results[i].Result = snapshotParams
+		} else {
+			results[i].Error = common.ServerError(errors.NotFoundf("volume snapshot %q", id))
+		}
+	}
+	return results, nil
+}
+
+func (m *mockVolumeSnapshotAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	if m.setVolumeSnapshotInfo != nil {
+		return m.setVolumeSnapshotInfo(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
+func (m *mockVolumeSnapshotAccessor) SetVolumeSnapshotErrors(snapshotErrors []params.VolumeSnapshotError) ([]params.ErrorResult, error) {
+	if m.setVolumeSnapshotErrors != nil {
+		return m.setVolumeSnapshotErrors(snapshotErrors)
+	}
+	return make([]params.ErrorResult, len(snapshotErrors)), nil
+}
+
+func (m *mockVolumeSnapshotAccessor) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if m.removeVolumeSnapshots != nil {
+		return m.removeVolumeSnapshots(ids)
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
+
+func newMockVolumeSnapshotAccessor() *mockVolumeSnapshotAccessor {
+	return &mockVolumeSnapshotAccessor{
+		snapshotsWatcher: newMockStringsWatcher(),
+		snapshots:        make(map[string]params.VolumeSnapshotParams),
 	}
 }
 
@@ -464,6 +573,10 @@ type dummyProvider struct {
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	destroyVolumeSnapshotsFunc   func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	resizeFilesystemsFunc        func([]storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
 }
@@ -573,6 +686,57 @@ func (s *dummyVolumeSource) DetachVolumes(params []storage.VolumeAttachmentParam
 	return make([]error, len(params)), nil
 }
 
+// CreateVolumeSnapshots makes some volume snapshots that we can check
+// later to ensure things went as expected.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	if s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			p.Id,
+			p.Volume,
+			storage.VolumeSnapshotInfo{
+				SnapshotId: "snap-" + p.Id,
+				Size:       p.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots lists volume snapshots.
+func (s *dummyVolumeSource) ListVolumeSnapshots() ([]string, error) {
+	return nil, nil
+}
+
+// DestroyVolumeSnapshots destroys volume snapshots.
+func (s *dummyVolumeSource) DestroyVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	if s.provider.destroyVolumeSnapshotsFunc != nil {
+		return s.provider.destroyVolumeSnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
+// ResizeVolumes grows volumes to the requested sizes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Volume = &storage.Volume{
+			p.Tag,
+			storage.VolumeInfo{
+				VolumeId: p.VolumeId,
+				Size:     p.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -644,6 +808,25 @@ func (s *dummyFilesystemSource) DetachFilesystems(params []storage.FilesystemAtt
 	return make([]error, len(params)), nil
 }
 
+// ResizeFilesystems grows filesystems to the requested sizes.
+func (s *dummyFilesystemSource) ResizeFilesystems(params []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	if s.provider.resizeFilesystemsFunc != nil {
+		return s.provider.resizeFilesystemsFunc(params)
+	}
+	results := make([]storage.ResizeFilesystemsResult, len(params))
+	for i, p := range params {
+		results[i].Filesystem = &storage.Filesystem{
+			Tag:    p.Tag,
+			Volume: p.Volume,
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: p.FilesystemId,
+				Size:         p.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
 type mockManagedFilesystemSource struct {
 	blockDevices map[names.VolumeTag]storage.BlockDevice
 	filesystems  map[names.FilesystemTag]storage.Filesystem
@@ -711,6 +894,26 @@ func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.Filesys
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		blockDevice, ok := s.blockDevices[arg.Volume]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v's backing-volume is not attached", arg.Tag.Id())
+			continue
+		}
+		results[i].Filesystem = &storage.Filesystem{
+			Tag:    arg.Tag,
+			Volume: arg.Volume,
+			FilesystemInfo: storage.FilesystemInfo{
+				Size:         blockDevice.Size,
+				FilesystemId: arg.FilesystemId,
+			},
+		}
+	}
+	return results, nil
+}
+
 type mockMachineAccessor struct {
 	instanceIds map[names.MachineTag]instance.Id
 	watcher     *mockNotifyWatcher
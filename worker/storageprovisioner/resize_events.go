@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// processVolumeResizes enquires about pending resizes of the
+// provisioned volumes with the specified tags, scheduling resize
+// operations as necessary.
+func processVolumeResizes(ctx *context, tags []names.VolumeTag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if errors.IsNotImplemented(err) {
+		// The controller does not support resizing.
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting volume resize parameters")
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// There is no pending resize.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for volume %q", tags[i].Id(),
+			)
+		}
+		resizeParams, err := volumeResizeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "converting volume resize parameters")
+		}
+		op := &resizeVolumeOp{args: resizeParams}
+		ctx.schedule.Remove(op.key())
+		scheduleOperations(ctx, op)
+	}
+	return nil
+}
+
+// processFilesystemResizes enquires about pending resizes of the
+// provisioned filesystems with the specified tags, scheduling resize
+// operations as necessary.
+func processFilesystemResizes(ctx *context, tags []names.FilesystemTag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	results, err := ctx.config.Filesystems.FilesystemResizeParams(tags)
+	if errors.IsNotImplemented(err) {
+		// The controller does not support resizing.
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting filesystem resize parameters")
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// There is no pending resize.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for filesystem %q", tags[i].Id(),
+			)
+		}
+		resizeParams, err := filesystemResizeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "converting filesystem resize parameters")
+		}
+		updatePendingFilesystemResize(ctx, resizeParams)
+	}
+	return nil
+}
+
+// updatePendingFilesystemResize adds the given filesystem resize params
+// to either the incomplete set or the schedule. Volume-backed filesystems
+// cannot be grown until the block device of the backing volume has been
+// seen to have grown, so the params remain incomplete until then.
+func updatePendingFilesystemResize(ctx *context, params storage.FilesystemResizeParams) {
+	op := &resizeFilesystemOp{args: params}
+	ctx.schedule.Remove(op.key())
+	if params.Volume != (names.VolumeTag{}) {
+		blockDevice, ok := ctx.volumeBlockDevices[params.Volume]
+		if !ok {
+			ctx.pendingVolumeBlockDevices.Add(params.Volume)
+		}
+		if !ok || blockDevice.Size < params.Size {
+			ctx.incompleteFilesystemResizeParams[params.Tag] = params
+			return
+		}
+	}
+	delete(ctx.incompleteFilesystemResizeParams, params.Tag)
+	scheduleOperations(ctx, op)
+}
+
+// removePendingFilesystemResize removes the specified pending filesystem
+// resize from the incomplete set and/or the schedule if it exists there.
+func removePendingFilesystemResize(ctx *context, tag names.FilesystemTag) {
+	delete(ctx.incompleteFilesystemResizeParams, tag)
+	ctx.schedule.Remove(resizeKey{tag})
+}
+
+// volumeResizeParamsFromParams converts volume resize parameters
+// from API params to storage.VolumeResizeParams.
+func volumeResizeParamsFromParams(in params.VolumeResizeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Tag:        volumeTag,
+		VolumeId:   in.VolumeId,
+		Size:       in.Size,
+		Provider:   storage.ProviderType(in.Provider),
+		Attributes: in.Attributes,
+	}, nil
+}
+
+// filesystemResizeParamsFromParams converts filesystem resize parameters
+// from API params to storage.FilesystemResizeParams.
+func filesystemResizeParamsFromParams(in params.FilesystemResizeParams) (storage.FilesystemResizeParams, error) {
+	filesystemTag, err := names.ParseFilesystemTag(in.FilesystemTag)
+	if err != nil {
+		return storage.FilesystemResizeParams{}, errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	if in.VolumeTag != "" {
+		volumeTag, err = names.ParseVolumeTag(in.VolumeTag)
+		if err != nil {
+			return storage.FilesystemResizeParams{}, errors.Trace(err)
+		}
+	}
+	return storage.FilesystemResizeParams{
+		Tag:          filesystemTag,
+		Volume:       volumeTag,
+		FilesystemId: in.FilesystemId,
+		Size:         in.Size,
+		Provider:     storage.ProviderType(in.Provider),
+		Attributes:   in.Attributes,
+	}, nil
+}
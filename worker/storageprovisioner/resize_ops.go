@@ -0,0 +1,234 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// resizeVolumes grows volumes according to the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	paramsByProvider := make(map[storage.ProviderType][]storage.VolumeResizeParams)
+	for _, op := range ops {
+		paramsByProvider[op.args.Provider] = append(paramsByProvider[op.args.Provider], op.args)
+	}
+	var reschedule []scheduleOp
+	var volumes []storage.Volume
+	var statuses []params.EntityStatusArgs
+	for providerType, resizeParams := range paramsByProvider {
+		logger.Debugf("resizing volumes: %v", resizeParams)
+		resizer, err := volumeResizer(ctx, providerType)
+		if errors.IsNotSupported(err) {
+			for _, p := range resizeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: params.StatusError,
+					Info:   err.Error(),
+				})
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		results, err := resizer.ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", providerType)
+		}
+		for i, result := range results {
+			p := resizeParams[i]
+			if result.Error != nil {
+				// Reschedule the volume resize.
+				reschedule = append(reschedule, ops[p.Tag])
+				logger.Debugf("failed to resize %s: %v", names.ReadableString(p.Tag), result.Error)
+				continue
+			}
+			volumes = append(volumes, *result.Volume)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(volumes) == 0 {
+		return nil
+	}
+	sizes := make([]params.StorageSize, len(volumes))
+	for i, v := range volumes {
+		sizes[i] = params.StorageSize{Tag: v.Tag.String(), Size: v.Size}
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSizes(sizes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume sizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing size of volume %s to state: %v",
+				volumes[i].Tag.Id(), result.Error,
+			)
+		}
+	}
+	for _, v := range volumes {
+		if info, ok := ctx.volumes[v.Tag]; ok {
+			info.Size = v.Size
+			ctx.volumes[v.Tag] = info
+		}
+	}
+	return nil
+}
+
+// resizeFilesystems grows filesystems according to the specified
+// parameters.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	paramsByProvider := make(map[storage.ProviderType][]storage.FilesystemResizeParams)
+	managedParams := make([]storage.FilesystemResizeParams, 0, len(ops))
+	for _, op := range ops {
+		if op.args.Volume != (names.VolumeTag{}) {
+			// Volume-backed filesystems are managed by
+			// the storage provisioner itself.
+			managedParams = append(managedParams, op.args)
+			continue
+		}
+		paramsByProvider[op.args.Provider] = append(paramsByProvider[op.args.Provider], op.args)
+	}
+	var reschedule []scheduleOp
+	var filesystems []storage.Filesystem
+	var statuses []params.EntityStatusArgs
+	resize := func(resizer storage.FilesystemResizer, sourceName string, resizeParams []storage.FilesystemResizeParams) error {
+		logger.Debugf("resizing filesystems: %v", resizeParams)
+		results, err := resizer.ResizeFilesystems(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing filesystems from source %q", sourceName)
+		}
+		for i, result := range results {
+			p := resizeParams[i]
+			if result.Error != nil {
+				// Reschedule the filesystem resize.
+				reschedule = append(reschedule, ops[p.Tag])
+				logger.Debugf("failed to resize %s: %v", names.ReadableString(p.Tag), result.Error)
+				continue
+			}
+			filesystems = append(filesystems, *result.Filesystem)
+		}
+		return nil
+	}
+	if len(managedParams) > 0 {
+		resizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+		if !ok {
+			return errors.New("managed filesystem source does not support resizing")
+		}
+		if err := resize(resizer, "managed", managedParams); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for providerType, resizeParams := range paramsByProvider {
+		resizer, err := filesystemResizer(ctx, providerType)
+		if errors.IsNotSupported(err) {
+			for _, p := range resizeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: params.StatusError,
+					Info:   err.Error(),
+				})
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := resize(resizer, string(providerType), resizeParams); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(filesystems) == 0 {
+		return nil
+	}
+	sizes := make([]params.StorageSize, len(filesystems))
+	for i, f := range filesystems {
+		sizes[i] = params.StorageSize{Tag: f.Tag.String(), Size: f.Size}
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemSizes(sizes)
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystem sizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing size of filesystem %s to state: %v",
+				filesystems[i].Tag.Id(), result.Error,
+			)
+		}
+	}
+	for _, f := range filesystems {
+		if info, ok := ctx.filesystems[f.Tag]; ok {
+			info.Size = f.Size
+			ctx.filesystems[f.Tag] = info
+		}
+	}
+	return nil
+}
+
+// volumeResizer returns the storage.VolumeResizer for the specified
+// storage provider, or a NotSupported error if the provider's volume
+// source does not support resizing volumes.
+func volumeResizer(ctx *context, providerType storage.ProviderType) (storage.VolumeResizer, error) {
+	sourceName := string(providerType)
+	source, err := volumeSource(ctx.modelConfig, ctx.config.StorageDir, sourceName, providerType)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("resizing %q volumes", sourceName)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	resizer, ok := source.(storage.VolumeResizer)
+	if !ok {
+		return nil, errors.NotSupportedf("resizing %q volumes", sourceName)
+	}
+	return resizer, nil
+}
+
+// filesystemResizer returns the storage.FilesystemResizer for the
+// specified storage provider, or a NotSupported error if the provider's
+// filesystem source does not support resizing filesystems.
+func filesystemResizer(ctx *context, providerType storage.ProviderType) (storage.FilesystemResizer, error) {
+	sourceName := string(providerType)
+	source, err := filesystemSource(ctx.modelConfig, ctx.config.StorageDir, sourceName, providerType)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("resizing %q filesystems", sourceName)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem source")
+	}
+	resizer, ok := source.(storage.FilesystemResizer)
+	if !ok {
+		return nil, errors.NotSupportedf("resizing %q filesystems", sourceName)
+	}
+	return resizer, nil
+}
+
+// resizeKey is the schedule key for resize operations. Resize operations
+// are keyed separately from the other operations on the same volume or
+// filesystem, which are keyed by the tag alone.
+type resizeKey struct {
+	tag names.Tag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args storage.VolumeResizeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeKey{op.args.Tag}
+}
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	args storage.FilesystemResizeParams
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	return resizeKey{op.args.Tag}
+}
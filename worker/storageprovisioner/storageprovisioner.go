@@ -9,7 +9,8 @@
 // manages model-scoped storage such as virtual disk services of the
 // cloud provider. In addition to this, each machine agent runs a machine-
 // storage provisioner worker that manages storage scoped to that machine,
-// such as loop devices, temporary filesystems (tmpfs), and rootfs.
+// such as loop devices, LVM logical volumes, temporary filesystems (tmpfs),
+// and rootfs.
 //
 // The storage provisioner worker is comprised of the following major
 // components:
@@ -86,6 +87,13 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// VolumeResizeParams returns the parameters for growing the volumes
+	// with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// SetVolumeSizes records the sizes of volumes that have been grown.
+	SetVolumeSizes([]params.StorageSize) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -120,6 +128,38 @@ type FilesystemAccessor interface {
 	// SetFilesystemAttachmentInfo records the details of newly provisioned
 	// filesystem attachments.
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	// FilesystemResizeParams returns the parameters for growing the
+	// filesystems with the specified tags.
+	FilesystemResizeParams([]names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error)
+
+	// SetFilesystemSizes records the sizes of filesystems that have
+	// been grown.
+	SetFilesystemSizes([]params.StorageSize) ([]params.ErrorResult, error)
+}
+
+// VolumeSnapshotAccessor defines an interface used to allow a storage
+// provisioner worker to perform volume snapshot related operations.
+type VolumeSnapshotAccessor interface {
+	// WatchVolumeSnapshots watches for changes to volume snapshots
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots() (watcher.StringsWatcher, error)
+
+	// VolumeSnapshotParams returns the parameters for creating or
+	// destroying the volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly created
+	// volume snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshotErrors records the errors that occurred when
+	// creating volume snapshots.
+	SetVolumeSnapshotErrors([]params.VolumeSnapshotError) ([]params.ErrorResult, error)
+
+	// RemoveVolumeSnapshots removes the specified volume snapshots
+	// from state.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
 }
 
 // MachineAccessor defines an interface used to allow a storage provisioner
@@ -214,6 +254,7 @@ func (w *storageProvisioner) loop() error {
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
 	machineChanges := make(chan names.MachineTag)
@@ -275,6 +316,20 @@ func (w *storageProvisioner) loop() error {
 			return errors.Trace(err)
 		}
 		filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
+
+		volumeSnapshotsWatcher, err := w.config.Snapshots.WatchVolumeSnapshots()
+		if errors.IsNotImplemented(err) {
+			// The controller does not support volume snapshots,
+			// so there will be none to create or destroy.
+			logger.Debugf("not watching volume snapshots: %v", err)
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		}
+		if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
 		return nil
 	}
 
@@ -294,6 +349,7 @@ func (w *storageProvisioner) loop() error {
 		incompleteVolumeAttachmentParams:     make(map[params.MachineStorageId]storage.VolumeAttachmentParams),
 		incompleteFilesystemParams:           make(map[names.FilesystemTag]storage.FilesystemParams),
 		incompleteFilesystemAttachmentParams: make(map[params.MachineStorageId]storage.FilesystemAttachmentParams),
+		incompleteFilesystemResizeParams:     make(map[names.FilesystemTag]storage.FilesystemResizeParams),
 		pendingVolumeBlockDevices:            make(set.Tags),
 	}
 	ctx.managedFilesystemSource = newManagedFilesystemSource(
@@ -353,6 +409,13 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return errors.New("machine block devices watcher closed")
@@ -384,6 +447,10 @@ func processSchedule(ctx *context) error {
 	destroyFilesystemOps := make(map[names.FilesystemTag]*destroyFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	createVolumeSnapshotOps := make(map[string]*createVolumeSnapshotOp)
+	destroyVolumeSnapshotOps := make(map[string]*destroyVolumeSnapshotOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -404,6 +471,14 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *createVolumeSnapshotOp:
+			createVolumeSnapshotOps[key.(string)] = op
+		case *destroyVolumeSnapshotOp:
+			destroyVolumeSnapshotOps[key.(string)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[op.args.Tag] = op
 		}
 	}
 	if len(destroyVolumeOps) > 0 {
@@ -446,6 +521,26 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
+	if len(destroyVolumeSnapshotOps) > 0 {
+		if err := destroyVolumeSnapshots(ctx, destroyVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "destroying volume snapshots")
+		}
+	}
+	if len(createVolumeSnapshotOps) > 0 {
+		if err := createVolumeSnapshots(ctx, createVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "creating volume snapshots")
+		}
+	}
 	return nil
 }
 
@@ -517,6 +612,16 @@ type context struct {
 	// map and a filesystem attachment operation is scheduled.
 	incompleteFilesystemAttachmentParams map[params.MachineStorageId]storage.FilesystemAttachmentParams
 
+	// incompleteFilesystemResizeParams contains incomplete parameters
+	// for filesystem resizes.
+	//
+	// Filesystem resize parameters are incomplete when the filesystem
+	// is volume-backed, and the backing volume's block device has not
+	// yet been seen to have grown to the requested size. Once it has,
+	// the parameters are removed from this map and a filesystem resize
+	// operation is scheduled.
+	incompleteFilesystemResizeParams map[names.FilesystemTag]storage.FilesystemResizeParams
+
 	// pendingVolumeBlockDevices contains the tags of volumes about whose
 	// block devices we wish to enquire.
 	pendingVolumeBlockDevices set.Tags
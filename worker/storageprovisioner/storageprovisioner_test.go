@@ -60,6 +60,7 @@ func (s *storageProvisionerSuite) TestStartStop(c *gc.C) {
 		Scope:       coretesting.ModelTag,
 		Volumes:     newMockVolumeAccessor(),
 		Filesystems: newMockFilesystemAccessor(),
+		Snapshots:   newMockVolumeSnapshotAccessor(),
 		Life:        &mockLifecycleManager{},
 		Environ:     newMockModelAccessor(c),
 		Machines:    newMockMachineAccessor(c),
@@ -1708,6 +1709,333 @@ func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	assertNoEvent(c, removedChan, "filesystems removed")
 }
 
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	snapshotAccessor := newMockVolumeSnapshotAccessor()
+	snapshotAccessor.snapshots["1@0"] = params.VolumeSnapshotParams{
+		Id:        "1@0",
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      1024,
+		Provider:  "dummy",
+		Life:      params.Alive,
+	}
+	snapshotAccessor.snapshots["1@1"] = params.VolumeSnapshotParams{
+		Id:        "1@1",
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      1024,
+		Provider:  "dummy",
+		Life:      params.Alive,
+		Info:      &params.VolumeSnapshotInfo{SnapshotId: "snap-1@1", Size: 1024},
+	}
+
+	createdChan := make(chan interface{}, 1)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		createdChan <- args
+		return []storage.CreateVolumeSnapshotsResult{{
+			VolumeSnapshot: &storage.VolumeSnapshot{
+				Id:     "1@0",
+				Volume: names.NewVolumeTag("1"),
+				VolumeSnapshotInfo: storage.VolumeSnapshotInfo{
+					SnapshotId: "snap-1@0",
+					Size:       1024,
+				},
+			},
+		}}, nil
+	}
+	setInfoChan := make(chan interface{}, 1)
+	snapshotAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		setInfoChan <- snapshots
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	args := &workerArgs{snapshots: snapshotAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	snapshotAccessor.snapshotsWatcher.changes <- []string{"1@0", "1@1"}
+	args.environ.watcher.changes <- struct{}{}
+
+	// Only the snapshot that has not already been created
+	// should be created.
+	created := waitChannel(c, createdChan, "waiting for volume snapshot to be created")
+	c.Assert(created, jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Id:       "1@0",
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     1024,
+		Provider: "dummy",
+	}})
+	snapshots := waitChannel(c, setInfoChan, "waiting for volume snapshot info to be set")
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshot{{
+		Id:        "1@0",
+		VolumeTag: "volume-1",
+		Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-1@0", Size: 1024},
+	}})
+	assertNoEvent(c, createdChan, "volume snapshots created")
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshotsNotSupported(c *gc.C) {
+	s.provider.volumeSourceFunc = func(*config.Config, *storage.Config) (storage.VolumeSource, error) {
+		// A volume source that does not implement
+		// storage.VolumeSnapshotter.
+		return struct{ storage.VolumeSource }{}, nil
+	}
+	snapshotAccessor := newMockVolumeSnapshotAccessor()
+	snapshotAccessor.snapshots["1@0"] = params.VolumeSnapshotParams{
+		Id:        "1@0",
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      1024,
+		Provider:  "dummy",
+		Life:      params.Alive,
+	}
+	errorsChan := make(chan interface{}, 1)
+	snapshotAccessor.setVolumeSnapshotErrors = func(snapshotErrors []params.VolumeSnapshotError) ([]params.ErrorResult, error) {
+		errorsChan <- snapshotErrors
+		return make([]params.ErrorResult, len(snapshotErrors)), nil
+	}
+
+	args := &workerArgs{snapshots: snapshotAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	snapshotAccessor.snapshotsWatcher.changes <- []string{"1@0"}
+	args.environ.watcher.changes <- struct{}{}
+
+	snapshotErrors := waitChannel(c, errorsChan, "waiting for volume snapshot error to be set")
+	c.Assert(snapshotErrors, jc.DeepEquals, []params.VolumeSnapshotError{{
+		Id:      "1@0",
+		Message: `snapshots of "dummy" volumes not supported`,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestVolumeSnapshotsNotImplemented(c *gc.C) {
+	// A controller that does not support volume snapshots does not
+	// stop the worker from provisioning storage.
+	snapshotAccessor := newMockVolumeSnapshotAccessor()
+	snapshotAccessor.watchErr = errors.NotImplementedf("WatchVolumeSnapshots() (need V3+)")
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		defer close(filesystemInfoSet)
+		return nil, nil
+	}
+
+	args := &workerArgs{filesystems: filesystemAccessor, snapshots: snapshotAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
+}
+
+func (s *storageProvisionerSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	snapshotAccessor := newMockVolumeSnapshotAccessor()
+	snapshotAccessor.snapshots["1@0"] = params.VolumeSnapshotParams{
+		Id:        "1@0",
+		VolumeTag: "volume-1",
+		Provider:  "dummy",
+		Life:      params.Dying,
+		Info:      &params.VolumeSnapshotInfo{SnapshotId: "snap-1@0", Size: 1024},
+	}
+	snapshotAccessor.snapshots["1@1"] = params.VolumeSnapshotParams{
+		Id:        "1@1",
+		VolumeTag: "volume-1",
+		Provider:  "dummy",
+		Life:      params.Dying,
+	}
+
+	destroyedChan := make(chan interface{}, 1)
+	s.provider.destroyVolumeSnapshotsFunc = func(snapshotIds []string) ([]error, error) {
+		destroyedChan <- snapshotIds
+		return make([]error, len(snapshotIds)), nil
+	}
+	removedChan := make(chan interface{}, 1)
+	snapshotAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		removedChan <- ids
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	args := &workerArgs{snapshots: snapshotAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	snapshotAccessor.snapshotsWatcher.changes <- []string{"1@0", "1@1", "1@2"}
+	args.environ.watcher.changes <- struct{}{}
+
+	// Only the created snapshot should be destroyed, but both
+	// should be removed. The missing snapshot is ignored.
+	destroyed := waitChannel(c, destroyedChan, "waiting for volume snapshot to be destroyed")
+	assertNoEvent(c, destroyedChan, "volume snapshots destroyed")
+	c.Assert(destroyed, jc.DeepEquals, []string{"snap-1@0"})
+
+	removed := waitChannel(c, removedChan, "waiting for volume snapshots to be removed")
+	c.Assert(removed, jc.SameContents, []string{"1@0", "1@1"})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.resizeParams["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		return []storage.ResizeVolumesResult{{
+			Volume: &storage.Volume{
+				args[0].Tag,
+				storage.VolumeInfo{VolumeId: "vol-1", Size: 2048},
+			},
+		}}, nil
+	}
+	setSizesChan := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSizes = func(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+		setSizesChan <- sizes
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Only the volume with a pending resize should be resized.
+	volumeAccessor.volumesWatcher.changes <- []string{"1", "2"}
+	args.environ.watcher.changes <- struct{}{}
+
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+		Provider: "dummy",
+	}})
+	sizes := waitChannel(c, setSizesChan, "waiting for volume size to be set")
+	c.Assert(sizes, jc.DeepEquals, []params.StorageSize{{Tag: "volume-1", Size: 2048}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesNotImplemented(c *gc.C) {
+	// A controller that does not support resizing does not stop
+	// the worker.
+	resizeChan := make(chan interface{}, 1)
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.volumeResizeParams = func(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+		resizeChan <- tags
+		return nil, errors.NotImplementedf("VolumeResizeParams() (need V3+)")
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, resizeChan, "waiting for volume resize parameters")
+
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	waitChannel(c, resizeChan, "waiting for volume resize parameters")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesNotSupported(c *gc.C) {
+	s.provider.volumeSourceFunc = func(*config.Config, *storage.Config) (storage.VolumeSource, error) {
+		// A volume source that does not implement
+		// storage.VolumeResizer.
+		return struct{ storage.VolumeSource }{}, nil
+	}
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.resizeParams["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+
+	statusChan := make(chan interface{}, 1)
+	statusSetter := &mockStatusSetter{
+		setStatus: func(args []params.EntityStatusArgs) error {
+			statusChan <- args
+			return nil
+		},
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, statusSetter: statusSetter}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+
+	statuses := waitChannel(c, statusChan, "waiting for volume status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-1",
+		Status: "error",
+		Info:   `resizing "dummy" volumes not supported`,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeFilesystems(c *gc.C) {
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-1"] = params.Filesystem{
+		FilesystemTag: "filesystem-1",
+		Info:          params.FilesystemInfo{FilesystemId: "fs-1", Size: 1024},
+	}
+	filesystemAccessor.resizeParams["filesystem-1"] = params.FilesystemResizeParams{
+		FilesystemTag: "filesystem-1",
+		FilesystemId:  "fs-1",
+		Size:          2048,
+		Provider:      "dummy",
+	}
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeFilesystemsFunc = func(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+		resizedChan <- args
+		return []storage.ResizeFilesystemsResult{{
+			Filesystem: &storage.Filesystem{
+				Tag:            args[0].Tag,
+				FilesystemInfo: storage.FilesystemInfo{FilesystemId: "fs-1", Size: 2048},
+			},
+		}}, nil
+	}
+	setSizesChan := make(chan interface{}, 1)
+	filesystemAccessor.setFilesystemSizes = func(sizes []params.StorageSize) ([]params.ErrorResult, error) {
+		setSizesChan <- sizes
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	args := &workerArgs{filesystems: filesystemAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+
+	resized := waitChannel(c, resizedChan, "waiting for filesystem to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("1"),
+		FilesystemId: "fs-1",
+		Size:         2048,
+		Provider:     "dummy",
+	}})
+	sizes := waitChannel(c, setSizesChan, "waiting for filesystem size to be set")
+	c.Assert(sizes, jc.DeepEquals, []params.StorageSize{{Tag: "filesystem-1", Size: 2048}})
+}
+
 func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args == nil {
 		args = &workerArgs{}
@@ -1726,6 +2054,9 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args.filesystems == nil {
 		args.filesystems = newMockFilesystemAccessor()
 	}
+	if args.snapshots == nil {
+		args.snapshots = newMockVolumeSnapshotAccessor()
+	}
 	if args.life == nil {
 		args.life = &mockLifecycleManager{}
 	}
@@ -1746,6 +2077,7 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 		StorageDir:  storageDir,
 		Volumes:     args.volumes,
 		Filesystems: args.filesystems,
+		Snapshots:   args.snapshots,
 		Life:        args.life,
 		Environ:     args.environ,
 		Machines:    args.machines,
@@ -1760,6 +2092,7 @@ type workerArgs struct {
 	scope        names.Tag
 	volumes      *mockVolumeAccessor
 	filesystems  *mockFilesystemAccessor
+	snapshots    *mockVolumeSnapshotAccessor
 	life         *mockLifecycleManager
 	environ      *mockModelAccessor
 	machines     *mockMachineAccessor
@@ -134,6 +134,7 @@ func updatePendingVolume(ctx *context, params storage.VolumeParams) {
 func removePendingVolume(ctx *context, tag names.VolumeTag) {
 	delete(ctx.incompleteVolumeParams, tag)
 	ctx.schedule.Remove(tag)
+	ctx.schedule.Remove(resizeKey{tag})
 }
 
 // updatePendingVolumeAttachment adds the given volume attachment params to
@@ -248,10 +249,12 @@ func processDyingVolumeAttachments(
 }
 
 // processAliveVolumes processes the VolumeResults for Alive volumes,
-// provisioning volumes and setting the info in state as necessary.
+// provisioning and resizing volumes and setting the info in state as
+// necessary.
 func processAliveVolumes(ctx *context, tags []names.Tag, volumeResults []params.VolumeResult) error {
 	// Filter out the already-provisioned volumes.
 	pending := make([]names.VolumeTag, 0, len(tags))
+	provisioned := make([]names.VolumeTag, 0, len(tags))
 	for i, result := range volumeResults {
 		volumeTag := tags[i].(names.VolumeTag)
 		if result.Error == nil {
@@ -263,6 +266,7 @@ func processAliveVolumes(ctx *context, tags []names.Tag, volumeResults []params.
 			}
 			updateVolume(ctx, volume)
 			removePendingVolume(ctx, volumeTag)
+			provisioned = append(provisioned, volumeTag)
 			continue
 		}
 		if !params.IsCodeNotProvisioned(result.Error) {
@@ -274,6 +278,10 @@ func processAliveVolumes(ctx *context, tags []names.Tag, volumeResults []params.
 		// to enquire about parameters below.
 		pending = append(pending, volumeTag)
 	}
+	// Already-provisioned volumes may need to be grown.
+	if err := processVolumeResizes(ctx, provisioned); err != nil {
+		return errors.Annotate(err, "processing volume resizes")
+	}
 	if len(pending) == 0 {
 		return nil
 	}
@@ -475,6 +483,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
 	}, nil
 }
 
@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// volumeSnapshotsChanged is called when the lifecycle states of the
+// volume snapshots with the provided IDs have been seen to have changed.
+func volumeSnapshotsChanged(ctx *context, ids []string) error {
+	results, err := ctx.config.Snapshots.VolumeSnapshotParams(ids)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot parameters")
+	}
+	var snapshotErrors []params.VolumeSnapshotError
+	for i, result := range results {
+		id := ids[i]
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The snapshot has been removed.
+				ctx.schedule.Remove(id)
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting parameters for volume snapshot %q", id)
+		}
+		snapshotParams, err := volumeSnapshotParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "converting volume snapshot parameters")
+		}
+		switch result.Result.Life {
+		case params.Alive:
+			if result.Result.Info != nil {
+				// The snapshot has already been created.
+				continue
+			}
+			if snapshotParams.VolumeId == "" {
+				snapshotErrors = append(snapshotErrors, params.VolumeSnapshotError{
+					Id:      id,
+					Message: "volume is not provisioned",
+				})
+				continue
+			}
+			ctx.schedule.Remove(id)
+			scheduleOperations(ctx, &createVolumeSnapshotOp{args: snapshotParams})
+		case params.Dying, params.Dead:
+			var snapshotId string
+			if result.Result.Info != nil {
+				snapshotId = result.Result.Info.SnapshotId
+			}
+			ctx.schedule.Remove(id)
+			scheduleOperations(ctx, &destroyVolumeSnapshotOp{
+				args:       snapshotParams,
+				snapshotId: snapshotId,
+			})
+		}
+	}
+	setVolumeSnapshotErrors(ctx, snapshotErrors)
+	return nil
+}
+
+// volumeSnapshotParamsFromParams converts volume snapshot parameters
+// from API params to storage.VolumeSnapshotParams.
+func volumeSnapshotParamsFromParams(in params.VolumeSnapshotParams) (storage.VolumeSnapshotParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return storage.VolumeSnapshotParams{
+		Id:         in.Id,
+		Volume:     volumeTag,
+		VolumeId:   in.VolumeId,
+		Size:       in.Size,
+		Provider:   storage.ProviderType(in.Provider),
+		Attributes: in.Attributes,
+	}, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// createVolumeSnapshots creates volume snapshots with the specified
+// parameters.
+func createVolumeSnapshots(ctx *context, ops map[string]*createVolumeSnapshotOp) error {
+	paramsByProvider := make(map[storage.ProviderType][]storage.VolumeSnapshotParams)
+	for _, op := range ops {
+		paramsByProvider[op.args.Provider] = append(paramsByProvider[op.args.Provider], op.args)
+	}
+	var reschedule []scheduleOp
+	var snapshots []params.VolumeSnapshot
+	var snapshotErrors []params.VolumeSnapshotError
+	for providerType, snapshotParams := range paramsByProvider {
+		logger.Debugf("creating volume snapshots: %v", snapshotParams)
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if errors.IsNotSupported(err) {
+			for _, p := range snapshotParams {
+				snapshotErrors = append(snapshotErrors, params.VolumeSnapshotError{
+					Id:      p.Id,
+					Message: err.Error(),
+				})
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		results, err := snapshotter.CreateVolumeSnapshots(snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots from source %q", providerType)
+		}
+		for i, result := range results {
+			p := snapshotParams[i]
+			if result.Error != nil {
+				// Reschedule the snapshot creation, and record
+				// the error so that it can be reported to the user.
+				reschedule = append(reschedule, ops[p.Id])
+				snapshotErrors = append(snapshotErrors, params.VolumeSnapshotError{
+					Id:      p.Id,
+					Message: result.Error.Error(),
+				})
+				logger.Debugf("failed to create volume snapshot %q: %v", p.Id, result.Error)
+				continue
+			}
+			snapshots = append(snapshots, volumeSnapshotFromStorage(*result.VolumeSnapshot))
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setVolumeSnapshotErrors(ctx, snapshotErrors)
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Snapshots.SetVolumeSnapshotInfo(snapshots)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume snapshot %q to state: %v",
+				snapshots[i].Id, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// destroyVolumeSnapshots destroys volume snapshots with the specified
+// parameters, and removes them from state.
+func destroyVolumeSnapshots(ctx *context, ops map[string]*destroyVolumeSnapshotOp) error {
+	opsByProvider := make(map[storage.ProviderType][]*destroyVolumeSnapshotOp)
+	var remove []string
+	for id, op := range ops {
+		if op.snapshotId == "" {
+			// The snapshot was never created, so there
+			// is nothing to destroy.
+			remove = append(remove, id)
+			continue
+		}
+		opsByProvider[op.args.Provider] = append(opsByProvider[op.args.Provider], op)
+	}
+	var reschedule []scheduleOp
+	for providerType, ops := range opsByProvider {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		snapshotIds := make([]string, len(ops))
+		for i, op := range ops {
+			snapshotIds[i] = op.snapshotId
+		}
+		logger.Debugf("destroying volume snapshots from %q: %v", providerType, snapshotIds)
+		errs, err := snapshotter.DestroyVolumeSnapshots(snapshotIds)
+		if err != nil {
+			return errors.Annotatef(err, "destroying volume snapshots from source %q", providerType)
+		}
+		for i, err := range errs {
+			if err == nil {
+				remove = append(remove, ops[i].args.Id)
+				continue
+			}
+			// Failed to destroy the snapshot; reschedule.
+			reschedule = append(reschedule, ops[i])
+			logger.Debugf("failed to destroy volume snapshot %q: %v", ops[i].args.Id, err)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(remove) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Snapshots.RemoveVolumeSnapshots(remove)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "removing volume snapshot %q from state", remove[i])
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotter returns the storage.VolumeSnapshotter for the
+// specified storage provider, or a NotSupported error if the
+// provider's volume source does not support snapshots.
+func volumeSnapshotter(ctx *context, providerType storage.ProviderType) (storage.VolumeSnapshotter, error) {
+	sourceName := string(providerType)
+	source, err := volumeSource(ctx.modelConfig, ctx.config.StorageDir, sourceName, providerType)
+	if errors.Cause(err) == errNonDynamic {
+		return nil, errors.NotSupportedf("snapshots of %q volumes", sourceName)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshots of %q volumes", sourceName)
+	}
+	return snapshotter, nil
+}
+
+// setVolumeSnapshotErrors records the given volume snapshot errors, if
+// any. If recording the errors fails, the error is logged but otherwise
+// ignored.
+func setVolumeSnapshotErrors(ctx *context, snapshotErrors []params.VolumeSnapshotError) {
+	if len(snapshotErrors) == 0 {
+		return
+	}
+	errorResults, err := ctx.config.Snapshots.SetVolumeSnapshotErrors(snapshotErrors)
+	if err != nil {
+		logger.Errorf("failed to set volume snapshot errors: %v", err)
+		return
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"failed to set error for volume snapshot %q: %v",
+				snapshotErrors[i].Id, result.Error,
+			)
+		}
+	}
+}
+
+// volumeSnapshotFromStorage converts a storage.VolumeSnapshot
+// to params.VolumeSnapshot.
+func volumeSnapshotFromStorage(in storage.VolumeSnapshot) params.VolumeSnapshot {
+	return params.VolumeSnapshot{
+		Id:        in.Id,
+		VolumeTag: in.Volume.String(),
+		Info: params.VolumeSnapshotInfo{
+			SnapshotId: in.SnapshotId,
+			Size:       in.Size,
+		},
+	}
+}
+
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	args storage.VolumeSnapshotParams
+}
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return op.args.Id
+}
+
+type destroyVolumeSnapshotOp struct {
+	exponentialBackoff
+	args       storage.VolumeSnapshotParams
+	snapshotId string
+}
+
+func (op *destroyVolumeSnapshotOp) key() interface{} {
+	return op.args.Id
+}
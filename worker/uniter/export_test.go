@@ -0,0 +1,19 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker"
+)
+
+var (
+	HookRecordFlushInterval = &hookRecordFlushInterval
+	MaxHookRecordBatch      = &maxHookRecordBatch
+	NewHookRecorder         = newHookRecorder
+)
+
+func AddHookRecord(w worker.Worker, record params.HookRecord) {
+	w.(*hookRecorder).Add(record)
+}
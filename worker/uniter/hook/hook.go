@@ -37,6 +37,12 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// StorageSize is the size, in MiB, of the storage instance relevant
+	// to the hook. It is only set when Kind is storage-attached, and is
+	// used to distinguish the notification of grown storage from the
+	// initial attachment.
+	StorageSize uint64 `yaml:"storage-size,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var (
+	// hookRecordFlushInterval is the longest time for which hook
+	// records are buffered before being sent to the controller.
+	hookRecordFlushInterval = time.Minute
+
+	// maxHookRecordBatch is the number of buffered hook records
+	// which causes them to be sent without waiting any longer.
+	maxHookRecordBatch = 20
+)
+
+// hookRecordAdder is implemented by *uniter.Unit.
+type hookRecordAdder interface {
+	AddHookRecords(records ...params.HookRecord) error
+}
+
+// hookRecorder is a worker which buffers the hook records passed to
+// Add, and sends them to the controller in batches. Any records still
+// buffered when it is stopped are sent before it exits.
+type hookRecorder struct {
+	catacomb catacomb.Catacomb
+	unit     hookRecordAdder
+	clock    clock.Clock
+	records  chan params.HookRecord
+}
+
+func newHookRecorder(unit hookRecordAdder, clock clock.Clock) (*hookRecorder, error) {
+	r := &hookRecorder{
+		unit:    unit,
+		clock:   clock,
+		records: make(chan params.HookRecord),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &r.catacomb,
+		Work: r.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r, nil
+}
+
+// Add buffers the record to be sent to the controller. It is dropped
+// if the recorder is stopping.
+func (r *hookRecorder) Add(record params.HookRecord) {
+	select {
+	case r.records <- record:
+	case <-r.catacomb.Dying():
+		logger.Warningf("cannot record operation %q: hook recorder stopped", record.Operation)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (r *hookRecorder) Kill() {
+	r.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (r *hookRecorder) Wait() error {
+	return r.catacomb.Wait()
+}
+
+func (r *hookRecorder) loop() error {
+	var pending []params.HookRecord
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-r.catacomb.Dying():
+			r.flush(pending)
+			return r.catacomb.ErrDying()
+		case record := <-r.records:
+			pending = append(pending, record)
+			if len(pending) >= maxHookRecordBatch {
+				r.flush(pending)
+				pending, flush = nil, nil
+			} else if flush == nil {
+				flush = r.clock.After(hookRecordFlushInterval)
+			}
+		case <-flush:
+			r.flush(pending)
+			pending, flush = nil, nil
+		}
+	}
+}
+
+// flush sends the supplied records to the controller. Failure to do so
+// is logged, but does not stop the uniter.
+func (r *hookRecorder) flush(records []params.HookRecord) {
+	if len(records) == 0 {
+		return
+	}
+	if err := r.unit.AddHookRecords(records...); err != nil {
+		logger.Errorf("cannot record %d operations: %v", len(records), err)
+	}
+}
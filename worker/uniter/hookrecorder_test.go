@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/uniter"
+)
+
+type HookRecorderSuite struct {
+	coretesting.BaseSuite
+	clock *coretesting.Clock
+	adder *fakeHookRecordAdder
+}
+
+var _ = gc.Suite(&HookRecorderSuite{})
+
+func (s *HookRecorderSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC))
+	s.adder = &fakeHookRecordAdder{batches: make(chan []params.HookRecord, 10)}
+	s.PatchValue(uniter.MaxHookRecordBatch, 3)
+}
+
+func (s *HookRecorderSuite) newRecorder(c *gc.C) worker.Worker {
+	recorder, err := uniter.NewHookRecorder(s.adder, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return recorder
+}
+
+func (s *HookRecorderSuite) TestFlushAfterInterval(c *gc.C) {
+	recorder := s.newRecorder(c)
+	defer worker.Stop(recorder)
+	uniter.AddHookRecord(recorder, params.HookRecord{Operation: "one"})
+	uniter.AddHookRecord(recorder, params.HookRecord{Operation: "two"})
+	s.adder.checkNoBatch(c)
+
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("flush timer not set")
+	}
+	s.clock.Advance(*uniter.HookRecordFlushInterval)
+	s.adder.checkBatch(c, "one", "two")
+}
+
+func (s *HookRecorderSuite) TestFlushFullBatch(c *gc.C) {
+	recorder := s.newRecorder(c)
+	defer worker.Stop(recorder)
+	for _, op := range []string{"one", "two", "three", "four"} {
+		uniter.AddHookRecord(recorder, params.HookRecord{Operation: op})
+	}
+	s.adder.checkBatch(c, "one", "two", "three")
+	s.adder.checkNoBatch(c)
+}
+
+func (s *HookRecorderSuite) TestFlushOnStop(c *gc.C) {
+	recorder := s.newRecorder(c)
+	uniter.AddHookRecord(recorder, params.HookRecord{Operation: "one"})
+	err := worker.Stop(recorder)
+	c.Assert(err, jc.ErrorIsNil)
+	s.adder.checkBatch(c, "one")
+}
+
+type fakeHookRecordAdder struct {
+	batches chan []params.HookRecord
+}
+
+func (a *fakeHookRecordAdder) AddHookRecords(records ...params.HookRecord) error {
+	a.batches <- records
+	return nil
+}
+
+func (a *fakeHookRecordAdder) checkBatch(c *gc.C, operations ...string) {
+	select {
+	case records := <-a.batches:
+		var got []string
+		for _, record := range records {
+			got = append(got, record.Operation)
+		}
+		c.Assert(got, jc.DeepEquals, operations)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("no hook records added")
+	}
+}
+
+func (a *fakeHookRecordAdder) checkNoBatch(c *gc.C) {
+	select {
+	case records := <-a.batches:
+		c.Fatalf("unexpected hook records added: %v", records)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
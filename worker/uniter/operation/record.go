@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+// Record describes an operation run by an Executor.
+type Record struct {
+	// Operation is the operation's string representation.
+	Operation string
+
+	// Hook describes the hook run by the operation, if any.
+	Hook *hook.Info
+
+	// Started is the time at which the operation started, and Duration
+	// is the time it took to prepare, execute and commit it.
+	Started  time.Time
+	Duration time.Duration
+
+	// ExitCode and Stderr hold the exit code and the final standard
+	// error output of the hook or action run by the operation, if any.
+	ExitCode int
+	Stderr   string
+
+	// Error holds the error returned by the run, if any.
+	Error string
+}
+
+// recorder is implemented by operations which can add details of what they
+// did to a Record.
+type recorder interface {
+	record(*Record)
+}
+
+// NewRecordingExecutor returns an Executor which runs operations with the
+// supplied Executor, and passes a Record of each operation run to the
+// supplied func. Operations which are skipped, or whose Prepare step
+// returns ErrSkipExecute, are not recorded.
+func NewRecordingExecutor(executor Executor, record func(Record), clock clock.Clock) Executor {
+	return &recordingExecutor{
+		Executor: executor,
+		record:   record,
+		clock:    clock,
+	}
+}
+
+type recordingExecutor struct {
+	Executor
+	record func(Record)
+	clock  clock.Clock
+}
+
+// Run is part of the Executor interface.
+func (x *recordingExecutor) Run(op Operation) error {
+	started := x.clock.Now()
+	prepared := &skipDetectingOperation{Operation: op}
+	err := x.Executor.Run(prepared)
+	if prepared.skipped {
+		return err
+	}
+	record := Record{
+		Operation: op.String(),
+		Started:   started,
+		Duration:  x.clock.Now().Sub(started),
+	}
+	if r, ok := op.(recorder); ok {
+		r.record(&record)
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	x.record(record)
+	return err
+}
+
+// skipDetectingOperation wraps an Operation to note whether its Prepare
+// step returned ErrSkipExecute, in which case nothing was executed.
+type skipDetectingOperation struct {
+	Operation
+	skipped bool
+}
+
+// Prepare is part of the Operation interface.
+func (op *skipDetectingOperation) Prepare(state State) (*State, error) {
+	newState, err := op.Operation.Prepare(state)
+	op.skipped = errors.Cause(err) == ErrSkipExecute
+	return newState, err
+}
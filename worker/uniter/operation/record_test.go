@@ -0,0 +1,137 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type RecordingExecutorSuite struct {
+	testing.IsolationSuite
+	clock    *coretesting.Clock
+	executor *fakeExecutor
+	records  []operation.Record
+}
+
+var _ = gc.Suite(&RecordingExecutorSuite{})
+
+func (s *RecordingExecutorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC))
+	s.executor = &fakeExecutor{clock: s.clock}
+	s.records = nil
+}
+
+func (s *RecordingExecutorSuite) newExecutor() operation.Executor {
+	record := func(record operation.Record) {
+		s.records = append(s.records, record)
+	}
+	return operation.NewRecordingExecutor(s.executor, record, s.clock)
+}
+
+func newRecordedOperation(prepareErr error) *mockOperation {
+	return &mockOperation{
+		prepare: newStep(nil, prepareErr),
+		execute: newStep(nil, nil),
+	}
+}
+
+func (s *RecordingExecutorSuite) TestRun(c *gc.C) {
+	err := s.newExecutor().Run(newRecordedOperation(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.records, jc.DeepEquals, []operation.Record{{
+		Operation: "mock operation",
+		Started:   time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC),
+		Duration:  3 * time.Second,
+	}})
+}
+
+func (s *RecordingExecutorSuite) TestRunError(c *gc.C) {
+	s.executor.err = errors.New("splat")
+	err := s.newExecutor().Run(newRecordedOperation(nil))
+	c.Assert(err, gc.ErrorMatches, "splat")
+	c.Assert(s.records, gc.HasLen, 1)
+	c.Assert(s.records[0].Error, gc.Equals, "splat")
+}
+
+func (s *RecordingExecutorSuite) TestRunHook(c *gc.C) {
+	runnerFactory := NewRunHookRunnerFactory(errors.New("exit status 1"))
+	runner := runnerFactory.MockNewHookRunner.runner
+	runner.exitCode = 1
+	runner.stderr = "oops\n"
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks: &ExecuteHookCallbacks{
+			PrepareHookCallbacks: NewPrepareHookCallbacks(),
+			MockNotifyHookFailed: &MockNotify{},
+		},
+	})
+	info := hook.Info{Kind: hooks.RelationJoined, RelationId: 123, RemoteUnit: "foo/1"}
+	op, err := factory.NewRunHook(info)
+	c.Assert(err, jc.ErrorIsNil)
+	s.executor.err = operation.ErrHookFailed
+
+	err = s.newExecutor().Run(op)
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(s.records, jc.DeepEquals, []operation.Record{{
+		Operation: "run relation-joined (123; foo/1) hook",
+		Hook:      &info,
+		Started:   time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC),
+		Duration:  3 * time.Second,
+		ExitCode:  1,
+		Stderr:    "oops\n",
+		Error:     "hook failed",
+	}})
+}
+
+func (s *RecordingExecutorSuite) TestSkipNotRecorded(c *gc.C) {
+	err := s.newExecutor().Skip(&mockOperation{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.records, gc.HasLen, 0)
+	c.Assert(s.executor.skipped, jc.IsTrue)
+}
+
+func (s *RecordingExecutorSuite) TestRunSkipExecuteNotRecorded(c *gc.C) {
+	op := newRecordedOperation(operation.ErrSkipExecute)
+	err := s.newExecutor().Run(op)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.records, gc.HasLen, 0)
+	c.Assert(op.execute.called, jc.IsFalse)
+}
+
+// fakeExecutor prepares and executes operations, taking three seconds
+// to do so, and returns err.
+type fakeExecutor struct {
+	operation.Executor
+	clock   *coretesting.Clock
+	err     error
+	skipped bool
+}
+
+func (x *fakeExecutor) Run(op operation.Operation) error {
+	switch _, err := op.Prepare(operation.State{}); errors.Cause(err) {
+	case operation.ErrSkipExecute:
+	case nil:
+		op.Execute(operation.State{})
+	default:
+		return err
+	}
+	x.clock.Advance(3 * time.Second)
+	return x.err
+}
+
+func (x *fakeExecutor) Skip(op operation.Operation) error {
+	x.skipped = true
+	return nil
+}
@@ -86,6 +86,14 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// record is part of the recorder interface.
+func (ra *runAction) record(record *Record) {
+	if ra.runner != nil {
+		record.ExitCode = ra.runner.ExitCode()
+		record.Stderr = ra.runner.Stderr()
+	}
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
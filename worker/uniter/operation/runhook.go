@@ -69,6 +69,16 @@ func (rh *runHook) Prepare(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// record is part of the recorder interface.
+func (rh *runHook) record(record *Record) {
+	info := rh.info
+	record.Hook = &info
+	if rh.runner != nil {
+		record.ExitCode = rh.runner.ExitCode()
+		record.Stderr = rh.runner.Stderr()
+	}
+}
+
 // RunningHookMessage returns the info message to print when running a hook.
 func RunningHookMessage(hookName string) string {
 	return fmt.Sprintf("running %s hook", hookName)
@@ -103,6 +113,17 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
 	case err == nil:
+	case runner.IsHookTimeoutError(cause):
+		logger.Errorf("hook %q timed out: %v", rh.name, err)
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		// Record the timeout, so that it can be reported until the
+		// hook is retried or resolved.
+		return stateChange{
+			Kind:         RunHook,
+			Step:         Pending,
+			Hook:         &rh.info,
+			HookTimedOut: cause.(*runner.HookTimeoutError).Elapsed,
+		}.apply(state), ErrHookFailed
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
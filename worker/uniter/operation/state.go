@@ -5,6 +5,7 @@ package operation
 
 import (
 	"os"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
@@ -85,6 +86,11 @@ type State struct {
 	// upgrade is complete (instead of running an upgrade-charm hook).
 	Hook *hook.Info `yaml:"hook,omitempty"`
 
+	// HookTimedOut, if non-zero, indicates that the RunHook operation's
+	// hook was killed for exceeding its timeout, and holds the time for
+	// which it had been running.
+	HookTimedOut time.Duration `yaml:"hook-timed-out,omitempty"`
+
 	// ActionId holds action information relevant to the current operation. If
 	// Kind is Continue, it holds the last action that was executed; if Kind is
 	// RunAction, it holds the running action.
@@ -151,6 +157,9 @@ func (st State) validate() (err error) {
 	default:
 		return errors.Errorf("unknown operation step %q", st.Step)
 	}
+	if st.HookTimedOut != 0 && st.Kind != RunHook {
+		return errors.Errorf("unexpected hook timeout with Kind %s", st.Kind)
+	}
 	if hasHook {
 		return st.Hook.Validate()
 	}
@@ -165,6 +174,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimedOut    time.Duration
 }
 
 func (change stateChange) apply(state State) *State {
@@ -173,6 +183,7 @@ func (change stateChange) apply(state State) *State {
 	state.Hook = change.Hook
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
+	state.HookTimedOut = change.HookTimedOut
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
 	return &state
 }
@@ -5,6 +5,7 @@ package operation_test
 
 import (
 	"path/filepath"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -137,6 +138,13 @@ var stateTests = []struct {
 			Step: operation.Pending,
 			Hook: relhook,
 		},
+	}, {
+		st: operation.State{
+			Kind:         operation.RunHook,
+			Step:         operation.Pending,
+			Hook:         &hook.Info{Kind: hooks.Install},
+			HookTimedOut: 5 * time.Minute,
+		},
 	},
 	// Upgrade operation.
 	{
@@ -188,6 +196,13 @@ var stateTests = []struct {
 			Step:   operation.Pending,
 			Leader: true,
 		},
+	}, {
+		st: operation.State{
+			Kind:         operation.Continue,
+			Step:         operation.Pending,
+			HookTimedOut: 5 * time.Minute,
+		},
+		err: `unexpected hook timeout with Kind continue`,
 	},
 }
 
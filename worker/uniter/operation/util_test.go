@@ -362,7 +362,9 @@ type MockRunner struct {
 	*MockRunAction
 	*MockRunCommands
 	*MockRunHook
-	context runner.Context
+	context  runner.Context
+	exitCode int
+	stderr   string
 }
 
 func (r *MockRunner) Context() runner.Context {
@@ -377,6 +379,14 @@ func (r *MockRunner) RunCommands(commands string) (*utilexec.ExecResponse, error
 	return r.MockRunCommands.Call(commands)
 }
 
+func (r *MockRunner) ExitCode() int {
+	return r.exitCode
+}
+
+func (r *MockRunner) Stderr() string {
+	return r.stderr
+}
+
 func (r *MockRunner) RunHook(hookName string) error {
 	r.Context().(*MockContext).setStatusCalled = r.MockRunHook.setStatusCalled
 	return r.MockRunHook.Call(hookName)
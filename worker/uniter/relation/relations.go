@@ -190,13 +190,13 @@ func (r *relations) NextHook(
 			continue
 		}
 		var remoteBroken bool
-		if remoteState.Life == params.Dying || relationSnapshot.Life == params.Dying {
+		if remoteState.Life == params.Dying || relationSnapshot.Life == params.Dying || relationSnapshot.Suspended {
 			relationSnapshot = remotestate.RelationSnapshot{}
 			remoteBroken = true
 			// TODO(axw) if relation is implicit, leave scope & remove.
 		}
-		// If either the unit or the relation are Dying,
-		// then the relation should be broken.
+		// If either the unit or the relation are Dying, or the
+		// relation is suspended, then the relation should be broken.
 		hook, err := nextRelationHook(relationer.dir.State(), relationSnapshot, remoteBroken)
 		if err == resolver.ErrNoOperation {
 			continue
@@ -358,10 +358,11 @@ func (r *relations) update(remote map[int]remotestate.RelationSnapshot) error {
 	for id, relationSnapshot := range remote {
 		if _, found := r.relationers[id]; found {
 			// We've seen this relation before. The only changes
-			// we care about are to the lifecycle state, and to
-			// the member settings versions. We handle differences
-			// in settings in nextRelationHook.
-			if relationSnapshot.Life == params.Dying {
+			// we care about are to the lifecycle state, to the
+			// suspension of the relation, and to the member
+			// settings versions. We handle differences in
+			// settings in nextRelationHook.
+			if relationSnapshot.Life == params.Dying || relationSnapshot.Suspended {
 				if err := r.setDying(id); err != nil {
 					return errors.Trace(err)
 				}
@@ -369,8 +370,9 @@ func (r *relations) update(remote map[int]remotestate.RelationSnapshot) error {
 			continue
 		}
 		// Relations that are not alive are simply skipped, because they
-		// were not previously known anyway.
-		if relationSnapshot.Life != params.Alive {
+		// were not previously known anyway. Suspended relations are
+		// skipped until they are resumed.
+		if relationSnapshot.Life != params.Alive || relationSnapshot.Suspended {
 			continue
 		}
 		rel, err := r.st.RelationById(id)
@@ -429,6 +429,86 @@ func (s *relationsSuite) TestHookRelationBroken(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
 }
 
+func (s *relationsSuite) TestHookRelationSuspended(c *gc.C) {
+	var numCalls int32
+	apiCalls := relationJoinedApiCalls()
+	apiCalls = append(apiCalls, getPrincipalApiCalls(3)...)
+	r := s.assertHookRelationJoined(c, &numCalls, apiCalls...)
+	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
+		Life: params.Alive,
+	}, &numCalls)
+
+	// A suspended relation is departed and broken, like a dying one,
+	// even though its remote units have not left it yet.
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: remotestate.RelationSnapshot{
+				Life:      params.Alive,
+				Suspended: true,
+				Members: map[string]int64{
+					"wordpress": 1,
+				},
+			},
+		},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+	op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook relation-departed on unit with relation 1")
+	_, err = r.PrepareHook(op.(*mockOperation).hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.CommitHook(op.(*mockOperation).hookInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	op, err = relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	assertNumCalls(c, &numCalls, 11)
+	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
+}
+
+func (s *relationsSuite) TestSuspendedRelationNotJoined(c *gc.C) {
+	unitTag := names.NewUnitTag("wordpress/0")
+	abort := make(chan struct{})
+
+	var numCalls int32
+	unitEntity := params.Entities{Entities: []params.Entity{params.Entity{Tag: "unit-wordpress-0"}}}
+	apiCaller := mockAPICaller(c, &numCalls,
+		uniterApiCall("Life", unitEntity, params.LifeResults{Results: []params.LifeResult{{Life: params.Alive}}}, nil),
+		uniterApiCall("JoinedRelations", unitEntity, params.StringsResults{Results: []params.StringsResult{{Result: []string{}}}}, nil),
+		uniterApiCall("GetPrincipal", unitEntity, params.StringBoolResults{Results: []params.StringBoolResult{{Result: "", Ok: false}}}, nil),
+	)
+	st := uniter.NewState(apiCaller, unitTag)
+	r, err := relation.NewRelations(st, unitTag, s.stateDir, s.relationsDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: remotestate.RelationSnapshot{
+				Life:      params.Alive,
+				Suspended: true,
+				Members: map[string]int64{
+					"wordpress": 1,
+				},
+			},
+		},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+	_, err = relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+	assertNumCalls(c, &numCalls, 3)
+	c.Assert(r.GetInfo(), gc.HasLen, 0)
+}
+
 func (s *relationsSuite) TestCommitHook(c *gc.C) {
 	var numCalls int32
 	apiCalls := relationJoinedApiCalls()
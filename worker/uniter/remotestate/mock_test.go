@@ -277,8 +277,9 @@ func (s *mockService) WatchRelations() (watcher.StringsWatcher, error) {
 }
 
 type mockRelation struct {
-	id   int
-	life params.Life
+	id        int
+	life      params.Life
+	suspended bool
 }
 
 func (r *mockRelation) Id() int {
@@ -289,6 +290,10 @@ func (r *mockRelation) Life() params.Life {
 	return r.life
 }
 
+func (r *mockRelation) Suspended() bool {
+	return r.suspended
+}
+
 type mockLeadershipTracker struct {
 	leadership.Tracker
 	claimTicket  mockTicket
@@ -71,8 +71,9 @@ type Snapshot struct {
 }
 
 type RelationSnapshot struct {
-	Life    params.Life
-	Members map[string]int64
+	Life      params.Life
+	Suspended bool
+	Members   map[string]int64
 }
 
 // StorageSnapshot has information relating to a storage
@@ -82,4 +83,5 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+	Size     uint64
 }
@@ -59,6 +59,7 @@ type Service interface {
 type Relation interface {
 	Id() int
 	Life() params.Life
+	Suspended() bool
 }
 
 func NewAPIState(st *uniter.State) State {
@@ -539,6 +539,7 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 			if _, ok := w.relations[relationTag]; ok {
 				relationSnapshot := w.current.Relations[rel.Id()]
 				relationSnapshot.Life = rel.Life()
+				relationSnapshot.Suspended = rel.Suspended()
 				w.current.Relations[rel.Id()] = relationSnapshot
 				continue
 			}
@@ -567,8 +568,9 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 	rel Relation, relationTag names.RelationTag, ruw watcher.RelationUnitsWatcher,
 ) error {
 	relationSnapshot := RelationSnapshot{
-		Life:    rel.Life(),
-		Members: make(map[string]int64),
+		Life:      rel.Life(),
+		Suspended: rel.Suspended(),
+		Members:   make(map[string]int64),
 	}
 	select {
 	case <-w.catacomb.Dying():
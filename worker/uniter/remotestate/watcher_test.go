@@ -448,10 +448,16 @@ func (s *WatcherSuite) TestRelationsChanged(c *gc.C) {
 
 	// If a relation is known, then updating it does not require any input
 	// from the relation units watcher.
+	s.st.relations[relationTag].suspended = true
+	s.st.unit.service.relationsWatcher.changes <- []string{relationTag.Id()}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Relations[123].Suspended, jc.IsTrue)
+	s.st.relations[relationTag].suspended = false
 	s.st.relations[relationTag].life = params.Dying
 	s.st.unit.service.relationsWatcher.changes <- []string{relationTag.Id()}
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
 	c.Assert(s.watcher.Snapshot().Relations[123].Life, gc.Equals, params.Dying)
+	c.Assert(s.watcher.Snapshot().Relations[123].Suspended, jc.IsFalse)
 
 	// If a relation is not found, then it should be removed from the
 	// snapshot and its relation units watcher stopped.
@@ -17,7 +17,7 @@ import (
 // ResolverConfig defines configuration for the uniter resolver.
 type ResolverConfig struct {
 	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
+	ReportHookError     func(operation.State) error
 	FixDeployer         func() error
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
@@ -159,7 +159,7 @@ func (s *uniterResolver) nextOpHookError(
 ) (operation.Operation, error) {
 
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	if err := s.config.ReportHookError(localState.State); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	"github.com/juju/testing"
@@ -33,7 +35,7 @@ type resolverSuite struct {
 	resolver             resolver.Resolver
 
 	clearResolved   func() error
-	reportHookError func(hook.Info) error
+	reportHookError func(operation.State) error
 }
 
 var _ = gc.Suite(&resolverSuite{})
@@ -54,13 +56,13 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		return errors.New("unexpected resolved")
 	}
 
-	s.reportHookError = func(hook.Info) error {
+	s.reportHookError = func(operation.State) error {
 		return errors.New("unexpected report hook error")
 	}
 
 	s.resolver = uniter.NewUniterResolver(uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
-		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
+		ReportHookError:     func(st operation.State) error { return s.reportHookError(st) },
 		FixDeployer:         func() error { return nil },
 		StartRetryHookTimer: func() { s.stub.AddCall("StartRetryHookTimer") },
 		StopRetryHookTimer:  func() { s.stub.AddCall("StopRetryHookTimer") },
@@ -107,7 +109,7 @@ func (s *resolverSuite) TestNotStartedNotInstalled(c *gc.C) {
 }
 
 func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(operation.State) error { return nil }
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
 		CharmURL:             s.charmURL,
@@ -132,8 +134,30 @@ func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer") // no change
 }
 
+func (s *resolverSuite) TestHookErrorReportsTimeout(c *gc.C) {
+	var reported []operation.State
+	s.reportHookError = func(st operation.State) error {
+		reported = append(reported, st)
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:         operation.RunHook,
+			Step:         operation.Pending,
+			Installed:    true,
+			Hook:         &hook.Info{Kind: hooks.Install},
+			HookTimedOut: 5 * time.Minute,
+		},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(reported, jc.DeepEquals, []operation.State{localState.State})
+}
+
 func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(operation.State) error { return nil }
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
 		CharmURL:             s.charmURL,
@@ -177,7 +201,7 @@ func (s *resolverSuite) TestResolvedNoHooksStopRetryTimer(c *gc.C) {
 func (s *resolverSuite) testResolveHookErrorStopRetryTimer(c *gc.C, mode params.ResolvedMode) {
 	s.stub.ResetCalls()
 	s.clearResolved = func() error { return nil }
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(operation.State) error { return nil }
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
 		CharmURL:             s.charmURL,
@@ -203,7 +227,7 @@ func (s *resolverSuite) testResolveHookErrorStopRetryTimer(c *gc.C, mode params.
 }
 
 func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(operation.State) error { return nil }
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
 		CharmURL:             s.charmURL,
@@ -383,6 +383,39 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// SetWorkloadVersion is part of the jujuc.ContextWorkload interface.
+func (ctx *HookContext) SetWorkloadVersion(version string) error {
+	return ctx.unit.SetWorkloadVersion(version)
+}
+
+// SetWorkloadInfo is part of the jujuc.ContextWorkload interface.
+func (ctx *HookContext) SetWorkloadInfo(changes map[string]string) error {
+	return ctx.unit.SetWorkloadInfo(changes)
+}
+
+// AddSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) AddSecret(name string, values map[string]string) (int, error) {
+	return ctx.unit.AddSecretRevision(name, values)
+}
+
+// SecretValues is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) SecretValues(owner, name string, revision int) (map[string]string, error) {
+	if !names.IsValidService(owner) {
+		return nil, errors.NotValidf("service name %q", owner)
+	}
+	_, values, err := ctx.unit.SecretValues(names.NewServiceTag(owner), name, revision)
+	return values, err
+}
+
+// GrantSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *HookContext) GrantSecret(name string, relationId int) error {
+	r, found := ctx.relations[relationId]
+	if !found {
+		return errors.NotFoundf("relation %d", relationId)
+	}
+	return ctx.unit.GrantSecret(name, r.ru.Relation().Tag())
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -459,6 +492,49 @@ func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	return unitRanges
 }
 
+// leaseScopeTag returns the tag of the entity to which a charm lease
+// with the supplied scope belongs.
+func (ctx *HookContext) leaseScopeTag(scope jujuc.LeaseScope) (names.Tag, error) {
+	switch scope {
+	case jujuc.LeaseScopeService:
+		svc, err := names.UnitService(ctx.unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return names.NewServiceTag(svc), nil
+	case jujuc.LeaseScopeModel:
+		return names.NewModelTag(ctx.uuid), nil
+	}
+	return nil, errors.NotValidf("lease scope %q", scope)
+}
+
+// ClaimLease is part of the jujuc.ContextLeases interface.
+func (ctx *HookContext) ClaimLease(scope jujuc.LeaseScope, name string, duration time.Duration) error {
+	scopeTag, err := ctx.leaseScopeTag(scope)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.unit.ClaimCharmLease(scopeTag, name, duration)
+}
+
+// ReleaseLease is part of the jujuc.ContextLeases interface.
+func (ctx *HookContext) ReleaseLease(scope jujuc.LeaseScope, name string) error {
+	scopeTag, err := ctx.leaseScopeTag(scope)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ctx.unit.ReleaseCharmLease(scopeTag, name)
+}
+
+// CheckLease is part of the jujuc.ContextLeases interface.
+func (ctx *HookContext) CheckLease(scope jujuc.LeaseScope, name string) (bool, error) {
+	scopeTag, err := ctx.leaseScopeTag(scope)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return ctx.unit.CheckCharmLease(scopeTag, name)
+}
+
 func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	if ctx.configSettings == nil {
 		var err error
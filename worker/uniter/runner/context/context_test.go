@@ -7,6 +7,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/juju/names"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -394,6 +395,71 @@ func (s *InterfaceSuite) TestStorageAddConstraintsDifferentStorage(c *gc.C) {
 	assertStorageAddInContext(c, ctx, expected)
 }
 
+func (s *InterfaceSuite) TestServiceLease(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	err := ctx.ClaimLease(jujuc.LeaseScopeService, "backup", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	held, err := ctx.CheckLease(jujuc.LeaseScopeService, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsTrue)
+
+	err = ctx.ReleaseLease(jujuc.LeaseScopeService, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	held, err = ctx.CheckLease(jujuc.LeaseScopeService, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsFalse)
+}
+
+func (s *InterfaceSuite) TestModelLease(c *gc.C) {
+	ctx := s.getHookContext(c, s.State.ModelUUID(), -1, "", noProxies)
+	err := ctx.ClaimLease(jujuc.LeaseScopeModel, "backup", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	held, err := ctx.CheckLease(jujuc.LeaseScopeModel, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsTrue)
+	held, err = ctx.CheckLease(jujuc.LeaseScopeService, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsFalse)
+}
+
+func (s *InterfaceSuite) TestInvalidLeaseScope(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	err := ctx.ClaimLease("unit", "backup", time.Minute)
+	c.Check(err, gc.ErrorMatches, `lease scope "unit" not valid`)
+}
+
+func (s *InterfaceSuite) TestSetWorkloadVersion(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	err := ctx.SetWorkloadVersion("5.7.13")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.SetWorkloadInfo(map[string]string{"edition": "community"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.unit.WorkloadVersion(), gc.Equals, "5.7.13")
+	c.Check(s.unit.WorkloadInfo(), jc.DeepEquals, map[string]string{"edition": "community"})
+}
+
+func (s *InterfaceSuite) TestSecrets(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	revision, err := ctx.AddSecret("admin", map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revision, gc.Equals, 1)
+	values, err := ctx.SecretValues("u", "admin", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(values, jc.DeepEquals, map[string]string{"password": "sekrit"})
+
+	err = ctx.GrantSecret("admin", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(s.service.ServiceTag(), "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead(names.NewServiceTag("db0")), jc.IsTrue)
+
+	err = ctx.GrantSecret("admin", 99)
+	c.Check(err, gc.ErrorMatches, "relation 99 not found")
+}
+
 func addStorageToContext(ctx *context.HookContext,
 	name string,
 	cons params.StorageConstraints,
@@ -5,6 +5,7 @@ package runner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -28,3 +29,25 @@ func IsBadActionError(err error) bool {
 func NewBadActionError(actionName, problem string) error {
 	return &badActionError{actionName, problem}
 }
+
+// HookTimeoutError is returned when a hook is killed because it ran for
+// longer than its timeout.
+type HookTimeoutError struct {
+	// HookName is the name of the hook that was killed.
+	HookName string
+
+	// Elapsed is the time for which the hook ran before it was killed.
+	Elapsed time.Duration
+}
+
+// Error is part of the error interface.
+func (e *HookTimeoutError) Error() string {
+	return fmt.Sprintf("hook %q timed out after %v", e.HookName, e.Elapsed)
+}
+
+// IsHookTimeoutError returns true if the cause of the supplied error is a
+// *HookTimeoutError.
+func IsHookTimeoutError(err error) bool {
+	_, ok := errors.Cause(err).(*HookTimeoutError)
+	return ok
+}
@@ -4,6 +4,8 @@
 package runner
 
 import (
+	"time"
+
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
 
@@ -17,3 +19,15 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+func RunnerTimeout(rnr Runner) time.Duration {
+	return rnr.(*runner).timeout
+}
+
+func RunnerSnapshotDir(rnr Runner) string {
+	return rnr.(*runner).snapshotDir
+}
+
+func SetRunnerSnapshotDir(rnr Runner, dir string) {
+	rnr.(*runner).snapshotDir = dir
+}
@@ -4,14 +4,22 @@
 package runner
 
 import (
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 )
 
 // Factory represents a long-lived object that can create runners
@@ -32,11 +40,13 @@ type Factory interface {
 }
 
 // NewFactory returns a Factory capable of creating runners for executing
-// charm hooks, actions and commands.
+// charm hooks, actions and commands. Hook runners use the supplied clock
+// to time out hooks.
 func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
+	clock clock.Clock,
 ) (
 	Factory, error,
 ) {
@@ -44,6 +54,7 @@ func NewFactory(
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		clock:          clock,
 	}
 
 	return f, nil
@@ -57,6 +68,7 @@ type factory struct {
 
 	// Fields that shouldn't change in a factory's lifetime.
 	paths context.Paths
+	clock clock.Clock
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -75,14 +87,70 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 		return nil, errors.Trace(err)
 	}
 
+	cfg, err := f.state.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model config")
+	}
+	timeout, err := f.hookTimeout(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	ctx, err := f.contextFactory.HookContext(hookInfo)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := &runner{
+		context: ctx,
+		paths:   f.paths,
+		timeout: timeout,
+		clock:   f.clock,
+	}
+	if cfg.CaptureFailedHooks() {
+		runner.snapshotDir = f.paths.ComponentDir(replay.SnapshotsDir)
+	}
 	return runner, nil
 }
 
+// hookTimeout returns the maximum time for which a hook of the deployed
+// charm may run: the hook-timeout declared in the charm's metadata if it
+// has one, and otherwise the hook-timeout in the supplied model config.
+// Zero means that hooks are not timed out.
+func (f *factory) hookTimeout(cfg *config.Config) (time.Duration, error) {
+	timeout, ok, err := charmHookTimeout(f.paths.GetCharmDir())
+	if err != nil {
+		return 0, errors.Trace(err)
+	} else if ok {
+		return timeout, nil
+	}
+	timeout, _ = cfg.HookTimeout()
+	return timeout, nil
+}
+
+// charmHookTimeout returns the hook-timeout declared in the metadata of
+// the charm in the supplied directory, and whether it declared one.
+//
+// The field is read directly from metadata.yaml, which charm.Meta does
+// not expose, and is ignored by older agents.
+func charmHookTimeout(charmDir string) (time.Duration, bool, error) {
+	var meta struct {
+		HookTimeout string `yaml:"hook-timeout"`
+	}
+	err := utils.ReadYaml(filepath.Join(charmDir, "metadata.yaml"), &meta)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, errors.Annotate(err, "cannot read charm metadata")
+	}
+	if meta.HookTimeout == "" {
+		return 0, false, nil
+	}
+	timeout, err := time.ParseDuration(meta.HookTimeout)
+	if err != nil || timeout < 0 {
+		return 0, false, errors.Errorf("invalid hook-timeout %q in charm metadata", meta.HookTimeout)
+	}
+	return timeout, true, nil
+}
+
 // NewActionRunner exists to satisfy the Factory interface.
 func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 	ch, err := getCharm(f.paths.GetCharmDir())
@@ -4,7 +4,9 @@
 package runner_test
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -113,6 +115,63 @@ func (s *FactorySuite) TestNewHookRunner(c *gc.C) {
 	s.AssertPaths(c, rnr)
 }
 
+func (s *FactorySuite) TestNewHookRunnerNoTimeout(c *gc.C) {
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerTimeout(rnr), gc.Equals, time.Duration(0))
+}
+
+func (s *FactorySuite) TestNewHookRunnerModelTimeout(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"hook-timeout": "30m"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerTimeout(rnr), gc.Equals, 30*time.Minute)
+}
+
+func (s *FactorySuite) TestNewHookRunnerNoCapture(c *gc.C) {
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerSnapshotDir(rnr), gc.Equals, "")
+}
+
+func (s *FactorySuite) TestNewHookRunnerCaptureFailedHooks(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"capture-failed-hooks": true}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerSnapshotDir(rnr), gc.Equals, s.paths.ComponentDir("replay"))
+}
+
+func (s *FactorySuite) TestNewHookRunnerCharmTimeout(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"hook-timeout": "30m"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.writeCharmMetadata(c, "name: wordpress\nhook-timeout: 2h\n")
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerTimeout(rnr), gc.Equals, 2*time.Hour)
+}
+
+func (s *FactorySuite) TestNewHookRunnerBadCharmTimeout(c *gc.C) {
+	s.writeCharmMetadata(c, "name: wordpress\nhook-timeout: forever\n")
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(rnr, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, `invalid hook-timeout "forever" in charm metadata`)
+}
+
+func (s *FactorySuite) TestNewCommandRunnerNoTimeout(c *gc.C) {
+	s.writeCharmMetadata(c, "name: wordpress\nhook-timeout: 2h\n")
+	rnr, err := s.factory.NewCommandRunner(context.CommandInfo{RelationId: -1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerTimeout(rnr), gc.Equals, time.Duration(0))
+}
+
+func (s *FactorySuite) writeCharmMetadata(c *gc.C, content string) {
+	path := filepath.Join(s.paths.GetCharmDir(), "metadata.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *FactorySuite) TestNewHookRunnerWithBadHook(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{})
 	c.Assert(rnr, gc.IsNil)
@@ -174,6 +233,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		uniter,
 		s.paths,
 		contextFactory,
+		testing.NewClock(time.Time{}),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
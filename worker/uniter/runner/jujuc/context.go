@@ -45,9 +45,12 @@ type Context interface {
 type HookContext interface {
 	ContextUnit
 	ContextStatus
+	ContextWorkload
+	ContextSecrets
 	ContextInstance
 	ContextNetworking
 	ContextLeadership
+	ContextLeases
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -123,6 +126,37 @@ type ContextStatus interface {
 	SetServiceStatus(StatusInfo) error
 }
 
+// ContextWorkload is the part of a hook context related to the software
+// the unit runs.
+type ContextWorkload interface {
+	// SetWorkloadVersion records the version of the software the
+	// unit runs.
+	SetWorkloadVersion(version string) error
+
+	// SetWorkloadInfo updates the information about the software the
+	// unit runs. Keys with empty values are removed.
+	SetWorkloadInfo(changes map[string]string) error
+}
+
+// ContextSecrets is the part of a hook context related to secrets, which
+// are named sets of values owned by a service and stored encrypted by
+// the controller.
+type ContextSecrets interface {
+	// AddSecret stores the supplied values as a new revision of the named
+	// secret owned by the unit's service, creating the secret if it does
+	// not exist, and returns the new revision.
+	AddSecret(name string, values map[string]string) (int, error)
+
+	// SecretValues returns the values of the supplied revision of the
+	// named secret owned by the named service; revision 0 denotes the
+	// latest.
+	SecretValues(owner, name string, revision int) (map[string]string, error)
+
+	// GrantSecret allows the services at the other end of the identified
+	// relation to read the named secret owned by the unit's service.
+	GrantSecret(name string, relationId int) error
+}
+
 // ContextInstance is the part of a hook context related to the unit's instance.
 type ContextInstance interface {
 	// AvailabilityZone returns the executing unit's availability zone or an error
@@ -176,6 +210,43 @@ type ContextLeadership interface {
 	WriteLeaderSettings(map[string]string) error
 }
 
+// LeaseScope determines which units contend for a charm lease.
+type LeaseScope string
+
+const (
+	// LeaseScopeService leases are contended by the units of the
+	// executing unit's service.
+	LeaseScopeService LeaseScope = "service"
+
+	// LeaseScopeModel leases are contended by every unit in the model.
+	LeaseScopeModel LeaseScope = "model"
+)
+
+// ParseLeaseScope returns the LeaseScope with the supplied name.
+func ParseLeaseScope(value string) (LeaseScope, error) {
+	switch scope := LeaseScope(value); scope {
+	case LeaseScopeService, LeaseScopeModel:
+		return scope, nil
+	}
+	return "", errors.Errorf("invalid lease scope %q, expected %q or %q", value, LeaseScopeService, LeaseScopeModel)
+}
+
+// ContextLeases is the part of a hook context related to named leases
+// that charms claim for their own purposes, independent of leadership.
+type ContextLeases interface {
+	// ClaimLease claims, or extends, the named lease on behalf of the
+	// executing unit, for at least the supplied duration. If another unit
+	// holds the lease, the returned error's cause is lease.ErrClaimDenied.
+	ClaimLease(scope LeaseScope, name string, duration time.Duration) error
+
+	// ReleaseLease gives up the named lease, if the executing unit holds
+	// it. Otherwise, the returned error's cause is lease.ErrNotHeld.
+	ReleaseLease(scope LeaseScope, name string) error
+
+	// CheckLease reports whether the executing unit holds the named lease.
+	CheckLease(scope LeaseScope, name string) (bool, error)
+}
+
 // ContextMetrics is the part of a hook context related to metrics.
 type ContextMetrics interface {
 	// AddMetric records a metric to return after hook execution.
@@ -11,3 +11,5 @@ import (
 func HandleSettingsFile(c *RelationSetCommand, ctx *cmd.Context) error {
 	return c.handleSettingsFile(ctx)
 }
+
+var LoggableArgs = loggableArgs
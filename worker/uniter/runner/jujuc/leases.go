@@ -0,0 +1,177 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// defaultLeaseDuration is the time for which lease-claim claims a lease if
+// no --duration is given.
+const defaultLeaseDuration = time.Minute
+
+// leaseCommand holds the flags and arguments common to the lease commands.
+type leaseCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	scopeFlag string
+	Scope     LeaseScope
+	Name      string
+}
+
+func (c *leaseCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.scopeFlag, "scope", string(LeaseScopeService), `the units that contend for the lease: "service" or "model"`)
+}
+
+func (c *leaseCommand) Init(args []string) error {
+	scope, err := ParseLeaseScope(c.scopeFlag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.Scope = scope
+	if len(args) == 0 {
+		return errors.New("no lease name specified")
+	}
+	c.Name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// LeaseClaimCommand implements the lease-claim command.
+type LeaseClaimCommand struct {
+	leaseCommand
+	Duration time.Duration
+}
+
+// NewLeaseClaimCommand returns a new LeaseClaimCommand with the given context.
+func NewLeaseClaimCommand(ctx Context) (cmd.Command, error) {
+	return &LeaseClaimCommand{leaseCommand: leaseCommand{ctx: ctx}}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *LeaseClaimCommand) Info() *cmd.Info {
+	doc := `
+lease-claim claims the named lease for the local unit, or extends it if the
+unit already holds it, so that no other unit can hold it for at least the
+requested duration. It fails if another unit holds the lease.
+
+By default the lease is only contended by units of the local unit's service;
+with --scope=model, every unit in the model contends for it. A unit must
+claim a lease again before it runs out if it wants to keep it, and should
+use lease-release when it is done.
+
+Leases are entirely independent of service leadership, and may be used to
+ensure that, for example, only one unit runs a nightly job.
+`
+	return &cmd.Info{
+		Name:    "lease-claim",
+		Args:    "<name>",
+		Purpose: "claim or extend a named lease",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *LeaseClaimCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.leaseCommand.SetFlags(f)
+	f.DurationVar(&c.Duration, "duration", defaultLeaseDuration, "how long to hold the lease (at most 1h)")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *LeaseClaimCommand) Init(args []string) error {
+	if c.Duration <= 0 {
+		return errors.Errorf("invalid duration %v", c.Duration)
+	}
+	return c.leaseCommand.Init(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *LeaseClaimCommand) Run(ctx *cmd.Context) error {
+	err := c.ctx.ClaimLease(c.Scope, c.Name, c.Duration)
+	if errors.Cause(err) == lease.ErrClaimDenied {
+		return errors.Errorf("lease %q is held by another unit", c.Name)
+	}
+	return errors.Annotatef(err, "cannot claim lease %q", c.Name)
+}
+
+// LeaseReleaseCommand implements the lease-release command.
+type LeaseReleaseCommand struct {
+	leaseCommand
+}
+
+// NewLeaseReleaseCommand returns a new LeaseReleaseCommand with the given
+// context.
+func NewLeaseReleaseCommand(ctx Context) (cmd.Command, error) {
+	return &LeaseReleaseCommand{leaseCommand{ctx: ctx}}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *LeaseReleaseCommand) Info() *cmd.Info {
+	doc := `
+lease-release gives up a lease held by the local unit, so that other units
+may claim it without waiting for it to run out. It fails if the local unit
+does not hold the lease.
+`
+	return &cmd.Info{
+		Name:    "lease-release",
+		Args:    "<name>",
+		Purpose: "give up a named lease",
+		Doc:     doc,
+	}
+}
+
+// Run is part of the cmd.Command interface.
+func (c *LeaseReleaseCommand) Run(ctx *cmd.Context) error {
+	err := c.ctx.ReleaseLease(c.Scope, c.Name)
+	if errors.Cause(err) == lease.ErrNotHeld {
+		return errors.Errorf("lease %q is not held by this unit", c.Name)
+	}
+	return errors.Annotatef(err, "cannot release lease %q", c.Name)
+}
+
+// LeaseCheckCommand implements the lease-check command.
+type LeaseCheckCommand struct {
+	leaseCommand
+	out cmd.Output
+}
+
+// NewLeaseCheckCommand returns a new LeaseCheckCommand with the given context.
+func NewLeaseCheckCommand(ctx Context) (cmd.Command, error) {
+	return &LeaseCheckCommand{leaseCommand: leaseCommand{ctx: ctx}}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *LeaseCheckCommand) Info() *cmd.Info {
+	doc := `
+lease-check prints a boolean indicating whether the local unit currently
+holds the named lease. If it fails, you should assume that it does not.
+`
+	return &cmd.Info{
+		Name:    "lease-check",
+		Args:    "<name>",
+		Purpose: "print whether the local unit holds a named lease",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *LeaseCheckCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.leaseCommand.SetFlags(f)
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *LeaseCheckCommand) Run(ctx *cmd.Context) error {
+	held, err := c.ctx.CheckLease(c.Scope, c.Name)
+	if err != nil {
+		return errors.Annotatef(err, "lease status unknown")
+	}
+	return c.out.Write(ctx, held)
+}
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type LeasesSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&LeasesSuite{})
+
+func (s *LeasesSuite) run(c *gc.C, hctx *Context, args ...string) (int, string, string) {
+	com, err := jujuc.NewCommand(hctx, cmdString(args[0]))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args[1:])
+	return code, bufferString(ctx.Stdout), bufferString(ctx.Stderr)
+}
+
+func (s *LeasesSuite) TestClaimCheckRelease(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for i, t := range []struct {
+		args   []string
+		stdout string
+	}{
+		{[]string{"lease-check", "backup"}, "False\n"},
+		{[]string{"lease-claim", "backup"}, ""},
+		{[]string{"lease-check", "backup"}, "True\n"},
+		{[]string{"lease-check", "--scope", "model", "backup"}, "False\n"},
+		{[]string{"lease-claim", "--scope", "model", "--duration", "10m", "backup"}, ""},
+		{[]string{"lease-release", "backup"}, ""},
+		{[]string{"lease-check", "backup"}, "False\n"},
+		{[]string{"lease-check", "--scope", "model", "backup", "--format", "json"}, "true\n"},
+	} {
+		c.Logf("test %d: %v", i, t.args)
+		code, stdout, stderr := s.run(c, hctx, t.args...)
+		c.Check(code, gc.Equals, 0)
+		c.Check(stdout, gc.Equals, t.stdout)
+		c.Check(stderr, gc.Equals, "")
+	}
+	s.Stub.CheckCall(c, 1, "ClaimLease", jujuc.LeaseScopeService, "backup", time.Minute)
+	s.Stub.CheckCall(c, 4, "ClaimLease", jujuc.LeaseScopeModel, "backup", 10*time.Minute)
+}
+
+func (s *LeasesSuite) TestClaimDenied(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.Annotate(lease.ErrClaimDenied, "nope"))
+	code, stdout, stderr := s.run(c, hctx, "lease-claim", "backup")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, `error: lease "backup" is held by another unit`+"\n")
+}
+
+func (s *LeasesSuite) TestReleaseNotHeld(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	code, stdout, stderr := s.run(c, hctx, "lease-release", "--scope", "model", "backup")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, `error: lease "backup" is not held by this unit`+"\n")
+}
+
+func (s *LeasesSuite) TestCheckError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("pow"))
+	code, stdout, stderr := s.run(c, hctx, "lease-check", "backup")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "error: lease status unknown: pow\n")
+}
+
+var badLeaseArgsTests = []struct {
+	args []string
+	err  string
+}{
+	{[]string{"lease-claim"}, "no lease name specified"},
+	{[]string{"lease-claim", "a", "b"}, `unrecognized args: \["b"\]`},
+	{[]string{"lease-claim", "--duration", "0", "a"}, "invalid duration 0s"},
+	{[]string{"lease-claim", "--scope", "world", "a"}, `invalid lease scope "world", expected "service" or "model"`},
+	{[]string{"lease-release"}, "no lease name specified"},
+	{[]string{"lease-release", "--scope", "unit", "a"}, `invalid lease scope "unit", expected "service" or "model"`},
+	{[]string{"lease-check"}, "no lease name specified"},
+	{[]string{"lease-check", "a", "b"}, `unrecognized args: \["b"\]`},
+}
+
+func (s *LeasesSuite) TestBadArgs(c *gc.C) {
+	for i, t := range badLeaseArgsTests {
+		c.Logf("test %d: %v", i, t.args)
+		hctx := s.GetHookContext(c, -1, "")
+		com, err := jujuc.NewCommand(hctx, cmdString(t.args[0]))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args[1:])
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
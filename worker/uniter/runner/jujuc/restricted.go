@@ -39,6 +39,25 @@ func (*RestrictedContext) ServiceStatus() (ServiceStatusInfo, error) {
 // SetServiceStatus implements jujuc.Context.
 func (*RestrictedContext) SetServiceStatus(StatusInfo) error { return ErrRestrictedContext }
 
+// SetWorkloadVersion implements jujuc.Context.
+func (*RestrictedContext) SetWorkloadVersion(string) error { return ErrRestrictedContext }
+
+// SetWorkloadInfo implements jujuc.Context.
+func (*RestrictedContext) SetWorkloadInfo(map[string]string) error { return ErrRestrictedContext }
+
+// AddSecret implements jujuc.Context.
+func (*RestrictedContext) AddSecret(string, map[string]string) (int, error) {
+	return 0, ErrRestrictedContext
+}
+
+// SecretValues implements jujuc.Context.
+func (*RestrictedContext) SecretValues(string, string, int) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements jujuc.Context.
+func (*RestrictedContext) GrantSecret(string, int) error { return ErrRestrictedContext }
+
 // AvailabilityZone implements jujuc.Context.
 func (*RestrictedContext) AvailabilityZone() (string, error) { return "", ErrRestrictedContext }
 
@@ -75,6 +94,19 @@ func (*RestrictedContext) LeaderSettings() (map[string]string, error) {
 // WriteLeaderSettings implements jujuc.Context.
 func (*RestrictedContext) WriteLeaderSettings(map[string]string) error { return ErrRestrictedContext }
 
+// ClaimLease implements jujuc.Context.
+func (*RestrictedContext) ClaimLease(LeaseScope, string, time.Duration) error {
+	return ErrRestrictedContext
+}
+
+// ReleaseLease implements jujuc.Context.
+func (*RestrictedContext) ReleaseLease(LeaseScope, string) error { return ErrRestrictedContext }
+
+// CheckLease implements jujuc.Context.
+func (*RestrictedContext) CheckLease(LeaseScope, string) (bool, error) {
+	return false, ErrRestrictedContext
+}
+
 // AddMetric implements jujuc.Context.
 func (*RestrictedContext) AddMetric(string, string, time.Time) error { return ErrRestrictedContext }
 
@@ -0,0 +1,291 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/keyvalues"
+	goyaml "gopkg.in/yaml.v2"
+	"launchpad.net/gnuflag"
+)
+
+// parseSecretId splits a secret identifier of the form [<service>/]<name>
+// into the name of the owning service, which is empty if not specified,
+// and the name of the secret.
+func parseSecretId(id string) (owner, name string, err error) {
+	name = id
+	if i := strings.Index(id, "/"); i >= 0 {
+		owner, name = id[:i], id[i+1:]
+		if !names.IsValidService(owner) {
+			return "", "", errors.Errorf("invalid secret %q", id)
+		}
+	}
+	if name == "" || strings.Contains(name, "=") {
+		return "", "", errors.Errorf("invalid secret %q", id)
+	}
+	return owner, name, nil
+}
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx        Context
+	name       string
+	values     map[string]string
+	valuesFile cmd.FileVar
+}
+
+// NewSecretAddCommand returns a new secret-add command with the given
+// context.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add stores a set of values, such as a password, as a new revision of
+the named secret owned by the unit's service, creating the secret if it does
+not exist. The values are encrypted by the controller, and are not visible
+through service config or relation settings.
+
+Values given as arguments are visible to other processes on the machine, so
+they should instead be passed in a file containing a YAML map, with the
+--file option. A value of "-" for the filename means <stdin>. Values in the
+file are overridden by any duplicate key=value arguments.
+
+The secret's identifier, of the form <service>/<name>, is printed. It may be
+passed to related units, which can read the secret with secret-get once they
+have been granted access with secret-grant.
+`
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<name> [<key>=<value> ...]",
+		Purpose: "add a revision to a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.valuesFile.SetStdin()
+	f.Var(&c.valuesFile, "file", "file containing key-value pairs")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretAddCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("no secret specified")
+	}
+	c.name = args[0]
+	if strings.Contains(c.name, "/") || strings.Contains(c.name, "=") {
+		return errors.Errorf("invalid secret name %q", c.name)
+	}
+	if len(args) < 2 && c.valuesFile.Path == "" {
+		return errors.New("no values specified")
+	}
+	c.values, err = keyvalues.Parse(args[1:], false)
+	return
+}
+
+// readValuesFile adds the values in the file given with --file, if any,
+// to those given as arguments, which take precedence.
+func (c *secretAddCommand) readValuesFile(ctx *cmd.Context) error {
+	if c.valuesFile.Path == "" {
+		return nil
+	}
+	file, err := c.valuesFile.Open(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	values := make(map[string]string)
+	if err := goyaml.Unmarshal(data, values); err != nil {
+		return errors.Annotate(err, "cannot read values")
+	}
+	for k, v := range c.values {
+		values[k] = v
+	}
+	if len(values) == 0 {
+		return errors.New("no values specified")
+	}
+	c.values = values
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	if err := c.readValuesFile(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := c.ctx.AddSecret(c.name, c.values); err != nil {
+		return errors.Annotatef(err, "cannot add secret %q", c.name)
+	}
+	service, err := names.UnitService(c.ctx.UnitName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(ctx.Stdout, "%s/%s\n", service, c.name)
+	return nil
+}
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	owner    string
+	name     string
+	key      string
+	revision int
+	out      cmd.Output
+}
+
+// NewSecretGetCommand returns a new secret-get command with the given
+// context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of the specified key of a secret. If no key is
+given, or if the key is "-", all keys and values will be printed.
+
+The secret is identified as <service>/<name>, or as <name> if it is owned by
+the unit's service. Secrets owned by other services can only be read once
+access has been granted with secret-grant. By default the latest revision is
+read; use --revision to read an earlier one.
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<secret> [<key>]",
+		Purpose: "print the values of a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.IntVar(&c.revision, "revision", 0, "the revision of the secret to read")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("no secret specified")
+	}
+	if c.revision < 0 {
+		return errors.Errorf("invalid revision %d", c.revision)
+	}
+	c.owner, c.name, err = parseSecretId(args[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.key = ""
+	if len(args) == 1 {
+		return nil
+	}
+	if key := args[1]; key != "-" {
+		c.key = key
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	owner := c.owner
+	if owner == "" {
+		service, err := names.UnitService(c.ctx.UnitName())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		owner = service
+	}
+	values, err := c.ctx.SecretValues(owner, c.name, c.revision)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read secret \"%s/%s\"", owner, c.name)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, values)
+	}
+	if value, ok := values[c.key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
+
+// secretGrantCommand implements the secret-grant command.
+type secretGrantCommand struct {
+	cmd.CommandBase
+	ctx             Context
+	name            string
+	relationId      int
+	relationIdProxy gnuflag.Value
+}
+
+// NewSecretGrantCommand returns a new secret-grant command with the given
+// context.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	c := &secretGrantCommand{ctx: ctx}
+	rV, err := newRelationIdValue(ctx, &c.relationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.relationIdProxy = rV
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	doc := `
+secret-grant allows the units of the service at the other end of a relation
+to read the named secret owned by the unit's service. If no relation is
+specified then the current relation is used.
+`
+	return &cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<name>",
+		Purpose: "grant a related service access to a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relationIdProxy, "r", "specify a relation by id")
+	f.Var(c.relationIdProxy, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGrantCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no secret specified")
+	}
+	c.name = args[0]
+	if strings.Contains(c.name, "/") || strings.Contains(c.name, "=") {
+		return errors.Errorf("invalid secret name %q", c.name)
+	}
+	if c.relationId == -1 {
+		return errors.New("no relation id specified")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.GrantSecret(c.name, c.relationId)
+	return errors.Annotatef(err, "cannot grant secret %q", c.name)
+}
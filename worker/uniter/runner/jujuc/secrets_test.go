@@ -0,0 +1,174 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretsSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) run(c *gc.C, hctx jujuc.Context, args ...string) (int, string, string) {
+	com, err := jujuc.NewCommand(hctx, cmdString(args[0]))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args[1:])
+	return code, bufferString(ctx.Stdout), bufferString(ctx.Stderr)
+}
+
+func (s *SecretsSuite) TestSecretAdd(c *gc.C) {
+	hctx, info := s.newHookContext(-1, "")
+	code, stdout, stderr := s.run(c, hctx, "secret-add", "root-password", "password=sekrit", "user=root")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "u/root-password\n")
+	c.Check(stderr, gc.Equals, "")
+	c.Check(info.Secrets.Owned, jc.DeepEquals, map[string]map[string]string{
+		"root-password": {"password": "sekrit", "user": "root"},
+	})
+}
+
+func (s *SecretsSuite) TestSecretAddInit(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no secret specified"},
+		{[]string{"password"}, "no values specified"},
+		{[]string{"mysql/password", "a=b"}, `invalid secret name "mysql/password"`},
+		{[]string{"password", "a"}, `expected "key=value", got "a"`},
+	} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretsSuite) TestSecretAddFile(c *gc.C) {
+	hctx, info := s.newHookContext(-1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	ctx.Stdin = bytes.NewBufferString("password: sekrit\nuser: nobody\n")
+	code := cmd.Main(com, ctx, []string{"root-password", "--file", "-", "user=root"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "u/root-password\n")
+	c.Check(info.Secrets.Owned, jc.DeepEquals, map[string]map[string]string{
+		"root-password": {"password": "sekrit", "user": "root"},
+	})
+}
+
+func (s *SecretsSuite) TestSecretAddLoggableArgs(c *gc.C) {
+	args := []string{"root-password", "--file", "-", "password=sekrit"}
+	c.Check(jujuc.LoggableArgs(cmdString("secret-add"), args), jc.DeepEquals, []string{
+		"root-password", "--file", "-", "password=<redacted>",
+	})
+	c.Check(jujuc.LoggableArgs(cmdString("relation-set"), args), jc.DeepEquals, args)
+}
+
+func (s *SecretsSuite) TestSecretAddError(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	s.Stub.SetErrors(errors.New("pow"))
+	code, stdout, stderr := s.run(c, hctx, "secret-add", "root-password", "password=sekrit")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, `error: cannot add secret "root-password": pow`+"\n")
+}
+
+func (s *SecretsSuite) TestSecretGet(c *gc.C) {
+	hctx, info := s.newHookContext(-1, "")
+	info.Secrets.SetValues("u", "admin", map[string]string{"password": "a"})
+	info.Secrets.SetValues("mysql", "root", map[string]string{"password": "b"})
+	for i, t := range []struct {
+		args   []string
+		stdout string
+	}{
+		{[]string{"secret-get", "admin", "password"}, "a\n"},
+		{[]string{"secret-get", "u/admin", "password"}, "a\n"},
+		{[]string{"secret-get", "mysql/root", "password"}, "b\n"},
+		{[]string{"secret-get", "mysql/root", "missing"}, ""},
+		{[]string{"secret-get", "mysql/root", "--format", "json"}, `{"password":"b"}` + "\n"},
+		{[]string{"secret-get", "--revision", "3", "mysql/root", "-", "--format", "json"}, `{"password":"b"}` + "\n"},
+	} {
+		c.Logf("test %d: %v", i, t.args)
+		code, stdout, stderr := s.run(c, hctx, t.args...)
+		c.Check(code, gc.Equals, 0)
+		c.Check(stdout, gc.Equals, t.stdout)
+		c.Check(stderr, gc.Equals, "")
+	}
+	s.Stub.CheckCall(c, 1, "SecretValues", "u", "admin", 0)
+	s.Stub.CheckCall(c, 6, "SecretValues", "mysql", "root", 3)
+}
+
+func (s *SecretsSuite) TestSecretGetInit(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no secret specified"},
+		{[]string{"no-such/service/x"}, `invalid secret "no-such/service/x"`},
+		{[]string{"mysql/"}, `invalid secret "mysql/"`},
+		{[]string{"mysql/root", "password", "extra"}, `unrecognized args: \["extra"\]`},
+		{[]string{"--revision", "-1", "mysql/root"}, "invalid revision -1"},
+	} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretsSuite) TestSecretGetNotFound(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	code, stdout, stderr := s.run(c, hctx, "secret-get", "mysql/root")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, `error: cannot read secret "mysql/root": secret "mysql/root" not found`+"\n")
+}
+
+func (s *SecretsSuite) TestSecretGrant(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	code, stdout, stderr := s.run(c, hctx, "secret-grant", "admin")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "")
+	code, _, _ = s.run(c, hctx, "secret-grant", "-r", "peer0:0", "admin")
+	c.Check(code, gc.Equals, 0)
+	c.Check(info.Secrets.Grants, jc.DeepEquals, map[string][]int{"admin": {1, 0}})
+}
+
+func (s *SecretsSuite) TestSecretGrantInit(c *gc.C) {
+	hctx, _ := s.newHookContext(-1, "")
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no secret specified"},
+		{[]string{"admin"}, "no relation id specified"},
+		{[]string{"-r", "1", "mysql/admin"}, `invalid secret name "mysql/admin"`},
+		{[]string{"-r", "1", "admin", "extra"}, `unrecognized args: \["extra"\]`},
+	} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"net/rpc"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/juju/cmd"
@@ -75,6 +76,23 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var workloadCommands = map[string]creator{
+	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
+	"workload-info-set" + cmdSuffix:       NewWorkloadInfoSetCommand,
+}
+
+var secretCommands = map[string]creator{
+	"secret-add" + cmdSuffix:   NewSecretAddCommand,
+	"secret-get" + cmdSuffix:   NewSecretGetCommand,
+	"secret-grant" + cmdSuffix: NewSecretGrantCommand,
+}
+
+var leaseCommands = map[string]creator{
+	"lease-check" + cmdSuffix:   NewLeaseCheckCommand,
+	"lease-claim" + cmdSuffix:   NewLeaseClaimCommand,
+	"lease-release" + cmdSuffix: NewLeaseReleaseCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -85,6 +103,9 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(leaseCommands)
+	add(workloadCommands)
+	add(secretCommands)
 	add(registeredCommands)
 	return all
 }
@@ -170,7 +191,7 @@ func (j *Jujuc) Main(req Request, resp *exec.ExecResponse) error {
 	}
 	j.mu.Lock()
 	defer j.mu.Unlock()
-	logger.Infof("running hook tool %q %q", req.CommandName, req.Args)
+	logger.Infof("running hook tool %q %q", req.CommandName, loggableArgs(req.CommandName, req.Args))
 	logger.Debugf("hook context id %q; dir %q", req.ContextId, req.Dir)
 	wrapper := &cmdWrapper{c, nil}
 	resp.Code = cmd.Main(wrapper, ctx, req.Args)
@@ -182,6 +203,22 @@ func (j *Jujuc) Main(req Request, resp *exec.ExecResponse) error {
 	return nil
 }
 
+// loggableArgs returns the arguments of the named hook tool as they may
+// be logged: the values passed to secret-add are redacted.
+func loggableArgs(commandName string, args []string) []string {
+	if strings.TrimSuffix(commandName, cmdSuffix) != "secret-add" {
+		return args
+	}
+	result := make([]string, len(args))
+	for i, arg := range args {
+		if j := strings.Index(arg, "="); j >= 0 && !strings.HasPrefix(arg, "-") {
+			arg = arg[:j+1] + "<redacted>"
+		}
+		result[i] = arg
+	}
+	return result
+}
+
 // Server implements a server that serves command invocations via
 // a unix domain socket.
 type Server struct {
@@ -13,9 +13,12 @@ import (
 type ContextInfo struct {
 	Unit
 	Status
+	Workload
+	Secrets
 	Instance
 	NetworkInterface
 	Leadership
+	Leases
 	Metrics
 	Storage
 	Components
@@ -52,9 +55,12 @@ type contextBase struct {
 type Context struct {
 	ContextUnit
 	ContextStatus
+	ContextWorkload
+	ContextSecrets
 	ContextInstance
 	ContextNetworking
 	ContextLeader
+	ContextLeases
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -70,12 +76,18 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextUnit.info = &info.Unit
 	ctx.ContextStatus.stub = stub
 	ctx.ContextStatus.info = &info.Status
+	ctx.ContextWorkload.stub = stub
+	ctx.ContextWorkload.info = &info.Workload
+	ctx.ContextSecrets.stub = stub
+	ctx.ContextSecrets.info = &info.Secrets
 	ctx.ContextInstance.stub = stub
 	ctx.ContextInstance.info = &info.Instance
 	ctx.ContextNetworking.stub = stub
 	ctx.ContextNetworking.info = &info.NetworkInterface
 	ctx.ContextLeader.stub = stub
 	ctx.ContextLeader.info = &info.Leadership
+	ctx.ContextLeases.stub = stub
+	ctx.ContextLeases.info = &info.Leases
 	ctx.ContextMetrics.stub = stub
 	ctx.ContextMetrics.info = &info.Metrics
 	ctx.ContextStorage.stub = stub
@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Leases holds the values for the hook context.
+type Leases struct {
+	// Held records the leases held by the unit, keyed on
+	// "<scope>:<name>".
+	Held map[string]bool
+}
+
+// SetHeld records that the unit holds the identified lease.
+func (l *Leases) SetHeld(scope jujuc.LeaseScope, name string) {
+	if l.Held == nil {
+		l.Held = make(map[string]bool)
+	}
+	l.Held[leaseKey(scope, name)] = true
+}
+
+func leaseKey(scope jujuc.LeaseScope, name string) string {
+	return string(scope) + ":" + name
+}
+
+// ContextLeases is a test double for jujuc.ContextLeases.
+type ContextLeases struct {
+	contextBase
+	info *Leases
+}
+
+// ClaimLease implements jujuc.ContextLeases.
+func (c *ContextLeases) ClaimLease(scope jujuc.LeaseScope, name string, duration time.Duration) error {
+	c.stub.AddCall("ClaimLease", scope, name, duration)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.SetHeld(scope, name)
+	return nil
+}
+
+// ReleaseLease implements jujuc.ContextLeases.
+func (c *ContextLeases) ReleaseLease(scope jujuc.LeaseScope, name string) error {
+	c.stub.AddCall("ReleaseLease", scope, name)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	key := leaseKey(scope, name)
+	if !c.info.Held[key] {
+		return lease.ErrNotHeld
+	}
+	delete(c.info.Held, key)
+	return nil
+}
+
+// CheckLease implements jujuc.ContextLeases.
+func (c *ContextLeases) CheckLease(scope jujuc.LeaseScope, name string) (bool, error) {
+	c.stub.AddCall("CheckLease", scope, name)
+	if err := c.stub.NextErr(); err != nil {
+		return false, errors.Trace(err)
+	}
+
+	return c.info.Held[leaseKey(scope, name)], nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"github.com/juju/errors"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	// Owned records the values most recently added to each secret
+	// owned by the unit's service, keyed on the secret's name.
+	Owned map[string]map[string]string
+
+	// Values records the latest values of each secret, keyed on
+	// "<service>/<name>".
+	Values map[string]map[string]string
+
+	// Grants records the relations to which each secret owned by the
+	// unit's service has been granted, keyed on the secret's name.
+	Grants map[string][]int
+}
+
+// SetValues records the values of the identified secret.
+func (s *Secrets) SetValues(owner, name string, values map[string]string) {
+	if s.Values == nil {
+		s.Values = make(map[string]map[string]string)
+	}
+	s.Values[owner+"/"+name] = values
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// AddSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) AddSecret(name string, values map[string]string) (int, error) {
+	c.stub.AddCall("AddSecret", name, values)
+	if err := c.stub.NextErr(); err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	if c.info.Owned == nil {
+		c.info.Owned = make(map[string]map[string]string)
+	}
+	c.info.Owned[name] = values
+	return 1, nil
+}
+
+// SecretValues implements jujuc.ContextSecrets.
+func (c *ContextSecrets) SecretValues(owner, name string, revision int) (map[string]string, error) {
+	c.stub.AddCall("SecretValues", owner, name, revision)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	values, ok := c.info.Values[owner+"/"+name]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", owner+"/"+name)
+	}
+	return values, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(name string, relationId int) error {
+	c.stub.AddCall("GrantSecret", name, relationId)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.Grants == nil {
+		c.info.Grants = make(map[string][]int)
+	}
+	c.info.Grants[name] = append(c.info.Grants[name], relationId)
+	return nil
+}
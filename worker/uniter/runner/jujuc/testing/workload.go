@@ -0,0 +1,51 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"github.com/juju/errors"
+)
+
+// Workload holds the values for the hook context.
+type Workload struct {
+	Version string
+	Info    map[string]string
+}
+
+// ContextWorkload is a test double for jujuc.ContextWorkload.
+type ContextWorkload struct {
+	contextBase
+	info *Workload
+}
+
+// SetWorkloadVersion implements jujuc.ContextWorkload.
+func (c *ContextWorkload) SetWorkloadVersion(version string) error {
+	c.stub.AddCall("SetWorkloadVersion", version)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.Version = version
+	return nil
+}
+
+// SetWorkloadInfo implements jujuc.ContextWorkload.
+func (c *ContextWorkload) SetWorkloadInfo(changes map[string]string) error {
+	c.stub.AddCall("SetWorkloadInfo", changes)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.Info == nil {
+		c.info.Info = make(map[string]string)
+	}
+	for k, v := range changes {
+		if v == "" {
+			delete(c.info.Info, k)
+		} else {
+			c.info.Info[k] = v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// applicationVersionSetCommand implements the application-version-set
+// command.
+type applicationVersionSetCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	version string
+}
+
+// NewApplicationVersionSetCommand returns a new application-version-set
+// command with the given context.
+func NewApplicationVersionSetCommand(ctx Context) (cmd.Command, error) {
+	return &applicationVersionSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *applicationVersionSetCommand) Info() *cmd.Info {
+	doc := `
+application-version-set records the version of the software the unit runs,
+such as the version of the database server the charm deployed, so that it
+is shown by juju status. An empty version clears it.
+`
+	return &cmd.Info{
+		Name:    "application-version-set",
+		Args:    "<version>",
+		Purpose: "set the version of the unit's software",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *applicationVersionSetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no version specified")
+	}
+	c.version = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *applicationVersionSetCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.SetWorkloadVersion(c.version)
+	return errors.Annotate(err, "cannot set application version")
+}
+
+// workloadInfoSetCommand implements the workload-info-set command.
+type workloadInfoSetCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	changes map[string]string
+}
+
+// NewWorkloadInfoSetCommand returns a new workload-info-set command with
+// the given context.
+func NewWorkloadInfoSetCommand(ctx Context) (cmd.Command, error) {
+	return &workloadInfoSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *workloadInfoSetCommand) Info() *cmd.Info {
+	doc := `
+workload-info-set records information about the software the unit runs,
+such as its edition or role, as key/value pairs which are shown by
+juju status. A key with an empty value is removed. Keys consist of
+lower-case letters, digits and hyphens, and start with a letter.
+`
+	return &cmd.Info{
+		Name:    "workload-info-set",
+		Args:    "<key>=<value> [...]",
+		Purpose: "set information about the unit's software",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *workloadInfoSetCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("no workload info specified")
+	}
+	c.changes, err = keyvalues.Parse(args, true)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *workloadInfoSetCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.SetWorkloadInfo(c.changes)
+	return errors.Annotate(err, "cannot set workload info")
+}
@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type WorkloadSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&WorkloadSuite{})
+
+func (s *WorkloadSuite) run(c *gc.C, hctx *Context, args ...string) (int, string, string) {
+	com, err := jujuc.NewCommand(hctx, cmdString(args[0]))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args[1:])
+	return code, bufferString(ctx.Stdout), bufferString(ctx.Stderr)
+}
+
+func (s *WorkloadSuite) TestApplicationVersionSet(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	code, stdout, stderr := s.run(c, hctx, "application-version-set", "5.7.13")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "SetWorkloadVersion", "5.7.13")
+	c.Check(hctx.info.Workload.Version, gc.Equals, "5.7.13")
+}
+
+func (s *WorkloadSuite) TestApplicationVersionSetInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no version specified"},
+		{[]string{"1.0", "2.0"}, `unrecognized args: \["2.0"\]`},
+	} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.GetHookContext(c, -1, ""), cmdString("application-version-set"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *WorkloadSuite) TestApplicationVersionSetError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("pow"))
+	code, stdout, stderr := s.run(c, hctx, "application-version-set", "5.7.13")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "error: cannot set application version: pow\n")
+}
+
+func (s *WorkloadSuite) TestWorkloadInfoSet(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Workload.Info = map[string]string{"role": "replica"}
+	code, stdout, stderr := s.run(c, hctx, "workload-info-set", "edition=community", "role=")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "SetWorkloadInfo", map[string]string{
+		"edition": "community",
+		"role":    "",
+	})
+	c.Check(hctx.info.Workload.Info, jc.DeepEquals, map[string]string{
+		"edition": "community",
+	})
+}
+
+func (s *WorkloadSuite) TestWorkloadInfoSetInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no workload info specified"},
+		{[]string{"edition"}, `expected "key=value", got "edition"`},
+		{[]string{"a=1", "a=2"}, `key "a" specified more than once`},
+	} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.GetHookContext(c, -1, ""), cmdString("workload-info-set"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = testing.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *WorkloadSuite) TestWorkloadInfoSetError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("pow"))
+	code, stdout, stderr := s.run(c, hctx, "workload-info-set", "edition=community")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stdout, gc.Equals, "")
+	c.Check(stderr, gc.Equals, "error: cannot set workload info: pow\n")
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/juju/loggo"
 )
@@ -18,6 +19,11 @@ type hookLogger struct {
 	mu      sync.Mutex
 	stopped bool
 	logger  loggo.Logger
+
+	// keep, if positive, is the number of bytes of the most
+	// recent output to retain in kept.
+	keep int
+	kept []byte
 }
 
 func (l *hookLogger) run() {
@@ -38,6 +44,13 @@ func (l *hookLogger) run() {
 			return
 		}
 		l.logger.Infof("%s", line)
+		if l.keep > 0 {
+			l.kept = append(l.kept, line...)
+			l.kept = append(l.kept, '\n')
+			if len(l.kept) > l.keep {
+				l.kept = l.kept[len(l.kept)-l.keep:]
+			}
+		}
 		l.mu.Unlock()
 	}
 }
@@ -58,3 +71,19 @@ func (l *hookLogger) stop() {
 	l.stopped = true
 	l.mu.Unlock()
 }
+
+// output returns the most recent output retained by the logger.
+func (l *hookLogger) output() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	kept := l.kept
+	if len(kept) == l.keep {
+		// The output may have been cut in the middle of a rune; skip
+		// its continuation bytes, but no more, as the output need not
+		// be valid UTF-8 at all.
+		for i := 0; i < utf8.UTFMax-1 && len(kept) > 0 && !utf8.RuneStart(kept[0]); i++ {
+			kept = kept[1:]
+		}
+	}
+	return string(kept)
+}
@@ -0,0 +1,385 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.runner.replay")
+
+// Context is a jujuc.Context which answers from a Snapshot. Changes made
+// through it are applied to the snapshot alone, so that later hook tools
+// in the same replay see them, but are never sent to the controller.
+type Context struct {
+	snapshot *Snapshot
+}
+
+var _ jujuc.Context = (*Context)(nil)
+
+// NewContext returns a Context which answers from, and applies changes
+// to, the supplied snapshot.
+func NewContext(snapshot *Snapshot) *Context {
+	return &Context{snapshot: snapshot}
+}
+
+// discard logs a change that cannot be replayed, and is ignored.
+func (ctx *Context) discard(format string, args ...interface{}) error {
+	logger.Infof("replay: ignoring %s", fmt.Sprintf(format, args...))
+	return nil
+}
+
+// UnitName is part of the jujuc.ContextUnit interface.
+func (ctx *Context) UnitName() string {
+	return ctx.snapshot.UnitName
+}
+
+// ConfigSettings is part of the jujuc.ContextUnit interface.
+func (ctx *Context) ConfigSettings() (charm.Settings, error) {
+	settings := make(charm.Settings)
+	for k, v := range ctx.snapshot.Config {
+		settings[k] = v
+	}
+	return settings, nil
+}
+
+// UnitStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) UnitStatus() (*jujuc.StatusInfo, error) {
+	status := ctx.snapshot.UnitStatus
+	return &status, nil
+}
+
+// SetUnitStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) SetUnitStatus(status jujuc.StatusInfo) error {
+	ctx.snapshot.UnitStatus = status
+	return nil
+}
+
+// ServiceStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) ServiceStatus() (jujuc.ServiceStatusInfo, error) {
+	return jujuc.ServiceStatusInfo{}, errors.NotSupportedf("service status in a replayed hook")
+}
+
+// SetServiceStatus is part of the jujuc.ContextStatus interface.
+func (ctx *Context) SetServiceStatus(status jujuc.StatusInfo) error {
+	return ctx.discard("service status %q", status.Status)
+}
+
+// SetWorkloadVersion is part of the jujuc.ContextWorkload interface.
+func (ctx *Context) SetWorkloadVersion(version string) error {
+	return ctx.discard("workload version %q", version)
+}
+
+// SetWorkloadInfo is part of the jujuc.ContextWorkload interface.
+func (ctx *Context) SetWorkloadInfo(changes map[string]string) error {
+	return ctx.discard("workload info change")
+}
+
+// AddSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *Context) AddSecret(name string, values map[string]string) (int, error) {
+	return 0, errors.NotSupportedf("secrets in a replayed hook")
+}
+
+// SecretValues is part of the jujuc.ContextSecrets interface.
+func (ctx *Context) SecretValues(owner, name string, revision int) (map[string]string, error) {
+	return nil, errors.NotSupportedf("secrets in a replayed hook")
+}
+
+// GrantSecret is part of the jujuc.ContextSecrets interface.
+func (ctx *Context) GrantSecret(name string, relationId int) error {
+	return errors.NotSupportedf("secrets in a replayed hook")
+}
+
+// AvailabilityZone is part of the jujuc.ContextInstance interface.
+func (ctx *Context) AvailabilityZone() (string, error) {
+	if ctx.snapshot.AvailabilityZone == "" {
+		return "", errors.NotFoundf("availability zone")
+	}
+	return ctx.snapshot.AvailabilityZone, nil
+}
+
+// RequestReboot is part of the jujuc.ContextInstance interface.
+func (ctx *Context) RequestReboot(priority jujuc.RebootPriority) error {
+	return ctx.discard("reboot request")
+}
+
+// PublicAddress is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) PublicAddress() (string, error) {
+	if ctx.snapshot.PublicAddress == "" {
+		return "", errors.NotFoundf("public address")
+	}
+	return ctx.snapshot.PublicAddress, nil
+}
+
+// PrivateAddress is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) PrivateAddress() (string, error) {
+	if ctx.snapshot.PrivateAddress == "" {
+		return "", errors.NotFoundf("private address")
+	}
+	return ctx.snapshot.PrivateAddress, nil
+}
+
+// OpenPorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) OpenPorts(protocol string, fromPort, toPort int) error {
+	portRange := network.PortRange{
+		Protocol: protocol,
+		FromPort: fromPort,
+		ToPort:   toPort,
+	}
+	for _, opened := range ctx.snapshot.OpenedPorts {
+		if opened == portRange {
+			return nil
+		}
+	}
+	ctx.snapshot.OpenedPorts = append(ctx.snapshot.OpenedPorts, portRange)
+	return nil
+}
+
+// ClosePorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) ClosePorts(protocol string, fromPort, toPort int) error {
+	portRange := network.PortRange{
+		Protocol: protocol,
+		FromPort: fromPort,
+		ToPort:   toPort,
+	}
+	var opened []network.PortRange
+	for _, existing := range ctx.snapshot.OpenedPorts {
+		if existing != portRange {
+			opened = append(opened, existing)
+		}
+	}
+	ctx.snapshot.OpenedPorts = opened
+	return nil
+}
+
+// OpenedPorts is part of the jujuc.ContextNetworking interface.
+func (ctx *Context) OpenedPorts() []network.PortRange {
+	opened := append([]network.PortRange(nil), ctx.snapshot.OpenedPorts...)
+	network.SortPortRanges(opened)
+	return opened
+}
+
+// IsLeader is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) IsLeader() (bool, error) {
+	return ctx.snapshot.IsLeader, nil
+}
+
+// LeaderSettings is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) LeaderSettings() (map[string]string, error) {
+	settings := make(map[string]string)
+	for k, v := range ctx.snapshot.LeaderSettings {
+		settings[k] = v
+	}
+	return settings, nil
+}
+
+// WriteLeaderSettings is part of the jujuc.ContextLeadership interface.
+func (ctx *Context) WriteLeaderSettings(settings map[string]string) error {
+	if !ctx.snapshot.IsLeader {
+		return errors.New("cannot write settings: not the leader")
+	}
+	if ctx.snapshot.LeaderSettings == nil {
+		ctx.snapshot.LeaderSettings = make(map[string]string)
+	}
+	for k, v := range settings {
+		if v == "" {
+			delete(ctx.snapshot.LeaderSettings, k)
+		} else {
+			ctx.snapshot.LeaderSettings[k] = v
+		}
+	}
+	return nil
+}
+
+// ClaimLease is part of the jujuc.ContextLeases interface.
+func (ctx *Context) ClaimLease(scope jujuc.LeaseScope, name string, duration time.Duration) error {
+	return errors.NotSupportedf("leases in a replayed hook")
+}
+
+// ReleaseLease is part of the jujuc.ContextLeases interface.
+func (ctx *Context) ReleaseLease(scope jujuc.LeaseScope, name string) error {
+	return errors.NotSupportedf("leases in a replayed hook")
+}
+
+// CheckLease is part of the jujuc.ContextLeases interface.
+func (ctx *Context) CheckLease(scope jujuc.LeaseScope, name string) (bool, error) {
+	return false, errors.NotSupportedf("leases in a replayed hook")
+}
+
+// AddMetric is part of the jujuc.ContextMetrics interface.
+func (ctx *Context) AddMetric(key, value string, created time.Time) error {
+	return ctx.discard("metric %q", key)
+}
+
+// StorageTags is part of the jujuc.ContextStorage interface.
+func (ctx *Context) StorageTags() ([]names.StorageTag, error) {
+	return nil, errors.NotSupportedf("storage in a replayed hook")
+}
+
+// Storage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) Storage(tag names.StorageTag) (jujuc.ContextStorageAttachment, error) {
+	return nil, errors.NotSupportedf("storage in a replayed hook")
+}
+
+// HookStorage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) HookStorage() (jujuc.ContextStorageAttachment, error) {
+	return nil, errors.NotSupportedf("storage in a replayed hook")
+}
+
+// AddUnitStorage is part of the jujuc.ContextStorage interface.
+func (ctx *Context) AddUnitStorage(map[string]params.StorageConstraints) error {
+	return ctx.discard("storage request")
+}
+
+// Component is part of the jujuc.ContextComponents interface.
+func (ctx *Context) Component(name string) (jujuc.ContextComponent, error) {
+	return nil, errors.NotSupportedf("component %q in a replayed hook", name)
+}
+
+// Relation is part of the jujuc.ContextRelations interface.
+func (ctx *Context) Relation(id int) (jujuc.ContextRelation, error) {
+	info, ok := ctx.snapshot.Relations[id]
+	if !ok {
+		return nil, errors.NotFoundf("relation")
+	}
+	if info.Settings == nil {
+		info.Settings = make(params.Settings)
+		ctx.snapshot.Relations[id] = info
+	}
+	return &relation{id: id, info: info}, nil
+}
+
+// RelationIds is part of the jujuc.ContextRelations interface.
+func (ctx *Context) RelationIds() ([]int, error) {
+	ids := []int{}
+	for id := range ctx.snapshot.Relations {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// HookRelation is part of the jujuc.Context interface.
+func (ctx *Context) HookRelation() (jujuc.ContextRelation, error) {
+	return ctx.Relation(ctx.snapshot.RelationId)
+}
+
+// RemoteUnitName is part of the jujuc.Context interface.
+func (ctx *Context) RemoteUnitName() (string, error) {
+	if ctx.snapshot.RemoteUnit == "" {
+		return "", errors.NotFoundf("remote unit")
+	}
+	return ctx.snapshot.RemoteUnit, nil
+}
+
+// ActionParams is part of the jujuc.Context interface.
+func (ctx *Context) ActionParams() (map[string]interface{}, error) {
+	return nil, errors.New("not running an action")
+}
+
+// UpdateActionResults is part of the jujuc.Context interface.
+func (ctx *Context) UpdateActionResults(keys []string, value string) error {
+	return errors.New("not running an action")
+}
+
+// SetActionMessage is part of the jujuc.Context interface.
+func (ctx *Context) SetActionMessage(message string) error {
+	return errors.New("not running an action")
+}
+
+// SetActionFailed is part of the jujuc.Context interface.
+func (ctx *Context) SetActionFailed() error {
+	return errors.New("not running an action")
+}
+
+// relation is a jujuc.ContextRelation which answers from a snapshot.
+type relation struct {
+	id   int
+	info RelationInfo
+}
+
+// Id is part of the jujuc.ContextRelation interface.
+func (r *relation) Id() int {
+	return r.id
+}
+
+// Name is part of the jujuc.ContextRelation interface.
+func (r *relation) Name() string {
+	return r.info.Name
+}
+
+// FakeId is part of the jujuc.ContextRelation interface.
+func (r *relation) FakeId() string {
+	return fmt.Sprintf("%s:%d", r.info.Name, r.id)
+}
+
+// Settings is part of the jujuc.ContextRelation interface. Changes to
+// the returned settings are seen by later hook tools in the replay.
+func (r *relation) Settings() (jujuc.Settings, error) {
+	return settings(r.info.Settings), nil
+}
+
+// UnitNames is part of the jujuc.ContextRelation interface.
+func (r *relation) UnitNames() []string {
+	var unitNames []string
+	for unitName := range r.info.Units {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+	return unitNames
+}
+
+// ReadSettings is part of the jujuc.ContextRelation interface.
+func (r *relation) ReadSettings(unitName string) (params.Settings, error) {
+	unitSettings, ok := r.info.Units[unitName]
+	if !ok {
+		return nil, errors.NotFoundf("settings for unit %q", unitName)
+	}
+	result := make(params.Settings)
+	for k, v := range unitSettings {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// NetworkConfig is part of the jujuc.ContextRelation interface.
+func (r *relation) NetworkConfig() ([]params.NetworkConfig, error) {
+	if len(r.info.NetworkConfig) == 0 {
+		return nil, errors.NotFoundf("network config")
+	}
+	return r.info.NetworkConfig, nil
+}
+
+// settings is a jujuc.Settings backed by a snapshot's relation settings.
+type settings params.Settings
+
+// Map is part of the jujuc.Settings interface.
+func (s settings) Map() params.Settings {
+	result := make(params.Settings)
+	for k, v := range s {
+		result[k] = v
+	}
+	return result
+}
+
+// Set is part of the jujuc.Settings interface.
+func (s settings) Set(key, value string) {
+	s[key] = value
+}
+
+// Delete is part of the jujuc.Settings interface.
+func (s settings) Delete(key string) {
+	delete(s, key)
+}
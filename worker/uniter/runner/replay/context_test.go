@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ContextSuite struct {
+	snapshot *replay.Snapshot
+	ctx      *replay.Context
+}
+
+var _ = gc.Suite(&ContextSuite{})
+
+func (s *ContextSuite) SetUpTest(c *gc.C) {
+	s.snapshot = &replay.Snapshot{
+		HookName:       "db-relation-changed",
+		UnitName:       "u/0",
+		UnitStatus:     jujuc.StatusInfo{Status: "active"},
+		PrivateAddress: "10.0.0.1",
+		OpenedPorts:    []network.PortRange{{Protocol: "tcp", FromPort: 80, ToPort: 80}},
+		Relations: map[int]replay.RelationInfo{
+			1: {
+				Name:  "db",
+				Units: map[string]params.Settings{"mysql/0": {"user": "fred"}},
+			},
+		},
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+	}
+	s.ctx = replay.NewContext(s.snapshot)
+}
+
+func (s *ContextSuite) TestAddresses(c *gc.C) {
+	address, err := s.ctx.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "10.0.0.1")
+	_, err = s.ctx.PublicAddress()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.ctx.AvailabilityZone()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ContextSuite) TestStatus(c *gc.C) {
+	err := s.ctx.SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no db"})
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := s.ctx.UnitStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*status, jc.DeepEquals, jujuc.StatusInfo{Status: "blocked", Info: "no db"})
+}
+
+func (s *ContextSuite) TestPorts(c *gc.C) {
+	err := s.ctx.OpenPorts("tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.ctx.ClosePorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.ctx.OpenedPorts(), jc.DeepEquals, []network.PortRange{
+		{Protocol: "tcp", FromPort: 443, ToPort: 443},
+	})
+}
+
+func (s *ContextSuite) TestLeaderSettings(c *gc.C) {
+	err := s.ctx.WriteLeaderSettings(map[string]string{"password": "sekrit"})
+	c.Assert(err, gc.ErrorMatches, "cannot write settings: not the leader")
+
+	s.snapshot.IsLeader = true
+	err = s.ctx.WriteLeaderSettings(map[string]string{"password": "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := s.ctx.LeaderSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]string{"password": "sekrit"})
+}
+
+func (s *ContextSuite) TestRelationSettings(c *gc.C) {
+	relation, err := s.ctx.HookRelation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.Id(), gc.Equals, 1)
+	c.Assert(relation.FakeId(), gc.Equals, "db:1")
+	c.Assert(relation.UnitNames(), jc.DeepEquals, []string{"mysql/0"})
+	remote, err := relation.ReadSettings("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remote, jc.DeepEquals, params.Settings{"user": "fred"})
+
+	settings, err := relation.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("host", "u-0")
+
+	// The change is seen by later hook tools in the replay.
+	relation, err = s.ctx.Relation(1)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = relation.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, params.Settings{"host": "u-0"})
+}
+
+func (s *ContextSuite) TestRelationNotFound(c *gc.C) {
+	_, err := s.ctx.Relation(2)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ContextSuite) TestNotSupported(c *gc.C) {
+	_, err := s.ctx.StorageTags()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.ctx.ServiceStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.ctx.SecretValues("mysql", "root", 0)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.ctx.ActionParams()
+	c.Assert(err, gc.ErrorMatches, "not running an action")
+}
@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/fs"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Params holds the parameters of a hook replay.
+type Params struct {
+	// Snapshot is the context captured for the hook to replay.
+	Snapshot *Snapshot
+
+	// CharmDir is the directory of the charm whose hook is replayed.
+	// The hook is run in a copy of it, which is discarded afterwards.
+	CharmDir string
+
+	// SocketPath is the path of the socket on which the hook tools of
+	// the replayed hook are served. It must not be the unit agent's.
+	SocketPath string
+
+	// Stdout and Stderr receive the output of the replayed hook.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Replay runs the hook captured in the supplied snapshot again, with its
+// hook tools answering from the snapshot rather than from the unit agent,
+// and returns the hook's error, if it failed. The hook runs in a sandbox:
+// changes it makes via the hook tools are seen by the replayed hook, but
+// not by the controller, and it runs in a private copy of the charm
+// directory, with private home and temporary directories, all of which
+// are discarded when it exits. Changes it makes elsewhere on the machine
+// are not contained.
+func Replay(p Params) error {
+	sandbox, err := ioutil.TempDir("", "juju-replay-")
+	if err != nil {
+		return errors.Annotate(err, "cannot create sandbox")
+	}
+	defer func() {
+		if err := os.RemoveAll(sandbox); err != nil {
+			logger.Errorf("cannot remove sandbox %q: %v", sandbox, err)
+		}
+	}()
+	charmDir := filepath.Join(sandbox, "charm")
+	if err := fs.Copy(p.CharmDir, charmDir); err != nil {
+		return errors.Annotate(err, "cannot copy charm directory into sandbox")
+	}
+	homeDir := filepath.Join(sandbox, "home")
+	tmpDir := filepath.Join(sandbox, "tmp")
+	for _, dir := range []string{homeDir, tmpDir} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			return errors.Annotate(err, "cannot create sandbox")
+		}
+	}
+
+	ctx := NewContext(p.Snapshot)
+	contextId := fmt.Sprintf("%s-replay-%s", p.Snapshot.UnitName, p.Snapshot.HookName)
+	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
+		if ctxId != contextId {
+			return nil, errors.Errorf("expected context id %q, got %q", contextId, ctxId)
+		}
+		return jujuc.NewCommand(ctx, cmdName)
+	}
+	srv, err := jujuc.NewServer(getCmd, p.SocketPath)
+	if err != nil {
+		return errors.Annotate(err, "cannot start hook tool server")
+	}
+	go srv.Run()
+	defer srv.Close()
+
+	env := replaceEnv(p.Snapshot.Env, map[string]string{
+		"JUJU_AGENT_SOCKET": p.SocketPath,
+		"JUJU_CONTEXT_ID":   contextId,
+		"CHARM_DIR":         charmDir,
+		"JUJU_CHARM_DIR":    charmDir,
+		"HOME":              homeDir,
+		"TMPDIR":            tmpDir,
+	})
+	ps := exec.Command(filepath.Join(charmDir, "hooks", p.Snapshot.HookName))
+	ps.Env = env
+	ps.Dir = charmDir
+	ps.Stdout = p.Stdout
+	ps.Stderr = p.Stderr
+	return ps.Run()
+}
+
+// replaceEnv returns the supplied environment with the named variables
+// set to the supplied values.
+func replaceEnv(env []string, values map[string]string) []string {
+	var result []string
+	for _, kv := range env {
+		name := strings.SplitN(kv, "=", 2)[0]
+		if _, ok := values[name]; !ok {
+			result = append(result, kv)
+		}
+	}
+	for name, value := range values {
+		result = append(result, name+"="+value)
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ReplaySuite struct{}
+
+var _ = gc.Suite(&ReplaySuite{})
+
+func (s *ReplaySuite) TestReplay(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("replayed hooks are bash scripts in this test")
+	}
+	charmDir := c.MkDir()
+	hooksDir := filepath.Join(charmDir, "hooks")
+	err := os.Mkdir(hooksDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	script := "#!/bin/bash\necho $JUJU_UNIT_NAME $JUJU_CONTEXT_ID $JUJU_AGENT_SOCKET\necho oops >&2\nexit 3\n"
+	err = ioutil.WriteFile(filepath.Join(hooksDir, "install"), []byte(script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	socketPath := filepath.Join(c.MkDir(), "replay.socket")
+	var stdout, stderr bytes.Buffer
+	err = replay.Replay(replay.Params{
+		Snapshot: &replay.Snapshot{
+			HookName:   "install",
+			UnitName:   "u/0",
+			Env:        []string{"JUJU_UNIT_NAME=u/0", "JUJU_CONTEXT_ID=u/0-install-123", "JUJU_AGENT_SOCKET=@agent"},
+			RelationId: -1,
+		},
+		CharmDir:   charmDir,
+		SocketPath: socketPath,
+		Stdout:     &stdout,
+		Stderr:     &stderr,
+	})
+	c.Assert(err, gc.ErrorMatches, "exit status 3")
+	c.Assert(stdout.String(), gc.Equals, fmt.Sprintf("u/0 u/0-replay-install %s\n", socketPath))
+	c.Assert(stderr.String(), gc.Equals, "oops\n")
+}
+
+func (s *ReplaySuite) TestReplaySandboxed(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("replayed hooks are bash scripts in this test")
+	}
+	charmDir := c.MkDir()
+	hooksDir := filepath.Join(charmDir, "hooks")
+	err := os.Mkdir(hooksDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	script := "#!/bin/bash\ntouch $CHARM_DIR/changed $HOME/changed $TMPDIR/changed\npwd\n"
+	err = ioutil.WriteFile(filepath.Join(hooksDir, "install"), []byte(script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var stdout bytes.Buffer
+	err = replay.Replay(replay.Params{
+		Snapshot: &replay.Snapshot{
+			HookName:   "install",
+			UnitName:   "u/0",
+			Env:        []string{"CHARM_DIR=" + charmDir, "HOME=" + c.MkDir()},
+			RelationId: -1,
+		},
+		CharmDir:   charmDir,
+		SocketPath: filepath.Join(c.MkDir(), "replay.socket"),
+		Stdout:     &stdout,
+		Stderr:     ioutil.Discard,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(charmDir, "changed"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+	sandboxCharmDir := strings.TrimSpace(stdout.String())
+	c.Assert(sandboxCharmDir, gc.Not(gc.Equals), charmDir)
+	_, err = os.Stat(sandboxCharmDir)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
@@ -0,0 +1,216 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package replay captures the context in which a hook runs, as seen by
+// the hook tools, and replays hooks against captured contexts.
+package replay
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// SnapshotsDir is the name of the directory, within a unit agent's base
+// directory, in which snapshots of failed hooks are kept.
+const SnapshotsDir = "replay"
+
+// Snapshot holds the context of a hook as seen by the hook tools, so
+// that the hook can later be replayed against it.
+type Snapshot struct {
+	HookName string    `yaml:"hook-name"`
+	Captured time.Time `yaml:"captured"`
+
+	// Env holds the environment in which the hook ran.
+	Env []string `yaml:"env"`
+
+	UnitName         string               `yaml:"unit-name"`
+	Config           charm.Settings       `yaml:"config"`
+	UnitStatus       jujuc.StatusInfo     `yaml:"unit-status"`
+	PublicAddress    string               `yaml:"public-address,omitempty"`
+	PrivateAddress   string               `yaml:"private-address,omitempty"`
+	AvailabilityZone string               `yaml:"availability-zone,omitempty"`
+	OpenedPorts      []network.PortRange  `yaml:"opened-ports,omitempty"`
+	IsLeader         bool                 `yaml:"is-leader"`
+	LeaderSettings   map[string]string    `yaml:"leader-settings,omitempty"`
+	Relations        map[int]RelationInfo `yaml:"relations,omitempty"`
+
+	// RelationId and RemoteUnit identify the relation, and the remote
+	// unit within it, of a relation hook. RelationId is -1 otherwise.
+	RelationId int    `yaml:"relation-id"`
+	RemoteUnit string `yaml:"remote-unit,omitempty"`
+}
+
+// RelationInfo holds a relation as seen by the hook tools.
+type RelationInfo struct {
+	Name string `yaml:"name"`
+
+	// Settings holds the local unit's settings in the relation.
+	Settings params.Settings `yaml:"settings"`
+
+	// Units holds the settings of each remote unit in the relation.
+	Units map[string]params.Settings `yaml:"units"`
+
+	NetworkConfig []params.NetworkConfig `yaml:"network-config,omitempty"`
+}
+
+// NewSnapshot captures the context, as seen by the hook tools, of the
+// named hook, which is about to run with the supplied environment.
+func NewSnapshot(ctx jujuc.Context, hookName string, env []string, now time.Time) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		HookName:    hookName,
+		Captured:    now,
+		Env:         env,
+		UnitName:    ctx.UnitName(),
+		OpenedPorts: ctx.OpenedPorts(),
+		Relations:   make(map[int]RelationInfo),
+		RelationId:  -1,
+	}
+	var err error
+	if snapshot.Config, err = ctx.ConfigSettings(); err != nil {
+		return nil, errors.Annotate(err, "cannot read config")
+	}
+	status, err := ctx.UnitStatus()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read unit status")
+	}
+	snapshot.UnitStatus = *status
+	// Addresses and availability zones are not always known; the hook
+	// tools report an error in that case, and so will the replay.
+	snapshot.PublicAddress, _ = ctx.PublicAddress()
+	snapshot.PrivateAddress, _ = ctx.PrivateAddress()
+	snapshot.AvailabilityZone, _ = ctx.AvailabilityZone()
+	if snapshot.IsLeader, err = ctx.IsLeader(); err != nil {
+		return nil, errors.Annotate(err, "cannot determine leadership")
+	}
+	if snapshot.LeaderSettings, err = ctx.LeaderSettings(); err != nil {
+		return nil, errors.Annotate(err, "cannot read leader settings")
+	}
+
+	ids, err := ctx.RelationIds()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read relations")
+	}
+	for _, id := range ids {
+		info, err := newRelationInfo(ctx, id)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot read relation %d", id)
+		}
+		snapshot.Relations[id] = info
+	}
+	if relation, err := ctx.HookRelation(); err == nil {
+		snapshot.RelationId = relation.Id()
+	}
+	if remoteUnit, err := ctx.RemoteUnitName(); err == nil {
+		snapshot.RemoteUnit = remoteUnit
+	}
+	return snapshot, nil
+}
+
+func newRelationInfo(ctx jujuc.Context, id int) (RelationInfo, error) {
+	relation, err := ctx.Relation(id)
+	if err != nil {
+		return RelationInfo{}, errors.Trace(err)
+	}
+	settings, err := relation.Settings()
+	if err != nil {
+		return RelationInfo{}, errors.Trace(err)
+	}
+	info := RelationInfo{
+		Name:     relation.Name(),
+		Settings: settings.Map(),
+		Units:    make(map[string]params.Settings),
+	}
+	for _, unitName := range relation.UnitNames() {
+		unitSettings, err := relation.ReadSettings(unitName)
+		if err != nil {
+			return RelationInfo{}, errors.Annotatef(err, "cannot read settings for %q", unitName)
+		}
+		info.Units[unitName] = unitSettings
+	}
+	// Network configuration is not always available; the hook tools
+	// report an error in that case, and so will the replay.
+	info.NetworkConfig, _ = relation.NetworkConfig()
+	return info, nil
+}
+
+// validHookName matches the names of hooks, including those prefixed
+// with the name of a relation or storage.
+var validHookName = regexp.MustCompile(`^[a-z][a-z0-9]*([_-][a-z0-9]+)*$`)
+
+// IsValidHookName returns whether the name is a valid hook name. Only
+// valid hook names may be used in snapshot paths.
+func IsValidHookName(hookName string) bool {
+	return validHookName.MatchString(hookName)
+}
+
+// SnapshotPath returns the path of the snapshot of the named hook, run
+// for the identified relation, in the supplied directory. The relation
+// id is -1 for hooks which are not relation hooks. The hook name must
+// be valid.
+func SnapshotPath(dir, hookName string, relationId int) string {
+	if relationId < 0 {
+		return filepath.Join(dir, hookName+".yaml")
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s.%d.yaml", hookName, relationId))
+}
+
+// WriteSnapshot writes the supplied snapshot to the supplied path.
+func WriteSnapshot(path string, snapshot *Snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.WriteYaml(path, snapshot))
+}
+
+// ReadSnapshot reads a snapshot from the supplied path.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := utils.ReadYaml(path, &snapshot); err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			return nil, errors.NotFoundf("hook snapshot %q", path)
+		}
+		return nil, errors.Trace(err)
+	}
+	return &snapshot, nil
+}
+
+// LatestSnapshot returns the path of the most recently written snapshot
+// of the named hook, for any relation, in the supplied directory. If no
+// hook name is given, snapshots of all hooks are considered.
+func LatestSnapshot(dir, hookName string) (string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", errors.Trace(err)
+	}
+	var latest os.FileInfo
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".yaml") {
+			continue
+		}
+		// Hook names contain no dots, so the hook name of a snapshot
+		// is everything before the first one.
+		if hookName != "" && strings.SplitN(info.Name(), ".", 2)[0] != hookName {
+			continue
+		}
+		if latest == nil || info.ModTime().After(latest.ModTime()) {
+			latest = info
+		}
+	}
+	if latest == nil {
+		return "", errors.NotFoundf("hook snapshot in %q", dir)
+	}
+	return filepath.Join(dir, latest.Name()), nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	jujuctesting "github.com/juju/juju/worker/uniter/runner/jujuc/testing"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type SnapshotSuite struct {
+	jujuctesting.ContextSuite
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
+
+var captured = time.Date(2016, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func (s *SnapshotSuite) TestNewSnapshot(c *gc.C) {
+	ctx, info := s.NewHookContext()
+	info.UnitStatus = jujuc.StatusInfo{Status: "maintenance", Info: "installing"}
+	info.AddPorts("tcp", 80, 80)
+	info.IsLeader = true
+	info.LeaderSettings = map[string]string{"password": "sekrit"}
+	rel := info.SetNewRelation(1, "db", s.Stub)
+	rel.UnitName = "u/0"
+	rel.SetRelated("u/0", jujuctesting.Settings{"host": "u-0"}, nil)
+	rel.SetRelated("mysql/0", jujuctesting.Settings{"user": "fred"}, nil)
+	info.SetAsRelationHook(1, "mysql/0")
+
+	env := []string{"JUJU_UNIT_NAME=u/0"}
+	snapshot, err := replay.NewSnapshot(ctx, "db-relation-changed", env, captured)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, &replay.Snapshot{
+		HookName:         "db-relation-changed",
+		Captured:         captured,
+		Env:              env,
+		UnitName:         "u/0",
+		Config:           info.ConfigSettings,
+		UnitStatus:       jujuc.StatusInfo{Status: "maintenance", Info: "installing"},
+		PublicAddress:    "gimli.minecraft.testing.invalid",
+		PrivateAddress:   "192.168.0.99",
+		AvailabilityZone: "us-east-1a",
+		OpenedPorts:      []network.PortRange{{Protocol: "tcp", FromPort: 80, ToPort: 80}},
+		IsLeader:         true,
+		LeaderSettings:   map[string]string{"password": "sekrit"},
+		Relations: map[int]replay.RelationInfo{
+			1: {
+				Name:     "db",
+				Settings: params.Settings{"host": "u-0"},
+				Units: map[string]params.Settings{
+					"u/0":     {"host": "u-0"},
+					"mysql/0": {"user": "fred"},
+				},
+			},
+		},
+		RelationId: 1,
+		RemoteUnit: "mysql/0",
+	})
+}
+
+func (s *SnapshotSuite) TestNewSnapshotError(c *gc.C) {
+	ctx, _ := s.NewHookContext()
+	s.Stub.SetErrors(nil, nil, errors.New("boom"))
+	_, err := replay.NewSnapshot(ctx, "install", nil, captured)
+	c.Assert(err, gc.ErrorMatches, "cannot read config: boom")
+}
+
+func (s *SnapshotSuite) TestWriteReadSnapshot(c *gc.C) {
+	snapshot := &replay.Snapshot{
+		HookName:   "install",
+		Captured:   captured,
+		Env:        []string{"JUJU_UNIT_NAME=u/0"},
+		UnitName:   "u/0",
+		Config:     charm.Settings{"title": "My Title"},
+		UnitStatus: jujuc.StatusInfo{Status: "maintenance"},
+		IsLeader:   true,
+		Relations: map[int]replay.RelationInfo{
+			1: {
+				Name:     "db",
+				Settings: params.Settings{"host": "u-0"},
+				Units:    map[string]params.Settings{"mysql/0": {"user": "fred"}},
+			},
+		},
+		RelationId: -1,
+	}
+	path := replay.SnapshotPath(filepath.Join(c.MkDir(), "replay"), "install", -1)
+	err := replay.WriteSnapshot(path, snapshot)
+	c.Assert(err, jc.ErrorIsNil)
+	read, err := replay.ReadSnapshot(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, snapshot)
+}
+
+func (s *SnapshotSuite) TestReadSnapshotNotFound(c *gc.C) {
+	_, err := replay.ReadSnapshot(replay.SnapshotPath(c.MkDir(), "install", -1))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SnapshotSuite) TestLatestSnapshot(c *gc.C) {
+	dir := c.MkDir()
+	s.writeSnapshots(c, dir)
+	path, err := replay.LatestSnapshot(dir, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, replay.SnapshotPath(dir, "config-changed", -1))
+}
+
+func (s *SnapshotSuite) TestLatestSnapshotOfHook(c *gc.C) {
+	dir := c.MkDir()
+	s.writeSnapshots(c, dir)
+	path, err := replay.LatestSnapshot(dir, "db-relation-changed")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, replay.SnapshotPath(dir, "db-relation-changed", 1))
+	_, err = replay.LatestSnapshot(dir, "stop")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SnapshotSuite) TestLatestSnapshotNotFound(c *gc.C) {
+	_, err := replay.LatestSnapshot(filepath.Join(c.MkDir(), "missing"), "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SnapshotSuite) TestSnapshotPathRelation(c *gc.C) {
+	c.Assert(replay.SnapshotPath("/dir", "install", -1), gc.Equals, filepath.Join("/dir", "install.yaml"))
+	c.Assert(replay.SnapshotPath("/dir", "db-relation-changed", 3), gc.Equals, filepath.Join("/dir", "db-relation-changed.3.yaml"))
+}
+
+func (s *SnapshotSuite) TestIsValidHookName(c *gc.C) {
+	for _, name := range []string{"install", "db-relation-changed", "data-storage-attached", "db_admin-relation-joined"} {
+		c.Check(replay.IsValidHookName(name), jc.IsTrue, gc.Commentf("%q", name))
+	}
+	for _, name := range []string{"", "../install", "install.yaml", "a/b", "install; rm -rf /", "Install"} {
+		c.Check(replay.IsValidHookName(name), jc.IsFalse, gc.Commentf("%q", name))
+	}
+}
+
+// writeSnapshots writes snapshots of several hooks, each more recent
+// than the last.
+func (s *SnapshotSuite) writeSnapshots(c *gc.C, dir string) {
+	for i, hook := range []struct {
+		name       string
+		relationId int
+	}{
+		{"start", -1},
+		{"db-relation-changed", 0},
+		{"install", -1},
+		{"db-relation-changed", 1},
+		{"config-changed", -1},
+	} {
+		path := replay.SnapshotPath(dir, hook.name, hook.relationId)
+		err := replay.WriteSnapshot(path, &replay.Snapshot{HookName: hook.name, RelationId: hook.relationId})
+		c.Assert(err, jc.ErrorIsNil)
+		mtime := captured.Add(time.Duration(i) * time.Minute)
+		err = os.Chtimes(path, mtime, mtime)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
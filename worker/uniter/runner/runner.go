@@ -8,15 +8,20 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	utilexec "github.com/juju/utils/exec"
 
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 	jujuos "github.com/juju/utils/os"
 )
 
@@ -36,8 +41,21 @@ type Runner interface {
 
 	// RunCommands executes the supplied script.
 	RunCommands(commands string) (*utilexec.ExecResponse, error)
+
+	// ExitCode returns the exit code of the most recent hook or action
+	// run. It is -1 if the hook did not exit normally, and zero if no
+	// hook was run.
+	ExitCode() int
+
+	// Stderr returns the final standard error output of the most
+	// recent hook or action run, up to MaxStderr bytes.
+	Stderr() string
 }
 
+// MaxStderr is the maximum length of the hook or action standard error
+// output retained by a Runner.
+const MaxStderr = 4096
+
 // Context exposes jujuc.Context, and additional methods needed by Runner.
 type Context interface {
 	jujuc.Context
@@ -54,22 +72,59 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return &runner{context: context, paths: paths, clock: clock.WallClock}
+}
+
+// NewRunnerWithTimeout returns a Runner backed by the supplied context and
+// paths, which kills any hook or action that runs for longer than the
+// supplied timeout, as measured by the supplied clock, together with every
+// process it started. A zero timeout behaves exactly like NewRunner.
+func NewRunnerWithTimeout(context Context, paths context.Paths, timeout time.Duration, clock clock.Clock) Runner {
+	return &runner{
+		context: context,
+		paths:   paths,
+		timeout: timeout,
+		clock:   clock,
+	}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+
+	// timeout, if positive, is the time after which a running hook
+	// will be killed; clock is used to measure it.
+	timeout time.Duration
+	clock   clock.Clock
+
+	// exitCode and stderr hold the exit code and final standard
+	// error output of the most recent hook or action run.
+	exitCode int
+	stderr   string
+
+	// snapshotDir, if set, is the directory in which the context of
+	// each hook that fails is saved, so that it can be replayed.
+	snapshotDir string
 }
 
 func (runner *runner) Context() Context {
 	return runner.context
 }
 
+// ExitCode exists to satisfy the Runner interface.
+func (runner *runner) ExitCode() int {
+	return runner.exitCode
+}
+
+// Stderr exists to satisfy the Runner interface.
+func (runner *runner) Stderr() string {
+	return runner.stderr
+}
+
 // RunCommands exists to satisfy the Runner interface.
 func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, error) {
-	srv, err := runner.startJujucServer()
+	srv, err := runner.startJujucServer(nil)
 	if err != nil {
 		return nil, err
 	}
@@ -110,12 +165,7 @@ func (runner *runner) RunHook(hookName string) error {
 }
 
 func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
-	srv, err := runner.startJujucServer()
-	if err != nil {
-		return err
-	}
-	defer srv.Close()
-
+	runner.exitCode, runner.stderr = 0, ""
 	env, err := runner.context.HookVars(runner.paths)
 	if err != nil {
 		return errors.Trace(err)
@@ -127,6 +177,21 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		env = mergeWindowsEnvironment(env, os.Environ())
 	}
 
+	var snapshotter *hookSnapshotter
+	if runner.snapshotDir != "" && charmLocation == "hooks" {
+		snapshotter = &hookSnapshotter{
+			context:  runner.context,
+			hookName: hookName,
+			env:      env,
+			clock:    runner.clock,
+		}
+	}
+	srv, err := runner.startJujucServer(snapshotter)
+	if err != nil {
+		return err
+	}
+	defer srv.Close()
+
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
 		logger.Infof("executing %s via debug-hooks", hookName)
@@ -134,6 +199,12 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 	} else {
 		err = runner.runCharmHook(hookName, env, charmLocation)
 	}
+	runner.exitCode = exitCode(err)
+	if snapshotter != nil && err != nil && !context.IsMissingHookError(errors.Cause(err)) {
+		if snapshot := snapshotter.snapshot(); snapshot != nil {
+			runner.saveSnapshot(snapshot)
+		}
+	}
 	return runner.context.Flush(hookName, err)
 }
 
@@ -151,32 +222,119 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
 	}
+	errReader, errWriter, err := os.Pipe()
+	if err != nil {
+		outReader.Close()
+		outWriter.Close()
+		return errors.Errorf("cannot make logging pipe: %v", err)
+	}
 	ps.Stdout = outWriter
-	ps.Stderr = outWriter
+	ps.Stderr = errWriter
+	if runner.timeout > 0 {
+		// Run the hook in its own process group, so that anything
+		// it starts is killed along with it if it times out.
+		setProcessGroup(ps)
+	}
 	hookLogger := &hookLogger{
 		r:      outReader,
 		done:   make(chan struct{}),
 		logger: runner.getLogger(hookName),
 	}
+	errLogger := &hookLogger{
+		r:      errReader,
+		done:   make(chan struct{}),
+		logger: runner.getLogger(hookName),
+		keep:   MaxStderr,
+	}
 	go hookLogger.run()
+	go errLogger.run()
 	err = ps.Start()
 	outWriter.Close()
+	errWriter.Close()
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
-		err = ps.Wait()
+		err = runner.waitHook(hookName, ps)
 	}
 	hookLogger.stop()
+	errLogger.stop()
+	runner.stderr = errLogger.output()
 	return errors.Trace(err)
 }
 
-func (runner *runner) startJujucServer() (*jujuc.Server, error) {
+// waitHook blocks until the supplied hook process exits. If the runner
+// has a timeout and the hook is still running when it expires, the hook's
+// process group is killed and a *HookTimeoutError is returned.
+func (runner *runner) waitHook(hookName string, ps *exec.Cmd) error {
+	if runner.timeout <= 0 {
+		return ps.Wait()
+	}
+	started := runner.clock.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-runner.clock.After(runner.timeout):
+	}
+	elapsed := runner.clock.Now().Sub(started)
+	logger.Errorf("killing %q hook after %v", hookName, elapsed)
+	if err := killProcessGroup(ps.Process); err != nil {
+		logger.Errorf("cannot kill %q hook: %v", hookName, err)
+	}
+	<-done
+	return &HookTimeoutError{
+		HookName: hookName,
+		Elapsed:  elapsed,
+	}
+}
+
+// saveSnapshot saves the captured context of a failed hook, so that it
+// can be replayed. Failure to do so does not affect the hook.
+func (runner *runner) saveSnapshot(snapshot *replay.Snapshot) {
+	if !replay.IsValidHookName(snapshot.HookName) {
+		logger.Errorf("not saving context of failed hook with invalid name %q", snapshot.HookName)
+		return
+	}
+	path := replay.SnapshotPath(runner.snapshotDir, snapshot.HookName, snapshot.RelationId)
+	if err := replay.WriteSnapshot(path, snapshot); err != nil {
+		logger.Errorf("cannot save context of failed %q hook: %v", snapshot.HookName, err)
+		return
+	}
+	logger.Infof("saved context of failed %q hook to %s", snapshot.HookName, path)
+}
+
+// exitCode returns the exit code of a hook which finished with the
+// supplied error.
+func exitCode(err error) int {
+	if err == nil || context.IsMissingHookError(errors.Cause(err)) {
+		return 0
+	}
+	exitErr, ok := errors.Cause(err).(*exec.ExitError)
+	if !ok {
+		return -1
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+		return status.ExitStatus()
+	}
+	return -1
+}
+
+// startJujucServer starts a server for the hook tools run in the runner's
+// context. If snapshotter is not nil, it captures the context before the
+// first hook tool is run.
+func (runner *runner) startJujucServer(snapshotter *hookSnapshotter) (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
 		if ctxId != runner.context.Id() {
 			return nil, errors.Errorf("expected context id %q, got %q", runner.context.Id(), ctxId)
 		}
+		if snapshotter != nil {
+			snapshotter.capture()
+		}
 		return jujuc.NewCommand(runner.context, cmdName)
 	}
 	srv, err := jujuc.NewServer(getCmd, runner.paths.GetJujucSocket())
@@ -198,3 +356,44 @@ type hookProcess struct {
 func (p hookProcess) Pid() int {
 	return p.Process.Pid
 }
+
+// hookSnapshotter captures the context of a hook, so that a failed hook
+// can be replayed. The context can only be changed by the hook tools, so
+// it is captured when the first of them runs, or when the hook fails if
+// none has run; hooks which succeed without running a hook tool cost
+// nothing.
+type hookSnapshotter struct {
+	context  Context
+	hookName string
+	env      []string
+	clock    clock.Clock
+
+	mu       sync.Mutex
+	captured bool
+	result   *replay.Snapshot
+}
+
+// capture captures the context, unless it has been captured already.
+func (s *hookSnapshotter) capture() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.captured {
+		return
+	}
+	s.captured = true
+	snapshot, err := replay.NewSnapshot(s.context, s.hookName, s.env, s.clock.Now())
+	if err != nil {
+		logger.Errorf("cannot capture context of %q hook: %v", s.hookName, err)
+		return
+	}
+	s.result = snapshot
+}
+
+// snapshot returns the captured context, capturing it first if no hook
+// tool has run. It returns nil if the context could not be captured.
+func (s *hookSnapshotter) snapshot() *replay.Snapshot {
+	s.capture()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.result
+}
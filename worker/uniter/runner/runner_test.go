@@ -6,8 +6,10 @@ package runner_test
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,9 +20,11 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -144,6 +148,50 @@ func (s *RunHookSuite) TestRunHook(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestRunHookCapturesFailedHook(c *gc.C) {
+	ctx, err := s.contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	paths := runnertesting.NewRealPaths(c)
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 1,
+	}, paths.GetCharmDir())
+	snapshotDir := c.MkDir()
+	now := time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)
+	rnr := runner.NewRunnerWithTimeout(ctx, paths, 0, coretesting.NewClock(now))
+	runner.SetRunnerSnapshotDir(rnr, snapshotDir)
+
+	err = rnr.RunHook(hookName)
+	c.Assert(err, gc.ErrorMatches, "exit status 1")
+	snapshot, err := replay.ReadSnapshot(replay.SnapshotPath(snapshotDir, hookName, -1))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.HookName, gc.Equals, hookName)
+	c.Check(snapshot.Captured.Equal(now), jc.IsTrue)
+	c.Check(snapshot.UnitName, gc.Equals, ctx.UnitName())
+	c.Check(snapshot.RelationId, gc.Equals, -1)
+}
+
+func (s *RunHookSuite) TestRunHookDoesNotCaptureSuccessfulHook(c *gc.C) {
+	ctx, err := s.contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	paths := runnertesting.NewRealPaths(c)
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, paths.GetCharmDir())
+	snapshotDir := c.MkDir()
+	rnr := runner.NewRunner(ctx, paths)
+	runner.SetRunnerSnapshotDir(rnr, snapshotDir)
+
+	err = rnr.RunHook(hookName)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = replay.LatestSnapshot(snapshotDir, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 type MockContext struct {
 	runner.Context
 	actionData   *context.ActionData
@@ -297,3 +345,116 @@ func (s *RunMockContextSuite) TestRunCommandsFlushFailure(c *gc.C) {
 	c.Assert(ctx.flushFailure, gc.IsNil) // exit code in _ result, as tested elsewhere
 	s.assertRecordedPid(c, ctx.expectPid)
 }
+
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook process groups are not supported on windows")
+	}
+	hooksDir := filepath.Join(s.paths.GetCharmDir(), "hooks")
+	err := os.Mkdir(hooksDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	childPidPath := filepath.Join(s.paths.GetCharmDir(), "child-pid")
+	script := fmt.Sprintf("#!/bin/bash\nsleep 3600 &\necho $! > %s\nsleep 3600\n", childPidPath)
+	err = ioutil.WriteFile(filepath.Join(hooksDir, hookName), []byte(script), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := &MockContext{}
+	clock := coretesting.NewClock(time.Time{})
+	rnr := runner.NewRunnerWithTimeout(ctx, s.paths, time.Minute, clock)
+	done := make(chan error, 1)
+	go func() {
+		done <- rnr.RunHook("something-happened")
+	}()
+
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("hook timeout never started")
+	}
+	var childPid int
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		content, err := ioutil.ReadFile(childPidPath)
+		if err == nil {
+			childPid, err = strconv.Atoi(strings.TrimSpace(string(content)))
+		}
+		if err == nil {
+			break
+		}
+	}
+	c.Assert(childPid, gc.Not(gc.Equals), 0)
+
+	clock.Advance(time.Minute)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("hook was not killed")
+	}
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 1m0s`)
+	c.Assert(runner.IsHookTimeoutError(ctx.flushFailure), jc.IsTrue)
+	c.Assert(rnr.ExitCode(), gc.Equals, -1)
+	for a := coretesting.LongAttempt.Start(); processExists(childPid); {
+		if !a.Next() {
+			c.Fatalf("hook's child process was not killed")
+		}
+	}
+}
+
+func (s *RunMockContextSuite) TestRunHookWithinTimeout(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 123,
+	}, s.paths.GetCharmDir())
+	clock := coretesting.NewClock(time.Time{})
+	rnr := runner.NewRunnerWithTimeout(ctx, s.paths, time.Minute, clock)
+	err := rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+	c.Assert(runner.IsHookTimeoutError(ctx.flushFailure), jc.IsFalse)
+	c.Assert(rnr.ExitCode(), gc.Equals, 123)
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+func (s *RunMockContextSuite) TestRunHookStderr(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Have to figure out a good way to output to stderr from powershell")
+	}
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: "not kept",
+		stderr: "kept",
+		code:   1,
+	}, s.paths.GetCharmDir())
+	rnr := runner.NewRunner(ctx, s.paths)
+	err := rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 1")
+	c.Assert(rnr.ExitCode(), gc.Equals, 1)
+	c.Assert(rnr.Stderr(), gc.Equals, "kept\n")
+}
+
+func (s *RunMockContextSuite) TestRunHookStderrTruncated(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Have to figure out a good way to output to stderr from powershell")
+	}
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stderr: strings.Repeat("x", runner.MaxStderr) + "end",
+	}, s.paths.GetCharmDir())
+	rnr := runner.NewRunner(ctx, s.paths)
+	err := rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	stderr := rnr.Stderr()
+	c.Assert(stderr, gc.HasLen, runner.MaxStderr)
+	c.Assert(strings.HasSuffix(stderr, "x\nend\n"), jc.IsTrue)
+}
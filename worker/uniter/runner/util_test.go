@@ -40,6 +40,7 @@ type ContextSuite struct {
 	factory        runner.Factory
 	contextFactory context.ContextFactory
 	membership     map[int][]string
+	clock          *coretesting.Clock
 
 	st      api.Connection
 	service *state.Service
@@ -98,6 +99,7 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 	s.AddContextRelation(c, "db0")
 	s.AddContextRelation(c, "db1")
 
+	s.clock = coretesting.NewClock(time.Time{})
 	s.contextFactory, err = context.NewContextFactory(
 		s.uniter,
 		s.unit.Tag().(names.UnitTag),
@@ -105,7 +107,7 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 		s.getRelationInfos,
 		s.storage,
 		s.paths,
-		coretesting.NewClock(time.Time{}),
+		s.clock,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -113,6 +115,7 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 		s.uniter,
 		s.paths,
 		s.contextFactory,
+		s.clock,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.factory = factory
@@ -219,6 +219,147 @@ func (s *attachmentsSuite) TestAttachmentsStorage(c *gc.C) {
 	c.Assert(ctx.Location(), gc.Equals, "/dev/sdb")
 }
 
+func (s *attachmentsSuite) TestAttachmentsStorageGrown(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	attachment := params.StorageAttachment{
+		StorageTag: storageTag.String(),
+		UnitTag:    unitTag.String(),
+		Life:       params.Alive,
+		Kind:       params.StorageKindFilesystem,
+		Location:   "/srv/data",
+		Size:       1024,
+	}
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (params.StorageAttachment, error) {
+			return attachment, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	err = att.UpdateStorage([]names.StorageTag{storageTag})
+	c.Assert(err, jc.ErrorIsNil)
+	err = att.CommitHook(hook.Info{
+		Kind:        hooks.StorageAttached,
+		StorageId:   storageTag.Id(),
+		StorageSize: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageResolver := storage.NewResolver(att)
+	storage.SetStorageLife(storageResolver, map[names.StorageTag]params.Life{
+		storageTag: params.Alive,
+	})
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+		},
+	}
+	snapshot := remotestate.StorageSnapshot{
+		Kind:     params.StorageKindFilesystem,
+		Life:     params.Alive,
+		Location: "/srv/data",
+		Attached: true,
+		Size:     1024,
+	}
+	remoteState := remotestate.Snapshot{
+		Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+			storageTag: snapshot,
+		},
+	}
+	_, err = storageResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+
+	// Once the storage has been grown, the storage-attached
+	// hook is run again.
+	snapshot.Size = 2048
+	remoteState.Storage[storageTag] = snapshot
+	op, err := storageResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+}
+
+func (s *attachmentsSuite) TestAttachmentsStorageGrownSizeNotRecorded(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	attachment := params.StorageAttachment{
+		StorageTag: storageTag.String(),
+		UnitTag:    unitTag.String(),
+		Life:       params.Alive,
+		Kind:       params.StorageKindFilesystem,
+		Location:   "/srv/data",
+	}
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (params.StorageAttachment, error) {
+			return attachment, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	err = att.UpdateStorage([]names.StorageTag{storageTag})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage-attached hook was committed without a size.
+	err = att.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageResolver := storage.NewResolver(att)
+	storage.SetStorageLife(storageResolver, map[names.StorageTag]params.Life{
+		storageTag: params.Alive,
+	})
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+		},
+	}
+	snapshot := remotestate.StorageSnapshot{
+		Kind:     params.StorageKindFilesystem,
+		Life:     params.Alive,
+		Location: "/srv/data",
+		Attached: true,
+		Size:     1024,
+	}
+	remoteState := remotestate.Snapshot{
+		Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+			storageTag: snapshot,
+		},
+	}
+
+	// The first size observed is recorded, without running a hook.
+	_, err = storageResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+	state, err := storage.ReadStateFile(stateDir, storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
+
+	// Once the storage has been grown, the storage-attached
+	// hook is run again.
+	snapshot.Size = 2048
+	remoteState.Storage[storageTag] = snapshot
+	op, err := storageResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+}
+
 func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	stateDir := c.MkDir()
 	unitTag := names.NewUnitTag("mysql/0")
@@ -20,8 +20,17 @@ func StateAttached(s State) bool {
 	return s.(*stateFile).attached
 }
 
+func StateSize(s State) uint64 {
+	return s.(*stateFile).size
+}
+
 func ValidateHook(tag names.StorageTag, attached bool, hi hook.Info) error {
-	st := &state{tag, attached}
+	st := &state{storage: tag, attached: attached}
+	return st.ValidateHook(hi)
+}
+
+func ValidateHookWithSize(tag names.StorageTag, attached bool, size uint64, hi hook.Info) error {
+	st := &state{storage: tag, attached: attached, size: size}
 	return st.ValidateHook(hi)
 }
 
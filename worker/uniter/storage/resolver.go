@@ -130,11 +130,24 @@ func (s *storageResolver) nextHookOp(
 	switch snap.Life {
 	case params.Alive:
 		if storageAttachment.attached {
-			// Storage attachments currently do not change
-			// (apart from lifecycle) after being provisioned.
+			// Storage attachments do not change (apart from
+			// lifecycle and size) after being provisioned.
 			// We don't process unprovisioned storage here,
-			// so there's nothing to do.
-			return nil, resolver.ErrNoOperation
+			// so there's nothing to do unless the storage
+			// has been grown.
+			if storageAttachment.size == 0 && snap.Size > 0 {
+				// The size was not recorded when the storage
+				// was attached, e.g. by an older agent. Record
+				// the size first observed, so that the storage
+				// growing after this is noticed.
+				if err := storageAttachment.RecordSize(snap.Size); err != nil {
+					return nil, errors.Trace(err)
+				}
+				return nil, resolver.ErrNoOperation
+			}
+			if snap.Size <= storageAttachment.size {
+				return nil, resolver.ErrNoOperation
+			}
 		}
 	case params.Dying:
 		if !storageAttachment.attached {
@@ -154,6 +167,7 @@ func (s *storageResolver) nextHookOp(
 	}
 	if snap.Life == params.Alive {
 		hookInfo.Kind = hooks.StorageAttached
+		hookInfo.StorageSize = snap.Size
 	} else {
 		hookInfo.Kind = hooks.StorageDetaching
 	}
@@ -25,6 +25,11 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// size records the size of the storage, in MiB, as of the
+	// most recently committed storage-attached hook, or zero
+	// if the size is not known.
+	size uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -38,7 +43,9 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 	}
 	switch hi.Kind {
 	case hooks.StorageAttached:
-		if s.attached {
+		// A storage-attached hook is run again for attached
+		// storage when it has been grown.
+		if s.attached && hi.StorageSize <= s.size {
 			return errors.New("storage already attached")
 		}
 	case hooks.StorageDetaching:
@@ -85,6 +92,7 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	d.state.size = info.Size
 	return d, nil
 }
 
@@ -130,12 +138,34 @@ func (d *stateFile) CommitHook(hi hook.Info) (err error) {
 		return d.Remove()
 	}
 	attached := true
-	di := diskInfo{&attached}
+	size := d.state.size
+	if hi.StorageSize > size {
+		size = hi.StorageSize
+	}
+	di := diskInfo{Attached: &attached, Size: size}
 	if err := utils.WriteYaml(d.path, &di); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
 	d.state.attached = true
+	d.state.size = size
+	return nil
+}
+
+// RecordSize atomically writes to disk the size of attached storage whose
+// size was not recorded when its storage-attached hook was committed.
+func (d *stateFile) RecordSize(size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "failed to write size of %q on state directory", d.storage.Id())
+	if !d.state.attached {
+		return errors.New("storage not attached")
+	}
+	attached := true
+	di := diskInfo{Attached: &attached, Size: size}
+	if err := utils.WriteYaml(d.path, &di); err != nil {
+		return err
+	}
+	// If write was successful, update own state.
+	d.state.size = size
 	return nil
 }
 
@@ -146,10 +176,12 @@ func (d *stateFile) Remove() error {
 	}
 	// If atomic delete succeeded, update own state.
 	d.state.attached = false
+	d.state.size = 0
 	return nil
 }
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool  `yaml:"attached,omitempty"`
+	Size     uint64 `yaml:"size,omitempty"`
 }
@@ -170,6 +170,35 @@ func (s *stateSuite) TestCommitHook(c *gc.C) {
 	}
 }
 
+func (s *stateSuite) TestCommitHookSize(c *gc.C) {
+	dir := c.MkDir()
+	tag := names.NewStorageTag("data/0")
+	state, err := storage.ReadStateFile(dir, tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.CommitHook(hook.Info{
+		Kind:        hooks.StorageAttached,
+		StorageId:   "data/0",
+		StorageSize: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
+
+	// A storage-attached hook without a size does
+	// not clear the previously recorded size.
+	err = state.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: "data/0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
+
+	state, err = storage.ReadStateFile(dir, tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateAttached(state), jc.IsTrue)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(1024))
+}
+
 func (s *stateSuite) TestValidateHook(c *gc.C) {
 	const unattached = false
 	const attached = true
@@ -200,3 +229,17 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
 	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
 }
+
+func (s *stateSuite) TestValidateHookGrown(c *gc.C) {
+	tag := names.NewStorageTag("data/0")
+	validate := func(size uint64) error {
+		return storage.ValidateHookWithSize(tag, true, 1024, hook.Info{
+			Kind:        hooks.StorageAttached,
+			StorageId:   "data/0",
+			StorageSize: size,
+		})
+	}
+	c.Assert(validate(2048), jc.ErrorIsNil)
+	c.Assert(validate(1024), gc.ErrorMatches, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+	c.Assert(validate(0), gc.ErrorMatches, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+}
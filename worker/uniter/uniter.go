@@ -27,7 +27,6 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/charm"
-	"github.com/juju/juju/worker/uniter/hook"
 	uniterleadership "github.com/juju/juju/worker/uniter/leadership"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/relation"
@@ -81,6 +80,7 @@ type Uniter struct {
 	operationFactory     operation.Factory
 	operationExecutor    operation.Executor
 	newOperationExecutor NewExecutorFunc
+	hookRecorder         *hookRecorder
 
 	leadershipTracker leadership.Tracker
 	charmDirGuard     fortress.Guard
@@ -451,7 +451,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.clock,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -469,7 +469,14 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	u.operationExecutor = operationExecutor
+	u.hookRecorder, err = newHookRecorder(u.unit, u.clock)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := u.catacomb.Add(u.hookRecorder); err != nil {
+		return errors.Trace(err)
+	}
+	u.operationExecutor = operation.NewRecordingExecutor(operationExecutor, u.recordOperation, u.clock)
 
 	logger.Debugf("starting juju-run listener on unix:%s", u.paths.Runtime.JujuRunSocket)
 	commandRunner, err := NewChannelCommandRunner(ChannelCommandRunnerConfig{
@@ -552,10 +559,33 @@ func (u *Uniter) acquireExecutionLock(message string) (func() error, error) {
 	}, nil
 }
 
-func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+// recordOperation queues the supplied record to be added to the unit's
+// hook history.
+func (u *Uniter) recordOperation(record operation.Record) {
+	hookRecord := params.HookRecord{
+		Operation:  record.Operation,
+		RelationId: -1,
+		Started:    record.Started,
+		Duration:   record.Duration,
+		ExitCode:   record.ExitCode,
+		Stderr:     record.Stderr,
+		Error:      record.Error,
+	}
+	if record.Hook != nil {
+		hookRecord.Hook = string(record.Hook.Kind)
+		if record.Hook.Kind.IsRelation() {
+			hookRecord.RelationId = record.Hook.RelationId
+			hookRecord.RemoteUnit = record.Hook.RemoteUnit
+		}
+	}
+	u.hookRecorder.Add(hookRecord)
+}
+
+func (u *Uniter) reportHookError(opState operation.State) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
+	hookInfo := *opState.Hook
 	hookName := string(hookInfo.Kind)
 	statusData := map[string]interface{}{}
 	if hookInfo.Kind.IsRelation() {
@@ -571,5 +601,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if opState.HookTimedOut > 0 {
+		statusData["elapsed"] = opState.HookTimedOut.String()
+		statusMessage = fmt.Sprintf("hook timed out: %q after %v", hookName, opState.HookTimedOut)
+	}
 	return setAgentStatus(u, params.StatusError, statusMessage, statusData)
 }
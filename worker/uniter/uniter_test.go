@@ -235,6 +235,26 @@ func (s *UniterSuite) TestUniterUpdateStatusHook(c *gc.C) {
 	})
 }
 
+func (s *UniterSuite) TestUniterHookHistory(c *gc.C) {
+	s.PatchValue(uniter.HookRecordFlushInterval, coretesting.ShortWait)
+	s.runUniterTests(c, []uniterTest{
+		ut(
+			"hooks run are recorded, most recent first",
+			quickStart{},
+			verifyHookHistory{
+				hooks: []string{"start", "config-changed", "leader-elected", "install"},
+			},
+		), ut(
+			"failed hooks are recorded with their exit code",
+			startupError{"config-changed"},
+			verifyHookHistory{
+				hooks:    []string{"config-changed", "leader-elected", "install"},
+				exitCode: 1,
+			},
+		),
+	})
+}
+
 func (s *UniterSuite) TestNoUniterUpdateStatusHookInError(c *gc.C) {
 	s.runUniterTests(c, []uniterTest{
 		ut(
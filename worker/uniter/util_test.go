@@ -977,6 +977,40 @@ func (s verifyCharm) step(c *gc.C, ctx *context) {
 	c.Assert(url, gc.DeepEquals, curl(checkRevision))
 }
 
+type verifyHookHistory struct {
+	hooks    []string
+	exitCode int
+}
+
+func (s verifyHookHistory) step(c *gc.C, ctx *context) {
+	// Operations are recorded after they finish, so we may need
+	// to wait for the most recent one to appear.
+	var hooks []string
+	var records []state.HookRecord
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		var err error
+		records, err = ctx.unit.HookHistory(state.MaxHookHistory)
+		c.Assert(err, jc.ErrorIsNil)
+		hooks = nil
+		for _, record := range records {
+			if record.Hook != "" {
+				hooks = append(hooks, record.Hook)
+			}
+		}
+		if reflect.DeepEqual(hooks, s.hooks) {
+			break
+		}
+	}
+	c.Assert(hooks, jc.DeepEquals, s.hooks)
+	for _, record := range records {
+		if record.Hook != "" {
+			c.Assert(record.Operation, gc.Equals, fmt.Sprintf("run %s hook", record.Hook))
+			c.Assert(record.ExitCode, gc.Equals, s.exitCode)
+			break
+		}
+	}
+}
+
 type pushResource struct{}
 
 func (s pushResource) step(c *gc.C, ctx *context) {